@@ -0,0 +1,153 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"go/types"
+	"io"
+	"log"
+	"os"
+	"os/signal"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+
+	"github.com/stellar/go-stellar-sdk/strkey"
+	"github.com/stellar/go-stellar-sdk/support/config"
+	support "github.com/stellar/go-stellar-sdk/support/config"
+	"github.com/stellar/go-stellar-sdk/support/db"
+	horizon "github.com/stellar/stellar-horizon/internal"
+	"github.com/stellar/stellar-horizon/internal/actions"
+	"github.com/stellar/stellar-horizon/internal/db2/history"
+	"github.com/stellar/stellar-horizon/internal/render/csv"
+	"github.com/stellar/stellar-horizon/internal/resource"
+)
+
+var exportStatementAccount string
+var exportStatementFrom, exportStatementTo uint32
+var exportStatementFormat string
+var processAccountStatementFn = processAccountStatement
+
+var exportAccountStatementCmdOpts = support.ConfigOptions{
+	{
+		Name:        "account",
+		ConfigKey:   &exportStatementAccount,
+		OptType:     types.String,
+		Required:    true,
+		FlagDefault: "",
+		Usage:       "account whose statement is exported",
+	},
+	{
+		Name:        "from-ledger",
+		ConfigKey:   &exportStatementFrom,
+		OptType:     types.Uint32,
+		Required:    true,
+		FlagDefault: uint32(0),
+		Usage:       "first ledger of the statement",
+	},
+	{
+		Name:        "to-ledger",
+		ConfigKey:   &exportStatementTo,
+		OptType:     types.Uint32,
+		Required:    true,
+		FlagDefault: uint32(0),
+		Usage:       "last ledger of the statement",
+	},
+	{
+		Name:        "format",
+		ConfigKey:   &exportStatementFormat,
+		OptType:     types.String,
+		Required:    false,
+		FlagDefault: "csv",
+		Usage:       "format of the statement, csv or json",
+	},
+}
+
+func DefineExportCommands(rootCmd *cobra.Command, horizonConfig *horizon.Config, horizonFlags config.ConfigOptions) {
+	var exportCmd = &cobra.Command{
+		Use:   "export [command]",
+		Short: "commands to export data from horizon's postgres db",
+	}
+
+	var exportAccountStatementCmd = &cobra.Command{
+		Use:   "account-statement",
+		Short: "exports the statement of an account",
+		Long: "exports the debits and credits of the balances of an account between two ledgers (inclusive) " +
+			"to the standard output: fees, payments, trades, claimable balance claims and any other balance change, " +
+			"with the balance after each of them. The ledgers must be within the ingested history.",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := exportAccountStatementCmdOpts.RequireE(); err != nil {
+				return err
+			}
+			if err := exportAccountStatementCmdOpts.SetValues(); err != nil {
+				return err
+			}
+
+			if !strkey.IsValidEd25519PublicKey(exportStatementAccount) {
+				return fmt.Errorf("invalid account: %s", exportStatementAccount)
+			}
+			if exportStatementFrom == 0 || exportStatementFrom > exportStatementTo {
+				return fmt.Errorf("invalid range: [%d, %d]", exportStatementFrom, exportStatementTo)
+			}
+			if exportStatementFormat != "csv" && exportStatementFormat != "json" {
+				return fmt.Errorf("invalid format: %s", exportStatementFormat)
+			}
+
+			if err := requireAndSetFlags(horizonFlags, horizon.DatabaseURLFlagName); err != nil {
+				return err
+			}
+			return processAccountStatementFn(horizonConfig, cmd.OutOrStdout())
+		},
+	}
+
+	for _, co := range exportAccountStatementCmdOpts {
+		if err := co.Init(exportAccountStatementCmd); err != nil {
+			log.Fatal(err.Error())
+		}
+	}
+
+	viper.BindPFlags(exportAccountStatementCmd.PersistentFlags())
+
+	rootCmd.AddCommand(exportCmd)
+	exportCmd.AddCommand(exportAccountStatementCmd)
+}
+
+func processAccountStatement(horizonConfig *horizon.Config, out io.Writer) error {
+	session, err := db.Open("postgres", horizonConfig.DatabaseURL)
+	if err != nil {
+		return fmt.Errorf("cannot open Horizon DB: %v", err)
+	}
+	defer session.Close()
+
+	ctx, cancel := signal.NotifyContext(context.Background(), os.Interrupt, os.Kill)
+	defer cancel()
+
+	statement, err := actions.AccountStatement(
+		ctx,
+		&history.Q{SessionInterface: session},
+		exportStatementAccount,
+		nil,
+		exportStatementFrom,
+		exportStatementTo,
+		0,
+	)
+	if err != nil {
+		return err
+	}
+
+	if exportStatementFormat == "json" {
+		encoder := json.NewEncoder(out)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(statement)
+	}
+	records := make([][]string, 0, len(statement.Entries))
+	for _, entry := range statement.Entries {
+		records = append(records, entry.CSVRecord())
+	}
+	return csv.Write(out, resource.AccountStatementCSVHeader, records)
+}
+
+func init() {
+	DefineExportCommands(RootCmd, globalConfig, globalFlags)
+}
@@ -0,0 +1,71 @@
+package cmd
+
+import (
+	"io"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	horizon "github.com/stellar/stellar-horizon/internal"
+)
+
+func TestExportAccountStatementCmd(t *testing.T) {
+	// stub out the export command execution body,
+	// just test the cmd argument parsing and validation
+	processAccountStatementFn = func(*horizon.Config, io.Writer) error {
+		return nil
+	}
+	defer func() {
+		processAccountStatementFn = processAccountStatement
+	}()
+
+	account := "GAOQJGUAB7NI7K7I62ORBXMN3J4SSWQUQ7FOEPSDJ322W2HMCNWPHXFB"
+	tests := []struct {
+		name         string
+		args         []string
+		errorMessage string
+	}{
+		{
+			name: "csv statement",
+			args: []string{"--account", account, "--from-ledger", "1", "--to-ledger", "10"},
+		},
+		{
+			name: "json statement",
+			args: []string{"--account", account, "--from-ledger", "10", "--to-ledger", "10", "--format", "json"},
+		},
+		{
+			name:         "invalid account",
+			args:         []string{"--account", "GABC", "--from-ledger", "1", "--to-ledger", "10"},
+			errorMessage: "invalid account: GABC",
+		},
+		{
+			name:         "invalid range",
+			args:         []string{"--account", account, "--from-ledger", "10", "--to-ledger", "1"},
+			errorMessage: "invalid range: [10, 1]",
+		},
+		{
+			name:         "invalid format",
+			args:         []string{"--account", account, "--from-ledger", "1", "--to-ledger", "10", "--format", "xml"},
+			errorMessage: "invalid format: xml",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rootCmd, horizonConfig, horizonFlags := newRootBaseCmd()
+			DefineExportCommands(rootCmd, horizonConfig, horizonFlags)
+			args := append([]string{
+				"export", "account-statement",
+				"--db-url", "postgres://localhost/horizon",
+			}, tt.args...)
+			rootCmd.SetArgs(args)
+
+			err := rootCmd.Execute()
+			if tt.errorMessage == "" {
+				require.NoError(t, err)
+			} else {
+				require.EqualError(t, err, tt.errorMessage)
+			}
+		})
+	}
+}
@@ -82,6 +82,50 @@ var ingestVerifyRangeCmdOpts = support.ConfigOptions{
 	generateDatastoreConfigOpt(&ingestVerifyStorageBackendConfigPath),
 }
 
+var ingestApplyFiltersFrom, ingestApplyFiltersTo uint32
+var ingestApplyFiltersDryRun bool
+var ingestApplyFiltersParallelWorkers uint
+var ingestApplyFiltersStorageBackendConfigPath string
+var ingestApplyFiltersLedgerBackendType ingest.LedgerBackendType
+var processApplyFiltersFn = processApplyFilters
+
+var ingestApplyFiltersCmdOpts = support.ConfigOptions{
+	{
+		Name:        "from",
+		ConfigKey:   &ingestApplyFiltersFrom,
+		OptType:     types.Uint32,
+		Required:    true,
+		FlagDefault: uint32(0),
+		Usage:       "first ledger of the range to apply the filters to",
+	},
+	{
+		Name:        "to",
+		ConfigKey:   &ingestApplyFiltersTo,
+		OptType:     types.Uint32,
+		Required:    true,
+		FlagDefault: uint32(0),
+		Usage:       "last ledger of the range to apply the filters to",
+	},
+	{
+		Name:        "dry-run",
+		ConfigKey:   &ingestApplyFiltersDryRun,
+		OptType:     types.Bool,
+		Required:    false,
+		FlagDefault: false,
+		Usage:       "[optional] only print a summary of the changes without modifying the history",
+	},
+	{
+		Name:        "parallel-workers",
+		ConfigKey:   &ingestApplyFiltersParallelWorkers,
+		OptType:     types.Uint,
+		Required:    false,
+		FlagDefault: uint(1),
+		Usage:       "[optional] number of workers evaluating and reingesting the range in parallel",
+	},
+	generateLedgerBackendOpt(&ingestApplyFiltersLedgerBackendType),
+	generateDatastoreConfigOpt(&ingestApplyFiltersStorageBackendConfigPath),
+}
+
 var ingestionLoadTestLedgersPath string
 var ingestionLoadTestFixturesPath string
 var ingestionLoadTestCloseDuration time.Duration
@@ -440,6 +484,45 @@ func DefineIngestCommands(rootCmd *cobra.Command, horizonConfig *horizon.Config,
 		},
 	}
 
+	var ingestApplyFiltersCmd = &cobra.Command{
+		Use:   "apply-filters",
+		Short: "applies the current ingestion filters to the already ingested history within a range",
+		Long: "re-evaluates the ledgers between X and Y sequence number (inclusive) with the current ingestion filters " +
+			"and reingests the parts of the range whose history doesn't match them, removing the transactions which " +
+			"don't pass the filters anymore and ingesting the newly included ones. The ledgers which are not ingested yet " +
+			"are skipped and the ranges are reingested holding the ingestion lock, so they can include the latest ledgers " +
+			"while the ingestion is running. Use --dry-run to only print a summary.",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := ingestApplyFiltersCmdOpts.RequireE(); err != nil {
+				return err
+			}
+			if err := ingestApplyFiltersCmdOpts.SetValues(); err != nil {
+				return err
+			}
+
+			if ingestApplyFiltersFrom == 0 || ingestApplyFiltersFrom > ingestApplyFiltersTo {
+				return fmt.Errorf("invalid range: [%d, %d]", ingestApplyFiltersFrom, ingestApplyFiltersTo)
+			}
+
+			maxBatchSize := ingest.MaxCaptiveCoreBackendBatchSize
+			storageBackendConfig := ingest.StorageBackendConfig{}
+			options := horizon.ApplyOptions{RequireCaptiveCoreFullConfig: false}
+			if ingestApplyFiltersLedgerBackendType == ingest.BufferedStorageBackend {
+				var err error
+				if storageBackendConfig, err = loadStorageBackendConfig(ingestApplyFiltersStorageBackendConfigPath); err != nil {
+					return err
+				}
+				options.NoCaptiveCore = true
+				maxBatchSize = ingest.MaxBufferedStorageBackendBatchSize
+			}
+
+			if err := horizon.ApplyFlags(horizonConfig, horizonFlags, options); err != nil {
+				return err
+			}
+			return processApplyFiltersFn(horizonConfig, storageBackendConfig, maxBatchSize)
+		},
+	}
+
 	for _, co := range ingestVerifyRangeCmdOpts {
 		err := co.Init(ingestVerifyRangeCmd)
 		if err != nil {
@@ -447,6 +530,13 @@ func DefineIngestCommands(rootCmd *cobra.Command, horizonConfig *horizon.Config,
 		}
 	}
 
+	for _, co := range ingestApplyFiltersCmdOpts {
+		err := co.Init(ingestApplyFiltersCmd)
+		if err != nil {
+			log.Fatal(err.Error())
+		}
+	}
+
 	for _, co := range stressTestCmdOpts {
 		err := co.Init(ingestStressTestCmd)
 		if err != nil {
@@ -472,6 +562,7 @@ func DefineIngestCommands(rootCmd *cobra.Command, horizonConfig *horizon.Config,
 	viper.BindPFlags(ingestBuildStateCmd.PersistentFlags())
 	viper.BindPFlags(ingestLoadTestCmd.PersistentFlags())
 	viper.BindPFlags(ingestStressTestCmd.PersistentFlags())
+	viper.BindPFlags(ingestApplyFiltersCmd.PersistentFlags())
 
 	rootCmd.AddCommand(ingestCmd)
 	ingestCmd.AddCommand(
@@ -481,6 +572,7 @@ func DefineIngestCommands(rootCmd *cobra.Command, horizonConfig *horizon.Config,
 		ingestBuildStateCmd,
 		ingestLoadTestCmd,
 		ingestLoadTestRestoreCmd,
+		ingestApplyFiltersCmd,
 	)
 }
 
@@ -562,6 +654,64 @@ func processVerifyRange(horizonConfig *horizon.Config, horizonFlags config.Confi
 	})
 }
 
+func processApplyFilters(horizonConfig *horizon.Config, backendConfig ingest.StorageBackendConfig, maxBatchSize uint) error {
+	horizonSession, err := db.Open("postgres", horizonConfig.DatabaseURL)
+	if err != nil {
+		return fmt.Errorf("cannot open Horizon DB: %v", err)
+	}
+
+	ingestConfig := ingest.Config{
+		NetworkPassphrase:      horizonConfig.NetworkPassphrase,
+		HistorySession:         horizonSession,
+		HistoryArchiveURLs:     horizonConfig.HistoryArchiveURLs,
+		HistoryArchiveCaching:  horizonConfig.HistoryArchiveCaching,
+		CheckpointFrequency:    horizonConfig.CheckpointFrequency,
+		CaptiveCoreBinaryPath:  horizonConfig.CaptiveCoreBinaryPath,
+		CaptiveCoreToml:        horizonConfig.CaptiveCoreToml,
+		CaptiveCoreStoragePath: horizonConfig.CaptiveCoreStoragePath,
+		StellarCoreURL:         horizonConfig.StellarCoreURL,
+		RoundingSlippageFilter: horizonConfig.RoundingSlippageFilter,
+		MaxLedgerPerFlush:      ingest.MaxLedgersPerFlush,
+		SkipTxmeta:             horizonConfig.SkipTxmeta,
+		LedgerBackendType:      ingestApplyFiltersLedgerBackendType,
+		StorageBackendConfig:   backendConfig,
+	}
+
+	system, err := ingest.NewParallelSystems(ingestConfig, ingestApplyFiltersParallelWorkers, ingest.MinBatchSize, maxBatchSize)
+	if err != nil {
+		return err
+	}
+
+	summary, err := system.ApplyFilters(
+		[]history.LedgerRange{{StartSequence: ingestApplyFiltersFrom, EndSequence: ingestApplyFiltersTo}},
+		ingestApplyFiltersDryRun,
+	)
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("Ledgers evaluated: %d\n", summary.Ledgers)
+	fmt.Printf("Transactions stored: %d\n", summary.TransactionsStored)
+	fmt.Printf("Transactions not passing the filters: %d\n", summary.TransactionsToRemove)
+	fmt.Printf("Transactions passing the filters but not stored: %d\n", summary.TransactionsToAdd)
+	if summary.LedgersNotIngested > 0 {
+		fmt.Printf("Ledgers skipped because they are not ingested yet: %d\n", summary.LedgersNotIngested)
+	}
+	if len(summary.ChangedRanges) == 0 {
+		fmt.Println("History matches the current filters, nothing to reingest")
+		return nil
+	}
+	if ingestApplyFiltersDryRun {
+		fmt.Println("Ranges to reingest:")
+	} else {
+		fmt.Println("Reingested ranges:")
+	}
+	for _, r := range summary.ChangedRanges {
+		fmt.Printf("[%d, %d]\n", r.StartSequence, r.EndSequence)
+	}
+	return nil
+}
+
 // generateDatastoreConfigOpt returns a *support.ConfigOption for the datastore-config flag
 func generateDatastoreConfigOpt(configKey *string) *support.ConfigOption {
 	return &support.ConfigOption{
@@ -28,6 +28,9 @@ func (s *IngestCommandsTestSuite) SetupSuite() {
 	processVerifyRangeFn = func(*horizon.Config, config.ConfigOptions, ingest.StorageBackendConfig) error {
 		return nil
 	}
+	processApplyFiltersFn = func(*horizon.Config, ingest.StorageBackendConfig, uint) error {
+		return nil
+	}
 	s.db = dbtest.Postgres(s.T())
 	RootCmd.SetArgs([]string{
 		"db", "migrate", "up", "--db-url", s.db.DSN})
@@ -113,3 +116,60 @@ func (s *IngestCommandsTestSuite) TestIngestVerifyRangeCmd() {
 		})
 	}
 }
+
+func (s *IngestCommandsTestSuite) TestIngestApplyFiltersCmd() {
+	tests := []struct {
+		name         string
+		args         []string
+		expectError  bool
+		errorMessage string
+	}{
+		{
+			name: "invalid range",
+			args: []string{
+				"--from", "10", "--to", "1",
+				"--network", "testnet",
+			},
+			expectError:  true,
+			errorMessage: "invalid range: [10, 1]",
+		},
+		{
+			name: "datastore backend without config",
+			args: []string{
+				"--from", "1", "--to", "10",
+				"--network", "testnet",
+				"--ledgerbackend", "datastore",
+			},
+			expectError:  true,
+			errorMessage: "datastore config file is required for datastore ledgerbackend type",
+		},
+		{
+			name: "dry run",
+			args: []string{
+				"--from", "1", "--to", "10",
+				"--network", "testnet",
+				"--dry-run",
+			},
+			expectError: false,
+		},
+	}
+
+	for _, tt := range tests {
+		s.T().Run(tt.name, func(t *testing.T) {
+			rootCmd := newIngestCmd()
+			args := append([]string{"ingest", "apply-filters"}, tt.args...)
+			rootCmd.SetArgs(append([]string{
+				"--db-url", s.db.DSN,
+				"--stellar-core-binary-path", "/test/core/bin/path",
+			}, args...))
+
+			if tt.expectError {
+				err := rootCmd.Execute()
+				require.Error(t, err)
+				require.Contains(t, err.Error(), tt.errorMessage)
+			} else {
+				require.NoError(t, rootCmd.Execute())
+			}
+		})
+	}
+}
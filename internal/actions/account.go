@@ -305,6 +305,7 @@ func getLedgerBySequence(ctx context.Context, hq *history.Q, sequence int32) (*h
 // AccountByIDQuery query struct for accounts/{account_id} end-point
 type AccountByIDQuery struct {
 	AccountID string `schema:"account_id" valid:"accountID,optional"`
+	Ledger    uint32 `schema:"ledger" valid:"-"`
 }
 
 // GetAccountByIDHandler is the action handler for the /accounts/{account_id} endpoint
@@ -335,7 +336,12 @@ func (handler GetAccountByIDHandler) GetResource(
 	if err != nil {
 		return nil, err
 	}
-	account, err := AccountInfo(r.Context(), historyQ, qp.AccountID)
+	var account *protocol.Account
+	if qp.Ledger > 0 {
+		account, err = AccountInfoAt(r.Context(), historyQ, qp.AccountID, qp.Ledger)
+	} else {
+		account, err = AccountInfo(r.Context(), historyQ, qp.AccountID)
+	}
 	if err != nil {
 		return Account{}, err
 	}
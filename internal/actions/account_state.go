@@ -0,0 +1,186 @@
+package actions
+
+import (
+	"context"
+	"sort"
+
+	protocol "github.com/stellar/go-stellar-sdk/protocols/horizon"
+	"github.com/stellar/go-stellar-sdk/support/errors"
+	"github.com/stellar/go-stellar-sdk/support/render/problem"
+	"github.com/stellar/go-stellar-sdk/xdr"
+	"github.com/stellar/stellar-horizon/internal/db2/history"
+	"github.com/stellar/stellar-horizon/internal/ingest/processors"
+	"github.com/stellar/stellar-horizon/internal/resourceadapter"
+)
+
+// accountStateLedgerRange returns the range of ledgers at the end of which
+// the state of accounts can be rebuilt. It starts at the ledger before the
+// oldest change in the account state change log, which has every change from
+// then on, and is bounded by the history retention window.
+func accountStateLedgerRange(ctx context.Context, hq *history.Q) (uint32, uint32, error) {
+	latest, err := hq.GetLastLedgerIngestNonBlocking(ctx)
+	if err != nil {
+		return 0, 0, errors.Wrap(err, "loading last ingested ledger")
+	}
+	var elder int32
+	if err = hq.ElderLedger(ctx, &elder); err != nil {
+		return 0, 0, errors.Wrap(err, "loading elder ledger")
+	}
+	oldest, err := hq.OldestAccountStateChangeLedger(ctx)
+	if err != nil {
+		return 0, 0, errors.Wrap(err, "loading oldest account state change")
+	}
+
+	from := latest
+	if oldest > 0 && oldest-1 < from {
+		from = oldest - 1
+	}
+	if uint32(elder) > from {
+		from = uint32(elder)
+	}
+	return from, latest, nil
+}
+
+// AccountInfoAt returns the information about an account identified by addr
+// at the end of the given ledger. The state of the account is rebuilt from its
+// current state by replacing the entries which changed after the ledger with
+// their state before their first change.
+func AccountInfoAt(ctx context.Context, hq *history.Q, addr string, ledger uint32) (*protocol.Account, error) {
+	from, to, err := accountStateLedgerRange(ctx, hq)
+	if err != nil {
+		return nil, err
+	}
+	if ledger < from || ledger > to {
+		return nil, problem.MakeInvalidFieldProblem(
+			"ledger",
+			errors.Errorf("the state of accounts is only available from ledger %d to ledger %d", from, to),
+		)
+	}
+
+	changes, err := hq.AccountStateChangesAfter(ctx, addr, ledger)
+	if err != nil {
+		return nil, errors.Wrap(err, "loading account state changes")
+	}
+	// entries which changed after the ledger, nil when they didn't exist yet
+	preEntries := map[string]*xdr.LedgerEntry{}
+	for _, change := range changes {
+		if !change.PreEntry.Valid {
+			preEntries[change.LedgerKey] = nil
+			continue
+		}
+		var entry xdr.LedgerEntry
+		if err = xdr.SafeUnmarshalBase64(change.PreEntry.String, &entry); err != nil {
+			return nil, errors.Wrap(err, "decoding account state change")
+		}
+		preEntries[change.LedgerKey] = &entry
+	}
+
+	var accountKey xdr.LedgerKey
+	if err = accountKey.SetAccount(xdr.MustAddress(addr)); err != nil {
+		return nil, errors.Wrap(err, "creating account ledger key")
+	}
+	accountKeyString, err := accountKey.MarshalBinaryBase64()
+	if err != nil {
+		return nil, errors.Wrap(err, "marshaling account ledger key")
+	}
+
+	var (
+		record     history.AccountEntry
+		signers    []history.AccountSigner
+		data       []history.Data
+		trustlines []history.TrustLine
+	)
+	if pre, ok := preEntries[accountKeyString]; ok {
+		if pre == nil {
+			return nil, problem.NotFound
+		}
+		record = processors.AccountEntryToRow(*pre)
+		signers = processors.AccountEntrySignersToRows(*pre)
+	} else {
+		record, err = hq.GetAccountByID(ctx, addr)
+		if err != nil {
+			return nil, errors.Wrap(err, "getting history account record")
+		}
+		signers, err = hq.GetAccountSignersByAccountID(ctx, addr)
+		if err != nil {
+			return nil, errors.Wrap(err, "getting history signers")
+		}
+	}
+
+	currentData, err := hq.GetAccountDataByAccountID(ctx, addr)
+	if err != nil {
+		return nil, errors.Wrap(err, "getting history account data")
+	}
+	for _, d := range currentData {
+		var key xdr.LedgerKey
+		if err = key.SetData(xdr.MustAddress(addr), d.Name); err != nil {
+			return nil, errors.Wrap(err, "creating data ledger key")
+		}
+		keyString, err := key.MarshalBinaryBase64()
+		if err != nil {
+			return nil, errors.Wrap(err, "marshaling data ledger key")
+		}
+		if _, ok := preEntries[keyString]; !ok {
+			data = append(data, d)
+		}
+	}
+
+	currentTrustlines, err := hq.GetSortedTrustLinesByAccountID(ctx, addr)
+	if err != nil {
+		return nil, errors.Wrap(err, "getting history trustlines")
+	}
+	for _, tl := range currentTrustlines {
+		if _, ok := preEntries[tl.LedgerKey]; !ok {
+			trustlines = append(trustlines, tl)
+		}
+	}
+
+	for _, pre := range preEntries {
+		if pre == nil {
+			continue
+		}
+		switch pre.Data.Type {
+		case xdr.LedgerEntryTypeData:
+			data = append(data, processors.DataEntryToRow(*pre))
+		case xdr.LedgerEntryTypeTrustline:
+			tl, err := processors.TrustLineEntryToRow(*pre)
+			if err != nil {
+				return nil, err
+			}
+			trustlines = append(trustlines, tl)
+		}
+	}
+	sort.Slice(data, func(i, j int) bool {
+		return data[i].Name < data[j].Name
+	})
+	sort.Slice(trustlines, func(i, j int) bool {
+		a, b := trustlines[i], trustlines[j]
+		if a.AssetCode != b.AssetCode {
+			return a.AssetCode < b.AssetCode
+		}
+		if a.AssetIssuer != b.AssetIssuer {
+			return a.AssetIssuer < b.AssetIssuer
+		}
+		return a.LiquidityPoolID < b.LiquidityPoolID
+	})
+
+	lastModified, err := getLedgerBySequence(ctx, hq, int32(record.LastModifiedLedger))
+	if err != nil {
+		return nil, err
+	}
+
+	var resource protocol.Account
+	err = resourceadapter.PopulateAccountEntry(
+		ctx,
+		&resource,
+		record,
+		data,
+		signers,
+		trustlines,
+		lastModified,
+	)
+	if err != nil {
+		return nil, errors.Wrap(err, "populating account entry")
+	}
+	return &resource, nil
+}
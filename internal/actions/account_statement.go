@@ -0,0 +1,192 @@
+package actions
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"github.com/stellar/go-stellar-sdk/support/errors"
+	"github.com/stellar/go-stellar-sdk/support/render/hal"
+	"github.com/stellar/go-stellar-sdk/support/render/problem"
+	"github.com/stellar/go-stellar-sdk/xdr"
+	horizonContext "github.com/stellar/stellar-horizon/internal/context"
+	"github.com/stellar/stellar-horizon/internal/db2"
+	"github.com/stellar/stellar-horizon/internal/db2/history"
+	"github.com/stellar/stellar-horizon/internal/resource"
+	"github.com/stellar/stellar-horizon/internal/resourceadapter"
+)
+
+// maxAccountStatementEntries is the maximum number of entries of the
+// statements served by the accounts/{account_id}/statement end-point. Larger
+// statements can be exported with the `horizon export account-statement`
+// command.
+const maxAccountStatementEntries = 10000
+
+// AccountStatementQuery query struct for the accounts/{account_id}/statement
+// end-point, the asset is in canonical form.
+type AccountStatementQuery struct {
+	AccountID   string `schema:"account_id" valid:"accountID,required"`
+	AssetFilter string `schema:"asset" valid:"asset,optional"`
+	FromLedger  uint32 `schema:"from_ledger" valid:"-"`
+	ToLedger    uint32 `schema:"to_ledger" valid:"-"`
+}
+
+// Validate runs validations on AccountStatementQuery
+func (q AccountStatementQuery) Validate() error {
+	if q.FromLedger == 0 {
+		return problem.MakeInvalidFieldProblem(
+			"from_ledger",
+			errors.New("from_ledger is required"),
+		)
+	}
+	if q.ToLedger < q.FromLedger {
+		return problem.MakeInvalidFieldProblem(
+			"to_ledger",
+			errors.New("to_ledger is required and must not be lower than from_ledger"),
+		)
+	}
+	return nil
+}
+
+// Asset returns the asset the statement is filtered by, or nil when it is not
+// filtered.
+func (q AccountStatementQuery) Asset() (*xdr.Asset, error) {
+	if q.AssetFilter == "" {
+		return nil, nil
+	}
+	assets, err := xdr.BuildAssets(q.AssetFilter)
+	if err != nil {
+		return nil, problem.MakeInvalidFieldProblem("asset", err)
+	}
+	return &assets[0], nil
+}
+
+// AccountStatement loads the statement of the debits and credits of the
+// balances of an account from a ledger to another, of a single asset when
+// asset is not nil. The entries are the balance changes of the account, so
+// they include fees, payments, trades, claimable balance claims and every
+// other change which adds up to the balance of the entries. It fails when the
+// statement has more than maxEntries entries, unless maxEntries is zero.
+func AccountStatement(
+	ctx context.Context,
+	hq *history.Q,
+	accountID string,
+	asset *xdr.Asset,
+	fromLedger, toLedger uint32,
+	maxEntries int,
+) (resource.AccountStatement, error) {
+	statement := resource.AccountStatement{
+		Account:    accountID,
+		FromLedger: fromLedger,
+		ToLedger:   toLedger,
+		Entries:    []resource.AccountStatementEntry{},
+	}
+
+	latest, err := hq.GetLastLedgerIngestNonBlocking(ctx)
+	if err != nil {
+		return statement, errors.Wrap(err, "loading last ingested ledger")
+	}
+	var elder int32
+	if err = hq.ElderLedger(ctx, &elder); err != nil {
+		return statement, errors.Wrap(err, "loading elder ledger")
+	}
+	if fromLedger < uint32(elder) {
+		return statement, problem.MakeInvalidFieldProblem(
+			"from_ledger",
+			errors.Errorf("statements are only available from ledger %d to ledger %d", elder, latest),
+		)
+	}
+	if toLedger > latest {
+		return statement, problem.MakeInvalidFieldProblem(
+			"to_ledger",
+			errors.Errorf("statements are only available from ledger %d to ledger %d", elder, latest),
+		)
+	}
+
+	query := history.BalanceChangesQuery{
+		PageQuery:  db2.PageQuery{Order: db2.OrderAscending, Limit: db2.MaxPageSize},
+		AccountID:  accountID,
+		Asset:      asset,
+		FromLedger: fromLedger,
+		ToLedger:   toLedger,
+	}
+	for {
+		records, err := hq.BalanceChanges(ctx, query, elder)
+		if err != nil {
+			return statement, errors.Wrap(err, "loading balance change records")
+		}
+		for _, record := range records {
+			var entry resource.AccountStatementEntry
+			resourceadapter.PopulateAccountStatementEntry(&entry, record)
+			statement.Entries = append(statement.Entries, entry)
+		}
+		if maxEntries > 0 && len(statement.Entries) > maxEntries {
+			return statement, problem.MakeInvalidFieldProblem(
+				"to_ledger",
+				errors.Errorf("the statement has more than %d entries, request a shorter range of ledgers", maxEntries),
+			)
+		}
+		if uint64(len(records)) < query.PageQuery.Limit {
+			break
+		}
+		query.PageQuery.Cursor = records[len(records)-1].PagingToken()
+	}
+
+	self := fmt.Sprintf("/accounts/%s/statement?from_ledger=%d&to_ledger=%d", accountID, fromLedger, toLedger)
+	if asset != nil {
+		self += "&asset=" + asset.StringCanonical()
+	}
+	lb := hal.LinkBuilder{Base: horizonContext.BaseURL(ctx)}
+	statement.Links.Self = lb.Link(self)
+	statement.Links.Account = lb.Linkf("/accounts/%s", accountID)
+	return statement, nil
+}
+
+// GetAccountStatementHandler is the action handler for the end-point returning
+// the statement of an account.
+type GetAccountStatementHandler struct{}
+
+func (handler GetAccountStatementHandler) loadStatement(r *http.Request) (resource.AccountStatement, error) {
+	qp := AccountStatementQuery{}
+	if err := getParams(&qp, r); err != nil {
+		return resource.AccountStatement{}, err
+	}
+	asset, err := qp.Asset()
+	if err != nil {
+		return resource.AccountStatement{}, err
+	}
+
+	historyQ, err := horizonContext.HistoryQFromRequest(r)
+	if err != nil {
+		return resource.AccountStatement{}, err
+	}
+
+	return AccountStatement(
+		r.Context(),
+		historyQ,
+		qp.AccountID,
+		asset,
+		qp.FromLedger,
+		qp.ToLedger,
+		maxAccountStatementEntries,
+	)
+}
+
+// GetResource returns the statement of an account.
+func (handler GetAccountStatementHandler) GetResource(w HeaderWriter, r *http.Request) (interface{}, error) {
+	return handler.loadStatement(r)
+}
+
+// GetCSVRecords returns the entries of the statement of an account as CSV
+// records.
+func (handler GetAccountStatementHandler) GetCSVRecords(r *http.Request) ([]string, [][]string, error) {
+	statement, err := handler.loadStatement(r)
+	if err != nil {
+		return nil, nil, err
+	}
+	records := make([][]string, 0, len(statement.Entries))
+	for _, entry := range statement.Entries {
+		records = append(records, entry.CSVRecord())
+	}
+	return resource.AccountStatementCSVHeader, records, nil
+}
@@ -0,0 +1,65 @@
+package actions
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/stellar/go-stellar-sdk/support/render/problem"
+	"github.com/stellar/stellar-horizon/internal/db2/history"
+	"github.com/stellar/stellar-horizon/internal/test"
+)
+
+func TestAccountStatementQueryValidate(t *testing.T) {
+	account := "GAOQJGUAB7NI7K7I62ORBXMN3J4SSWQUQ7FOEPSDJ322W2HMCNWPHXFB"
+	for _, tc := range []struct {
+		query        AccountStatementQuery
+		invalidField string
+	}{
+		{AccountStatementQuery{AccountID: account, FromLedger: 1, ToLedger: 10}, ""},
+		{AccountStatementQuery{AccountID: account, FromLedger: 10, ToLedger: 10}, ""},
+		{AccountStatementQuery{AccountID: account, ToLedger: 10}, "from_ledger"},
+		{AccountStatementQuery{AccountID: account, FromLedger: 10}, "to_ledger"},
+		{AccountStatementQuery{AccountID: account, FromLedger: 10, ToLedger: 9}, "to_ledger"},
+	} {
+		err := tc.query.Validate()
+		if tc.invalidField == "" {
+			assert.NoError(t, err)
+			continue
+		}
+		p, ok := err.(*problem.P)
+		require.True(t, ok)
+		assert.Equal(t, tc.invalidField, p.Extras["invalid_field"])
+	}
+}
+
+func TestAccountStatementLedgerBounds(t *testing.T) {
+	tt := test.Start(t)
+	defer tt.Finish()
+	test.ResetHorizonDB(t, tt.HorizonDB)
+	q := &history.Q{tt.HorizonSession()}
+	account := "GAOQJGUAB7NI7K7I62ORBXMN3J4SSWQUQ7FOEPSDJ322W2HMCNWPHXFB"
+
+	// the history starts at ledger 123
+	history.FeeBumpScenario(tt, q, true)
+	tt.Assert.NoError(q.UpdateLastLedgerIngest(tt.Ctx, 130))
+
+	for _, tc := range []struct {
+		fromLedger, toLedger uint32
+		invalidField         string
+	}{
+		{123, 130, ""},
+		{122, 130, "from_ledger"},
+		{123, 131, "to_ledger"},
+	} {
+		_, err := AccountStatement(tt.Ctx, q, account, nil, tc.fromLedger, tc.toLedger, 0)
+		if tc.invalidField == "" {
+			tt.Assert.NoError(err)
+			continue
+		}
+		p, ok := err.(*problem.P)
+		tt.Assert.True(ok)
+		tt.Assert.Equal(tc.invalidField, p.Extras["invalid_field"])
+	}
+}
@@ -15,6 +15,7 @@ import (
 	"github.com/stellar/stellar-horizon/internal/db2"
 	"github.com/stellar/stellar-horizon/internal/db2/history"
 	"github.com/stellar/stellar-horizon/internal/ledger"
+	"github.com/stellar/stellar-horizon/internal/resource"
 	"github.com/stellar/stellar-horizon/internal/resourceadapter"
 )
 
@@ -169,3 +170,170 @@ func (handler AssetStatsHandler) GetResourcePage(
 
 	return response, nil
 }
+
+// extractCanonicalAsset returns the type, code and issuer of an asset in
+// canonical form.
+func extractCanonicalAsset(asset string) (xdr.AssetType, string, string, error) {
+	assets, err := xdr.BuildAssets(asset)
+	if err != nil {
+		return 0, "", "", problem.MakeInvalidFieldProblem("asset", err)
+	}
+	var assetType xdr.AssetType
+	var assetCode, assetIssuer string
+	if err = assets[0].Extract(&assetType, &assetCode, &assetIssuer); err != nil {
+		return 0, "", "", problem.MakeInvalidFieldProblem("asset", err)
+	}
+	return assetType, assetCode, assetIssuer, nil
+}
+
+// AssetHistoryQuery query struct for the /assets/{asset}/history end-point,
+// the asset is in the CODE:ISSUER canonical form. Without a resolution the
+// history has a snapshot for every ledger in which the stats of the asset
+// changed.
+type AssetHistoryQuery struct {
+	Asset      string `schema:"asset" valid:"asset"`
+	Resolution uint64 `schema:"resolution" valid:"-"`
+}
+
+// Validate runs validations on AssetHistoryQuery
+func (q AssetHistoryQuery) Validate() error {
+	if strings.ToLower(q.Asset) == "native" {
+		return problem.MakeInvalidFieldProblem(
+			"asset",
+			errors.New("the native asset has no asset stats"),
+		)
+	}
+	return validateSnapshotResolution(q.Resolution)
+}
+
+// AssetHistoryHandler is the action handler for the history of the stats of
+// an asset.
+type AssetHistoryHandler struct {
+	LedgerState *ledger.State
+}
+
+// GetResourcePage returns a page of asset stat snapshots.
+func (handler AssetHistoryHandler) GetResourcePage(w HeaderWriter, r *http.Request) ([]hal.Pageable, error) {
+	ctx := r.Context()
+	qp := AssetHistoryQuery{}
+	err := getParams(&qp, r)
+	if err != nil {
+		return nil, err
+	}
+	assetType, assetCode, assetIssuer, err := extractCanonicalAsset(qp.Asset)
+	if err != nil {
+		return nil, err
+	}
+
+	// bucket cursors are timestamps, not toids
+	var pq db2.PageQuery
+	if qp.Resolution > 0 {
+		pq, err = GetPageQuery(handler.LedgerState, r, DisableCursorValidation)
+	} else {
+		pq, err = GetPageQuery(handler.LedgerState, r)
+		if err == nil {
+			err = validateAndAdjustCursor(handler.LedgerState, &pq)
+		}
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	historyQ, err := horizonContext.HistoryQFromRequest(r)
+	if err != nil {
+		return nil, err
+	}
+
+	var records []history.AssetStatSnapshot
+	if qp.Resolution > 0 {
+		records, err = historyQ.AssetStatSnapshotBuckets(ctx, assetType, assetCode, assetIssuer, int64(qp.Resolution), pq)
+	} else {
+		records, err = historyQ.AssetStatSnapshots(ctx, assetType, assetCode, assetIssuer, pq)
+	}
+	if err != nil {
+		return nil, errors.Wrap(err, "loading asset stat snapshots")
+	}
+
+	var result []hal.Pageable
+	for _, record := range records {
+		var res resource.AssetStatSnapshot
+		if err = resourceadapter.PopulateAssetStatSnapshot(ctx, &res, record, int64(qp.Resolution)); err != nil {
+			return nil, err
+		}
+		result = append(result, res)
+	}
+
+	return result, nil
+}
+
+// AssetHoldersQuery query struct for the /assets/{asset}/holders end-point,
+// the asset is in the CODE:ISSUER canonical form.
+type AssetHoldersQuery struct {
+	Asset string `schema:"asset" valid:"asset"`
+}
+
+// Validate runs validations on AssetHoldersQuery
+func (q AssetHoldersQuery) Validate() error {
+	if strings.ToLower(q.Asset) == "native" {
+		return problem.MakeInvalidFieldProblem(
+			"asset",
+			errors.New("the native asset is not held in trustlines"),
+		)
+	}
+	return nil
+}
+
+// AssetHoldersHandler is the action handler for the end-point returning the
+// accounts and contracts holding an asset, ordered by balance descending.
+type AssetHoldersHandler struct {
+	LedgerState *ledger.State
+}
+
+// GetResourcePage returns a page of asset holders.
+func (handler AssetHoldersHandler) GetResourcePage(w HeaderWriter, r *http.Request) ([]hal.Pageable, error) {
+	ctx := r.Context()
+	qp := AssetHoldersQuery{}
+	err := getParams(&qp, r)
+	if err != nil {
+		return nil, err
+	}
+	assetType, assetCode, assetIssuer, err := extractCanonicalAsset(qp.Asset)
+	if err != nil {
+		return nil, err
+	}
+
+	pq, err := GetPageQuery(handler.LedgerState, r, DisableCursorValidation)
+	if err != nil {
+		return nil, err
+	}
+	query := history.AssetHoldersQuery{
+		PageQuery:   pq,
+		AssetType:   assetType,
+		AssetCode:   assetCode,
+		AssetIssuer: assetIssuer,
+	}
+	if _, _, err = query.Cursor(); err != nil {
+		return nil, problem.MakeInvalidFieldProblem("cursor", err)
+	}
+
+	historyQ, err := horizonContext.HistoryQFromRequest(r)
+	if err != nil {
+		return nil, err
+	}
+
+	records, err := historyQ.GetAssetHolders(ctx, query)
+	if err != nil {
+		return nil, errors.Wrap(err, "loading asset holders")
+	}
+
+	var result []hal.Pageable
+	for _, record := range records {
+		var res resource.AssetHolder
+		if err = resourceadapter.PopulateAssetHolder(ctx, &res, record); err != nil {
+			return nil, err
+		}
+		result = append(result, res)
+	}
+
+	return result, nil
+}
@@ -469,3 +469,48 @@ func TestAssetStatsIssuerDoesNotExist(t *testing.T) {
 	assetStat := results[0].(horizon.AssetStat)
 	tt.Assert.Equal(assetStat, expectedAssetStatResponse)
 }
+
+func TestAssetHistoryQueryValidate(t *testing.T) {
+	asset := usdAsset.StringCanonical()
+	assert.NoError(t, AssetHistoryQuery{Asset: asset}.Validate())
+	assert.NoError(t, AssetHistoryQuery{Asset: asset, Resolution: 86400000}.Validate())
+
+	for _, testCase := range []struct {
+		query AssetHistoryQuery
+		field string
+	}{
+		{AssetHistoryQuery{Asset: "native"}, "asset"},
+		{AssetHistoryQuery{Asset: asset, Resolution: 1000}, "resolution"},
+	} {
+		err := testCase.query.Validate()
+		p, ok := err.(*problem.P)
+		assert.True(t, ok, "expected *problem.P, got %T", err)
+		assert.Equal(t, testCase.field, p.Extras["invalid_field"])
+	}
+}
+
+func TestAssetHoldersValidation(t *testing.T) {
+	handler := AssetHoldersHandler{}
+	asset := usdAsset.StringCanonical()
+
+	for _, testCase := range []struct {
+		name        string
+		queryParams map[string]string
+		asset       string
+		field       string
+	}{
+		{"native asset", map[string]string{}, "native", "asset"},
+		{"invalid asset", map[string]string{}, "USD", "asset"},
+		{"invalid cursor", map[string]string{"cursor": "100"}, asset, "cursor"},
+		{"invalid cursor address", map[string]string{"cursor": "100-GABC"}, asset, "cursor"},
+	} {
+		t.Run(testCase.name, func(t *testing.T) {
+			r := makeRequest(t, testCase.queryParams, map[string]string{"asset": testCase.asset}, nil)
+			_, err := handler.GetResourcePage(httptest.NewRecorder(), r)
+			p, ok := err.(*problem.P)
+			if assert.True(t, ok, "expected *problem.P, got %T", err) {
+				assert.Equal(t, testCase.field, p.Extras["invalid_field"])
+			}
+		})
+	}
+}
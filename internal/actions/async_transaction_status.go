@@ -0,0 +1,124 @@
+package actions
+
+import (
+	"net/http"
+
+	proto "github.com/stellar/go-stellar-sdk/protocols/stellarcore"
+	"github.com/stellar/go-stellar-sdk/support/errors"
+	"github.com/stellar/go-stellar-sdk/support/render/problem"
+	horizonContext "github.com/stellar/stellar-horizon/internal/context"
+	"github.com/stellar/stellar-horizon/internal/db2/history"
+	"github.com/stellar/stellar-horizon/internal/ledger"
+	"github.com/stellar/stellar-horizon/internal/resource"
+	"github.com/stellar/stellar-horizon/internal/txsub"
+)
+
+// AsyncTransactionStatusQuery query struct for the
+// /transactions_async/{tx_id} end-point
+type AsyncTransactionStatusQuery struct {
+	TransactionHash string `schema:"tx_id" valid:"transactionHash"`
+}
+
+// asyncTransactionStatusResponse is the streamable response of the
+// /transactions_async/{tx_id} end-point.
+type asyncTransactionStatusResponse struct {
+	resource.AsyncTransactionStatus
+}
+
+// Equals implements StreamableObjectResponse.
+func (s asyncTransactionStatusResponse) Equals(other StreamableObjectResponse) bool {
+	otherStatus, ok := other.(asyncTransactionStatusResponse)
+	if !ok {
+		return false
+	}
+	return s.Hash == otherStatus.Hash && s.TxStatus == otherStatus.TxStatus
+}
+
+// GetAsyncTransactionStatusHandler is the action handler for the
+// /transactions_async/{tx_id} end-point. Transactions included in an ingested
+// ledger are found whether they were submitted to this instance or not; the
+// status of the other transactions is only known for the latest transactions
+// submitted to this instance. The submissions are tracked in memory, so behind
+// a load balancer a pending transaction is not found by the other instances
+// until it's ingested.
+type GetAsyncTransactionStatusHandler struct {
+	LedgerState *ledger.State
+	Submissions *txsub.AsyncSubmissions
+}
+
+// GetResource implements streamableObjectAction.
+func (handler GetAsyncTransactionStatusHandler) GetResource(_ HeaderWriter, r *http.Request) (StreamableObjectResponse, error) {
+	qp := AsyncTransactionStatusQuery{}
+	if err := getParams(&qp, r); err != nil {
+		return nil, err
+	}
+
+	historyQ, err := horizonContext.HistoryQFromRequest(r)
+	if err != nil {
+		return nil, err
+	}
+
+	// the ledger status is loaded before the transaction so that a
+	// transaction which isn't found isn't included in the ledger either
+	status := handler.LedgerState.CurrentStatus()
+
+	record, err := ingestedTransactionByHash(r, historyQ, qp.TransactionHash)
+	if err != nil {
+		return nil, err
+	}
+	response := asyncTransactionStatusResponse{resource.AsyncTransactionStatus{Hash: qp.TransactionHash}}
+	if record != nil {
+		successful := record.Successful
+		response.TxStatus = resource.AsyncTransactionStatusIngested
+		response.Ledger = record.LedgerSequence
+		response.Successful = &successful
+		response.ResultXDR = record.TxResult
+		return response, nil
+	}
+
+	submission, ok := handler.Submissions.Get(qp.TransactionHash)
+	switch {
+	case !ok:
+		return nil, asyncTransactionNotFoundProblem
+	case submission.CoreStatus == proto.TXStatusError:
+		response.TxStatus = resource.AsyncTransactionStatusFailed
+		response.ErrorResultXDR = submission.ErrorResultXDR
+	case submission.Expired(status.HistoryLatest, status.HistoryLatestClosedAt):
+		response.TxStatus = resource.AsyncTransactionStatusExpired
+	default:
+		response.TxStatus = resource.AsyncTransactionStatusPending
+	}
+	return response, nil
+}
+
+// asyncTransactionNotFoundProblem is returned for the transactions which are
+// neither ingested nor tracked by this instance.
+var asyncTransactionNotFoundProblem = &problem.P{
+	Type:   "not_found",
+	Title:  "Resource Missing",
+	Status: http.StatusNotFound,
+	Detail: "The transaction was not found in the ingested ledgers nor in the latest transactions submitted to " +
+		"this Horizon instance. The status of a pending transaction is only known by the instance it was " +
+		"submitted to, and by every instance once it's ingested.",
+}
+
+// ingestedTransactionByHash returns an ingested transaction, including the
+// transactions which didn't pass the ingestion filters, or nil when it isn't
+// found.
+func ingestedTransactionByHash(r *http.Request, historyQ *history.Q, hash string) (*history.Transaction, error) {
+	var record history.Transaction
+	err := historyQ.TransactionByHash(r.Context(), &record, hash)
+	if err == nil {
+		return &record, nil
+	} else if !historyQ.NoRows(err) {
+		return nil, errors.Wrap(err, "loading transaction record")
+	}
+
+	err = historyQ.PreFilteredTransactionByHash(r.Context(), &record, hash)
+	if err == nil {
+		return &record, nil
+	} else if !historyQ.NoRows(err) {
+		return nil, errors.Wrap(err, "loading prefiltered transaction record")
+	}
+	return nil, nil
+}
@@ -0,0 +1,119 @@
+package actions
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/guregu/null"
+	"github.com/stretchr/testify/assert"
+
+	proto "github.com/stellar/go-stellar-sdk/protocols/stellarcore"
+	"github.com/stellar/go-stellar-sdk/support/render/problem"
+	"github.com/stellar/stellar-horizon/internal/db2/history"
+	"github.com/stellar/stellar-horizon/internal/ledger"
+	"github.com/stellar/stellar-horizon/internal/resource"
+	"github.com/stellar/stellar-horizon/internal/test"
+	"github.com/stellar/stellar-horizon/internal/txsub"
+)
+
+func TestGetAsyncTransactionStatusHandler(t *testing.T) {
+	tt := test.Start(t)
+	defer tt.Finish()
+	test.ResetHorizonDB(t, tt.HorizonDB)
+	q := &history.Q{tt.HorizonSession()}
+	fixture := history.FeeBumpScenario(tt, q, true)
+
+	closedAt := time.Unix(1000, 0)
+	handler := GetAsyncTransactionStatusHandler{
+		LedgerState: &ledger.State{},
+		Submissions: txsub.NewAsyncSubmissions(10),
+	}
+	handler.LedgerState.SetStatus(ledger.Status{
+		HorizonStatus: ledger.HorizonStatus{HistoryLatest: 100, HistoryLatestClosedAt: closedAt},
+	})
+	status := func(hash string) (resource.AsyncTransactionStatus, error) {
+		response, err := handler.GetResource(
+			httptest.NewRecorder(),
+			makeRequest(t, map[string]string{}, map[string]string{"tx_id": hash}, q),
+		)
+		if err != nil {
+			return resource.AsyncTransactionStatus{}, err
+		}
+		return response.(asyncTransactionStatusResponse).AsyncTransactionStatus, nil
+	}
+
+	pendingHash := "2374e99349b9ef7dba9a5db3339b78fda8f34777b1af33ba468ad5c0df946d4d"
+	failedHash := "aa168f12124b7c196c0adaee7c73a64d37f99428cacb59a91ff389626845e7cf"
+
+	// transactions neither ingested nor submitted to this instance
+	_, err := status(pendingHash)
+	p, ok := err.(*problem.P)
+	tt.Assert.True(ok)
+	tt.Assert.Equal(http.StatusNotFound, p.Status)
+
+	handler.Submissions.Add(txsub.AsyncSubmission{
+		Hash:         pendingHash,
+		CoreStatus:   proto.TXStatusPending,
+		TimeBounds:   history.TimeBounds{Null: true},
+		LedgerBounds: history.LedgerBounds{MinLedger: null.IntFrom(0), MaxLedger: null.IntFrom(102)},
+	})
+	handler.Submissions.Add(txsub.AsyncSubmission{
+		Hash:           failedHash,
+		CoreStatus:     proto.TXStatusError,
+		ErrorResultXDR: "AAAAAAAAAGT////7AAAAAA==",
+		TimeBounds:     history.TimeBounds{Null: true},
+		LedgerBounds:   history.LedgerBounds{Null: true},
+	})
+	handler.Submissions.Add(txsub.AsyncSubmission{
+		Hash:         fixture.OuterHash,
+		CoreStatus:   proto.TXStatusPending,
+		TimeBounds:   history.TimeBounds{Null: true},
+		LedgerBounds: history.LedgerBounds{Null: true},
+	})
+
+	response, err := status(pendingHash)
+	tt.Assert.NoError(err)
+	tt.Assert.Equal(resource.AsyncTransactionStatusPending, response.TxStatus)
+	tt.Assert.False(response.IsFinal())
+
+	response, err = status(failedHash)
+	tt.Assert.NoError(err)
+	tt.Assert.Equal(resource.AsyncTransactionStatusFailed, response.TxStatus)
+	tt.Assert.Equal("AAAAAAAAAGT////7AAAAAA==", response.ErrorResultXDR)
+	tt.Assert.True(response.IsFinal())
+
+	// ingested transactions are found whether they are pending or not
+	response, err = status(fixture.OuterHash)
+	tt.Assert.NoError(err)
+	tt.Assert.Equal(resource.AsyncTransactionStatusIngested, response.TxStatus)
+	tt.Assert.Equal(fixture.Ledger.Sequence, response.Ledger)
+	tt.Assert.True(*response.Successful)
+	tt.Assert.Equal(fixture.Transaction.TxResult, response.ResultXDR)
+
+	// the pending transaction can't be included after ledger 101
+	handler.LedgerState.SetStatus(ledger.Status{
+		HorizonStatus: ledger.HorizonStatus{HistoryLatest: 101, HistoryLatestClosedAt: closedAt.Add(5 * time.Second)},
+	})
+	response, err = status(pendingHash)
+	tt.Assert.NoError(err)
+	tt.Assert.Equal(resource.AsyncTransactionStatusExpired, response.TxStatus)
+	tt.Assert.True(response.IsFinal())
+}
+
+func TestAsyncTransactionStatusResponseEquals(t *testing.T) {
+	pending := asyncTransactionStatusResponse{resource.AsyncTransactionStatus{
+		Hash: "a", TxStatus: resource.AsyncTransactionStatusPending,
+	}}
+	ingested := asyncTransactionStatusResponse{resource.AsyncTransactionStatus{
+		Hash: "a", TxStatus: resource.AsyncTransactionStatusIngested, Ledger: 10,
+	}}
+	other := asyncTransactionStatusResponse{resource.AsyncTransactionStatus{
+		Hash: "b", TxStatus: resource.AsyncTransactionStatusPending,
+	}}
+
+	assert.True(t, pending.Equals(pending))
+	assert.False(t, pending.Equals(ingested))
+	assert.False(t, pending.Equals(other))
+}
@@ -0,0 +1,89 @@
+package actions
+
+import (
+	"net/http"
+
+	"github.com/stellar/go-stellar-sdk/support/errors"
+	"github.com/stellar/go-stellar-sdk/support/render/hal"
+	"github.com/stellar/go-stellar-sdk/support/render/problem"
+	"github.com/stellar/go-stellar-sdk/xdr"
+	horizonContext "github.com/stellar/stellar-horizon/internal/context"
+	"github.com/stellar/stellar-horizon/internal/db2/history"
+	"github.com/stellar/stellar-horizon/internal/ledger"
+	"github.com/stellar/stellar-horizon/internal/resource"
+	"github.com/stellar/stellar-horizon/internal/resourceadapter"
+)
+
+// BalanceChangesQuery query struct for the
+// accounts/{account_id}/balance_changes end-point, the asset is in canonical
+// form.
+type BalanceChangesQuery struct {
+	AccountID   string `schema:"account_id" valid:"accountID,required"`
+	AssetFilter string `schema:"asset" valid:"asset,optional"`
+}
+
+// Asset returns the asset the balance changes are filtered by, or nil when
+// they are not filtered.
+func (q BalanceChangesQuery) Asset() (*xdr.Asset, error) {
+	if q.AssetFilter == "" {
+		return nil, nil
+	}
+	assets, err := xdr.BuildAssets(q.AssetFilter)
+	if err != nil {
+		return nil, problem.MakeInvalidFieldProblem("asset", err)
+	}
+	return &assets[0], nil
+}
+
+// GetBalanceChangesHandler is the action handler for the end-point returning
+// the balance changes of an account.
+type GetBalanceChangesHandler struct {
+	LedgerState *ledger.State
+}
+
+// GetResourcePage returns a page of balance changes.
+func (handler GetBalanceChangesHandler) GetResourcePage(w HeaderWriter, r *http.Request) ([]hal.Pageable, error) {
+	ctx := r.Context()
+	pq, err := GetPageQuery(handler.LedgerState, r)
+	if err != nil {
+		return nil, err
+	}
+
+	err = validateAndAdjustCursor(handler.LedgerState, &pq)
+	if err != nil {
+		return nil, err
+	}
+
+	qp := BalanceChangesQuery{}
+	if err = getParams(&qp, r); err != nil {
+		return nil, err
+	}
+	asset, err := qp.Asset()
+	if err != nil {
+		return nil, err
+	}
+
+	historyQ, err := horizonContext.HistoryQFromRequest(r)
+	if err != nil {
+		return nil, err
+	}
+
+	query := history.BalanceChangesQuery{
+		PageQuery: pq,
+		AccountID: qp.AccountID,
+		Asset:     asset,
+	}
+	records, err := historyQ.BalanceChanges(ctx, query, handler.LedgerState.CurrentStatus().HistoryElder)
+	if err != nil {
+		return nil, errors.Wrap(err, "loading balance change records")
+	}
+
+	var result []hal.Pageable
+	for _, record := range records {
+		var res resource.BalanceChange
+		resourceadapter.PopulateBalanceChange(ctx, &res, record)
+		result = append(result, res)
+	}
+
+	return result, nil
+}
@@ -0,0 +1,31 @@
+package actions
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/stellar/go-stellar-sdk/support/render/problem"
+	"github.com/stellar/go-stellar-sdk/xdr"
+)
+
+func TestBalanceChangesQueryAsset(t *testing.T) {
+	asset, err := BalanceChangesQuery{}.Asset()
+	require.NoError(t, err)
+	assert.Nil(t, asset)
+
+	asset, err = BalanceChangesQuery{AssetFilter: "native"}.Asset()
+	require.NoError(t, err)
+	assert.True(t, asset.Equals(xdr.MustNewNativeAsset()))
+
+	issuer := "GAOQJGUAB7NI7K7I62ORBXMN3J4SSWQUQ7FOEPSDJ322W2HMCNWPHXFB"
+	asset, err = BalanceChangesQuery{AssetFilter: "USD:" + issuer}.Asset()
+	require.NoError(t, err)
+	assert.True(t, asset.Equals(xdr.MustNewCreditAsset("USD", issuer)))
+
+	_, err = BalanceChangesQuery{AssetFilter: "USD"}.Asset()
+	p, ok := err.(*problem.P)
+	require.True(t, ok)
+	assert.Equal(t, "asset", p.Extras["invalid_field"])
+}
@@ -0,0 +1,253 @@
+package actions
+
+import (
+	"context"
+	"crypto/sha256"
+	"net/http"
+
+	"github.com/stellar/go-stellar-sdk/strkey"
+	"github.com/stellar/go-stellar-sdk/support/errors"
+	"github.com/stellar/go-stellar-sdk/support/render/hal"
+	"github.com/stellar/go-stellar-sdk/support/render/problem"
+	"github.com/stellar/go-stellar-sdk/xdr"
+	horizonContext "github.com/stellar/stellar-horizon/internal/context"
+	"github.com/stellar/stellar-horizon/internal/db2/history"
+	"github.com/stellar/stellar-horizon/internal/ledger"
+	"github.com/stellar/stellar-horizon/internal/resource"
+	"github.com/stellar/stellar-horizon/internal/resourceadapter"
+)
+
+// ContractQuery query struct for contracts/{contract_id} end-points
+type ContractQuery struct {
+	ContractID string `schema:"contract_id" valid:"contractID,required"`
+}
+
+// instanceKeyHash returns the key hash of the contract instance ledger entry
+// of the contract.
+func (q ContractQuery) instanceKeyHash() (xdr.Hash, error) {
+	raw, err := strkey.Decode(strkey.VersionByteContract, q.ContractID)
+	if err != nil {
+		return xdr.Hash{}, err
+	}
+	var contractID xdr.ContractId
+	copy(contractID[:], raw)
+
+	ledgerKey := xdr.LedgerKey{
+		Type: xdr.LedgerEntryTypeContractData,
+		ContractData: &xdr.LedgerKeyContractData{
+			Contract: xdr.ScAddress{
+				Type:       xdr.ScAddressTypeScAddressTypeContract,
+				ContractId: &contractID,
+			},
+			Key:        xdr.ScVal{Type: xdr.ScValTypeScvLedgerKeyContractInstance},
+			Durability: xdr.ContractDataDurabilityPersistent,
+		},
+	}
+	bin, err := ledgerKey.MarshalBinary()
+	if err != nil {
+		return xdr.Hash{}, err
+	}
+	return sha256.Sum256(bin), nil
+}
+
+// GetContractByIDHandler is the action handler for the end-point returning a
+// contract.
+type GetContractByIDHandler struct{}
+
+// GetResource returns a contract.
+func (handler GetContractByIDHandler) GetResource(w HeaderWriter, r *http.Request) (interface{}, error) {
+	ctx := r.Context()
+	qp := ContractQuery{}
+	err := getParams(&qp, r)
+	if err != nil {
+		return nil, err
+	}
+
+	keyHash, err := qp.instanceKeyHash()
+	if err != nil {
+		return nil, problem.MakeInvalidFieldProblem("contract_id", err)
+	}
+
+	historyQ, err := horizonContext.HistoryQFromRequest(r)
+	if err != nil {
+		return nil, err
+	}
+	instance, err := historyQ.GetContractDataByKeyHash(ctx, keyHash)
+	if err != nil {
+		return nil, err
+	}
+	ledger, err := loadLastModifiedLedger(ctx, historyQ, instance.LastModifiedLedger)
+	if err != nil {
+		return nil, err
+	}
+
+	var resource resource.Contract
+	err = resourceadapter.PopulateContract(ctx, &resource, instance, ledger)
+	if err != nil {
+		return nil, err
+	}
+
+	return resource, nil
+}
+
+// GetContractDataHandler is the action handler for the end-point returning
+// the persistent contract data entries of a contract.
+type GetContractDataHandler struct {
+	LedgerState *ledger.State
+}
+
+// GetResourcePage returns a page of contract data entries.
+func (handler GetContractDataHandler) GetResourcePage(w HeaderWriter, r *http.Request) ([]hal.Pageable, error) {
+	ctx := r.Context()
+	qp := ContractQuery{}
+	err := getParams(&qp, r)
+	if err != nil {
+		return nil, err
+	}
+
+	pq, err := GetPageQuery(handler.LedgerState, r, DisableCursorValidation)
+	if err != nil {
+		return nil, err
+	}
+
+	query := history.ContractDataQuery{
+		PageQuery:  pq,
+		ContractID: qp.ContractID,
+	}
+	if _, err = query.Cursor(); err != nil {
+		return nil, problem.MakeInvalidFieldProblem("cursor", err)
+	}
+
+	historyQ, err := horizonContext.HistoryQFromRequest(r)
+	if err != nil {
+		return nil, err
+	}
+
+	records, err := historyQ.GetContractData(ctx, query)
+	if err != nil {
+		return nil, errors.Wrap(err, "loading contract data records")
+	}
+
+	ledgerCache := history.LedgerCache{}
+	for _, record := range records {
+		ledgerCache.Queue(int32(record.LastModifiedLedger))
+	}
+	if err := ledgerCache.Load(ctx, historyQ); err != nil {
+		return nil, errors.Wrap(err, "failed to load ledger batch")
+	}
+
+	var result []hal.Pageable
+	for _, record := range records {
+		var res resource.ContractData
+
+		var ledger *history.Ledger
+		if l, ok := ledgerCache.Records[int32(record.LastModifiedLedger)]; ok {
+			ledger = &l
+		}
+
+		if err := resourceadapter.PopulateContractData(ctx, &res, record, ledger); err != nil {
+			return nil, err
+		}
+		result = append(result, res)
+	}
+
+	return result, nil
+}
+
+// AccountContractBalancesQuery query struct for the
+// accounts/{account_id}/contract_balances end-point
+type AccountContractBalancesQuery struct {
+	AccountID string `schema:"account_id" valid:"accountID,required"`
+}
+
+// GetAccountContractBalancesHandler is the action handler for the end-point
+// returning the stellar asset contract balances of an account.
+type GetAccountContractBalancesHandler struct {
+	LedgerState *ledger.State
+}
+
+// GetResourcePage returns a page of stellar asset contract balances.
+func (handler GetAccountContractBalancesHandler) GetResourcePage(w HeaderWriter, r *http.Request) ([]hal.Pageable, error) {
+	qp := AccountContractBalancesQuery{}
+	if err := getParams(&qp, r); err != nil {
+		return nil, err
+	}
+	return getContractBalancesPage(r, handler.LedgerState, qp.AccountID)
+}
+
+// GetContractBalancesHandler is the action handler for the end-point returning
+// the stellar asset contract balances held by a contract.
+type GetContractBalancesHandler struct {
+	LedgerState *ledger.State
+}
+
+// GetResourcePage returns a page of stellar asset contract balances.
+func (handler GetContractBalancesHandler) GetResourcePage(w HeaderWriter, r *http.Request) ([]hal.Pageable, error) {
+	qp := ContractQuery{}
+	if err := getParams(&qp, r); err != nil {
+		return nil, err
+	}
+	return getContractBalancesPage(r, handler.LedgerState, qp.ContractID)
+}
+
+func getContractBalancesPage(r *http.Request, ledgerState *ledger.State, holder string) ([]hal.Pageable, error) {
+	ctx := r.Context()
+	pq, err := GetPageQuery(ledgerState, r, DisableCursorValidation)
+	if err != nil {
+		return nil, err
+	}
+
+	query := history.ContractBalancesQuery{
+		PageQuery: pq,
+		Holder:    holder,
+	}
+	if _, err = query.Cursor(); err != nil {
+		return nil, problem.MakeInvalidFieldProblem("cursor", err)
+	}
+
+	historyQ, err := horizonContext.HistoryQFromRequest(r)
+	if err != nil {
+		return nil, err
+	}
+
+	records, err := historyQ.GetContractBalances(ctx, query)
+	if err != nil {
+		return nil, errors.Wrap(err, "loading contract balances")
+	}
+
+	ledgerCache := history.LedgerCache{}
+	for _, record := range records {
+		ledgerCache.Queue(int32(record.LastModifiedLedger))
+	}
+	if err := ledgerCache.Load(ctx, historyQ); err != nil {
+		return nil, errors.Wrap(err, "failed to load ledger batch")
+	}
+
+	var result []hal.Pageable
+	for _, record := range records {
+		var res resource.ContractBalance
+
+		var ledger *history.Ledger
+		if l, ok := ledgerCache.Records[int32(record.LastModifiedLedger)]; ok {
+			ledger = &l
+		}
+
+		if err := resourceadapter.PopulateContractBalance(ctx, &res, record, ledger); err != nil {
+			return nil, err
+		}
+		result = append(result, res)
+	}
+
+	return result, nil
+}
+
+func loadLastModifiedLedger(ctx context.Context, historyQ *history.Q, sequence uint32) (*history.Ledger, error) {
+	ledger := &history.Ledger{}
+	err := historyQ.LedgerBySequence(ctx, ledger, int32(sequence))
+	if historyQ.NoRows(err) {
+		return nil, nil
+	} else if err != nil {
+		return nil, errors.Wrap(err, "LedgerBySequence error")
+	}
+	return ledger, nil
+}
@@ -0,0 +1,119 @@
+package actions
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/stellar/go-stellar-sdk/support/errors"
+	"github.com/stellar/go-stellar-sdk/support/render/hal"
+	"github.com/stellar/go-stellar-sdk/support/render/problem"
+	"github.com/stellar/go-stellar-sdk/xdr"
+	horizonContext "github.com/stellar/stellar-horizon/internal/context"
+	"github.com/stellar/stellar-horizon/internal/db2/history"
+	"github.com/stellar/stellar-horizon/internal/ledger"
+	"github.com/stellar/stellar-horizon/internal/resource"
+	"github.com/stellar/stellar-horizon/internal/resourceadapter"
+)
+
+// maxContractEventTopics is the maximum number of topics a contract event can
+// have, filters with more segments can never match.
+const maxContractEventTopics = 4
+
+// ContractEventsQuery query struct for contract events end-points
+type ContractEventsQuery struct {
+	ContractID string `schema:"contract_id" valid:"contractID,optional"`
+	TxHash     string `schema:"tx_id" valid:"transactionHash,optional"`
+	Topics     string `schema:"topics" valid:"-"`
+}
+
+// TopicsFilter returns the topic segments of the `topics` parameter.
+func (qp ContractEventsQuery) TopicsFilter() []string {
+	if qp.Topics == "" {
+		return nil
+	}
+	return strings.Split(qp.Topics, ",")
+}
+
+// Validate runs extra validations on query parameters
+func (qp ContractEventsQuery) Validate() error {
+	topics := qp.TopicsFilter()
+	if len(topics) > maxContractEventTopics {
+		return problem.MakeInvalidFieldProblem(
+			"topics",
+			errors.Errorf("topics cannot have more than %d segments", maxContractEventTopics),
+		)
+	}
+
+	for _, topic := range topics {
+		if topic == history.ContractEventTopicWildcard {
+			continue
+		}
+		var scVal xdr.ScVal
+		if err := xdr.SafeUnmarshalBase64(topic, &scVal); err != nil {
+			return problem.MakeInvalidFieldProblem(
+				"topics",
+				errors.New("each topic must be `*` or a base64 encoded XDR ScVal"),
+			)
+		}
+	}
+	return nil
+}
+
+// GetContractEventsHandler is the action handler for all end-points returning
+// a list of contract events.
+type GetContractEventsHandler struct {
+	LedgerState *ledger.State
+}
+
+// GetResourcePage returns a page of contract events.
+func (handler GetContractEventsHandler) GetResourcePage(w HeaderWriter, r *http.Request) ([]hal.Pageable, error) {
+	ctx := r.Context()
+	pq, err := GetPageQuery(handler.LedgerState, r)
+	if err != nil {
+		return nil, err
+	}
+
+	err = validateAndAdjustCursor(handler.LedgerState, &pq)
+	if err != nil {
+		return nil, err
+	}
+
+	qp := ContractEventsQuery{}
+	err = getParams(&qp, r)
+	if err != nil {
+		return nil, err
+	}
+
+	historyQ, err := horizonContext.HistoryQFromRequest(r)
+	if err != nil {
+		return nil, err
+	}
+
+	query := history.ContractEventsQuery{
+		PageQuery:       pq,
+		ContractID:      qp.ContractID,
+		TransactionHash: qp.TxHash,
+		Topics:          qp.TopicsFilter(),
+	}
+	records, err := historyQ.ContractEvents(ctx, query, handler.LedgerState.CurrentStatus().HistoryElder)
+	if err != nil {
+		return nil, errors.Wrap(err, "loading contract event records")
+	}
+
+	ledgers := &history.LedgerCache{}
+	for _, record := range records {
+		ledgers.Queue(record.LedgerSequence())
+	}
+	if err = ledgers.Load(ctx, historyQ); err != nil {
+		return nil, errors.Wrap(err, "loading ledgers")
+	}
+
+	var result []hal.Pageable
+	for _, record := range records {
+		var res resource.ContractEvent
+		resourceadapter.PopulateContractEvent(ctx, &res, record, ledgers.Records[record.LedgerSequence()])
+		result = append(result, res)
+	}
+
+	return result, nil
+}
@@ -0,0 +1,42 @@
+package actions
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stellar/go-stellar-sdk/support/render/problem"
+	"github.com/stellar/go-stellar-sdk/xdr"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestContractEventsQueryValidate(t *testing.T) {
+	sym := xdr.ScSymbol("transfer")
+	topic, err := xdr.MarshalBase64(xdr.ScVal{Type: xdr.ScValTypeScvSymbol, Sym: &sym})
+	assert.NoError(t, err)
+
+	for _, tc := range []struct {
+		name    string
+		topics  string
+		wantErr bool
+	}{
+		{name: "empty", topics: "", wantErr: false},
+		{name: "single topic", topics: topic, wantErr: false},
+		{name: "wildcards", topics: "*,*," + topic, wantErr: false},
+		{name: "too many segments", topics: strings.Repeat("*,", 4) + "*", wantErr: true},
+		{name: "invalid xdr", topics: "*,dHJhbnNmZXI=", wantErr: true},
+		{name: "empty segment", topics: topic + ",", wantErr: true},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			q := ContractEventsQuery{Topics: tc.topics}
+			err := q.Validate()
+			if tc.wantErr {
+				assert.Error(t, err)
+				p, ok := err.(*problem.P)
+				assert.True(t, ok, "expected *problem.P, got %T", err)
+				assert.Equal(t, "topics", p.Extras["invalid_field"])
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
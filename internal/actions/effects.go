@@ -50,6 +50,11 @@ type GetEffectsHandler struct {
 	LedgerState *ledger.State
 }
 
+// CSVColumns returns the columns of effects rendered as CSV.
+func (handler GetEffectsHandler) CSVColumns() []string {
+	return resourceadapter.EffectCSVColumns
+}
+
 func (handler GetEffectsHandler) GetResourcePage(w HeaderWriter, r *http.Request) ([]hal.Pageable, error) {
 	pq, err := GetPageQuery(handler.LedgerState, r)
 	if err != nil {
@@ -5,10 +5,13 @@ import (
 	"fmt"
 	"net/http"
 
-	hProtocol "github.com/stellar/go-stellar-sdk/protocols/horizon"
+	"github.com/stellar/go-stellar-sdk/protocols/horizon/operations"
+	"github.com/stellar/go-stellar-sdk/strkey"
+	"github.com/stellar/go-stellar-sdk/support/log"
 	"github.com/stellar/go-stellar-sdk/support/render/problem"
 	horizonContext "github.com/stellar/stellar-horizon/internal/context"
 	"github.com/stellar/stellar-horizon/internal/db2/history"
+	"github.com/stellar/stellar-horizon/internal/resource"
 )
 
 // these admin HTTP endpoints are documented in internal/httpx/static/admin_oapi.yml
@@ -29,6 +32,11 @@ func (handler FilterConfigHandler) GetAssetConfig(w http.ResponseWriter, r *http
 	}
 
 	responsePayload := handler.assetConfigResource(config)
+	responsePayload.AppliedFromLedger, err = handler.appliedFromLedger(r, historyQ, history.AssetFilterName, config.LastModified)
+	if err != nil {
+		problem.Render(r.Context(), w, err)
+		return
+	}
 	enc := json.NewEncoder(w)
 	if err = enc.Encode(responsePayload); err != nil {
 		problem.Render(r.Context(), w, err)
@@ -50,6 +58,11 @@ func (handler FilterConfigHandler) GetAccountConfig(w http.ResponseWriter, r *ht
 	}
 
 	responsePayload := handler.accountConfigResource(config)
+	responsePayload.AppliedFromLedger, err = handler.appliedFromLedger(r, historyQ, history.AccountFilterName, config.LastModified)
+	if err != nil {
+		problem.Render(r.Context(), w, err)
+		return
+	}
 	enc := json.NewEncoder(w)
 	if err = enc.Encode(responsePayload); err != nil {
 		problem.Render(r.Context(), w, err)
@@ -72,11 +85,14 @@ func (handler FilterConfigHandler) UpdateAccountConfig(w http.ResponseWriter, r
 	filterConfig := history.AccountFilterConfig{}
 	filterConfig.Enabled = *filterRequest.Enabled
 	filterConfig.Whitelist = filterRequest.Whitelist
+	filterConfig.Blacklist = filterRequest.Blacklist
 
 	config, err := historyQ.UpdateAccountFilterConfig(r.Context(), filterConfig)
 	if err != nil {
 		problem.Render(r.Context(), w, err)
+		return
 	}
+	handler.notifyFilterRulesUpdated(r, historyQ, history.AccountFilterName)
 
 	responsePayload := handler.accountConfigResource(config)
 	enc := json.NewEncoder(w)
@@ -101,11 +117,14 @@ func (handler FilterConfigHandler) UpdateAssetConfig(w http.ResponseWriter, r *h
 	filterConfig := history.AssetFilterConfig{}
 	filterConfig.Enabled = *filterRequest.Enabled
 	filterConfig.Whitelist = filterRequest.Whitelist
+	filterConfig.Blacklist = filterRequest.Blacklist
 
 	config, err := historyQ.UpdateAssetFilterConfig(r.Context(), filterConfig)
 	if err != nil {
 		problem.Render(r.Context(), w, err)
+		return
 	}
+	handler.notifyFilterRulesUpdated(r, historyQ, history.AssetFilterName)
 
 	responsePayload := handler.assetConfigResource(config)
 	enc := json.NewEncoder(w)
@@ -114,38 +133,231 @@ func (handler FilterConfigHandler) UpdateAssetConfig(w http.ResponseWriter, r *h
 	}
 }
 
-func (handler FilterConfigHandler) assetFilterResource(r *http.Request) (hProtocol.AssetFilterConfig, error) {
-	var filterRequest hProtocol.AssetFilterConfig
+func (handler FilterConfigHandler) GetContractConfig(w http.ResponseWriter, r *http.Request) {
+	historyQ, err := horizonContext.HistoryQFromRequest(r)
+	if err != nil {
+		problem.Render(r.Context(), w, err)
+		return
+	}
+
+	config, err := historyQ.GetContractFilterConfig(r.Context())
+
+	if err != nil {
+		problem.Render(r.Context(), w, err)
+		return
+	}
+
+	responsePayload := handler.contractConfigResource(config)
+	responsePayload.AppliedFromLedger, err = handler.appliedFromLedger(r, historyQ, history.ContractFilterName, config.LastModified)
+	if err != nil {
+		problem.Render(r.Context(), w, err)
+		return
+	}
+	enc := json.NewEncoder(w)
+	if err = enc.Encode(responsePayload); err != nil {
+		problem.Render(r.Context(), w, err)
+	}
+}
+
+func (handler FilterConfigHandler) GetOperationTypeConfig(w http.ResponseWriter, r *http.Request) {
+	historyQ, err := horizonContext.HistoryQFromRequest(r)
+	if err != nil {
+		problem.Render(r.Context(), w, err)
+		return
+	}
+
+	config, err := historyQ.GetOperationTypeFilterConfig(r.Context())
+
+	if err != nil {
+		problem.Render(r.Context(), w, err)
+		return
+	}
+
+	responsePayload := handler.operationTypeConfigResource(config)
+	responsePayload.AppliedFromLedger, err = handler.appliedFromLedger(r, historyQ, history.OperationTypeFilterName, config.LastModified)
+	if err != nil {
+		problem.Render(r.Context(), w, err)
+		return
+	}
+	enc := json.NewEncoder(w)
+	if err = enc.Encode(responsePayload); err != nil {
+		problem.Render(r.Context(), w, err)
+	}
+}
+
+func (handler FilterConfigHandler) UpdateContractConfig(w http.ResponseWriter, r *http.Request) {
+	historyQ, err := horizonContext.HistoryQFromRequest(r)
+	if err != nil {
+		problem.Render(r.Context(), w, err)
+		return
+	}
+
+	filterRequest, err := handler.contractFilterResource(r)
+	if err != nil {
+		problem.Render(r.Context(), w, err)
+		return
+	}
+
+	filterConfig := history.ContractFilterConfig{}
+	filterConfig.Enabled = *filterRequest.Enabled
+	filterConfig.Whitelist = filterRequest.Whitelist
+
+	config, err := historyQ.UpdateContractFilterConfig(r.Context(), filterConfig)
+	if err != nil {
+		problem.Render(r.Context(), w, err)
+		return
+	}
+	handler.notifyFilterRulesUpdated(r, historyQ, history.ContractFilterName)
+
+	responsePayload := handler.contractConfigResource(config)
+	enc := json.NewEncoder(w)
+	if err = enc.Encode(responsePayload); err != nil {
+		problem.Render(r.Context(), w, err)
+	}
+}
+
+func (handler FilterConfigHandler) UpdateOperationTypeConfig(w http.ResponseWriter, r *http.Request) {
+	historyQ, err := horizonContext.HistoryQFromRequest(r)
+	if err != nil {
+		problem.Render(r.Context(), w, err)
+		return
+	}
+
+	filterRequest, err := handler.operationTypeFilterResource(r)
+	if err != nil {
+		problem.Render(r.Context(), w, err)
+		return
+	}
+
+	filterConfig := history.OperationTypeFilterConfig{}
+	filterConfig.Enabled = *filterRequest.Enabled
+	filterConfig.Whitelist = filterRequest.Whitelist
+	filterConfig.MemoWhitelist = filterRequest.MemoWhitelist
+
+	config, err := historyQ.UpdateOperationTypeFilterConfig(r.Context(), filterConfig)
+	if err != nil {
+		problem.Render(r.Context(), w, err)
+		return
+	}
+	handler.notifyFilterRulesUpdated(r, historyQ, history.OperationTypeFilterName)
+
+	responsePayload := handler.operationTypeConfigResource(config)
+	enc := json.NewEncoder(w)
+	if err = enc.Encode(responsePayload); err != nil {
+		problem.Render(r.Context(), w, err)
+	}
+}
+
+// notifyFilterRulesUpdated notifies the ingesting instances of the filter rules
+// update so they apply it from the next ledger, a failed notification is only
+// logged because the update is anyway applied once the rules are polled.
+func (handler FilterConfigHandler) notifyFilterRulesUpdated(r *http.Request, historyQ *history.Q, filterName string) {
+	if err := historyQ.NotifyFilterRulesUpdated(r.Context(), filterName); err != nil {
+		log.Ctx(r.Context()).WithError(err).Warnf("could not notify %s filter rules update", filterName)
+	}
+}
+
+// appliedFromLedger returns the first ledger ingested with the version of the
+// filter rules last modified at lastModified, or 0 if ingestion hasn't
+// applied it yet.
+func (handler FilterConfigHandler) appliedFromLedger(r *http.Request, historyQ *history.Q, filterName string, lastModified int64) (uint32, error) {
+	appliedFrom, err := historyQ.GetFilterRulesAppliedFrom(r.Context(), filterName)
+	if err != nil {
+		return 0, err
+	}
+	if appliedFrom.LastModified != lastModified {
+		return 0, nil
+	}
+	return appliedFrom.Ledger, nil
+}
+
+func (handler FilterConfigHandler) assetFilterResource(r *http.Request) (resource.AssetFilterConfig, error) {
+	var filterRequest resource.AssetFilterConfig
 	dec := json.NewDecoder(r.Body)
 	if err := dec.Decode(&filterRequest); err != nil {
 		p := problem.NewProblemWithInvalidField(problem.BadRequest, "reason", fmt.Errorf("invalid json for asset filter config %v", err.Error()))
-		return hProtocol.AssetFilterConfig{}, p
+		return resource.AssetFilterConfig{}, p
 	}
 	return filterRequest, nil
 }
 
-func (handler FilterConfigHandler) accountFilterResource(r *http.Request) (hProtocol.AccountFilterConfig, error) {
-	var filterRequest hProtocol.AccountFilterConfig
+func (handler FilterConfigHandler) accountFilterResource(r *http.Request) (resource.AccountFilterConfig, error) {
+	var filterRequest resource.AccountFilterConfig
 	dec := json.NewDecoder(r.Body)
 	if err := dec.Decode(&filterRequest); err != nil {
 		p := problem.NewProblemWithInvalidField(problem.BadRequest, "reason", fmt.Errorf("invalid json for account filter config %v", err.Error()))
-		return hProtocol.AccountFilterConfig{}, p
+		return resource.AccountFilterConfig{}, p
+	}
+	return filterRequest, nil
+}
+
+func (handler FilterConfigHandler) contractFilterResource(r *http.Request) (resource.ContractFilterConfig, error) {
+	var filterRequest resource.ContractFilterConfig
+	dec := json.NewDecoder(r.Body)
+	if err := dec.Decode(&filterRequest); err != nil {
+		p := problem.NewProblemWithInvalidField(problem.BadRequest, "reason", fmt.Errorf("invalid json for contract filter config %v", err.Error()))
+		return resource.ContractFilterConfig{}, p
+	}
+	for _, contractID := range filterRequest.Whitelist {
+		if !strkey.IsValidContractAddress(contractID) {
+			p := problem.NewProblemWithInvalidField(problem.BadRequest, "whitelist", fmt.Errorf("invalid contract address %q", contractID))
+			return resource.ContractFilterConfig{}, p
+		}
 	}
 	return filterRequest, nil
 }
 
-func (handler FilterConfigHandler) assetConfigResource(config history.AssetFilterConfig) hProtocol.AssetFilterConfig {
-	return hProtocol.AssetFilterConfig{
+func (handler FilterConfigHandler) operationTypeFilterResource(r *http.Request) (resource.OperationTypeFilterConfig, error) {
+	var filterRequest resource.OperationTypeFilterConfig
+	dec := json.NewDecoder(r.Body)
+	if err := dec.Decode(&filterRequest); err != nil {
+		p := problem.NewProblemWithInvalidField(problem.BadRequest, "reason", fmt.Errorf("invalid json for operation type filter config %v", err.Error()))
+		return resource.OperationTypeFilterConfig{}, p
+	}
+	validTypes := make(map[string]bool, len(operations.TypeNames))
+	for _, name := range operations.TypeNames {
+		validTypes[name] = true
+	}
+	for _, operationType := range filterRequest.Whitelist {
+		if !validTypes[operationType] {
+			p := problem.NewProblemWithInvalidField(problem.BadRequest, "whitelist", fmt.Errorf("invalid operation type %q", operationType))
+			return resource.OperationTypeFilterConfig{}, p
+		}
+	}
+	return filterRequest, nil
+}
+
+func (handler FilterConfigHandler) assetConfigResource(config history.AssetFilterConfig) resource.AssetFilterConfig {
+	return resource.AssetFilterConfig{
 		Whitelist:    config.Whitelist,
+		Blacklist:    config.Blacklist,
 		Enabled:      &config.Enabled,
 		LastModified: config.LastModified,
 	}
 }
 
-func (handler FilterConfigHandler) accountConfigResource(config history.AccountFilterConfig) hProtocol.AccountFilterConfig {
-	return hProtocol.AccountFilterConfig{
+func (handler FilterConfigHandler) accountConfigResource(config history.AccountFilterConfig) resource.AccountFilterConfig {
+	return resource.AccountFilterConfig{
 		Whitelist:    config.Whitelist,
+		Blacklist:    config.Blacklist,
 		Enabled:      &config.Enabled,
 		LastModified: config.LastModified,
 	}
 }
+
+func (handler FilterConfigHandler) contractConfigResource(config history.ContractFilterConfig) resource.ContractFilterConfig {
+	return resource.ContractFilterConfig{
+		Whitelist:    config.Whitelist,
+		Enabled:      &config.Enabled,
+		LastModified: config.LastModified,
+	}
+}
+
+func (handler FilterConfigHandler) operationTypeConfigResource(config history.OperationTypeFilterConfig) resource.OperationTypeFilterConfig {
+	return resource.OperationTypeFilterConfig{
+		Whitelist:     config.Whitelist,
+		MemoWhitelist: config.MemoWhitelist,
+		Enabled:       &config.Enabled,
+		LastModified:  config.LastModified,
+	}
+}
@@ -8,8 +8,8 @@ import (
 	"strings"
 	"testing"
 
-	hProtocol "github.com/stellar/go-stellar-sdk/protocols/horizon"
 	"github.com/stellar/stellar-horizon/internal/db2/history"
+	"github.com/stellar/stellar-horizon/internal/resource"
 	"github.com/stellar/stellar-horizon/internal/test"
 )
 
@@ -46,7 +46,7 @@ func TestGetAssetFilterConfig(t *testing.T) {
 	raw, err := ioutil.ReadAll(resp.Body)
 	tt.Assert.NoError(err)
 
-	var filterCfgResource hProtocol.AssetFilterConfig
+	var filterCfgResource resource.AssetFilterConfig
 	json.Unmarshal(raw, &filterCfgResource)
 	tt.Assert.NoError(err)
 
@@ -88,7 +88,7 @@ func TestGetAccountFilterConfig(t *testing.T) {
 	raw, err := ioutil.ReadAll(resp.Body)
 	tt.Assert.NoError(err)
 
-	var filterCfgResource hProtocol.AccountFilterConfig
+	var filterCfgResource resource.AccountFilterConfig
 	json.Unmarshal(raw, &filterCfgResource)
 	tt.Assert.NoError(err)
 
@@ -180,6 +180,7 @@ func TestUpdateAssetFilterConfig(t *testing.T) {
 	request.Body = ioutil.NopCloser(strings.NewReader(`
 	    {
 			"whitelist": ["4","5","6"],
+			"blacklist": ["7"],
 			"enabled": true
 		}`))
 
@@ -194,13 +195,14 @@ func TestUpdateAssetFilterConfig(t *testing.T) {
 	raw, err := ioutil.ReadAll(resp.Body)
 	tt.Assert.NoError(err)
 
-	var filterCfgResource hProtocol.AssetFilterConfig
+	var filterCfgResource resource.AssetFilterConfig
 	json.Unmarshal(raw, &filterCfgResource)
 	tt.Assert.NoError(err)
 
 	tt.Assert.Equal(*filterCfgResource.Enabled, true)
 	tt.Assert.True(filterCfgResource.LastModified > 0)
 	tt.Assert.ElementsMatch(filterCfgResource.Whitelist, []string{"4", "5", "6"})
+	tt.Assert.ElementsMatch(filterCfgResource.Blacklist, []string{"7"})
 }
 
 func TestUpdateAccountFilterConfig(t *testing.T) {
@@ -222,6 +224,7 @@ func TestUpdateAccountFilterConfig(t *testing.T) {
 	request.Body = ioutil.NopCloser(strings.NewReader(`
 	    {
 			"whitelist": ["4","5","6"],
+			"blacklist": ["7"],
 			"enabled": true
 		}`))
 
@@ -236,11 +239,202 @@ func TestUpdateAccountFilterConfig(t *testing.T) {
 	raw, err := ioutil.ReadAll(resp.Body)
 	tt.Assert.NoError(err)
 
-	var filterCfgResource hProtocol.AccountFilterConfig
+	var filterCfgResource resource.AccountFilterConfig
 	json.Unmarshal(raw, &filterCfgResource)
 	tt.Assert.NoError(err)
 
 	tt.Assert.Equal(*filterCfgResource.Enabled, true)
 	tt.Assert.True(filterCfgResource.LastModified > 0)
 	tt.Assert.ElementsMatch(filterCfgResource.Whitelist, []string{"4", "5", "6"})
+	tt.Assert.ElementsMatch(filterCfgResource.Blacklist, []string{"7"})
+}
+
+func TestGetContractFilterConfig(t *testing.T) {
+	tt := test.Start(t)
+	defer tt.Finish()
+	test.ResetHorizonDB(t, tt.HorizonDB)
+
+	q := &history.Q{SessionInterface: tt.HorizonSession()}
+
+	contractID := "CA3D5KRYM6CB7OWQ6TWYRR3Z4T7GNZLKERYNZGGA5SOAOPIFY6YQGAXE"
+	fc1 := history.ContractFilterConfig{
+		Whitelist: []string{contractID},
+		Enabled:   true,
+	}
+
+	_, err := q.UpdateContractFilterConfig(tt.Ctx, fc1)
+	tt.Assert.NoError(err)
+
+	handler := &FilterConfigHandler{}
+	recorder := httptest.NewRecorder()
+	handler.GetContractConfig(
+		recorder,
+		makeRequest(
+			t,
+			map[string]string{},
+			map[string]string{},
+			q,
+		),
+	)
+
+	resp := recorder.Result()
+	tt.Assert.Equal(http.StatusOK, resp.StatusCode)
+
+	raw, err := ioutil.ReadAll(resp.Body)
+	tt.Assert.NoError(err)
+
+	var filterCfgResource resource.ContractFilterConfig
+	tt.Assert.NoError(json.Unmarshal(raw, &filterCfgResource))
+
+	tt.Assert.ElementsMatch(filterCfgResource.Whitelist, []string{contractID})
+	tt.Assert.Equal(*filterCfgResource.Enabled, true)
+	tt.Assert.True(filterCfgResource.LastModified > 0)
+}
+
+func TestInvalidUpdateContractFilterConfig(t *testing.T) {
+	tt := test.Start(t)
+	defer tt.Finish()
+	test.ResetHorizonDB(t, tt.HorizonDB)
+
+	q := &history.Q{SessionInterface: tt.HorizonSession()}
+
+	handler := &FilterConfigHandler{}
+	recorder := httptest.NewRecorder()
+	request := makeRequest(
+		t,
+		map[string]string{},
+		map[string]string{},
+		q,
+	)
+
+	request.Body = ioutil.NopCloser(strings.NewReader(`
+	    {
+			"whitelist": ["GD6WNNTW664WH7FXC5RUMUTF7P5QSURC2IT36VOQEEGFZ4UWUEQGECAL"],
+			"enabled": true
+		}`))
+
+	handler.UpdateContractConfig(
+		recorder,
+		request,
+	)
+
+	resp := recorder.Result()
+	// only contract addresses can be whitelisted
+	tt.Assert.Equal(http.StatusBadRequest, resp.StatusCode)
+}
+
+func TestUpdateOperationTypeFilterConfig(t *testing.T) {
+	tt := test.Start(t)
+	defer tt.Finish()
+	test.ResetHorizonDB(t, tt.HorizonDB)
+
+	q := &history.Q{SessionInterface: tt.HorizonSession()}
+
+	handler := &FilterConfigHandler{}
+	recorder := httptest.NewRecorder()
+	request := makeRequest(
+		t,
+		map[string]string{},
+		map[string]string{},
+		q,
+	)
+
+	request.Body = ioutil.NopCloser(strings.NewReader(`
+	    {
+			"whitelist": ["payment", "invoke_host_function"],
+			"memo_whitelist": ["deposit"],
+			"enabled": true
+		}`))
+
+	handler.UpdateOperationTypeConfig(
+		recorder,
+		request,
+	)
+
+	resp := recorder.Result()
+	tt.Assert.Equal(http.StatusOK, resp.StatusCode)
+
+	raw, err := ioutil.ReadAll(resp.Body)
+	tt.Assert.NoError(err)
+
+	var filterCfgResource resource.OperationTypeFilterConfig
+	tt.Assert.NoError(json.Unmarshal(raw, &filterCfgResource))
+
+	tt.Assert.Equal(*filterCfgResource.Enabled, true)
+	tt.Assert.True(filterCfgResource.LastModified > 0)
+	tt.Assert.ElementsMatch(filterCfgResource.Whitelist, []string{"payment", "invoke_host_function"})
+	tt.Assert.ElementsMatch(filterCfgResource.MemoWhitelist, []string{"deposit"})
+}
+
+func TestInvalidUpdateOperationTypeFilterConfig(t *testing.T) {
+	tt := test.Start(t)
+	defer tt.Finish()
+	test.ResetHorizonDB(t, tt.HorizonDB)
+
+	q := &history.Q{SessionInterface: tt.HorizonSession()}
+
+	handler := &FilterConfigHandler{}
+	recorder := httptest.NewRecorder()
+	request := makeRequest(
+		t,
+		map[string]string{},
+		map[string]string{},
+		q,
+	)
+
+	request.Body = ioutil.NopCloser(strings.NewReader(`
+	    {
+			"whitelist": ["not_an_operation"],
+			"enabled": true
+		}`))
+
+	handler.UpdateOperationTypeConfig(
+		recorder,
+		request,
+	)
+
+	resp := recorder.Result()
+	tt.Assert.Equal(http.StatusBadRequest, resp.StatusCode)
+}
+
+func TestGetAssetFilterConfigAppliedFromLedger(t *testing.T) {
+	tt := test.Start(t)
+	defer tt.Finish()
+	test.ResetHorizonDB(t, tt.HorizonDB)
+
+	q := &history.Q{SessionInterface: tt.HorizonSession()}
+
+	config, err := q.UpdateAssetFilterConfig(tt.Ctx, history.AssetFilterConfig{
+		Whitelist: []string{"1"},
+		Enabled:   true,
+	})
+	tt.Assert.NoError(err)
+
+	getConfig := func() resource.AssetFilterConfig {
+		handler := &FilterConfigHandler{}
+		recorder := httptest.NewRecorder()
+		handler.GetAssetConfig(
+			recorder,
+			makeRequest(t, map[string]string{}, map[string]string{}, q),
+		)
+		resp := recorder.Result()
+		tt.Assert.Equal(http.StatusOK, resp.StatusCode)
+
+		var filterCfgResource resource.AssetFilterConfig
+		tt.Assert.NoError(json.NewDecoder(resp.Body).Decode(&filterCfgResource))
+		return filterCfgResource
+	}
+
+	// a previous version of the rules was applied
+	tt.Assert.NoError(q.UpdateFilterRulesAppliedFrom(tt.Ctx, history.AssetFilterName, history.FilterRulesAppliedFrom{
+		LastModified: config.LastModified - 1,
+		Ledger:       10,
+	}))
+	tt.Assert.Equal(uint32(0), getConfig().AppliedFromLedger)
+
+	tt.Assert.NoError(q.UpdateFilterRulesAppliedFrom(tt.Ctx, history.AssetFilterName, history.FilterRulesAppliedFrom{
+		LastModified: config.LastModified,
+		Ledger:       20,
+	}))
+	tt.Assert.Equal(uint32(20), getConfig().AppliedFromLedger)
 }
@@ -16,6 +16,11 @@ type GetLedgersHandler struct {
 	LedgerState *ledger.State
 }
 
+// CSVColumns returns the columns of ledgers rendered as CSV.
+func (handler GetLedgersHandler) CSVColumns() []string {
+	return resourceadapter.LedgerCSVColumns
+}
+
 func (handler GetLedgersHandler) GetResourcePage(w HeaderWriter, r *http.Request) ([]hal.Pageable, error) {
 	pq, err := GetPageQuery(handler.LedgerState, r)
 	if err != nil {
@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"net/http"
 	"strings"
+	gTime "time"
 
 	protocol "github.com/stellar/go-stellar-sdk/protocols/horizon"
 	"github.com/stellar/go-stellar-sdk/support/errors"
@@ -12,8 +13,10 @@ import (
 	"github.com/stellar/go-stellar-sdk/support/render/problem"
 	"github.com/stellar/go-stellar-sdk/xdr"
 	horizonContext "github.com/stellar/stellar-horizon/internal/context"
+	"github.com/stellar/stellar-horizon/internal/db2"
 	"github.com/stellar/stellar-horizon/internal/db2/history"
 	"github.com/stellar/stellar-horizon/internal/ledger"
+	"github.com/stellar/stellar-horizon/internal/resource"
 	"github.com/stellar/stellar-horizon/internal/resourceadapter"
 )
 
@@ -182,3 +185,101 @@ func (handler GetLiquidityPoolsHandler) getLiquidityPoolsPage(ctx context.Contex
 
 	return liquidityPools, nil
 }
+
+// LiquidityPoolHistoryQuery query struct for liquidity_pools/id/history
+// end-point. Without a resolution the history has a snapshot for every ledger
+// in which the pool changed.
+type LiquidityPoolHistoryQuery struct {
+	ID         string `schema:"liquidity_pool_id" valid:"sha256"`
+	Resolution uint64 `schema:"resolution" valid:"-"`
+}
+
+// Validate runs validations on LiquidityPoolHistoryQuery
+func (q LiquidityPoolHistoryQuery) Validate() error {
+	return validateSnapshotResolution(q.Resolution)
+}
+
+// validateSnapshotResolution validates the optional resolution of the buckets
+// of the history end-points, which are the trade aggregation resolutions.
+func validateSnapshotResolution(resolution uint64) error {
+	if resolution == 0 {
+		return nil
+	}
+	if _, ok := history.AllowedResolutions[gTime.Duration(resolution)*gTime.Millisecond]; !ok {
+		return problem.MakeInvalidFieldProblem(
+			"resolution",
+			errors.New("illegal resolution. "+
+				"allowed resolutions are: 1 minute (60000), 5 minutes (300000), 15 minutes (900000), 1 hour (3600000), "+
+				"1 day (86400000) and 1 week (604800000)"),
+		)
+	}
+	return nil
+}
+
+// GetLiquidityPoolHistoryHandler is the action handler for the history of a
+// liquidity pool.
+type GetLiquidityPoolHistoryHandler struct {
+	LedgerState *ledger.State
+}
+
+// GetResourcePage returns a page of liquidity pool snapshots.
+func (handler GetLiquidityPoolHistoryHandler) GetResourcePage(w HeaderWriter, r *http.Request) ([]hal.Pageable, error) {
+	ctx := r.Context()
+	qp := LiquidityPoolHistoryQuery{}
+	err := getParams(&qp, r)
+	if err != nil {
+		return nil, err
+	}
+
+	// bucket cursors are timestamps, not toids
+	var pq db2.PageQuery
+	if qp.Resolution > 0 {
+		pq, err = GetPageQuery(handler.LedgerState, r, DisableCursorValidation)
+	} else {
+		pq, err = GetPageQuery(handler.LedgerState, r)
+		if err == nil {
+			err = validateAndAdjustCursor(handler.LedgerState, &pq)
+		}
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	historyQ, err := horizonContext.HistoryQFromRequest(r)
+	if err != nil {
+		return nil, err
+	}
+	pool, err := historyQ.FindLiquidityPoolByID(ctx, qp.ID)
+	if err != nil {
+		return nil, err
+	}
+	if len(pool.AssetReserves) != 2 {
+		return nil, errors.Errorf("liquidity pool %s has %d reserves", qp.ID, len(pool.AssetReserves))
+	}
+	assets := [2]string{
+		pool.AssetReserves[0].Asset.StringCanonical(),
+		pool.AssetReserves[1].Asset.StringCanonical(),
+	}
+
+	var records []history.LiquidityPoolSnapshot
+	if qp.Resolution > 0 {
+		records, err = historyQ.LiquidityPoolSnapshotBuckets(ctx, qp.ID, int64(qp.Resolution), pq)
+	} else {
+		records, err = historyQ.LiquidityPoolSnapshots(ctx, qp.ID, pq)
+	}
+	if err != nil {
+		return nil, errors.Wrap(err, "loading liquidity pool snapshots")
+	}
+
+	var result []hal.Pageable
+	for _, record := range records {
+		var res resource.LiquidityPoolSnapshot
+		err = resourceadapter.PopulateLiquidityPoolSnapshot(ctx, &res, qp.ID, assets, record, int64(qp.Resolution))
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, res)
+	}
+
+	return result, nil
+}
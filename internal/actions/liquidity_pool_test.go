@@ -5,13 +5,17 @@ import (
 	"net/http/httptest"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/stellar/go-stellar-sdk/keypair"
 	protocol "github.com/stellar/go-stellar-sdk/protocols/horizon"
 	"github.com/stellar/go-stellar-sdk/support/errors"
 	"github.com/stellar/go-stellar-sdk/support/render/problem"
+	"github.com/stellar/go-stellar-sdk/toid"
 	"github.com/stellar/go-stellar-sdk/xdr"
 	"github.com/stellar/stellar-horizon/internal/db2/history"
+	"github.com/stellar/stellar-horizon/internal/ledger"
+	"github.com/stellar/stellar-horizon/internal/resource"
 	"github.com/stellar/stellar-horizon/internal/test"
 	"github.com/stretchr/testify/assert"
 )
@@ -198,3 +202,86 @@ func TestGetLiquidityPools(t *testing.T) {
 		assert.Equal(t, lp1.PoolID, resource.ID)
 	})
 }
+
+func TestLiquidityPoolHistoryQueryValidate(t *testing.T) {
+	id := "cafebabecafebabecafebabecafebabecafebabecafebabecafebabecafebabe"
+	assert.NoError(t, LiquidityPoolHistoryQuery{ID: id}.Validate())
+	assert.NoError(t, LiquidityPoolHistoryQuery{ID: id, Resolution: 3600000}.Validate())
+
+	err := LiquidityPoolHistoryQuery{ID: id, Resolution: 1000}.Validate()
+	p, ok := err.(*problem.P)
+	assert.True(t, ok, "expected *problem.P, got %T", err)
+	assert.Equal(t, "resolution", p.Extras["invalid_field"])
+}
+
+func TestGetLiquidityPoolHistory(t *testing.T) {
+	tt := test.Start(t)
+	defer tt.Finish()
+	test.ResetHorizonDB(t, tt.HorizonDB)
+	q := &history.Q{tt.HorizonSession()}
+
+	lp := history.MakeTestPool(nativeAsset, 100, usdAsset, 200)
+	tt.Assert.NoError(q.UpsertLiquidityPools(tt.Ctx, []history.LiquidityPool{lp}))
+
+	lpLoader := history.NewLiquidityPoolLoader(history.ConcurrentInserts)
+	closedAt := time.Date(2023, 3, 1, 10, 0, 0, 0, time.UTC)
+	tt.Assert.NoError(q.Begin(tt.Ctx))
+	builder := q.NewLiquidityPoolSnapshotBatchInsertBuilder()
+	for i := 0; i < 3; i++ {
+		tt.Assert.NoError(builder.Add(history.InsertLiquidityPoolSnapshot{
+			LiquidityPoolID: lpLoader.GetFuture(lp.PoolID),
+			LedgerID:        toid.New(int32(10+i), 0, 0).ToInt64(),
+			ClosedAt:        closedAt.Add(time.Duration(i) * time.Minute),
+			Fee:             30,
+			ReserveA:        100,
+			ReserveB:        200,
+			TotalShares:     6789,
+			TrustlineCount:  2,
+			TradeCount:      1,
+			VolumeA:         10,
+		}))
+	}
+	tt.Assert.NoError(lpLoader.Exec(tt.Ctx, q))
+	tt.Assert.NoError(builder.Exec(tt.Ctx, q))
+	tt.Assert.NoError(q.Commit())
+
+	handler := GetLiquidityPoolHistoryHandler{LedgerState: &ledger.State{}}
+	response, err := handler.GetResourcePage(httptest.NewRecorder(), makeRequest(
+		t,
+		map[string]string{"limit": "2"},
+		map[string]string{"liquidity_pool_id": lp.PoolID},
+		q,
+	))
+	tt.Assert.NoError(err)
+	tt.Assert.Len(response, 2)
+	snapshot := response[0].(resource.LiquidityPoolSnapshot)
+	tt.Assert.Equal(int32(10), snapshot.Ledger)
+	tt.Assert.Equal("native", snapshot.Reserves[0].Asset)
+	tt.Assert.Equal("0.0000010", snapshot.Reserves[0].Volume)
+	tt.Assert.Equal(usdAsset.StringCanonical(), snapshot.Reserves[1].Asset)
+	tt.Assert.Empty(snapshot.FeeAPR)
+
+	response, err = handler.GetResourcePage(httptest.NewRecorder(), makeRequest(
+		t,
+		map[string]string{"resolution": "3600000"},
+		map[string]string{"liquidity_pool_id": lp.PoolID},
+		q,
+	))
+	tt.Assert.NoError(err)
+	tt.Assert.Len(response, 1)
+	snapshot = response[0].(resource.LiquidityPoolSnapshot)
+	tt.Assert.Equal(closedAt.UnixMilli(), snapshot.Timestamp)
+	tt.Assert.Equal(int32(12), snapshot.Ledger)
+	tt.Assert.Equal(int64(3), snapshot.TradeCount)
+	tt.Assert.Equal("0.0000030", snapshot.Reserves[0].Volume)
+	tt.Assert.NotEmpty(snapshot.FeeAPR)
+
+	// the history of a pool which does not exist
+	_, err = handler.GetResourcePage(httptest.NewRecorder(), makeRequest(
+		t,
+		map[string]string{},
+		map[string]string{"liquidity_pool_id": "cafebabecafebabecafebabecafebabecafebabecafebabecafebabecafebabe"},
+		q,
+	))
+	tt.Assert.True(q.NoRows(errors.Cause(err)))
+}
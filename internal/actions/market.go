@@ -0,0 +1,204 @@
+package actions
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/stellar/go-stellar-sdk/support/errors"
+	"github.com/stellar/go-stellar-sdk/support/log"
+	"github.com/stellar/go-stellar-sdk/support/render/hal"
+	"github.com/stellar/go-stellar-sdk/support/render/problem"
+	strtime "github.com/stellar/go-stellar-sdk/support/time"
+	"github.com/stellar/go-stellar-sdk/xdr"
+	horizonContext "github.com/stellar/stellar-horizon/internal/context"
+	"github.com/stellar/stellar-horizon/internal/db2/history"
+	"github.com/stellar/stellar-horizon/internal/ledger"
+	"github.com/stellar/stellar-horizon/internal/paths"
+	"github.com/stellar/stellar-horizon/internal/resource"
+	"github.com/stellar/stellar-horizon/internal/resourceadapter"
+)
+
+// marketSummaryPeriod is the period summarized by the market end-points
+const marketSummaryPeriod = 24 * time.Hour
+
+// marketSince returns the start of the period summarized by the market
+// end-points, rounded down to the 1 minute trade aggregation buckets.
+func marketSince(ledgerState *ledger.State) strtime.Millis {
+	closedAt := ledgerState.CurrentStatus().HistoryLatestClosedAt
+	if closedAt.IsZero() {
+		closedAt = time.Now()
+	}
+	return strtime.MillisFromTime(closedAt.Add(-marketSummaryPeriod)).RoundDown(60000)
+}
+
+// marketQuotes returns the top of the book of the given markets, or nil when
+// the order book is not available.
+func marketQuotes(ctx context.Context, finder paths.QuoteFinder, markets []paths.Market) []paths.Quote {
+	if finder == nil || len(markets) == 0 {
+		return nil
+	}
+	quotes, err := finder.Quotes(ctx, markets)
+	if err != nil {
+		log.Ctx(ctx).WithError(err).Warn("could not find market quotes")
+		return nil
+	}
+	return quotes
+}
+
+// GetMarketsHandler is the action handler for the /markets end-point, which
+// lists the markets with trades in the last 24 hours.
+type GetMarketsHandler struct {
+	LedgerState *ledger.State
+	QuoteFinder paths.QuoteFinder
+}
+
+// GetResourcePage returns a page of markets.
+func (handler GetMarketsHandler) GetResourcePage(w HeaderWriter, r *http.Request) ([]hal.Pageable, error) {
+	ctx := r.Context()
+	pq, err := GetPageQuery(handler.LedgerState, r, DisableCursorValidation)
+	if err != nil {
+		return nil, err
+	}
+
+	historyQ, err := horizonContext.HistoryQFromRequest(r)
+	if err != nil {
+		return nil, err
+	}
+	since := marketSince(handler.LedgerState)
+	summaries, err := historyQ.MarketSummaries(ctx, since, pq)
+	if err != nil {
+		return nil, errors.Wrap(err, "loading market summaries")
+	}
+
+	markets := make([]paths.Market, len(summaries))
+	for i, summary := range summaries {
+		if markets[i].Base, err = xdr.BuildAsset(
+			summary.BaseAssetType, summary.BaseAssetIssuer, summary.BaseAssetCode,
+		); err != nil {
+			return nil, errors.Wrap(err, "invalid base asset")
+		}
+		if markets[i].Counter, err = xdr.BuildAsset(
+			summary.CounterAssetType, summary.CounterAssetIssuer, summary.CounterAssetCode,
+		); err != nil {
+			return nil, errors.Wrap(err, "invalid counter asset")
+		}
+	}
+	quotes := marketQuotes(ctx, handler.QuoteFinder, markets)
+
+	var result []hal.Pageable
+	for i := range summaries {
+		var quote *paths.Quote
+		if quotes != nil {
+			quote = &quotes[i]
+		}
+		var res resource.Market
+		err = resourceadapter.PopulateMarket(ctx, &res, markets[i].Base, markets[i].Counter, &summaries[i], quote, since.ToTime())
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, res)
+	}
+
+	return result, nil
+}
+
+// MarketQuery query struct for the /markets/{base_asset}/{counter_asset}
+// end-point, assets are in canonical form.
+type MarketQuery struct {
+	BaseAsset    string `schema:"base_asset" valid:"asset"`
+	CounterAsset string `schema:"counter_asset" valid:"asset"`
+}
+
+// Validate runs custom validations on MarketQuery
+func (q MarketQuery) Validate() error {
+	if q.BaseAsset == q.CounterAsset {
+		return problem.MakeInvalidFieldProblem(
+			"counter_asset",
+			errors.New("base and counter assets must be different"),
+		)
+	}
+	return nil
+}
+
+// Assets returns the base and counter assets of the market
+func (q MarketQuery) Assets() (xdr.Asset, xdr.Asset, error) {
+	assets, err := xdr.BuildAssets(q.BaseAsset + "," + q.CounterAsset)
+	if err != nil {
+		return xdr.Asset{}, xdr.Asset{}, err
+	}
+	return assets[0], assets[1], nil
+}
+
+// loadMarketSummary returns the summary of the trades of a market since the
+// given time, or nil when there were none. Assets which were never traded
+// have no id, their markets have no trades but may still have offers.
+func loadMarketSummary(
+	ctx context.Context,
+	historyQ *history.Q,
+	base, counter xdr.Asset,
+	since strtime.Millis,
+) (*history.MarketSummary, error) {
+	baseAssetID, err := historyQ.GetAssetID(ctx, base)
+	if historyQ.NoRows(err) {
+		return nil, nil
+	} else if err != nil {
+		return nil, errors.Wrap(err, "loading base asset id")
+	}
+	counterAssetID, err := historyQ.GetAssetID(ctx, counter)
+	if historyQ.NoRows(err) {
+		return nil, nil
+	} else if err != nil {
+		return nil, errors.Wrap(err, "loading counter asset id")
+	}
+
+	summary, err := historyQ.MarketSummary(ctx, baseAssetID, counterAssetID, since)
+	if historyQ.NoRows(err) {
+		return nil, nil
+	} else if err != nil {
+		return nil, errors.Wrap(err, "loading market summary")
+	}
+	return &summary, nil
+}
+
+// GetMarketHandler is the action handler for the
+// /markets/{base_asset}/{counter_asset} end-point.
+type GetMarketHandler struct {
+	LedgerState *ledger.State
+	QuoteFinder paths.QuoteFinder
+}
+
+// GetResource returns a market.
+func (handler GetMarketHandler) GetResource(w HeaderWriter, r *http.Request) (interface{}, error) {
+	ctx := r.Context()
+	qp := MarketQuery{}
+	if err := getParams(&qp, r); err != nil {
+		return nil, err
+	}
+	base, counter, err := qp.Assets()
+	if err != nil {
+		return nil, problem.MakeInvalidFieldProblem("base_asset", err)
+	}
+
+	historyQ, err := horizonContext.HistoryQFromRequest(r)
+	if err != nil {
+		return nil, err
+	}
+	since := marketSince(handler.LedgerState)
+
+	summary, err := loadMarketSummary(ctx, historyQ, base, counter, since)
+	if err != nil {
+		return nil, err
+	}
+
+	var quote *paths.Quote
+	if quotes := marketQuotes(ctx, handler.QuoteFinder, []paths.Market{{Base: base, Counter: counter}}); quotes != nil {
+		quote = &quotes[0]
+	}
+
+	var res resource.Market
+	if err := resourceadapter.PopulateMarket(ctx, &res, base, counter, summary, quote, since.ToTime()); err != nil {
+		return nil, err
+	}
+	return res, nil
+}
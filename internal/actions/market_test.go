@@ -0,0 +1,139 @@
+package actions
+
+import (
+	"math/big"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"github.com/stellar/go-stellar-sdk/support/render/problem"
+	strtime "github.com/stellar/go-stellar-sdk/support/time"
+	"github.com/stellar/go-stellar-sdk/toid"
+	"github.com/stellar/go-stellar-sdk/xdr"
+	"github.com/stellar/stellar-horizon/internal/db2/history"
+	"github.com/stellar/stellar-horizon/internal/ledger"
+	"github.com/stellar/stellar-horizon/internal/paths"
+	"github.com/stellar/stellar-horizon/internal/resource"
+	"github.com/stellar/stellar-horizon/internal/simplepath"
+	"github.com/stellar/stellar-horizon/internal/test"
+)
+
+func TestMarketQueryValidate(t *testing.T) {
+	for _, tc := range []struct {
+		name    string
+		query   MarketQuery
+		wantErr bool
+	}{
+		{name: "credit counter", query: MarketQuery{BaseAsset: "native", CounterAsset: usdAsset.StringCanonical()}},
+		{name: "credit base", query: MarketQuery{BaseAsset: usdAsset.StringCanonical(), CounterAsset: eurAsset.StringCanonical()}},
+		{name: "same assets", query: MarketQuery{BaseAsset: "native", CounterAsset: "native"}, wantErr: true},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			err := tc.query.Validate()
+			if tc.wantErr {
+				assert.IsType(t, &problem.P{}, err)
+				return
+			}
+			assert.NoError(t, err)
+			base, counter, err := tc.query.Assets()
+			assert.NoError(t, err)
+			assert.Equal(t, tc.query.BaseAsset, base.StringCanonical())
+			assert.Equal(t, tc.query.CounterAsset, counter.StringCanonical())
+		})
+	}
+}
+
+func TestGetMarkets(t *testing.T) {
+	tt := test.Start(t)
+	defer tt.Finish()
+	test.ResetHorizonDB(t, tt.HorizonDB)
+	q := &history.Q{tt.HorizonSession()}
+
+	assets, err := q.CreateAssets(tt.Ctx, []xdr.Asset{nativeAsset, usdAsset}, 2)
+	tt.Assert.NoError(err)
+	nativeID, usdID := assets[nativeAsset.String()].ID, assets[usdAsset.String()].ID
+	baseAssetID, counterAssetID := nativeID, usdID
+	baseAmount, counterAmount := int64(100), int64(250)
+	if baseAssetID > counterAssetID {
+		baseAssetID, counterAssetID = counterAssetID, baseAssetID
+		baseAmount, counterAmount = counterAmount, baseAmount
+	}
+
+	closedAt := time.Now().UTC().Add(-time.Hour)
+	tt.Assert.NoError(q.Begin(tt.Ctx))
+	builder := q.NewTradeBatchInsertBuilder()
+	tt.Assert.NoError(builder.Add(history.InsertTrade{
+		HistoryOperationID: toid.New(100, 1, 1).ToInt64(),
+		Order:              1,
+		LedgerCloseTime:    closedAt,
+		BaseAssetID:        baseAssetID,
+		CounterAssetID:     counterAssetID,
+		BaseIsSeller:       true,
+		BaseAmount:         baseAmount,
+		CounterAmount:      counterAmount,
+		PriceN:             counterAmount,
+		PriceD:             baseAmount,
+		Type:               history.OrderbookTradeType,
+	}))
+	tt.Assert.NoError(builder.Exec(tt.Ctx, q))
+	closedAtMillis := strtime.MillisFromTime(closedAt)
+	tt.Assert.NoError(q.RebuildTradeAggregationTimes(tt.Ctx, closedAtMillis, closedAtMillis, 1000))
+	tt.Assert.NoError(q.Commit())
+
+	market := paths.Market{Base: nativeAsset, Counter: usdAsset}
+	finder := &paths.MockFinder{}
+	finder.On("Quotes", mock.Anything, []paths.Market{market}).
+		Return([]paths.Quote{{Bid: big.NewRat(12, 5), Ask: nil}}, nil)
+
+	ledgerState := &ledger.State{}
+	ledgerState.SetHorizonStatus(ledger.HorizonStatus{HistoryLatestClosedAt: time.Now()})
+
+	marketHandler := GetMarketHandler{LedgerState: ledgerState, QuoteFinder: finder}
+	response, err := marketHandler.GetResource(httptest.NewRecorder(), makeRequest(
+		t,
+		map[string]string{},
+		map[string]string{"base_asset": "native", "counter_asset": usdAsset.StringCanonical()},
+		q,
+	))
+	tt.Assert.NoError(err)
+	result := response.(resource.Market)
+	tt.Assert.Equal(int64(1), result.TradeCount)
+	tt.Assert.Equal("0.0000100", result.BaseVolume)
+	tt.Assert.Equal("0.0000250", result.CounterVolume)
+	tt.Assert.Equal("2.5000000", result.LastPrice)
+	tt.Assert.Equal("2.4000000", result.Bid)
+	tt.Assert.Empty(result.Ask)
+
+	// a market which was never traded
+	finder.On("Quotes", mock.Anything, mock.Anything).Return([]paths.Quote(nil), simplepath.ErrEmptyInMemoryOrderBook)
+	response, err = marketHandler.GetResource(httptest.NewRecorder(), makeRequest(
+		t,
+		map[string]string{},
+		map[string]string{"base_asset": "native", "counter_asset": eurAsset.StringCanonical()},
+		q,
+	))
+	tt.Assert.NoError(err)
+	result = response.(resource.Market)
+	tt.Assert.Zero(result.TradeCount)
+	tt.Assert.Empty(result.LastPrice)
+	tt.Assert.Empty(result.Bid)
+
+	marketsHandler := GetMarketsHandler{LedgerState: ledgerState}
+	records, err := marketsHandler.GetResourcePage(httptest.NewRecorder(), makeRequest(
+		t, map[string]string{}, map[string]string{}, q,
+	))
+	tt.Assert.NoError(err)
+	tt.Assert.Len(records, 1)
+	result = records[0].(resource.Market)
+	tt.Assert.Equal(int64(1), result.TradeCount)
+	tt.Assert.Empty(result.Bid)
+
+	records, err = marketsHandler.GetResourcePage(httptest.NewRecorder(), makeRequest(
+		t, map[string]string{"cursor": result.PagingToken()}, map[string]string{}, q,
+	))
+	tt.Assert.NoError(err)
+	tt.Assert.Empty(records)
+}
@@ -32,6 +32,7 @@ type OperationsQuery struct {
 	AccountID                 string `schema:"account_id" valid:"accountID,optional"`
 	ClaimableBalanceID        string `schema:"claimable_balance_id" valid:"claimableBalanceID,optional"`
 	LiquidityPoolID           string `schema:"liquidity_pool_id" valid:"sha256,optional"`
+	ContractID                string `schema:"contract_id" valid:"contractID,optional"`
 	TransactionHash           string `schema:"tx_id" valid:"transactionHash,optional"`
 	IncludeFailedTransactions bool   `schema:"include_failed" valid:"-"`
 	LedgerID                  uint32 `schema:"ledger_id" valid:"-"`
@@ -43,6 +44,7 @@ func (qp OperationsQuery) Validate() error {
 		qp.AccountID,
 		qp.ClaimableBalanceID,
 		qp.LiquidityPoolID,
+		qp.ContractID,
 		qp.LedgerID,
 		qp.TransactionHash,
 	)
@@ -68,6 +70,11 @@ type GetOperationsHandler struct {
 	SkipTxMeta   bool
 }
 
+// CSVColumns returns the columns of operations rendered as CSV.
+func (handler GetOperationsHandler) CSVColumns() []string {
+	return resourceadapter.OperationCSVColumns
+}
+
 // GetResourcePage returns a page of operations.
 func (handler GetOperationsHandler) GetResourcePage(w HeaderWriter, r *http.Request) ([]hal.Pageable, error) {
 	ctx := r.Context()
@@ -102,6 +109,8 @@ func (handler GetOperationsHandler) GetResourcePage(w HeaderWriter, r *http.Requ
 		query.ForClaimableBalance(ctx, qp.ClaimableBalanceID)
 	case qp.LiquidityPoolID != "":
 		query.ForLiquidityPool(ctx, qp.LiquidityPoolID)
+	case qp.ContractID != "":
+		query.ForContract(ctx, qp.ContractID)
 	case qp.LedgerID > 0:
 		query.ForLedger(ctx, int32(qp.LedgerID))
 	case qp.TransactionHash != "":
@@ -0,0 +1,96 @@
+package actions
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/stellar/go-stellar-sdk/protocols/horizon"
+	"github.com/stellar/go-stellar-sdk/support/errors"
+	"github.com/stellar/go-stellar-sdk/support/render/problem"
+	"github.com/stellar/go-stellar-sdk/xdr"
+	horizonContext "github.com/stellar/stellar-horizon/internal/context"
+	"github.com/stellar/stellar-horizon/internal/resource"
+	"github.com/stellar/stellar-horizon/internal/txsim"
+)
+
+// SimulateTransactionHandler is the action handler for the
+// /transactions/simulate endpoint, which predicts the result of a classic
+// transaction from the ingested state without submitting it to Stellar Core.
+type SimulateTransactionHandler struct {
+	NetworkPassphrase string
+	DecodeOptions     xdr.DecodeOptions // Decoded output size limit for XDR unmarshaling of user-supplied input
+}
+
+// GetResource implements ObjectActionHandler.
+func (handler SimulateTransactionHandler) GetResource(_ HeaderWriter, r *http.Request) (interface{}, error) {
+	if err := validateBodyType(r); err != nil {
+		return nil, err
+	}
+
+	raw, err := getString(r, "tx")
+	if err != nil {
+		return nil, err
+	}
+
+	info, err := extractEnvelopeInfo(raw, handler.NetworkPassphrase, handler.DecodeOptions)
+	if err != nil {
+		p := transactionMalformedProblem(raw)
+		p.Extras["error"] = err
+		return nil, p
+	}
+
+	ctx := r.Context()
+	historyQ, err := horizonContext.HistoryQFromRequest(r)
+	if err != nil {
+		return nil, err
+	}
+	latest, err := historyQ.GetLastLedgerIngestNonBlocking(ctx)
+	if err != nil {
+		return nil, errors.Wrap(err, "loading last ingested ledger")
+	}
+	ledger, err := getLedgerBySequence(ctx, historyQ, int32(latest))
+	if err != nil {
+		return nil, errors.Wrap(err, "loading last ingested ledger")
+	}
+	if ledger == nil {
+		return nil, problem.NotFound
+	}
+
+	result, err := txsim.Simulate(ctx, historyQ, txsim.Ledger{
+		Sequence:    uint32(ledger.Sequence),
+		CloseTime:   ledger.ClosedAt,
+		BaseFee:     int64(ledger.BaseFee),
+		BaseReserve: int64(ledger.BaseReserve),
+	}, handler.NetworkPassphrase, info.parsed)
+	if err == txsim.ErrSorobanNotSupported {
+		return nil, &problem.P{
+			Type:   "transaction_simulation_not_supported",
+			Title:  "Transaction Simulation Not Supported",
+			Status: http.StatusBadRequest,
+			Detail: "Transactions with Soroban operations can't be simulated by " +
+				"Horizon. Use the simulateTransaction method of Stellar RPC instead.",
+			Extras: map[string]interface{}{
+				"envelope_xdr": raw,
+			},
+		}
+	} else if err != nil {
+		return nil, err
+	}
+
+	unsimulated := result.UncheckedOperations
+	if unsimulated == nil {
+		unsimulated = []int{}
+	}
+	return resource.TransactionSimulation{
+		Hash:       info.hash,
+		Ledger:     ledger.Sequence + 1,
+		Successful: result.Successful(),
+		FeeCharged: strconv.FormatInt(result.FeeCharged, 10),
+		ResultCodes: horizon.TransactionResultCodes{
+			TransactionCode:      result.TransactionCode,
+			InnerTransactionCode: result.InnerTransactionCode,
+			OperationCodes:       result.OperationCodes,
+		},
+		UnsimulatedOperations: unsimulated,
+	}, nil
+}
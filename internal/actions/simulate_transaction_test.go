@@ -0,0 +1,34 @@
+package actions
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/stellar/go-stellar-sdk/network"
+	"github.com/stellar/go-stellar-sdk/support/render/problem"
+)
+
+func TestSimulateTransactionHandler_MalformedTransaction(t *testing.T) {
+	handler := SimulateTransactionHandler{NetworkPassphrase: network.PublicNetworkPassphrase}
+
+	form := url.Values{}
+	form.Set("tx", "not a transaction")
+	request, err := http.NewRequest(
+		"POST",
+		"http://localhost:8000/transactions/simulate",
+		strings.NewReader(form.Encode()),
+	)
+	assert.NoError(t, err)
+	request.Header.Add("Content-Type", "application/x-www-form-urlencoded")
+
+	_, err = handler.GetResource(httptest.NewRecorder(), request)
+	assert.IsType(t, &problem.P{}, err)
+	p := err.(*problem.P)
+	assert.Equal(t, "transaction_malformed", p.Type)
+	assert.Equal(t, http.StatusBadRequest, p.Status)
+}
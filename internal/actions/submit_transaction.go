@@ -21,6 +21,7 @@ import (
 
 type NetworkSubmitter interface {
 	Submit(ctx context.Context, rawTx string, envelope xdr.TransactionEnvelope, hash string) <-chan txsub.Result
+	SubmitWithFeeBumps(ctx context.Context, rawTx string, envelope xdr.TransactionEnvelope, hash string, policy txsub.FeeBumpPolicy) <-chan txsub.Result
 }
 
 type SubmitTransactionHandler struct {
@@ -62,6 +63,24 @@ func extractEnvelopeInfo(raw string, passphrase string, decodeOptions xdr.Decode
 	return result, nil
 }
 
+// transactionMalformedProblem is the problem of a transaction envelope which
+// can't be decoded.
+func transactionMalformedProblem(raw string) *problem.P {
+	return &problem.P{
+		Type:   "transaction_malformed",
+		Title:  "Transaction Malformed",
+		Status: http.StatusBadRequest,
+		Detail: "Horizon could not decode the transaction envelope in this " +
+			"request. A transaction should be an XDR TransactionEnvelope struct " +
+			"encoded using base64.  The envelope read from this request is " +
+			"echoed in the `extras.envelope_xdr` field of this response for your " +
+			"convenience.",
+		Extras: map[string]interface{}{
+			"envelope_xdr": raw,
+		},
+	}
+}
+
 func validateBodyType(r *http.Request) error {
 	c := r.Header.Get("Content-Type")
 	if c == "" {
@@ -79,20 +98,36 @@ func validateBodyType(r *http.Request) error {
 	return nil
 }
 
-func (handler SubmitTransactionHandler) response(r *http.Request, info envelopeInfo, result txsub.Result) (hal.Pageable, error) {
+func (handler SubmitTransactionHandler) response(r *http.Request, info envelopeInfo, result txsub.Result, feeBumps bool) (hal.Pageable, error) {
 	if result.Err == nil {
+		// with fee bumps, the transaction included in the ledger can be one
+		// of the fee bump transactions
+		hash := info.hash
+		if feeBumps {
+			hash = result.Transaction.TransactionHash
+		}
 		var resource horizon.Transaction
 		err := resourceadapter.PopulateTransaction(
 			r.Context(),
-			info.hash,
+			hash,
 			&resource,
 			result.Transaction,
 			handler.SkipTxMeta,
 		)
+		if err == nil && feeBumps {
+			return transactionWithFeeBumps{resource, feeBumpAttempts(result.FeeBumpAttempts)}, nil
+		}
 		return resource, err
 	}
 
 	if result.Err == txsub.ErrTimeout {
+		extras := map[string]interface{}{
+			"hash":         info.hash,
+			"envelope_xdr": info.raw,
+		}
+		if feeBumps {
+			extras["fee_bump_attempts"] = feeBumpAttempts(result.FeeBumpAttempts)
+		}
 		return nil, &problem.P{
 			Type:   "transaction_submission_timeout",
 			Title:  "Transaction Submission Timeout",
@@ -100,10 +135,7 @@ func (handler SubmitTransactionHandler) response(r *http.Request, info envelopeI
 			Detail: "Your transaction submission request has timed out. This does not necessarily mean the submission has failed. " +
 				"Before resubmitting, please use the transaction hash provided in `extras.hash` to poll the GET /transactions endpoint for sometime and " +
 				"check if it was included in a ledger.",
-			Extras: map[string]interface{}{
-				"hash":         info.hash,
-				"envelope_xdr": info.raw,
-			},
+			Extras: extras,
 		}
 	}
 
@@ -111,6 +143,20 @@ func (handler SubmitTransactionHandler) response(r *http.Request, info envelopeI
 		return nil, &hProblem.ClientDisconnected
 	}
 
+	if result.Err == txsub.ErrFeeBumpPolicyRegistered {
+		return nil, &problem.P{
+			Type:   "fee_bump_policy_conflict",
+			Title:  "Fee Bump Policy Conflict",
+			Status: http.StatusConflict,
+			Detail: "The transaction is already pending with a fee bump policy. " +
+				"Wait for the result of the pending submission, or submit the transaction without a fee bump policy.",
+			Extras: map[string]interface{}{
+				"hash":         info.hash,
+				"envelope_xdr": info.raw,
+			},
+		}
+	}
+
 	if failedErr, ok := result.Err.(*txsub.FailedTransactionError); ok {
 		rcr := horizon.TransactionResultCodes{}
 		err := resourceadapter.PopulateTransactionResultCodes(
@@ -128,6 +174,9 @@ func (handler SubmitTransactionHandler) response(r *http.Request, info envelopeI
 			"result_xdr":   failedErr.ResultXDR,
 			"result_codes": rcr,
 		}
+		if feeBumps {
+			extras["fee_bump_attempts"] = feeBumpAttempts(result.FeeBumpAttempts)
+		}
 		if failedErr.DiagnosticEventsXDR != "" {
 			events, err := stellarcore.DiagnosticEventsToSlice(failedErr.DiagnosticEventsXDR)
 			if err != nil {
@@ -174,19 +223,12 @@ func (handler SubmitTransactionHandler) GetResource(w HeaderWriter, r *http.Requ
 
 	info, err := extractEnvelopeInfo(raw, handler.NetworkPassphrase, handler.DecodeOptions)
 	if err != nil {
-		return nil, &problem.P{
-			Type:   "transaction_malformed",
-			Title:  "Transaction Malformed",
-			Status: http.StatusBadRequest,
-			Detail: "Horizon could not decode the transaction envelope in this " +
-				"request. A transaction should be an XDR TransactionEnvelope struct " +
-				"encoded using base64.  The envelope read from this request is " +
-				"echoed in the `extras.envelope_xdr` field of this response for your " +
-				"convenience.",
-			Extras: map[string]interface{}{
-				"envelope_xdr": raw,
-			},
-		}
+		return nil, transactionMalformedProblem(raw)
+	}
+
+	feeBumpPolicy, err := handler.feeBumpPolicy(r, info)
+	if err != nil {
+		return nil, err
 	}
 
 	coreState := handler.GetCoreState()
@@ -194,11 +236,25 @@ func (handler SubmitTransactionHandler) GetResource(w HeaderWriter, r *http.Requ
 		return nil, hProblem.StaleHistory
 	}
 
-	submission := handler.Submitter.Submit(r.Context(), info.raw, info.parsed, info.hash)
+	var submission <-chan txsub.Result
+	if feeBumpPolicy != nil {
+		// the fee bump transactions have their own hashes, the submission is
+		// tracked by the hash of the inner transaction, which is matched with
+		// the inner_transaction_hash of the ingested transactions
+		submission = handler.Submitter.SubmitWithFeeBumps(r.Context(), info.raw, info.parsed, submissionInnerHash(info), *feeBumpPolicy)
+	} else {
+		submission = handler.Submitter.Submit(r.Context(), info.raw, info.parsed, info.hash)
+	}
+
+	return handler.wait(r, info, submission, feeBumpPolicy != nil)
+}
 
+// wait returns the response of a submission once its result is known, or
+// when the request is canceled or times out.
+func (handler SubmitTransactionHandler) wait(r *http.Request, info envelopeInfo, submission <-chan txsub.Result, feeBumps bool) (hal.Pageable, error) {
 	select {
 	case result := <-submission:
-		return handler.response(r, info, result)
+		return handler.response(r, info, result, feeBumps)
 	case <-r.Context().Done():
 		if r.Context().Err() == context.Canceled {
 			return nil, hProblem.ClientDisconnected
@@ -212,7 +268,7 @@ func (handler SubmitTransactionHandler) GetResource(w HeaderWriter, r *http.Requ
 				"check if it was included in a ledger.",
 			Extras: map[string]interface{}{
 				"hash":         info.hash,
-				"envelope_xdr": raw,
+				"envelope_xdr": info.raw,
 			},
 		}
 	}
@@ -3,6 +3,7 @@ package actions
 import (
 	"context"
 	"net/http"
+	"time"
 
 	"github.com/stellar/go-stellar-sdk/protocols/horizon"
 	proto "github.com/stellar/go-stellar-sdk/protocols/stellarcore"
@@ -10,7 +11,9 @@ import (
 	"github.com/stellar/go-stellar-sdk/support/log"
 	"github.com/stellar/go-stellar-sdk/support/render/problem"
 	"github.com/stellar/go-stellar-sdk/xdr"
+	"github.com/stellar/stellar-horizon/internal/db2/history"
 	hProblem "github.com/stellar/stellar-horizon/internal/render/problem"
+	"github.com/stellar/stellar-horizon/internal/txsub"
 )
 
 type coreClient interface {
@@ -23,12 +26,13 @@ type AsyncSubmitTransactionHandler struct {
 	ClientWithMetrics coreClient
 	CoreStateGetter
 	DecodeOptions xdr.DecodeOptions // Decoded output size limit for XDR unmarshaling of user-supplied input
+	// Submissions, when set, keeps track of the transactions accepted or
+	// rejected by Stellar Core for the /transactions_async/{tx_id} end-point.
+	Submissions *txsub.AsyncSubmissions
 }
 
 func (handler AsyncSubmitTransactionHandler) GetResource(_ HeaderWriter, r *http.Request) (interface{}, error) {
 	// TODO: Move the problem responses to a separate file as constants or a function.
-	logger := log.Ctx(r.Context())
-
 	if err := validateBodyType(r); err != nil {
 		return nil, err
 	}
@@ -53,20 +57,9 @@ func (handler AsyncSubmitTransactionHandler) GetResource(_ HeaderWriter, r *http
 
 	info, err := extractEnvelopeInfo(raw, handler.NetworkPassphrase, handler.DecodeOptions)
 	if err != nil {
-		return nil, &problem.P{
-			Type:   "transaction_malformed",
-			Title:  "Transaction Malformed",
-			Status: http.StatusBadRequest,
-			Detail: "Horizon could not decode the transaction envelope in this " +
-				"request. A transaction should be an XDR TransactionEnvelope struct " +
-				"encoded using base64.  The envelope read from this request is " +
-				"echoed in the `extras.envelope_xdr` field of this response for your " +
-				"convenience.",
-			Extras: map[string]interface{}{
-				"envelope_xdr": raw,
-				"error":        err,
-			},
-		}
+		p := transactionMalformedProblem(raw)
+		p.Extras["error"] = err
+		return nil, p
 	}
 
 	coreState := handler.GetCoreState()
@@ -74,9 +67,18 @@ func (handler AsyncSubmitTransactionHandler) GetResource(_ HeaderWriter, r *http
 		return nil, hProblem.StaleHistory
 	}
 
-	resp, err := handler.ClientWithMetrics.SubmitTx(r.Context(), raw)
+	return handler.submit(r.Context(), info)
+}
+
+// submit submits a transaction to Stellar Core and returns its status without
+// waiting for it to be included in a ledger.
+func (handler AsyncSubmitTransactionHandler) submit(ctx context.Context, info envelopeInfo) (horizon.AsyncTransactionSubmissionResponse, error) {
+	logger := log.Ctx(ctx)
+	raw := info.raw
+
+	resp, err := handler.ClientWithMetrics.SubmitTx(ctx, raw)
 	if err != nil {
-		return nil, &problem.P{
+		return horizon.AsyncTransactionSubmissionResponse{}, &problem.P{
 			Type:   "transaction_submission_failed",
 			Title:  "Transaction Submission Failed",
 			Status: http.StatusInternalServerError,
@@ -93,7 +95,7 @@ func (handler AsyncSubmitTransactionHandler) GetResource(_ HeaderWriter, r *http
 
 	if resp.IsException() {
 		logger.WithField("envelope_xdr", raw).WithError(errors.New(resp.Exception)).Error("Transaction submission exception from stellar-core")
-		return nil, &problem.P{
+		return horizon.AsyncTransactionSubmissionResponse{}, &problem.P{
 			Type:   "transaction_submission_exception",
 			Title:  "Transaction Submission Exception",
 			Status: http.StatusInternalServerError,
@@ -119,10 +121,21 @@ func (handler AsyncSubmitTransactionHandler) GetResource(_ HeaderWriter, r *http
 			response.ErrorResultXDR = resp.Error
 		}
 
+		if handler.Submissions != nil && resp.Status != proto.TXStatusTryAgainLater {
+			handler.Submissions.Add(txsub.AsyncSubmission{
+				Hash:           info.hash,
+				CoreStatus:     resp.Status,
+				ErrorResultXDR: response.ErrorResultXDR,
+				SubmittedAt:    time.Now(),
+				TimeBounds:     history.FormatTimeBounds(info.parsed.TimeBounds()),
+				LedgerBounds:   history.FormatLedgerBounds(info.parsed.LedgerBounds()),
+			})
+		}
+
 		return response, nil
 	default:
 		logger.WithField("envelope_xdr", raw).WithError(errors.New(resp.Error)).Error("Received invalid submission status from stellar-core")
-		return nil, &problem.P{
+		return horizon.AsyncTransactionSubmissionResponse{}, &problem.P{
 			Type:   "transaction_submission_invalid_status",
 			Title:  "Transaction Submission Invalid Status",
 			Status: http.StatusInternalServerError,
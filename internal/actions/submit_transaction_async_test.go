@@ -21,6 +21,7 @@ import (
 	"github.com/stellar/go-stellar-sdk/support/render/problem"
 	"github.com/stellar/go-stellar-sdk/xdr"
 	"github.com/stellar/stellar-horizon/internal/corestate"
+	"github.com/stellar/stellar-horizon/internal/txsub"
 )
 
 const (
@@ -219,6 +220,44 @@ func TestAsyncSubmitTransactionHandler_TransactionStatusResponse(t *testing.T) {
 	}
 }
 
+func TestAsyncSubmitTransactionHandler_TracksSubmissions(t *testing.T) {
+	coreStateGetter := new(coreStateGetterMock)
+	coreStateGetter.On("GetCoreState").Return(corestate.State{Synced: true})
+
+	for _, testCase := range []struct {
+		status  string
+		tracked bool
+	}{
+		{status: proto.TXStatusPending, tracked: true},
+		{status: proto.TXStatusDuplicate, tracked: true},
+		{status: proto.TXStatusError, tracked: true},
+		{status: proto.TXStatusTryAgainLater, tracked: false},
+	} {
+		MockClientWithMetrics := &MockClientWithMetrics{}
+		MockClientWithMetrics.On("SubmitTx", context.Background(), TxXDR).
+			Return(&proto.TXResponse{Status: testCase.status}, nil)
+
+		submissions := txsub.NewAsyncSubmissions(10)
+		handler := AsyncSubmitTransactionHandler{
+			NetworkPassphrase: network.PublicNetworkPassphrase,
+			ClientWithMetrics: MockClientWithMetrics,
+			CoreStateGetter:   coreStateGetter,
+			Submissions:       submissions,
+		}
+
+		_, err := handler.GetResource(httptest.NewRecorder(), createRequest())
+		assert.NoError(t, err)
+
+		submission, ok := submissions.Get(TxHash)
+		assert.Equal(t, testCase.tracked, ok)
+		if testCase.tracked {
+			assert.Equal(t, testCase.status, submission.CoreStatus)
+			assert.True(t, submission.TimeBounds.Null)
+			assert.True(t, submission.LedgerBounds.Null)
+		}
+	}
+}
+
 func TestAsyncSubmitTransactionHandler_XDRDecodeLimitExceeded(t *testing.T) {
 	txB64 := buildOversizedEnvelopeXDR(t)
 
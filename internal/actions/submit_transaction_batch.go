@@ -0,0 +1,195 @@
+package actions
+
+import (
+	"fmt"
+	"net/http"
+	"sync"
+
+	"github.com/stellar/go-stellar-sdk/protocols/horizon"
+	"github.com/stellar/go-stellar-sdk/support/errors"
+	"github.com/stellar/go-stellar-sdk/support/log"
+	"github.com/stellar/go-stellar-sdk/support/render/problem"
+	hProblem "github.com/stellar/stellar-horizon/internal/render/problem"
+	"github.com/stellar/stellar-horizon/internal/resource"
+)
+
+// DefaultMaxTransactionBatchSize is the default maximum number of
+// transactions of a batch submission.
+const DefaultMaxTransactionBatchSize = 100
+
+// BatchSubmitTransactionHandler submits the transactions of the repeated tx
+// parameter concurrently, like SubmitTransactionHandler, and responds with the
+// result of each of them once they are all known.
+type BatchSubmitTransactionHandler struct {
+	SubmitTransactionHandler
+	// MaxBatchSize is the maximum number of transactions of a batch.
+	MaxBatchSize int
+}
+
+// AsyncBatchSubmitTransactionHandler submits the transactions of the repeated
+// tx parameter concurrently, like AsyncSubmitTransactionHandler, and responds
+// with the status returned by Stellar Core for each of them.
+type AsyncBatchSubmitTransactionHandler struct {
+	AsyncSubmitTransactionHandler
+	// MaxBatchSize is the maximum number of transactions of a batch.
+	MaxBatchSize int
+}
+
+// getTransactionBatch returns the envelopes of the repeated tx parameter of a
+// batch submission.
+func getTransactionBatch(r *http.Request, maxBatchSize int) ([]string, error) {
+	if err := validateBodyType(r); err != nil {
+		return nil, err
+	}
+
+	// reading the first envelope parses the form
+	first, err := getString(r, "tx")
+	if err != nil {
+		return nil, err
+	}
+	if first == "" {
+		return nil, problem.MakeInvalidFieldProblem("tx", errors.New("at least one transaction must be submitted"))
+	}
+
+	raws := r.Form["tx"]
+	if maxBatchSize <= 0 {
+		maxBatchSize = DefaultMaxTransactionBatchSize
+	}
+	if len(raws) > maxBatchSize {
+		return nil, problem.MakeInvalidFieldProblem(
+			"tx",
+			errors.Errorf("at most %d transactions can be submitted in a batch", maxBatchSize),
+		)
+	}
+	for i, raw := range raws {
+		if err := checkUTF8(fmt.Sprintf("tx[%d]", i), raw); err != nil {
+			return nil, err
+		}
+	}
+	return raws, nil
+}
+
+func transactionSubmissionDisabledProblem() *problem.P {
+	return &problem.P{
+		Type:   "transaction_submission_disabled",
+		Title:  "Transaction Submission Disabled",
+		Status: http.StatusForbidden,
+		Detail: "Transaction submission has been disabled for Horizon. " +
+			"To enable it again, remove env variable DISABLE_TX_SUB.",
+		Extras: map[string]interface{}{},
+	}
+}
+
+// batchResultProblem returns the problem rendered for the error of a single
+// submission: problems are rendered as is, the registered errors as their
+// problem and the other errors as a server error, like problem.Render does.
+func batchResultProblem(r *http.Request, err error) *problem.P {
+	if knownErr := problem.IsKnownError(err); knownErr != nil {
+		err = knownErr
+	}
+	switch p := err.(type) {
+	case *problem.P:
+		return p
+	case problem.P:
+		return &p
+	default:
+		log.Ctx(r.Context()).WithStack(err).Error(err)
+		serverError := problem.ServerError
+		return &serverError
+	}
+}
+
+func (handler BatchSubmitTransactionHandler) GetResource(w HeaderWriter, r *http.Request) (interface{}, error) {
+	if handler.DisableTxSub {
+		return nil, transactionSubmissionDisabledProblem()
+	}
+
+	raws, err := getTransactionBatch(r, handler.MaxBatchSize)
+	if err != nil {
+		return nil, err
+	}
+
+	coreState := handler.GetCoreState()
+	if !coreState.Synced {
+		return nil, hProblem.StaleHistory
+	}
+
+	response := resource.TransactionBatchSubmission{
+		Results: make([]resource.TransactionBatchResult, len(raws)),
+	}
+	var wg sync.WaitGroup
+	for i, raw := range raws {
+		info, err := extractEnvelopeInfo(raw, handler.NetworkPassphrase, handler.DecodeOptions)
+		if err != nil {
+			response.Results[i].Error = transactionMalformedProblem(raw)
+			continue
+		}
+		response.Results[i].Hash = info.hash
+
+		wg.Add(1)
+		go func(result *resource.TransactionBatchResult, info envelopeInfo) {
+			defer wg.Done()
+			submission := handler.Submitter.Submit(r.Context(), info.raw, info.parsed, info.hash)
+			pageable, err := handler.wait(r, info, submission, false)
+			if err != nil {
+				result.Error = batchResultProblem(r, err)
+				return
+			}
+			transaction, ok := pageable.(horizon.Transaction)
+			if !ok {
+				result.Error = batchResultProblem(r, errors.Errorf("unexpected submission response %T", pageable))
+				return
+			}
+			result.Transaction = &transaction
+		}(&response.Results[i], info)
+	}
+	wg.Wait()
+
+	return response, nil
+}
+
+func (handler AsyncBatchSubmitTransactionHandler) GetResource(w HeaderWriter, r *http.Request) (interface{}, error) {
+	if handler.DisableTxSub {
+		return nil, transactionSubmissionDisabledProblem()
+	}
+
+	raws, err := getTransactionBatch(r, handler.MaxBatchSize)
+	if err != nil {
+		return nil, err
+	}
+
+	coreState := handler.GetCoreState()
+	if !coreState.Synced {
+		return nil, hProblem.StaleHistory
+	}
+
+	response := resource.AsyncTransactionBatchSubmission{
+		Results: make([]resource.AsyncTransactionBatchResult, len(raws)),
+	}
+	var wg sync.WaitGroup
+	for i, raw := range raws {
+		info, err := extractEnvelopeInfo(raw, handler.NetworkPassphrase, handler.DecodeOptions)
+		if err != nil {
+			p := transactionMalformedProblem(raw)
+			p.Extras["error"] = err
+			response.Results[i].Error = p
+			continue
+		}
+		response.Results[i].Hash = info.hash
+
+		wg.Add(1)
+		go func(result *resource.AsyncTransactionBatchResult, info envelopeInfo) {
+			defer wg.Done()
+			status, err := handler.submit(r.Context(), info)
+			if err != nil {
+				result.Error = batchResultProblem(r, err)
+				return
+			}
+			result.TxStatus = status.TxStatus
+			result.ErrorResultXDR = status.ErrorResultXDR
+		}(&response.Results[i], info)
+	}
+	wg.Wait()
+
+	return response, nil
+}
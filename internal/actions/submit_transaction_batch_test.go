@@ -0,0 +1,204 @@
+package actions
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/stellar/go-stellar-sdk/keypair"
+	"github.com/stellar/go-stellar-sdk/network"
+	proto "github.com/stellar/go-stellar-sdk/protocols/stellarcore"
+	"github.com/stellar/go-stellar-sdk/support/errors"
+	"github.com/stellar/go-stellar-sdk/support/render/problem"
+	"github.com/stellar/go-stellar-sdk/txnbuild"
+	"github.com/stellar/go-stellar-sdk/xdr"
+	"github.com/stellar/stellar-horizon/internal/corestate"
+	"github.com/stellar/stellar-horizon/internal/db2/history"
+	"github.com/stellar/stellar-horizon/internal/resource"
+	"github.com/stellar/stellar-horizon/internal/txsub"
+)
+
+// batchSubmitterMock responds to each submission with the result of its hash.
+type batchSubmitterMock struct {
+	NetworkSubmitter
+	results map[string]txsub.Result
+}
+
+func (m *batchSubmitterMock) Submit(ctx context.Context, rawTx string, envelope xdr.TransactionEnvelope, hash string) <-chan txsub.Result {
+	result := make(chan txsub.Result, 1)
+	result <- m.results[hash]
+	return result
+}
+
+func buildBatchTransaction(t *testing.T, name string) (string, string) {
+	source := keypair.Master(name).(*keypair.Full)
+	tx, err := txnbuild.NewTransaction(txnbuild.TransactionParams{
+		SourceAccount: &txnbuild.SimpleAccount{AccountID: source.Address(), Sequence: 1},
+		Operations:    []txnbuild.Operation{&txnbuild.BumpSequence{BumpTo: 10}},
+		BaseFee:       txnbuild.MinBaseFee,
+		Preconditions: txnbuild.Preconditions{TimeBounds: txnbuild.NewInfiniteTimeout()},
+	})
+	require.NoError(t, err)
+	tx, err = tx.Sign(network.PublicNetworkPassphrase, source)
+	require.NoError(t, err)
+	raw, err := tx.Base64()
+	require.NoError(t, err)
+	hash, err := tx.HashHex(network.PublicNetworkPassphrase)
+	require.NoError(t, err)
+	return raw, hash
+}
+
+func batchSubmissionRequest(t *testing.T, path string, txs ...string) *http.Request {
+	form := url.Values{}
+	for _, tx := range txs {
+		form.Add("tx", tx)
+	}
+	request, err := http.NewRequest("POST", "https://horizon.stellar.org"+path, strings.NewReader(form.Encode()))
+	require.NoError(t, err)
+	request.Header.Add("Content-Type", "application/x-www-form-urlencoded")
+	return request
+}
+
+func TestBatchSubmitTransactionHandler(t *testing.T) {
+	successRaw, successHash := buildBatchTransaction(t, "success")
+	timeoutRaw, timeoutHash := buildBatchTransaction(t, "timeout")
+	badSeqRaw, badSeqHash := buildBatchTransaction(t, "bad seq")
+	brokenRaw, brokenHash := buildBatchTransaction(t, "broken")
+
+	coreState := &coreStateGetterMock{}
+	coreState.On("GetCoreState").Return(corestate.State{Synced: true})
+	handler := BatchSubmitTransactionHandler{
+		SubmitTransactionHandler: SubmitTransactionHandler{
+			Submitter: &batchSubmitterMock{results: map[string]txsub.Result{
+				successHash: {Transaction: history.Transaction{
+					TransactionWithoutLedger: history.TransactionWithoutLedger{
+						TransactionHash: successHash,
+						LedgerSequence:  10,
+						TxEnvelope:      successRaw,
+						TxResult:        "AAAAAAAAAGQAAAAAAAAAAQAAAAAAAAALAAAAAAAAAAA=",
+						Successful:      true,
+					},
+				}},
+				timeoutHash: {Err: txsub.ErrTimeout},
+				badSeqHash:  {Err: txsub.ErrBadSequence},
+				brokenHash:  {Err: errors.New("broken")},
+			}},
+			NetworkPassphrase: network.PublicNetworkPassphrase,
+			CoreStateGetter:   coreState,
+		},
+		MaxBatchSize: 5,
+	}
+
+	response, err := handler.GetResource(
+		httptest.NewRecorder(),
+		batchSubmissionRequest(t, "/transactions/batch", successRaw, "AAAA", timeoutRaw, badSeqRaw, brokenRaw),
+	)
+	require.NoError(t, err)
+	results := response.(resource.TransactionBatchSubmission).Results
+	require.Len(t, results, 5)
+
+	assert.Equal(t, successHash, results[0].Hash)
+	require.NotNil(t, results[0].Transaction)
+	assert.Equal(t, successHash, results[0].Transaction.Hash)
+	assert.Nil(t, results[0].Error)
+
+	assert.Empty(t, results[1].Hash)
+	assert.Nil(t, results[1].Transaction)
+	require.NotNil(t, results[1].Error)
+	assert.Equal(t, "transaction_malformed", results[1].Error.Type)
+
+	assert.Equal(t, timeoutHash, results[2].Hash)
+	require.NotNil(t, results[2].Error)
+	assert.Equal(t, "transaction_submission_timeout", results[2].Error.Type)
+	assert.Equal(t, timeoutHash, results[2].Error.Extras["hash"])
+
+	// the errors are converted like the ones of single submissions
+	for i, raw := range []string{badSeqRaw, brokenRaw} {
+		single := &batchSubmitterMock{results: handler.Submitter.(*batchSubmitterMock).results}
+		_, singleErr := SubmitTransactionHandler{
+			Submitter:         single,
+			NetworkPassphrase: network.PublicNetworkPassphrase,
+			CoreStateGetter:   coreState,
+		}.GetResource(httptest.NewRecorder(), batchSubmissionRequest(t, "/transactions", raw))
+		require.Error(t, singleErr)
+		require.NotNil(t, results[3+i].Error)
+		assert.Equal(t, batchResultProblem(&http.Request{}, singleErr), results[3+i].Error)
+	}
+	assert.Equal(t, "transaction_failed", results[3].Error.Type)
+	assert.Equal(t, "server_error", results[4].Error.Type)
+}
+
+func TestBatchSubmitTransactionHandlerValidation(t *testing.T) {
+	raw, _ := buildBatchTransaction(t, "source")
+	handler := BatchSubmitTransactionHandler{MaxBatchSize: 2}
+
+	_, err := handler.GetResource(httptest.NewRecorder(), batchSubmissionRequest(t, "/transactions/batch"))
+	require.IsType(t, &problem.P{}, err)
+	assert.Equal(t, "tx", err.(*problem.P).Extras["invalid_field"])
+
+	_, err = handler.GetResource(httptest.NewRecorder(), batchSubmissionRequest(t, "/transactions/batch", raw, raw, raw))
+	require.IsType(t, &problem.P{}, err)
+	assert.Equal(t, http.StatusBadRequest, err.(*problem.P).Status)
+	assert.Equal(t, "tx", err.(*problem.P).Extras["invalid_field"])
+
+	handler.DisableTxSub = true
+	_, err = handler.GetResource(httptest.NewRecorder(), batchSubmissionRequest(t, "/transactions/batch", raw))
+	require.IsType(t, &problem.P{}, err)
+	assert.Equal(t, "transaction_submission_disabled", err.(*problem.P).Type)
+}
+
+func TestAsyncBatchSubmitTransactionHandler(t *testing.T) {
+	pendingRaw, pendingHash := buildBatchTransaction(t, "pending")
+	failedRaw, failedHash := buildBatchTransaction(t, "failed")
+
+	client := &MockClientWithMetrics{}
+	client.On("SubmitTx", mock.Anything, pendingRaw).
+		Return(&proto.TXResponse{Status: proto.TXStatusPending}, nil).Once()
+	client.On("SubmitTx", mock.Anything, failedRaw).
+		Return(&proto.TXResponse{Status: proto.TXStatusError, Error: "AAAAAAAAAGT/////AAAAAQAAAAAAAAAB////+wAAAAA="}, nil).Once()
+	coreState := &coreStateGetterMock{}
+	coreState.On("GetCoreState").Return(corestate.State{Synced: true})
+	submissions := txsub.NewAsyncSubmissions(10)
+	handler := AsyncBatchSubmitTransactionHandler{
+		AsyncSubmitTransactionHandler: AsyncSubmitTransactionHandler{
+			NetworkPassphrase: network.PublicNetworkPassphrase,
+			ClientWithMetrics: client,
+			CoreStateGetter:   coreState,
+			Submissions:       submissions,
+		},
+	}
+
+	response, err := handler.GetResource(
+		httptest.NewRecorder(),
+		batchSubmissionRequest(t, "/transactions_async/batch", pendingRaw, failedRaw, "AAAA"),
+	)
+	require.NoError(t, err)
+	client.AssertExpectations(t)
+
+	results := response.(resource.AsyncTransactionBatchSubmission).Results
+	require.Len(t, results, 3)
+	assert.Equal(t, resource.AsyncTransactionBatchResult{
+		Hash:     pendingHash,
+		TxStatus: proto.TXStatusPending,
+	}, results[0])
+	assert.Equal(t, resource.AsyncTransactionBatchResult{
+		Hash:           failedHash,
+		TxStatus:       proto.TXStatusError,
+		ErrorResultXDR: "AAAAAAAAAGT/////AAAAAQAAAAAAAAAB////+wAAAAA=",
+	}, results[1])
+	require.NotNil(t, results[2].Error)
+	assert.Equal(t, "transaction_malformed", results[2].Error.Type)
+
+	// the submissions are tracked like the ones of /transactions_async
+	_, ok := submissions.Get(pendingHash)
+	assert.True(t, ok)
+	_, ok = submissions.Get(failedHash)
+	assert.True(t, ok)
+}
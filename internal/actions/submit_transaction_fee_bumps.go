@@ -0,0 +1,150 @@
+package actions
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/stellar/go-stellar-sdk/protocols/horizon"
+	"github.com/stellar/go-stellar-sdk/support/errors"
+	"github.com/stellar/go-stellar-sdk/support/render/problem"
+	"github.com/stellar/stellar-horizon/internal/resource"
+	"github.com/stellar/stellar-horizon/internal/txsub"
+)
+
+const (
+	// maxFeeBumpEnvelopes is the maximum number of fee bump transactions of a
+	// submission.
+	maxFeeBumpEnvelopes = 10
+	// defaultFeeBumpPercentile is the fee percentile of fee bump policies
+	// without fee_bump_percentile.
+	defaultFeeBumpPercentile = 90
+)
+
+// feeBumpPolicy returns the fee bump policy of a submission, from its
+// fee_bump_tx (repeated) and fee_bump_percentile (p10 to p99) parameters, or
+// nil when the submission has no fee bump transactions. The fee bump
+// transactions must wrap the submitted transaction and offer a higher fee.
+func (handler SubmitTransactionHandler) feeBumpPolicy(r *http.Request, info envelopeInfo) (*txsub.FeeBumpPolicy, error) {
+	// the form was parsed when reading the tx parameter
+	rawEnvelopes := r.Form["fee_bump_tx"]
+	if len(rawEnvelopes) == 0 {
+		return nil, nil
+	}
+	if len(rawEnvelopes) > maxFeeBumpEnvelopes {
+		return nil, problem.MakeInvalidFieldProblem(
+			"fee_bump_tx",
+			errors.Errorf("at most %d fee bump transactions can be submitted", maxFeeBumpEnvelopes),
+		)
+	}
+
+	policy := &txsub.FeeBumpPolicy{Percentile: defaultFeeBumpPercentile}
+	value, err := getString(r, "fee_bump_percentile")
+	if err != nil {
+		return nil, err
+	}
+	if value != "" {
+		percentile, err := parseFeeBumpPercentile(value)
+		if err != nil {
+			return nil, problem.MakeInvalidFieldProblem("fee_bump_percentile", err)
+		}
+		policy.Percentile = percentile
+	}
+
+	innerHash := submissionInnerHash(info)
+	fee := int64(info.parsed.Fee())
+	if info.parsed.IsFeeBump() {
+		fee = info.parsed.FeeBumpFee()
+	}
+	for i, raw := range rawEnvelopes {
+		feeBump, err := extractEnvelopeInfo(raw, handler.NetworkPassphrase, handler.DecodeOptions)
+		switch {
+		case err != nil:
+			err = errors.Wrap(err, "could not decode the transaction envelope")
+		case !feeBump.parsed.IsFeeBump():
+			err = errors.New("not a fee bump transaction")
+		case feeBump.innerHash != innerHash:
+			err = errors.New("the inner transaction isn't the submitted transaction")
+		case feeBump.parsed.FeeBumpFee() <= fee:
+			err = errors.New("the fee isn't higher than the fee of the submitted transaction")
+		}
+		if err != nil {
+			return nil, problem.MakeInvalidFieldProblem(fmt.Sprintf("fee_bump_tx[%d]", i), err)
+		}
+		policy.Envelopes = append(policy.Envelopes, txsub.FeeBumpEnvelope{
+			Raw:      feeBump.raw,
+			Envelope: feeBump.parsed,
+			Hash:     feeBump.hash,
+		})
+	}
+	return policy, nil
+}
+
+// parseFeeBumpPercentile parses a fee percentile like p90.
+func parseFeeBumpPercentile(value string) (int, error) {
+	percentile, err := strconv.Atoi(strings.TrimPrefix(value, "p"))
+	if err == nil && strings.HasPrefix(value, "p") {
+		for _, supported := range txsub.FeeBumpPercentiles {
+			if percentile == supported {
+				return percentile, nil
+			}
+		}
+	}
+	return 0, errors.Errorf("%s isn't one of the fee percentiles p10, p20, ..., p90, p95 or p99", value)
+}
+
+// submissionInnerHash returns the hash of the inner transaction of a
+// submission. The fee bump transactions wrapping it have their own hashes, the
+// ingested transactions are matched by their inner_transaction_hash.
+func submissionInnerHash(info envelopeInfo) string {
+	if info.parsed.IsFeeBump() {
+		return info.innerHash
+	}
+	return info.hash
+}
+
+// feeBumpAttempts returns the resources of the fee bump transactions broadcast
+// for a submission.
+func feeBumpAttempts(attempts []txsub.FeeBumpAttempt) []resource.FeeBumpAttempt {
+	resources := make([]resource.FeeBumpAttempt, 0, len(attempts))
+	for _, attempt := range attempts {
+		dest := resource.FeeBumpAttempt{
+			Hash:            attempt.Hash,
+			FeePerOperation: attempt.FeePerOperation,
+			SubmittedAt:     attempt.SubmittedAt,
+		}
+		if failedErr, ok := attempt.Err.(*txsub.FailedTransactionError); ok {
+			dest.ResultXDR = failedErr.ResultXDR
+		} else if attempt.Err != nil {
+			dest.Error = attempt.Err.Error()
+		}
+		resources = append(resources, dest)
+	}
+	return resources
+}
+
+// transactionWithFeeBumps is the transaction of a submission with a fee bump
+// policy, with the fee bump transactions broadcast for it.
+type transactionWithFeeBumps struct {
+	horizon.Transaction
+	FeeBumpAttempts []resource.FeeBumpAttempt
+}
+
+// MarshalJSON adds the fee_bump_attempts field to the JSON of the
+// transaction, which has its own MarshalJSON.
+func (t transactionWithFeeBumps) MarshalJSON() ([]byte, error) {
+	transaction, err := json.Marshal(t.Transaction)
+	if err != nil {
+		return nil, err
+	}
+	attempts, err := json.Marshal(t.FeeBumpAttempts)
+	if err != nil {
+		return nil, err
+	}
+	transaction = transaction[:len(transaction)-1]
+	transaction = append(transaction, `,"fee_bump_attempts":`...)
+	transaction = append(transaction, attempts...)
+	return append(transaction, '}'), nil
+}
@@ -0,0 +1,232 @@
+package actions
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/stellar/go-stellar-sdk/keypair"
+	"github.com/stellar/go-stellar-sdk/network"
+	"github.com/stellar/go-stellar-sdk/support/render/problem"
+	"github.com/stellar/go-stellar-sdk/txnbuild"
+	"github.com/stellar/stellar-horizon/internal/corestate"
+	"github.com/stellar/stellar-horizon/internal/db2/history"
+	"github.com/stellar/stellar-horizon/internal/txsub"
+)
+
+func buildFeeBumps(t *testing.T, baseFees ...int64) (*txnbuild.Transaction, []*txnbuild.FeeBumpTransaction) {
+	source := keypair.Master("source").(*keypair.Full)
+	feeSource := keypair.Master("fee source").(*keypair.Full)
+	tx, err := txnbuild.NewTransaction(txnbuild.TransactionParams{
+		SourceAccount: &txnbuild.SimpleAccount{AccountID: source.Address(), Sequence: 1},
+		Operations: []txnbuild.Operation{
+			&txnbuild.BumpSequence{BumpTo: 10},
+		},
+		BaseFee:       txnbuild.MinBaseFee,
+		Preconditions: txnbuild.Preconditions{TimeBounds: txnbuild.NewInfiniteTimeout()},
+	})
+	require.NoError(t, err)
+	tx, err = tx.Sign(network.PublicNetworkPassphrase, source)
+	require.NoError(t, err)
+
+	var feeBumps []*txnbuild.FeeBumpTransaction
+	for _, baseFee := range baseFees {
+		feeBump, err := txnbuild.NewFeeBumpTransaction(txnbuild.FeeBumpTransactionParams{
+			Inner:      tx,
+			FeeAccount: feeSource.Address(),
+			BaseFee:    baseFee,
+		})
+		require.NoError(t, err)
+		feeBump, err = feeBump.Sign(network.PublicNetworkPassphrase, feeSource)
+		require.NoError(t, err)
+		feeBumps = append(feeBumps, feeBump)
+	}
+	return tx, feeBumps
+}
+
+func feeBumpSubmissionRequest(t *testing.T, tx string, feeBumps []string, percentile string) *http.Request {
+	form := url.Values{}
+	form.Set("tx", tx)
+	for _, feeBump := range feeBumps {
+		form.Add("fee_bump_tx", feeBump)
+	}
+	if percentile != "" {
+		form.Set("fee_bump_percentile", percentile)
+	}
+	request, err := http.NewRequest(
+		"POST",
+		"https://horizon.stellar.org/transactions",
+		strings.NewReader(form.Encode()),
+	)
+	require.NoError(t, err)
+	request.Header.Add("Content-Type", "application/x-www-form-urlencoded")
+	return request
+}
+
+func TestSubmitTransactionFeeBumpsValidation(t *testing.T) {
+	tx, feeBumps := buildFeeBumps(t, 1000)
+	rawTx, err := tx.Base64()
+	require.NoError(t, err)
+	rawFeeBump, err := feeBumps[0].Base64()
+	require.NoError(t, err)
+
+	// a fee bump transaction of another transaction
+	source := keypair.Master("another source").(*keypair.Full)
+	otherTx, err := txnbuild.NewTransaction(txnbuild.TransactionParams{
+		SourceAccount: &txnbuild.SimpleAccount{AccountID: source.Address(), Sequence: 1},
+		Operations:    []txnbuild.Operation{&txnbuild.BumpSequence{BumpTo: 10}},
+		BaseFee:       txnbuild.MinBaseFee,
+		Preconditions: txnbuild.Preconditions{TimeBounds: txnbuild.NewInfiniteTimeout()},
+	})
+	require.NoError(t, err)
+	otherFeeBump, err := txnbuild.NewFeeBumpTransaction(txnbuild.FeeBumpTransactionParams{
+		Inner:      otherTx,
+		FeeAccount: source.Address(),
+		BaseFee:    1000,
+	})
+	require.NoError(t, err)
+	rawOtherFeeBump, err := otherFeeBump.Base64()
+	require.NoError(t, err)
+
+	coreState := &coreStateGetterMock{}
+	coreState.On("GetCoreState").Return(corestate.State{Synced: true})
+	handler := SubmitTransactionHandler{
+		Submitter:         &networkSubmitterMock{},
+		NetworkPassphrase: network.PublicNetworkPassphrase,
+		CoreStateGetter:   coreState,
+	}
+
+	for _, testCase := range []struct {
+		name       string
+		feeBumps   []string
+		percentile string
+		field      string
+	}{
+		{name: "not a fee bump", feeBumps: []string{rawTx}, field: "fee_bump_tx[0]"},
+		{name: "other transaction", feeBumps: []string{rawFeeBump, rawOtherFeeBump}, field: "fee_bump_tx[1]"},
+		{name: "malformed", feeBumps: []string{"AAAA"}, field: "fee_bump_tx[0]"},
+		{name: "percentile", feeBumps: []string{rawFeeBump}, percentile: "p42", field: "fee_bump_percentile"},
+	} {
+		t.Run(testCase.name, func(t *testing.T) {
+			request := feeBumpSubmissionRequest(t, rawTx, testCase.feeBumps, testCase.percentile)
+			_, err := handler.GetResource(httptest.NewRecorder(), request)
+			require.IsType(t, &problem.P{}, err)
+			p := err.(*problem.P)
+			assert.Equal(t, http.StatusBadRequest, p.Status)
+			assert.Equal(t, testCase.field, p.Extras["invalid_field"])
+		})
+	}
+}
+
+func TestSubmitTransactionWithFeeBumps(t *testing.T) {
+	tx, feeBumps := buildFeeBumps(t, 1000, 10000)
+	rawTx, err := tx.Base64()
+	require.NoError(t, err)
+	hash, err := tx.HashHex(network.PublicNetworkPassphrase)
+	require.NoError(t, err)
+	var rawFeeBumps []string
+	for _, feeBump := range feeBumps {
+		raw, err := feeBump.Base64()
+		require.NoError(t, err)
+		rawFeeBumps = append(rawFeeBumps, raw)
+	}
+	includedHash, err := feeBumps[1].HashHex(network.PublicNetworkPassphrase)
+	require.NoError(t, err)
+	envelope, err := feeBumps[1].Base64()
+	require.NoError(t, err)
+
+	submitted := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+	results := make(chan txsub.Result, 1)
+	results <- txsub.Result{
+		Transaction: history.Transaction{
+			TransactionWithoutLedger: history.TransactionWithoutLedger{
+				TransactionHash: includedHash,
+				LedgerSequence:  10,
+				TxEnvelope:      envelope,
+				TxResult:        "AAAAAAAAAGQAAAAAAAAAAQAAAAAAAAALAAAAAAAAAAA=",
+				Successful:      true,
+			},
+		},
+		FeeBumpAttempts: []txsub.FeeBumpAttempt{
+			{Hash: includedHash, FeePerOperation: 10000, SubmittedAt: submitted},
+		},
+	}
+
+	submitter := &networkSubmitterMock{}
+	submitter.On("SubmitWithFeeBumps", hash, mock.MatchedBy(func(policy txsub.FeeBumpPolicy) bool {
+		return policy.Percentile == 70 && len(policy.Envelopes) == 2
+	})).Return(results).Once()
+	coreState := &coreStateGetterMock{}
+	coreState.On("GetCoreState").Return(corestate.State{Synced: true})
+	handler := SubmitTransactionHandler{
+		Submitter:         submitter,
+		NetworkPassphrase: network.PublicNetworkPassphrase,
+		CoreStateGetter:   coreState,
+	}
+
+	response, err := handler.GetResource(
+		httptest.NewRecorder(),
+		feeBumpSubmissionRequest(t, rawTx, rawFeeBumps, "p70"),
+	)
+	require.NoError(t, err)
+	submitter.AssertExpectations(t)
+
+	data, err := json.Marshal(response)
+	require.NoError(t, err)
+	var fields map[string]interface{}
+	require.NoError(t, json.Unmarshal(data, &fields))
+	assert.Equal(t, includedHash, fields["hash"])
+	assert.Equal(t, []interface{}{
+		map[string]interface{}{
+			"hash":              includedHash,
+			"fee_per_operation": "10000",
+			"submitted_at":      "2024-01-02T03:04:05Z",
+		},
+	}, fields["fee_bump_attempts"])
+}
+
+func TestSubmitTransactionWithFeeBumpsPolicyConflict(t *testing.T) {
+	tx, feeBumps := buildFeeBumps(t, 1000, 10000)
+	rawTx, err := tx.Base64()
+	require.NoError(t, err)
+	hash, err := tx.HashHex(network.PublicNetworkPassphrase)
+	require.NoError(t, err)
+	var rawFeeBumps []string
+	for _, feeBump := range feeBumps {
+		raw, err := feeBump.Base64()
+		require.NoError(t, err)
+		rawFeeBumps = append(rawFeeBumps, raw)
+	}
+
+	results := make(chan txsub.Result, 1)
+	results <- txsub.Result{Err: txsub.ErrFeeBumpPolicyRegistered}
+
+	submitter := &networkSubmitterMock{}
+	submitter.On("SubmitWithFeeBumps", hash, mock.Anything).Return(results).Once()
+	coreState := &coreStateGetterMock{}
+	coreState.On("GetCoreState").Return(corestate.State{Synced: true})
+	handler := SubmitTransactionHandler{
+		Submitter:         submitter,
+		NetworkPassphrase: network.PublicNetworkPassphrase,
+		CoreStateGetter:   coreState,
+	}
+
+	_, err = handler.GetResource(
+		httptest.NewRecorder(),
+		feeBumpSubmissionRequest(t, rawTx, rawFeeBumps, "p70"),
+	)
+	submitter.AssertExpectations(t)
+	p, ok := err.(*problem.P)
+	require.True(t, ok)
+	assert.Equal(t, "fee_bump_policy_conflict", p.Type)
+	assert.Equal(t, http.StatusConflict, p.Status)
+	assert.Equal(t, hash, p.Extras["hash"])
+}
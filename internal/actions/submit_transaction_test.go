@@ -50,6 +50,11 @@ func (m *networkSubmitterMock) Submit(ctx context.Context, rawTx string, envelop
 	return a.Get(0).(chan txsub.Result)
 }
 
+func (m *networkSubmitterMock) SubmitWithFeeBumps(ctx context.Context, rawTx string, envelope xdr.TransactionEnvelope, hash string, policy txsub.FeeBumpPolicy) <-chan txsub.Result {
+	a := m.Called(hash, policy)
+	return a.Get(0).(chan txsub.Result)
+}
+
 func TestStellarCoreNotSynced(t *testing.T) {
 	mock := &coreStateGetterMock{}
 	mock.On("GetCoreState").Return(corestate.State{
@@ -0,0 +1,280 @@
+package actions
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+
+	"github.com/stellar/go-stellar-sdk/strkey"
+	"github.com/stellar/go-stellar-sdk/support/errors"
+	"github.com/stellar/go-stellar-sdk/support/render/problem"
+	"github.com/stellar/go-stellar-sdk/xdr"
+	horizonContext "github.com/stellar/stellar-horizon/internal/context"
+	"github.com/stellar/stellar-horizon/internal/db2/history"
+	"github.com/stellar/stellar-horizon/internal/ledger"
+	"github.com/stellar/stellar-horizon/internal/resource"
+)
+
+// SubscriptionsHandler manages the subscriptions to the ingested transactions,
+// these admin HTTP endpoints are documented in internal/httpx/static/admin_oapi.yml
+type SubscriptionsHandler struct {
+	LedgerState *ledger.State
+}
+
+func (handler SubscriptionsHandler) CreateSubscription(w http.ResponseWriter, r *http.Request) {
+	historyQ, err := horizonContext.HistoryQFromRequest(r)
+	if err != nil {
+		problem.Render(r.Context(), w, err)
+		return
+	}
+
+	request, err := handler.subscriptionRequest(r)
+	if err != nil {
+		problem.Render(r.Context(), w, err)
+		return
+	}
+
+	secret, err := newSubscriptionSecret()
+	if err != nil {
+		problem.Render(r.Context(), w, err)
+		return
+	}
+
+	subscription, err := historyQ.CreateSubscription(r.Context(), history.Subscription{
+		CallbackURL: request.CallbackURL,
+		Secret:      secret,
+		AccountIDs:  request.AccountIDs,
+		Assets:      request.Assets,
+		ContractIDs: request.ContractIDs,
+	})
+	if err != nil {
+		problem.Render(r.Context(), w, err)
+		return
+	}
+
+	responsePayload := handler.subscriptionResource(subscription)
+	// the secret is only disclosed once
+	responsePayload.Secret = subscription.Secret
+	w.WriteHeader(http.StatusCreated)
+	enc := json.NewEncoder(w)
+	if err = enc.Encode(responsePayload); err != nil {
+		problem.Render(r.Context(), w, err)
+	}
+}
+
+func (handler SubscriptionsHandler) GetSubscriptions(w http.ResponseWriter, r *http.Request) {
+	historyQ, err := horizonContext.HistoryQFromRequest(r)
+	if err != nil {
+		problem.Render(r.Context(), w, err)
+		return
+	}
+
+	pq, err := GetPageQuery(handler.LedgerState, r, DisableCursorValidation)
+	if err != nil {
+		problem.Render(r.Context(), w, err)
+		return
+	}
+
+	subscriptions, err := historyQ.GetSubscriptions(r.Context(), pq)
+	if err != nil {
+		problem.Render(r.Context(), w, err)
+		return
+	}
+
+	responsePayload := make([]resource.Subscription, 0, len(subscriptions))
+	for _, subscription := range subscriptions {
+		responsePayload = append(responsePayload, handler.subscriptionResource(subscription))
+	}
+	enc := json.NewEncoder(w)
+	if err = enc.Encode(responsePayload); err != nil {
+		problem.Render(r.Context(), w, err)
+	}
+}
+
+func (handler SubscriptionsHandler) GetSubscription(w http.ResponseWriter, r *http.Request) {
+	historyQ, err := horizonContext.HistoryQFromRequest(r)
+	if err != nil {
+		problem.Render(r.Context(), w, err)
+		return
+	}
+
+	id, err := handler.subscriptionID(r)
+	if err != nil {
+		problem.Render(r.Context(), w, err)
+		return
+	}
+
+	subscription, err := historyQ.GetSubscription(r.Context(), id)
+	if err != nil {
+		problem.Render(r.Context(), w, err)
+		return
+	}
+
+	responsePayload := handler.subscriptionResource(subscription)
+	enc := json.NewEncoder(w)
+	if err = enc.Encode(responsePayload); err != nil {
+		problem.Render(r.Context(), w, err)
+	}
+}
+
+func (handler SubscriptionsHandler) DeleteSubscription(w http.ResponseWriter, r *http.Request) {
+	historyQ, err := horizonContext.HistoryQFromRequest(r)
+	if err != nil {
+		problem.Render(r.Context(), w, err)
+		return
+	}
+
+	id, err := handler.subscriptionID(r)
+	if err != nil {
+		problem.Render(r.Context(), w, err)
+		return
+	}
+
+	deleted, err := historyQ.DeleteSubscription(r.Context(), id)
+	if err != nil {
+		problem.Render(r.Context(), w, err)
+		return
+	}
+	if deleted == 0 {
+		problem.Render(r.Context(), w, problem.NotFound)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (handler SubscriptionsHandler) GetSubscriptionDeliveries(w http.ResponseWriter, r *http.Request) {
+	historyQ, err := horizonContext.HistoryQFromRequest(r)
+	if err != nil {
+		problem.Render(r.Context(), w, err)
+		return
+	}
+
+	id, err := handler.subscriptionID(r)
+	if err != nil {
+		problem.Render(r.Context(), w, err)
+		return
+	}
+
+	pq, err := GetPageQuery(handler.LedgerState, r, DisableCursorValidation)
+	if err != nil {
+		problem.Render(r.Context(), w, err)
+		return
+	}
+
+	// render a 404 for unknown subscriptions rather than an empty list
+	if _, err = historyQ.GetSubscription(r.Context(), id); err != nil {
+		problem.Render(r.Context(), w, err)
+		return
+	}
+
+	deliveries, err := historyQ.GetSubscriptionDeliveries(r.Context(), id, pq)
+	if err != nil {
+		problem.Render(r.Context(), w, err)
+		return
+	}
+
+	responsePayload := make([]resource.SubscriptionDelivery, 0, len(deliveries))
+	for _, delivery := range deliveries {
+		responsePayload = append(responsePayload, handler.deliveryResource(delivery))
+	}
+	enc := json.NewEncoder(w)
+	if err = enc.Encode(responsePayload); err != nil {
+		problem.Render(r.Context(), w, err)
+	}
+}
+
+func (handler SubscriptionsHandler) subscriptionID(r *http.Request) (int64, error) {
+	value, err := getStringFromURLParam(r, "id")
+	if err != nil {
+		return 0, err
+	}
+	id, err := strconv.ParseInt(value, 10, 64)
+	if err != nil {
+		return 0, problem.MakeInvalidFieldProblem("id", errors.New("invalid subscription id"))
+	}
+	return id, nil
+}
+
+func (handler SubscriptionsHandler) subscriptionRequest(r *http.Request) (resource.SubscriptionRequest, error) {
+	var request resource.SubscriptionRequest
+	dec := json.NewDecoder(r.Body)
+	if err := dec.Decode(&request); err != nil {
+		p := problem.NewProblemWithInvalidField(problem.BadRequest, "reason", fmt.Errorf("invalid json for subscription %v", err.Error()))
+		return resource.SubscriptionRequest{}, p
+	}
+
+	callbackURL, err := url.Parse(request.CallbackURL)
+	if err != nil || (callbackURL.Scheme != "http" && callbackURL.Scheme != "https") || callbackURL.Host == "" {
+		p := problem.NewProblemWithInvalidField(problem.BadRequest, "callback_url", fmt.Errorf("invalid http(s) url %q", request.CallbackURL))
+		return resource.SubscriptionRequest{}, p
+	}
+	for _, accountID := range request.AccountIDs {
+		if !strkey.IsValidEd25519PublicKey(accountID) {
+			p := problem.NewProblemWithInvalidField(problem.BadRequest, "account_ids", fmt.Errorf("invalid account id %q", accountID))
+			return resource.SubscriptionRequest{}, p
+		}
+	}
+	for i, asset := range request.Assets {
+		assets, err := xdr.BuildAssets(asset)
+		if err != nil || len(assets) != 1 {
+			p := problem.NewProblemWithInvalidField(problem.BadRequest, "assets", fmt.Errorf("invalid canonical asset %q", asset))
+			return resource.SubscriptionRequest{}, p
+		}
+		request.Assets[i] = assets[0].StringCanonical()
+	}
+	for _, contractID := range request.ContractIDs {
+		if !strkey.IsValidContractAddress(contractID) {
+			p := problem.NewProblemWithInvalidField(problem.BadRequest, "contract_ids", fmt.Errorf("invalid contract address %q", contractID))
+			return resource.SubscriptionRequest{}, p
+		}
+	}
+	return request, nil
+}
+
+func (handler SubscriptionsHandler) subscriptionResource(subscription history.Subscription) resource.Subscription {
+	return resource.Subscription{
+		ID:          subscription.ID,
+		CallbackURL: subscription.CallbackURL,
+		AccountIDs:  subscription.AccountIDs,
+		Assets:      subscription.Assets,
+		ContractIDs: subscription.ContractIDs,
+		CreatedAt:   subscription.CreatedAt,
+	}
+}
+
+func (handler SubscriptionsHandler) deliveryResource(delivery history.SubscriptionDelivery) resource.SubscriptionDelivery {
+	result := resource.SubscriptionDelivery{
+		ID:             delivery.ID,
+		SubscriptionID: delivery.SubscriptionID,
+		Ledger:         delivery.LedgerSequence,
+		Status:         delivery.Status,
+		Attempts:       delivery.Attempts,
+		LastError:      delivery.LastError.String,
+		ResponseStatus: delivery.ResponseStatus.Int64,
+		CreatedAt:      delivery.CreatedAt,
+		Payload:        delivery.Payload,
+	}
+	if delivery.Status == history.SubscriptionDeliveryPending {
+		nextAttemptAt := delivery.NextAttemptAt
+		result.NextAttemptAt = &nextAttemptAt
+	}
+	if delivery.DeliveredAt.Valid {
+		deliveredAt := delivery.DeliveredAt.Time
+		result.DeliveredAt = &deliveredAt
+	}
+	return result
+}
+
+// newSubscriptionSecret returns a random hex encoded key used to sign the
+// payloads delivered to a subscription.
+func newSubscriptionSecret() (string, error) {
+	secret := make([]byte, 32)
+	if _, err := rand.Read(secret); err != nil {
+		return "", errors.Wrap(err, "could not generate subscription secret")
+	}
+	return hex.EncodeToString(secret), nil
+}
@@ -155,6 +155,11 @@ type GetTradesHandler struct {
 	CoreStateGetter
 }
 
+// CSVColumns returns the columns of trades rendered as CSV.
+func (handler GetTradesHandler) CSVColumns() []string {
+	return resourceadapter.TradeCSVColumns
+}
+
 // GetResourcePage returns a page of trades.
 func (handler GetTradesHandler) GetResourcePage(w HeaderWriter, r *http.Request) ([]hal.Pageable, error) {
 	ctx := r.Context()
@@ -463,3 +468,129 @@ func (handler GetTradeAggregationsHandler) buildPage(r *http.Request, records []
 
 	return page, nil
 }
+
+// TradeAggregationsStream is the state of a trade aggregations stream: the
+// start of the oldest bucket which hasn't been closed yet and the last sent
+// version of the bucket in progress.
+type TradeAggregationsStream struct {
+	resolution int64
+	offset     int64
+	next       time.Millis
+	inProgress *horizon.TradeAggregation
+}
+
+// StreamedTradeAggregation is a trade aggregation bucket sent to a stream.
+// Closed is false while trades can still be added to the bucket.
+type StreamedTradeAggregation struct {
+	horizon.TradeAggregation
+	Closed bool
+}
+
+// NewStream starts a trade aggregations stream from the bucket following the
+// cursor (the timestamp of the last closed bucket received by the client), the
+// bucket in progress if the cursor is `now`, or the start time otherwise.
+func (handler GetTradeAggregationsHandler) NewStream(r *http.Request) (*TradeAggregationsStream, error) {
+	qp := TradeAggregationsQuery{}
+	if err := getParams(&qp, r); err != nil {
+		return nil, err
+	}
+
+	stream := &TradeAggregationsStream{
+		resolution: int64(qp.ResolutionFilter),
+		offset:     int64(qp.OffsetFilter),
+		next:       qp.StartTimeFilter,
+	}
+
+	cursor := r.Header.Get("Last-Event-ID")
+	if cursor == "" {
+		var err error
+		if cursor, err = getString(r, ParamCursor); err != nil {
+			return nil, err
+		}
+	}
+	switch cursor {
+	case "":
+	case "now":
+		closedAt := handler.LedgerState.CurrentStatus().HistoryLatestClosedAt
+		stream.next = stream.bucket(time.MillisFromSeconds(closedAt.Unix()))
+	default:
+		timestamp, err := strconv.ParseInt(cursor, 10, 64)
+		if err != nil || timestamp < 0 {
+			return nil, problem.MakeInvalidFieldProblem(
+				ParamCursor,
+				errors.New("the cursor must be the timestamp of a trade aggregation or `now`"),
+			)
+		}
+		stream.next = time.MillisFromInt64(timestamp + stream.resolution)
+	}
+	return stream, nil
+}
+
+// GetStreamResources returns the buckets closed since the previous call, and
+// the bucket in progress if it changed.
+func (handler GetTradeAggregationsHandler) GetStreamResources(r *http.Request, stream *TradeAggregationsStream) ([]StreamedTradeAggregation, error) {
+	ctx := r.Context()
+	qp := TradeAggregationsQuery{}
+	if err := getParams(&qp, r); err != nil {
+		return nil, err
+	}
+	if !qp.EndTimeFilter.IsNil() && stream.next >= qp.EndTimeFilter {
+		return nil, nil
+	}
+	qp.StartTimeFilter = stream.next
+
+	historyQ, err := horizonContext.HistoryQFromRequest(r)
+	if err != nil {
+		return nil, err
+	}
+
+	pq := db2.PageQuery{Order: db2.OrderAscending, Limit: db2.MaxPageSize}
+	records, err := handler.fetchRecords(ctx, historyQ, qp, pq)
+	if err != nil {
+		return nil, err
+	}
+
+	aggregations := make([]horizon.TradeAggregation, 0, len(records))
+	for _, record := range records {
+		var res horizon.TradeAggregation
+		if err = resourceadapter.PopulateTradeAggregation(ctx, &res, record); err != nil {
+			return nil, err
+		}
+		aggregations = append(aggregations, res)
+	}
+
+	closedAt := handler.LedgerState.CurrentStatus().HistoryLatestClosedAt
+	return stream.update(aggregations, time.MillisFromSeconds(closedAt.Unix())), nil
+}
+
+// bucket returns the start of the bucket containing the given time.
+func (s *TradeAggregationsStream) bucket(t time.Millis) time.Millis {
+	offset := time.MillisFromInt64(s.offset)
+	if t < offset {
+		return offset
+	}
+	return (t - offset).RoundDown(s.resolution) + offset
+}
+
+// update returns the buckets to send given the buckets following the last
+// closed one and the close time of the latest ingested ledger. A bucket is
+// closed once a ledger closed after its end has been ingested.
+func (s *TradeAggregationsStream) update(aggregations []horizon.TradeAggregation, closedAt time.Millis) []StreamedTradeAggregation {
+	var updates []StreamedTradeAggregation
+	for _, aggregation := range aggregations {
+		end := time.MillisFromInt64(aggregation.Timestamp + s.resolution)
+		if end <= closedAt {
+			updates = append(updates, StreamedTradeAggregation{TradeAggregation: aggregation, Closed: true})
+			s.next = end
+			s.inProgress = nil
+			continue
+		}
+
+		if s.inProgress == nil || *s.inProgress != aggregation {
+			updates = append(updates, StreamedTradeAggregation{TradeAggregation: aggregation})
+			inProgress := aggregation
+			s.inProgress = &inProgress
+		}
+	}
+	return updates
+}
@@ -0,0 +1,101 @@
+package actions
+
+import (
+	"testing"
+	gTime "time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/stellar/go-stellar-sdk/protocols/horizon"
+	"github.com/stellar/go-stellar-sdk/support/render/problem"
+	"github.com/stellar/go-stellar-sdk/support/time"
+	"github.com/stellar/stellar-horizon/internal/ledger"
+)
+
+const (
+	minuteMillis = int64(gTime.Minute / gTime.Millisecond)
+	hourMillis   = int64(gTime.Hour / gTime.Millisecond)
+)
+
+func tradeAggregationsStreamParams(params map[string]string) map[string]string {
+	query := map[string]string{
+		"base_asset_type":      "native",
+		"counter_asset_type":   "credit_alphanum4",
+		"counter_asset_code":   "USDC",
+		"counter_asset_issuer": "GA5ZSEJYB37JRC5AVCIA5MOP4RHTM335X2KGX3IHOJAPP5RE34K4KZVN",
+		"resolution":           "3600000",
+	}
+	for key, value := range params {
+		query[key] = value
+	}
+	return query
+}
+
+func TestTradeAggregationsNewStream(t *testing.T) {
+	ledgerState := &ledger.State{}
+	ledgerState.SetHorizonStatus(ledger.HorizonStatus{
+		HistoryLatestClosedAt: gTime.UnixMilli(10*hourMillis + 25*minuteMillis).UTC(),
+	})
+	handler := GetTradeAggregationsHandler{LedgerState: ledgerState}
+
+	stream, err := handler.NewStream(makeRequest(t, tradeAggregationsStreamParams(map[string]string{
+		"start_time": "7200000",
+	}), map[string]string{}, nil))
+	require.NoError(t, err)
+	assert.Equal(t, time.MillisFromInt64(2*hourMillis), stream.next)
+
+	// the stream resumes after the last closed bucket
+	stream, err = handler.NewStream(makeRequest(t, tradeAggregationsStreamParams(map[string]string{
+		"cursor": "7200000",
+	}), map[string]string{}, nil))
+	require.NoError(t, err)
+	assert.Equal(t, time.MillisFromInt64(3*hourMillis), stream.next)
+
+	// the stream starts from the bucket in progress
+	stream, err = handler.NewStream(makeRequest(t, tradeAggregationsStreamParams(map[string]string{
+		"cursor": "now",
+		"offset": "3600000",
+	}), map[string]string{}, nil))
+	require.NoError(t, err)
+	assert.Equal(t, time.MillisFromInt64(10*hourMillis), stream.next)
+
+	_, err = handler.NewStream(makeRequest(t, tradeAggregationsStreamParams(map[string]string{
+		"cursor": "yesterday",
+	}), map[string]string{}, nil))
+	var p *problem.P
+	require.ErrorAs(t, err, &p)
+	assert.Equal(t, "cursor", p.Extras["invalid_field"])
+}
+
+func TestTradeAggregationsStreamUpdate(t *testing.T) {
+	stream := &TradeAggregationsStream{resolution: hourMillis}
+	first := horizon.TradeAggregation{Timestamp: 0, TradeCount: 1}
+	second := horizon.TradeAggregation{Timestamp: hourMillis, TradeCount: 1}
+
+	updates := stream.update([]horizon.TradeAggregation{first}, time.MillisFromInt64(30*minuteMillis))
+	assert.Equal(t, []StreamedTradeAggregation{{TradeAggregation: first}}, updates)
+
+	// the bucket in progress is only sent when it changes
+	updates = stream.update([]horizon.TradeAggregation{first}, time.MillisFromInt64(40*minuteMillis))
+	assert.Empty(t, updates)
+	first.TradeCount = 2
+	updates = stream.update([]horizon.TradeAggregation{first}, time.MillisFromInt64(50*minuteMillis))
+	assert.Equal(t, []StreamedTradeAggregation{{TradeAggregation: first}}, updates)
+	assert.Equal(t, time.MillisFromInt64(0), stream.next)
+
+	// the bucket is closed by a ledger closed after its end
+	updates = stream.update(
+		[]horizon.TradeAggregation{first, second},
+		time.MillisFromInt64(hourMillis+10*minuteMillis),
+	)
+	assert.Equal(t, []StreamedTradeAggregation{
+		{TradeAggregation: first, Closed: true},
+		{TradeAggregation: second},
+	}, updates)
+	assert.Equal(t, time.MillisFromInt64(hourMillis), stream.next)
+
+	updates = stream.update([]horizon.TradeAggregation{second}, time.MillisFromInt64(2*hourMillis))
+	assert.Equal(t, []StreamedTradeAggregation{{TradeAggregation: second, Closed: true}}, updates)
+	assert.Equal(t, time.MillisFromInt64(2*hourMillis), stream.next)
+}
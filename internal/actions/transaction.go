@@ -61,6 +61,7 @@ type TransactionsQuery struct {
 	AccountID                 string `schema:"account_id" valid:"accountID,optional"`
 	ClaimableBalanceID        string `schema:"claimable_balance_id" valid:"claimableBalanceID,optional"`
 	LiquidityPoolID           string `schema:"liquidity_pool_id" valid:"sha256,optional"`
+	ContractID                string `schema:"contract_id" valid:"contractID,optional"`
 	IncludeFailedTransactions bool   `schema:"include_failed" valid:"-"`
 	LedgerID                  uint32 `schema:"ledger_id" valid:"-"`
 }
@@ -71,6 +72,7 @@ func (qp TransactionsQuery) Validate() error {
 		qp.AccountID,
 		qp.ClaimableBalanceID,
 		qp.LiquidityPoolID,
+		qp.ContractID,
 		qp.LedgerID,
 	)
 
@@ -94,6 +96,11 @@ type GetTransactionsHandler struct {
 	SkipTxMeta  bool
 }
 
+// CSVColumns returns the columns of transactions rendered as CSV.
+func (handler GetTransactionsHandler) CSVColumns() []string {
+	return resourceadapter.TransactionCSVColumns
+}
+
 // GetResourcePage returns a page of transactions.
 func (handler GetTransactionsHandler) GetResourcePage(w HeaderWriter, r *http.Request) ([]hal.Pageable, error) {
 	ctx := r.Context()
@@ -152,6 +159,8 @@ func loadTransactionRecords(ctx context.Context, hq *history.Q, qp TransactionsQ
 		txs.ForClaimableBalance(ctx, qp.ClaimableBalanceID)
 	case qp.LiquidityPoolID != "":
 		txs.ForLiquidityPool(ctx, qp.LiquidityPoolID)
+	case qp.ContractID != "":
+		txs.ForContract(ctx, qp.ContractID)
 	case qp.LedgerID > 0:
 		txs.ForLedger(ctx, int32(qp.LedgerID))
 	}
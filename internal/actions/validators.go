@@ -10,6 +10,7 @@ import (
 	"github.com/gorilla/schema"
 
 	"github.com/stellar/go-stellar-sdk/amount"
+	"github.com/stellar/go-stellar-sdk/strkey"
 	"github.com/stellar/go-stellar-sdk/support/errors"
 	"github.com/stellar/go-stellar-sdk/xdr"
 	"github.com/stellar/stellar-horizon/internal/assets"
@@ -26,6 +27,7 @@ func init() {
 	govalidator.TagMap["assetType"] = isAssetType
 	govalidator.TagMap["asset"] = isAsset
 	govalidator.TagMap["claimableBalanceID"] = isClaimableBalanceID
+	govalidator.TagMap["contractID"] = isContractID
 	govalidator.TagMap["transactionHash"] = isTransactionHash
 	govalidator.TagMap["sha256"] = govalidator.IsSHA256
 	govalidator.TagMap["tradeType"] = isTradeType
@@ -38,6 +40,7 @@ var customTagsErrorMessages = map[string]string{
 	"assetType":            "Asset type must be native, credit_alphanum4 or credit_alphanum12",
 	"bool":                 "Filter should be true or false",
 	"claimable_balance_id": "Claimable Balance ID must be the hex-encoded XDR representation of a Claimable Balance ID",
+	"contractID":           "Contract ID must start with `C` and contain 56 alphanum characters",
 	"ledger_id":            "Ledger ID must be an integer higher than 0",
 	"offer_id":             "Offer ID must be an integer higher than 0",
 	"op_id":                "Operation ID must be an integer higher than 0",
@@ -140,6 +143,14 @@ func isAccountID(str string) bool {
 	return true
 }
 
+func isContractID(str string) bool {
+	if _, err := strkey.Decode(strkey.VersionByteContract, str); err != nil {
+		return false
+	}
+
+	return true
+}
+
 func isTransactionHash(str string) bool {
 	decoded, err := hex.DecodeString(str)
 	if err != nil {
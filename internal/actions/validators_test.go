@@ -264,6 +264,56 @@ func TestAmountValidator(t *testing.T) {
 	}
 }
 
+func TestContractIDValidator(t *testing.T) {
+	type Query struct {
+		Contract string `valid:"contractID,optional"`
+	}
+
+	for _, testCase := range []struct {
+		name  string
+		value string
+		valid bool
+	}{
+		{
+			"valid contract id",
+			"CA3D5KRYM6CB7OWQ6TWYRR3Z4T7GNZLKERYNZGGA5SOAOPIFY6YQGAXE",
+			true,
+		},
+		{
+			"account id is not a contract id",
+			"GAUJETIZVEP2NRYLUESJ3LS66NVCEGMON4UDCBCSBEVPIID773P2W6AY",
+			false,
+		},
+		{
+			"invalid checksum",
+			"CA3D5KRYM6CB7OWQ6TWYRR3Z4T7GNZLKERYNZGGA5SOAOPIFY6YQGAXA",
+			false,
+		},
+		{
+			"empty contract id should not be validated",
+			"",
+			true,
+		},
+	} {
+		t.Run(testCase.name, func(t *testing.T) {
+			tt := assert.New(t)
+
+			q := Query{
+				Contract: testCase.value,
+			}
+
+			result, err := govalidator.ValidateStruct(q)
+			if testCase.valid {
+				tt.NoError(err)
+				tt.True(result)
+			} else {
+				expected := fmt.Sprintf("Contract: %s does not validate as contractID", testCase.value)
+				tt.Equal(expected, err.Error())
+			}
+		})
+	}
+}
+
 func TestTransactionHashValidator(t *testing.T) {
 	type Query struct {
 		TransactionHash string `valid:"transactionHash,optional"`
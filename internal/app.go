@@ -45,6 +45,7 @@ type App struct {
 	orderBookStream *ingest.OrderBookStream
 	submitter       *txsub.System
 	paths           paths.Finder
+	quotes          paths.QuoteFinder
 	ingester        ingest.System
 	ticks           *time.Ticker
 	ledgerState     *ledger.State
@@ -508,27 +509,30 @@ func (a *App) init() error {
 	initTxSubMetrics(a)
 
 	routerConfig := httpx.RouterConfig{
-		DBSession:               a.historyQ.SessionInterface,
-		TxSubmitter:             a.submitter,
-		RateQuota:               a.config.RateQuota,
-		BehindCloudflare:        a.config.BehindCloudflare,
-		BehindAWSLoadBalancer:   a.config.BehindAWSLoadBalancer,
-		SSEUpdateFrequency:      a.config.SSEUpdateFrequency,
-		StaleThreshold:          a.config.StaleThreshold,
-		ConnectionTimeout:       a.config.ConnectionTimeout,
-		ClientQueryTimeout:      a.config.ClientQueryTimeout,
-		MaxConcurrentRequests:   a.config.MaxConcurrentRequests,
-		MaxHTTPRequestSize:      a.config.MaxHTTPRequestSize,
-		NetworkPassphrase:       a.config.NetworkPassphrase,
-		MaxPathLength:           a.config.MaxPathLength,
-		MaxAssetsPerPathRequest: a.config.MaxAssetsPerPathRequest,
-		PathFinder:              a.paths,
-		PrometheusRegistry:      a.prometheusRegistry,
-		CoreGetter:              a,
-		HorizonVersion:          a.horizonVersion,
-		FriendbotURL:            a.config.FriendbotURL,
-		DisableTxSub:            a.config.DisableTxSub,
-		StellarCoreURL:          a.config.StellarCoreURL,
+		DBSession:                  a.historyQ.SessionInterface,
+		TxSubmitter:                a.submitter,
+		RateQuota:                  a.config.RateQuota,
+		BehindCloudflare:           a.config.BehindCloudflare,
+		BehindAWSLoadBalancer:      a.config.BehindAWSLoadBalancer,
+		SSEUpdateFrequency:         a.config.SSEUpdateFrequency,
+		StaleThreshold:             a.config.StaleThreshold,
+		ConnectionTimeout:          a.config.ConnectionTimeout,
+		ClientQueryTimeout:         a.config.ClientQueryTimeout,
+		MaxConcurrentRequests:      a.config.MaxConcurrentRequests,
+		MaxHTTPRequestSize:         a.config.MaxHTTPRequestSize,
+		NetworkPassphrase:          a.config.NetworkPassphrase,
+		MaxPathLength:              a.config.MaxPathLength,
+		MaxAssetsPerPathRequest:    a.config.MaxAssetsPerPathRequest,
+		PathFinder:                 a.paths,
+		QuoteFinder:                a.quotes,
+		PrometheusRegistry:         a.prometheusRegistry,
+		CoreGetter:                 a,
+		HorizonVersion:             a.horizonVersion,
+		FriendbotURL:               a.config.FriendbotURL,
+		DisableTxSub:               a.config.DisableTxSub,
+		MaxTransactionBatchSize:    a.config.MaxTransactionBatchSize,
+		MaxTrackedAsyncSubmissions: a.config.MaxTrackedAsyncSubmissions,
+		StellarCoreURL:             a.config.StellarCoreURL,
 		HealthCheck: healthCheck{
 			session: a.historyQ.SessionInterface,
 			ctx:     a.ctx,
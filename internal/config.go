@@ -125,9 +125,27 @@ type Config struct {
 	Network string
 	// DisableTxSub disables transaction submission functionality for Horizon.
 	DisableTxSub bool
+	// MaxTransactionBatchSize is the maximum number of transactions submitted
+	// to `/transactions/batch` and `/transactions_async/batch`.
+	MaxTransactionBatchSize int
+	// MaxTrackedAsyncSubmissions is the number of the latest transactions
+	// submitted to `/transactions_async` whose status is tracked in memory.
+	MaxTrackedAsyncSubmissions int
+	// SharedTxSub shares the pending transaction submissions between the
+	// instances using the same database.
+	SharedTxSub bool
 	// SkipTxmeta, when enabled, will not store meta xdr in history transaction table
 	SkipTxmeta bool
 	// EmitVerboseMeta, when enabled will include all kinds of events in txMeta - diagnosticEvents/classicEvents
 	// SkipTxMeta and EmitVerboseMeta dont go hand in hand. i.e EmitVerboseMeta cannot be TRUE if SkipTxMeta is set to TRUE
 	EmitVerboseMeta bool
+	// LedgerSinkURL is the optional destination (file:// or http(s):// URL) to
+	// which the ingested ledgers are published
+	LedgerSinkURL string
+	// EnableSubscriptions enables the delivery of the ingested transactions
+	// to the callback urls of the subscriptions
+	EnableSubscriptions bool
+	// SubscriptionDeliveryRetention is the duration for which the delivered
+	// and failed deliveries of the subscriptions are kept, 0 keeps them forever
+	SubscriptionDeliveryRetention time.Duration
 }
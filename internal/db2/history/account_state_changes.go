@@ -0,0 +1,111 @@
+package history
+
+import (
+	"context"
+
+	sq "github.com/Masterminds/squirrel"
+	"github.com/guregu/null"
+
+	"github.com/stellar/go-stellar-sdk/support/db"
+	"github.com/stellar/go-stellar-sdk/toid"
+)
+
+// AccountStateChange is a row of the history_account_state_changes table. It
+// records an account, trustline or data ledger entry of an account as it was
+// before being changed in a ledger. PreEntry is the base64 encoded LedgerEntry,
+// it's not valid when the entry was created in the ledger.
+type AccountStateChange struct {
+	AccountID string      `db:"account_id"`
+	LedgerKey string      `db:"ledger_key"`
+	LedgerID  int64       `db:"history_ledger_id"`
+	PreEntry  null.String `db:"pre_entry"`
+}
+
+// Ledger returns the sequence of the ledger in which the entry changed.
+func (c AccountStateChange) Ledger() uint32 {
+	return uint32(toid.Parse(c.LedgerID).LedgerSequence)
+}
+
+// QAccountStateChanges defines the account state change log related queries.
+type QAccountStateChanges interface {
+	NewAccountStateChangeBatchInsertBuilder() AccountStateChangeBatchInsertBuilder
+	DeleteAccountStateChangesRange(ctx context.Context, start, end int64) (int64, error)
+}
+
+// AccountStateChangeBatchInsertBuilder is used to insert changes into the
+// history_account_state_changes table
+type AccountStateChangeBatchInsertBuilder interface {
+	Add(change AccountStateChange) error
+	Exec(ctx context.Context) error
+	Len() int
+}
+
+type accountStateChangeBatchInsertBuilder struct {
+	session db.SessionInterface
+	builder db.FastBatchInsertBuilder
+	table   string
+}
+
+// NewAccountStateChangeBatchInsertBuilder constructs a new
+// AccountStateChangeBatchInsertBuilder instance
+func (q *Q) NewAccountStateChangeBatchInsertBuilder() AccountStateChangeBatchInsertBuilder {
+	return &accountStateChangeBatchInsertBuilder{
+		session: q,
+		builder: db.FastBatchInsertBuilder{},
+		table:   "history_account_state_changes",
+	}
+}
+
+// Add adds a new change to the batch
+func (i *accountStateChangeBatchInsertBuilder) Add(change AccountStateChange) error {
+	return i.builder.RowStruct(change)
+}
+
+// Exec writes the batch of changes to the database.
+func (i *accountStateChangeBatchInsertBuilder) Exec(ctx context.Context) error {
+	return i.builder.Exec(ctx, i.session, i.table)
+}
+
+// Len returns the number of elements in the batch
+func (i *accountStateChangeBatchInsertBuilder) Len() int {
+	return i.builder.Len()
+}
+
+// DeleteAccountStateChangesRange deletes the account state changes of the
+// ledgers in the given toid range. Like asset stat snapshots, the changes are
+// not deleted by DeleteRangeAll because reingestion does not replay them.
+func (q *Q) DeleteAccountStateChangesRange(ctx context.Context, start, end int64) (int64, error) {
+	return q.DeleteRange(ctx, start, end, "history_account_state_changes", "history_ledger_id")
+}
+
+// AccountStateChangesAfter returns the first change after the given ledger of
+// every ledger entry of the account which changed after it. The state of the
+// account at the end of the ledger is made of the entries before these changes
+// and of the current entries which didn't change since.
+func (q *Q) AccountStateChangesAfter(ctx context.Context, accountID string, ledger uint32) ([]AccountStateChange, error) {
+	sql := sq.Select(
+		"DISTINCT ON (hasc.ledger_key) hasc.account_id",
+		"hasc.ledger_key",
+		"hasc.history_ledger_id",
+		"hasc.pre_entry",
+	).From("history_account_state_changes hasc").
+		Where("hasc.account_id = ?", accountID).
+		Where("hasc.history_ledger_id >= ?", toid.New(int32(ledger+1), 0, 0).ToInt64()).
+		OrderBy("hasc.ledger_key", "hasc.history_ledger_id ASC")
+
+	var changes []AccountStateChange
+	err := q.Select(ctx, &changes, sql)
+	return changes, err
+}
+
+// OldestAccountStateChangeLedger returns the ledger of the oldest change in
+// the account state change log, or 0 if the log is empty. The log has every
+// change from that ledger on.
+func (q *Q) OldestAccountStateChangeLedger(ctx context.Context) (uint32, error) {
+	var id int64
+	sql := sq.Select("COALESCE(MIN(history_ledger_id), 0)").From("history_account_state_changes")
+	if err := q.Get(ctx, &id, sql); err != nil {
+		return 0, err
+	}
+	return uint32(toid.Parse(id).LedgerSequence), nil
+}
@@ -0,0 +1,74 @@
+package history
+
+import (
+	"database/sql"
+	"testing"
+
+	"github.com/guregu/null"
+
+	"github.com/stellar/go-stellar-sdk/toid"
+	"github.com/stellar/stellar-horizon/internal/test"
+)
+
+func TestAccountStateChanges(t *testing.T) {
+	tt := test.Start(t)
+	defer tt.Finish()
+	test.ResetHorizonDB(t, tt.HorizonDB)
+	q := &Q{tt.HorizonSession()}
+	tt.Assert.NoError(q.BeginTx(tt.Ctx, &sql.TxOptions{}))
+	defer func() {
+		_ = q.Rollback()
+	}()
+
+	oldest, err := q.OldestAccountStateChangeLedger(tt.Ctx)
+	tt.Assert.NoError(err)
+	tt.Assert.Equal(uint32(0), oldest)
+
+	account := "GAOQJGUAB7NI7K7I62ORBXMN3J4SSWQUQ7FOEPSDJ322W2HMCNWPHXFB"
+	ledgerID := func(ledger int32) int64 {
+		return toid.New(ledger, 0, 0).ToInt64()
+	}
+	builder := q.NewAccountStateChangeBatchInsertBuilder()
+	for _, change := range []AccountStateChange{
+		// the account was created in ledger 10 and changed in ledgers 12
+		// and 14, its data entry was created in ledger 13
+		{AccountID: account, LedgerKey: "account", LedgerID: ledgerID(10)},
+		{AccountID: account, LedgerKey: "account", LedgerID: ledgerID(12), PreEntry: null.StringFrom("a10")},
+		{AccountID: account, LedgerKey: "data", LedgerID: ledgerID(13)},
+		{AccountID: account, LedgerKey: "account", LedgerID: ledgerID(14), PreEntry: null.StringFrom("a12")},
+		{AccountID: "GBRPYHIL2CI3FNQ4BXLFMNDLFJUNPU2HY3ZMFSHONUCEOASW7QC7OX2H", LedgerKey: "other", LedgerID: ledgerID(11)},
+	} {
+		tt.Assert.NoError(builder.Add(change))
+	}
+	tt.Assert.NoError(builder.Exec(tt.Ctx))
+
+	oldest, err = q.OldestAccountStateChangeLedger(tt.Ctx)
+	tt.Assert.NoError(err)
+	tt.Assert.Equal(uint32(10), oldest)
+
+	changes, err := q.AccountStateChangesAfter(tt.Ctx, account, 11)
+	tt.Assert.NoError(err)
+	tt.Assert.Len(changes, 2)
+	tt.Assert.Equal("account", changes[0].LedgerKey)
+	tt.Assert.Equal(uint32(12), changes[0].Ledger())
+	tt.Assert.Equal("a10", changes[0].PreEntry.String)
+	tt.Assert.Equal("data", changes[1].LedgerKey)
+	tt.Assert.False(changes[1].PreEntry.Valid)
+
+	changes, err = q.AccountStateChangesAfter(tt.Ctx, account, 13)
+	tt.Assert.NoError(err)
+	tt.Assert.Len(changes, 1)
+	tt.Assert.Equal("a12", changes[0].PreEntry.String)
+
+	changes, err = q.AccountStateChangesAfter(tt.Ctx, account, 14)
+	tt.Assert.NoError(err)
+	tt.Assert.Empty(changes)
+
+	deleted, err := q.DeleteAccountStateChangesRange(tt.Ctx, ledgerID(10), ledgerID(12))
+	tt.Assert.NoError(err)
+	tt.Assert.Equal(int64(2), deleted)
+
+	oldest, err = q.OldestAccountStateChangeLedger(tt.Ctx)
+	tt.Assert.NoError(err)
+	tt.Assert.Equal(uint32(12), oldest)
+}
@@ -0,0 +1,193 @@
+package history
+
+import (
+	"context"
+	"encoding/hex"
+	"fmt"
+	"math"
+	"math/big"
+	"strings"
+
+	sq "github.com/Masterminds/squirrel"
+
+	"github.com/stellar/go-stellar-sdk/strkey"
+	"github.com/stellar/go-stellar-sdk/support/errors"
+	"github.com/stellar/go-stellar-sdk/xdr"
+	"github.com/stellar/stellar-horizon/internal/db2"
+)
+
+// AssetHolder is an account holding an asset in a trustline or a contract
+// holding it in a stellar asset contract balance.
+type AssetHolder struct {
+	// AccountID is set when the holder is an account
+	AccountID string `db:"account_id"`
+	// HolderContractID is set when the holder is a contract
+	HolderContractID []byte `db:"holder_contract_id"`
+	// Balance is the balance in stroops
+	Balance            string `db:"balance"`
+	LastModifiedLedger uint32 `db:"last_modified_ledger"`
+}
+
+// Address returns the strkey encoded address of the holder.
+func (h AssetHolder) Address() string {
+	if h.AccountID != "" {
+		return h.AccountID
+	}
+	return strkey.MustEncode(strkey.VersionByteContract, h.HolderContractID)
+}
+
+// PagingToken returns a cursor for this holder which is its balance followed
+// by its address.
+func (h AssetHolder) PagingToken() string {
+	return fmt.Sprintf("%s-%s", h.Balance, h.Address())
+}
+
+// AssetHoldersQuery is a helper struct to configure queries to the holders of
+// an asset. Ascending pages walk the holders from the largest balance down,
+// descending pages from the smallest balance up.
+type AssetHoldersQuery struct {
+	PageQuery   db2.PageQuery
+	AssetType   xdr.AssetType
+	AssetCode   string
+	AssetIssuer string
+}
+
+// Cursor validates and returns the balance and holder key of the query page
+// cursor. The holder key is the account id of accounts and the hex encoded id
+// of contracts prefixed by C, which is the key holders of the same balance are
+// ordered by.
+func (q AssetHoldersQuery) Cursor() (*big.Int, string, error) {
+	if q.PageQuery.Cursor == "" {
+		return nil, "", nil
+	}
+	parts := strings.SplitN(q.PageQuery.Cursor, "-", 2)
+	if len(parts) != 2 {
+		return nil, "", errors.New("cursor must be a balance and an address separated by -")
+	}
+	balance, ok := new(big.Int).SetString(parts[0], 10)
+	if !ok || balance.Sign() < 0 {
+		return nil, "", errors.New("cursor balance must be a non-negative integer")
+	}
+
+	switch {
+	case strkey.IsValidEd25519PublicKey(parts[1]):
+		return balance, parts[1], nil
+	case strkey.IsValidContractAddress(parts[1]):
+		contractID := strkey.MustDecode(strkey.VersionByteContract, parts[1])
+		return balance, "C" + hex.EncodeToString(contractID), nil
+	default:
+		return nil, "", errors.New("cursor address must be an account or a contract address")
+	}
+}
+
+// holderCursorCondition returns the condition selecting the holders after the
+// cursor in the order of the page. balanceColumn can only hold balances up to
+// maxBalance, a cursor balance above it selects either all or none of them.
+func holderCursorCondition(
+	balanceColumn, keyColumn string,
+	maxBalance *big.Int,
+	balance *big.Int,
+	key string,
+	order string,
+) sq.Sqlizer {
+	if balance == nil {
+		return sq.Expr("true")
+	}
+	op := "<"
+	if order == db2.OrderDescending {
+		op = ">"
+	}
+	if maxBalance != nil && balance.Cmp(maxBalance) > 0 {
+		return sq.Expr(fmt.Sprintf("%t", op == "<"))
+	}
+	return sq.Expr(
+		fmt.Sprintf("(%s, %s) %s (?, ?)", balanceColumn, keyColumn, op),
+		balance.String(), key,
+	)
+}
+
+// GetAssetHolders returns a page of the accounts and contracts holding an
+// asset, ordered by balance and then by holder. Holders with a zero balance
+// are omitted.
+func (q *Q) GetAssetHolders(ctx context.Context, query AssetHoldersQuery) ([]AssetHolder, error) {
+	balance, key, err := query.Cursor()
+	if err != nil {
+		return nil, err
+	}
+
+	var order string
+	switch query.PageQuery.Order {
+	case db2.OrderAscending:
+		order = "DESC"
+	case db2.OrderDescending:
+		order = "ASC"
+	default:
+		return nil, errors.Errorf("invalid paging order: %s", query.PageQuery.Order)
+	}
+
+	// every holder type is paged on its own so that the trustlines are read
+	// in the order of the trust_lines_by_type_code_issuer_balance index
+	trustLines := sq.Select(
+		"tl.account_id",
+		"NULL::bytea AS holder_contract_id",
+		"tl.balance::numeric AS balance",
+		"tl.account_id AS holder_key",
+		"tl.last_modified_ledger",
+	).From("trust_lines tl").
+		Where(map[string]interface{}{
+			"tl.asset_type":   query.AssetType,
+			"tl.asset_code":   query.AssetCode,
+			"tl.asset_issuer": query.AssetIssuer,
+		}).
+		Where("tl.balance > 0").
+		Where(holderCursorCondition(
+			"tl.balance", "tl.account_id", big.NewInt(math.MaxInt64), balance, key, query.PageQuery.Order,
+		)).
+		OrderBy("tl.balance "+order, "tl.account_id "+order).
+		Limit(query.PageQuery.Limit)
+
+	contractKey := "'C' || encode(cab.holder_contract_id, 'hex')"
+	contracts := sq.Select(
+		"NULL AS account_id",
+		"cab.holder_contract_id",
+		"cab.amount AS balance",
+		contractKey+" AS holder_key",
+		"cab.last_modified_ledger",
+	).From("contract_asset_balances cab").
+		Join("asset_contracts ac ON ac.contract_id = cab.asset_contract_id").
+		Where(map[string]interface{}{
+			"ac.asset_type":   query.AssetType,
+			"ac.asset_code":   query.AssetCode,
+			"ac.asset_issuer": query.AssetIssuer,
+		}).
+		Where("cab.amount > 0").
+		Where(holderCursorCondition(
+			"cab.amount", contractKey, nil, balance, key, query.PageQuery.Order,
+		)).
+		OrderBy("cab.amount "+order, "holder_key "+order).
+		Limit(query.PageQuery.Limit)
+
+	trustLinesSQL, trustLinesArgs, err := trustLines.ToSql()
+	if err != nil {
+		return nil, errors.Wrap(err, "could not build trustline holders query")
+	}
+	contractsSQL, contractsArgs, err := contracts.ToSql()
+	if err != nil {
+		return nil, errors.Wrap(err, "could not build contract holders query")
+	}
+
+	sql := fmt.Sprintf(`SELECT
+		COALESCE(h.account_id, '') AS account_id,
+		h.holder_contract_id,
+		CAST(h.balance AS text) AS balance,
+		h.last_modified_ledger
+	FROM ((%s) UNION ALL (%s)) h
+	ORDER BY h.balance %s, h.holder_key %s
+	LIMIT %d`, trustLinesSQL, contractsSQL, order, order, query.PageQuery.Limit)
+
+	var rows []AssetHolder
+	if err = q.SelectRaw(ctx, &rows, sql, append(trustLinesArgs, contractsArgs...)...); err != nil {
+		return nil, errors.Wrap(err, "could not run select query")
+	}
+	return rows, nil
+}
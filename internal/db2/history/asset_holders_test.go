@@ -0,0 +1,134 @@
+package history
+
+import (
+	"encoding/hex"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/stellar/go-stellar-sdk/strkey"
+	"github.com/stellar/go-stellar-sdk/xdr"
+	"github.com/stellar/stellar-horizon/internal/db2"
+	"github.com/stellar/stellar-horizon/internal/test"
+)
+
+func TestAssetHoldersQueryCursor(t *testing.T) {
+	contractID := xdr.Hash{1}
+	contract := strkey.MustEncode(strkey.VersionByteContract, contractID[:])
+
+	query := AssetHoldersQuery{}
+	balance, key, err := query.Cursor()
+	assert.NoError(t, err)
+	assert.Nil(t, balance)
+	assert.Empty(t, key)
+
+	query.PageQuery.Cursor = "10000-" + usdTrustLine.AccountID
+	balance, key, err = query.Cursor()
+	assert.NoError(t, err)
+	assert.Equal(t, "10000", balance.String())
+	assert.Equal(t, usdTrustLine.AccountID, key)
+
+	query.PageQuery.Cursor = "170141183460469231731687303715884105727-" + contract
+	balance, key, err = query.Cursor()
+	assert.NoError(t, err)
+	assert.Equal(t, "170141183460469231731687303715884105727", balance.String())
+	assert.Equal(t, "C"+hex.EncodeToString(contractID[:]), key)
+
+	for _, cursor := range []string{
+		"10000",
+		"-10000-" + usdTrustLine.AccountID,
+		"abc-" + usdTrustLine.AccountID,
+		"10000-GABC",
+	} {
+		query.PageQuery.Cursor = cursor
+		_, _, err = query.Cursor()
+		assert.Error(t, err, cursor)
+	}
+}
+
+func TestGetAssetHolders(t *testing.T) {
+	tt := test.Start(t)
+	defer tt.Finish()
+	test.ResetHorizonDB(t, tt.HorizonDB)
+	q := &Q{tt.HorizonSession()}
+
+	usdID, holderID := xdr.Hash{3}, xdr.Hash{4}
+	emptyHolderID := xdr.Hash{5}
+	holder := strkey.MustEncode(strkey.VersionByteContract, holderID[:])
+
+	tt.Require.NoError(q.Begin(tt.Ctx))
+	tt.Require.NoError(q.UpsertTrustLines(tt.Ctx, []TrustLine{eurTrustLine, usdTrustLine, usdTrustLine2}))
+	tt.Require.NoError(q.InsertAssetContracts(tt.Ctx, []AssetContract{
+		{
+			KeyHash:          hashBytes(xdr.Hash{13}),
+			ContractID:       hashBytes(usdID),
+			AssetType:        usdTrustLine.AssetType,
+			AssetCode:        usdTrustLine.AssetCode,
+			AssetIssuer:      usdTrustLine.AssetIssuer,
+			ExpirationLedger: 100,
+		},
+	}))
+	tt.Require.NoError(q.InsertContractAssetBalances(tt.Ctx, []ContractAssetBalance{
+		{
+			KeyHash:            hashBytes(xdr.Hash{21}),
+			ContractID:         hashBytes(usdID),
+			HolderContractID:   hashBytes(holderID),
+			Amount:             "170141183460469231731687303715884105727",
+			ExpirationLedger:   100,
+			LastModifiedLedger: 50,
+		},
+		{
+			KeyHash:            hashBytes(xdr.Hash{22}),
+			ContractID:         hashBytes(usdID),
+			HolderContractID:   hashBytes(emptyHolderID),
+			Amount:             "0",
+			ExpirationLedger:   100,
+			LastModifiedLedger: 50,
+		},
+	}))
+	tt.Require.NoError(q.Commit())
+
+	contractHolder := AssetHolder{
+		HolderContractID:   hashBytes(holderID),
+		Balance:            "170141183460469231731687303715884105727",
+		LastModifiedLedger: 50,
+	}
+	usdHolder := AssetHolder{
+		AccountID:          usdTrustLine.AccountID,
+		Balance:            "10000",
+		LastModifiedLedger: usdTrustLine.LastModifiedLedger,
+	}
+	usdHolder2 := AssetHolder{
+		AccountID:          usdTrustLine2.AccountID,
+		Balance:            "10000",
+		LastModifiedLedger: usdTrustLine2.LastModifiedLedger,
+	}
+	query := AssetHoldersQuery{
+		PageQuery:   db2.PageQuery{Order: db2.OrderAscending, Limit: 10},
+		AssetType:   usdTrustLine.AssetType,
+		AssetCode:   usdTrustLine.AssetCode,
+		AssetIssuer: usdTrustLine.AssetIssuer,
+	}
+
+	holders, err := q.GetAssetHolders(tt.Ctx, query)
+	tt.Require.NoError(err)
+	tt.Assert.Equal([]AssetHolder{contractHolder, usdHolder, usdHolder2}, holders)
+	tt.Assert.Equal("170141183460469231731687303715884105727-"+holder, holders[0].PagingToken())
+
+	query.PageQuery.Cursor = holders[1].PagingToken()
+	holders, err = q.GetAssetHolders(tt.Ctx, query)
+	tt.Require.NoError(err)
+	tt.Assert.Equal([]AssetHolder{usdHolder2}, holders)
+
+	query.PageQuery.Cursor = ""
+	query.PageQuery.Order = db2.OrderDescending
+	query.PageQuery.Limit = 2
+	holders, err = q.GetAssetHolders(tt.Ctx, query)
+	tt.Require.NoError(err)
+	tt.Assert.Equal([]AssetHolder{usdHolder2, usdHolder}, holders)
+
+	query.PageQuery.Cursor = holders[1].PagingToken()
+	holders, err = q.GetAssetHolders(tt.Ctx, query)
+	tt.Require.NoError(err)
+	tt.Assert.Equal([]AssetHolder{contractHolder}, holders)
+}
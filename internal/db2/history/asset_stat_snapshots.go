@@ -0,0 +1,183 @@
+package history
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	sq "github.com/Masterminds/squirrel"
+	"github.com/lib/pq"
+
+	"github.com/stellar/go-stellar-sdk/support/errors"
+	"github.com/stellar/go-stellar-sdk/toid"
+	"github.com/stellar/go-stellar-sdk/xdr"
+	"github.com/stellar/stellar-horizon/internal/db2"
+)
+
+// InsertAssetStatSnapshots records in the history_asset_stats table the
+// current stats of the assets of the given deltas and of the assets of the
+// given stellar asset contracts. It must be called once the changes of the
+// ledger were applied to the asset stats tables. Removed stats are recorded as
+// empty objects, which are scanned as zero stats.
+func (q *Q) InsertAssetStatSnapshots(
+	ctx context.Context,
+	ledger uint32,
+	closedAt time.Time,
+	deltas []ExpAssetStat,
+	contractIDs []xdr.ContractId,
+) error {
+	if len(deltas) == 0 && len(contractIDs) == 0 {
+		return nil
+	}
+
+	assetTypes := make([]int32, len(deltas))
+	assetCodes := make([]string, len(deltas))
+	assetIssuers := make([]string, len(deltas))
+	for i, delta := range deltas {
+		assetTypes[i] = int32(delta.AssetType)
+		assetCodes[i] = delta.AssetCode
+		assetIssuers[i] = delta.AssetIssuer
+	}
+	contracts := make([][]byte, len(contractIDs))
+	for i := range contractIDs {
+		contracts[i] = contractIDs[i][:]
+	}
+
+	sql := `INSERT INTO history_asset_stats
+		(asset_type, asset_code, asset_issuer, history_ledger_id, closed_at, accounts, balances, contracts)
+	SELECT a.asset_type, a.asset_code, a.asset_issuer, ?, ?,
+		COALESCE(es.accounts, '{}'), COALESCE(es.balances, '{}'), COALESCE(cas.stat, '{}')
+	FROM (
+		SELECT * FROM unnest(?::int[], ?::text[], ?::text[]) AS t(asset_type, asset_code, asset_issuer)
+		UNION
+		SELECT asset_type, asset_code, asset_issuer FROM asset_contracts WHERE contract_id = ANY(?::bytea[])
+	) a
+	LEFT JOIN exp_asset_stats es ON
+		es.asset_type = a.asset_type AND es.asset_code = a.asset_code AND es.asset_issuer = a.asset_issuer
+	LEFT JOIN asset_contracts ac ON
+		ac.asset_type = a.asset_type AND ac.asset_code = a.asset_code AND ac.asset_issuer = a.asset_issuer
+	LEFT JOIN contract_asset_stats cas ON cas.contract_id = ac.contract_id`
+
+	_, err := q.ExecRaw(
+		ctx,
+		sql,
+		toid.New(int32(ledger), 0, 0).ToInt64(),
+		closedAt.UTC(),
+		pq.Array(assetTypes),
+		pq.Array(assetCodes),
+		pq.Array(assetIssuers),
+		pq.ByteaArray(contracts),
+	)
+	return err
+}
+
+// DeleteAssetStatSnapshotsRange deletes the asset stat snapshots of the
+// ledgers in the given toid range. The snapshots are not deleted with the
+// rest of the history by DeleteRangeAll because they are recorded by the
+// ingestion of ledger entry changes, which reingestion does not replay.
+func (q *Q) DeleteAssetStatSnapshotsRange(ctx context.Context, start, end int64) (int64, error) {
+	return q.DeleteRange(ctx, start, end, "history_asset_stats", "history_ledger_id")
+}
+
+// AssetStatSnapshot is a snapshot of the stats of an asset at the end of a
+// ledger, or at the end of a bucket of ledgers when Timestamp is set.
+type AssetStatSnapshot struct {
+	Timestamp int64                `db:"timestamp"`
+	LedgerID  int64                `db:"history_ledger_id"`
+	ClosedAt  time.Time            `db:"closed_at"`
+	Accounts  ExpAssetStatAccounts `db:"accounts"`
+	Balances  ExpAssetStatBalances `db:"balances"`
+	Contracts ContractStat         `db:"contracts"`
+}
+
+func assetStatSnapshotsWhere(sql sq.SelectBuilder, assetType xdr.AssetType, assetCode, assetIssuer string) sq.SelectBuilder {
+	return sql.Where(map[string]interface{}{
+		"has.asset_type":   assetType,
+		"has.asset_code":   assetCode,
+		"has.asset_issuer": assetIssuer,
+	})
+}
+
+// AssetStatSnapshots returns the per ledger snapshots of the stats of an
+// asset, paged by ledger toid.
+func (q *Q) AssetStatSnapshots(
+	ctx context.Context,
+	assetType xdr.AssetType,
+	assetCode, assetIssuer string,
+	page db2.PageQuery,
+) ([]AssetStatSnapshot, error) {
+	sql, err := page.ApplyTo(
+		assetStatSnapshotsWhere(
+			sq.Select(
+				"0 as timestamp",
+				"has.history_ledger_id",
+				"has.closed_at",
+				"has.accounts",
+				"has.balances",
+				"has.contracts",
+			).From("history_asset_stats has"),
+			assetType, assetCode, assetIssuer,
+		),
+		"has.history_ledger_id",
+	)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not apply query to page")
+	}
+
+	var snapshots []AssetStatSnapshot
+	err = q.Select(ctx, &snapshots, sql)
+	return snapshots, err
+}
+
+// AssetStatSnapshotBuckets returns the snapshots of the stats of an asset at
+// the end of buckets of the given resolution in milliseconds, paged by bucket
+// timestamp. Buckets in which the stats didn't change are omitted.
+func (q *Q) AssetStatSnapshotBuckets(
+	ctx context.Context,
+	assetType xdr.AssetType,
+	assetCode, assetIssuer string,
+	resolution int64,
+	page db2.PageQuery,
+) ([]AssetStatSnapshot, error) {
+	if resolution <= 0 {
+		return nil, errors.New("resolution must be positive")
+	}
+	cursor, err := page.CursorInt64()
+	if err != nil {
+		return nil, err
+	}
+
+	// the snapshots are ordered by ledger so last() picks the stats at the
+	// end of the bucket
+	snapshots := assetStatSnapshotsWhere(
+		sq.Select(
+			fmt.Sprintf("to_millis(has.closed_at, %d) as timestamp", resolution),
+			"has.*",
+		).From("history_asset_stats has"),
+		assetType, assetCode, assetIssuer,
+	).OrderBy("has.history_ledger_id ASC")
+
+	sql := sq.Select(
+		"timestamp",
+		"max(history_ledger_id) as history_ledger_id",
+		"max(closed_at) as closed_at",
+		"last(accounts) as accounts",
+		"last(balances) as balances",
+		"last(contracts) as contracts",
+	).FromSelect(snapshots, "snapshots").
+		GroupBy("timestamp").
+		Limit(page.Limit)
+
+	switch page.Order {
+	case db2.OrderAscending:
+		sql = sql.Having("timestamp > ?", cursor).OrderBy("timestamp ASC")
+	case db2.OrderDescending:
+		sql = sql.Having("timestamp < ?", cursor).OrderBy("timestamp DESC")
+	default:
+		return nil, db2.ErrInvalidOrder
+	}
+
+	var buckets []AssetStatSnapshot
+	err = q.Select(ctx, &buckets, sql)
+	return buckets, err
+}
@@ -0,0 +1,73 @@
+package history
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stellar/go-stellar-sdk/xdr"
+
+	"github.com/stellar/stellar-horizon/internal/db2"
+	"github.com/stellar/stellar-horizon/internal/test"
+)
+
+func TestAssetStatSnapshots(t *testing.T) {
+	tt := test.Start(t)
+	defer tt.Finish()
+	test.ResetHorizonDB(t, tt.HorizonDB)
+	q := &Q{tt.HorizonSession()}
+
+	issuer := "GBRPYHIL2CI3FNQ4BXLFMNDLFJUNPU2HY3ZMFSHONUCEOASW7QC7OX2H"
+	stat := ExpAssetStat{
+		AssetType:   xdr.AssetTypeAssetTypeCreditAlphanum4,
+		AssetCode:   "USD",
+		AssetIssuer: issuer,
+		Accounts:    ExpAssetStatAccounts{Authorized: 1},
+		Balances: ExpAssetStatBalances{
+			Authorized:                      "100",
+			AuthorizedToMaintainLiabilities: "0",
+			Unauthorized:                    "0",
+			ClaimableBalances:               "0",
+			LiquidityPools:                  "0",
+		},
+	}
+	closedAt := time.Date(2023, 3, 1, 10, 0, 0, 0, time.UTC)
+
+	// the stats of an asset changed in ledgers 10 and 11 and removed in
+	// ledger 12, an hour later
+	_, err := q.InsertAssetStat(tt.Ctx, stat)
+	tt.Assert.NoError(err)
+	tt.Assert.NoError(q.InsertAssetStatSnapshots(tt.Ctx, 10, closedAt, []ExpAssetStat{stat}, nil))
+
+	stat.Accounts.Authorized = 2
+	stat.Balances.Authorized = "250"
+	_, err = q.UpdateAssetStat(tt.Ctx, stat)
+	tt.Assert.NoError(err)
+	tt.Assert.NoError(q.InsertAssetStatSnapshots(tt.Ctx, 11, closedAt.Add(time.Minute), []ExpAssetStat{stat}, nil))
+
+	_, err = q.RemoveAssetStat(tt.Ctx, stat.AssetType, stat.AssetCode, stat.AssetIssuer)
+	tt.Assert.NoError(err)
+	tt.Assert.NoError(q.InsertAssetStatSnapshots(tt.Ctx, 12, closedAt.Add(time.Hour), []ExpAssetStat{stat}, nil))
+
+	snapshots, err := q.AssetStatSnapshots(tt.Ctx, stat.AssetType, "USD", issuer, db2.PageQuery{Order: db2.OrderAscending, Limit: 10})
+	tt.Assert.NoError(err)
+	tt.Assert.Len(snapshots, 3)
+	tt.Assert.Equal(int32(1), snapshots[0].Accounts.Authorized)
+	tt.Assert.Equal("100", snapshots[0].Balances.Authorized)
+	tt.Assert.Equal("250", snapshots[1].Balances.Authorized)
+	tt.Assert.Equal("0", snapshots[1].Contracts.ActiveBalance)
+	tt.Assert.True(snapshots[2].Accounts.IsZero())
+	tt.Assert.Equal("0", snapshots[2].Balances.Authorized)
+
+	hour := int64(time.Hour / time.Millisecond)
+	buckets, err := q.AssetStatSnapshotBuckets(tt.Ctx, stat.AssetType, "USD", issuer, hour, db2.PageQuery{Order: db2.OrderAscending, Limit: 10})
+	tt.Assert.NoError(err)
+	tt.Assert.Len(buckets, 2)
+	tt.Assert.Equal(closedAt.UnixMilli(), buckets[0].Timestamp)
+	tt.Assert.Equal(snapshots[1].LedgerID, buckets[0].LedgerID)
+	tt.Assert.Equal("250", buckets[0].Balances.Authorized)
+	tt.Assert.Equal("0", buckets[1].Balances.Authorized)
+
+	deleted, err := q.DeleteAssetStatSnapshotsRange(tt.Ctx, snapshots[0].LedgerID, snapshots[2].LedgerID)
+	tt.Assert.NoError(err)
+	tt.Assert.Equal(int64(2), deleted)
+}
@@ -143,11 +143,16 @@ type ContractAssetBalance struct {
 	KeyHash []byte `db:"key_hash"`
 	// ContractID is the contract id of the stellar asset contract
 	ContractID []byte `db:"asset_contract_id"`
+	// HolderContractID is the contract id of the contract holding the balance
+	HolderContractID []byte `db:"holder_contract_id"`
 	// Amount is the amount held by the contract
 	Amount string `db:"amount"`
 	// ExpirationLedger is the latest ledger for which this contract balance
 	// ledger entry is active
 	ExpirationLedger uint32 `db:"expiration_ledger"`
+	// LastModifiedLedger is the ledger in which the amount of the balance
+	// last changed
+	LastModifiedLedger uint32 `db:"last_modified_ledger"`
 }
 
 // InsertContractAssetBalances will insert the given list of rows into the contract_asset_balances table
@@ -172,11 +177,11 @@ func (q *Q) InsertContractAssetBalances(ctx context.Context, rows []ContractAsse
 
 const maxUpdateBatchSize = 30000
 
-// UpdateContractAssetBalanceAmounts will update the expiration ledgers for the given list of keys
-// (if they exist in the db).
-func (q *Q) UpdateContractAssetBalanceAmounts(ctx context.Context, keys []xdr.Hash, amounts []string) error {
+// UpdateContractAssetBalanceAmounts will update the amounts for the given list of keys
+// (if they exist in the db) and mark them as last modified at the given ledger.
+func (q *Q) UpdateContractAssetBalanceAmounts(ctx context.Context, keys []xdr.Hash, amounts []string, ledger uint32) error {
 	for len(keys) > 0 {
-		var args []interface{}
+		args := []interface{}{ledger}
 		var values []string
 
 		for i := 0; len(keys) > 0 && i < maxUpdateBatchSize; i++ {
@@ -189,7 +194,8 @@ func (q *Q) UpdateContractAssetBalanceAmounts(ctx context.Context, keys []xdr.Ha
 		sql := fmt.Sprintf(`
 			UPDATE contract_asset_balances
 			SET
-			  amount = myvalues.amount
+			  amount = myvalues.amount,
+			  last_modified_ledger = ?
 			FROM (
 			  VALUES
 				%s
@@ -1091,19 +1091,23 @@ func TestInsertContractAssetBalances(t *testing.T) {
 	keyHash := [32]byte{}
 	contractID := [32]byte{1}
 	balance := ContractAssetBalance{
-		KeyHash:          keyHash[:],
-		ContractID:       contractID[:],
-		Amount:           "100",
-		ExpirationLedger: 10,
+		KeyHash:            keyHash[:],
+		ContractID:         contractID[:],
+		HolderContractID:   []byte{5},
+		Amount:             "100",
+		ExpirationLedger:   10,
+		LastModifiedLedger: 5,
 	}
 
 	otherKeyHash := [32]byte{2}
 	otherContractID := [32]byte{3}
 	otherBalance := ContractAssetBalance{
-		KeyHash:          otherKeyHash[:],
-		ContractID:       otherContractID[:],
-		Amount:           "101",
-		ExpirationLedger: 11,
+		KeyHash:            otherKeyHash[:],
+		ContractID:         otherContractID[:],
+		HolderContractID:   []byte{6},
+		Amount:             "101",
+		ExpirationLedger:   11,
+		LastModifiedLedger: 6,
 	}
 
 	tt.Assert.NoError(
@@ -1130,19 +1134,23 @@ func TestRemoveContractAssetBalances(t *testing.T) {
 	keyHash := [32]byte{}
 	contractID := [32]byte{1}
 	balance := ContractAssetBalance{
-		KeyHash:          keyHash[:],
-		ContractID:       contractID[:],
-		Amount:           "100",
-		ExpirationLedger: 10,
+		KeyHash:            keyHash[:],
+		ContractID:         contractID[:],
+		HolderContractID:   []byte{5},
+		Amount:             "100",
+		ExpirationLedger:   10,
+		LastModifiedLedger: 5,
 	}
 
 	otherKeyHash := [32]byte{2}
 	otherContractID := [32]byte{3}
 	otherBalance := ContractAssetBalance{
-		KeyHash:          otherKeyHash[:],
-		ContractID:       otherContractID[:],
-		Amount:           "101",
-		ExpirationLedger: 11,
+		KeyHash:            otherKeyHash[:],
+		ContractID:         otherContractID[:],
+		HolderContractID:   []byte{6},
+		Amount:             "101",
+		ExpirationLedger:   11,
+		LastModifiedLedger: 6,
 	}
 
 	tt.Assert.NoError(
@@ -1181,19 +1189,23 @@ func TestUpdateContractAssetBalanceAmounts(t *testing.T) {
 	keyHash := [32]byte{}
 	contractID := [32]byte{1}
 	balance := ContractAssetBalance{
-		KeyHash:          keyHash[:],
-		ContractID:       contractID[:],
-		Amount:           "100",
-		ExpirationLedger: 10,
+		KeyHash:            keyHash[:],
+		ContractID:         contractID[:],
+		HolderContractID:   []byte{5},
+		Amount:             "100",
+		ExpirationLedger:   10,
+		LastModifiedLedger: 5,
 	}
 
 	otherKeyHash := [32]byte{2}
 	otherContractID := [32]byte{3}
 	otherBalance := ContractAssetBalance{
-		KeyHash:          otherKeyHash[:],
-		ContractID:       otherContractID[:],
-		Amount:           "101",
-		ExpirationLedger: 11,
+		KeyHash:            otherKeyHash[:],
+		ContractID:         otherContractID[:],
+		HolderContractID:   []byte{6},
+		Amount:             "101",
+		ExpirationLedger:   11,
+		LastModifiedLedger: 6,
 	}
 
 	tt.Assert.NoError(
@@ -1207,6 +1219,7 @@ func TestUpdateContractAssetBalanceAmounts(t *testing.T) {
 			context.Background(),
 			[]xdr.Hash{otherKeyHash, keyHash, nonExistantKeyHash},
 			[]string{"1", "2", "3"},
+			20,
 		),
 	)
 
@@ -1214,7 +1227,9 @@ func TestUpdateContractAssetBalanceAmounts(t *testing.T) {
 	tt.Assert.NoError(err)
 
 	balance.Amount = "2"
+	balance.LastModifiedLedger = 20
 	otherBalance.Amount = "1"
+	otherBalance.LastModifiedLedger = 20
 	assertContractAssetBalancesEqual(t, balances, []ContractAssetBalance{balance, otherBalance})
 
 	tt.Assert.NoError(q.Rollback())
@@ -1231,19 +1246,23 @@ func TestUpdateContractAssetBalanceExpirations(t *testing.T) {
 	keyHash := [32]byte{}
 	contractID := [32]byte{1}
 	balance := ContractAssetBalance{
-		KeyHash:          keyHash[:],
-		ContractID:       contractID[:],
-		Amount:           "100",
-		ExpirationLedger: 10,
+		KeyHash:            keyHash[:],
+		ContractID:         contractID[:],
+		HolderContractID:   []byte{5},
+		Amount:             "100",
+		ExpirationLedger:   10,
+		LastModifiedLedger: 5,
 	}
 
 	otherKeyHash := [32]byte{2}
 	otherContractID := [32]byte{3}
 	otherBalance := ContractAssetBalance{
-		KeyHash:          otherKeyHash[:],
-		ContractID:       otherContractID[:],
-		Amount:           "101",
-		ExpirationLedger: 11,
+		KeyHash:            otherKeyHash[:],
+		ContractID:         otherContractID[:],
+		HolderContractID:   []byte{6},
+		Amount:             "101",
+		ExpirationLedger:   11,
+		LastModifiedLedger: 6,
 	}
 
 	tt.Assert.NoError(
@@ -0,0 +1,225 @@
+package history
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	sq "github.com/Masterminds/squirrel"
+
+	"github.com/stellar/go-stellar-sdk/support/db"
+	"github.com/stellar/go-stellar-sdk/support/errors"
+	"github.com/stellar/go-stellar-sdk/toid"
+	"github.com/stellar/go-stellar-sdk/xdr"
+	"github.com/stellar/stellar-horizon/internal/db2"
+)
+
+// Reasons of the balance changes. Balance changes of operations which are not
+// payments, trades, liquidity pool deposits and withdrawals or contract
+// invocations have the name of the operation type as reason.
+const (
+	// BalanceChangeReasonFee is the reason of the fees charged and refunded
+	BalanceChangeReasonFee = "fee"
+	// BalanceChangeReasonPayment is the reason of the balance changes of the
+	// source and destination of payments, path payments, account creations and
+	// merges
+	BalanceChangeReasonPayment = "payment"
+	// BalanceChangeReasonTrade is the reason of the balance changes of offers
+	// and of the offers crossed by path payments
+	BalanceChangeReasonTrade = "trade"
+	// BalanceChangeReasonLiquidityPoolDeposit is the reason of the balance
+	// changes of liquidity pool deposits
+	BalanceChangeReasonLiquidityPoolDeposit = "liquidity_pool_deposit"
+	// BalanceChangeReasonLiquidityPoolWithdraw is the reason of the balance
+	// changes of liquidity pool withdrawals
+	BalanceChangeReasonLiquidityPoolWithdraw = "liquidity_pool_withdraw"
+	// BalanceChangeReasonContractTransfer is the reason of the balance changes
+	// of contract invocations, like Stellar Asset Contract transfers
+	BalanceChangeReasonContractTransfer = "contract_transfer"
+)
+
+// InsertBalanceChange represents the arguments to
+// BalanceChangeBatchInsertBuilder.Add() which is used to insert rows into the
+// history_balance_changes table. OperationID is the id of the transaction for
+// fees.
+type InsertBalanceChange struct {
+	OperationID     int64
+	Order           int32
+	TransactionID   int64
+	Account         FutureAccountID
+	Asset           FutureAssetID
+	LedgerCloseTime time.Time
+	Reason          string
+	AmountBefore    int64
+	AmountAfter     int64
+}
+
+// BalanceChangeBatchInsertBuilder is used to insert balance changes into the
+// history_balance_changes table
+type BalanceChangeBatchInsertBuilder interface {
+	Add(change InsertBalanceChange) error
+	Exec(ctx context.Context, session db.SessionInterface) error
+}
+
+type balanceChangeBatchInsertBuilder struct {
+	table   string
+	builder db.FastBatchInsertBuilder
+}
+
+// NewBalanceChangeBatchInsertBuilder constructs a new
+// BalanceChangeBatchInsertBuilder instance
+func (q *Q) NewBalanceChangeBatchInsertBuilder() BalanceChangeBatchInsertBuilder {
+	return &balanceChangeBatchInsertBuilder{
+		table:   "history_balance_changes",
+		builder: db.FastBatchInsertBuilder{},
+	}
+}
+
+// Add adds a new balance change to the batch
+func (i *balanceChangeBatchInsertBuilder) Add(change InsertBalanceChange) error {
+	return i.builder.Row(map[string]interface{}{
+		"history_operation_id":   change.OperationID,
+		"order":                  change.Order,
+		"history_transaction_id": change.TransactionID,
+		"history_account_id":     change.Account,
+		"history_asset_id":       change.Asset,
+		"ledger_closed_at":       change.LedgerCloseTime,
+		"reason":                 change.Reason,
+		"amount_before":          change.AmountBefore,
+		"amount_after":           change.AmountAfter,
+	})
+}
+
+// Exec flushes all pending balance changes to the db
+func (i *balanceChangeBatchInsertBuilder) Exec(ctx context.Context, session db.SessionInterface) error {
+	return i.builder.Exec(ctx, session, i.table)
+}
+
+// QBalanceChanges defines history_balance_changes related queries.
+type QBalanceChanges interface {
+	NewBalanceChangeBatchInsertBuilder() BalanceChangeBatchInsertBuilder
+}
+
+// BalanceChange is a row of data from the `history_balance_changes` table
+type BalanceChange struct {
+	HistoryOperationID   int64     `db:"history_operation_id"`
+	Order                int32     `db:"order"`
+	HistoryTransactionID int64     `db:"history_transaction_id"`
+	TransactionHash      string    `db:"transaction_hash"`
+	Account              string    `db:"account"`
+	AssetType            string    `db:"asset_type"`
+	AssetCode            string    `db:"asset_code"`
+	AssetIssuer          string    `db:"asset_issuer"`
+	LedgerCloseTime      time.Time `db:"ledger_closed_at"`
+	Reason               string    `db:"reason"`
+	AmountBefore         int64     `db:"amount_before"`
+	AmountAfter          int64     `db:"amount_after"`
+}
+
+// ID returns a lexically ordered id for this balance change record
+func (r *BalanceChange) ID() string {
+	return fmt.Sprintf("%019d-%010d", r.HistoryOperationID, r.Order)
+}
+
+// IsFee returns true when the balance change is a fee charged or refunded by
+// the transaction rather than a change made by one of its operations.
+func (r *BalanceChange) IsFee() bool {
+	return r.HistoryOperationID == r.HistoryTransactionID
+}
+
+// LedgerSequence return the ledger in which the balance changed.
+func (r *BalanceChange) LedgerSequence() int32 {
+	return toid.Parse(r.HistoryOperationID).LedgerSequence
+}
+
+// PagingToken returns a cursor for this balance change
+func (r *BalanceChange) PagingToken() string {
+	return fmt.Sprintf("%d-%d", r.HistoryOperationID, r.Order)
+}
+
+// BalanceChangesQuery is a helper struct to configure queries to the balance
+// changes of an account, of all its assets when Asset is nil. FromLedger and
+// ToLedger restrict the balance changes to an inclusive range of ledgers, a
+// zero bound is unbounded.
+type BalanceChangesQuery struct {
+	PageQuery  db2.PageQuery
+	AccountID  string
+	Asset      *xdr.Asset
+	FromLedger uint32
+	ToLedger   uint32
+}
+
+// BalanceChanges returns a page of the balance changes of an account
+func (q *Q) BalanceChanges(ctx context.Context, query BalanceChangesQuery, oldestLedger int32) ([]BalanceChange, error) {
+	op, idx, err := parseEffectsCursor(query.PageQuery)
+	if err != nil {
+		return nil, err
+	}
+
+	var account Account
+	if err = q.AccountByAddress(ctx, &account, query.AccountID); q.NoRows(err) {
+		return nil, nil
+	} else if err != nil {
+		return nil, errors.Wrap(err, "could not load account id")
+	}
+	sql := selectBalanceChange.Where("hbc.history_account_id = ?", account.ID)
+
+	if query.Asset != nil {
+		assetID, err := q.GetAssetID(ctx, *query.Asset)
+		if q.NoRows(err) {
+			return nil, nil
+		} else if err != nil {
+			return nil, errors.Wrap(err, "could not load asset id")
+		}
+		sql = sql.Where("hbc.history_asset_id = ?", assetID)
+	}
+
+	if query.FromLedger > 0 {
+		sql = sql.Where("hbc.history_operation_id >= ?", toid.New(int32(query.FromLedger), 0, 0).ToInt64())
+	}
+	if query.ToLedger > 0 {
+		sql = sql.Where("hbc.history_operation_id < ?", toid.New(int32(query.ToLedger)+1, 0, 0).ToInt64())
+	}
+
+	switch query.PageQuery.Order {
+	case "asc":
+		sql = sql.
+			Where("(hbc.history_operation_id, hbc.order) > (?, ?)", op, idx).
+			OrderBy("hbc.history_operation_id asc, hbc.order asc")
+	case "desc":
+		if lowerBound := lowestLedgerBound(oldestLedger); lowerBound > 0 {
+			sql = sql.Where("hbc.history_operation_id > ?", lowerBound)
+		}
+		sql = sql.
+			Where("(hbc.history_operation_id, hbc.order) < (?, ?)", op, idx).
+			OrderBy("hbc.history_operation_id desc, hbc.order desc")
+	default:
+		return nil, errors.Errorf("invalid paging order: %s", query.PageQuery.Order)
+	}
+
+	sql = sql.Limit(query.PageQuery.Limit)
+
+	var rows []BalanceChange
+	if err = q.Select(ctx, &rows, sql); err != nil {
+		return nil, errors.Wrap(err, "could not run select query")
+	}
+	return rows, nil
+}
+
+var selectBalanceChange = sq.Select(
+	"hbc.history_operation_id",
+	"hbc.order",
+	"hbc.history_transaction_id",
+	"COALESCE(ht.transaction_hash, '') as transaction_hash",
+	"ha.address as account",
+	"hast.asset_type",
+	"hast.asset_code",
+	"hast.asset_issuer",
+	"hbc.ledger_closed_at",
+	"hbc.reason",
+	"hbc.amount_before",
+	"hbc.amount_after",
+).From("history_balance_changes hbc").
+	Join("history_accounts ha ON ha.id = hbc.history_account_id").
+	Join("history_assets hast ON hast.id = hbc.history_asset_id").
+	LeftJoin("history_transactions ht ON ht.id = hbc.history_transaction_id")
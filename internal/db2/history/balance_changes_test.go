@@ -0,0 +1,106 @@
+package history
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stellar/go-stellar-sdk/toid"
+	"github.com/stellar/go-stellar-sdk/xdr"
+
+	"github.com/stellar/stellar-horizon/internal/db2"
+	"github.com/stellar/stellar-horizon/internal/test"
+)
+
+func TestBalanceChanges(t *testing.T) {
+	tt := test.Start(t)
+	defer tt.Finish()
+	test.ResetHorizonDB(t, tt.HorizonDB)
+	q := &Q{tt.HorizonSession()}
+
+	account := "GAOQJGUAB7NI7K7I62ORBXMN3J4SSWQUQ7FOEPSDJ322W2HMCNWPHXFB"
+	other := "GBRPYHIL2CI3FNQ4BXLFMNDLFJUNPU2HY3ZMFSHONUCEOASW7QC7OX2H"
+	native := xdr.MustNewNativeAsset()
+	usd := xdr.MustNewCreditAsset("USD", other)
+	accountLoader := NewAccountLoader(ConcurrentInserts)
+	assetLoader := NewAssetLoader(ConcurrentInserts)
+	closedAt := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+	transactionID := toid.New(20, 1, 0).ToInt64()
+	operationID := toid.New(20, 1, 1).ToInt64()
+
+	tt.Assert.NoError(q.Begin(tt.Ctx))
+	builder := q.NewBalanceChangeBatchInsertBuilder()
+	for _, change := range []InsertBalanceChange{
+		{OperationID: transactionID, Order: 1, Account: accountLoader.GetFuture(account), Asset: assetLoader.GetFuture(AssetKeyFromXDR(native)), Reason: BalanceChangeReasonFee, AmountBefore: 1000, AmountAfter: 900},
+		{OperationID: operationID, Order: 1, Account: accountLoader.GetFuture(account), Asset: assetLoader.GetFuture(AssetKeyFromXDR(usd)), Reason: BalanceChangeReasonPayment, AmountBefore: 50, AmountAfter: 0},
+		{OperationID: operationID, Order: 2, Account: accountLoader.GetFuture(other), Asset: assetLoader.GetFuture(AssetKeyFromXDR(usd)), Reason: BalanceChangeReasonPayment, AmountBefore: 0, AmountAfter: 50},
+	} {
+		change.TransactionID = transactionID
+		change.LedgerCloseTime = closedAt
+		tt.Assert.NoError(builder.Add(change))
+	}
+	tt.Assert.NoError(accountLoader.Exec(tt.Ctx, q))
+	tt.Assert.NoError(assetLoader.Exec(tt.Ctx, q))
+	tt.Assert.NoError(builder.Exec(tt.Ctx, q))
+	tt.Assert.NoError(q.Commit())
+
+	changes, err := q.BalanceChanges(tt.Ctx, BalanceChangesQuery{
+		PageQuery: db2.PageQuery{Order: db2.OrderAscending, Limit: 10},
+		AccountID: account,
+	}, 0)
+	tt.Assert.NoError(err)
+	tt.Assert.Len(changes, 2)
+	tt.Assert.True(changes[0].IsFee())
+	tt.Assert.Equal("native", changes[0].AssetType)
+	tt.Assert.Equal(int64(900), changes[0].AmountAfter)
+	tt.Assert.False(changes[1].IsFee())
+	tt.Assert.Equal("USD", changes[1].AssetCode)
+	tt.Assert.Equal(BalanceChangeReasonPayment, changes[1].Reason)
+	tt.Assert.Equal(closedAt, changes[1].LedgerCloseTime.UTC())
+
+	changes, err = q.BalanceChanges(tt.Ctx, BalanceChangesQuery{
+		PageQuery: db2.PageQuery{Order: db2.OrderDescending, Limit: 10, Cursor: changes[1].PagingToken()},
+		AccountID: account,
+		Asset:     &native,
+	}, 0)
+	tt.Assert.NoError(err)
+	tt.Assert.Len(changes, 1)
+	tt.Assert.Equal(int64(1000), changes[0].AmountBefore)
+
+	for _, ledgers := range [][2]uint32{{19, 20}, {20, 0}, {0, 20}} {
+		changes, err = q.BalanceChanges(tt.Ctx, BalanceChangesQuery{
+			PageQuery:  db2.PageQuery{Order: db2.OrderAscending, Limit: 10},
+			AccountID:  account,
+			FromLedger: ledgers[0],
+			ToLedger:   ledgers[1],
+		}, 0)
+		tt.Assert.NoError(err)
+		tt.Assert.Len(changes, 2)
+	}
+	for _, ledgers := range [][2]uint32{{21, 0}, {0, 19}} {
+		changes, err = q.BalanceChanges(tt.Ctx, BalanceChangesQuery{
+			PageQuery:  db2.PageQuery{Order: db2.OrderAscending, Limit: 10},
+			AccountID:  account,
+			FromLedger: ledgers[0],
+			ToLedger:   ledgers[1],
+		}, 0)
+		tt.Assert.NoError(err)
+		tt.Assert.Empty(changes)
+	}
+
+	// assets and accounts which were never ingested have no balance changes
+	eur := xdr.MustNewCreditAsset("EUR", other)
+	changes, err = q.BalanceChanges(tt.Ctx, BalanceChangesQuery{
+		PageQuery: db2.PageQuery{Order: db2.OrderAscending, Limit: 10},
+		AccountID: account,
+		Asset:     &eur,
+	}, 0)
+	tt.Assert.NoError(err)
+	tt.Assert.Empty(changes)
+
+	changes, err = q.BalanceChanges(tt.Ctx, BalanceChangesQuery{
+		PageQuery: db2.PageQuery{Order: db2.OrderAscending, Limit: 10},
+		AccountID: "GC3C4AKRBQLHOJ45U4XG35ESVWRDECWO5XLDGYADO6DPR3L7KIDVUMML",
+	}, 0)
+	tt.Assert.NoError(err)
+	tt.Assert.Empty(changes)
+}
@@ -0,0 +1,153 @@
+package history
+
+import (
+	"context"
+
+	sq "github.com/Masterminds/squirrel"
+
+	"github.com/stellar/go-stellar-sdk/strkey"
+	"github.com/stellar/go-stellar-sdk/support/errors"
+	"github.com/stellar/go-stellar-sdk/xdr"
+	"github.com/stellar/stellar-horizon/internal/db2"
+)
+
+// ContractBalance is a balance of a stellar asset contract held by an
+// account or a contract. Balances of accounts are derived from their
+// trustlines (or native balance) while balances of contracts are stored in
+// the contract_asset_balances table.
+type ContractBalance struct {
+	// AssetContractID is the contract id of the stellar asset contract
+	AssetContractID []byte        `db:"asset_contract_id"`
+	AssetType       xdr.AssetType `db:"asset_type"`
+	AssetCode       string        `db:"asset_code"`
+	AssetIssuer     string        `db:"asset_issuer"`
+	// Amount is the balance in stroops
+	Amount string `db:"amount"`
+	// LastModifiedLedger is the ledger in which the balance last changed
+	LastModifiedLedger uint32 `db:"last_modified_ledger"`
+}
+
+// PagingToken returns a cursor for this balance which is the strkey encoded
+// address of the stellar asset contract.
+func (r ContractBalance) PagingToken() string {
+	return strkey.MustEncode(strkey.VersionByteContract, r.AssetContractID)
+}
+
+// ContractBalancesQuery is a helper struct to configure queries to stellar
+// asset contract balances
+type ContractBalancesQuery struct {
+	PageQuery db2.PageQuery
+	// Holder is the strkey encoded address of the account or contract
+	// holding the balances
+	Holder string
+}
+
+// Cursor validates and returns the query page cursor which is the contract
+// id of the stellar asset contract of the last balance of the previous page.
+func (q ContractBalancesQuery) Cursor() ([]byte, error) {
+	if q.PageQuery.Cursor == "" {
+		return nil, nil
+	}
+	cursor, err := strkey.Decode(strkey.VersionByteContract, q.PageQuery.Cursor)
+	if err != nil {
+		return nil, errors.New("cursor must be a contract address")
+	}
+	return cursor, nil
+}
+
+// GetContractBalances returns a page of stellar asset contract balances held
+// by the given account or contract, ordered by asset contract id.
+func (q *Q) GetContractBalances(ctx context.Context, query ContractBalancesQuery) ([]ContractBalance, error) {
+	cursor, err := query.Cursor()
+	if err != nil {
+		return nil, err
+	}
+
+	versionByte, err := strkey.Version(query.Holder)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not decode holder")
+	}
+
+	var balances sq.SelectBuilder
+	switch versionByte {
+	case strkey.VersionByteAccountID:
+		balances, err = accountContractBalances(query.Holder)
+		if err != nil {
+			return nil, err
+		}
+	case strkey.VersionByteContract:
+		holder, err := strkey.Decode(strkey.VersionByteContract, query.Holder)
+		if err != nil {
+			return nil, errors.Wrap(err, "could not decode holder")
+		}
+		balances = sq.Select(
+			"ac.contract_id AS asset_contract_id",
+			"ac.asset_type",
+			"ac.asset_code",
+			"ac.asset_issuer",
+			"CAST(cab.amount AS text) AS amount",
+			"cab.last_modified_ledger",
+		).From("contract_asset_balances cab").
+			Join("asset_contracts ac ON ac.contract_id = cab.asset_contract_id").
+			Where("cab.holder_contract_id = ?", holder)
+	default:
+		return nil, errors.Errorf("unsupported holder: %s", query.Holder)
+	}
+
+	sql := sq.Select("b.*").FromSelect(balances, "b")
+	switch query.PageQuery.Order {
+	case db2.OrderAscending:
+		if cursor != nil {
+			sql = sql.Where("b.asset_contract_id > ?", cursor)
+		}
+		sql = sql.OrderBy("b.asset_contract_id asc")
+	case db2.OrderDescending:
+		if cursor != nil {
+			sql = sql.Where("b.asset_contract_id < ?", cursor)
+		}
+		sql = sql.OrderBy("b.asset_contract_id desc")
+	default:
+		return nil, errors.Errorf("invalid paging order: %s", query.PageQuery.Order)
+	}
+
+	sql = sql.Limit(query.PageQuery.Limit)
+
+	var rows []ContractBalance
+	if err = q.Select(ctx, &rows, sql); err != nil {
+		return nil, errors.Wrap(err, "could not run select query")
+	}
+	return rows, nil
+}
+
+// accountContractBalances selects the stellar asset contract balances of an
+// account. These are the trustlines of the account whose asset has a deployed
+// stellar asset contract, along with the native balance of the account.
+func accountContractBalances(accountID string) (sq.SelectBuilder, error) {
+	native, args, err := sq.Select(
+		"ac.contract_id AS asset_contract_id",
+		"ac.asset_type",
+		"ac.asset_code",
+		"ac.asset_issuer",
+		"CAST(a.balance AS text) AS amount",
+		"a.last_modified_ledger",
+	).From("accounts a").
+		Join("asset_contracts ac ON ac.asset_type = ?", xdr.AssetTypeAssetTypeNative).
+		Where("a.account_id = ?", accountID).
+		ToSql()
+	if err != nil {
+		return sq.SelectBuilder{}, errors.Wrap(err, "could not build native balance query")
+	}
+
+	return sq.Select(
+		"ac.contract_id AS asset_contract_id",
+		"ac.asset_type",
+		"ac.asset_code",
+		"ac.asset_issuer",
+		"CAST(tl.balance AS text) AS amount",
+		"tl.last_modified_ledger",
+	).From("trust_lines tl").
+		Join("asset_contracts ac ON ac.asset_type = tl.asset_type AND "+
+			"ac.asset_code = tl.asset_code AND ac.asset_issuer = tl.asset_issuer").
+		Where("tl.account_id = ?", accountID).
+		Suffix("UNION ALL "+native, args...), nil
+}
@@ -0,0 +1,121 @@
+package history
+
+import (
+	"testing"
+
+	"github.com/stellar/go-stellar-sdk/strkey"
+	"github.com/stellar/go-stellar-sdk/xdr"
+	"github.com/stellar/stellar-horizon/internal/db2"
+	"github.com/stellar/stellar-horizon/internal/test"
+)
+
+func TestGetContractBalances(t *testing.T) {
+	tt := test.Start(t)
+	defer tt.Finish()
+	test.ResetHorizonDB(t, tt.HorizonDB)
+	q := &Q{tt.HorizonSession()}
+
+	nativeID, eurID, usdID := xdr.Hash{1}, xdr.Hash{2}, xdr.Hash{3}
+	holderID := xdr.Hash{4}
+	holder := strkey.MustEncode(strkey.VersionByteContract, holderID[:])
+
+	tt.Require.NoError(q.Begin(tt.Ctx))
+	tt.Require.NoError(q.UpsertAccounts(tt.Ctx, []AccountEntry{account1}))
+	tt.Require.NoError(q.UpsertTrustLines(tt.Ctx, []TrustLine{eurTrustLine, usdTrustLine}))
+	tt.Require.NoError(q.InsertAssetContracts(tt.Ctx, []AssetContract{
+		{
+			KeyHash:          hashBytes(xdr.Hash{11}),
+			ContractID:       hashBytes(nativeID),
+			AssetType:        xdr.AssetTypeAssetTypeNative,
+			ExpirationLedger: 100,
+		},
+		{
+			KeyHash:          hashBytes(xdr.Hash{12}),
+			ContractID:       hashBytes(eurID),
+			AssetType:        eurTrustLine.AssetType,
+			AssetCode:        eurTrustLine.AssetCode,
+			AssetIssuer:      eurTrustLine.AssetIssuer,
+			ExpirationLedger: 100,
+		},
+		{
+			KeyHash:          hashBytes(xdr.Hash{13}),
+			ContractID:       hashBytes(usdID),
+			AssetType:        usdTrustLine.AssetType,
+			AssetCode:        usdTrustLine.AssetCode,
+			AssetIssuer:      usdTrustLine.AssetIssuer,
+			ExpirationLedger: 100,
+		},
+	}))
+	tt.Require.NoError(q.InsertContractAssetBalances(tt.Ctx, []ContractAssetBalance{
+		{
+			KeyHash:            hashBytes(xdr.Hash{21}),
+			ContractID:         hashBytes(usdID),
+			HolderContractID:   hashBytes(holderID),
+			Amount:             "170141183460469231731687303715884105727",
+			ExpirationLedger:   100,
+			LastModifiedLedger: 50,
+		},
+		{
+			// balance of an asset without a deployed stellar asset contract
+			KeyHash:            hashBytes(xdr.Hash{22}),
+			ContractID:         hashBytes(xdr.Hash{5}),
+			HolderContractID:   hashBytes(holderID),
+			Amount:             "10",
+			ExpirationLedger:   100,
+			LastModifiedLedger: 50,
+		},
+	}))
+	tt.Require.NoError(q.Commit())
+
+	balances, err := q.GetContractBalances(tt.Ctx, ContractBalancesQuery{
+		PageQuery: db2.PageQuery{Order: "asc", Limit: 10},
+		Holder:    account1.AccountID,
+	})
+	tt.Require.NoError(err)
+	tt.Assert.Equal([]ContractBalance{
+		{
+			AssetContractID:    hashBytes(nativeID),
+			AssetType:          xdr.AssetTypeAssetTypeNative,
+			Amount:             "20000",
+			LastModifiedLedger: account1.LastModifiedLedger,
+		},
+		{
+			AssetContractID:    hashBytes(eurID),
+			AssetType:          eurTrustLine.AssetType,
+			AssetCode:          eurTrustLine.AssetCode,
+			AssetIssuer:        eurTrustLine.AssetIssuer,
+			Amount:             "30000",
+			LastModifiedLedger: eurTrustLine.LastModifiedLedger,
+		},
+	}, balances)
+
+	balances, err = q.GetContractBalances(tt.Ctx, ContractBalancesQuery{
+		PageQuery: db2.PageQuery{Cursor: balances[1].PagingToken(), Order: "desc", Limit: 10},
+		Holder:    account1.AccountID,
+	})
+	tt.Require.NoError(err)
+	tt.Require.Len(balances, 1)
+	tt.Assert.Equal(hashBytes(nativeID), balances[0].AssetContractID)
+
+	balances, err = q.GetContractBalances(tt.Ctx, ContractBalancesQuery{
+		PageQuery: db2.PageQuery{Order: "asc", Limit: 10},
+		Holder:    holder,
+	})
+	tt.Require.NoError(err)
+	tt.Assert.Equal([]ContractBalance{
+		{
+			AssetContractID:    hashBytes(usdID),
+			AssetType:          usdTrustLine.AssetType,
+			AssetCode:          usdTrustLine.AssetCode,
+			AssetIssuer:        usdTrustLine.AssetIssuer,
+			Amount:             "170141183460469231731687303715884105727",
+			LastModifiedLedger: 50,
+		},
+	}, balances)
+
+	_, err = q.GetContractBalances(tt.Ctx, ContractBalancesQuery{
+		PageQuery: db2.PageQuery{Cursor: "zz", Order: "asc", Limit: 10},
+		Holder:    holder,
+	})
+	tt.Assert.EqualError(err, "cursor must be a contract address")
+}
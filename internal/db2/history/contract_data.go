@@ -0,0 +1,210 @@
+package history
+
+import (
+	"context"
+	"encoding/hex"
+
+	sq "github.com/Masterminds/squirrel"
+	"github.com/lib/pq"
+
+	"github.com/stellar/go-stellar-sdk/support/db"
+	"github.com/stellar/go-stellar-sdk/support/errors"
+	"github.com/stellar/go-stellar-sdk/xdr"
+	"github.com/stellar/stellar-horizon/internal/db2"
+)
+
+// ContractData represents a row in the contract_data table. Only persistent
+// contract data entries (including contract instances) are stored.
+type ContractData struct {
+	// KeyHash is a hash of the contract data's ledger entry key
+	KeyHash []byte `db:"key_hash"`
+	// ContractID is the strkey encoded address of the contract owning the entry
+	ContractID string `db:"contract_id"`
+	// Key is the base64 encoded ScVal key of the entry
+	Key string `db:"key"`
+	// Val is the base64 encoded ScVal value of the entry
+	Val string `db:"val"`
+	// ExpirationLedger is the latest ledger for which this contract data
+	// ledger entry is live
+	ExpirationLedger   uint32 `db:"expiration_ledger"`
+	LastModifiedLedger uint32 `db:"last_modified_ledger"`
+}
+
+// PagingToken returns a cursor for this contract data entry
+func (r ContractData) PagingToken() string {
+	return hex.EncodeToString(r.KeyHash)
+}
+
+// ContractDataQuery is a helper struct to configure queries to contract data
+type ContractDataQuery struct {
+	PageQuery  db2.PageQuery
+	ContractID string
+}
+
+// Cursor validates and returns the query page cursor which is the hex encoded
+// key hash of the last entry of the previous page.
+func (q ContractDataQuery) Cursor() ([]byte, error) {
+	if q.PageQuery.Cursor == "" {
+		return nil, nil
+	}
+	cursor, err := hex.DecodeString(q.PageQuery.Cursor)
+	if err != nil || len(cursor) != len(xdr.Hash{}) {
+		return nil, errors.New("cursor must be a hex encoded key hash")
+	}
+	return cursor, nil
+}
+
+// QContractData defines contract data related queries.
+type QContractData interface {
+	UpsertContractData(ctx context.Context, rows []ContractData) error
+	RemoveContractData(ctx context.Context, keys []xdr.Hash) (int64, error)
+	UpdateContractDataExpirations(ctx context.Context, keys []xdr.Hash, expirationLedgers []uint32) error
+	GetContractDataByKeyHashes(ctx context.Context, keys []xdr.Hash) ([]ContractData, error)
+	CountContractData(ctx context.Context) (int, error)
+}
+
+// UpsertContractData upserts a batch of entries in the contract_data table.
+// The expiration ledger of existing rows is left untouched, it is maintained
+// separately by UpdateContractDataExpirations.
+func (q *Q) UpsertContractData(ctx context.Context, rows []ContractData) error {
+	if len(rows) == 0 {
+		return nil
+	}
+
+	keyHashes := make([][]byte, 0, len(rows))
+	contractIDs := make([]string, 0, len(rows))
+	keys := make([]string, 0, len(rows))
+	vals := make([]string, 0, len(rows))
+	lastModifiedLedgers := make([]int64, 0, len(rows))
+	for _, row := range rows {
+		keyHashes = append(keyHashes, row.KeyHash)
+		contractIDs = append(contractIDs, row.ContractID)
+		keys = append(keys, row.Key)
+		vals = append(vals, row.Val)
+		lastModifiedLedgers = append(lastModifiedLedgers, int64(row.LastModifiedLedger))
+	}
+
+	sql := `
+	WITH r AS
+		(SELECT
+			unnest(?::bytea[]) /* key_hash */,
+			unnest(?::text[]) /* contract_id */,
+			unnest(?::text[]) /* key */,
+			unnest(?::text[]) /* val */,
+			unnest(?::integer[]) /* last_modified_ledger */
+		)
+	INSERT INTO contract_data
+		(key_hash, contract_id, key, val, last_modified_ledger)
+	SELECT * from r
+	ON CONFLICT (key_hash) DO UPDATE SET
+		contract_id = excluded.contract_id,
+		key = excluded.key,
+		val = excluded.val,
+		last_modified_ledger = excluded.last_modified_ledger`
+
+	_, err := q.ExecRaw(
+		context.WithValue(ctx, &db.QueryTypeContextKey, db.UpsertQueryType),
+		sql,
+		pq.ByteaArray(keyHashes),
+		pq.Array(contractIDs),
+		pq.Array(keys),
+		pq.Array(vals),
+		pq.Array(lastModifiedLedgers),
+	)
+	return err
+}
+
+// RemoveContractData deletes rows from the contract_data table.
+// Returns number of rows affected and error.
+func (q *Q) RemoveContractData(ctx context.Context, keys []xdr.Hash) (int64, error) {
+	if len(keys) == 0 {
+		return 0, nil
+	}
+
+	sql := sq.Delete("contract_data").
+		Where(map[string]interface{}{"key_hash": hashesToBytes(keys)})
+	result, err := q.Exec(ctx, sql)
+	if err != nil {
+		return 0, err
+	}
+
+	return result.RowsAffected()
+}
+
+// UpdateContractDataExpirations will update the expiration ledgers for the given list of keys
+// (if they exist in the db).
+func (q *Q) UpdateContractDataExpirations(ctx context.Context, keys []xdr.Hash, expirationLedgers []uint32) error {
+	return q.updateExpirations(ctx, "contract_data", keys, expirationLedgers)
+}
+
+// GetContractDataByKeyHashes fetches all contract_data rows for the given
+// list of key hashes.
+func (q *Q) GetContractDataByKeyHashes(ctx context.Context, keys []xdr.Hash) ([]ContractData, error) {
+	sql := selectContractData.Where(map[string]interface{}{"cd.key_hash": hashesToBytes(keys)})
+	var rows []ContractData
+	err := q.Select(ctx, &rows, sql)
+	return rows, err
+}
+
+// GetContractDataByKeyHash returns a single contract_data row.
+func (q *Q) GetContractDataByKeyHash(ctx context.Context, key xdr.Hash) (ContractData, error) {
+	var row ContractData
+	sql := selectContractData.Limit(1).Where("cd.key_hash = ?", key[:])
+	err := q.Get(ctx, &row, sql)
+	return row, err
+}
+
+// CountContractData returns the total number of contract data entries in the DB
+func (q *Q) CountContractData(ctx context.Context) (int, error) {
+	sql := sq.Select("count(*)").From("contract_data")
+
+	var count int
+	if err := q.Get(ctx, &count, sql); err != nil {
+		return 0, errors.Wrap(err, "could not run select query")
+	}
+
+	return count, nil
+}
+
+// GetContractData returns a page of the contract data entries of a contract.
+func (q *Q) GetContractData(ctx context.Context, query ContractDataQuery) ([]ContractData, error) {
+	sql := selectContractData.Where("cd.contract_id = ?", query.ContractID)
+
+	cursor, err := query.Cursor()
+	if err != nil {
+		return nil, err
+	}
+
+	switch query.PageQuery.Order {
+	case db2.OrderAscending:
+		if cursor != nil {
+			sql = sql.Where("cd.key_hash > ?", cursor)
+		}
+		sql = sql.OrderBy("cd.key_hash asc")
+	case db2.OrderDescending:
+		if cursor != nil {
+			sql = sql.Where("cd.key_hash < ?", cursor)
+		}
+		sql = sql.OrderBy("cd.key_hash desc")
+	default:
+		return nil, errors.Errorf("invalid paging order: %s", query.PageQuery.Order)
+	}
+
+	sql = sql.Limit(query.PageQuery.Limit)
+
+	var rows []ContractData
+	if err = q.Select(ctx, &rows, sql); err != nil {
+		return nil, errors.Wrap(err, "could not run select query")
+	}
+	return rows, nil
+}
+
+func hashesToBytes(keys []xdr.Hash) [][]byte {
+	keyBytes := make([][]byte, len(keys))
+	for i := range keys {
+		keyBytes[i] = keys[i][:]
+	}
+	return keyBytes
+}
+
+var selectContractData = sq.Select("cd.*").From("contract_data cd")
@@ -0,0 +1,82 @@
+package history
+
+import (
+	"testing"
+
+	"github.com/stellar/go-stellar-sdk/xdr"
+	"github.com/stellar/stellar-horizon/internal/db2"
+	"github.com/stellar/stellar-horizon/internal/test"
+)
+
+func TestContractData(t *testing.T) {
+	tt := test.Start(t)
+	defer tt.Finish()
+	test.ResetHorizonDB(t, tt.HorizonDB)
+	q := &Q{tt.HorizonSession()}
+
+	contractA := "CA3D5KRYM6CB7OWQ6TWYRR3Z4T7GNZLKERYNZGGA5SOAOPIFY6YQGAXE"
+	contractB := "CDLZFC3SYJYDZT7K67VZ75HPJVIEUVNIXF47ZG2FB2RMQQVU2HHGCYSC"
+	rows := []ContractData{
+		{KeyHash: hashBytes(xdr.Hash{1}), ContractID: contractA, Key: "a", Val: "1", LastModifiedLedger: 10},
+		{KeyHash: hashBytes(xdr.Hash{2}), ContractID: contractA, Key: "b", Val: "2", LastModifiedLedger: 11},
+		{KeyHash: hashBytes(xdr.Hash{3}), ContractID: contractB, Key: "c", Val: "3", LastModifiedLedger: 12},
+	}
+
+	tt.Require.NoError(q.Begin(tt.Ctx))
+	tt.Require.NoError(q.UpsertContractData(tt.Ctx, rows))
+	tt.Require.NoError(q.UpdateContractDataExpirations(tt.Ctx, []xdr.Hash{{1}, {3}}, []uint32{100, 300}))
+
+	// updating an entry doesn't reset its expiration ledger
+	rows[0].Val = "10"
+	rows[0].LastModifiedLedger = 20
+	tt.Require.NoError(q.UpsertContractData(tt.Ctx, rows[:1]))
+	tt.Require.NoError(q.Commit())
+
+	count, err := q.CountContractData(tt.Ctx)
+	tt.Require.NoError(err)
+	tt.Assert.Equal(3, count)
+
+	row, err := q.GetContractDataByKeyHash(tt.Ctx, xdr.Hash{1})
+	tt.Require.NoError(err)
+	tt.Assert.Equal("10", row.Val)
+	tt.Assert.Equal(uint32(20), row.LastModifiedLedger)
+	tt.Assert.Equal(uint32(100), row.ExpirationLedger)
+
+	found, err := q.GetContractDataByKeyHashes(tt.Ctx, []xdr.Hash{{2}, {3}, {4}})
+	tt.Require.NoError(err)
+	tt.Assert.Len(found, 2)
+
+	page, err := q.GetContractData(tt.Ctx, ContractDataQuery{
+		PageQuery:  db2.PageQuery{Order: "asc", Limit: 10},
+		ContractID: contractA,
+	})
+	tt.Require.NoError(err)
+	tt.Require.Len(page, 2)
+	tt.Assert.Equal("a", page[0].Key)
+	tt.Assert.Equal("b", page[1].Key)
+
+	page, err = q.GetContractData(tt.Ctx, ContractDataQuery{
+		PageQuery:  db2.PageQuery{Cursor: page[1].PagingToken(), Order: "desc", Limit: 10},
+		ContractID: contractA,
+	})
+	tt.Require.NoError(err)
+	tt.Require.Len(page, 1)
+	tt.Assert.Equal("a", page[0].Key)
+
+	_, err = q.GetContractData(tt.Ctx, ContractDataQuery{
+		PageQuery:  db2.PageQuery{Cursor: "zz", Order: "asc", Limit: 10},
+		ContractID: contractA,
+	})
+	tt.Assert.Error(err)
+
+	removed, err := q.RemoveContractData(tt.Ctx, []xdr.Hash{{1}, {4}})
+	tt.Require.NoError(err)
+	tt.Assert.Equal(int64(1), removed)
+
+	_, err = q.GetContractDataByKeyHash(tt.Ctx, xdr.Hash{1})
+	tt.Assert.True(q.NoRows(err))
+}
+
+func hashBytes(hash xdr.Hash) []byte {
+	return hash[:]
+}
@@ -0,0 +1,126 @@
+package history
+
+import (
+	"context"
+	"fmt"
+
+	sq "github.com/Masterminds/squirrel"
+	"github.com/guregu/null"
+	"github.com/lib/pq"
+
+	"github.com/stellar/go-stellar-sdk/support/errors"
+	"github.com/stellar/go-stellar-sdk/toid"
+	"github.com/stellar/go-stellar-sdk/xdr"
+	"github.com/stellar/stellar-horizon/internal/db2"
+)
+
+// ContractEventTopicWildcard can be used in ContractEventsQuery.Topics to
+// match any value at a given topic position.
+const ContractEventTopicWildcard = "*"
+
+// ContractEvent is a row of data from the `history_contract_events` table
+type ContractEvent struct {
+	HistoryOperationID   int64                 `db:"history_operation_id"`
+	Order                int32                 `db:"order"`
+	HistoryTransactionID int64                 `db:"history_transaction_id"`
+	TransactionHash      string                `db:"transaction_hash"`
+	ContractID           null.String           `db:"contract_id"`
+	Type                 xdr.ContractEventType `db:"type"`
+	Topics               pq.StringArray        `db:"topics"`
+	Data                 string                `db:"data"`
+}
+
+// ID returns a lexically ordered id for this contract event record
+func (r *ContractEvent) ID() string {
+	return fmt.Sprintf("%019d-%010d", r.HistoryOperationID, r.Order)
+}
+
+// LedgerSequence return the ledger in which the contract event was emitted.
+func (r *ContractEvent) LedgerSequence() int32 {
+	id := toid.Parse(r.HistoryOperationID)
+	return id.LedgerSequence
+}
+
+// PagingToken returns a cursor for this contract event
+func (r *ContractEvent) PagingToken() string {
+	return fmt.Sprintf("%d-%d", r.HistoryOperationID, r.Order)
+}
+
+// ContractEventsQuery is a helper struct to configure queries to contract events
+type ContractEventsQuery struct {
+	PageQuery       db2.PageQuery
+	ContractID      string
+	TransactionHash string
+	// Topics is matched positionally against the event topics, an event
+	// matches if it has at least len(Topics) topics and every non-wildcard
+	// entry is equal to the topic at the same position.
+	Topics []string
+}
+
+// QContractEvents defines history_contract_events related queries.
+type QContractEvents interface {
+	NewContractEventBatchInsertBuilder() ContractEventBatchInsertBuilder
+}
+
+// ContractEvents returns a page of contract events matching the given query
+func (q *Q) ContractEvents(ctx context.Context, query ContractEventsQuery, oldestLedger int32) ([]ContractEvent, error) {
+	op, idx, err := parseEffectsCursor(query.PageQuery)
+	if err != nil {
+		return nil, err
+	}
+
+	sql := selectContractEvent
+
+	if query.ContractID != "" {
+		sql = sql.Where("hce.contract_id = ?", query.ContractID)
+	}
+
+	if query.TransactionHash != "" {
+		var tx Transaction
+		if err = q.TransactionByHash(ctx, &tx, query.TransactionHash); err != nil {
+			return nil, err
+		}
+		sql = sql.Where("hce.history_transaction_id = ?", tx.ID)
+		// events of a single transaction are never reaped separately
+		oldestLedger = 0
+	}
+
+	if len(query.Topics) > 0 {
+		sql = sql.Where("cardinality(hce.topics) >= ?", len(query.Topics))
+		for i, topic := range query.Topics {
+			if topic == ContractEventTopicWildcard {
+				continue
+			}
+			// postgres arrays are 1-indexed
+			sql = sql.Where(fmt.Sprintf("hce.topics[%d] = ?", i+1), topic)
+		}
+	}
+
+	switch query.PageQuery.Order {
+	case "asc":
+		sql = sql.
+			Where("(hce.history_operation_id, hce.order) > (?, ?)", op, idx).
+			OrderBy("hce.history_operation_id asc, hce.order asc")
+	case "desc":
+		if lowerBound := lowestLedgerBound(oldestLedger); lowerBound > 0 {
+			sql = sql.Where("hce.history_operation_id > ?", lowerBound)
+		}
+		sql = sql.
+			Where("(hce.history_operation_id, hce.order) < (?, ?)", op, idx).
+			OrderBy("hce.history_operation_id desc, hce.order desc")
+	default:
+		return nil, errors.Errorf("invalid paging order: %s", query.PageQuery.Order)
+	}
+
+	sql = sql.Limit(query.PageQuery.Limit)
+
+	var rows []ContractEvent
+	if err = q.Select(ctx, &rows, sql); err != nil {
+		return nil, errors.Wrap(err, "could not run select query")
+	}
+	return rows, nil
+}
+
+var selectContractEvent = sq.Select("hce.*, ht.transaction_hash").
+	From("history_contract_events hce").
+	LeftJoin("history_transactions ht ON ht.id = hce.history_transaction_id")
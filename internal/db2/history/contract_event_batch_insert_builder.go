@@ -0,0 +1,65 @@
+package history
+
+import (
+	"context"
+
+	"github.com/guregu/null"
+	"github.com/lib/pq"
+
+	"github.com/stellar/go-stellar-sdk/support/db"
+	"github.com/stellar/go-stellar-sdk/xdr"
+)
+
+// ContractEventBatchInsertBuilder is used to insert contract events into the
+// history_contract_events table
+type ContractEventBatchInsertBuilder interface {
+	Add(
+		operationID int64,
+		order uint32,
+		transactionID int64,
+		contractID null.String,
+		eventType xdr.ContractEventType,
+		topics []string,
+		data string,
+	) error
+	Exec(ctx context.Context, session db.SessionInterface) error
+}
+
+// contractEventBatchInsertBuilder is a simple wrapper around db.BatchInsertBuilder
+type contractEventBatchInsertBuilder struct {
+	table   string
+	builder db.FastBatchInsertBuilder
+}
+
+// NewContractEventBatchInsertBuilder constructs a new ContractEventBatchInsertBuilder instance
+func (q *Q) NewContractEventBatchInsertBuilder() ContractEventBatchInsertBuilder {
+	return &contractEventBatchInsertBuilder{
+		table:   "history_contract_events",
+		builder: db.FastBatchInsertBuilder{},
+	}
+}
+
+// Add adds a contract event to the batch
+func (i *contractEventBatchInsertBuilder) Add(
+	operationID int64,
+	order uint32,
+	transactionID int64,
+	contractID null.String,
+	eventType xdr.ContractEventType,
+	topics []string,
+	data string,
+) error {
+	return i.builder.Row(map[string]interface{}{
+		"history_operation_id":   operationID,
+		"order":                  order,
+		"history_transaction_id": transactionID,
+		"contract_id":            contractID,
+		"type":                   int16(eventType),
+		"topics":                 pq.StringArray(topics),
+		"data":                   data,
+	})
+}
+
+func (i *contractEventBatchInsertBuilder) Exec(ctx context.Context, session db.SessionInterface) error {
+	return i.builder.Exec(ctx, session, i.table)
+}
@@ -0,0 +1,85 @@
+package history
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/guregu/null"
+
+	"github.com/stellar/go-stellar-sdk/toid"
+	"github.com/stellar/go-stellar-sdk/xdr"
+	"github.com/stellar/stellar-horizon/internal/db2"
+	"github.com/stellar/stellar-horizon/internal/test"
+)
+
+func TestContractEvents(t *testing.T) {
+	tt := test.Start(t)
+	defer tt.Finish()
+	test.ResetHorizonDB(t, tt.HorizonDB)
+	q := &Q{tt.HorizonSession()}
+	tt.Require.NoError(q.Begin(tt.Ctx))
+
+	contractA := "CA3D5KRYM6CB7OWQ6TWYRR3Z4T7GNZLKERYNZGGA5SOAOPIFY6YQGAXE"
+	contractB := "CDLZFC3SYJYDZT7K67VZ75HPJVIEUVNIXF47ZG2FB2RMQQVU2HHGCYSC"
+	sequence := int32(56)
+	txID := toid.New(sequence, 1, 0).ToInt64()
+	opID := toid.New(sequence, 1, 1).ToInt64()
+
+	builder := q.NewContractEventBatchInsertBuilder()
+	tt.Require.NoError(builder.Add(opID, 1, txID, null.StringFrom(contractA),
+		xdr.ContractEventTypeContract, []string{"transfer", "from", "to"}, "amount"))
+	tt.Require.NoError(builder.Add(opID, 2, txID, null.StringFrom(contractB),
+		xdr.ContractEventTypeContract, []string{"mint", "to"}, "amount"))
+	tt.Require.NoError(builder.Add(opID, 3, txID, null.String{},
+		xdr.ContractEventTypeSystem, []string{"upgrade"}, "wasm"))
+	tt.Require.NoError(builder.Exec(tt.Ctx, q))
+	tt.Require.NoError(q.Commit())
+
+	page := db2.PageQuery{Cursor: "0-0", Order: "asc", Limit: 200}
+
+	events, err := q.ContractEvents(tt.Ctx, ContractEventsQuery{PageQuery: page}, 0)
+	tt.Require.NoError(err)
+	tt.Require.Len(events, 3)
+	tt.Assert.Equal(opID, events[0].HistoryOperationID)
+	tt.Assert.Equal(int32(1), events[0].Order)
+	tt.Assert.Equal(txID, events[0].HistoryTransactionID)
+	tt.Assert.Equal(contractA, events[0].ContractID.String)
+	tt.Assert.Equal(xdr.ContractEventTypeContract, events[0].Type)
+	tt.Assert.Equal([]string{"transfer", "from", "to"}, []string(events[0].Topics))
+	tt.Assert.Equal("amount", events[0].Data)
+	tt.Assert.False(events[2].ContractID.Valid)
+	tt.Assert.Equal(sequence, events[0].LedgerSequence())
+
+	events, err = q.ContractEvents(tt.Ctx, ContractEventsQuery{PageQuery: page, ContractID: contractB}, 0)
+	tt.Require.NoError(err)
+	tt.Require.Len(events, 1)
+	tt.Assert.Equal(int32(2), events[0].Order)
+
+	events, err = q.ContractEvents(tt.Ctx, ContractEventsQuery{
+		PageQuery: page,
+		Topics:    []string{ContractEventTopicWildcard, "to"},
+	}, 0)
+	tt.Require.NoError(err)
+	tt.Require.Len(events, 1)
+	tt.Assert.Equal(int32(2), events[0].Order)
+
+	events, err = q.ContractEvents(tt.Ctx, ContractEventsQuery{
+		PageQuery: page,
+		Topics:    []string{ContractEventTopicWildcard, ContractEventTopicWildcard},
+	}, 0)
+	tt.Require.NoError(err)
+	tt.Require.Len(events, 2)
+
+	events, err = q.ContractEvents(tt.Ctx, ContractEventsQuery{
+		PageQuery: db2.PageQuery{Cursor: fmt.Sprintf("%d-%d", opID, 3), Order: "desc", Limit: 1},
+	}, 0)
+	tt.Require.NoError(err)
+	tt.Require.Len(events, 1)
+	tt.Assert.Equal(int32(2), events[0].Order)
+
+	events, err = q.ContractEvents(tt.Ctx, ContractEventsQuery{
+		PageQuery: db2.PageQuery{Cursor: fmt.Sprintf("%d-0", toid.New(sequence+5, 0, 0).ToInt64()), Order: "desc", Limit: 200},
+	}, sequence+2)
+	tt.Require.NoError(err)
+	tt.Require.Empty(events)
+}
@@ -0,0 +1,63 @@
+package history
+
+import (
+	"cmp"
+
+	"github.com/stellar/go-stellar-sdk/support/collections/set"
+)
+
+// FutureContractID represents a future history contract.
+// A FutureContractID is created by a ContractLoader and
+// the contract id is available after calling Exec() on
+// the ContractLoader.
+type FutureContractID = future[string, HistoryContract]
+
+// ContractLoader will map contracts to their internal
+// history ids. If there is no existing mapping for a given contract,
+// the ContractLoader will insert into the history_contracts table to
+// establish a mapping.
+type ContractLoader = loader[string, HistoryContract]
+
+// NewContractLoader will construct a new ContractLoader instance.
+func NewContractLoader(concurrencyMode ConcurrencyMode) *ContractLoader {
+	return &ContractLoader{
+		sealed: false,
+		set:    set.Set[string]{},
+		ids:    map[string]int64{},
+		stats:  LoaderStats{},
+		name:   "ContractLoader",
+		table:  "history_contracts",
+		columnsForKeys: func(keys []string) []columnValues {
+			return []columnValues{
+				{
+					name:    "contract_id",
+					dbType:  "text",
+					objects: keys,
+				},
+			}
+		},
+		mappingFromRow: func(row HistoryContract) (string, int64) {
+			return row.ContractID, row.InternalID
+		},
+		less:            cmp.Less[string],
+		concurrencyMode: concurrencyMode,
+	}
+}
+
+// ContractLoaderStub is a stub wrapper around ContractLoader which allows
+// you to manually configure the mapping of contracts to history contract ids
+type ContractLoaderStub struct {
+	Loader *ContractLoader
+}
+
+// NewContractLoaderStub returns a new ContractLoaderStub instance
+func NewContractLoaderStub() ContractLoaderStub {
+	return ContractLoaderStub{Loader: NewContractLoader(ConcurrentInserts)}
+}
+
+// Insert updates the wrapped ContractLoader so that the given contract
+// is mapped to the provided history contract id
+func (a ContractLoaderStub) Insert(contract string, id int64) {
+	a.Loader.sealed = true
+	a.Loader.ids[contract] = id
+}
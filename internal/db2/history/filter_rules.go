@@ -3,36 +3,93 @@ package history
 import (
 	"context"
 	"database/sql"
+	"fmt"
+	"strconv"
+	"strings"
 
 	sq "github.com/Masterminds/squirrel"
 	"github.com/lib/pq"
+
+	"github.com/stellar/go-stellar-sdk/support/errors"
+)
+
+const (
+	assetFilterRulesTableName         = "asset_filter_rules"
+	accountFilterRulesTableName       = "account_filter_rules"
+	contractFilterRulesTableName      = "contract_filter_rules"
+	operationTypeFilterRulesTableName = "operation_type_filter_rules"
+	whitelistColumnName               = "whitelist"
+	blacklistColumnName               = "blacklist"
+	memoWhitelistColumnName           = "memo_whitelist"
+	enabledColumnName                 = "enabled"
+	lastModifiedColumnName            = "last_modified"
+
+	// FilterRulesUpdatedChannel is the postgres notification channel on which
+	// the name of a filter is published when its rules are updated.
+	FilterRulesUpdatedChannel = "horizon_filter_rules_updated"
 )
 
+// Names of the ingestion filters, used as payload of the filter rules update
+// notifications and to record the ledger from which the rules are applied.
 const (
-	assetFilterRulesTableName   = "asset_filter_rules"
-	accountFilterRulesTableName = "account_filter_rules"
-	whitelistColumnName         = "whitelist"
-	enabledColumnName           = "enabled"
-	lastModifiedColumnName      = "last_modified"
+	AssetFilterName         = "asset"
+	AccountFilterName       = "account"
+	ContractFilterName      = "contract"
+	OperationTypeFilterName = "operation_type"
 )
 
 type AssetFilterConfig struct {
-	Enabled      bool           `db:"enabled"`
-	Whitelist    pq.StringArray `db:"whitelist"`
+	Enabled   bool           `db:"enabled"`
+	Whitelist pq.StringArray `db:"whitelist"`
+	// Blacklist excludes transactions regardless of the whitelists of all filters
+	Blacklist    pq.StringArray `db:"blacklist"`
 	LastModified int64          `db:"last_modified"`
 }
 
 type AccountFilterConfig struct {
+	Enabled   bool           `db:"enabled"`
+	Whitelist pq.StringArray `db:"whitelist"`
+	// Blacklist excludes transactions regardless of the whitelists of all filters
+	Blacklist    pq.StringArray `db:"blacklist"`
+	LastModified int64          `db:"last_modified"`
+}
+
+// ContractFilterConfig whitelists transactions invoking one of the listed
+// contracts (strkey encoded contract addresses).
+type ContractFilterConfig struct {
 	Enabled      bool           `db:"enabled"`
 	Whitelist    pq.StringArray `db:"whitelist"`
 	LastModified int64          `db:"last_modified"`
 }
 
+// OperationTypeFilterConfig whitelists transactions containing an operation of
+// one of the listed types and/or carrying one of the listed memos.
+type OperationTypeFilterConfig struct {
+	Enabled       bool           `db:"enabled"`
+	Whitelist     pq.StringArray `db:"whitelist"`
+	MemoWhitelist pq.StringArray `db:"memo_whitelist"`
+	LastModified  int64          `db:"last_modified"`
+}
+
 type QFilter interface {
 	GetAccountFilterConfig(ctx context.Context) (AccountFilterConfig, error)
 	GetAssetFilterConfig(ctx context.Context) (AssetFilterConfig, error)
+	GetContractFilterConfig(ctx context.Context) (ContractFilterConfig, error)
+	GetOperationTypeFilterConfig(ctx context.Context) (OperationTypeFilterConfig, error)
 	UpdateAssetFilterConfig(ctx context.Context, config AssetFilterConfig) (AssetFilterConfig, error)
 	UpdateAccountFilterConfig(ctx context.Context, config AccountFilterConfig) (AccountFilterConfig, error)
+	UpdateContractFilterConfig(ctx context.Context, config ContractFilterConfig) (ContractFilterConfig, error)
+	UpdateOperationTypeFilterConfig(ctx context.Context, config OperationTypeFilterConfig) (OperationTypeFilterConfig, error)
+	NotifyFilterRulesUpdated(ctx context.Context, filterName string) error
+	GetFilterRulesAppliedFrom(ctx context.Context, filterName string) (FilterRulesAppliedFrom, error)
+	UpdateFilterRulesAppliedFrom(ctx context.Context, filterName string, appliedFrom FilterRulesAppliedFrom) error
+}
+
+// FilterRulesAppliedFrom records the first ledger ingested with a version of
+// the rules of a filter, the version being the last_modified of the rules.
+type FilterRulesAppliedFrom struct {
+	LastModified int64
+	Ledger       uint32
 }
 
 func (q *Q) GetAccountFilterConfig(ctx context.Context) (AccountFilterConfig, error) {
@@ -51,11 +108,32 @@ func (q *Q) GetAssetFilterConfig(ctx context.Context) (AssetFilterConfig, error)
 	return filterConfig, err
 }
 
+func (q *Q) GetContractFilterConfig(ctx context.Context) (ContractFilterConfig, error) {
+	filterConfig := ContractFilterConfig{}
+	sql := sq.Select("*").From(contractFilterRulesTableName)
+	err := q.Get(ctx, &filterConfig, sql)
+
+	return filterConfig, err
+}
+
+func (q *Q) GetOperationTypeFilterConfig(ctx context.Context) (OperationTypeFilterConfig, error) {
+	filterConfig := OperationTypeFilterConfig{}
+	sql := sq.Select("*").From(operationTypeFilterRulesTableName)
+	err := q.Get(ctx, &filterConfig, sql)
+
+	return filterConfig, err
+}
+
 func (q *Q) UpdateAssetFilterConfig(ctx context.Context, config AssetFilterConfig) (AssetFilterConfig, error) {
+	blacklist := config.Blacklist
+	if blacklist == nil {
+		blacklist = pq.StringArray{}
+	}
 	updateCols := map[string]interface{}{
 		lastModifiedColumnName: sq.Expr(`extract(epoch from now() at time zone 'utc')`),
 		enabledColumnName:      config.Enabled,
 		whitelistColumnName:    config.Whitelist,
+		blacklistColumnName:    blacklist,
 	}
 
 	sqlUpdate := sq.Update(assetFilterRulesTableName).SetMap(updateCols)
@@ -72,10 +150,15 @@ func (q *Q) UpdateAssetFilterConfig(ctx context.Context, config AssetFilterConfi
 }
 
 func (q *Q) UpdateAccountFilterConfig(ctx context.Context, config AccountFilterConfig) (AccountFilterConfig, error) {
+	blacklist := config.Blacklist
+	if blacklist == nil {
+		blacklist = pq.StringArray{}
+	}
 	updateCols := map[string]interface{}{
 		lastModifiedColumnName: sq.Expr(`extract(epoch from now() at time zone 'utc')`),
 		enabledColumnName:      config.Enabled,
 		whitelistColumnName:    config.Whitelist,
+		blacklistColumnName:    blacklist,
 	}
 
 	sqlUpdate := sq.Update(accountFilterRulesTableName).SetMap(updateCols)
@@ -91,6 +174,93 @@ func (q *Q) UpdateAccountFilterConfig(ctx context.Context, config AccountFilterC
 	return q.GetAccountFilterConfig(ctx)
 }
 
+func (q *Q) UpdateContractFilterConfig(ctx context.Context, config ContractFilterConfig) (ContractFilterConfig, error) {
+	updateCols := map[string]interface{}{
+		lastModifiedColumnName: sq.Expr(`extract(epoch from now() at time zone 'utc')`),
+		enabledColumnName:      config.Enabled,
+		whitelistColumnName:    config.Whitelist,
+	}
+
+	sqlUpdate := sq.Update(contractFilterRulesTableName).SetMap(updateCols)
+
+	rowCnt, err := q.checkForError(sqlUpdate, ctx)
+	if err != nil {
+		return ContractFilterConfig{}, err
+	}
+
+	if rowCnt < 1 {
+		return ContractFilterConfig{}, sql.ErrNoRows
+	}
+	return q.GetContractFilterConfig(ctx)
+}
+
+func (q *Q) UpdateOperationTypeFilterConfig(ctx context.Context, config OperationTypeFilterConfig) (OperationTypeFilterConfig, error) {
+	memoWhitelist := config.MemoWhitelist
+	if memoWhitelist == nil {
+		memoWhitelist = pq.StringArray{}
+	}
+	updateCols := map[string]interface{}{
+		lastModifiedColumnName:  sq.Expr(`extract(epoch from now() at time zone 'utc')`),
+		enabledColumnName:       config.Enabled,
+		whitelistColumnName:     config.Whitelist,
+		memoWhitelistColumnName: memoWhitelist,
+	}
+
+	sqlUpdate := sq.Update(operationTypeFilterRulesTableName).SetMap(updateCols)
+
+	rowCnt, err := q.checkForError(sqlUpdate, ctx)
+	if err != nil {
+		return OperationTypeFilterConfig{}, err
+	}
+
+	if rowCnt < 1 {
+		return OperationTypeFilterConfig{}, sql.ErrNoRows
+	}
+	return q.GetOperationTypeFilterConfig(ctx)
+}
+
+// NotifyFilterRulesUpdated notifies the ingesting instances listening on
+// FilterRulesUpdatedChannel that the rules of the filter have been updated.
+func (q *Q) NotifyFilterRulesUpdated(ctx context.Context, filterName string) error {
+	_, err := q.ExecRaw(ctx, "SELECT pg_notify(?, ?)", FilterRulesUpdatedChannel, filterName)
+	return err
+}
+
+// GetFilterRulesAppliedFrom returns the version of the rules of the filter
+// last applied by ingestion and the ledger from which it was applied. A zero
+// value is returned if no version has been recorded yet.
+func (q *Q) GetFilterRulesAppliedFrom(ctx context.Context, filterName string) (FilterRulesAppliedFrom, error) {
+	value, err := q.getValueFromStore(ctx, filterRulesAppliedFromKeyPrefix+filterName, false)
+	if err != nil || value == "" {
+		return FilterRulesAppliedFrom{}, err
+	}
+
+	lastModified, ledger, found := strings.Cut(value, ":")
+	if !found {
+		return FilterRulesAppliedFrom{}, errors.Errorf("invalid filter rules applied from value: %s", value)
+	}
+	var appliedFrom FilterRulesAppliedFrom
+	if appliedFrom.LastModified, err = strconv.ParseInt(lastModified, 10, 64); err != nil {
+		return FilterRulesAppliedFrom{}, errors.Wrap(err, "error converting last modified value")
+	}
+	ledgerSequence, err := strconv.ParseUint(ledger, 10, 32)
+	if err != nil {
+		return FilterRulesAppliedFrom{}, errors.Wrap(err, "error converting ledger value")
+	}
+	appliedFrom.Ledger = uint32(ledgerSequence)
+	return appliedFrom, nil
+}
+
+// UpdateFilterRulesAppliedFrom records the ledger from which a version of the
+// rules of the filter is applied by ingestion.
+func (q *Q) UpdateFilterRulesAppliedFrom(ctx context.Context, filterName string, appliedFrom FilterRulesAppliedFrom) error {
+	return q.updateValueInStore(
+		ctx,
+		filterRulesAppliedFromKeyPrefix+filterName,
+		fmt.Sprintf("%d:%d", appliedFrom.LastModified, appliedFrom.Ledger),
+	)
+}
+
 func (q *Q) checkForError(builder sq.Sqlizer, ctx context.Context) (int64, error) {
 	result, err := q.Exec(ctx, builder)
 	if err != nil {
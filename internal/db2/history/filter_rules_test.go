@@ -24,6 +24,13 @@ func TestAssetFilterConfig(t *testing.T) {
 	assert.NoError(t, err)
 	tt.Assert.Equal(fc1Result.Enabled, true)
 	tt.Assert.ElementsMatch(fc1Result.Whitelist, []string{"1", "2"})
+	tt.Assert.Len(fc1Result.Blacklist, 0)
+
+	fc1Result.Blacklist = []string{"3"}
+	fc1Result, err = q.UpdateAssetFilterConfig(tt.Ctx, fc1Result)
+	assert.NoError(t, err)
+	tt.Assert.ElementsMatch(fc1Result.Whitelist, []string{"1", "2"})
+	tt.Assert.ElementsMatch(fc1Result.Blacklist, []string{"3"})
 }
 
 func TestAccountFilterConfig(t *testing.T) {
@@ -42,4 +49,80 @@ func TestAccountFilterConfig(t *testing.T) {
 	fc1Result, err = q.UpdateAccountFilterConfig(tt.Ctx, fc1Result)
 	tt.Assert.Equal(fc1Result.Enabled, true)
 	tt.Assert.ElementsMatch(fc1Result.Whitelist, []string{"1", "2"})
+	tt.Assert.Len(fc1Result.Blacklist, 0)
+
+	fc1Result.Blacklist = []string{"3"}
+	fc1Result, err = q.UpdateAccountFilterConfig(tt.Ctx, fc1Result)
+	assert.NoError(t, err)
+	tt.Assert.ElementsMatch(fc1Result.Blacklist, []string{"3"})
+}
+
+func TestContractFilterConfig(t *testing.T) {
+	tt := test.Start(t)
+	defer tt.Finish()
+	test.ResetHorizonDB(t, tt.HorizonDB)
+	q := &Q{tt.HorizonSession()}
+
+	fc1Result, err := q.GetContractFilterConfig(tt.Ctx)
+	assert.NoError(t, err)
+	tt.Assert.Equal(fc1Result.Enabled, false)
+	tt.Assert.Len(fc1Result.Whitelist, 0)
+
+	fc1Result.Enabled = true
+	fc1Result.Whitelist = append(fc1Result.Whitelist, "1", "2")
+	fc1Result, err = q.UpdateContractFilterConfig(tt.Ctx, fc1Result)
+	assert.NoError(t, err)
+	tt.Assert.Equal(fc1Result.Enabled, true)
+	tt.Assert.ElementsMatch(fc1Result.Whitelist, []string{"1", "2"})
+}
+
+func TestOperationTypeFilterConfig(t *testing.T) {
+	tt := test.Start(t)
+	defer tt.Finish()
+	test.ResetHorizonDB(t, tt.HorizonDB)
+	q := &Q{tt.HorizonSession()}
+
+	fc1Result, err := q.GetOperationTypeFilterConfig(tt.Ctx)
+	assert.NoError(t, err)
+	tt.Assert.Equal(fc1Result.Enabled, false)
+	tt.Assert.Len(fc1Result.Whitelist, 0)
+	tt.Assert.Len(fc1Result.MemoWhitelist, 0)
+
+	fc1Result.Enabled = true
+	fc1Result.Whitelist = append(fc1Result.Whitelist, "payment")
+	fc1Result.MemoWhitelist = append(fc1Result.MemoWhitelist, "deposit")
+	fc1Result, err = q.UpdateOperationTypeFilterConfig(tt.Ctx, fc1Result)
+	assert.NoError(t, err)
+	tt.Assert.Equal(fc1Result.Enabled, true)
+	tt.Assert.ElementsMatch(fc1Result.Whitelist, []string{"payment"})
+	tt.Assert.ElementsMatch(fc1Result.MemoWhitelist, []string{"deposit"})
+
+	// the memo whitelist is optional
+	fc1Result.MemoWhitelist = nil
+	fc1Result, err = q.UpdateOperationTypeFilterConfig(tt.Ctx, fc1Result)
+	assert.NoError(t, err)
+	tt.Assert.Len(fc1Result.MemoWhitelist, 0)
+}
+
+func TestFilterRulesAppliedFrom(t *testing.T) {
+	tt := test.Start(t)
+	defer tt.Finish()
+	test.ResetHorizonDB(t, tt.HorizonDB)
+	q := &Q{tt.HorizonSession()}
+
+	appliedFrom, err := q.GetFilterRulesAppliedFrom(tt.Ctx, AssetFilterName)
+	assert.NoError(t, err)
+	tt.Assert.Equal(FilterRulesAppliedFrom{}, appliedFrom)
+
+	expected := FilterRulesAppliedFrom{LastModified: 1700000000, Ledger: 123}
+	assert.NoError(t, q.UpdateFilterRulesAppliedFrom(tt.Ctx, AssetFilterName, expected))
+	appliedFrom, err = q.GetFilterRulesAppliedFrom(tt.Ctx, AssetFilterName)
+	assert.NoError(t, err)
+	tt.Assert.Equal(expected, appliedFrom)
+
+	appliedFrom, err = q.GetFilterRulesAppliedFrom(tt.Ctx, AccountFilterName)
+	assert.NoError(t, err)
+	tt.Assert.Equal(FilterRulesAppliedFrom{}, appliedFrom)
+
+	assert.NoError(t, q.NotifyFilterRulesUpdated(tt.Ctx, AssetFilterName))
 }
@@ -0,0 +1,99 @@
+package history
+
+import (
+	"context"
+
+	sq "github.com/Masterminds/squirrel"
+
+	"github.com/stellar/go-stellar-sdk/support/db"
+)
+
+// QHistoryContracts defines contract participants related queries.
+type QHistoryContracts interface {
+	NewOperationContractBatchInsertBuilder() OperationContractBatchInsertBuilder
+	NewTransactionContractBatchInsertBuilder() TransactionContractBatchInsertBuilder
+}
+
+// HistoryContract is a row of data from the `history_contracts` table
+type HistoryContract struct {
+	ContractID string `db:"contract_id"`
+	InternalID int64  `db:"id"`
+}
+
+var selectHistoryContract = sq.Select("hc.*").From("history_contracts hc")
+
+// ContractsByIDs loads rows from `history_contracts`, by contract_id
+func (q *Q) ContractsByIDs(ctx context.Context, contractIDs []string) (dest []HistoryContract, err error) {
+	sql := selectHistoryContract.Where(map[string]interface{}{
+		"hc.contract_id": contractIDs, // hc.contract_id IN (...)
+	})
+	err = q.Select(ctx, &dest, sql)
+	return dest, err
+}
+
+// ContractByID loads a row from `history_contracts`, by contract_id
+func (q *Q) ContractByID(ctx context.Context, contractID string) (dest HistoryContract, err error) {
+	sql := selectHistoryContract.Limit(1).Where("hc.contract_id = ?", contractID)
+	err = q.Get(ctx, &dest, sql)
+	return dest, err
+}
+
+type OperationContractBatchInsertBuilder interface {
+	Add(operationID int64, contract FutureContractID) error
+	Exec(ctx context.Context, session db.SessionInterface) error
+}
+
+type operationContractBatchInsertBuilder struct {
+	table   string
+	builder db.FastBatchInsertBuilder
+}
+
+func (q *Q) NewOperationContractBatchInsertBuilder() OperationContractBatchInsertBuilder {
+	return &operationContractBatchInsertBuilder{
+		table:   "history_operation_contracts",
+		builder: db.FastBatchInsertBuilder{},
+	}
+}
+
+// Add adds a new operation contract to the batch
+func (i *operationContractBatchInsertBuilder) Add(operationID int64, contract FutureContractID) error {
+	return i.builder.Row(map[string]interface{}{
+		"history_operation_id": operationID,
+		"history_contract_id":  contract,
+	})
+}
+
+// Exec flushes all pending operation contracts to the db
+func (i *operationContractBatchInsertBuilder) Exec(ctx context.Context, session db.SessionInterface) error {
+	return i.builder.Exec(ctx, session, i.table)
+}
+
+type TransactionContractBatchInsertBuilder interface {
+	Add(transactionID int64, contract FutureContractID) error
+	Exec(ctx context.Context, session db.SessionInterface) error
+}
+
+type transactionContractBatchInsertBuilder struct {
+	table   string
+	builder db.FastBatchInsertBuilder
+}
+
+func (q *Q) NewTransactionContractBatchInsertBuilder() TransactionContractBatchInsertBuilder {
+	return &transactionContractBatchInsertBuilder{
+		table:   "history_transaction_contracts",
+		builder: db.FastBatchInsertBuilder{},
+	}
+}
+
+// Add adds a new transaction contract to the batch
+func (i *transactionContractBatchInsertBuilder) Add(transactionID int64, contract FutureContractID) error {
+	return i.builder.Row(map[string]interface{}{
+		"history_transaction_id": transactionID,
+		"history_contract_id":    contract,
+	})
+}
+
+// Exec flushes all pending transaction contracts to the db
+func (i *transactionContractBatchInsertBuilder) Exec(ctx context.Context, session db.SessionInterface) error {
+	return i.builder.Exec(ctx, session, i.table)
+}
@@ -15,6 +15,7 @@ type QHistoryLiquidityPools interface {
 	CreateHistoryLiquidityPools(ctx context.Context, poolIDs []string, batchSize int) (map[string]int64, error)
 	NewOperationLiquidityPoolBatchInsertBuilder() OperationLiquidityPoolBatchInsertBuilder
 	NewTransactionLiquidityPoolBatchInsertBuilder() TransactionLiquidityPoolBatchInsertBuilder
+	NewLiquidityPoolSnapshotBatchInsertBuilder() LiquidityPoolSnapshotBatchInsertBuilder
 }
 
 // CreateHistoryLiquidityPools creates rows in the history_liquidity_pools table for a given list of ids.
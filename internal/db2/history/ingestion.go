@@ -8,7 +8,9 @@ import (
 // Ingestion state tables are horizon database tables populated by
 // the ingestion system using history archive snapshots.
 // Any horizon database tables which cannot be populated using
-// history archive snapshots will not be truncated.
+// history archive snapshots will not be truncated, except for the account
+// state change log which is only consistent with the state it was recorded
+// along with.
 func (q *Q) TruncateIngestStateTables(ctx context.Context) error {
 	return q.TruncateTables(ctx, []string{
 		"accounts",
@@ -17,9 +19,11 @@ func (q *Q) TruncateIngestStateTables(ctx context.Context) error {
 		"claimable_balances",
 		"claimable_balance_claimants",
 		"exp_asset_stats",
+		"history_account_state_changes",
 		"contract_asset_balances",
 		"contract_asset_stats",
 		"asset_contracts",
+		"contract_data",
 		"liquidity_pools",
 		"offers",
 		"trust_lines",
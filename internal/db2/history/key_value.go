@@ -23,6 +23,8 @@ const (
 	lookupTableReapOffsetSuffix     = "_reap_offset"
 	loadTestLedgerKey               = "load_test_ledger"
 	loadTestRunID                   = "load_test_run_id"
+	filterRulesAppliedFromKeyPrefix = "filter_rules_applied_from_"
+	ledgerSinkCursorKey             = "ledger_sink_cursor"
 )
 
 // GetLastLedgerIngestNonBlocking works like GetLastLedgerIngest but
@@ -139,6 +141,25 @@ func (q *Q) UpdateLastLedgerIngest(ctx context.Context, ledgerSequence uint32) e
 	)
 }
 
+// GetLedgerSinkCursor returns the last ledger published to the ledger sink.
+// Returns zero if no ledger has been published yet.
+func (q *Q) GetLedgerSinkCursor(ctx context.Context) (uint32, error) {
+	parsed, err := q.getIntValueFromStore(ctx, ledgerSinkCursorKey, 32)
+	if err != nil {
+		return 0, errors.Wrap(err, "error converting ledger sink cursor value")
+	}
+	return uint32(parsed), nil
+}
+
+// UpdateLedgerSinkCursor updates the last ledger published to the ledger sink.
+func (q *Q) UpdateLedgerSinkCursor(ctx context.Context, ledgerSequence uint32) error {
+	return q.updateValueInStore(
+		ctx,
+		ledgerSinkCursorKey,
+		strconv.FormatUint(uint64(ledgerSequence), 10),
+	)
+}
+
 // GetIngestVersion returns the ingestion version. Returns zero
 // if there is no value.
 func (q *Q) GetIngestVersion(ctx context.Context) (int, error) {
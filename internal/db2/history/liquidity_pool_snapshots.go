@@ -0,0 +1,176 @@
+package history
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	sq "github.com/Masterminds/squirrel"
+
+	"github.com/stellar/go-stellar-sdk/support/db"
+	"github.com/stellar/go-stellar-sdk/support/errors"
+	"github.com/stellar/stellar-horizon/internal/db2"
+)
+
+// InsertLiquidityPoolSnapshot represents the arguments to
+// LiquidityPoolSnapshotBatchInsertBuilder.Add() which is used to insert rows
+// into the history_liquidity_pool_snapshots table. VolumeA and VolumeB are the
+// amounts of each reserve asset received by the pool in trades in the ledger.
+type InsertLiquidityPoolSnapshot struct {
+	LiquidityPoolID FutureLiquidityPoolID
+	LedgerID        int64
+	ClosedAt        time.Time
+	Fee             uint32
+	ReserveA        int64
+	ReserveB        int64
+	TotalShares     int64
+	TrustlineCount  int64
+	TradeCount      int32
+	VolumeA         int64
+	VolumeB         int64
+}
+
+// LiquidityPoolSnapshotBatchInsertBuilder is used to insert snapshots into the
+// history_liquidity_pool_snapshots table
+type LiquidityPoolSnapshotBatchInsertBuilder interface {
+	Add(snapshot InsertLiquidityPoolSnapshot) error
+	Exec(ctx context.Context, session db.SessionInterface) error
+}
+
+type liquidityPoolSnapshotBatchInsertBuilder struct {
+	table   string
+	builder db.FastBatchInsertBuilder
+}
+
+// NewLiquidityPoolSnapshotBatchInsertBuilder constructs a new
+// LiquidityPoolSnapshotBatchInsertBuilder instance
+func (q *Q) NewLiquidityPoolSnapshotBatchInsertBuilder() LiquidityPoolSnapshotBatchInsertBuilder {
+	return &liquidityPoolSnapshotBatchInsertBuilder{
+		table:   "history_liquidity_pool_snapshots",
+		builder: db.FastBatchInsertBuilder{},
+	}
+}
+
+// Add adds a new liquidity pool snapshot to the batch
+func (i *liquidityPoolSnapshotBatchInsertBuilder) Add(snapshot InsertLiquidityPoolSnapshot) error {
+	return i.builder.Row(map[string]interface{}{
+		"history_liquidity_pool_id": snapshot.LiquidityPoolID,
+		"history_ledger_id":         snapshot.LedgerID,
+		"closed_at":                 snapshot.ClosedAt,
+		"fee":                       snapshot.Fee,
+		"reserve_a":                 snapshot.ReserveA,
+		"reserve_b":                 snapshot.ReserveB,
+		"total_shares":              snapshot.TotalShares,
+		"trustline_count":           snapshot.TrustlineCount,
+		"trade_count":               snapshot.TradeCount,
+		"volume_a":                  snapshot.VolumeA,
+		"volume_b":                  snapshot.VolumeB,
+	})
+}
+
+// Exec flushes all pending liquidity pool snapshots to the db
+func (i *liquidityPoolSnapshotBatchInsertBuilder) Exec(ctx context.Context, session db.SessionInterface) error {
+	return i.builder.Exec(ctx, session, i.table)
+}
+
+// LiquidityPoolSnapshot is a snapshot of a liquidity pool at the end of a
+// ledger, or at the end of a bucket of ledgers when Timestamp is set. Reserves,
+// shares, trustlines and fee are the ones of the last ledger in the bucket,
+// trade counts and volumes are summed over the bucket.
+type LiquidityPoolSnapshot struct {
+	Timestamp      int64     `db:"timestamp"`
+	LedgerID       int64     `db:"history_ledger_id"`
+	ClosedAt       time.Time `db:"closed_at"`
+	Fee            uint32    `db:"fee"`
+	ReserveA       int64     `db:"reserve_a"`
+	ReserveB       int64     `db:"reserve_b"`
+	TotalShares    int64     `db:"total_shares"`
+	TrustlineCount int64     `db:"trustline_count"`
+	TradeCount     int64     `db:"trade_count"`
+	VolumeA        string    `db:"volume_a"`
+	VolumeB        string    `db:"volume_b"`
+}
+
+var selectLiquidityPoolSnapshots = sq.Select(
+	"0 as timestamp",
+	"hlps.history_ledger_id",
+	"hlps.closed_at",
+	"hlps.fee",
+	"hlps.reserve_a",
+	"hlps.reserve_b",
+	"hlps.total_shares",
+	"hlps.trustline_count",
+	"hlps.trade_count",
+	"hlps.volume_a",
+	"hlps.volume_b",
+).From("history_liquidity_pool_snapshots hlps").
+	Join("history_liquidity_pools hlp ON hlp.id = hlps.history_liquidity_pool_id")
+
+// LiquidityPoolSnapshots returns the per ledger snapshots of a liquidity pool,
+// paged by ledger toid.
+func (q *Q) LiquidityPoolSnapshots(ctx context.Context, poolID string, page db2.PageQuery) ([]LiquidityPoolSnapshot, error) {
+	sql, err := page.ApplyTo(
+		selectLiquidityPoolSnapshots.Where("hlp.liquidity_pool_id = ?", poolID),
+		"hlps.history_ledger_id",
+	)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not apply query to page")
+	}
+
+	var snapshots []LiquidityPoolSnapshot
+	err = q.Select(ctx, &snapshots, sql)
+	return snapshots, err
+}
+
+// LiquidityPoolSnapshotBuckets returns the snapshots of a liquidity pool
+// aggregated in buckets of the given resolution in milliseconds, paged by
+// bucket timestamp. Buckets in which the pool didn't change are omitted.
+func (q *Q) LiquidityPoolSnapshotBuckets(ctx context.Context, poolID string, resolution int64, page db2.PageQuery) ([]LiquidityPoolSnapshot, error) {
+	if resolution <= 0 {
+		return nil, errors.New("resolution must be positive")
+	}
+	cursor, err := page.CursorInt64()
+	if err != nil {
+		return nil, err
+	}
+	bucket := fmt.Sprintf("to_millis(hlps.closed_at, %d)", resolution)
+
+	// the snapshots are ordered by ledger so last() picks the state of the
+	// pool at the end of the bucket
+	snapshots := sq.Select(
+		bucket+" as timestamp",
+		"hlps.*",
+	).From("history_liquidity_pool_snapshots hlps").
+		Join("history_liquidity_pools hlp ON hlp.id = hlps.history_liquidity_pool_id").
+		Where("hlp.liquidity_pool_id = ?", poolID).
+		OrderBy("hlps.history_ledger_id ASC")
+
+	sql := sq.Select(
+		"timestamp",
+		"max(history_ledger_id) as history_ledger_id",
+		"max(closed_at) as closed_at",
+		"(last(ARRAY[reserve_a, reserve_b, total_shares, trustline_count, fee]))[1] as reserve_a",
+		"(last(ARRAY[reserve_a, reserve_b, total_shares, trustline_count, fee]))[2] as reserve_b",
+		"(last(ARRAY[reserve_a, reserve_b, total_shares, trustline_count, fee]))[3] as total_shares",
+		"(last(ARRAY[reserve_a, reserve_b, total_shares, trustline_count, fee]))[4] as trustline_count",
+		"(last(ARRAY[reserve_a, reserve_b, total_shares, trustline_count, fee]))[5] as fee",
+		"sum(trade_count) as trade_count",
+		"sum(volume_a) as volume_a",
+		"sum(volume_b) as volume_b",
+	).FromSelect(snapshots, "snapshots").
+		GroupBy("timestamp").
+		Limit(page.Limit)
+
+	switch page.Order {
+	case db2.OrderAscending:
+		sql = sql.Having("timestamp > ?", cursor).OrderBy("timestamp ASC")
+	case db2.OrderDescending:
+		sql = sql.Having("timestamp < ?", cursor).OrderBy("timestamp DESC")
+	default:
+		return nil, db2.ErrInvalidOrder
+	}
+
+	var buckets []LiquidityPoolSnapshot
+	err = q.Select(ctx, &buckets, sql)
+	return buckets, err
+}
@@ -0,0 +1,82 @@
+package history
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stellar/go-stellar-sdk/toid"
+
+	"github.com/stellar/stellar-horizon/internal/db2"
+	"github.com/stellar/stellar-horizon/internal/test"
+)
+
+func TestLiquidityPoolSnapshots(t *testing.T) {
+	tt := test.Start(t)
+	defer tt.Finish()
+	test.ResetHorizonDB(t, tt.HorizonDB)
+	q := &Q{tt.HorizonSession()}
+
+	poolID := "cafebabe"
+	lpLoader := NewLiquidityPoolLoader(ConcurrentInserts)
+	start := time.Date(2023, 3, 1, 10, 0, 0, 0, time.UTC)
+
+	tt.Assert.NoError(q.Begin(tt.Ctx))
+	builder := q.NewLiquidityPoolSnapshotBatchInsertBuilder()
+	for i, snapshot := range []InsertLiquidityPoolSnapshot{
+		{ClosedAt: start.Add(5 * time.Minute), ReserveA: 100, ReserveB: 200, TradeCount: 1, VolumeA: 10},
+		{ClosedAt: start.Add(50 * time.Minute), ReserveA: 110, ReserveB: 190, TradeCount: 2, VolumeA: 5, VolumeB: 7},
+		{ClosedAt: start.Add(70 * time.Minute), ReserveA: 120, ReserveB: 180, TradeCount: 1, VolumeB: 3},
+	} {
+		snapshot.LiquidityPoolID = lpLoader.GetFuture(poolID)
+		snapshot.LedgerID = toid.New(int32(10+i), 0, 0).ToInt64()
+		snapshot.Fee = 30
+		snapshot.TotalShares = 1000
+		snapshot.TrustlineCount = 3
+		tt.Assert.NoError(builder.Add(snapshot))
+	}
+	tt.Assert.NoError(lpLoader.Exec(tt.Ctx, q))
+	tt.Assert.NoError(builder.Exec(tt.Ctx, q))
+	tt.Assert.NoError(q.Commit())
+
+	snapshots, err := q.LiquidityPoolSnapshots(tt.Ctx, poolID, db2.PageQuery{Order: db2.OrderAscending, Limit: 2})
+	tt.Assert.NoError(err)
+	tt.Assert.Len(snapshots, 2)
+	tt.Assert.Equal(toid.New(10, 0, 0).ToInt64(), snapshots[0].LedgerID)
+	tt.Assert.Equal(int64(110), snapshots[1].ReserveA)
+	tt.Assert.Equal("7", snapshots[1].VolumeB)
+
+	snapshots, err = q.LiquidityPoolSnapshots(tt.Ctx, "deadbeef", db2.PageQuery{Order: db2.OrderAscending, Limit: 2})
+	tt.Assert.NoError(err)
+	tt.Assert.Empty(snapshots)
+
+	hour := int64(time.Hour / time.Millisecond)
+	buckets, err := q.LiquidityPoolSnapshotBuckets(tt.Ctx, poolID, hour, db2.PageQuery{Order: db2.OrderAscending, Limit: 10})
+	tt.Assert.NoError(err)
+	tt.Assert.Len(buckets, 2)
+
+	// the first bucket has the reserves of its last ledger and the trades of
+	// both of its ledgers
+	tt.Assert.Equal(start.UnixMilli(), buckets[0].Timestamp)
+	tt.Assert.Equal(toid.New(11, 0, 0).ToInt64(), buckets[0].LedgerID)
+	tt.Assert.Equal(int64(110), buckets[0].ReserveA)
+	tt.Assert.Equal(int64(190), buckets[0].ReserveB)
+	tt.Assert.Equal(int64(1000), buckets[0].TotalShares)
+	tt.Assert.Equal(uint32(30), buckets[0].Fee)
+	tt.Assert.Equal(int64(3), buckets[0].TradeCount)
+	tt.Assert.Equal("15", buckets[0].VolumeA)
+	tt.Assert.Equal("7", buckets[0].VolumeB)
+	tt.Assert.Equal(start.UnixMilli()+hour, buckets[1].Timestamp)
+
+	buckets, err = q.LiquidityPoolSnapshotBuckets(tt.Ctx, poolID, hour, db2.PageQuery{
+		Cursor: "0",
+		Order:  db2.OrderDescending,
+		Limit:  1,
+	})
+	tt.Assert.NoError(err)
+	tt.Assert.Empty(buckets)
+
+	buckets, err = q.LiquidityPoolSnapshotBuckets(tt.Ctx, poolID, hour, db2.PageQuery{Order: db2.OrderDescending, Limit: 1})
+	tt.Assert.NoError(err)
+	tt.Assert.Len(buckets, 1)
+	tt.Assert.Equal(int64(120), buckets[0].ReserveA)
+}
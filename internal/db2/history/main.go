@@ -262,10 +262,15 @@ type AccountEntry struct {
 
 type IngestionQ interface {
 	QAccounts
+	QAccountStateChanges
 	QFilter
 	QAssetStats
+	QBalanceChanges
 	QClaimableBalances
 	QHistoryClaimableBalances
+	QContractData
+	QContractEvents
+	QHistoryContracts
 	QData
 	QEffects
 	QLedgers
@@ -279,6 +284,7 @@ type IngestionQ interface {
 	NewTransactionParticipantsBatchInsertBuilder() TransactionParticipantsBatchInsertBuilder
 	NewOperationParticipantBatchInsertBuilder() OperationParticipantBatchInsertBuilder
 	QSigners
+	QSubscriptions
 	//QTrades
 	NewTradeBatchInsertBuilder() TradeBatchInsertBuilder
 	RebuildTradeAggregationTimes(ctx context.Context, from, to strtime.Millis, roundingSlippageFilter int) error
@@ -576,7 +582,7 @@ type QAssetStats interface {
 	DeleteAssetContractsExpiringAt(ctx context.Context, ledger uint32) (int64, error)
 	InsertContractAssetBalances(ctx context.Context, rows []ContractAssetBalance) error
 	RemoveContractAssetBalances(ctx context.Context, keys []xdr.Hash) error
-	UpdateContractAssetBalanceAmounts(ctx context.Context, keys []xdr.Hash, amounts []string) error
+	UpdateContractAssetBalanceAmounts(ctx context.Context, keys []xdr.Hash, amounts []string, ledger uint32) error
 	UpdateContractAssetBalanceExpirations(ctx context.Context, keys []xdr.Hash, expirationLedgers []uint32) error
 	GetContractAssetBalances(ctx context.Context, keys []xdr.Hash) ([]ContractAssetBalance, error)
 	DeleteContractAssetBalancesExpiringAt(ctx context.Context, ledger uint32) ([]ContractAssetBalance, error)
@@ -592,6 +598,8 @@ type QAssetStats interface {
 	RemoveAssetContractStat(ctx context.Context, contractID []byte) (int64, error)
 	GetAssetStats(ctx context.Context, assetCode, assetIssuer string, page db2.PageQuery) ([]AssetAndContractStat, error)
 	CountTrustLines(ctx context.Context) (int, error)
+	InsertAssetStatSnapshots(ctx context.Context, ledger uint32, closedAt time.Time, deltas []ExpAssetStat, contractIDs []xdr.ContractId) error
+	DeleteAssetStatSnapshotsRange(ctx context.Context, start, end int64) (int64, error)
 }
 
 type QCreateAccountsHistory interface {
@@ -1064,8 +1072,16 @@ var historyLookupTables = map[string][]tableObjectFieldPair{
 			name:        "history_trades",
 			objectField: "counter_account_id",
 		},
+		{
+			name:        "history_balance_changes",
+			objectField: "history_account_id",
+		},
 	},
 	"history_assets": {
+		{
+			name:        "history_balance_changes",
+			objectField: "history_asset_id",
+		},
 		{
 			name:        "history_trades",
 			objectField: "base_asset_id",
@@ -1082,6 +1098,22 @@ var historyLookupTables = map[string][]tableObjectFieldPair{
 			name:        "history_trades_60000",
 			objectField: "counter_asset_id",
 		},
+		{
+			name:        "history_trades_3600000",
+			objectField: "base_asset_id",
+		},
+		{
+			name:        "history_trades_3600000",
+			objectField: "counter_asset_id",
+		},
+		{
+			name:        "history_trades_86400000",
+			objectField: "base_asset_id",
+		},
+		{
+			name:        "history_trades_86400000",
+			objectField: "counter_asset_id",
+		},
 	},
 	"history_claimable_balances": {
 		{
@@ -1102,6 +1134,20 @@ var historyLookupTables = map[string][]tableObjectFieldPair{
 			name:        "history_operation_liquidity_pools",
 			objectField: "history_liquidity_pool_id",
 		},
+		{
+			name:        "history_liquidity_pool_snapshots",
+			objectField: "history_liquidity_pool_id",
+		},
+	},
+	"history_contracts": {
+		{
+			name:        "history_transaction_contracts",
+			objectField: "history_contract_id",
+		},
+		{
+			name:        "history_operation_contracts",
+			objectField: "history_contract_id",
+		},
 	},
 }
 
@@ -1233,15 +1279,22 @@ func constructFindReapLookupTablesQuery(table string, batchSize int, offset int6
 func (q *Q) DeleteRangeAll(ctx context.Context, start, end int64) (int64, error) {
 	var total int64
 	for table, column := range map[string]string{
+		"history_balance_changes":                "history_operation_id",
+		"history_contract_events":                "history_operation_id",
 		"history_effects":                        "history_operation_id",
 		"history_ledgers":                        "id",
+		"history_liquidity_pool_snapshots":       "history_ledger_id",
 		"history_operation_claimable_balances":   "history_operation_id",
+		"history_operation_contracts":            "history_operation_id",
 		"history_operation_participants":         "history_operation_id",
 		"history_operation_liquidity_pools":      "history_operation_id",
 		"history_operations":                     "id",
 		"history_trades":                         "history_operation_id",
 		"history_trades_60000":                   "open_ledger_toid",
+		"history_trades_3600000":                 "open_ledger_toid",
+		"history_trades_86400000":                "open_ledger_toid",
 		"history_transaction_claimable_balances": "history_transaction_id",
+		"history_transaction_contracts":          "history_transaction_id",
 		"history_transaction_participants":       "history_transaction_id",
 		"history_transaction_liquidity_pools":    "history_transaction_id",
 		"history_transactions":                   "id",
@@ -0,0 +1,167 @@
+package history
+
+import (
+	"context"
+	"fmt"
+
+	sq "github.com/Masterminds/squirrel"
+
+	"github.com/stellar/go-stellar-sdk/support/errors"
+	strtime "github.com/stellar/go-stellar-sdk/support/time"
+	"github.com/stellar/stellar-horizon/internal/db2"
+)
+
+// MarketSummary is the summary of the trades of a market since a given time,
+// aggregated from the 1 minute trade aggregation buckets. Timestamp is the
+// bucket of the first trade of the summary.
+type MarketSummary struct {
+	BaseAssetID        int64  `db:"base_asset_id"`
+	BaseAssetType      string `db:"base_asset_type"`
+	BaseAssetCode      string `db:"base_asset_code"`
+	BaseAssetIssuer    string `db:"base_asset_issuer"`
+	CounterAssetID     int64  `db:"counter_asset_id"`
+	CounterAssetType   string `db:"counter_asset_type"`
+	CounterAssetCode   string `db:"counter_asset_code"`
+	CounterAssetIssuer string `db:"counter_asset_issuer"`
+	TradeAggregation
+}
+
+// PagingToken returns a cursor for this market summary
+func (s MarketSummary) PagingToken() string {
+	return fmt.Sprintf("%d-%d", s.BaseAssetID, s.CounterAssetID)
+}
+
+// marketSummaryColumns returns the aggregates of the trade aggregation
+// buckets of a market. The buckets are stored with the asset with the lowest
+// id as base asset, reversed swaps base and counter.
+func marketSummaryColumns(reversed bool) []string {
+	if reversed {
+		return []string{
+			"min(timestamp) as timestamp",
+			"sum(\"count\") as count",
+			"sum(counter_volume) as base_volume",
+			"sum(base_volume) as counter_volume",
+			"sum(base_volume::numeric)/sum(counter_volume::numeric) as avg",
+			"(min_price(ARRAY[low_n, low_d]))[2] as high_n",
+			"(min_price(ARRAY[low_n, low_d]))[1] as high_d",
+			"(max_price(ARRAY[high_n, high_d]))[2] as low_n",
+			"(max_price(ARRAY[high_n, high_d]))[1] as low_d",
+			"(first(ARRAY[open_n, open_d]))[2] as open_n",
+			"(first(ARRAY[open_n, open_d]))[1] as open_d",
+			"(last(ARRAY[close_n, close_d]))[2] as close_n",
+			"(last(ARRAY[close_n, close_d]))[1] as close_d",
+		}
+	}
+	return []string{
+		"min(timestamp) as timestamp",
+		"sum(\"count\") as count",
+		"sum(base_volume) as base_volume",
+		"sum(counter_volume) as counter_volume",
+		"sum(counter_volume::numeric)/sum(base_volume::numeric) as avg",
+		"(max_price(ARRAY[high_n, high_d]))[1] as high_n",
+		"(max_price(ARRAY[high_n, high_d]))[2] as high_d",
+		"(min_price(ARRAY[low_n, low_d]))[1] as low_n",
+		"(min_price(ARRAY[low_n, low_d]))[2] as low_d",
+		"(first(ARRAY[open_n, open_d]))[1] as open_n",
+		"(first(ARRAY[open_n, open_d]))[2] as open_d",
+		"(last(ARRAY[close_n, close_d]))[1] as close_n",
+		"(last(ARRAY[close_n, close_d]))[2] as close_d",
+	}
+}
+
+// marketBuckets selects the 1 minute buckets since the given time, ordered
+// by timestamp so first() and last() pick the open and close prices.
+func marketBuckets(since strtime.Millis) sq.SelectBuilder {
+	return sq.Select("*").
+		From(HistoryTradesTableName).
+		Where(sq.GtOrEq{"timestamp": since}).
+		OrderBy("base_asset_id", "counter_asset_id", "timestamp")
+}
+
+// selectMarketSummaries aggregates the buckets by market and joins the assets
+// of the markets.
+func selectMarketSummaries(buckets sq.SelectBuilder, reversed bool) sq.SelectBuilder {
+	baseColumn, counterColumn := "base_asset_id", "counter_asset_id"
+	if reversed {
+		baseColumn, counterColumn = counterColumn, baseColumn
+	}
+	summaries := sq.Select(
+		append([]string{
+			baseColumn + " as base_asset_id",
+			counterColumn + " as counter_asset_id",
+		}, marketSummaryColumns(reversed)...)...,
+	).FromSelect(buckets, "buckets").
+		GroupBy("buckets.base_asset_id", "buckets.counter_asset_id")
+
+	return sq.Select(
+		"summaries.*",
+		"base.asset_type as base_asset_type",
+		"base.asset_code as base_asset_code",
+		"base.asset_issuer as base_asset_issuer",
+		"counter.asset_type as counter_asset_type",
+		"counter.asset_code as counter_asset_code",
+		"counter.asset_issuer as counter_asset_issuer",
+	).FromSelect(summaries, "summaries").
+		Join("history_assets base ON base.id = summaries.base_asset_id").
+		Join("history_assets counter ON counter.id = summaries.counter_asset_id")
+}
+
+// MarketSummaries returns the summaries of the markets with trades since the
+// given time, paged by the ids of their base and counter assets. The page of
+// markets is selected first, only the buckets of these markets are aggregated.
+func (q *Q) MarketSummaries(ctx context.Context, since strtime.Millis, page db2.PageQuery) ([]MarketSummary, error) {
+	baseAssetID, counterAssetID, err := page.CursorInt64Pair(db2.DefaultPairSep)
+	if err != nil {
+		return nil, err
+	}
+
+	markets := sq.Select("base_asset_id", "counter_asset_id").Distinct().
+		From(HistoryTradesTableName).
+		Where(sq.GtOrEq{"timestamp": since}).
+		Limit(page.Limit)
+	var order string
+	switch page.Order {
+	case db2.OrderAscending:
+		markets = markets.Where(
+			"(base_asset_id, counter_asset_id) > (?, ?)", baseAssetID, counterAssetID,
+		).OrderBy("base_asset_id ASC", "counter_asset_id ASC")
+		order = "ASC"
+	case db2.OrderDescending:
+		markets = markets.Where(
+			"(base_asset_id, counter_asset_id) < (?, ?)", baseAssetID, counterAssetID,
+		).OrderBy("base_asset_id DESC", "counter_asset_id DESC")
+		order = "DESC"
+	default:
+		return nil, db2.ErrInvalidOrder
+	}
+
+	marketsSQL, marketsArgs, err := markets.ToSql()
+	if err != nil {
+		return nil, errors.Wrap(err, "could not build markets query")
+	}
+	buckets := marketBuckets(since).Where("(base_asset_id, counter_asset_id) IN ("+marketsSQL+")", marketsArgs...)
+	sql := selectMarketSummaries(buckets, false).
+		OrderBy("summaries.base_asset_id "+order, "summaries.counter_asset_id "+order)
+
+	var summaries []MarketSummary
+	if err := q.Select(ctx, &summaries, sql); err != nil {
+		return nil, errors.Wrap(err, "could not select market summaries")
+	}
+	return summaries, nil
+}
+
+// MarketSummary returns the summary of the trades of a market since the given
+// time, or sql.ErrNoRows when there are no trades.
+func (q *Q) MarketSummary(ctx context.Context, baseAssetID, counterAssetID int64, since strtime.Millis) (MarketSummary, error) {
+	reversed := baseAssetID > counterAssetID
+	buckets := marketBuckets(since)
+	if reversed {
+		buckets = buckets.Where(sq.Eq{"base_asset_id": counterAssetID, "counter_asset_id": baseAssetID})
+	} else {
+		buckets = buckets.Where(sq.Eq{"base_asset_id": baseAssetID, "counter_asset_id": counterAssetID})
+	}
+
+	var summary MarketSummary
+	err := q.Get(ctx, &summary, selectMarketSummaries(buckets, reversed))
+	return summary, err
+}
@@ -0,0 +1,113 @@
+package history
+
+import (
+	"testing"
+	"time"
+
+	strtime "github.com/stellar/go-stellar-sdk/support/time"
+	"github.com/stellar/go-stellar-sdk/toid"
+	"github.com/stellar/go-stellar-sdk/xdr"
+
+	"github.com/stellar/stellar-horizon/internal/db2"
+	"github.com/stellar/stellar-horizon/internal/test"
+)
+
+func TestMarketSummaries(t *testing.T) {
+	tt := test.Start(t)
+	defer tt.Finish()
+	test.ResetHorizonDB(t, tt.HorizonDB)
+	q := &Q{tt.HorizonSession()}
+
+	usdc := xdr.MustNewCreditAsset("USDC", "GA5ZSEJYB37JRC5AVCIA5MOP4RHTM335X2KGX3IHOJAPP5RE34K4KZVN")
+	assets, err := q.CreateAssets(tt.Ctx, []xdr.Asset{xdr.MustNewNativeAsset(), usdc}, 2)
+	tt.Assert.NoError(err)
+	baseAssetID := assets[xdr.MustNewNativeAsset().String()].ID
+	counterAssetID := assets[usdc.String()].ID
+	if baseAssetID > counterAssetID {
+		baseAssetID, counterAssetID = counterAssetID, baseAssetID
+	}
+
+	// an old trade outside of the summarized period, followed by trades at
+	// prices 2, 4 and 3
+	start := time.Date(2023, 3, 1, 0, 0, 0, 0, time.UTC)
+	var trades []InsertTrade
+	for i, trade := range []struct {
+		offset        time.Duration
+		counterAmount int64
+	}{
+		{-time.Hour, 1000},
+		{time.Minute, 200},
+		{2 * time.Minute, 400},
+		{3 * time.Minute, 300},
+	} {
+		trades = append(trades, InsertTrade{
+			HistoryOperationID: toid.New(int32(100+i), 1, 1).ToInt64(),
+			Order:              1,
+			LedgerCloseTime:    start.Add(trade.offset),
+			BaseAssetID:        baseAssetID,
+			CounterAssetID:     counterAssetID,
+			BaseIsSeller:       true,
+			BaseAmount:         100,
+			CounterAmount:      trade.counterAmount,
+			PriceN:             trade.counterAmount,
+			PriceD:             100,
+			Type:               OrderbookTradeType,
+		})
+	}
+
+	tt.Assert.NoError(q.Begin(tt.Ctx))
+	builder := q.NewTradeBatchInsertBuilder()
+	for _, trade := range trades {
+		tt.Assert.NoError(builder.Add(trade))
+	}
+	tt.Assert.NoError(builder.Exec(tt.Ctx, q))
+	tt.Assert.NoError(q.RebuildTradeAggregationTimes(
+		tt.Ctx,
+		strtime.MillisFromTime(trades[0].LedgerCloseTime),
+		strtime.MillisFromTime(trades[len(trades)-1].LedgerCloseTime),
+		1000,
+	))
+	tt.Assert.NoError(q.Commit())
+
+	since := strtime.MillisFromTime(start)
+	summaries, err := q.MarketSummaries(tt.Ctx, since, db2.PageQuery{Order: db2.OrderAscending, Limit: 10})
+	tt.Assert.NoError(err)
+	tt.Assert.Len(summaries, 1)
+	summary := summaries[0]
+	tt.Assert.Equal(baseAssetID, summary.BaseAssetID)
+	tt.Assert.Equal(counterAssetID, summary.CounterAssetID)
+	tt.Assert.Equal(int64(3), summary.TradeCount)
+	tt.Assert.Equal("300", summary.BaseVolume)
+	tt.Assert.Equal("900", summary.CounterVolume)
+	tt.Assert.Equal([2]int64{2, 1}, [2]int64{summary.OpenN, summary.OpenD})
+	tt.Assert.Equal([2]int64{4, 1}, [2]int64{summary.HighN, summary.HighD})
+	tt.Assert.Equal([2]int64{2, 1}, [2]int64{summary.LowN, summary.LowD})
+	tt.Assert.Equal([2]int64{3, 1}, [2]int64{summary.CloseN, summary.CloseD})
+
+	summaries, err = q.MarketSummaries(tt.Ctx, since, db2.PageQuery{
+		Cursor: summary.PagingToken(), Order: db2.OrderAscending, Limit: 10,
+	})
+	tt.Assert.NoError(err)
+	tt.Assert.Empty(summaries)
+
+	tt.Assert.Equal(summary, mustMarketSummary(tt, q, baseAssetID, counterAssetID, since))
+
+	// the reversed market has reversed volumes and inverted prices
+	reversed := mustMarketSummary(tt, q, counterAssetID, baseAssetID, since)
+	tt.Assert.Equal(counterAssetID, reversed.BaseAssetID)
+	tt.Assert.Equal("900", reversed.BaseVolume)
+	tt.Assert.Equal("300", reversed.CounterVolume)
+	tt.Assert.Equal([2]int64{1, 2}, [2]int64{reversed.OpenN, reversed.OpenD})
+	tt.Assert.Equal([2]int64{1, 2}, [2]int64{reversed.HighN, reversed.HighD})
+	tt.Assert.Equal([2]int64{1, 4}, [2]int64{reversed.LowN, reversed.LowD})
+	tt.Assert.Equal([2]int64{1, 3}, [2]int64{reversed.CloseN, reversed.CloseD})
+
+	_, err = q.MarketSummary(tt.Ctx, baseAssetID, counterAssetID, strtime.MillisFromTime(start.Add(time.Hour)))
+	tt.Assert.True(q.NoRows(err))
+}
+
+func mustMarketSummary(tt *test.T, q *Q, baseAssetID, counterAssetID int64, since strtime.Millis) MarketSummary {
+	summary, err := q.MarketSummary(tt.Ctx, baseAssetID, counterAssetID, since)
+	tt.Assert.NoError(err)
+	return summary
+}
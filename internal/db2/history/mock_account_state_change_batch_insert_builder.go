@@ -0,0 +1,26 @@
+package history
+
+import (
+	"context"
+
+	"github.com/stretchr/testify/mock"
+)
+
+type MockAccountStateChangeBatchInsertBuilder struct {
+	mock.Mock
+}
+
+func (m *MockAccountStateChangeBatchInsertBuilder) Add(change AccountStateChange) error {
+	a := m.Called(change)
+	return a.Error(0)
+}
+
+func (m *MockAccountStateChangeBatchInsertBuilder) Exec(ctx context.Context) error {
+	a := m.Called(ctx)
+	return a.Error(0)
+}
+
+func (m *MockAccountStateChangeBatchInsertBuilder) Len() int {
+	a := m.Called()
+	return a.Int(0)
+}
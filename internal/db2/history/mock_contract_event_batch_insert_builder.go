@@ -0,0 +1,44 @@
+package history
+
+import (
+	"context"
+
+	"github.com/guregu/null"
+	"github.com/stretchr/testify/mock"
+
+	"github.com/stellar/go-stellar-sdk/support/db"
+	"github.com/stellar/go-stellar-sdk/xdr"
+)
+
+// MockContractEventBatchInsertBuilder mock ContractEventBatchInsertBuilder
+type MockContractEventBatchInsertBuilder struct {
+	mock.Mock
+}
+
+// Add mock
+func (m *MockContractEventBatchInsertBuilder) Add(
+	operationID int64,
+	order uint32,
+	transactionID int64,
+	contractID null.String,
+	eventType xdr.ContractEventType,
+	topics []string,
+	data string,
+) error {
+	a := m.Called(
+		operationID,
+		order,
+		transactionID,
+		contractID,
+		eventType,
+		topics,
+		data,
+	)
+	return a.Error(0)
+}
+
+// Exec mock
+func (m *MockContractEventBatchInsertBuilder) Exec(ctx context.Context, session db.SessionInterface) error {
+	a := m.Called(ctx, session)
+	return a.Error(0)
+}
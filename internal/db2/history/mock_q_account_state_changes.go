@@ -0,0 +1,22 @@
+package history
+
+import (
+	"context"
+
+	"github.com/stretchr/testify/mock"
+)
+
+// MockQAccountStateChanges is a mock implementation of the QAccountStateChanges interface
+type MockQAccountStateChanges struct {
+	mock.Mock
+}
+
+func (m *MockQAccountStateChanges) NewAccountStateChangeBatchInsertBuilder() AccountStateChangeBatchInsertBuilder {
+	a := m.Called()
+	return a.Get(0).(AccountStateChangeBatchInsertBuilder)
+}
+
+func (m *MockQAccountStateChanges) DeleteAccountStateChangesRange(ctx context.Context, start, end int64) (int64, error) {
+	a := m.Called(ctx, start, end)
+	return a.Get(0).(int64), a.Error(1)
+}
@@ -2,6 +2,7 @@ package history
 
 import (
 	"context"
+	"time"
 
 	"github.com/stretchr/testify/mock"
 
@@ -39,8 +40,8 @@ func (m *MockQAssetStats) RemoveContractAssetBalances(ctx context.Context, keys
 	return a.Error(0)
 }
 
-func (m *MockQAssetStats) UpdateContractAssetBalanceAmounts(ctx context.Context, keys []xdr.Hash, amounts []string) error {
-	a := m.Called(ctx, keys, amounts)
+func (m *MockQAssetStats) UpdateContractAssetBalanceAmounts(ctx context.Context, keys []xdr.Hash, amounts []string, ledger uint32) error {
+	a := m.Called(ctx, keys, amounts, ledger)
 	return a.Error(0)
 }
 
@@ -123,3 +124,13 @@ func (m *MockQAssetStats) CountContractIDs(ctx context.Context) (int, error) {
 	a := m.Called(ctx)
 	return a.Get(0).(int), a.Error(1)
 }
+
+func (m *MockQAssetStats) InsertAssetStatSnapshots(ctx context.Context, ledger uint32, closedAt time.Time, deltas []ExpAssetStat, contractIDs []xdr.ContractId) error {
+	a := m.Called(ctx, ledger, closedAt, deltas, contractIDs)
+	return a.Error(0)
+}
+
+func (m *MockQAssetStats) DeleteAssetStatSnapshotsRange(ctx context.Context, start, end int64) (int64, error) {
+	a := m.Called(ctx, start, end)
+	return a.Get(0).(int64), a.Error(1)
+}
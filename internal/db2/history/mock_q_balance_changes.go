@@ -0,0 +1,36 @@
+package history
+
+import (
+	"context"
+
+	"github.com/stretchr/testify/mock"
+
+	"github.com/stellar/go-stellar-sdk/support/db"
+)
+
+// MockQBalanceChanges is a mock implementation of the QBalanceChanges interface
+type MockQBalanceChanges struct {
+	mock.Mock
+}
+
+func (m *MockQBalanceChanges) NewBalanceChangeBatchInsertBuilder() BalanceChangeBatchInsertBuilder {
+	a := m.Called()
+	return a.Get(0).(BalanceChangeBatchInsertBuilder)
+}
+
+// MockBalanceChangeBatchInsertBuilder mock BalanceChangeBatchInsertBuilder
+type MockBalanceChangeBatchInsertBuilder struct {
+	mock.Mock
+}
+
+// Add mock
+func (m *MockBalanceChangeBatchInsertBuilder) Add(change InsertBalanceChange) error {
+	a := m.Called(change)
+	return a.Error(0)
+}
+
+// Exec mock
+func (m *MockBalanceChangeBatchInsertBuilder) Exec(ctx context.Context, session db.SessionInterface) error {
+	a := m.Called(ctx, session)
+	return a.Error(0)
+}
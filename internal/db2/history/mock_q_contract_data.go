@@ -0,0 +1,39 @@
+package history
+
+import (
+	"context"
+
+	"github.com/stretchr/testify/mock"
+
+	"github.com/stellar/go-stellar-sdk/xdr"
+)
+
+// MockQContractData is a mock implementation of the QContractData interface
+type MockQContractData struct {
+	mock.Mock
+}
+
+func (m *MockQContractData) UpsertContractData(ctx context.Context, rows []ContractData) error {
+	a := m.Called(ctx, rows)
+	return a.Error(0)
+}
+
+func (m *MockQContractData) RemoveContractData(ctx context.Context, keys []xdr.Hash) (int64, error) {
+	a := m.Called(ctx, keys)
+	return a.Get(0).(int64), a.Error(1)
+}
+
+func (m *MockQContractData) UpdateContractDataExpirations(ctx context.Context, keys []xdr.Hash, expirationLedgers []uint32) error {
+	a := m.Called(ctx, keys, expirationLedgers)
+	return a.Error(0)
+}
+
+func (m *MockQContractData) GetContractDataByKeyHashes(ctx context.Context, keys []xdr.Hash) ([]ContractData, error) {
+	a := m.Called(ctx, keys)
+	return a.Get(0).([]ContractData), a.Error(1)
+}
+
+func (m *MockQContractData) CountContractData(ctx context.Context) (int, error) {
+	a := m.Called(ctx)
+	return a.Get(0).(int), a.Error(1)
+}
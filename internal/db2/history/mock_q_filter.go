@@ -30,3 +30,38 @@ func (m *MockQFilter) UpdateAssetFilterConfig(ctx context.Context, config AssetF
 	a := m.Called(ctx, config)
 	return a.Get(0).(AssetFilterConfig), a.Error(0)
 }
+
+func (m *MockQFilter) GetContractFilterConfig(ctx context.Context) (ContractFilterConfig, error) {
+	a := m.Called(ctx)
+	return a.Get(0).(ContractFilterConfig), a.Error(1)
+}
+
+func (m *MockQFilter) GetOperationTypeFilterConfig(ctx context.Context) (OperationTypeFilterConfig, error) {
+	a := m.Called(ctx)
+	return a.Get(0).(OperationTypeFilterConfig), a.Error(1)
+}
+
+func (m *MockQFilter) UpdateContractFilterConfig(ctx context.Context, config ContractFilterConfig) (ContractFilterConfig, error) {
+	a := m.Called(ctx, config)
+	return a.Get(0).(ContractFilterConfig), a.Error(1)
+}
+
+func (m *MockQFilter) UpdateOperationTypeFilterConfig(ctx context.Context, config OperationTypeFilterConfig) (OperationTypeFilterConfig, error) {
+	a := m.Called(ctx, config)
+	return a.Get(0).(OperationTypeFilterConfig), a.Error(1)
+}
+
+func (m *MockQFilter) NotifyFilterRulesUpdated(ctx context.Context, filterName string) error {
+	a := m.Called(ctx, filterName)
+	return a.Error(0)
+}
+
+func (m *MockQFilter) GetFilterRulesAppliedFrom(ctx context.Context, filterName string) (FilterRulesAppliedFrom, error) {
+	a := m.Called(ctx, filterName)
+	return a.Get(0).(FilterRulesAppliedFrom), a.Error(1)
+}
+
+func (m *MockQFilter) UpdateFilterRulesAppliedFrom(ctx context.Context, filterName string, appliedFrom FilterRulesAppliedFrom) error {
+	a := m.Called(ctx, filterName, appliedFrom)
+	return a.Error(0)
+}
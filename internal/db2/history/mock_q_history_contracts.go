@@ -0,0 +1,58 @@
+package history
+
+import (
+	"context"
+
+	"github.com/stellar/go-stellar-sdk/support/db"
+
+	"github.com/stretchr/testify/mock"
+)
+
+// MockQHistoryContracts is a mock implementation of the QHistoryContracts interface
+type MockQHistoryContracts struct {
+	mock.Mock
+}
+
+// NewTransactionContractBatchInsertBuilder mock
+func (m *MockQHistoryContracts) NewTransactionContractBatchInsertBuilder() TransactionContractBatchInsertBuilder {
+	a := m.Called()
+	return a.Get(0).(TransactionContractBatchInsertBuilder)
+}
+
+// MockTransactionContractBatchInsertBuilder is a mock implementation of the
+// TransactionContractBatchInsertBuilder interface
+type MockTransactionContractBatchInsertBuilder struct {
+	mock.Mock
+}
+
+func (m *MockTransactionContractBatchInsertBuilder) Add(transactionID int64, contract FutureContractID) error {
+	a := m.Called(transactionID, contract)
+	return a.Error(0)
+}
+
+func (m *MockTransactionContractBatchInsertBuilder) Exec(ctx context.Context, session db.SessionInterface) error {
+	a := m.Called(ctx, session)
+	return a.Error(0)
+}
+
+// NewOperationContractBatchInsertBuilder mock
+func (m *MockQHistoryContracts) NewOperationContractBatchInsertBuilder() OperationContractBatchInsertBuilder {
+	a := m.Called()
+	return a.Get(0).(OperationContractBatchInsertBuilder)
+}
+
+// MockOperationContractBatchInsertBuilder is a mock implementation of the
+// OperationContractBatchInsertBuilder interface
+type MockOperationContractBatchInsertBuilder struct {
+	mock.Mock
+}
+
+func (m *MockOperationContractBatchInsertBuilder) Add(operationID int64, contract FutureContractID) error {
+	a := m.Called(operationID, contract)
+	return a.Error(0)
+}
+
+func (m *MockOperationContractBatchInsertBuilder) Exec(ctx context.Context, session db.SessionInterface) error {
+	a := m.Called(ctx, session)
+	return a.Error(0)
+}
@@ -60,3 +60,25 @@ func (m *MockOperationLiquidityPoolBatchInsertBuilder) Exec(ctx context.Context,
 	a := m.Called(ctx, session)
 	return a.Error(0)
 }
+
+// NewLiquidityPoolSnapshotBatchInsertBuilder mock
+func (m *MockQHistoryLiquidityPools) NewLiquidityPoolSnapshotBatchInsertBuilder() LiquidityPoolSnapshotBatchInsertBuilder {
+	a := m.Called()
+	return a.Get(0).(LiquidityPoolSnapshotBatchInsertBuilder)
+}
+
+// MockLiquidityPoolSnapshotBatchInsertBuilder is a mock implementation of the
+// LiquidityPoolSnapshotBatchInsertBuilder interface
+type MockLiquidityPoolSnapshotBatchInsertBuilder struct {
+	mock.Mock
+}
+
+func (m *MockLiquidityPoolSnapshotBatchInsertBuilder) Add(snapshot InsertLiquidityPoolSnapshot) error {
+	a := m.Called(snapshot)
+	return a.Error(0)
+}
+
+func (m *MockLiquidityPoolSnapshotBatchInsertBuilder) Exec(ctx context.Context, session db.SessionInterface) error {
+	a := m.Called(ctx, session)
+	return a.Error(0)
+}
@@ -0,0 +1,70 @@
+package history
+
+import (
+	"context"
+	"time"
+
+	"github.com/stretchr/testify/mock"
+
+	"github.com/stellar/stellar-horizon/internal/db2"
+)
+
+// MockQSubscriptions is a mock implementation of the QSubscriptions interface
+type MockQSubscriptions struct {
+	mock.Mock
+}
+
+func (m *MockQSubscriptions) CreateSubscription(ctx context.Context, subscription Subscription) (Subscription, error) {
+	a := m.Called(ctx, subscription)
+	return a.Get(0).(Subscription), a.Error(1)
+}
+
+func (m *MockQSubscriptions) GetSubscription(ctx context.Context, id int64) (Subscription, error) {
+	a := m.Called(ctx, id)
+	return a.Get(0).(Subscription), a.Error(1)
+}
+
+func (m *MockQSubscriptions) GetSubscriptions(ctx context.Context, page db2.PageQuery) ([]Subscription, error) {
+	a := m.Called(ctx, page)
+	return a.Get(0).([]Subscription), a.Error(1)
+}
+
+func (m *MockQSubscriptions) GetAllSubscriptions(ctx context.Context) ([]Subscription, error) {
+	a := m.Called(ctx)
+	return a.Get(0).([]Subscription), a.Error(1)
+}
+
+func (m *MockQSubscriptions) GetSubscriptionsVersion(ctx context.Context) (SubscriptionsVersion, error) {
+	a := m.Called(ctx)
+	return a.Get(0).(SubscriptionsVersion), a.Error(1)
+}
+
+func (m *MockQSubscriptions) DeleteSubscription(ctx context.Context, id int64) (int64, error) {
+	a := m.Called(ctx, id)
+	return a.Get(0).(int64), a.Error(1)
+}
+
+func (m *MockQSubscriptions) NewSubscriptionDeliveryBatchInsertBuilder() SubscriptionDeliveryBatchInsertBuilder {
+	a := m.Called()
+	return a.Get(0).(SubscriptionDeliveryBatchInsertBuilder)
+}
+
+func (m *MockQSubscriptions) GetSubscriptionDeliveries(ctx context.Context, subscriptionID int64, page db2.PageQuery) ([]SubscriptionDelivery, error) {
+	a := m.Called(ctx, subscriptionID, page)
+	return a.Get(0).([]SubscriptionDelivery), a.Error(1)
+}
+
+func (m *MockQSubscriptions) ClaimSubscriptionDeliveries(ctx context.Context, limit int, lease time.Duration) ([]SubscriptionDelivery, error) {
+	a := m.Called(ctx, limit, lease)
+	return a.Get(0).([]SubscriptionDelivery), a.Error(1)
+}
+
+func (m *MockQSubscriptions) UpdateSubscriptionDelivery(ctx context.Context, delivery SubscriptionDelivery) error {
+	a := m.Called(ctx, delivery)
+	return a.Error(0)
+}
+
+func (m *MockQSubscriptions) DeleteSubscriptionDeliveriesBefore(ctx context.Context, before time.Time) (int64, error) {
+	a := m.Called(ctx, before)
+	return a.Get(0).(int64), a.Error(1)
+}
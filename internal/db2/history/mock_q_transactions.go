@@ -1,6 +1,10 @@
 package history
 
-import "github.com/stretchr/testify/mock"
+import (
+	"context"
+
+	"github.com/stretchr/testify/mock"
+)
 
 // MockQTransactions is a mock implementation of the QTransactions interface
 type MockQTransactions struct {
@@ -16,3 +20,8 @@ func (m *MockQTransactions) NewTransactionFilteredTmpBatchInsertBuilder() Transa
 	a := m.Called()
 	return a.Get(0).(TransactionBatchInsertBuilder)
 }
+
+func (m *MockQTransactions) TransactionHashesInLedgerRange(ctx context.Context, fromLedger, toLedger uint32) ([]string, error) {
+	a := m.Called(ctx, fromLedger, toLedger)
+	return a.Get(0).([]string), a.Error(1)
+}
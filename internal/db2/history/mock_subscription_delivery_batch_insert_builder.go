@@ -0,0 +1,26 @@
+package history
+
+import (
+	"context"
+
+	"github.com/stretchr/testify/mock"
+
+	"github.com/stellar/go-stellar-sdk/support/db"
+)
+
+// MockSubscriptionDeliveryBatchInsertBuilder mock SubscriptionDeliveryBatchInsertBuilder
+type MockSubscriptionDeliveryBatchInsertBuilder struct {
+	mock.Mock
+}
+
+// Add mock
+func (m *MockSubscriptionDeliveryBatchInsertBuilder) Add(subscriptionID int64, ledgerSequence uint32, payload []byte) error {
+	a := m.Called(subscriptionID, ledgerSequence, payload)
+	return a.Error(0)
+}
+
+// Exec mock
+func (m *MockSubscriptionDeliveryBatchInsertBuilder) Exec(ctx context.Context, session db.SessionInterface) error {
+	a := m.Called(ctx, session)
+	return a.Error(0)
+}
@@ -194,6 +194,26 @@ func (q *OperationsQ) ForLiquidityPool(ctx context.Context, lpID string) *Operat
 	return q
 }
 
+// ForContract filters the query to only operations pertaining to a
+// contract, specified by its strkey encoded address.
+func (q *OperationsQ) ForContract(ctx context.Context, contractID string) *OperationsQ {
+	var hContract HistoryContract
+	hContract, q.Err = q.parent.ContractByID(ctx, contractID)
+	if q.Err != nil {
+		return q
+	}
+
+	q.sql = q.sql.Join(
+		"history_operation_contracts hoc ON "+
+			"hoc.history_operation_id = hop.id",
+	).Where("hoc.history_contract_id = ?", hContract.InternalID)
+
+	// in order to use hoc.history_operation_id index
+	q.opIdCol = "hoc.history_operation_id"
+
+	return q
+}
+
 // ForLedger filters the query to a only operations in a specific ledger,
 // specified by its sequence.
 func (q *OperationsQ) ForLedger(ctx context.Context, seq int32) *OperationsQ {
@@ -156,6 +156,96 @@ func TestOperationByLiquidityPool(t *testing.T) {
 	tt.Assert.Equal(ops[1].ID, opID1)
 }
 
+func TestOperationByContract(t *testing.T) {
+	tt := test.Start(t)
+	defer tt.Finish()
+	test.ResetHorizonDB(t, tt.HorizonDB)
+	q := &Q{tt.HorizonSession()}
+
+	txIndex := int32(1)
+	sequence := int32(56)
+	txID := toid.New(sequence, txIndex, 0).ToInt64()
+	opID1 := toid.New(sequence, txIndex, 1).ToInt64()
+	opID2 := toid.New(sequence, txIndex, 2).ToInt64()
+
+	tt.Assert.NoError(q.Begin(tt.Ctx))
+
+	// Insert a phony transaction
+	transactionBuilder := q.NewTransactionBatchInsertBuilder()
+	firstTransaction := buildLedgerTransaction(tt.T, testTransaction{
+		index:         uint32(txIndex),
+		envelopeXDR:   "AAAAACiSTRmpH6bHC6Ekna5e82oiGY5vKDEEUgkq9CB//t+rAAAAyAEXUhsAADDRAAAAAAAAAAAAAAABAAAAAAAAAAsBF1IbAABX4QAAAAAAAAAA",
+		resultXDR:     "AAAAAAAAASwAAAAAAAAAAwAAAAAAAAAAAAAAAAAAAAAAAAABAAAAAAAAAAAAAAAFAAAAAAAAAAA=",
+		feeChangesXDR: "AAAAAA==",
+		metaXDR:       "AAAAAQAAAAAAAAAA",
+		hash:          "19aaa18db88605aedec04659fb45e06f240b022eb2d429e05133e4d53cd945ba",
+	})
+	err := transactionBuilder.Add(firstTransaction, uint32(sequence))
+	tt.Assert.NoError(err)
+	err = transactionBuilder.Exec(tt.Ctx, q)
+	tt.Assert.NoError(err)
+
+	// Insert a two phony operations
+	operationBuilder := q.NewOperationBatchInsertBuilder()
+	err = operationBuilder.Add(
+		opID1,
+		txID,
+		1,
+		xdr.OperationTypeEndSponsoringFutureReserves,
+		[]byte("{}"),
+		"GAUJETIZVEP2NRYLUESJ3LS66NVCEGMON4UDCBCSBEVPIID773P2W6AY",
+		null.String{},
+		false,
+	)
+	tt.Assert.NoError(err)
+
+	err = operationBuilder.Add(
+		opID2,
+		txID,
+		1,
+		xdr.OperationTypeEndSponsoringFutureReserves,
+		[]byte("{}"),
+		"GAUJETIZVEP2NRYLUESJ3LS66NVCEGMON4UDCBCSBEVPIID773P2W6AY",
+		null.String{},
+		false,
+	)
+	tt.Assert.NoError(err)
+	err = operationBuilder.Exec(tt.Ctx, q)
+	tt.Assert.NoError(err)
+
+	// Insert contract history
+	contractID := "CA3D5KRYM6CB7OWQ6TWYRR3Z4T7GNZLKERYNZGGA5SOAOPIFY6YQGAXE"
+	contractLoader := NewContractLoader(ConcurrentInserts)
+
+	contractOperationBuilder := q.NewOperationContractBatchInsertBuilder()
+	tt.Assert.NoError(contractOperationBuilder.Add(opID1, contractLoader.GetFuture(contractID)))
+	tt.Assert.NoError(contractOperationBuilder.Add(opID2, contractLoader.GetFuture(contractID)))
+	tt.Assert.NoError(contractLoader.Exec(tt.Ctx, q))
+	tt.Assert.NoError(contractOperationBuilder.Exec(tt.Ctx, q))
+
+	tt.Assert.NoError(q.Commit())
+
+	// Check ascending order
+	pq := db2.PageQuery{
+		Cursor: "",
+		Order:  "asc",
+		Limit:  2,
+	}
+	ops, _, err := q.Operations().ForContract(tt.Ctx, contractID).Page(pq, 0).Fetch(tt.Ctx)
+	tt.Assert.NoError(err)
+	tt.Assert.Len(ops, 2)
+	tt.Assert.Equal(ops[0].ID, opID1)
+	tt.Assert.Equal(ops[1].ID, opID2)
+
+	// Check descending order
+	pq.Order = "desc"
+	ops, _, err = q.Operations().ForContract(tt.Ctx, contractID).Page(pq, 0).Fetch(tt.Ctx)
+	tt.Assert.NoError(err)
+	tt.Assert.Len(ops, 2)
+	tt.Assert.Equal(ops[0].ID, opID2)
+	tt.Assert.Equal(ops[1].ID, opID1)
+}
+
 func TestOperationQueryBuilder(t *testing.T) {
 	tt := test.Start(t)
 	tt.Scenario("base")
@@ -0,0 +1,256 @@
+package history
+
+import (
+	"context"
+	"time"
+
+	sq "github.com/Masterminds/squirrel"
+	"github.com/guregu/null"
+	"github.com/lib/pq"
+
+	"github.com/stellar/go-stellar-sdk/support/db"
+	"github.com/stellar/go-stellar-sdk/support/errors"
+	"github.com/stellar/stellar-horizon/internal/db2"
+)
+
+// Statuses of the deliveries of the subscriptions.
+const (
+	SubscriptionDeliveryPending   = "pending"
+	SubscriptionDeliveryDelivered = "delivered"
+	SubscriptionDeliveryFailed    = "failed"
+)
+
+// Subscription is a row of data from the `subscriptions` table. Ingestion
+// delivers to the callback url the transactions having one of the accounts as
+// participant, or referring to one of the assets, or invoking one of the
+// contracts.
+type Subscription struct {
+	ID          int64          `db:"id"`
+	CallbackURL string         `db:"callback_url"`
+	Secret      string         `db:"secret"`
+	AccountIDs  pq.StringArray `db:"account_ids"`
+	Assets      pq.StringArray `db:"assets"`
+	ContractIDs pq.StringArray `db:"contract_ids"`
+	CreatedAt   time.Time      `db:"created_at"`
+}
+
+// SubscriptionDelivery is a row of data from the `subscription_deliveries`
+// table, it holds the payload delivered to a subscription for a ledger.
+type SubscriptionDelivery struct {
+	ID             int64       `db:"id"`
+	SubscriptionID int64       `db:"subscription_id"`
+	LedgerSequence uint32      `db:"ledger_sequence"`
+	Payload        []byte      `db:"payload"`
+	Status         string      `db:"status"`
+	Attempts       int32       `db:"attempts"`
+	NextAttemptAt  time.Time   `db:"next_attempt_at"`
+	LastError      null.String `db:"last_error"`
+	ResponseStatus null.Int    `db:"response_status"`
+	CreatedAt      time.Time   `db:"created_at"`
+	DeliveredAt    null.Time   `db:"delivered_at"`
+}
+
+// SubscriptionsVersion changes whenever a subscription is created or deleted,
+// subscriptions are never updated.
+type SubscriptionsVersion struct {
+	MaxID int64 `db:"max_id"`
+	Count int64 `db:"count"`
+}
+
+// QSubscriptions defines subscriptions related queries.
+type QSubscriptions interface {
+	CreateSubscription(ctx context.Context, subscription Subscription) (Subscription, error)
+	GetSubscription(ctx context.Context, id int64) (Subscription, error)
+	GetSubscriptions(ctx context.Context, page db2.PageQuery) ([]Subscription, error)
+	GetAllSubscriptions(ctx context.Context) ([]Subscription, error)
+	GetSubscriptionsVersion(ctx context.Context) (SubscriptionsVersion, error)
+	DeleteSubscription(ctx context.Context, id int64) (int64, error)
+	NewSubscriptionDeliveryBatchInsertBuilder() SubscriptionDeliveryBatchInsertBuilder
+	GetSubscriptionDeliveries(ctx context.Context, subscriptionID int64, page db2.PageQuery) ([]SubscriptionDelivery, error)
+	ClaimSubscriptionDeliveries(ctx context.Context, limit int, lease time.Duration) ([]SubscriptionDelivery, error)
+	UpdateSubscriptionDelivery(ctx context.Context, delivery SubscriptionDelivery) error
+	DeleteSubscriptionDeliveriesBefore(ctx context.Context, before time.Time) (int64, error)
+}
+
+var selectSubscription = sq.Select(
+	"id", "callback_url", "secret", "account_ids", "assets", "contract_ids", "created_at",
+).From("subscriptions")
+
+var selectSubscriptionDelivery = sq.Select(
+	"id", "subscription_id", "ledger_sequence", "payload", "status", "attempts",
+	"next_attempt_at", "last_error", "response_status", "created_at", "delivered_at",
+).From("subscription_deliveries")
+
+// CreateSubscription inserts the subscription and returns it with its id and
+// creation time.
+func (q *Q) CreateSubscription(ctx context.Context, subscription Subscription) (Subscription, error) {
+	sql := sq.Insert("subscriptions").SetMap(map[string]interface{}{
+		"callback_url": subscription.CallbackURL,
+		"secret":       subscription.Secret,
+		"account_ids":  nonNilStringArray(subscription.AccountIDs),
+		"assets":       nonNilStringArray(subscription.Assets),
+		"contract_ids": nonNilStringArray(subscription.ContractIDs),
+	}).Suffix("RETURNING id, callback_url, secret, account_ids, assets, contract_ids, created_at")
+
+	var created Subscription
+	if err := q.Get(ctx, &created, sql); err != nil {
+		return Subscription{}, errors.Wrap(err, "could not insert subscription")
+	}
+	return created, nil
+}
+
+// GetSubscription returns the subscription with the given id.
+func (q *Q) GetSubscription(ctx context.Context, id int64) (Subscription, error) {
+	var subscription Subscription
+	err := q.Get(ctx, &subscription, selectSubscription.Where("id = ?", id))
+	return subscription, err
+}
+
+// GetSubscriptions returns a page of subscriptions ordered by id.
+func (q *Q) GetSubscriptions(ctx context.Context, page db2.PageQuery) ([]Subscription, error) {
+	sql, err := page.ApplyTo(selectSubscription, "id")
+	if err != nil {
+		return nil, errors.Wrap(err, "could not apply page query")
+	}
+
+	var subscriptions []Subscription
+	err = q.Select(ctx, &subscriptions, sql)
+	return subscriptions, err
+}
+
+// GetAllSubscriptions returns all the subscriptions, ingestion keeps them in
+// memory to match the ingested transactions.
+func (q *Q) GetAllSubscriptions(ctx context.Context) ([]Subscription, error) {
+	var subscriptions []Subscription
+	err := q.Select(ctx, &subscriptions, selectSubscription.OrderBy("id asc"))
+	return subscriptions, err
+}
+
+// GetSubscriptionsVersion returns the current version of the subscriptions.
+func (q *Q) GetSubscriptionsVersion(ctx context.Context) (SubscriptionsVersion, error) {
+	var version SubscriptionsVersion
+	sql := sq.Select("COALESCE(MAX(id), 0) AS max_id", "COUNT(*) AS count").From("subscriptions")
+	err := q.Get(ctx, &version, sql)
+	return version, err
+}
+
+// DeleteSubscription removes the subscription and its deliveries, it returns
+// the number of subscriptions removed.
+func (q *Q) DeleteSubscription(ctx context.Context, id int64) (int64, error) {
+	result, err := q.Exec(ctx, sq.Delete("subscriptions").Where("id = ?", id))
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+// GetSubscriptionDeliveries returns a page of the deliveries of the
+// subscription ordered by id.
+func (q *Q) GetSubscriptionDeliveries(ctx context.Context, subscriptionID int64, page db2.PageQuery) ([]SubscriptionDelivery, error) {
+	sql, err := page.ApplyTo(selectSubscriptionDelivery.Where("subscription_id = ?", subscriptionID), "id")
+	if err != nil {
+		return nil, errors.Wrap(err, "could not apply page query")
+	}
+
+	var deliveries []SubscriptionDelivery
+	err = q.Select(ctx, &deliveries, sql)
+	return deliveries, err
+}
+
+// ClaimSubscriptionDeliveries returns up to limit pending deliveries due for
+// an attempt and postpones their next attempt by the lease duration, so that
+// concurrent dispatchers don't attempt the same deliveries.
+func (q *Q) ClaimSubscriptionDeliveries(ctx context.Context, limit int, lease time.Duration) ([]SubscriptionDelivery, error) {
+	var deliveries []SubscriptionDelivery
+	err := q.SelectRaw(ctx, &deliveries, `
+		UPDATE subscription_deliveries
+		SET next_attempt_at = (now() at time zone 'utc') + CAST(? AS double precision) * interval '1 second'
+		WHERE id IN (
+			SELECT id FROM subscription_deliveries
+			WHERE status = ? AND next_attempt_at <= (now() at time zone 'utc')
+			ORDER BY next_attempt_at, id
+			LIMIT ?
+			FOR UPDATE SKIP LOCKED
+		)
+		RETURNING id, subscription_id, ledger_sequence, payload, status, attempts,
+			next_attempt_at, last_error, response_status, created_at, delivered_at`,
+		lease.Seconds(), SubscriptionDeliveryPending, limit,
+	)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not claim subscription deliveries")
+	}
+	return deliveries, nil
+}
+
+// UpdateSubscriptionDelivery records the outcome of a delivery attempt.
+func (q *Q) UpdateSubscriptionDelivery(ctx context.Context, delivery SubscriptionDelivery) error {
+	sql := sq.Update("subscription_deliveries").SetMap(map[string]interface{}{
+		"status":          delivery.Status,
+		"attempts":        delivery.Attempts,
+		"next_attempt_at": delivery.NextAttemptAt,
+		"last_error":      delivery.LastError,
+		"response_status": delivery.ResponseStatus,
+		"delivered_at":    delivery.DeliveredAt,
+	}).Where("id = ?", delivery.ID)
+
+	_, err := q.Exec(ctx, sql)
+	return err
+}
+
+// DeleteSubscriptionDeliveriesBefore removes the delivered and failed
+// deliveries created before the given time and returns the number of rows
+// removed. Pending deliveries are kept until they are delivered or fail.
+func (q *Q) DeleteSubscriptionDeliveriesBefore(ctx context.Context, before time.Time) (int64, error) {
+	sql := sq.Delete("subscription_deliveries").
+		Where(sq.NotEq{"status": SubscriptionDeliveryPending}).
+		Where("created_at < ?", before.UTC())
+
+	result, err := q.Exec(ctx, sql)
+	if err != nil {
+		return 0, errors.Wrap(err, "could not delete subscription deliveries")
+	}
+	return result.RowsAffected()
+}
+
+// SubscriptionDeliveryBatchInsertBuilder is used to insert the deliveries of
+// an ingested ledger into the subscription_deliveries table
+type SubscriptionDeliveryBatchInsertBuilder interface {
+	Add(subscriptionID int64, ledgerSequence uint32, payload []byte) error
+	Exec(ctx context.Context, session db.SessionInterface) error
+}
+
+// subscriptionDeliveryBatchInsertBuilder is a simple wrapper around db.FastBatchInsertBuilder
+type subscriptionDeliveryBatchInsertBuilder struct {
+	table   string
+	builder db.FastBatchInsertBuilder
+}
+
+// NewSubscriptionDeliveryBatchInsertBuilder constructs a new SubscriptionDeliveryBatchInsertBuilder instance
+func (q *Q) NewSubscriptionDeliveryBatchInsertBuilder() SubscriptionDeliveryBatchInsertBuilder {
+	return &subscriptionDeliveryBatchInsertBuilder{
+		table:   "subscription_deliveries",
+		builder: db.FastBatchInsertBuilder{},
+	}
+}
+
+// Add adds a pending delivery to the batch
+func (i *subscriptionDeliveryBatchInsertBuilder) Add(subscriptionID int64, ledgerSequence uint32, payload []byte) error {
+	return i.builder.Row(map[string]interface{}{
+		"subscription_id": subscriptionID,
+		"ledger_sequence": ledgerSequence,
+		// the payload is passed as text, the copy protocol would encode a
+		// byte slice as bytea
+		"payload": string(payload),
+	})
+}
+
+func (i *subscriptionDeliveryBatchInsertBuilder) Exec(ctx context.Context, session db.SessionInterface) error {
+	return i.builder.Exec(ctx, session, i.table)
+}
+
+func nonNilStringArray(array pq.StringArray) pq.StringArray {
+	if array == nil {
+		return pq.StringArray{}
+	}
+	return array
+}
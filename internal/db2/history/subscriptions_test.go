@@ -0,0 +1,144 @@
+package history
+
+import (
+	"testing"
+	"time"
+
+	"github.com/lib/pq"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/stellar/stellar-horizon/internal/db2"
+	"github.com/stellar/stellar-horizon/internal/test"
+)
+
+func TestSubscriptions(t *testing.T) {
+	tt := test.Start(t)
+	defer tt.Finish()
+	test.ResetHorizonDB(t, tt.HorizonDB)
+	q := &Q{tt.HorizonSession()}
+
+	version, err := q.GetSubscriptionsVersion(tt.Ctx)
+	require.NoError(t, err)
+	assert.Equal(t, SubscriptionsVersion{}, version)
+
+	first, err := q.CreateSubscription(tt.Ctx, Subscription{
+		CallbackURL: "https://example.com/first",
+		Secret:      "secret",
+		AccountIDs:  pq.StringArray{"GD6WNNTW664WH7FXC5RUMUTF7P5QSURC2IT36VOQEEGFZ4UWUEQGECAL"},
+	})
+	require.NoError(t, err)
+	assert.NotZero(t, first.ID)
+	assert.Empty(t, first.Assets)
+	assert.Empty(t, first.ContractIDs)
+
+	second, err := q.CreateSubscription(tt.Ctx, Subscription{
+		CallbackURL: "https://example.com/second",
+		Secret:      "secret",
+		Assets:      pq.StringArray{"native"},
+	})
+	require.NoError(t, err)
+
+	fetched, err := q.GetSubscription(tt.Ctx, first.ID)
+	require.NoError(t, err)
+	assert.Equal(t, first.CallbackURL, fetched.CallbackURL)
+	assert.Equal(t, first.AccountIDs, fetched.AccountIDs)
+
+	subscriptions, err := q.GetSubscriptions(tt.Ctx, db2.PageQuery{Order: db2.OrderDescending, Limit: 10})
+	require.NoError(t, err)
+	require.Len(t, subscriptions, 2)
+	assert.Equal(t, second.ID, subscriptions[0].ID)
+
+	version, err = q.GetSubscriptionsVersion(tt.Ctx)
+	require.NoError(t, err)
+	assert.Equal(t, SubscriptionsVersion{MaxID: second.ID, Count: 2}, version)
+
+	require.NoError(t, q.Begin(tt.Ctx))
+	batch := q.NewSubscriptionDeliveryBatchInsertBuilder()
+	require.NoError(t, batch.Add(first.ID, 10, []byte(`{"ledger":10}`)))
+	require.NoError(t, batch.Add(second.ID, 10, []byte(`{"ledger":10}`)))
+	require.NoError(t, batch.Exec(tt.Ctx, q))
+	require.NoError(t, q.Commit())
+
+	claimed, err := q.ClaimSubscriptionDeliveries(tt.Ctx, 10, time.Minute)
+	require.NoError(t, err)
+	require.Len(t, claimed, 2)
+
+	// claimed deliveries are leased to the claiming dispatcher
+	leased, err := q.ClaimSubscriptionDeliveries(tt.Ctx, 10, time.Minute)
+	require.NoError(t, err)
+	assert.Empty(t, leased)
+
+	delivery := claimed[0]
+	delivery.Status = SubscriptionDeliveryDelivered
+	delivery.Attempts = 1
+	require.NoError(t, q.UpdateSubscriptionDelivery(tt.Ctx, delivery))
+
+	deliveries, err := q.GetSubscriptionDeliveries(tt.Ctx, delivery.SubscriptionID, db2.PageQuery{Order: db2.OrderAscending, Limit: 10})
+	require.NoError(t, err)
+	require.Len(t, deliveries, 1)
+	assert.Equal(t, SubscriptionDeliveryDelivered, deliveries[0].Status)
+	assert.Equal(t, int32(1), deliveries[0].Attempts)
+	assert.JSONEq(t, `{"ledger":10}`, string(deliveries[0].Payload))
+
+	// deleting a subscription removes its deliveries
+	deleted, err := q.DeleteSubscription(tt.Ctx, first.ID)
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), deleted)
+	deleted, err = q.DeleteSubscription(tt.Ctx, first.ID)
+	require.NoError(t, err)
+	assert.Equal(t, int64(0), deleted)
+
+	deliveries, err = q.GetSubscriptionDeliveries(tt.Ctx, first.ID, db2.PageQuery{Order: db2.OrderAscending, Limit: 10})
+	require.NoError(t, err)
+	assert.Empty(t, deliveries)
+
+	version, err = q.GetSubscriptionsVersion(tt.Ctx)
+	require.NoError(t, err)
+	assert.Equal(t, SubscriptionsVersion{MaxID: second.ID, Count: 1}, version)
+}
+
+func TestDeleteSubscriptionDeliveriesBefore(t *testing.T) {
+	tt := test.Start(t)
+	defer tt.Finish()
+	test.ResetHorizonDB(t, tt.HorizonDB)
+	q := &Q{tt.HorizonSession()}
+
+	subscription, err := q.CreateSubscription(tt.Ctx, Subscription{
+		CallbackURL: "https://example.com",
+		Secret:      "secret",
+		Assets:      pq.StringArray{"native"},
+	})
+	require.NoError(t, err)
+
+	require.NoError(t, q.Begin(tt.Ctx))
+	batch := q.NewSubscriptionDeliveryBatchInsertBuilder()
+	for ledger := uint32(10); ledger < 13; ledger++ {
+		require.NoError(t, batch.Add(subscription.ID, ledger, []byte(`{}`)))
+	}
+	require.NoError(t, batch.Exec(tt.Ctx, q))
+	require.NoError(t, q.Commit())
+
+	deliveries, err := q.GetSubscriptionDeliveries(tt.Ctx, subscription.ID, db2.PageQuery{Order: db2.OrderAscending, Limit: 10})
+	require.NoError(t, err)
+	require.Len(t, deliveries, 3)
+	deliveries[0].Status = SubscriptionDeliveryDelivered
+	require.NoError(t, q.UpdateSubscriptionDelivery(tt.Ctx, deliveries[0]))
+	deliveries[1].Status = SubscriptionDeliveryFailed
+	require.NoError(t, q.UpdateSubscriptionDelivery(tt.Ctx, deliveries[1]))
+
+	deleted, err := q.DeleteSubscriptionDeliveriesBefore(tt.Ctx, time.Now().Add(-time.Hour))
+	require.NoError(t, err)
+	assert.Equal(t, int64(0), deleted)
+
+	// the pending delivery is kept
+	deleted, err = q.DeleteSubscriptionDeliveriesBefore(tt.Ctx, time.Now().Add(time.Hour))
+	require.NoError(t, err)
+	assert.Equal(t, int64(2), deleted)
+
+	deliveries, err = q.GetSubscriptionDeliveries(tt.Ctx, subscription.ID, db2.PageQuery{Order: db2.OrderAscending, Limit: 10})
+	require.NoError(t, err)
+	require.Len(t, deliveries, 1)
+	assert.Equal(t, SubscriptionDeliveryPending, deliveries[0].Status)
+	assert.Equal(t, uint32(12), deliveries[0].LedgerSequence)
+}
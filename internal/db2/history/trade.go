@@ -9,6 +9,7 @@ import (
 
 	"github.com/stellar/go-stellar-sdk/support/db"
 	"github.com/stellar/go-stellar-sdk/support/errors"
+	"github.com/stellar/go-stellar-sdk/toid"
 	"github.com/stellar/go-stellar-sdk/xdr"
 	"github.com/stellar/stellar-horizon/internal/db2"
 )
@@ -76,6 +77,31 @@ func (q *Q) GetTradesForAssets(
 	})
 }
 
+// GetTradesForLedger returns all the trades of the ledger, in the order in
+// which they were executed.
+func (q *Q) GetTradesForLedger(ctx context.Context, seq int32) ([]Trade, error) {
+	start := toid.ID{LedgerSequence: seq}
+	end := toid.ID{LedgerSequence: seq + 1}
+	sql := joinTradeAssets(
+		joinTradeLiquidityPools(
+			joinTradeAccounts(
+				selectTradeFields.From("history_trades htrd"),
+				"history_accounts",
+			),
+			"history_liquidity_pools",
+		),
+		"history_assets",
+	).
+		Where("htrd.history_operation_id >= ? AND htrd.history_operation_id < ?", start.ToInt64(), end.ToInt64()).
+		OrderBy("htrd.history_operation_id asc, htrd.order asc")
+
+	var dest []Trade
+	if err := q.Select(ctx, &dest, sql); err != nil {
+		return nil, errors.Wrap(err, "could not select trades")
+	}
+	return dest, nil
+}
+
 type historyTradesQuery struct {
 	baseAssetID    int64
 	counterAssetID int64
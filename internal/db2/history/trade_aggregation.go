@@ -45,7 +45,38 @@ type TradeAggregation struct {
 	CloseD        int64   `db:"close_d"`
 }
 
-const HistoryTradesTableName = "history_trades_60000"
+const (
+	HistoryTradesTableName     = "history_trades_60000"
+	HistoryTradesHourTableName = "history_trades_3600000"
+	HistoryTradesDayTableName  = "history_trades_86400000"
+)
+
+// tradeAggregationTable is a table of precomputed trade aggregation buckets.
+type tradeAggregationTable struct {
+	name       string
+	resolution int64
+}
+
+// tradeAggregationTables are the precomputed trade aggregation tables, from the
+// finest to the coarsest resolution. Every table is rolled up from the
+// previous one.
+var tradeAggregationTables = []tradeAggregationTable{
+	{name: HistoryTradesTableName, resolution: 60_000},
+	{name: HistoryTradesHourTableName, resolution: 3_600_000},
+	{name: HistoryTradesDayTableName, resolution: 86_400_000},
+}
+
+// selectTradeAggregationTable returns the coarsest table whose buckets fit in
+// the buckets of the given resolution and offset.
+func selectTradeAggregationTable(resolution, offset int64) tradeAggregationTable {
+	table := tradeAggregationTables[0]
+	for _, candidate := range tradeAggregationTables[1:] {
+		if resolution%candidate.resolution == 0 && offset%candidate.resolution == 0 {
+			table = candidate
+		}
+	}
+	return table
+}
 
 // TradeAggregationsQ is a helper struct to aid in configuring queries to
 // bucket and aggregate trades
@@ -54,6 +85,7 @@ type TradeAggregationsQ struct {
 	counterAssetID int64
 	resolution     int64
 	offset         int64
+	table          tradeAggregationTable
 	startTime      strtime.Millis
 	endTime        strtime.Millis
 	pagingParams   db2.PageQuery
@@ -84,6 +116,7 @@ func (q Q) GetTradeAggregationsQ(baseAssetID int64, counterAssetID int64, resolu
 		counterAssetID: counterAssetID,
 		resolution:     resolution,
 		offset:         offset,
+		table:          selectTradeAggregationTable(resolution, offset),
 		pagingParams:   pagingParams,
 	}, nil
 }
@@ -135,7 +168,7 @@ func (q *TradeAggregationsQ) getRawTradesSql(orderPreserved bool) sq.SelectBuild
 
 	rawTradesSQL = rawTradesSQL.
 		Join("timestamp_range r ON 1=1").
-		From(fmt.Sprintf("%s AS tr", HistoryTradesTableName)).
+		From(fmt.Sprintf("%s AS tr", q.table.name)).
 		Where(sq.Eq{"base_asset_id": q.baseAssetID, "counter_asset_id": q.counterAssetID})
 
 	//adjust time range and apply time filters
@@ -144,7 +177,7 @@ func (q *TradeAggregationsQ) getRawTradesSql(orderPreserved bool) sq.SelectBuild
 		Where(fmt.Sprintf("r.max_ts >= %s", bucketTs)).
 		Where(fmt.Sprintf("r.min_ts <= %s", bucketTs))
 
-	if q.resolution != 60000 {
+	if q.resolution != q.table.resolution {
 		//ensure open/close order for cases when multiple trades occur in the same ledger
 		rawTradesSQL = rawTradesSQL.OrderBy("timestamp ASC", "open_ledger_toid ASC")
 		// Do on-the-fly aggregation for higher resolutions.
@@ -162,7 +195,7 @@ func (q *TradeAggregationsQ) GetSql() sq.SelectBuilder {
 		OrderBy("timestamp "+q.pagingParams.Order).
 		Prefix("WITH last_range_ts AS (?),",
 			lastRangeTs(
-				q.baseAssetID, q.counterAssetID, q.resolution, q.offset, q.startTime, q.endTime,
+				q.table.name, q.baseAssetID, q.counterAssetID, q.resolution, q.offset, q.startTime, q.endTime,
 				q.pagingParams.Order, q.pagingParams.Limit)).
 		Prefix("timestamp_range AS (?),",
 			timestampRange()).
@@ -187,11 +220,11 @@ func formatBucketTimestampSelect(resolution int64, offset int64, tsPrefix string
 	return fmt.Sprintf("%s AS timestamp", formatBucketTimestamp(resolution, offset, tsPrefix))
 }
 
-func lastRangeTs(baseAssetID, counterAssetID, resolution, offset int64, startTime, endTime strtime.Millis, order string, limit uint64) sq.SelectBuilder {
+func lastRangeTs(table string, baseAssetID, counterAssetID, resolution, offset int64, startTime, endTime strtime.Millis, order string, limit uint64) sq.SelectBuilder {
 	s := sq.Select(
 		formatBucketTimestampSelect(resolution, offset, ""),
 	).From(
-		HistoryTradesTableName,
+		table,
 	).Where(
 		sq.Eq{"base_asset_id": baseAssetID, "counter_asset_id": counterAssetID},
 	).Where(sq.GtOrEq{"timestamp": startTime})
@@ -274,7 +307,8 @@ func aggregate(rawTradesTable string) sq.SelectBuilder {
 
 // RebuildTradeAggregationTimes rebuilds a specific set of trade aggregation
 // buckets, (specified by start and end times) to ensure complete data in case
-// of partial reingestion.
+// of partial reingestion. The hourly and daily rollups of the rebuilt buckets
+// are rebuilt as well.
 func (q Q) RebuildTradeAggregationTimes(ctx context.Context, from, to strtime.Millis, roundingSlippageFilter int) error {
 	from = from.RoundDown(60_000)
 	to = to.RoundDown(60_000)
@@ -333,6 +367,66 @@ func (q Q) RebuildTradeAggregationTimes(ctx context.Context, from, to strtime.Mi
 	if err != nil {
 		return errors.Wrap(err, "could not rebuild trade aggregation bucket")
 	}
+
+	// Roll the rebuilt buckets up into the coarser tables.
+	for i := 1; i < len(tradeAggregationTables); i++ {
+		err = q.rebuildTradeAggregationRollup(ctx, tradeAggregationTables[i-1], tradeAggregationTables[i], from, to)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// rebuildTradeAggregationRollup rebuilds the buckets of the rollup table
+// containing the times between from and to, by aggregating the buckets of the
+// finer source table.
+func (q Q) rebuildTradeAggregationRollup(ctx context.Context, source, rollup tradeAggregationTable, from, to strtime.Millis) error {
+	from = from.RoundDown(rollup.resolution)
+	to = to.RoundDown(rollup.resolution)
+	_, err := q.Exec(ctx, sq.Delete(rollup.name).Where(
+		sq.GtOrEq{"timestamp": from},
+	).Where(
+		sq.LtOrEq{"timestamp": to},
+	))
+	if err != nil {
+		return errors.Wrapf(err, "could not rebuild %s trade aggregation bucket", rollup.name)
+	}
+
+	bucket := fmt.Sprintf("(timestamp / %d) * %d", rollup.resolution, rollup.resolution)
+	buckets := sq.Select(
+		bucket+" as bucket",
+		"*",
+	).From(source.name).Where(
+		sq.GtOrEq{"timestamp": from},
+	).Where(
+		sq.Lt{"timestamp": to + strtime.MillisFromInt64(rollup.resolution)},
+	).OrderBy("base_asset_id", "counter_asset_id", "timestamp")
+
+	rolledUp := sq.Select(
+		"bucket as timestamp",
+		"base_asset_id",
+		"counter_asset_id",
+		"sum(\"count\") as count",
+		"sum(base_volume) as base_volume",
+		"sum(counter_volume) as counter_volume",
+		"sum(counter_volume)/sum(base_volume) as avg",
+		"(max_price(ARRAY[high_n, high_d]))[1] as high_n",
+		"(max_price(ARRAY[high_n, high_d]))[2] as high_d",
+		"(min_price(ARRAY[low_n, low_d]))[1] as low_n",
+		"(min_price(ARRAY[low_n, low_d]))[2] as low_d",
+		"first(open_ledger_toid) as open_ledger_toid",
+		"(first(ARRAY[open_n, open_d]))[1] as open_n",
+		"(first(ARRAY[open_n, open_d]))[2] as open_d",
+		"last(close_ledger_toid) as close_ledger_toid",
+		"(last(ARRAY[close_n, close_d]))[1] as close_n",
+		"(last(ARRAY[close_n, close_d]))[2] as close_d",
+	).FromSelect(buckets, "buckets").GroupBy("base_asset_id", "counter_asset_id", "bucket")
+
+	_, err = q.Exec(ctx, sq.Insert(rollup.name).Select(rolledUp))
+	if err != nil {
+		return errors.Wrapf(err, "could not rebuild %s trade aggregation bucket", rollup.name)
+	}
 	return nil
 }
 
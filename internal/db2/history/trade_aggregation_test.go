@@ -0,0 +1,132 @@
+package history
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	strtime "github.com/stellar/go-stellar-sdk/support/time"
+	"github.com/stellar/go-stellar-sdk/toid"
+	"github.com/stellar/go-stellar-sdk/xdr"
+
+	"github.com/stellar/stellar-horizon/internal/db2"
+	"github.com/stellar/stellar-horizon/internal/test"
+)
+
+func TestSelectTradeAggregationTable(t *testing.T) {
+	const (
+		minute = int64(time.Minute / time.Millisecond)
+		hour   = int64(time.Hour / time.Millisecond)
+		day    = 24 * hour
+	)
+
+	for _, testCase := range []struct {
+		resolution int64
+		offset     int64
+		expected   string
+	}{
+		{minute, 0, HistoryTradesTableName},
+		{15 * minute, 0, HistoryTradesTableName},
+		{hour, 0, HistoryTradesHourTableName},
+		{hour, hour, HistoryTradesHourTableName},
+		{day, 0, HistoryTradesDayTableName},
+		{day, 5 * hour, HistoryTradesHourTableName},
+		{7 * day, 0, HistoryTradesDayTableName},
+		{7 * day, 23 * hour, HistoryTradesHourTableName},
+	} {
+		t.Run(fmt.Sprintf("%d-%d", testCase.resolution, testCase.offset), func(t *testing.T) {
+			assert.Equal(t, testCase.expected, selectTradeAggregationTable(testCase.resolution, testCase.offset).name)
+		})
+	}
+}
+
+func TestTradeAggregationRollupsMatchMinuteBuckets(t *testing.T) {
+	tt := test.Start(t)
+	defer tt.Finish()
+	test.ResetHorizonDB(t, tt.HorizonDB)
+	q := &Q{tt.HorizonSession()}
+
+	usdc := xdr.MustNewCreditAsset("USDC", "GA5ZSEJYB37JRC5AVCIA5MOP4RHTM335X2KGX3IHOJAPP5RE34K4KZVN")
+	assets, err := q.CreateAssets(tt.Ctx, []xdr.Asset{xdr.MustNewNativeAsset(), usdc}, 2)
+	tt.Assert.NoError(err)
+	baseAssetID := assets[xdr.MustNewNativeAsset().String()].ID
+	counterAssetID := assets[usdc.String()].ID
+	if baseAssetID > counterAssetID {
+		baseAssetID, counterAssetID = counterAssetID, baseAssetID
+	}
+
+	// a trade every 5 hours and 17 minutes over about 3 weeks, with a
+	// second trade in the same ledger every third trade
+	start := time.Date(2023, 3, 1, 0, 0, 0, 0, time.UTC)
+	var trades []InsertTrade
+	for i := 0; i < 100; i++ {
+		trade := InsertTrade{
+			HistoryOperationID: toid.New(int32(100+i), 1, 1).ToInt64(),
+			Order:              1,
+			LedgerCloseTime:    start.Add(time.Duration(i) * (5*time.Hour + 17*time.Minute)),
+			BaseAssetID:        baseAssetID,
+			CounterAssetID:     counterAssetID,
+			BaseIsSeller:       true,
+			BaseAmount:         int64(100 + i%7*13),
+			CounterAmount:      int64(200 + i%11*29),
+			PriceN:             int64(200 + i%11*29),
+			PriceD:             int64(100 + i%7*13),
+			Type:               OrderbookTradeType,
+		}
+		trades = append(trades, trade)
+		if i%3 == 0 {
+			trade.Order = 2
+			trade.BaseAmount, trade.CounterAmount = 50, 400
+			trade.PriceN, trade.PriceD = 8, 1
+			trades = append(trades, trade)
+		}
+	}
+
+	// ingest the trades in two batches to rebuild partially filled buckets
+	for _, batch := range [][]InsertTrade{trades[:70], trades[70:]} {
+		tt.Assert.NoError(q.Begin(tt.Ctx))
+		builder := q.NewTradeBatchInsertBuilder()
+		for _, trade := range batch {
+			tt.Assert.NoError(builder.Add(trade))
+		}
+		tt.Assert.NoError(builder.Exec(tt.Ctx, q))
+		tt.Assert.NoError(q.RebuildTradeAggregationTimes(
+			tt.Ctx,
+			strtime.MillisFromSeconds(batch[0].LedgerCloseTime.Unix()),
+			strtime.MillisFromSeconds(batch[len(batch)-1].LedgerCloseTime.Unix()),
+			1000,
+		))
+		tt.Assert.NoError(q.Commit())
+	}
+
+	const hour = int64(time.Hour / time.Millisecond)
+	for _, resolution := range []int64{hour, 24 * hour, 7 * 24 * hour} {
+		for _, offset := range []int64{0, hour, 5 * hour} {
+			if offset > resolution {
+				continue
+			}
+			for _, order := range []string{db2.OrderAscending, db2.OrderDescending} {
+				for _, pair := range [][2]int64{{baseAssetID, counterAssetID}, {counterAssetID, baseAssetID}} {
+					page := db2.PageQuery{Order: order, Limit: 200}
+
+					rollupQ, err := q.GetTradeAggregationsQ(pair[0], pair[1], resolution, offset, page)
+					tt.Assert.NoError(err)
+					tt.Assert.NotEqual(HistoryTradesTableName, rollupQ.table.name)
+					var rolledUp []TradeAggregation
+					tt.Assert.NoError(q.Select(tt.Ctx, &rolledUp, rollupQ.GetSql()))
+
+					rawQ, err := q.GetTradeAggregationsQ(pair[0], pair[1], resolution, offset, page)
+					tt.Assert.NoError(err)
+					rawQ.table = tradeAggregationTables[0]
+					var raw []TradeAggregation
+					tt.Assert.NoError(q.Select(tt.Ctx, &raw, rawQ.GetSql()))
+
+					tt.Assert.NotEmpty(raw)
+					tt.Assert.Equal(raw, rolledUp, "resolution %d offset %d order %s", resolution, offset, order)
+				}
+			}
+		}
+	}
+}
@@ -50,6 +50,21 @@ func (q *Q) AllTransactionsByHashesSinceLedger(ctx context.Context, hashes []str
 	return dest, nil
 }
 
+// TransactionHashesInLedgerRange returns the hashes of the transactions stored
+// in the `history_transactions` table in the given (inclusive) ledger range.
+func (q *Q) TransactionHashesInLedgerRange(ctx context.Context, fromLedger, toLedger uint32) ([]string, error) {
+	var hashes []string
+	sql := sq.Select("ht.transaction_hash").
+		From("history_transactions ht").
+		Where(sq.GtOrEq{"ht.ledger_sequence": fromLedger}).
+		Where(sq.LtOrEq{"ht.ledger_sequence": toLedger})
+
+	if err := q.Select(ctx, &hashes, sql); err != nil {
+		return nil, err
+	}
+	return hashes, nil
+}
+
 // TransactionsByIDs fetches transactions from the `history_transactions` table
 // which match the given ids
 func (q *Q) TransactionsByIDs(ctx context.Context, ids ...int64) (map[int64]Transaction, error) {
@@ -147,6 +162,22 @@ func (q *TransactionsQ) ForLiquidityPool(ctx context.Context, poolID string) *Tr
 	return q
 }
 
+// ForContract filters the transactions collection to a specific contract
+func (q *TransactionsQ) ForContract(ctx context.Context, contractID string) *TransactionsQ {
+	var hContract HistoryContract
+	hContract, q.Err = q.parent.ContractByID(ctx, contractID)
+	if q.Err != nil {
+		return q
+	}
+
+	q.sql = q.sql.
+		Join("history_transaction_contracts htc ON htc.history_transaction_id = ht.id").
+		Where("htc.history_contract_id = ?", hContract.InternalID)
+	q.txIdCol = "htc.history_transaction_id"
+
+	return q
+}
+
 // ForLedger filters the query to a only transactions in a specific ledger,
 // specified by its sequence.
 func (q *TransactionsQ) ForLedger(ctx context.Context, seq int32) *TransactionsQ {
@@ -243,6 +274,7 @@ func (q *TransactionsQ) Select(ctx context.Context, dest interface{}) error {
 type QTransactions interface {
 	NewTransactionBatchInsertBuilder() TransactionBatchInsertBuilder
 	NewTransactionFilteredTmpBatchInsertBuilder() TransactionBatchInsertBuilder
+	TransactionHashesInLedgerRange(ctx context.Context, fromLedger, toLedger uint32) ([]string, error)
 }
 
 func selectTransaction(table string) sq.SelectBuilder {
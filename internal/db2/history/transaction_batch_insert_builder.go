@@ -194,8 +194,8 @@ func transactionToRow(transaction ingest.LedgerTransaction, sequence uint32, enc
 		TxResult:                    resultBase64,
 		TxMeta:                      metaBase64,
 		TxFeeMeta:                   feeMetaBase64,
-		TimeBounds:                  formatTimeBounds(transaction.Envelope.TimeBounds()),
-		LedgerBounds:                formatLedgerBounds(transaction.Envelope.LedgerBounds()),
+		TimeBounds:                  FormatTimeBounds(transaction.Envelope.TimeBounds()),
+		LedgerBounds:                FormatLedgerBounds(transaction.Envelope.LedgerBounds()),
 		MinAccountSequence:          formatMinSequenceNumber(transaction.Envelope.MinSeqNum()),
 		MinAccountSequenceAge:       formatDuration(transaction.Envelope.MinSeqAge()),
 		MinAccountSequenceLedgerGap: formatUint32(transaction.Envelope.MinSeqLedgerGap()),
@@ -72,7 +72,8 @@ func (t LedgerBounds) Value() (driver.Value, error) {
 	return fmt.Sprintf("[%d, %d)", t.MinLedger.Int64, t.MaxLedger.Int64), nil
 }
 
-func formatLedgerBounds(ledgerBounds *xdr.LedgerBounds) LedgerBounds {
+// FormatLedgerBounds returns the ledger bounds of a transaction envelope.
+func FormatLedgerBounds(ledgerBounds *xdr.LedgerBounds) LedgerBounds {
 	if ledgerBounds == nil {
 		return LedgerBounds{Null: true}
 	}
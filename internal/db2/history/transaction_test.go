@@ -35,6 +35,30 @@ func TestTransactionQueries(t *testing.T) {
 	tt.Assert.Equal(err, sql.ErrNoRows)
 }
 
+func TestTransactionHashesInLedgerRange(t *testing.T) {
+	tt := test.Start(t)
+	test.ResetHorizonDB(t, tt.HorizonDB)
+	tt.Scenario("base")
+	defer tt.Finish()
+	q := &Q{tt.HorizonSession()}
+
+	hashes, err := q.TransactionHashesInLedgerRange(tt.Ctx, 2, 2)
+	tt.Assert.NoError(err)
+	tt.Assert.ElementsMatch([]string{
+		"2374e99349b9ef7dba9a5db3339b78fda8f34777b1af33ba468ad5c0df946d4d",
+		"164a5064eba64f2cdbadb856bf3448485fc626247ada3ed39cddf0f6902133b6",
+		"2b2e82dbabb024b27a0c3140ca71d8ac9bc71831f9f5a3bd69eca3d88fb0ec5c",
+	}, hashes)
+
+	hashes, err = q.TransactionHashesInLedgerRange(tt.Ctx, 3, 3)
+	tt.Assert.NoError(err)
+	tt.Assert.Equal([]string{"cebb875a00ff6e1383aef0fd251a76f22c1f9ab2a2dffcb077855736ade2659a"}, hashes)
+
+	hashes, err = q.TransactionHashesInLedgerRange(tt.Ctx, 100, 200)
+	tt.Assert.NoError(err)
+	tt.Assert.Empty(hashes)
+}
+
 func TestTransactionByLiquidityPool(t *testing.T) {
 	tt := test.Start(t)
 	defer tt.Finish()
@@ -94,6 +118,65 @@ func TestTransactionByLiquidityPool(t *testing.T) {
 
 }
 
+func TestTransactionByContract(t *testing.T) {
+	tt := test.Start(t)
+	defer tt.Finish()
+	test.ResetHorizonDB(t, tt.HorizonDB)
+	q := &Q{tt.HorizonSession()}
+
+	txIndex := int32(1)
+	sequence := int32(56)
+	txID := toid.New(sequence, int32(1), 0).ToInt64()
+
+	// Insert a phony ledger
+	ledgerCloseTime := time.Now().Unix()
+	ledgerBatch := q.NewLedgerBatchInsertBuilder()
+	err := ledgerBatch.Add(xdr.LedgerHeaderHistoryEntry{
+		Header: xdr.LedgerHeader{
+			LedgerSeq: xdr.Uint32(sequence),
+			ScpValue: xdr.StellarValue{
+				CloseTime: xdr.TimePoint(ledgerCloseTime),
+			},
+		},
+	}, 0, 0, 0, 0, 0)
+	tt.Assert.NoError(err)
+
+	tt.Assert.NoError(q.Begin(tt.Ctx))
+
+	tt.Assert.NoError(ledgerBatch.Exec(tt.Ctx, q.SessionInterface))
+
+	// Insert a phony transaction
+	transactionBuilder := q.NewTransactionBatchInsertBuilder()
+	firstTransaction := buildLedgerTransaction(tt.T, testTransaction{
+		index:         uint32(txIndex),
+		envelopeXDR:   "AAAAACiSTRmpH6bHC6Ekna5e82oiGY5vKDEEUgkq9CB//t+rAAAAyAEXUhsAADDRAAAAAAAAAAAAAAABAAAAAAAAAAsBF1IbAABX4QAAAAAAAAAA",
+		resultXDR:     "AAAAAAAAASwAAAAAAAAAAwAAAAAAAAAAAAAAAAAAAAAAAAABAAAAAAAAAAAAAAAFAAAAAAAAAAA=",
+		feeChangesXDR: "AAAAAA==",
+		metaXDR:       "AAAAAQAAAAAAAAAA",
+		hash:          "19aaa18db88605aedec04659fb45e06f240b022eb2d429e05133e4d53cd945ba",
+	})
+	err = transactionBuilder.Add(firstTransaction, uint32(sequence))
+	tt.Assert.NoError(err)
+	err = transactionBuilder.Exec(tt.Ctx, q)
+	tt.Assert.NoError(err)
+
+	// Insert contract history
+	contractID := "CA3D5KRYM6CB7OWQ6TWYRR3Z4T7GNZLKERYNZGGA5SOAOPIFY6YQGAXE"
+	contractLoader := NewContractLoader(ConcurrentInserts)
+	contractTransactionBuilder := q.NewTransactionContractBatchInsertBuilder()
+	tt.Assert.NoError(contractTransactionBuilder.Add(txID, contractLoader.GetFuture(contractID)))
+	tt.Assert.NoError(contractLoader.Exec(tt.Ctx, q))
+	tt.Assert.NoError(contractTransactionBuilder.Exec(tt.Ctx, q))
+	tt.Assert.NoError(q.Commit())
+
+	var records []Transaction
+	tt.Assert.NoError(
+		q.Transactions().ForContract(tt.Ctx, contractID).Select(tt.Ctx, &records),
+	)
+	tt.Assert.Len(records, 1)
+
+}
+
 // TestTransactionSuccessfulOnly tests if default query returns successful
 // transactions only.
 // If it's not enclosed in brackets, it may return incorrect result when mixed
@@ -73,7 +73,8 @@ func (t TimeBounds) Value() (driver.Value, error) {
 	return fmt.Sprintf("[%d, %d)", t.Lower.Int64, t.Upper.Int64), nil
 }
 
-func formatTimeBounds(timeBounds *xdr.TimeBounds) TimeBounds {
+// FormatTimeBounds returns the time bounds of a transaction envelope.
+func FormatTimeBounds(timeBounds *xdr.TimeBounds) TimeBounds {
 	if timeBounds == nil {
 		return TimeBounds{Null: true}
 	}
@@ -0,0 +1,63 @@
+package history
+
+import (
+	"context"
+	"time"
+
+	sq "github.com/Masterminds/squirrel"
+
+	"github.com/stellar/go-stellar-sdk/support/errors"
+)
+
+// TxSubFinishedChannel is the postgres notification channel on which the hash
+// of a pending submission is published when its result has been found.
+const TxSubFinishedChannel = "horizon_txsub_finished"
+
+// AddPendingSubmission records a transaction submitted to Stellar Core whose
+// result is awaited. It returns false when the transaction is already pending,
+// unless it was submitted before expiredBefore, in which case the submission
+// is recorded again and true is returned. The instance for which true is
+// returned owns the submission.
+func (q *Q) AddPendingSubmission(ctx context.Context, hash string, submittedAt, expiredBefore time.Time) (bool, error) {
+	sql := sq.Insert("txsub_pending").
+		Columns("transaction_hash", "submitted_at").
+		Values(hash, submittedAt.UTC()).
+		Suffix(
+			"ON CONFLICT (transaction_hash) DO UPDATE SET submitted_at = EXCLUDED.submitted_at "+
+				"WHERE txsub_pending.submitted_at < ?",
+			expiredBefore.UTC(),
+		)
+
+	result, err := q.Exec(ctx, sql)
+	if err != nil {
+		return false, errors.Wrap(err, "could not insert pending submission")
+	}
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+	return rows > 0, nil
+}
+
+// FinishPendingSubmission removes a pending submission and notifies the
+// instances listening on TxSubFinishedChannel that its result has been found.
+// Only the first instance finishing the submission notifies it.
+func (q *Q) FinishPendingSubmission(ctx context.Context, hash string) error {
+	_, err := q.ExecRaw(ctx,
+		`WITH finished AS (
+			DELETE FROM txsub_pending WHERE transaction_hash = ? RETURNING transaction_hash
+		) SELECT pg_notify(?, transaction_hash) FROM finished`,
+		hash, TxSubFinishedChannel,
+	)
+	return err
+}
+
+// DeletePendingSubmissionsBefore removes the pending submissions submitted
+// before the given time and returns the number of rows removed.
+func (q *Q) DeletePendingSubmissionsBefore(ctx context.Context, before time.Time) (int64, error) {
+	result, err := q.Exec(ctx, sq.Delete("txsub_pending").Where("submitted_at < ?", before.UTC()))
+	if err != nil {
+		return 0, errors.Wrap(err, "could not delete pending submissions")
+	}
+	return result.RowsAffected()
+}
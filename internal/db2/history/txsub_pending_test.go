@@ -0,0 +1,58 @@
+package history
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/stellar/stellar-horizon/internal/test"
+)
+
+func TestPendingSubmissions(t *testing.T) {
+	tt := test.Start(t)
+	defer tt.Finish()
+	test.ResetHorizonDB(t, tt.HorizonDB)
+	q := &Q{tt.HorizonSession()}
+
+	hash := "2374e99349b9ef7dba9a5db3339b78fda8f34777b1af33ba468ad5c0df946d4d"
+	other := "cebb875a00ff6e1383aef0fd251a76f22c1f9ab2a2dffcb077855736ade2659a"
+	now := time.Now()
+	expiredBefore := now.Add(-time.Minute)
+
+	added, err := q.AddPendingSubmission(tt.Ctx, hash, now, expiredBefore)
+	require.NoError(t, err)
+	assert.True(t, added)
+	// the submission is owned by the first instance recording it
+	added, err = q.AddPendingSubmission(tt.Ctx, hash, now, expiredBefore)
+	require.NoError(t, err)
+	assert.False(t, added)
+	added, err = q.AddPendingSubmission(tt.Ctx, other, now.Add(-time.Hour), expiredBefore)
+	require.NoError(t, err)
+	assert.True(t, added)
+	// an expired submission is recorded again
+	added, err = q.AddPendingSubmission(tt.Ctx, other, now, expiredBefore)
+	require.NoError(t, err)
+	assert.True(t, added)
+	added, err = q.AddPendingSubmission(tt.Ctx, other, now, expiredBefore)
+	require.NoError(t, err)
+	assert.False(t, added)
+
+	deleted, err := q.DeletePendingSubmissionsBefore(tt.Ctx, expiredBefore)
+	require.NoError(t, err)
+	assert.Equal(t, int64(0), deleted)
+	deleted, err = q.DeletePendingSubmissionsBefore(tt.Ctx, now.Add(time.Second))
+	require.NoError(t, err)
+	assert.Equal(t, int64(2), deleted)
+
+	added, err = q.AddPendingSubmission(tt.Ctx, hash, now, expiredBefore)
+	require.NoError(t, err)
+	assert.True(t, added)
+	require.NoError(t, q.FinishPendingSubmission(tt.Ctx, hash))
+	// finishing a submission twice is a no-op
+	require.NoError(t, q.FinishPendingSubmission(tt.Ctx, hash))
+	added, err = q.AddPendingSubmission(tt.Ctx, hash, now, expiredBefore)
+	require.NoError(t, err)
+	assert.True(t, added)
+}
@@ -68,7 +68,21 @@
 // migrations/69_add_asset_contracts_table.sql (671B)
 // migrations/6_create_assets_table.sql (366B)
 // migrations/70_replace_timestamp_trade_aggregations_brin_index.sql (317B)
+// migrations/71_contract_events.sql (723B)
+// migrations/72_contract_data.sql (548B)
+// migrations/73_contract_participants.sql (1.793kB)
+// migrations/74_contract_asset_balance_holders.sql (657B)
+// migrations/75_contract_operation_type_filter_rules.sql (672B)
+// migrations/76_filter_rules_blacklist.sql (317B)
+// migrations/77_subscriptions.sql (1.345kB)
+// migrations/78_trade_aggregation_rollups.sql (3.491kB)
+// migrations/79_liquidity_pool_snapshots.sql (952B)
 // migrations/7_modify_trades_table.sql (2.303kB)
+// migrations/80_history_asset_stats.sql (826B)
+// migrations/81_trust_lines_by_asset_balance.sql (296B)
+// migrations/82_history_account_state_changes.sql (784B)
+// migrations/83_history_balance_changes.sql (1.068kB)
+// migrations/84_txsub_pending.sql (448B)
 // migrations/8_add_aggregators.sql (907B)
 // migrations/8_create_asset_stats_table.sql (441B)
 // migrations/9_add_header_xdr.sql (161B)
@@ -1500,6 +1514,186 @@ func migrations70_replace_timestamp_trade_aggregations_brin_indexSql() (*asset,
 	return a, nil
 }
 
+var _migrations71_contract_eventsSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x8c\x92\x51\x4f\xc2\x30\x14\x85\xdf\xf7\x2b\x4e\xf6\x04\x71\x7b\x33\xbe\xf0\x84\xb2\x18\x22\x0e\x32\xc0\x48\x8c\x59\xba\xf6\x0a\x8d\xd0\x2e\xed\x8d\xba\x7f\x6f\xa8\x32\x51\x11\x7c\xbe\xe7\x9c\xaf\xbd\xe7\xa6\x29\xce\x36\x7a\xe9\x04\x13\xe6\x75\x14\x5d\x15\x59\x7f\x96\x61\xd6\xbf\x1c\x65\x58\x69\xcf\xd6\x35\xa5\xb4\x86\x9d\x90\x5c\xd2\x0b\x19\xf6\xe8\x44\x00\xda\xa9\xad\xc9\x09\xd6\xd6\x94\x5a\xa1\xd2\x4b\x6d\x18\xf9\x78\x86\x7c\x3e\x1a\x25\x41\x19\x5b\xa7\xc8\xc5\xd0\x86\x69\x49\xee\xc7\x74\x97\xc3\x4e\x18\x2f\xe4\xd1\xa4\xf6\x25\x5a\x81\xe9\x8d\x13\xa4\x29\x3c\xbb\x67\x6a\x40\x46\x5a\x45\xaa\xd5\x40\x28\xe5\xc8\xfb\x24\xf8\xf1\x64\x1d\x7c\xe3\x99\x36\xf8\xf8\x46\x08\xe4\xa6\x26\xf8\x8d\x58\xaf\x7f\xc3\xd8\xd6\x5a\xfa\xc0\x79\x78\xfc\x9a\x6d\x91\x95\xf0\x74\x71\xde\x22\xa7\xf2\x4e\xac\x3f\xf5\xc1\xaa\x04\x8b\x60\x3c\x6d\x0b\xfa\x49\x31\xbc\xed\x17\x0b\xdc\x64\x0b\x74\x0e\x2d\x36\xd9\x2d\xb1\x1b\x75\x7b\x6d\x4d\xc3\x7c\x90\xdd\x23\x5e\x49\x2a\xab\xbd\x9a\xb4\x8a\x31\xce\xff\xac\x6f\x3e\x1d\xe6\xd7\xa8\xd8\x11\xa1\xb3\x67\x4a\x70\x9c\xdc\x3b\x8c\xfd\xde\xdb\xff\xc9\x87\x7b\xdf\xfe\x6e\xff\x28\x07\xf6\xd5\x44\xd1\xa0\x18\x4f\x4e\x1c\xa5\x14\x5e\x0a\x45\xbd\xe8\x7d\x00\x32\x42\xdd\xfd\xd3\x02\x00\x00")
+
+func migrations71_contract_eventsSqlBytes() ([]byte, error) {
+	return bindataRead(
+		_migrations71_contract_eventsSql,
+		"migrations/71_contract_events.sql",
+	)
+}
+
+func migrations71_contract_eventsSql() (*asset, error) {
+	bytes, err := migrations71_contract_eventsSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "migrations/71_contract_events.sql", size: 0, mode: os.FileMode(0), modTime: time.Unix(0, 0)}
+	a := &asset{bytes: bytes, info: info, digest: [32]uint8{0x5a, 0x54, 0x4b, 0x50, 0x4c, 0xf, 0x76, 0xa4, 0x29, 0x53, 0x54, 0xa1, 0x66, 0x85, 0x76, 0xd7, 0x3e, 0x49, 0xca, 0x62, 0xc7, 0x25, 0x36, 0xfa, 0x6b, 0xb6, 0xc9, 0x5b, 0xc1, 0xed, 0x6a, 0x6}}
+	return a, nil
+}
+
+var _migrations72_contract_dataSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\xa4\x91\x41\x4f\xfa\x40\x14\xc4\xef\xfb\x29\x26\x9c\xf8\xe7\x4f\x13\x0f\xc6\x0b\xa7\x62\x57\x43\xac\x85\x94\xd6\xc8\xa9\x79\xec\x3e\x61\x43\x69\xc9\xee\x46\xe1\xdb\x9b\x06\x59\x2b\x78\x73\x4e\xef\x30\xf3\x7b\xc9\x4c\x14\xe1\xff\xce\xac\x2d\x79\x46\xb9\x17\xe2\x3e\x97\x71\x21\x51\xc4\x93\x54\x42\xb5\x8d\xb7\xa4\x7c\xa5\xc9\x13\x86\x02\x9d\xb6\x7c\xac\x36\xe4\x36\xe8\x6b\xb2\x2c\x64\x8c\x79\x3e\x7d\x8e\xf3\x25\x9e\xe4\x72\x74\x32\x07\x82\xd1\xdf\x66\xcf\x07\x8f\x6c\x56\x20\x2b\xd3\x74\x84\x28\x82\xf3\x76\xcb\x47\x70\xa3\x5a\xcd\x3a\xa4\x40\x5a\x5b\x76\x2e\x3c\xc6\x95\xae\x59\x2b\x72\x7c\x77\x1b\x58\x0b\xf5\x42\xf5\x09\xf0\x4e\xf5\xdf\x00\x7c\xd8\x1b\x4b\xde\xb4\x4d\x55\xb3\x5e\xb3\x05\x00\xd3\x78\xee\xce\x33\x03\x89\x7c\x88\xcb\xb4\xc0\xcd\x57\x07\x35\x39\x5f\xed\x5a\x6d\xde\x0c\xeb\x73\xf0\x32\x25\xfe\x8d\x43\xf9\xd3\x2c\x91\xaf\x18\xfc\x68\xbf\x5a\x1d\xab\x5e\x99\x03\xcc\xb2\x8b\x79\xca\xc5\x34\x7b\xc4\xca\x5b\x66\x0c\x7b\xd6\x51\x58\xac\x7b\xd1\xdf\x3b\x69\x3f\x1a\x21\x92\x7c\x36\xff\x75\x6f\x45\x4e\x91\xe6\xb1\xf8\x1c\x00\xb3\x16\x84\x82\x24\x02\x00\x00")
+
+func migrations72_contract_dataSqlBytes() ([]byte, error) {
+	return bindataRead(
+		_migrations72_contract_dataSql,
+		"migrations/72_contract_data.sql",
+	)
+}
+
+func migrations72_contract_dataSql() (*asset, error) {
+	bytes, err := migrations72_contract_dataSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "migrations/72_contract_data.sql", size: 0, mode: os.FileMode(0), modTime: time.Unix(0, 0)}
+	a := &asset{bytes: bytes, info: info, digest: [32]uint8{0x88, 0x52, 0xbb, 0xe1, 0x2f, 0x74, 0x41, 0x35, 0x7f, 0x33, 0x7f, 0x86, 0x6b, 0x88, 0xbb, 0x54, 0xf2, 0x18, 0x7c, 0x5e, 0x5c, 0xe1, 0x6e, 0x8f, 0xcf, 0x33, 0xe4, 0xf6, 0xe1, 0x6c, 0x10, 0x95}}
+	return a, nil
+}
+
+var _migrations73_contract_participantsSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\xb4\x95\x4f\xd3\xd2\x30\x10\xc6\xef\xfd\x14\x7b\x7b\xe9\x48\x0f\x5c\xe9\xa9\xb4\x51\x3a\x53\x52\x29\x89\xe2\xa9\x13\x9a\x0c\x66\xc4\x14\x93\x8c\xc2\xb7\x77\x44\x85\xd2\xa6\xfc\xa9\xbe\xc7\x64\x9e\xec\xf3\xdb\xdd\x67\x26\x41\x00\x6f\xbe\xca\xad\x66\x56\x00\xdd\x7b\x5e\x5c\xa0\x88\x20\x58\xa1\x25\x45\x38\x46\xf0\x59\x1a\x5b\xeb\x63\x59\xd5\xca\x6a\x56\x59\x53\x4a\x5e\x1a\xf1\xcd\x03\x00\x58\x91\xa8\x20\xf0\x31\x25\x73\x98\x9c\x2e\x52\x1c\x17\x68\x81\x30\x81\xd9\xa7\x3f\x57\x38\x87\x45\x8a\x3f\x44\x19\x45\xe7\x73\xb4\xbe\x9c\xe3\x28\x9e\x23\x98\x84\x67\x6b\x12\xcd\x32\x87\x2f\x8c\x4e\x72\xc9\x61\x23\xb7\x52\x59\xc0\x39\x01\x4c\xb3\x0c\x12\xf4\x36\xa2\x19\x01\x25\x0e\xf6\x3b\xdb\x8d\x5e\xfa\x98\x5f\xa6\x53\x2d\xb6\xd5\x8e\x19\xe3\x8f\x4f\xd5\xfe\x2a\x4a\xc9\xc1\x8a\x43\xa3\x68\x10\x80\xb1\xfa\x8b\x38\x82\x50\x55\xcd\x05\x3f\x6b\x81\x71\xae\x85\x31\x9e\x7f\x61\xa6\x38\x5d\x52\x04\x29\x4e\xd0\x1a\xa4\xe2\xe2\x50\x76\x21\x6a\xf5\xcb\x26\xc7\x8e\xde\xe8\x2a\xc5\xef\x60\x63\xb5\x10\x30\x92\xdc\x0f\x9f\x2d\xdc\x6c\xe4\xbe\x43\x43\xed\xf7\x0d\xbe\xde\x0b\xcd\xac\xac\x55\x67\x05\x5d\x45\x77\x29\xe3\x2b\x65\x93\xae\x25\x7c\x70\x8a\x0e\x9a\xdf\xf3\x34\xcd\x76\x5d\xcc\x57\x8d\x3b\xd1\xc7\x2e\xce\xcb\x0a\x9e\xc0\xb9\x2a\xfb\xaf\x5c\x83\x08\x06\xfa\x3e\x12\x08\xab\x99\x32\xac\xba\x19\x89\xa6\xe6\xf5\x43\xe1\x24\x72\xc4\xc2\x4d\xee\x1c\x44\xab\x81\x01\xd1\xe8\x85\x6a\x95\xfe\x1f\x7c\x03\x49\x06\xbb\xb7\x62\xd2\xfc\x3b\x92\xfa\x87\xf2\xbc\xa4\xc8\xdf\x3f\x14\x9b\x8a\x99\x8a\x71\x11\x3a\x9f\xb8\x62\x7b\xf3\x41\xaf\xec\xee\x37\x16\x7a\x3f\x07\x00\xed\x75\x67\x90\x01\x07\x00\x00")
+
+func migrations73_contract_participantsSqlBytes() ([]byte, error) {
+	return bindataRead(
+		_migrations73_contract_participantsSql,
+		"migrations/73_contract_participants.sql",
+	)
+}
+
+func migrations73_contract_participantsSql() (*asset, error) {
+	bytes, err := migrations73_contract_participantsSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "migrations/73_contract_participants.sql", size: 0, mode: os.FileMode(0), modTime: time.Unix(0, 0)}
+	a := &asset{bytes: bytes, info: info, digest: [32]uint8{0xa9, 0x9e, 0x9b, 0xbe, 0xd9, 0x7f, 0x10, 0xbd, 0x77, 0x2f, 0x30, 0xae, 0x50, 0xf2, 0x53, 0xc4, 0x1d, 0x43, 0xbb, 0xa5, 0xb7, 0x4f, 0xb8, 0x8f, 0x0, 0xa5, 0xd9, 0x35, 0x9a, 0x35, 0x55, 0x5e}}
+	return a, nil
+}
+
+var _migrations74_contract_asset_balance_holdersSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x94\x91\xc1\x6a\xab\x40\x14\x86\xf7\xf3\x14\x3f\x59\xdd\xcb\xd5\xfb\x02\xae\x4c\x94\x12\xb0\x5a\xac\x42\xbb\x92\xd1\x39\x89\x43\x27\x8e\xcc\x9c\x60\xf3\xf6\xc5\x84\x86\xd2\xd4\xd2\xae\x06\x0e\xff\x7f\xe6\xe3\x3b\x61\x88\x7f\x07\xbd\x77\x92\x09\xf5\x28\x44\x18\xa2\xb3\x03\x3b\xd9\x71\x23\xbd\x27\x6e\x5a\x69\xe4\xd0\x91\x87\xf6\x70\x34\xda\xf1\x68\x24\x93\x42\x7b\x02\xf7\x04\xcf\x73\xd5\x51\x7b\xd4\x46\x61\xea\x75\xd7\x63\x67\x8d\xb1\x93\x9f\x97\x71\xaf\x3d\x2e\x1f\x68\x3b\x04\xf0\x16\xf4\xaa\x3d\xeb\x61\x0f\x67\x27\x8f\x4e\x0e\x68\x09\xca\xd9\x71\x24\x05\x27\xb9\x27\x07\xee\xe7\xb1\xec\x5e\x76\xda\x18\x52\xff\x45\x55\xd6\xf9\x26\xae\x52\x54\xf1\x3a\x4b\x97\x18\x23\x21\xe2\xac\x4a\xcb\xef\x53\x02\x00\xe2\x24\xc1\xa6\xc8\xea\xfb\x1c\xbd\x35\x8a\x5c\x73\x4d\x6b\x85\xf5\x73\x95\xc6\xc8\x8b\x0a\x79\x9d\x65\xc1\xe7\x86\x91\x9e\x9b\x83\x55\x7a\xa7\x49\x35\x86\xd4\x9e\x1c\xf4\xc0\x34\xbf\xef\xad\x48\x88\x4d\x99\xce\xcc\xdb\x3c\x49\x9f\xb0\x5a\xc0\x69\xda\x53\x73\x41\x58\xa1\xc8\x17\xf5\xd7\x8f\xdb\xfc\x0e\x2d\x3b\x22\xfc\xb9\x45\x0e\x70\xc9\x7f\x18\xfd\x8d\xce\xf7\xbc\xde\x37\xb1\xd3\x20\x44\x52\x16\x0f\x3f\x27\xfa\x85\xd1\xf3\xe2\x45\xa5\xc1\x4d\xe6\x2b\x89\x91\x78\x1b\x00\x0c\x3b\x80\x15\x91\x02\x00\x00")
+
+func migrations74_contract_asset_balance_holdersSqlBytes() ([]byte, error) {
+	return bindataRead(
+		_migrations74_contract_asset_balance_holdersSql,
+		"migrations/74_contract_asset_balance_holders.sql",
+	)
+}
+
+func migrations74_contract_asset_balance_holdersSql() (*asset, error) {
+	bytes, err := migrations74_contract_asset_balance_holdersSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "migrations/74_contract_asset_balance_holders.sql", size: 0, mode: os.FileMode(0), modTime: time.Unix(0, 0)}
+	a := &asset{bytes: bytes, info: info, digest: [32]uint8{0xcc, 0x98, 0x20, 0x25, 0x8c, 0x46, 0xc4, 0xe9, 0x53, 0xae, 0xa4, 0x7a, 0x54, 0xeb, 0x67, 0x6, 0x49, 0xb3, 0x0, 0x6a, 0x31, 0xa1, 0xfe, 0xa, 0x17, 0xb8, 0x11, 0x88, 0x3e, 0xf6, 0x2a, 0x38}}
+	return a, nil
+}
+
+var _migrations75_contract_operation_type_filter_rulesSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\xb4\x92\xcd\x4a\xc3\x40\x14\x85\xf7\xf3\x14\x67\xd7\x16\x1b\x70\xdf\x55\xb5\x59\x14\x42\x2a\x6d\xea\x46\x24\x4c\x93\x9b\xe6\xc2\xfc\x84\x99\x5b\x8b\x88\xef\x2e\x6d\x20\x56\xd1\xae\x74\xff\xcd\xe1\x3b\x73\x4f\x92\xe0\xc6\xf2\x3e\x68\x21\x6c\x3b\xa5\xee\xd7\xe9\xbc\x48\x51\xcc\xef\xb2\x14\x95\x77\x12\x74\x25\x65\xc3\x46\x28\x94\xe1\x60\x28\x62\xac\x00\x80\x9c\xde\x19\xaa\xb1\xf3\xde\x20\x5f\x15\xc8\xb7\x59\x86\x9a\x1a\x7d\x30\x82\x46\x9b\x48\xd3\x33\x78\x6c\x59\xc8\x70\x14\xbc\xe8\x50\xb5\x3a\x3c\x3d\x0f\x7c\x4f\x18\x1d\xa5\xb4\xbe\xe6\x86\x4f\x81\xbc\x67\x27\x03\xa2\x26\xb3\x6f\x56\xbe\xa3\xa0\x85\xbd\x2b\xe5\xb5\xa3\x7f\x76\xb3\x64\x7d\xf9\x17\x15\x92\x04\xec\x22\x05\x81\xb4\x34\xb8\xd4\x1c\x7b\xd5\x28\xa7\x0b\x34\x3e\x80\x74\xd5\xc2\xd1\x11\x7d\x31\xb0\xed\x0c\x59\x72\x72\xee\xac\x96\xf9\x26\x5d\x17\x58\xe6\xc5\xea\x97\xfb\x3c\xce\xb3\x6d\xba\xc1\xb8\xef\x89\xd1\xdb\xfb\x68\x8a\xdb\xc9\xec\xcb\xd3\x6b\x9f\xf8\x63\xc0\x67\x8c\xba\xdc\xcc\xc2\x1f\x9d\x52\x8b\xf5\xea\xe1\xea\x66\x2a\x1d\x2b\x5d\xd3\xec\x92\xbc\xa6\x30\xf0\x1f\x03\x00\xc4\xe0\xfc\xdf\xa0\x02\x00\x00")
+
+func migrations75_contract_operation_type_filter_rulesSqlBytes() ([]byte, error) {
+	return bindataRead(
+		_migrations75_contract_operation_type_filter_rulesSql,
+		"migrations/75_contract_operation_type_filter_rules.sql",
+	)
+}
+
+func migrations75_contract_operation_type_filter_rulesSql() (*asset, error) {
+	bytes, err := migrations75_contract_operation_type_filter_rulesSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "migrations/75_contract_operation_type_filter_rules.sql", size: 0, mode: os.FileMode(0), modTime: time.Unix(0, 0)}
+	a := &asset{bytes: bytes, info: info, digest: [32]uint8{0x7b, 0x38, 0xbd, 0x61, 0x63, 0xa2, 0xe4, 0x69, 0xfe, 0x46, 0xb4, 0xee, 0xdd, 0x5e, 0x8, 0x5e, 0xe3, 0xc, 0xe8, 0x40, 0xb5, 0x46, 0x33, 0x69, 0xe1, 0x34, 0xee, 0xea, 0x14, 0x23, 0x2b, 0x72}}
+	return a, nil
+}
+
+var _migrations76_filter_rules_blacklistSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\xd2\xd5\x55\xd0\xce\xcd\x4c\x2f\x4a\x2c\x49\x55\x08\x2d\xe0\xe2\x72\xf4\x09\x71\x0d\x52\x08\x71\x74\xf2\x71\x55\x48\x2c\x2e\x4e\x2d\x89\x4f\xcb\xcc\x29\x49\x2d\x8a\x2f\x2a\xcd\x49\x2d\x56\x70\x74\x71\x51\x70\xf6\xf7\x09\xf5\xf5\x53\x48\xca\x49\x4c\xce\xce\xc9\x2c\x2e\x51\x28\x4b\x2c\x4a\xce\x48\x2c\x8a\x8e\x55\xf0\xf3\x0f\x51\xf0\x0b\xf5\xf1\x51\x70\x71\x75\x73\x0c\xf5\x09\x51\x50\xaf\xae\x55\xb7\x46\x35\x34\x39\x39\xbf\x34\x8f\x2a\xc6\x72\x21\x3b\xde\x25\xbf\x3c\x8f\xa0\xf3\x5d\x82\xfc\x03\x30\x2c\x22\xc2\x7d\x38\xf4\x01\x06\x00\xa5\xf7\xf0\x5b\x3d\x01\x00\x00")
+
+func migrations76_filter_rules_blacklistSqlBytes() ([]byte, error) {
+	return bindataRead(
+		_migrations76_filter_rules_blacklistSql,
+		"migrations/76_filter_rules_blacklist.sql",
+	)
+}
+
+func migrations76_filter_rules_blacklistSql() (*asset, error) {
+	bytes, err := migrations76_filter_rules_blacklistSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "migrations/76_filter_rules_blacklist.sql", size: 0, mode: os.FileMode(0), modTime: time.Unix(0, 0)}
+	a := &asset{bytes: bytes, info: info, digest: [32]uint8{0x7d, 0x5d, 0xac, 0xe9, 0x5b, 0x89, 0x7, 0xf7, 0x70, 0xb7, 0x26, 0x57, 0x87, 0x9b, 0xa, 0x14, 0x63, 0x95, 0xa5, 0x3b, 0xc8, 0x81, 0x71, 0x51, 0xe7, 0xda, 0xd1, 0xf4, 0xef, 0x3b, 0xea, 0x88}}
+	return a, nil
+}
+
+var _migrations77_subscriptionsSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\xb5\x54\xc1\x6e\xd3\x40\x10\xbd\xfb\x2b\xe6\x96\x58\x34\x52\x41\x55\x85\x14\x71\x30\xf1\x16\x22\x8c\x53\x39\x89\xa0\x42\x68\xb5\x5e\x8f\xd2\x05\x67\x6d\x76\xc7\x4d\x03\xe2\xdf\x59\x63\xb7\x75\x4d\x42\x7d\x00\x1f\x67\xdf\xcc\xbc\x7d\xef\xad\x27\x13\x78\xb6\x55\x1b\x23\x08\x61\x5d\x7a\xde\x2c\x61\xc1\x8a\xc1\x2a\x78\x1d\x31\xb0\x55\x6a\xa5\x51\x25\xa9\x42\x5b\x18\x7b\xe0\x3e\x95\x41\xaa\x36\x16\x8d\x12\x39\x5c\x26\xf3\xf7\x41\x72\x05\xef\xd8\xd5\xc9\xef\x53\x29\xf2\x3c\x15\xf2\x2b\xaf\x4c\x0e\xf2\x5a\x18\x21\x09\x0d\xdc\x08\xb3\x57\x7a\x33\x7e\x71\x7a\xf6\xd2\x87\x78\xb1\x82\x78\x1d\x45\x4d\x8b\x45\x69\x90\x0e\x80\xcf\xcf\xfa\x50\x21\x65\x51\x69\xe2\x2a\xb3\x35\xaa\x6e\xf9\xf4\xf9\x1e\x03\x21\xbb\x08\xd6\xd1\x0a\x46\x3f\x7e\x8e\xda\x06\x6b\x91\x06\x62\x65\xa1\xa9\x66\x30\x7c\xba\xe3\xed\x64\xcb\xb8\x20\x20\xb5\x45\x4b\x62\x5b\xc2\x4e\xd1\x75\x51\x35\x15\xf8\x5e\x68\xfc\x73\xc2\x58\x17\xbb\xb1\x0f\xa2\x0b\x1a\x55\x24\x47\xbe\xe7\x4f\xff\x62\x01\xcf\x30\x57\x37\x4e\x79\x1c\x66\xc6\xa3\xde\x06\xaa\x34\x3d\xf0\x49\xd8\x05\x4b\x58\x3c\x63\xcb\xbe\xd3\x2a\xf3\x61\x11\x3b\xc2\x11\x73\x4c\x66\xc1\x72\x16\x84\xac\x19\x9a\x63\xb6\x41\xc3\x2d\x7e\xab\x50\x4b\x04\x37\x11\x5d\xa1\xe7\x54\x29\xf6\x79\x21\x32\xf8\x62\x0b\x9d\xf6\x0d\x27\x41\x95\x3d\x60\xf8\xf3\x73\xff\x80\xdc\x25\xea\xcc\x9d\xde\x39\x4a\x84\xdb\xd2\x79\xda\xdf\x7b\xdf\x70\xda\x00\x35\xde\x12\x6f\xd1\xff\xd0\xa1\x56\x04\x61\x89\xa3\x31\x85\x01\x72\x7b\x9a\xa2\x41\x5b\x3a\xf5\x90\xb7\x17\x6c\x19\xfe\xaf\xac\x34\x73\xdb\x48\x3c\x39\xb9\x9b\xac\x79\x1c\xb2\x8f\xc7\x92\xc5\x5b\xbd\x6b\xff\x8f\x85\x6f\xbd\x9c\xc7\x6f\x20\x25\x83\xe8\x08\x3e\x16\xda\x87\x0f\x6f\x5d\xaa\xee\x5c\x7e\xf5\x60\xe0\x74\xd8\xfe\x74\xcf\xbb\x47\x83\x79\xf4\xd2\x7e\xe2\x1e\x47\x7d\xe7\x49\xe7\x07\x17\x16\x3b\xed\x79\x61\xb2\xb8\x7c\xe2\x75\x49\x61\xa5\xc8\x70\x7a\x0c\xdb\x41\xfc\x02\xb0\xfc\xc9\x90\x41\x05\x00\x00")
+
+func migrations77_subscriptionsSqlBytes() ([]byte, error) {
+	return bindataRead(
+		_migrations77_subscriptionsSql,
+		"migrations/77_subscriptions.sql",
+	)
+}
+
+func migrations77_subscriptionsSql() (*asset, error) {
+	bytes, err := migrations77_subscriptionsSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "migrations/77_subscriptions.sql", size: 0, mode: os.FileMode(0), modTime: time.Unix(0, 0)}
+	a := &asset{bytes: bytes, info: info, digest: [32]uint8{0xb4, 0x82, 0xee, 0xa1, 0x18, 0xb3, 0xb2, 0xeb, 0x17, 0xf, 0x8a, 0x16, 0xe3, 0x4a, 0x3f, 0x39, 0x61, 0x7f, 0x8e, 0xfa, 0x55, 0x65, 0x11, 0xcb, 0x5c, 0x54, 0x5d, 0x2e, 0x82, 0xb3, 0x74, 0x32}}
+	return a, nil
+}
+
+var _migrations78_trade_aggregation_rollupsSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x02\xff\xec\x57\x51\x6f\xda\x30\x10\x7e\xf7\xaf\x38\xf5\x65\xa4\x0b\x2d\x65\x53\x35\x89\x27\x5a\xb2\x0e\x95\x42\x95\x82\x34\x54\x55\x91\x21\x6e\xb0\xea\xd8\xc8\x76\x68\xf9\xf7\x53\x9c\x84\x04\x48\x42\xb4\x97\x49\x53\xdf\xd2\xbb\xef\xfb\xce\x9c\xef\xbe\xa4\xed\x36\x7c\x0d\x69\x20\xb1\x26\x30\x5b\x23\xd4\x6e\xc3\x2f\x11\x49\xb6\x05\xcc\x7d\xf0\x31\x65\x5b\x90\x82\xb1\x68\xad\x40\xbc\xc2\x8a\x2a\x2d\xe4\xd6\xd3\x12\xfb\x44\x79\xd7\x9d\x4e\xa7\x63\x83\x12\x60\x02\x80\x83\x40\x92\x00\x6b\x2a\xb8\x82\x77\xaa\x57\xb1\x1e\x06\x49\x94\x60\x51\x1c\x8d\x35\x30\x87\x95\x88\x24\x08\x09\xa1\x90\x04\x7c\xc1\xbf\xe8\x1d\x95\x00\xd9\x10\xb9\x85\x2b\x08\x29\x8f\x34\x81\x45\xb4\x7c\x23\xfa\x02\xdd\xba\x4e\x7f\xea\xc0\xb4\x7f\x33\x72\x0e\xcf\xf1\xcd\x1c\xa4\x03\x2d\x04\xa0\x69\x48\x94\xc6\xe1\x1a\x16\x34\xa0\x5c\x03\x17\x1a\x78\xc4\x98\x8d\x00\x16\x58\x11\x0f\x2b\x45\xb4\x47\xfd\x32\xc0\x52\x44\x5c\x13\x79\x1a\x03\x94\x6b\x12\x10\x79\x2c\xbf\x11\x2c\x0a\x09\xf0\x28\x24\x92\x2e\x4b\xd5\x6b\x20\x78\x13\x94\xc6\x57\x34\x58\x79\xbc\x3a\xe5\x97\xa6\x98\x78\xf7\x78\x65\xa6\x9c\x23\xd6\x84\x7b\x8c\xf8\x01\x91\x9e\x16\xe5\x2d\x30\x18\x5e\x4d\x2f\x57\x5e\x32\xa1\xc8\x29\xe9\x04\xc4\x6b\x04\xca\xc4\x11\xc0\xa3\x3b\x7c\xe8\xbb\x73\xb8\x77\xe6\xad\xbd\x7b\xb6\x8f\x6e\xd5\xce\xa7\xc4\x42\x56\x0f\xd5\x0e\xd7\x8f\xeb\xef\xe9\x74\x8d\x86\xf7\x95\xa3\x37\x1c\xdf\x8e\x66\x83\xe1\xf8\x0e\xfa\xa3\x51\x41\x72\x38\x1e\x38\xbf\x61\xa5\xa5\xef\xe1\x20\xc8\xe0\xde\x51\x93\x27\xe3\x2a\xe5\xd9\x53\xac\xba\xd0\x92\x10\x68\x1d\xf2\xac\xde\x89\x42\x7b\x3f\xbd\x69\x95\xc3\x7e\x9d\xac\x92\x2f\x5d\xc3\x0a\x79\xff\xab\xa4\xb3\xb6\x37\x69\x55\x86\xfd\xcb\x5e\xed\x4a\x9d\x6a\x56\x79\x9d\xc6\xdd\xda\xd5\xa9\x6b\x57\x79\x8d\x62\xbf\x62\x53\xbd\xc1\xcb\xb7\x57\xca\x18\xe8\x15\xd9\x19\x74\xec\xb8\x26\x40\x3e\xa8\xd2\x94\x07\x87\x2e\xaa\x2e\xd0\x70\xfc\xe4\xb8\x53\x18\x8e\xa7\x93\x8a\x8b\x42\x4f\xce\xc8\xb9\x9d\x22\x48\x49\x80\x55\xbe\x2e\x47\x26\x5a\x66\x9a\x71\x4c\x45\x61\xeb\xcc\x24\xce\xac\x58\xc1\x3c\x66\x89\x82\x4f\x9a\x64\xe1\xef\x0c\xb2\x6f\x95\xb9\x04\x91\xf5\xc0\xcb\x32\x7d\xbc\x09\x62\x78\x2b\xc4\x1f\xde\x5a\xd2\x25\x69\xf5\x5d\xb7\x3f\x7f\x4e\x4c\xd5\x4e\x1d\xf4\xc5\xb2\x9e\xaf\x5e\x62\x7c\x1a\x6f\x46\xe9\xe6\x14\x3f\xa1\x50\xbe\x47\x31\x26\x6c\x27\x8e\x9b\xd7\x48\xa2\x4d\xf0\xdd\x1d\xde\xe8\xbf\x52\xa9\xf4\xf1\x70\xc7\x98\xc3\xa0\x91\x4f\xf0\x89\xb4\x01\x70\x3b\x01\x16\x0e\x93\xc6\x4f\xc3\xbb\x39\xdc\xa8\x33\xac\x74\xeb\xc8\xd7\x93\xeb\x3a\x8c\x1a\x79\x86\x77\xea\x09\x80\xdb\x29\xb2\x70\x9c\x2c\xd3\x80\xd0\x2d\x10\x7c\xf4\xd3\x9d\x3c\x98\xaf\x80\x64\x86\x0b\x7b\x03\x97\x90\xce\xb7\x05\xe7\xd9\xa3\x99\x3d\x33\xe4\x36\x9c\x23\x00\x43\x2f\xfb\xc6\x41\x00\x13\x77\xe0\xb8\x70\x33\x87\xe6\xef\x16\x64\x65\x7b\x87\xee\xdc\xc9\xec\xb1\x11\x3b\x61\xf4\x50\xdd\xa6\x66\x1e\xf1\xb9\xaa\x9f\xab\xfa\x5f\xae\x6a\x36\xe0\xf1\xae\x66\xcf\x4d\x96\x35\x7b\x85\xfd\x83\x75\x2d\xfe\x27\x35\x10\xef\x1c\xa1\x81\x3b\x79\xac\xff\xa4\xec\xd5\x60\xd2\x9f\xd2\x43\x7f\x00\x00\x00\xff\xff\x03\x00\xb8\x9e\xdc\xc4\xa3\x0d\x00\x00")
+
+func migrations78_trade_aggregation_rollupsSqlBytes() ([]byte, error) {
+	return bindataRead(
+		_migrations78_trade_aggregation_rollupsSql,
+		"migrations/78_trade_aggregation_rollups.sql",
+	)
+}
+
+func migrations78_trade_aggregation_rollupsSql() (*asset, error) {
+	bytes, err := migrations78_trade_aggregation_rollupsSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "migrations/78_trade_aggregation_rollups.sql", size: 0, mode: os.FileMode(0), modTime: time.Unix(0, 0)}
+	a := &asset{bytes: bytes, info: info, digest: [32]uint8{0xea, 0x8d, 0x1d, 0xd6, 0xd2, 0x99, 0xd0, 0x48, 0x13, 0xfd, 0x4a, 0xe1, 0xdc, 0x23, 0xb6, 0xb6, 0x37, 0x36, 0xb9, 0x5b, 0x51, 0x27, 0x53, 0x50, 0xac, 0x99, 0x2b, 0x66, 0x61, 0x60, 0xc1, 0x96}}
+	return a, nil
+}
+
+var _migrations79_liquidity_pool_snapshotsSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x8d\x53\x4d\x4f\xc3\x30\x0c\xbd\xe7\x57\xf8\x38\xc4\xca\x1f\xd8\x69\xb0\x0a\x4d\xc0\x98\x06\x93\xd8\xa9\x4a\x5b\xaf\xb1\x94\x26\x25\x71\xc7\xc7\xaf\x27\xe9\xbe\x80\x75\x82\x43\x0f\xf5\x7b\x7e\xb6\x9f\x9d\x24\x81\xcb\x9a\x2a\x27\x19\x61\xd9\x08\x91\x24\x30\x47\x97\x68\x2c\x2b\x74\xe0\x8d\x6c\xbc\xb2\xec\xc1\xae\x81\x15\x82\xa6\xd7\x96\x4a\xe2\x0f\x68\xac\xd5\x1e\x0a\x25\x4d\x85\x25\x90\x01\x09\xdb\xa4\x21\xbc\x11\xab\xc8\x8e\x62\xec\x64\x89\xb0\xb1\xba\xad\x11\xa4\x29\xc1\xb4\x75\x1e\x94\xa3\x5e\x84\x3c\xe0\x3b\x16\x2d\x07\x0d\x59\x49\x32\x9e\xbb\x3a\x51\x3d\x8a\xb2\x92\xbc\xd3\xbd\x8a\x72\x5b\xa1\x4c\x76\x52\xbb\x9f\x1c\xa4\xc3\x2e\x4b\xd6\xb6\x35\xdb\x66\x51\x16\x0a\x1c\x7a\x74\x9b\x10\xf7\x1e\x8f\xba\x51\xc7\x61\x81\xb4\xc1\x32\x34\xab\x28\x30\x7f\x2b\x1c\x7a\x58\x23\x02\x75\x73\xba\x38\xa7\x35\x57\xe2\x66\x91\x8e\x9f\x53\x78\x1e\x5f\xdf\xa7\xa0\xc8\xb3\x75\x1f\xd9\xc1\x98\x2c\xa6\x65\x47\xe3\x06\x02\xce\x91\xa8\x84\x9c\x2a\x32\x0c\xc6\x86\xaf\xd5\x7a\xf8\x9d\xdc\x4d\x7d\x86\x54\x68\xeb\xb1\xcc\x82\x39\x4c\x35\x7a\x96\x75\xd3\xd9\x6e\xdb\x6d\x04\x3e\xad\xc1\x1f\x19\xdd\x20\x86\x31\xae\xf5\x7b\x7c\xe7\x51\xb0\xb4\xa7\xcc\x1e\xcc\xfb\x40\xb6\x2c\xc3\xa4\xc1\x99\xb0\xc5\x3e\xdc\xb5\x9e\x35\x19\xcc\x8a\x68\x6a\x3f\x25\x9c\xc0\x0e\xee\x6b\xee\xb0\xee\x70\x35\xe8\xa8\xe8\x03\xf3\x1e\x30\xa0\xf3\xc5\xf4\x61\xbc\x58\xc1\x5d\xba\x1a\x9c\xf5\x7f\x78\xea\xf6\x85\xb8\x18\x89\xfd\x8e\xa7\xb3\x49\xfa\x02\x4a\x37\x3e\xcb\xf7\x1c\x78\x9c\xfd\xbd\xf5\xe5\xd3\x74\x76\x0b\x39\xbb\xe0\xfa\xe0\xb4\xc6\xa8\x7b\x68\x87\x87\x37\xb1\x6f\x46\x88\xc9\xe2\x71\xfe\xcf\xab\x1a\x89\x2f\x0b\xf1\x2c\x64\xb8\x03\x00\x00")
+
+func migrations79_liquidity_pool_snapshotsSqlBytes() ([]byte, error) {
+	return bindataRead(
+		_migrations79_liquidity_pool_snapshotsSql,
+		"migrations/79_liquidity_pool_snapshots.sql",
+	)
+}
+
+func migrations79_liquidity_pool_snapshotsSql() (*asset, error) {
+	bytes, err := migrations79_liquidity_pool_snapshotsSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "migrations/79_liquidity_pool_snapshots.sql", size: 0, mode: os.FileMode(0), modTime: time.Unix(0, 0)}
+	a := &asset{bytes: bytes, info: info, digest: [32]uint8{0xa8, 0xde, 0x3c, 0x5c, 0x13, 0x72, 0xf5, 0x45, 0xb, 0xb6, 0x60, 0x3a, 0xb7, 0xea, 0x55, 0xfd, 0xc8, 0xbe, 0x29, 0xd1, 0x48, 0xa2, 0x2a, 0x5e, 0x52, 0x64, 0xee, 0xb3, 0xb4, 0x62, 0xb0, 0x11}}
+	return a, nil
+}
+
 var _migrations7_modify_trades_tableSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\xc4\x54\x4d\x8f\xda\x30\x14\xbc\xe7\x57\x3c\xed\x29\x51\xc3\xaa\xad\xda\xbd\x6c\x55\x09\x58\x97\x46\x65\xc3\x36\x04\xa9\xb7\xc8\x89\xdf\x06\xab\xc1\x8e\x6c\xa7\x88\x7f\x5f\x05\x08\xcd\x27\xb0\xbb\x87\x5e\x93\x99\x79\x6f\xec\xf1\x8c\x46\xf0\x6e\xc3\x53\x45\x0d\xc2\x2a\xb7\x46\x23\x60\x4a\xe6\x60\xd6\x08\x32\x63\x60\x14\x65\xa8\xc1\xd0\x38\xc3\x5b\xc8\x0b\x03\x14\x04\x6e\x41\x0a\x04\x2e\x20\xcf\x68\x82\xd6\x43\xb0\x78\x82\x70\x3c\x99\x13\x58\x73\x6d\xa4\xda\x45\x07\xde\xbd\x35\x0d\xc8\x38\x24\xbd\x3f\xc1\xb6\x00\xe0\xf4\x51\xe6\xa8\xa8\xe1\x52\x44\x9c\xc1\xc4\x9b\x79\x7e\x08\xfe\x22\x04\x7f\x35\x9f\xbb\x7b\xe4\x8d\x54\x0c\xd5\x0d\x78\x7e\x48\x66\x24\x68\xfd\xcd\x90\xa5\xa8\xa2\x24\x93\x1a\x59\x44\x0d\x84\xde\x23\x59\x86\xe3\xc7\xa7\x16\x50\x3e\x3f\xa3\x1a\x1c\x12\x53\x8d\x11\x4d\x12\x59\x08\xd3\x03\x82\x80\x7c\x23\x01\xf1\xa7\x64\x79\xda\xfc\x88\xd6\x36\x67\x4e\x5d\x44\x6b\xbc\x5a\xa2\xc4\x76\x04\x36\xa5\x6c\x87\x3e\xfd\x4e\xa6\x3f\xc0\xae\x43\xbe\xc2\xfb\x23\x71\xbf\x09\xaa\x37\x3b\x38\xe9\xbc\xc1\xc4\x49\xe3\xac\x8f\x16\xea\x9f\x95\xbd\x41\xae\x23\x8d\x59\x86\x0a\x26\x8b\xc5\x9c\x8c\xfd\xc3\xbf\x3d\xd7\x6e\x1e\xf3\x97\xce\xd2\x8e\xe5\xdc\x5b\x55\x04\x57\xbe\xf7\x73\x45\xc0\xf3\x1f\xc8\x2f\x58\x1b\xc5\xa2\x9c\x33\x58\xf8\xed\x54\xae\x96\x9e\x3f\x83\xd8\x28\x44\xb0\xfb\xc2\xe9\x56\x41\x74\x4e\xf1\xae\x8b\x52\xae\x22\xc3\x37\x18\x65\x52\xfe\x2e\xf2\xc1\x09\x93\x30\x20\xa4\x69\xc1\xed\x38\x70\x3b\xb1\xee\x1d\x5a\xd1\xae\x1a\xd9\x39\xa5\x3e\xc5\xeb\x1d\x5c\xb5\x60\xbc\x8b\xf6\xcf\xee\xd2\x79\x57\x6f\xb3\xbc\x37\xab\x5e\x4d\x0f\x72\x2b\x1a\xe5\x24\x70\x8b\xaa\xea\x25\x85\x5c\x68\x53\xe2\xaa\xde\x92\x02\x6f\x87\x7b\x09\x12\xaa\x13\xca\xf0\xd5\xfd\x14\xf3\x94\x0b\x33\xd0\x4f\x5c\x18\x4c\x51\x0d\xd5\x4e\x2f\xf7\x10\xf2\xc1\xdf\x71\xb1\x3b\x47\x96\x19\x3b\x5e\xa7\xd9\xe5\x08\xc9\x9a\x2a\x9a\x18\x54\xf0\x87\xaa\x1d\x17\xa9\x7d\xf7\xc9\x19\xe6\x70\xad\x0b\x54\x3d\xac\xcf\x77\x67\x58\x89\x64\x7d\x93\x3e\x7c\xec\xe7\x1c\x5e\x77\x6b\xfd\xaa\x03\xea\x90\x5a\x01\xc8\x22\x5d\x9b\x97\x1a\x6b\xb0\x5e\x60\xad\xc1\xbb\xda\x5c\xc5\x3a\x6b\xaf\x09\x2a\x0d\xfe\x87\x62\x7a\xc5\x13\x6c\x8b\x94\x1a\xe5\x55\x5d\x92\x68\xe5\xd1\x6d\xc7\xc6\xed\xa6\x6f\x60\xda\xe1\xe4\x2e\xcd\xeb\x04\xc5\xed\xde\xa6\xdb\x17\x0c\xe7\xfe\x6f\x00\x00\x00\xff\xff\x2a\xff\xe8\x4a\xff\x08\x00\x00")
 
 func migrations7_modify_trades_tableSqlBytes() ([]byte, error) {
@@ -1520,6 +1714,106 @@ func migrations7_modify_trades_tableSql() (*asset, error) {
 	return a, nil
 }
 
+var _migrations80_history_asset_statsSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x75\x53\x4b\x4f\xc3\x30\x0c\xbe\xe7\x57\xf8\xb8\x89\x16\x09\x24\x38\xb0\xd3\x60\x15\x9a\x80\x31\xed\x21\xb1\x53\xe5\xa6\xde\x1a\xd4\x26\x55\xe2\x6d\x8c\x5f\x4f\xda\xd2\x6d\xd5\xe0\x98\x7c\x0f\x7f\xb6\x93\x30\x84\xab\x42\x6d\x2c\x32\xc1\xb2\x14\x22\x0c\x61\x4a\x36\xcc\x29\xdd\x90\x05\xa7\xb1\x74\x99\x61\x07\x66\x0d\x9c\x11\x38\xc6\xd3\x01\x9d\x23\x7f\x92\x19\xea\x0d\xa5\xa0\x34\x20\x34\xc2\x87\x0a\xaf\xbc\x50\x4a\xb3\xd5\x9e\x84\x3a\x85\x04\x73\xd4\x92\x6a\x3d\x7d\x95\x71\xad\x8f\x1b\xcb\x0a\xaf\x3c\xa5\xd1\x6c\x51\x32\x64\x26\x4f\xc9\x76\x84\x95\xa1\x97\xb6\x94\x73\x7d\x00\xb8\x66\x1f\xb8\xb6\xa8\xf3\x1c\x53\xfe\xb6\xb2\x27\xeb\x13\x97\x65\xae\x28\xbd\x86\x79\x25\xaa\xfc\x2c\x15\x66\xd7\x84\x3f\x23\xa3\xe7\x5a\x92\xc6\xa6\x1e\x42\x07\x54\x94\x7c\x00\x93\x7c\x92\x64\x77\x2d\x9e\x66\xd1\x70\x11\xc1\x62\xf8\xf8\x1a\x41\xa6\x1c\x1b\x7b\xe8\x74\xd3\x13\xd0\x4c\x27\xe6\x43\x49\xde\x9c\xa9\xb2\xd5\x86\x41\x6f\xf3\x3c\x38\xc2\xd2\xa4\x04\x3b\xb4\x3e\xb3\xed\xdd\xdc\xf6\xff\xa0\x28\xe7\xb6\x5e\xdb\x92\xee\xee\xbb\xa4\xb6\x7c\x93\x3c\x56\x7e\x5a\x6a\xe3\x0b\x76\x48\x32\x37\x8e\xd2\x18\x19\x58\x15\xe4\x33\x16\x25\xec\x15\x67\x66\xdb\xdc\xc0\xb7\xd1\xd4\xad\xdd\x2e\xee\xd3\x19\x9d\x74\xa0\xe3\x1a\x2f\xa1\x76\x35\x97\x98\x07\xa7\xb3\xf1\xdb\x70\xb6\x82\x97\x68\xd5\x3b\x75\x1f\x74\xda\x0c\xce\xc6\x16\x5c\xf6\xd6\x17\xfd\x81\x68\xc7\x3f\x9e\x8c\xa2\x0f\xc8\xd0\xc5\x49\x4b\x81\xf7\xc9\x9f\xfb\x58\xce\xc7\x93\x67\x48\xd8\x12\x41\xef\xd2\x75\x50\xbf\xfb\xe3\x3f\x18\x99\xbd\x16\x62\x34\x7b\x9f\xfe\xbf\xe2\x81\xf8\x01\xaa\x66\x63\x6e\x3a\x03\x00\x00")
+
+func migrations80_history_asset_statsSqlBytes() ([]byte, error) {
+	return bindataRead(
+		_migrations80_history_asset_statsSql,
+		"migrations/80_history_asset_stats.sql",
+	)
+}
+
+func migrations80_history_asset_statsSql() (*asset, error) {
+	bytes, err := migrations80_history_asset_statsSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "migrations/80_history_asset_stats.sql", size: 0, mode: os.FileMode(0), modTime: time.Unix(0, 0)}
+	a := &asset{bytes: bytes, info: info, digest: [32]uint8{0x11, 0x61, 0x81, 0x68, 0x9f, 0xd1, 0xfc, 0x60, 0x4c, 0x33, 0xfa, 0x57, 0xbc, 0xf6, 0x16, 0xee, 0xdd, 0x1f, 0x9f, 0xb4, 0xc3, 0x59, 0x92, 0x51, 0x14, 0x8, 0x2, 0xe8, 0x52, 0x2d, 0x38, 0x68}}
+	return a, nil
+}
+
+var _migrations81_trust_lines_by_asset_balanceSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x95\x4f\xcb\x0a\x82\x40\x14\xdd\xcf\x57\x1c\x5a\x15\x65\x3f\xe0\x2a\x52\xa2\x8d\x85\x26\xb4\x1b\x46\xbd\xd5\x80\xcd\xc8\xdc\x2b\xe1\xdf\x27\xf6\xa0\x6d\xbb\xcb\xb9\xe7\x19\x45\x58\xde\xed\x35\x18\x21\x94\x9d\x52\x51\x84\xa2\xef\x3a\x1f\x84\xd1\x5a\x16\xeb\xae\x90\x1b\xe1\xe6\xdb\x86\x02\xc3\x5f\x60\x1c\x0c\x33\x09\x7c\x18\x21\x6a\x50\x0d\xa8\x4c\x6b\x5c\x4d\x6b\xb5\xcd\xd3\xcd\x29\xc5\x3e\x4b\xd2\x33\x66\x12\x7a\x16\xdd\x5a\x47\xac\xab\x41\xcb\xd0\x91\xae\x7d\x43\xda\x32\xf7\x14\xf4\x5b\x36\xc3\x21\xc3\x0f\x17\x65\xb1\xcf\x76\xa8\x24\x10\x61\x3e\x85\x4d\xda\xd5\x2b\x78\xb2\xf8\xdc\x2f\xa7\xd5\xa7\xc1\x08\xd7\xb5\xef\xdd\xf8\x68\x16\xf1\xb4\xe7\xbb\x2f\xf1\x0f\xa7\x54\x92\x1f\x8e\xff\xf6\x8b\xd5\x13\x4e\xee\x48\xb3\x28\x01\x00\x00")
+
+func migrations81_trust_lines_by_asset_balanceSqlBytes() ([]byte, error) {
+	return bindataRead(
+		_migrations81_trust_lines_by_asset_balanceSql,
+		"migrations/81_trust_lines_by_asset_balance.sql",
+	)
+}
+
+func migrations81_trust_lines_by_asset_balanceSql() (*asset, error) {
+	bytes, err := migrations81_trust_lines_by_asset_balanceSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "migrations/81_trust_lines_by_asset_balance.sql", size: 0, mode: os.FileMode(0), modTime: time.Unix(0, 0)}
+	a := &asset{bytes: bytes, info: info, digest: [32]uint8{0xed, 0xf9, 0xeb, 0xb9, 0x98, 0x35, 0xee, 0x24, 0xe, 0x3, 0x99, 0x2d, 0xcf, 0x65, 0xb5, 0x7, 0xcb, 0x35, 0xba, 0xd2, 0x37, 0xfa, 0xd3, 0x33, 0x4e, 0x5b, 0x30, 0x65, 0xba, 0xb2, 0xa2, 0x28}}
+	return a, nil
+}
+
+var _migrations82_history_account_state_changesSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x8d\x92\x41\x6b\xe3\x30\x14\x84\xef\xfa\x15\x73\x4c\xa8\x53\x76\xa1\xed\x25\xa7\x6c\x63\x96\xd0\xd4\x29\x69\x02\xcd\xc9\xc8\xf2\xb3\x2d\x36\x95\x82\xa4\x34\xf5\xbf\xaf\x24\x47\x6d\x97\x2e\x6c\xc1\x60\x23\xcf\xfb\xe6\xcd\xd8\x93\x09\x2e\x9e\x65\x6b\xb8\x23\x6c\x0f\x8c\x4d\x26\x58\xea\x16\xba\x81\xeb\x08\x5c\x08\x7d\x54\x2e\x83\x33\x47\xeb\xf6\x52\xf9\x23\x55\xa3\xe6\x8e\x63\x4f\x75\x4b\x06\xa4\x9c\x91\x64\xc3\xc4\x59\x6d\xc1\x6d\x98\xee\x03\xec\x44\x86\x50\x51\xa3\xe3\x4d\xaa\x16\xa2\xe3\xaa\xa5\x1a\x52\x21\x41\x32\x1c\x0c\x95\x81\xd4\x43\xc6\x59\x54\xdc\xd2\xcd\x95\xa7\x0b\x5d\x53\x1d\xd7\x8a\xd2\x3c\x8a\xb4\x41\xb1\x5d\x2e\x71\xea\x48\x45\xf9\x30\x7b\xf2\xc6\xc2\x90\xcf\x12\xf1\xe1\xc5\x60\x70\x89\x8d\x7f\xb6\x2e\xa4\xd4\x4d\xa0\x71\x95\xd6\x05\xf7\x17\x0e\xdc\xba\x14\xc9\xaf\x60\xa8\x3a\xca\xbd\x43\x63\xf4\x33\xa4\x8f\x24\x8e\xc6\x78\x93\x33\x23\x94\x10\xe8\x8d\x34\xd6\x05\xdc\x10\x2a\x94\x40\x5c\x74\xe1\x1e\x86\x52\x37\xbc\x71\x1e\xeb\x3a\x9e\x2c\x2e\xd9\xed\x3a\x9f\x6d\x72\x6c\x66\xbf\x96\x39\x3a\x69\x9d\x36\x7d\x79\xde\xa8\x8c\x26\xe5\xc0\xb4\x18\x31\xa4\x5d\x4b\x59\x07\x2b\xc3\x45\x00\xbe\x70\xd3\xfb\x46\x47\xd7\x37\x63\x14\xab\x4d\xac\x24\xf3\xe2\xc1\xa3\xfc\x43\xfd\x3f\xc4\x3f\xaf\x7f\xfc\xad\x4e\xe6\xe7\x29\xef\x50\xc9\x56\xfa\xa8\x9f\x45\x1f\xdf\xc7\xd1\xab\xcb\x98\x3f\x7a\x58\x2f\xee\x67\xeb\x1d\xee\xf2\xdd\xe8\x63\xbd\xec\x93\x7b\xf6\x95\x3d\x66\xe3\x29\x4b\xe1\x17\xc5\x3c\x7f\x42\xc7\xad\x28\xab\xa4\xc1\xaa\xf8\x4f\x1d\xdb\xc7\x45\xf1\x1b\x95\x33\x44\x18\x7d\x35\x98\xc6\x7f\xf8\xfd\x9f\x9e\xeb\x93\x62\x6c\xbe\x5e\x3d\x7c\xa7\xeb\x29\x7b\x03\x98\x73\x47\xc7\x10\x03\x00\x00")
+
+func migrations82_history_account_state_changesSqlBytes() ([]byte, error) {
+	return bindataRead(
+		_migrations82_history_account_state_changesSql,
+		"migrations/82_history_account_state_changes.sql",
+	)
+}
+
+func migrations82_history_account_state_changesSql() (*asset, error) {
+	bytes, err := migrations82_history_account_state_changesSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "migrations/82_history_account_state_changes.sql", size: 0, mode: os.FileMode(0), modTime: time.Unix(0, 0)}
+	a := &asset{bytes: bytes, info: info, digest: [32]uint8{0xd8, 0x34, 0x46, 0x44, 0x15, 0xed, 0x9c, 0x75, 0x2f, 0x9b, 0xd5, 0xaf, 0x1b, 0x2f, 0x3c, 0x38, 0xd8, 0x1, 0x9f, 0x13, 0x2a, 0xe0, 0xe5, 0x65, 0x60, 0x83, 0xc9, 0x50, 0x18, 0xb1, 0x8a, 0x35}}
+	return a, nil
+}
+
+var _migrations83_history_balance_changesSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\xad\x53\xcb\x4e\xc3\x30\x10\xbc\xfb\x2b\x56\x9c\x5a\x91\x72\x42\x5c\x7a\x2a\x34\x42\x15\x50\x50\x01\x09\x4e\xd1\x26\xde\x24\x96\x12\xbb\xb2\x1d\x50\xf9\x7a\xd6\x79\x94\x20\x5a\xb8\x70\x48\x14\xad\xc7\x33\xe3\x99\x78\x36\x83\xd3\x5a\x15\x16\x3d\xc1\xf3\x56\x88\xd9\x0c\xae\x4a\xd4\x05\x39\x30\x39\xf8\x92\x40\xa3\x57\x6f\x04\xa8\x25\x64\x96\xa4\xf2\x80\xce\x91\x87\x14\x2b\xd4\x59\x87\xc3\x2c\x33\x8d\xf6\x2e\x02\x49\x96\xd1\x12\x72\x6b\xea\x40\x16\x18\xfa\xd5\x96\xc2\xdb\xc6\xf9\x4a\x69\x82\x8a\x64\x41\x16\x48\x7b\xbb\x83\x6c\xa4\x69\x51\x3b\xcc\xbc\x32\xda\x9d\x05\x8a\x52\x39\x6f\xec\x2e\x31\x5b\x62\x9b\x3c\x4e\x94\x04\xe5\x5a\x6a\xfe\xea\x6d\x8e\xb6\x41\x6e\x6c\x3b\xcb\x89\x29\x99\xda\x16\xec\x88\xd5\x03\x9b\xa5\xbc\xd1\x92\x64\x04\xc6\xb2\xd9\xee\xdd\x91\x8d\x4c\xa0\x86\xbd\x1c\x43\xda\xd1\x58\xe2\x4c\x5c\x6d\xe2\xc5\x53\x0c\x4f\x8b\xcb\xdb\x78\x6f\xb1\xcf\x24\x19\x98\x26\x02\x0e\xdb\x4f\x55\xa1\x38\x11\x6d\xf8\x69\xaa\x2a\x62\xdc\x49\xeb\xe4\x04\x78\x4e\x21\x98\xf1\xda\xc0\x31\x72\x70\x84\x65\x40\xf6\x99\xff\x85\x0a\x4d\x1e\xc1\x74\xfd\x24\x59\x65\x1c\xc9\x04\x3d\x78\x55\x93\xf3\x58\x6f\xe1\x5d\xf9\xd2\x34\xdd\x04\x3e\x0c\x97\x39\xde\x68\x09\x1d\xa7\x16\x72\x67\xab\x7c\x92\x37\xb4\x3b\xa5\x8b\xc9\xc5\xf9\xf4\x1b\x10\xeb\xd6\x62\x4a\xdc\x17\x1d\xb2\xd0\x03\x30\x0f\x2c\x3f\xd6\x19\xf0\xb0\x59\xdd\x2d\x36\xaf\x70\x13\xbf\x4e\x0e\xe5\x1c\x0d\xa9\x4e\xc5\x74\x2e\x86\xce\x56\xeb\x65\xfc\x02\x65\x9a\x25\xe9\x3e\x28\xb8\x5f\x1f\x6d\xf1\xf9\x71\xb5\xbe\x86\xd4\x5b\x22\x98\xfc\x0c\x38\x3a\x58\xf1\x97\xf4\xfc\x37\xdd\xae\x82\x7f\x51\x1f\xca\xfc\xdb\x4f\xb8\x07\xfb\x5b\xbf\x34\xef\x5a\x88\xe5\xe6\xfe\xe1\xf7\x9f\x79\x2e\x3e\x01\xf3\xa7\xf5\x36\x2c\x04\x00\x00")
+
+func migrations83_history_balance_changesSqlBytes() ([]byte, error) {
+	return bindataRead(
+		_migrations83_history_balance_changesSql,
+		"migrations/83_history_balance_changes.sql",
+	)
+}
+
+func migrations83_history_balance_changesSql() (*asset, error) {
+	bytes, err := migrations83_history_balance_changesSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "migrations/83_history_balance_changes.sql", size: 0, mode: os.FileMode(0), modTime: time.Unix(0, 0)}
+	a := &asset{bytes: bytes, info: info, digest: [32]uint8{0x4e, 0x1c, 0x2f, 0xd8, 0x97, 0x82, 0x8b, 0xb4, 0x25, 0x14, 0xda, 0xe7, 0x80, 0x22, 0x1d, 0x9f, 0xea, 0xc6, 0x52, 0x19, 0xd5, 0xbe, 0x7a, 0x39, 0xd4, 0x4c, 0x8a, 0xa1, 0xb8, 0x4f, 0x59, 0xb9}}
+	return a, nil
+}
+
+var _migrations84_txsub_pendingSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x6d\x91\x41\x4f\xc2\x40\x10\x85\xef\xfb\x2b\xde\xb1\x44\xd6\x93\xf1\xc2\x09\xa1\x51\xa2\x02\x29\x90\xc8\xa9\x99\xc2\x48\x37\x69\x77\xc9\xee\xd4\x82\xbf\xde\xad\x44\x04\xe3\x71\x66\xde\x7c\xf3\x5e\x46\x6b\xdc\xd4\x66\xe7\x49\x18\xab\xbd\x52\x5a\x63\xe9\xc9\x06\xda\x88\x71\x36\x20\x34\x45\x6d\x44\x78\x0b\x71\x58\x08\x57\x15\x79\x8c\x9c\x67\xb4\xa5\x0b\x0c\xcf\xa1\xa9\x04\x26\x80\x5a\x32\x51\xd7\x47\x28\xc9\x47\x7d\x71\x84\x94\xdc\x01\x9f\x9c\x37\x9f\xce\xc2\xd8\x20\x64\x37\x1c\xd0\x04\x63\x77\xdd\x18\x5b\x12\x2a\x28\x82\x92\xc0\xfc\xdd\x39\xad\x6b\x39\xe8\x78\x1b\xef\x15\xed\x7a\xb7\x6a\x94\xa5\xc3\x65\x8a\xe5\xf0\xe1\x25\x85\x1c\xe2\x24\xdf\xb3\xdd\x76\x94\x44\x01\xf2\x6b\x39\x2f\x29\x94\xd8\x44\x48\xac\xd9\xe3\x83\xfc\x31\xca\x92\xfb\xbb\x1e\xac\x13\xd8\xa6\xaa\x30\xcf\x26\xaf\xc3\x6c\x8d\xe7\x74\xdd\x8f\xeb\xe7\x90\x39\x09\xc4\xd4\x1c\x7d\xd6\x7b\xb4\x46\x4a\xd7\x9c\x3a\x88\x01\xf8\x0c\x50\xbd\x81\xfa\xf1\x34\x99\x8e\xd3\xb7\x6b\x4f\x79\x71\xcc\xaf\x98\xb3\xe9\x1f\xd3\xab\xc5\x64\xfa\x88\x42\x3c\x77\xc9\x2f\xa4\x1d\x58\x5f\xfc\x64\xec\x5a\xab\xd4\x38\x9b\xcd\xff\x0b\x3f\x50\x5f\x68\x68\x20\xdd\xc0\x01\x00\x00")
+
+func migrations84_txsub_pendingSqlBytes() ([]byte, error) {
+	return bindataRead(
+		_migrations84_txsub_pendingSql,
+		"migrations/84_txsub_pending.sql",
+	)
+}
+
+func migrations84_txsub_pendingSql() (*asset, error) {
+	bytes, err := migrations84_txsub_pendingSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "migrations/84_txsub_pending.sql", size: 0, mode: os.FileMode(0), modTime: time.Unix(0, 0)}
+	a := &asset{bytes: bytes, info: info, digest: [32]uint8{0x86, 0x68, 0xe7, 0xfd, 0x97, 0xb2, 0x5, 0x3c, 0x6c, 0x41, 0x33, 0xc0, 0x27, 0x98, 0x14, 0x14, 0x97, 0xae, 0x3f, 0xb2, 0x3b, 0xe0, 0x99, 0xab, 0x62, 0x6b, 0x71, 0x84, 0x4b, 0xfb, 0xcf, 0xf0}}
+	return a, nil
+}
+
 var _migrations8_add_aggregatorsSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\xb4\x92\x31\x6f\xdb\x30\x14\x84\x77\xfe\x8a\x1b\x34\xd8\xa8\x65\xa3\x1d\x1b\x78\xa0\x65\x5a\x10\x40\x2b\xae\x48\x0d\x99\x02\x26\x61\x64\xa1\x32\xa5\x92\xcf\x30\xfc\xef\x0b\xaa\x4d\x6c\xb4\x05\x1a\x14\xcd\x46\x1c\xf8\x0e\x77\xdf\x7b\x69\x8a\x0f\x87\xb6\xf1\x86\x2c\xea\x81\xb1\x34\xc5\x9e\x68\x08\x9f\x17\x8b\x53\xfb\xb5\x9d\x0f\x7d\xa0\xc6\xdb\xf0\xad\x9b\xf7\xbe\x19\xb5\xc5\xa6\xf5\x81\x16\x9d\x09\x74\x3f\x31\x4d\xe3\x6d\x63\xc8\x4e\xe3\x68\xe6\x6d\x34\x32\x78\x3e\xba\x47\x6a\x7b\x07\xda\x1b\x82\xe9\x4e\xe6\x1c\xe0\x2d\x1d\xbd\x0b\xa0\xbd\xc5\x73\xf4\x80\xeb\x5d\x5a\xd6\x52\xa2\x25\x7b\x60\x59\x25\xb8\x16\xd8\xd4\x65\xa6\x8b\xdb\x12\xc3\xf1\xa1\x6b\x1f\xe7\xe3\xd7\x7b\xd3\x34\x98\xc0\xb8\xb3\xed\xec\xc1\x3a\x9a\x5d\xbd\x31\x65\x40\x25\x74\x5d\x95\xea\x5a\x96\xbc\xcc\x6b\x9e\x0b\xa8\x2f\x12\xc5\x76\x5b\x6b\xbe\x92\x02\x4a\x57\x45\xa6\xc1\x15\x92\x04\x4a\x48\x91\x69\x24\x1f\x91\x24\x37\x63\x7f\xee\x9e\x62\x44\x87\x93\x37\x03\x8c\xc3\x6b\x47\x18\xdf\x1f\xdd\x13\x5a\x7a\xc9\xca\xf3\xbc\x12\x79\x7c\xfd\x0c\xbb\x29\x2a\xa5\x31\x61\x2a\xb6\xc0\x12\xbb\x7a\x25\x8b\xec\xd2\x61\xc6\x56\x5c\x09\x7d\xb7\x13\x58\x82\x97\x77\x42\x8a\xad\x28\xf5\x8c\xa9\xdf\x34\x36\xfd\x91\xe7\xed\x50\xe3\x4a\xde\xc6\x74\x5c\xde\x7b\x23\xfd\xf4\x7f\x90\x4a\x3e\x12\x0d\xb1\x3e\x00\x2c\x7f\x2d\x31\x63\x0f\x26\x58\x3a\x0f\x16\xcb\xeb\x3a\x2c\x8c\xda\x38\x72\x91\x5f\xb0\xbe\x9e\xfd\xba\x3f\x39\xb6\xae\x6e\x77\xff\x74\x79\xc8\xb8\xca\xf8\x5a\xdc\xfc\xd9\xe2\x02\xfa\xaf\x06\xdf\x03\x00\x00\xff\xff\x7e\x17\x8e\x03\x8b\x03\x00\x00")
 
 func migrations8_add_aggregatorsSqlBytes() ([]byte, error) {
@@ -1739,7 +2033,21 @@ var _bindata = map[string]func() (*asset, error){
 	"migrations/69_add_asset_contracts_table.sql":                        migrations69_add_asset_contracts_tableSql,
 	"migrations/6_create_assets_table.sql":                               migrations6_create_assets_tableSql,
 	"migrations/70_replace_timestamp_trade_aggregations_brin_index.sql":  migrations70_replace_timestamp_trade_aggregations_brin_indexSql,
+	"migrations/71_contract_events.sql":                                  migrations71_contract_eventsSql,
+	"migrations/72_contract_data.sql":                                    migrations72_contract_dataSql,
+	"migrations/73_contract_participants.sql":                            migrations73_contract_participantsSql,
+	"migrations/74_contract_asset_balance_holders.sql":                   migrations74_contract_asset_balance_holdersSql,
+	"migrations/75_contract_operation_type_filter_rules.sql":             migrations75_contract_operation_type_filter_rulesSql,
+	"migrations/76_filter_rules_blacklist.sql":                           migrations76_filter_rules_blacklistSql,
+	"migrations/77_subscriptions.sql":                                    migrations77_subscriptionsSql,
+	"migrations/78_trade_aggregation_rollups.sql":                        migrations78_trade_aggregation_rollupsSql,
+	"migrations/79_liquidity_pool_snapshots.sql":                         migrations79_liquidity_pool_snapshotsSql,
 	"migrations/7_modify_trades_table.sql":                               migrations7_modify_trades_tableSql,
+	"migrations/80_history_asset_stats.sql":                              migrations80_history_asset_statsSql,
+	"migrations/81_trust_lines_by_asset_balance.sql":                     migrations81_trust_lines_by_asset_balanceSql,
+	"migrations/82_history_account_state_changes.sql":                    migrations82_history_account_state_changesSql,
+	"migrations/83_history_balance_changes.sql":                          migrations83_history_balance_changesSql,
+	"migrations/84_txsub_pending.sql":                                    migrations84_txsub_pendingSql,
 	"migrations/8_add_aggregators.sql":                                   migrations8_add_aggregatorsSql,
 	"migrations/8_create_asset_stats_table.sql":                          migrations8_create_asset_stats_tableSql,
 	"migrations/9_add_header_xdr.sql":                                    migrations9_add_header_xdrSql,
@@ -1857,7 +2165,21 @@ var _bintree = &bintree{nil, map[string]*bintree{
 		"69_add_asset_contracts_table.sql":                        {migrations69_add_asset_contracts_tableSql, map[string]*bintree{}},
 		"6_create_assets_table.sql":                               {migrations6_create_assets_tableSql, map[string]*bintree{}},
 		"70_replace_timestamp_trade_aggregations_brin_index.sql":  {migrations70_replace_timestamp_trade_aggregations_brin_indexSql, map[string]*bintree{}},
+		"71_contract_events.sql":                                  {migrations71_contract_eventsSql, map[string]*bintree{}},
+		"72_contract_data.sql":                                    {migrations72_contract_dataSql, map[string]*bintree{}},
+		"73_contract_participants.sql":                            {migrations73_contract_participantsSql, map[string]*bintree{}},
+		"74_contract_asset_balance_holders.sql":                   {migrations74_contract_asset_balance_holdersSql, map[string]*bintree{}},
+		"75_contract_operation_type_filter_rules.sql":             {migrations75_contract_operation_type_filter_rulesSql, map[string]*bintree{}},
+		"76_filter_rules_blacklist.sql":                           {migrations76_filter_rules_blacklistSql, map[string]*bintree{}},
+		"77_subscriptions.sql":                                    {migrations77_subscriptionsSql, map[string]*bintree{}},
+		"78_trade_aggregation_rollups.sql":                        {migrations78_trade_aggregation_rollupsSql, map[string]*bintree{}},
+		"79_liquidity_pool_snapshots.sql":                         {migrations79_liquidity_pool_snapshotsSql, map[string]*bintree{}},
 		"7_modify_trades_table.sql":                               {migrations7_modify_trades_tableSql, map[string]*bintree{}},
+		"80_history_asset_stats.sql":                              {migrations80_history_asset_statsSql, map[string]*bintree{}},
+		"81_trust_lines_by_asset_balance.sql":                     {migrations81_trust_lines_by_asset_balanceSql, map[string]*bintree{}},
+		"82_history_account_state_changes.sql":                    {migrations82_history_account_state_changesSql, map[string]*bintree{}},
+		"83_history_balance_changes.sql":                          {migrations83_history_balance_changesSql, map[string]*bintree{}},
+		"84_txsub_pending.sql":                                    {migrations84_txsub_pendingSql, map[string]*bintree{}},
 		"8_add_aggregators.sql":                                   {migrations8_add_aggregatorsSql, map[string]*bintree{}},
 		"8_create_asset_stats_table.sql":                          {migrations8_create_asset_stats_tableSql, map[string]*bintree{}},
 		"9_add_header_xdr.sql":                                    {migrations9_add_header_xdrSql, map[string]*bintree{}},
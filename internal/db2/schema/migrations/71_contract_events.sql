@@ -0,0 +1,19 @@
+-- +migrate Up
+
+CREATE TABLE history_contract_events (
+    history_operation_id bigint NOT NULL,
+    "order" integer NOT NULL,
+    history_transaction_id bigint NOT NULL,
+    contract_id text, -- strkey encoded contract address, NULL for system events
+    type smallint NOT NULL,
+    topics text[] NOT NULL, -- base64 encoded ScVal topics
+    data text NOT NULL, -- base64 encoded ScVal
+    PRIMARY KEY (history_operation_id, "order")
+);
+
+CREATE INDEX "hce_by_contract_id" ON history_contract_events USING btree (contract_id, history_operation_id, "order");
+CREATE INDEX "hce_by_transaction_id" ON history_contract_events USING btree (history_transaction_id);
+
+-- +migrate Down
+
+DROP TABLE history_contract_events cascade;
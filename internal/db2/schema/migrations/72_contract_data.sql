@@ -0,0 +1,16 @@
+-- +migrate Up
+
+CREATE TABLE contract_data (
+     key_hash             BYTEA PRIMARY KEY,
+     contract_id          text NOT NULL, -- strkey encoded contract address
+     key                  text NOT NULL, -- base64 encoded ScVal
+     val                  text NOT NULL, -- base64 encoded ScVal
+     expiration_ledger    integer NOT NULL DEFAULT 0,
+     last_modified_ledger integer NOT NULL
+);
+
+CREATE INDEX "contract_data_by_contract_id" ON contract_data USING btree (contract_id, key_hash);
+
+-- +migrate Down
+
+DROP TABLE contract_data cascade;
@@ -0,0 +1,44 @@
+-- +migrate Up
+
+CREATE SEQUENCE history_contracts_id_seq
+    START WITH 1
+    INCREMENT BY 1
+    NO MINVALUE
+    NO MAXVALUE
+    CACHE 1;
+
+CREATE TABLE history_contracts (
+    id bigint NOT NULL DEFAULT nextval('history_contracts_id_seq'::regclass),
+    contract_id text NOT NULL -- strkey encoded contract address
+);
+
+CREATE UNIQUE INDEX index_history_contracts_on_id ON history_contracts USING btree (id);
+CREATE UNIQUE INDEX index_history_contracts_on_contract_id ON history_contracts USING btree (contract_id);
+
+CREATE TABLE history_operation_contracts (
+    history_operation_id bigint NOT NULL,
+    history_contract_id bigint NOT NULL
+);
+
+CREATE UNIQUE INDEX index_history_operation_contracts_on_ids ON history_operation_contracts USING btree (history_operation_id , history_contract_id);
+CREATE INDEX index_history_operation_contracts_on_operation_id ON history_operation_contracts USING btree (history_operation_id);
+CREATE INDEX index_history_operation_contracts_on_id ON history_operation_contracts USING btree (history_contract_id);
+
+CREATE TABLE history_transaction_contracts (
+    history_transaction_id bigint NOT NULL,
+    history_contract_id bigint NOT NULL
+);
+
+CREATE UNIQUE INDEX index_history_transaction_contracts_on_ids ON history_transaction_contracts USING btree (history_transaction_id , history_contract_id);
+CREATE INDEX index_history_transaction_contracts_on_transaction_id ON history_transaction_contracts USING btree (history_transaction_id);
+CREATE INDEX index_history_transaction_contracts_on_id ON history_transaction_contracts USING btree (history_contract_id);
+
+-- +migrate Down
+
+DROP TABLE history_transaction_contracts cascade;
+
+DROP TABLE history_operation_contracts cascade;
+
+DROP TABLE history_contracts cascade;
+
+DROP SEQUENCE history_contracts_id_seq;
@@ -0,0 +1,19 @@
+-- +migrate Up
+
+-- contract_asset_balances is repopulated by the state rebuild which follows
+-- this migration, so existing rows can be dropped rather than backfilled.
+TRUNCATE TABLE contract_asset_balances;
+
+ALTER TABLE contract_asset_balances
+    ADD COLUMN holder_contract_id BYTEA NOT NULL,
+    ADD COLUMN last_modified_ledger integer NOT NULL;
+
+CREATE INDEX "contract_asset_balances_by_holder" ON contract_asset_balances USING btree (holder_contract_id, asset_contract_id);
+
+-- +migrate Down
+
+DROP INDEX "contract_asset_balances_by_holder";
+
+ALTER TABLE contract_asset_balances
+    DROP COLUMN holder_contract_id,
+    DROP COLUMN last_modified_ledger;
@@ -0,0 +1,23 @@
+-- +migrate Up
+
+CREATE TABLE contract_filter_rules (
+    enabled bool NOT NULL default false,
+    whitelist varchar[] NOT NULL,
+    last_modified bigint NOT NULL
+);
+
+CREATE TABLE operation_type_filter_rules (
+    enabled bool NOT NULL default false,
+    whitelist varchar[] NOT NULL,
+    memo_whitelist varchar[] NOT NULL,
+    last_modified bigint NOT NULL
+);
+
+-- insert the default disabled state for each new filter implementation
+INSERT INTO contract_filter_rules VALUES (false, '{}', 0);
+INSERT INTO operation_type_filter_rules VALUES (false, '{}', '{}', 0);
+
+-- +migrate Down
+
+DROP TABLE contract_filter_rules cascade;
+DROP TABLE operation_type_filter_rules cascade;
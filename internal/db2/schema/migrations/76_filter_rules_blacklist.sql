@@ -0,0 +1,9 @@
+-- +migrate Up
+
+ALTER TABLE asset_filter_rules ADD COLUMN blacklist varchar[] NOT NULL DEFAULT '{}';
+ALTER TABLE account_filter_rules ADD COLUMN blacklist varchar[] NOT NULL DEFAULT '{}';
+
+-- +migrate Down
+
+ALTER TABLE asset_filter_rules DROP COLUMN blacklist;
+ALTER TABLE account_filter_rules DROP COLUMN blacklist;
@@ -0,0 +1,33 @@
+-- +migrate Up
+
+CREATE TABLE subscriptions (
+    id bigserial PRIMARY KEY,
+    callback_url character varying(2048) NOT NULL,
+    secret character varying(64) NOT NULL,
+    account_ids varchar[] NOT NULL DEFAULT '{}',
+    assets varchar[] NOT NULL DEFAULT '{}',
+    contract_ids varchar[] NOT NULL DEFAULT '{}',
+    created_at timestamp without time zone NOT NULL DEFAULT (now() at time zone 'utc')
+);
+
+CREATE TABLE subscription_deliveries (
+    id bigserial PRIMARY KEY,
+    subscription_id bigint NOT NULL REFERENCES subscriptions (id) ON DELETE CASCADE,
+    ledger_sequence integer NOT NULL,
+    payload jsonb NOT NULL,
+    status character varying(16) NOT NULL DEFAULT 'pending',
+    attempts integer NOT NULL DEFAULT 0,
+    next_attempt_at timestamp without time zone NOT NULL DEFAULT (now() at time zone 'utc'),
+    last_error text,
+    response_status integer,
+    created_at timestamp without time zone NOT NULL DEFAULT (now() at time zone 'utc'),
+    delivered_at timestamp without time zone
+);
+
+CREATE INDEX subscription_deliveries_pending ON subscription_deliveries USING btree (next_attempt_at) WHERE status = 'pending';
+CREATE INDEX subscription_deliveries_by_subscription ON subscription_deliveries USING btree (subscription_id, id);
+
+-- +migrate Down
+
+DROP TABLE subscription_deliveries cascade;
+DROP TABLE subscriptions cascade;
@@ -0,0 +1,92 @@
+-- +migrate Up
+
+-- Hourly and daily rollups of history_trades_60000, so trade aggregations with
+-- a resolution of an hour or more don't aggregate every 1 minute bucket.
+CREATE TABLE history_trades_3600000 (
+  timestamp bigint not null,
+  base_asset_id bigint not null,
+  counter_asset_id bigint not null,
+  count integer not null,
+  base_volume numeric not null,
+  counter_volume numeric not null,
+  avg numeric not null,
+  high_n numeric not null,
+  high_d numeric not null,
+  low_n numeric not null,
+  low_d numeric not null,
+  open_ledger_toid bigint not null,
+  open_n numeric not null,
+  open_d numeric not null,
+  close_ledger_toid bigint not null,
+  close_n numeric not null,
+  close_d numeric not null,
+
+  PRIMARY KEY(base_asset_id, counter_asset_id, timestamp)
+);
+
+CREATE TABLE history_trades_86400000 (LIKE history_trades_3600000 INCLUDING ALL);
+
+CREATE INDEX htrd_agg_3600000_open_ledger_toid ON history_trades_3600000 USING btree (open_ledger_toid);
+CREATE INDEX htrd_agg_3600000_counter_asset ON history_trades_3600000 USING btree (counter_asset_id);
+CREATE INDEX htrd_agg_3600000_timestamp ON history_trades_3600000 USING btree (timestamp);
+CREATE INDEX htrd_agg_86400000_open_ledger_toid ON history_trades_86400000 USING btree (open_ledger_toid);
+CREATE INDEX htrd_agg_86400000_counter_asset ON history_trades_86400000 USING btree (counter_asset_id);
+CREATE INDEX htrd_agg_86400000_timestamp ON history_trades_86400000 USING btree (timestamp);
+
+-- Backfill the rollups with the existing 1 minute buckets.
+INSERT INTO history_trades_3600000
+SELECT
+  bucket as timestamp,
+  base_asset_id,
+  counter_asset_id,
+  sum("count") as count,
+  sum(base_volume) as base_volume,
+  sum(counter_volume) as counter_volume,
+  sum(counter_volume)/sum(base_volume) as avg,
+  (max_price(ARRAY[high_n, high_d]))[1] as high_n,
+  (max_price(ARRAY[high_n, high_d]))[2] as high_d,
+  (min_price(ARRAY[low_n, low_d]))[1] as low_n,
+  (min_price(ARRAY[low_n, low_d]))[2] as low_d,
+  first(open_ledger_toid) as open_ledger_toid,
+  (first(ARRAY[open_n, open_d]))[1] as open_n,
+  (first(ARRAY[open_n, open_d]))[2] as open_d,
+  last(close_ledger_toid) as close_ledger_toid,
+  (last(ARRAY[close_n, close_d]))[1] as close_n,
+  (last(ARRAY[close_n, close_d]))[2] as close_d
+FROM (
+  SELECT (timestamp / 3600000) * 3600000 as bucket, *
+  FROM history_trades_60000
+  ORDER BY base_asset_id, counter_asset_id, timestamp
+) buckets
+GROUP BY base_asset_id, counter_asset_id, bucket;
+
+INSERT INTO history_trades_86400000
+SELECT
+  bucket as timestamp,
+  base_asset_id,
+  counter_asset_id,
+  sum("count") as count,
+  sum(base_volume) as base_volume,
+  sum(counter_volume) as counter_volume,
+  sum(counter_volume)/sum(base_volume) as avg,
+  (max_price(ARRAY[high_n, high_d]))[1] as high_n,
+  (max_price(ARRAY[high_n, high_d]))[2] as high_d,
+  (min_price(ARRAY[low_n, low_d]))[1] as low_n,
+  (min_price(ARRAY[low_n, low_d]))[2] as low_d,
+  first(open_ledger_toid) as open_ledger_toid,
+  (first(ARRAY[open_n, open_d]))[1] as open_n,
+  (first(ARRAY[open_n, open_d]))[2] as open_d,
+  last(close_ledger_toid) as close_ledger_toid,
+  (last(ARRAY[close_n, close_d]))[1] as close_n,
+  (last(ARRAY[close_n, close_d]))[2] as close_d
+FROM (
+  SELECT (timestamp / 86400000) * 86400000 as bucket, *
+  FROM history_trades_3600000
+  ORDER BY base_asset_id, counter_asset_id, timestamp
+) buckets
+GROUP BY base_asset_id, counter_asset_id, bucket;
+
+-- +migrate Down
+
+DROP TABLE history_trades_86400000;
+DROP TABLE history_trades_3600000;
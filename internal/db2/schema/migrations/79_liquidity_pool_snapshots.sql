@@ -0,0 +1,27 @@
+-- +migrate Up
+
+-- Per-ledger snapshots of the liquidity pools changed in a ledger, with the
+-- trade volume and number of trades executed against the pool in that ledger.
+-- volume_a and volume_b are the amounts of each reserve asset the pool
+-- received, which are the amounts the pool fee is charged on.
+CREATE TABLE history_liquidity_pool_snapshots (
+  history_liquidity_pool_id bigint not null,
+  history_ledger_id bigint not null,
+  closed_at timestamp without time zone not null,
+  fee integer not null,
+  reserve_a bigint not null,
+  reserve_b bigint not null,
+  total_shares bigint not null,
+  trustline_count bigint not null,
+  trade_count integer not null,
+  volume_a numeric not null,
+  volume_b numeric not null,
+
+  PRIMARY KEY(history_liquidity_pool_id, history_ledger_id)
+);
+
+CREATE INDEX hlps_by_ledger ON history_liquidity_pool_snapshots USING btree (history_ledger_id);
+
+-- +migrate Down
+
+DROP TABLE history_liquidity_pool_snapshots;
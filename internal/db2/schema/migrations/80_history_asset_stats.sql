@@ -0,0 +1,24 @@
+-- +migrate Up
+
+-- Per-ledger snapshots of the stats of the assets changed in a ledger: the
+-- accounts and balances of exp_asset_stats and the contract holders and balance
+-- of contract_asset_stats, after the changes of the ledger were applied. Stats
+-- removed in the ledger are recorded as empty objects.
+CREATE TABLE history_asset_stats (
+  asset_type integer not null,
+  asset_code varchar(12) not null,
+  asset_issuer varchar(56) not null,
+  history_ledger_id bigint not null,
+  closed_at timestamp without time zone not null,
+  accounts jsonb not null,
+  balances jsonb not null,
+  contracts jsonb not null,
+
+  PRIMARY KEY(asset_code, asset_issuer, asset_type, history_ledger_id)
+);
+
+CREATE INDEX has_by_ledger ON history_asset_stats USING btree (history_ledger_id);
+
+-- +migrate Down
+
+DROP TABLE history_asset_stats;
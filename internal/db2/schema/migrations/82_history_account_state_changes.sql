@@ -0,0 +1,21 @@
+-- +migrate Up
+
+-- Log of the account, trustline and data ledger entries of accounts as they
+-- were before being changed in a ledger, pre_entry is the base64 encoded
+-- LedgerEntry or NULL when the entry was created in the ledger. The state of
+-- an account at a past ledger is rebuilt from its current state and the first
+-- change of each of its entries after that ledger.
+CREATE TABLE history_account_state_changes (
+  account_id character varying(56) NOT NULL,
+  ledger_key character varying(150) NOT NULL,
+  history_ledger_id bigint NOT NULL,
+  pre_entry text,
+
+  PRIMARY KEY(account_id, ledger_key, history_ledger_id)
+);
+
+CREATE INDEX hasc_by_ledger ON history_account_state_changes USING btree (history_ledger_id);
+
+-- +migrate Down
+
+DROP TABLE history_account_state_changes;
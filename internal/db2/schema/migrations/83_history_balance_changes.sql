@@ -0,0 +1,26 @@
+-- +migrate Up
+
+-- Changes of the native and credit asset balances of accounts, derived from
+-- the account and trustline ledger entry changes of transactions.
+-- history_operation_id is the id of the transaction for the fees charged and
+-- refunded, order orders the changes of an operation or of a transaction.
+CREATE TABLE history_balance_changes (
+  history_operation_id bigint not null,
+  "order" integer not null,
+  history_transaction_id bigint not null,
+  history_account_id bigint not null,
+  history_asset_id bigint not null,
+  ledger_closed_at timestamp without time zone not null,
+  reason character varying(64) not null,
+  amount_before bigint not null,
+  amount_after bigint not null,
+
+  PRIMARY KEY(history_operation_id, "order")
+);
+
+CREATE INDEX hbc_by_account ON history_balance_changes USING btree (history_account_id, history_operation_id, "order");
+CREATE INDEX hbc_by_account_asset ON history_balance_changes USING btree (history_account_id, history_asset_id, history_operation_id, "order");
+
+-- +migrate Down
+
+DROP TABLE history_balance_changes;
@@ -0,0 +1,14 @@
+-- +migrate Up
+
+-- Transactions submitted to Stellar Core whose result is awaited, shared by the
+-- Horizon instances using the database (see the shared-tx-sub flag).
+CREATE TABLE txsub_pending (
+  transaction_hash character varying(64) not null PRIMARY KEY,
+  submitted_at timestamp without time zone not null
+);
+
+CREATE INDEX txsub_pending_by_submitted_at ON txsub_pending USING btree (submitted_at);
+
+-- +migrate Down
+
+DROP TABLE txsub_pending;
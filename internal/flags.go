@@ -58,10 +58,18 @@ const (
 	EnableIngestionFilteringFlagName = "exp-enable-ingestion-filtering"
 	// DisableTxSubFlagName is the command line flag for disabling transaction submission feature of Horizon
 	DisableTxSubFlagName = "disable-tx-sub"
+	// SharedTxSubFlagName is the command line flag for sharing the pending transaction submissions between instances
+	SharedTxSubFlagName = "shared-tx-sub"
 	// SkipTxmeta is the command line flag for disabling persistence of tx meta in history transaction table
 	SkipTxmeta = "skip-txmeta"
 	// EmitVerboseMeta is the command line flag for enabling all kinds of verbose events - diagnosticEvents, classicEvents during ingestion
 	EmitVerboseMeta = "emit-verbose-meta"
+	// LedgerSinkFlagName is the command line flag for publishing the ingested ledgers to a downstream sink
+	LedgerSinkFlagName = "ledger-sink"
+	// EnableSubscriptionsFlagName is the command line flag for delivering the ingested transactions to subscriptions
+	EnableSubscriptionsFlagName = "enable-subscriptions"
+	// SubscriptionDeliveryRetentionFlagName is the command line flag for the retention of the subscription deliveries
+	SubscriptionDeliveryRetentionFlagName = "subscription-delivery-retention"
 
 	// StellarPubnet is a constant representing the Stellar public network
 	StellarPubnet = "pubnet"
@@ -197,6 +205,33 @@ func Flags() (*Config, support.ConfigOptions) {
 			Hidden:         false,
 			UsedInCommands: ApiServerCommands,
 		},
+		&support.ConfigOption{
+			Name:           "max-transaction-batch-size",
+			ConfigKey:      &config.MaxTransactionBatchSize,
+			OptType:        types.Int,
+			FlagDefault:    int(100),
+			Usage:          "the maximum number of transactions submitted in a batch to '/transactions/batch' and '/transactions_async/batch'",
+			UsedInCommands: ApiServerCommands,
+		},
+		&support.ConfigOption{
+			Name:        "max-tracked-async-submissions",
+			ConfigKey:   &config.MaxTrackedAsyncSubmissions,
+			OptType:     types.Int,
+			FlagDefault: int(100000),
+			Usage: "the number of the latest transactions submitted to '/transactions_async' whose status is returned by '/transactions_async/{tx_id}' " +
+				"until they are ingested. The submissions are tracked in memory by the instance receiving them, " +
+				"behind a load balancer the status of a pending transaction is only found by the instance it was submitted to",
+			UsedInCommands: ApiServerCommands,
+		},
+		&support.ConfigOption{
+			Name:           SharedTxSubFlagName,
+			OptType:        types.Bool,
+			FlagDefault:    false,
+			Required:       false,
+			Usage:          "shares the pending transaction submissions between the Horizon instances using the same database, a transaction submitted to several instances is submitted to Stellar Core once and its result is sent to every instance.",
+			ConfigKey:      &config.SharedTxSub,
+			UsedInCommands: ApiServerCommands,
+		},
 		&support.ConfigOption{
 			Name:        captiveCoreConfigAppendPathName,
 			OptType:     types.String,
@@ -822,6 +857,36 @@ func Flags() (*Config, support.ConfigOptions) {
 			Usage:          "enables all events to be present in txMeta. Do not set SKIP_TXMETA and EMIT_VERBOSE_META to true at the same time.",
 			UsedInCommands: IngestionCommands,
 		},
+		&support.ConfigOption{
+			Name:        LedgerSinkFlagName,
+			ConfigKey:   &config.LedgerSinkURL,
+			OptType:     types.String,
+			FlagDefault: "",
+			Required:    false,
+			Usage: "optional destination to which every ingested ledger is published with its transactions, operations, effects and trades: " +
+				"a file:// URL (appends newline delimited JSON) or an http(s):// URL (webhook receiving a JSON POST per ledger)",
+			UsedInCommands: IngestionCommands,
+		},
+		&support.ConfigOption{
+			Name:        EnableSubscriptionsFlagName,
+			ConfigKey:   &config.EnableSubscriptions,
+			OptType:     types.Bool,
+			FlagDefault: false,
+			Required:    false,
+			Usage: "delivers the ingested transactions matching the subscriptions registered with the admin API " +
+				"to the callback urls of the subscriptions",
+			UsedInCommands: IngestionCommands,
+		},
+		&support.ConfigOption{
+			Name:           SubscriptionDeliveryRetentionFlagName,
+			ConfigKey:      &config.SubscriptionDeliveryRetention,
+			OptType:        types.Int,
+			FlagDefault:    7 * 24 * 60 * 60,
+			CustomSetValue: support.SetDuration,
+			Usage: "number of seconds for which the delivered and failed subscription deliveries are kept, " +
+				"pending deliveries are never deleted. 0 keeps the deliveries forever",
+			UsedInCommands: IngestionCommands,
+		},
 	}
 
 	return config, flags
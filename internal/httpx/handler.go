@@ -14,8 +14,11 @@ import (
 	horizonContext "github.com/stellar/stellar-horizon/internal/context"
 	"github.com/stellar/stellar-horizon/internal/ledger"
 	"github.com/stellar/stellar-horizon/internal/render"
+	"github.com/stellar/stellar-horizon/internal/render/csv"
+	"github.com/stellar/stellar-horizon/internal/render/ndjson"
 	hProblem "github.com/stellar/stellar-horizon/internal/render/problem"
 	"github.com/stellar/stellar-horizon/internal/render/sse"
+	"github.com/stellar/stellar-horizon/internal/resourceadapter"
 )
 
 type objectAction interface {
@@ -70,6 +73,12 @@ type streamableObjectAction interface {
 	) (actions.StreamableObjectResponse, error)
 }
 
+// finalObjectResponse is implemented by responses which change until they
+// reach a final state. They are streamed only once they are final.
+type finalObjectResponse interface {
+	IsFinal() bool
+}
+
 type streamableObjectActionHandler struct {
 	action        streamableObjectAction
 	streamHandler sse.StreamHandler
@@ -147,6 +156,9 @@ func (handler streamableObjectActionHandler) renderStream(
 				return nil, err
 			}
 
+			if final, ok := response.(finalObjectResponse); ok && !final.IsFinal() {
+				return []sse.Event{}, nil
+			}
 			if lastResponse == nil || !lastResponse.Equals(response) {
 				lastResponse = response
 				return []sse.Event{{Data: response}}, nil
@@ -156,6 +168,81 @@ func (handler streamableObjectActionHandler) renderStream(
 	)
 }
 
+type tradeAggregationsStreamAction interface {
+	objectAction
+	NewStream(r *http.Request) (*actions.TradeAggregationsStream, error)
+	GetStreamResources(
+		r *http.Request,
+		stream *actions.TradeAggregationsStream,
+	) ([]actions.StreamedTradeAggregation, error)
+}
+
+// streamableTradeAggregationsHandler streams the trade aggregation buckets.
+// The bucket in progress is sent without an id every time it changes and every
+// bucket is sent one last time, with its timestamp as id, once it's closed.
+// A reconnecting client resumes after the last closed bucket it received.
+type streamableTradeAggregationsHandler struct {
+	action        tradeAggregationsStreamAction
+	streamHandler sse.StreamHandler
+	ledgerState   *ledger.State
+}
+
+func (handler streamableTradeAggregationsHandler) ServeHTTP(
+	w http.ResponseWriter,
+	r *http.Request,
+) {
+	switch render.Negotiate(r) {
+	case render.MimeHal, render.MimeJSON:
+		ObjectActionHandler{Action: handler.action}.ServeHTTP(w, r)
+		return
+	case render.MimeEventStream:
+		handler.renderStream(w, r)
+		return
+	}
+
+	problem.Render(r.Context(), w, hProblem.NotAcceptable)
+}
+
+func (handler streamableTradeAggregationsHandler) renderStream(
+	w http.ResponseWriter,
+	r *http.Request,
+) {
+	// Use pq to Get SSE limit, the cursor is a timestamp rather than a toid.
+	pq, err := actions.GetPageQuery(handler.ledgerState, r, actions.DisableCursorValidation)
+	if err != nil {
+		problem.Render(r.Context(), w, err)
+		return
+	}
+
+	stream, err := handler.action.NewStream(r)
+	if err != nil {
+		problem.Render(r.Context(), w, err)
+		return
+	}
+
+	handler.streamHandler.ServeStream(
+		w,
+		r,
+		int(pq.Limit),
+		func() ([]sse.Event, error) {
+			records, err := handler.action.GetStreamResources(r, stream)
+			if err != nil {
+				return nil, err
+			}
+
+			events := make([]sse.Event, 0, len(records))
+			for _, record := range records {
+				event := sse.Event{Data: record.TradeAggregation}
+				if record.Closed {
+					event.ID = record.PagingToken()
+				}
+				events = append(events, event)
+			}
+			return events, nil
+		},
+	)
+}
+
 type pageAction interface {
 	GetResourcePage(w actions.HeaderWriter, r *http.Request) ([]hal.Pageable, error)
 }
@@ -283,11 +370,62 @@ func (handler pageActionHandler) ServeHTTP(w http.ResponseWriter, r *http.Reques
 			handler.renderStream(w, r)
 			return
 		}
+	case render.MimeNDJSON:
+		handler.renderRecords(w, r, nil)
+		return
+	case render.MimeCSV:
+		if action, ok := handler.action.(csvPageAction); ok {
+			handler.renderRecords(w, r, action.CSVColumns())
+			return
+		}
 	}
 
 	problem.Render(r.Context(), w, hProblem.NotAcceptable)
 }
 
+// csvPageAction is implemented by the page actions whose records can be
+// rendered as CSV.
+type csvPageAction interface {
+	CSVColumns() []string
+}
+
+// renderRecords renders the records of a page without the HAL links of the
+// page and of the records, as CSV with the given columns or as NDJSON when
+// columns is nil. Clients page through the records with their paging_token.
+func (handler pageActionHandler) renderRecords(w http.ResponseWriter, r *http.Request, columns []string) {
+	records, err := handler.action.GetResourcePage(w, r)
+	if err != nil {
+		problem.Render(r.Context(), w, err)
+		return
+	}
+
+	rows := make([]interface{}, 0, len(records))
+	csvRecords := make([][]string, 0, len(records))
+	for _, record := range records {
+		fields, err := resourceadapter.PopulateRecordFields(record)
+		if err != nil {
+			problem.Render(r.Context(), w, err)
+			return
+		}
+		if columns == nil {
+			rows = append(rows, fields)
+			continue
+		}
+		csvRecord, err := resourceadapter.CSVRecord(fields, columns)
+		if err != nil {
+			problem.Render(r.Context(), w, err)
+			return
+		}
+		csvRecords = append(csvRecords, csvRecord)
+	}
+
+	if columns == nil {
+		ndjson.Render(r.Context(), w, rows)
+	} else {
+		csv.Render(r.Context(), w, columns, csvRecords)
+	}
+}
+
 func buildPage(ledgerState *ledger.State, r *http.Request, records []hal.Pageable) (hal.Page, error) {
 	// Always DisableCursorValidation - we can assume it's valid since the
 	// validation is done in GetResourcePage.
@@ -337,3 +475,24 @@ func WrapRaw(next http.Handler, action rawAction) http.Handler {
 		}
 	})
 }
+
+type csvAction interface {
+	GetCSVRecords(r *http.Request) ([]string, [][]string, error)
+}
+
+// WrapCSV renders the records of action as CSV when the request accepts
+// text/csv and serves the request with next otherwise.
+func WrapCSV(next http.Handler, action csvAction) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if render.Negotiate(r) != render.MimeCSV {
+			next.ServeHTTP(w, r)
+			return
+		}
+		header, records, err := action.GetCSVRecords(r)
+		if err != nil {
+			problem.Render(r.Context(), w, err)
+			return
+		}
+		csv.Render(r.Context(), w, header, records)
+	})
+}
@@ -0,0 +1,68 @@
+package httpx
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/stellar/go-stellar-sdk/protocols/horizon"
+	"github.com/stellar/go-stellar-sdk/support/render/hal"
+	"github.com/stellar/stellar-horizon/internal/actions"
+)
+
+type testLedgersAction struct{}
+
+func (testLedgersAction) GetResourcePage(w actions.HeaderWriter, r *http.Request) ([]hal.Pageable, error) {
+	ledger := horizon.Ledger{ID: "abcd", PT: "8589934592", Sequence: 2, TotalCoins: "100.0000000"}
+	ledger.Links.Self = hal.NewLink("/ledgers/2")
+	return []hal.Pageable{ledger}, nil
+}
+
+type testCSVLedgersAction struct {
+	testLedgersAction
+}
+
+func (testCSVLedgersAction) CSVColumns() []string {
+	return []string{"paging_token", "sequence", "total_coins", "fee_pool"}
+}
+
+func TestPageActionHandlerRecords(t *testing.T) {
+	for _, tc := range []struct {
+		action      pageAction
+		accept      string
+		status      int
+		contentType string
+		body        string
+	}{
+		{
+			testCSVLedgersAction{}, "text/csv", http.StatusOK, "text/csv; charset=utf-8",
+			"paging_token,sequence,total_coins,fee_pool\n8589934592,2,100.0000000,\n",
+		},
+		{
+			testLedgersAction{}, "application/x-ndjson", http.StatusOK, "application/x-ndjson; charset=utf-8",
+			`{"base_fee_in_stroops":0,"base_reserve_in_stroops":0,"closed_at":"0001-01-01T00:00:00Z",` +
+				`"failed_transaction_count":null,"fee_pool":"","hash":"","header_xdr":"","id":"abcd",` +
+				`"max_tx_set_size":0,"operation_count":0,"paging_token":"8589934592","protocol_version":0,` +
+				`"sequence":2,"successful_transaction_count":0,"total_coins":"100.0000000",` +
+				`"tx_set_operation_count":null}` + "\n",
+		},
+		// pages of the actions without CSV columns can't be rendered as CSV
+		{testLedgersAction{}, "text/csv", http.StatusNotAcceptable, "application/problem+json; charset=utf-8", ""},
+	} {
+		t.Run(tc.accept, func(t *testing.T) {
+			r := httptest.NewRequest(http.MethodGet, "/ledgers", nil)
+			r.Header.Set("Accept", tc.accept)
+			w := httptest.NewRecorder()
+
+			restPageHandler(nil, tc.action).ServeHTTP(w, r)
+
+			assert.Equal(t, tc.status, w.Code)
+			assert.Equal(t, tc.contentType, w.Header().Get("Content-Type"))
+			if tc.body != "" {
+				assert.Equal(t, tc.body, w.Body.String())
+			}
+		})
+	}
+}
@@ -51,14 +51,20 @@ type RouterConfig struct {
 	MaxPathLength           uint
 	MaxAssetsPerPathRequest int
 	PathFinder              paths.Finder
+	QuoteFinder             paths.QuoteFinder
 	PrometheusRegistry      *prometheus.Registry
 	CoreGetter              actions.CoreStateGetter
 	HorizonVersion          string
 	FriendbotURL            *url.URL
 	HealthCheck             http.Handler
 	DisableTxSub            bool
-	SkipTxMeta              bool
-	StellarCoreURL          string
+	MaxTransactionBatchSize int
+	// MaxTrackedAsyncSubmissions is the number of the latest transactions
+	// submitted to /transactions_async whose status is tracked for
+	// /transactions_async/{tx_id}, by this instance only.
+	MaxTrackedAsyncSubmissions int
+	SkipTxMeta                 bool
+	StellarCoreURL             string
 }
 
 type Router struct {
@@ -189,6 +195,7 @@ func (r *Router) addRoutes(config *RouterConfig, rateLimiter *throttled.HTTPRate
 					accountData,
 				))
 				r.With(stateMiddleware.Wrap).Method(http.MethodGet, "/offers", streamableStatePageHandler(ledgerState, actions.GetAccountOffersHandler{LedgerState: ledgerState}, streamHandler))
+				r.With(stateMiddleware.Wrap).Method(http.MethodGet, "/contract_balances", restPageHandler(ledgerState, actions.GetAccountContractBalancesHandler{LedgerState: ledgerState}))
 			})
 		})
 
@@ -209,15 +216,31 @@ func (r *Router) addRoutes(config *RouterConfig, rateLimiter *throttled.HTTPRate
 				r.With(historyMiddleware).Method(http.MethodGet, "/transactions", streamableHistoryPageHandler(ledgerState, actions.GetTransactionsHandler{LedgerState: ledgerState, SkipTxMeta: config.SkipTxMeta}, streamHandler))
 				r.With(historyMiddleware).Method(http.MethodGet, "/effects", streamableHistoryPageHandler(ledgerState, actions.GetEffectsHandler{LedgerState: ledgerState}, streamHandler))
 				r.With(historyMiddleware).Method(http.MethodGet, "/trades", streamableHistoryPageHandler(ledgerState, actions.GetTradesHandler{LedgerState: ledgerState, CoreStateGetter: config.CoreGetter}, streamHandler))
+				r.With(historyMiddleware).Method(http.MethodGet, "/history", restPageHandler(ledgerState, actions.GetLiquidityPoolHistoryHandler{LedgerState: ledgerState}))
 			})
 		})
 
+		r.Route("/contracts/{contract_id:\\w+}", func(r chi.Router) {
+			r.With(stateMiddleware.Wrap).Method(http.MethodGet, "/", ObjectActionHandler{actions.GetContractByIDHandler{}})
+			r.With(stateMiddleware.Wrap).Method(http.MethodGet, "/data", restPageHandler(ledgerState, actions.GetContractDataHandler{LedgerState: ledgerState}))
+			r.With(stateMiddleware.Wrap).Method(http.MethodGet, "/balances", restPageHandler(ledgerState, actions.GetContractBalancesHandler{LedgerState: ledgerState}))
+			r.With(historyMiddleware).Method(http.MethodGet, "/events", streamableHistoryPageHandler(ledgerState, actions.GetContractEventsHandler{LedgerState: ledgerState}, streamHandler))
+			r.With(historyMiddleware).Method(http.MethodGet, "/operations", streamableHistoryPageHandler(ledgerState, actions.GetOperationsHandler{
+				LedgerState:  ledgerState,
+				OnlyPayments: false,
+				SkipTxMeta:   config.SkipTxMeta,
+			}, streamHandler))
+			r.With(historyMiddleware).Method(http.MethodGet, "/transactions", streamableHistoryPageHandler(ledgerState, actions.GetTransactionsHandler{LedgerState: ledgerState, SkipTxMeta: config.SkipTxMeta}, streamHandler))
+		})
+
 		r.Route("/offers", func(r chi.Router) {
 			r.With(stateMiddleware.Wrap).Method(http.MethodGet, "/", restPageHandler(ledgerState, actions.GetOffersHandler{LedgerState: ledgerState}))
 			r.With(stateMiddleware.Wrap).Method(http.MethodGet, "/{offer_id}", ObjectActionHandler{actions.GetOfferByID{}})
 		})
 
 		r.With(stateMiddleware.Wrap).Method(http.MethodGet, "/assets", restPageHandler(ledgerState, actions.AssetStatsHandler{LedgerState: ledgerState}))
+		r.With(historyMiddleware).Method(http.MethodGet, "/assets/{asset}/history", restPageHandler(ledgerState, actions.AssetHistoryHandler{LedgerState: ledgerState}))
+		r.With(stateMiddleware.Wrap).Method(http.MethodGet, "/assets/{asset}/holders", restPageHandler(ledgerState, actions.AssetHoldersHandler{LedgerState: ledgerState}))
 
 		if config.PathFinder != nil {
 			findPaths := ObjectActionHandler{actions.FindPathsHandler{
@@ -261,6 +284,9 @@ func (r *Router) addRoutes(config *RouterConfig, rateLimiter *throttled.HTTPRate
 			LedgerState:  ledgerState,
 			OnlyPayments: true,
 		}, streamHandler))
+		r.With(historyMiddleware).Method(http.MethodGet, "/accounts/{account_id:\\w+}/balance_changes", streamableHistoryPageHandler(ledgerState, actions.GetBalanceChangesHandler{LedgerState: ledgerState}, streamHandler))
+		accountStatement := actions.GetAccountStatementHandler{}
+		r.With(historyMiddleware).Method(http.MethodGet, "/accounts/{account_id:\\w+}/statement", WrapCSV(ObjectActionHandler{accountStatement}, accountStatement))
 		r.With(historyMiddleware).Method(http.MethodGet, "/accounts/{account_id:\\w+}/trades", streamableHistoryPageHandler(ledgerState, actions.GetTradesHandler{LedgerState: ledgerState, CoreStateGetter: config.CoreGetter}, streamHandler))
 		r.With(historyMiddleware).Method(http.MethodGet, "/accounts/{account_id:\\w+}/transactions", streamableHistoryPageHandler(ledgerState, actions.GetTransactionsHandler{LedgerState: ledgerState, SkipTxMeta: config.SkipTxMeta}, streamHandler))
 	})
@@ -301,6 +327,7 @@ func (r *Router) addRoutes(config *RouterConfig, rateLimiter *throttled.HTTPRate
 		r.Route("/{tx_id}", func(r chi.Router) {
 			r.With(historyMiddleware).Method(http.MethodGet, "/", ObjectActionHandler{actions.GetTransactionByHashHandler{SkipTxMeta: config.SkipTxMeta}})
 			r.With(historyMiddleware).Method(http.MethodGet, "/effects", streamableHistoryPageHandler(ledgerState, actions.GetEffectsHandler{LedgerState: ledgerState}, streamHandler))
+			r.With(historyMiddleware).Method(http.MethodGet, "/events", streamableHistoryPageHandler(ledgerState, actions.GetContractEventsHandler{LedgerState: ledgerState}, streamHandler))
 			r.With(historyMiddleware).Method(http.MethodGet, "/operations", streamableHistoryPageHandler(ledgerState, actions.GetOperationsHandler{
 				LedgerState:  ledgerState,
 				OnlyPayments: false,
@@ -334,26 +361,42 @@ func (r *Router) addRoutes(config *RouterConfig, rateLimiter *throttled.HTTPRate
 		// effect actions
 		r.With(historyMiddleware).Method(http.MethodGet, "/effects", streamableHistoryPageHandler(ledgerState, actions.GetEffectsHandler{LedgerState: ledgerState}, streamHandler))
 
+		// contract event actions
+		r.With(historyMiddleware).Method(http.MethodGet, "/contract_events", streamableHistoryPageHandler(ledgerState, actions.GetContractEventsHandler{LedgerState: ledgerState}, streamHandler))
+
 		// trading related endpoints
 		r.With(historyMiddleware).Method(http.MethodGet, "/trades", streamableHistoryPageHandler(ledgerState, actions.GetTradesHandler{LedgerState: ledgerState, CoreStateGetter: config.CoreGetter}, streamHandler))
-		r.With(historyMiddleware).Method(http.MethodGet, "/trade_aggregations", ObjectActionHandler{actions.GetTradeAggregationsHandler{LedgerState: ledgerState, CoreStateGetter: config.CoreGetter}})
+		r.With(historyMiddleware).Method(http.MethodGet, "/trade_aggregations", streamableTradeAggregationsHandler{
+			action:        actions.GetTradeAggregationsHandler{LedgerState: ledgerState, CoreStateGetter: config.CoreGetter},
+			streamHandler: streamHandler,
+			ledgerState:   ledgerState,
+		})
+		r.With(historyMiddleware).Method(http.MethodGet, "/markets", restPageHandler(ledgerState, actions.GetMarketsHandler{LedgerState: ledgerState, QuoteFinder: config.QuoteFinder}))
+		r.With(historyMiddleware).Method(http.MethodGet, "/markets/{base_asset}/{counter_asset}", ObjectActionHandler{actions.GetMarketHandler{LedgerState: ledgerState, QuoteFinder: config.QuoteFinder}})
 		// /offers/{offer_id} has been created above so we need to use absolute
 		// routes here.
 		r.With(historyMiddleware).Method(http.MethodGet, "/offers/{offer_id}/trades", streamableHistoryPageHandler(ledgerState, actions.GetTradesHandler{LedgerState: ledgerState, CoreStateGetter: config.CoreGetter}, streamHandler))
 	})
 
 	// Transaction submission API
-	r.Method(http.MethodPost, "/transactions", ObjectActionHandler{actions.SubmitTransactionHandler{
+	submitTransactionHandler := actions.SubmitTransactionHandler{
 		Submitter:         config.TxSubmitter,
 		NetworkPassphrase: config.NetworkPassphrase,
 		DisableTxSub:      config.DisableTxSub,
 		CoreStateGetter:   config.CoreGetter,
 		SkipTxMeta:        config.SkipTxMeta,
 		DecodeOptions:     xdr.DecodeOptions{MaxMemoryBytes: transactionDecodeMaxMemory},
+	}
+	r.Method(http.MethodPost, "/transactions", ObjectActionHandler{submitTransactionHandler})
+
+	r.Method(http.MethodPost, "/transactions/batch", ObjectActionHandler{actions.BatchSubmitTransactionHandler{
+		SubmitTransactionHandler: submitTransactionHandler,
+		MaxBatchSize:             config.MaxTransactionBatchSize,
 	}})
 
 	// Async Transaction submission API
-	r.Method(http.MethodPost, "/transactions_async", ObjectActionHandler{actions.AsyncSubmitTransactionHandler{
+	asyncSubmissions := txsub.NewAsyncSubmissions(config.MaxTrackedAsyncSubmissions)
+	asyncSubmitTransactionHandler := actions.AsyncSubmitTransactionHandler{
 		NetworkPassphrase: config.NetworkPassphrase,
 		DisableTxSub:      config.DisableTxSub,
 		CoreStateGetter:   config.CoreGetter,
@@ -362,6 +405,27 @@ func (r *Router) addRoutes(config *RouterConfig, rateLimiter *throttled.HTTPRate
 			URL:  config.StellarCoreURL,
 		}, config.PrometheusRegistry, "async_txsub"),
 		DecodeOptions: xdr.DecodeOptions{MaxMemoryBytes: transactionDecodeMaxMemory},
+		Submissions:   asyncSubmissions,
+	}
+	r.Method(http.MethodPost, "/transactions_async", ObjectActionHandler{asyncSubmitTransactionHandler})
+	r.Method(http.MethodPost, "/transactions_async/batch", ObjectActionHandler{actions.AsyncBatchSubmitTransactionHandler{
+		AsyncSubmitTransactionHandler: asyncSubmitTransactionHandler,
+		MaxBatchSize:                  config.MaxTransactionBatchSize,
+	}})
+	// the status of a transaction is streamed once, when it's final
+	r.With(historyMiddleware).Method(http.MethodGet, "/transactions_async/{tx_id}", streamableObjectActionHandler{
+		streamHandler: streamHandler,
+		action: actions.GetAsyncTransactionStatusHandler{
+			LedgerState: ledgerState,
+			Submissions: asyncSubmissions,
+		},
+		limit: 1,
+	})
+
+	// Transaction simulation API
+	r.With(stateMiddleware.Wrap).Method(http.MethodPost, "/transactions/simulate", ObjectActionHandler{actions.SimulateTransactionHandler{
+		NetworkPassphrase: config.NetworkPassphrase,
+		DecodeOptions:     xdr.DecodeOptions{MaxMemoryBytes: transactionDecodeMaxMemory},
 	}})
 
 	// Network state related endpoints
@@ -407,6 +471,18 @@ func (r *Router) addRoutes(config *RouterConfig, rateLimiter *throttled.HTTPRate
 		r.With(historyMiddleware).Put("/account", handler.UpdateAccountConfig)
 		r.With(historyMiddleware).Get("/asset", handler.GetAssetConfig)
 		r.With(historyMiddleware).Get("/account", handler.GetAccountConfig)
+		r.With(historyMiddleware).Put("/contract", handler.UpdateContractConfig)
+		r.With(historyMiddleware).Get("/contract", handler.GetContractConfig)
+		r.With(historyMiddleware).Put("/operation_type", handler.UpdateOperationTypeConfig)
+		r.With(historyMiddleware).Get("/operation_type", handler.GetOperationTypeConfig)
+	})
+	r.Internal.Route("/subscriptions", func(r chi.Router) {
+		handler := actions.SubscriptionsHandler{LedgerState: ledgerState}
+		r.With(historyMiddleware).Post("/", handler.CreateSubscription)
+		r.With(historyMiddleware).Get("/", handler.GetSubscriptions)
+		r.With(historyMiddleware).Get("/{id}", handler.GetSubscription)
+		r.With(historyMiddleware).Delete("/{id}", handler.DeleteSubscription)
+		r.With(historyMiddleware).Get("/{id}/deliveries", handler.GetSubscriptionDeliveries)
 	})
 }
 
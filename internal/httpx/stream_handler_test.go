@@ -15,6 +15,7 @@ import (
 	"github.com/go-chi/chi"
 	"github.com/stretchr/testify/mock"
 
+	"github.com/stellar/go-stellar-sdk/protocols/horizon"
 	"github.com/stellar/go-stellar-sdk/support/db"
 	"github.com/stellar/go-stellar-sdk/support/render/hal"
 	"github.com/stellar/stellar-horizon/internal/actions"
@@ -548,3 +549,94 @@ func TestRepeatableReadStream(t *testing.T) {
 		session.AssertExpectations(t)
 	})
 }
+
+type testTradeAggregationsAction struct {
+	updates      map[uint32][]actions.StreamedTradeAggregation
+	ledgerSource ledger.Source
+}
+
+func (action *testTradeAggregationsAction) GetResource(
+	w actions.HeaderWriter,
+	r *http.Request,
+) (interface{}, error) {
+	return nil, fmt.Errorf("unexpected call")
+}
+
+func (action *testTradeAggregationsAction) NewStream(r *http.Request) (*actions.TradeAggregationsStream, error) {
+	return &actions.TradeAggregationsStream{}, nil
+}
+
+func (action *testTradeAggregationsAction) GetStreamResources(
+	r *http.Request,
+	stream *actions.TradeAggregationsStream,
+) ([]actions.StreamedTradeAggregation, error) {
+	ledger := action.ledgerSource.CurrentLedger()
+	updates, ok := action.updates[ledger]
+	if !ok {
+		return nil, fmt.Errorf("unexpected ledger: %v", ledger)
+	}
+	return updates, nil
+}
+
+func streamedTradeAggregation(timestamp, count int64, closed bool) actions.StreamedTradeAggregation {
+	return actions.StreamedTradeAggregation{
+		TradeAggregation: horizon.TradeAggregation{Timestamp: timestamp, TradeCount: count},
+		Closed:           closed,
+	}
+}
+
+func TestTradeAggregationsStream(t *testing.T) {
+	ledgerSource := ledger.NewTestingSource(3)
+	action := &testTradeAggregationsAction{
+		ledgerSource: ledgerSource,
+		updates: map[uint32][]actions.StreamedTradeAggregation{
+			3: {streamedTradeAggregation(60000, 1, false)},
+			4: {streamedTradeAggregation(60000, 2, false)},
+			5: {},
+			6: {
+				streamedTradeAggregation(60000, 2, true),
+				streamedTradeAggregation(120000, 1, false),
+			},
+		},
+	}
+	handler := streamableTradeAggregationsHandler{
+		action:        action,
+		streamHandler: sse.StreamHandler{LedgerSourceFactory: &testingFactory{ledgerSource}},
+		ledgerState:   &ledger.State{},
+	}
+
+	st := newStreamTest(
+		handler.renderStream,
+		ledgerSource,
+		streamRequest(t, "limit=4"),
+		func(w *httptest.ResponseRecorder) {
+			var id string
+			var events []string
+			for _, line := range strings.Split(w.Body.String(), "\n") {
+				if strings.HasPrefix(line, "id: ") {
+					id = line[len("id: "):]
+				}
+				if !strings.HasPrefix(line, "data: {") {
+					continue
+				}
+				var aggregation horizon.TradeAggregation
+				if err := json.Unmarshal([]byte(line[len("data: "):]), &aggregation); err != nil {
+					t.Fatalf("could not parse json %v", err)
+				}
+				events = append(events, fmt.Sprintf("%s/%d/%d", id, aggregation.Timestamp, aggregation.TradeCount))
+				id = ""
+			}
+
+			// only closed buckets have an id
+			expected := []string{"/60000/1", "/60000/2", "60000/60000/2", "/120000/1"}
+			if strings.Join(expected, ",") != strings.Join(events, ",") {
+				t.Fatalf("expected %v but got %v", expected, events)
+			}
+		},
+	)
+
+	st.AddLedger(4)
+	st.AddLedger(5)
+	st.AddLedger(6)
+	st.Wait()
+}
@@ -0,0 +1,201 @@
+package ingest
+
+import (
+	"time"
+
+	"github.com/stellar/go-stellar-sdk/ingest/ledgerbackend"
+	"github.com/stellar/go-stellar-sdk/support/errors"
+	logpkg "github.com/stellar/go-stellar-sdk/support/log"
+	"github.com/stellar/stellar-horizon/internal/db2/history"
+)
+
+// FilterEvaluation compares the transactions stored in the history tables with
+// the transactions passing the current ingestion filters in a ledger range.
+type FilterEvaluation struct {
+	LedgerRange history.LedgerRange
+	// TransactionsStored is the number of transactions currently stored in the range.
+	TransactionsStored int
+	// TransactionsToRemove is the number of stored transactions which don't
+	// pass the current filters anymore.
+	TransactionsToRemove int
+	// TransactionsToAdd is the number of transactions which pass the current
+	// filters but are not stored.
+	TransactionsToAdd int
+	// LedgersNotIngested is the number of ledgers at the end of the range
+	// which are not evaluated because they are not ingested yet, they are
+	// filtered with the current filters when ingested.
+	LedgersNotIngested uint32
+}
+
+// Changed returns true if the history of the range doesn't match the current
+// filters and the range needs to be reingested.
+func (e FilterEvaluation) Changed() bool {
+	return e.TransactionsToRemove > 0 || e.TransactionsToAdd > 0
+}
+
+// ApplyFiltersSummary summarizes the evaluation of the ingestion filters over
+// the ledger ranges passed to ParallelSystems.ApplyFilters.
+type ApplyFiltersSummary struct {
+	// Ledgers is the number of ledgers evaluated.
+	Ledgers              uint32
+	TransactionsStored   int
+	TransactionsToRemove int
+	TransactionsToAdd    int
+	// LedgersNotIngested is the number of ledgers of the ranges which are
+	// after the last ingested ledger and are left to the ingestion.
+	LedgersNotIngested uint32
+	// ChangedRanges are the ranges whose history doesn't match the current
+	// filters, they are reingested unless running in dry-run mode.
+	ChangedRanges []history.LedgerRange
+}
+
+func (s *ApplyFiltersSummary) add(evaluation FilterEvaluation) {
+	evaluated := evaluation.LedgerRange
+	evaluated.EndSequence -= evaluation.LedgersNotIngested
+	s.Ledgers += evaluation.LedgerRange.EndSequence - evaluation.LedgerRange.StartSequence + 1 - evaluation.LedgersNotIngested
+	s.TransactionsStored += evaluation.TransactionsStored
+	s.TransactionsToRemove += evaluation.TransactionsToRemove
+	s.TransactionsToAdd += evaluation.TransactionsToAdd
+	s.LedgersNotIngested += evaluation.LedgersNotIngested
+	if !evaluation.Changed() {
+		return
+	}
+	// merge adjacent ranges, evaluations are added in ledger order
+	if last := len(s.ChangedRanges) - 1; last >= 0 &&
+		s.ChangedRanges[last].EndSequence+1 == evaluated.StartSequence {
+		s.ChangedRanges[last].EndSequence = evaluated.EndSequence
+		return
+	}
+	s.ChangedRanges = append(s.ChangedRanges, evaluated)
+}
+
+// EvaluateFilters runs the current ingestion filters on the transactions of
+// the ledger range and compares the result with the stored history, without
+// modifying it. The ledgers after the last ingested ledger are not evaluated.
+func (s *system) EvaluateFilters(ledgerRange history.LedgerRange) (FilterEvaluation, error) {
+	evaluation := FilterEvaluation{LedgerRange: ledgerRange}
+	if err := validateRanges([]history.LedgerRange{ledgerRange}); err != nil {
+		return evaluation, err
+	}
+
+	lastIngestedLedger, err := s.historyQ.GetLastLedgerIngestNonBlocking(s.ctx)
+	if err != nil {
+		return evaluation, errors.Wrap(err, getLastIngestedErrMsg)
+	}
+
+	fromLedger, toLedger := ledgerRange.StartSequence, ledgerRange.EndSequence
+	if lastIngestedLedger > 0 && toLedger > lastIngestedLedger {
+		// reingesting these ledgers would conflict with the ingestion, which
+		// applies the current filters to them anyway
+		toLedger = max(lastIngestedLedger, fromLedger-1)
+		evaluation.LedgersNotIngested = ledgerRange.EndSequence - toLedger
+	}
+	if fromLedger == 1 {
+		// ledger 1 is pregenerated and has no transactions
+		fromLedger = 2
+	}
+	if fromLedger > toLedger {
+		return evaluation, nil
+	}
+
+	stored, err := s.historyQ.TransactionHashesInLedgerRange(s.ctx, fromLedger, toLedger)
+	if err != nil {
+		return evaluation, errors.Wrap(err, "error getting stored transactions")
+	}
+	evaluation.TransactionsStored = len(stored)
+	storedSet := make(map[string]struct{}, len(stored))
+	for _, hash := range stored {
+		storedSet[hash] = struct{}{}
+	}
+
+	err = s.ledgerBackend.PrepareRange(s.ctx, ledgerbackend.BoundedRange(fromLedger, toLedger))
+	if err != nil {
+		return evaluation, errors.Wrap(err, "error preparing range")
+	}
+
+	startTime := time.Now()
+	for cur := fromLedger; cur <= toLedger; cur++ {
+		ledgerCloseMeta, err := s.ledgerBackend.GetLedger(s.ctx, cur)
+		if err != nil {
+			return evaluation, errors.Wrap(err, "error getting ledger")
+		}
+
+		included, err := s.runner.RunTransactionFilterersOnLedger(ledgerCloseMeta)
+		if err != nil {
+			return evaluation, errors.Wrapf(err, "error filtering ledger %d", cur)
+		}
+
+		for hash, include := range included {
+			_, isStored := storedSet[hash]
+			switch {
+			case include && !isStored:
+				evaluation.TransactionsToAdd++
+			case !include && isStored:
+				evaluation.TransactionsToRemove++
+			}
+		}
+	}
+
+	log.WithFields(logpkg.F{
+		"from":     ledgerRange.StartSequence,
+		"to":       ledgerRange.EndSequence,
+		"stored":   evaluation.TransactionsStored,
+		"remove":   evaluation.TransactionsToRemove,
+		"add":      evaluation.TransactionsToAdd,
+		"duration": time.Since(startTime).Seconds(),
+	}).Info("Evaluated filters on range")
+
+	return evaluation, nil
+}
+
+// ApplyFilters reingests the ledger range with the current ingestion filters.
+// Like a forced reingestion it holds the ingestion lock, so the range can
+// include the ledgers recently ingested by a running instance, which waits for
+// it to finish. The history of the range is deleted and ingested again in a
+// single transaction, which is rolled back on errors so that a failure doesn't
+// leave a gap in the history.
+func (s *system) ApplyFilters(ledgerRange history.LedgerRange) error {
+	if err := validateRanges([]history.LedgerRange{ledgerRange}); err != nil {
+		return err
+	}
+
+	h := reingestHistoryRangeState{fromLedger: ledgerRange.StartSequence, toLedger: ledgerRange.EndSequence, force: true}
+	if h.fromLedger == 1 {
+		// ledger 1 is pregenerated and has no transactions
+		h.fromLedger = 2
+	}
+	if h.fromLedger > h.toLedger {
+		return nil
+	}
+
+	if _, err := h.prepareRange(s); err != nil {
+		return err
+	}
+
+	startTime := time.Now()
+	if err := s.historyQ.Begin(s.ctx); err != nil {
+		return errors.Wrap(err, "Error starting a transaction")
+	}
+	defer s.historyQ.Rollback()
+
+	// acquire distributed lock so no one else can perform ingestion operations.
+	if _, err := s.historyQ.GetLastLedgerIngest(s.ctx); err != nil {
+		return errors.Wrap(err, getLastIngestedErrMsg)
+	}
+
+	if err := h.ingestRange(s, h.fromLedger, h.toLedger, false); err != nil {
+		return err
+	}
+
+	if err := s.historyQ.Commit(); err != nil {
+		return errors.Wrap(err, commitErrMsg)
+	}
+
+	log.WithFields(logpkg.F{
+		"from":     h.fromLedger,
+		"to":       h.toLedger,
+		"duration": time.Since(startTime).Seconds(),
+	}).Info("Filters applied")
+
+	return nil
+}
@@ -0,0 +1,174 @@
+package ingest
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/stellar/go-stellar-sdk/ingest/ledgerbackend"
+	"github.com/stellar/go-stellar-sdk/toid"
+	"github.com/stellar/go-stellar-sdk/xdr"
+	"github.com/stellar/stellar-horizon/internal/db2/history"
+)
+
+func TestEvaluateFilters(t *testing.T) {
+	ctx := context.Background()
+	historyQ := &mockDBQ{}
+	ledgerBackend := &mockLedgerBackend{}
+	runner := &mockProcessorsRunner{}
+	s := &system{
+		ctx:           ctx,
+		historyQ:      historyQ,
+		ledgerBackend: ledgerBackend,
+		runner:        runner,
+	}
+
+	historyQ.On("GetLastLedgerIngestNonBlocking", ctx).Return(uint32(3), nil).Once()
+	historyQ.MockQTransactions.On("TransactionHashesInLedgerRange", ctx, uint32(2), uint32(3)).
+		Return([]string{"stored-included", "stored-excluded"}, nil).Once()
+	ledgerBackend.On("PrepareRange", ctx, ledgerbackend.BoundedRange(2, 3)).Return(nil).Once()
+
+	for _, sequence := range []uint32{2, 3} {
+		meta := xdr.LedgerCloseMeta{
+			V0: &xdr.LedgerCloseMetaV0{
+				LedgerHeader: xdr.LedgerHeaderHistoryEntry{
+					Header: xdr.LedgerHeader{LedgerSeq: xdr.Uint32(sequence)},
+				},
+			},
+		}
+		ledgerBackend.On("GetLedger", ctx, sequence).Return(meta, nil).Once()
+		if sequence == 2 {
+			runner.On("RunTransactionFilterersOnLedger", meta).Return(map[string]bool{
+				"stored-included": true,
+				"new-included":    true,
+			}, nil).Once()
+		} else {
+			runner.On("RunTransactionFilterersOnLedger", meta).Return(map[string]bool{
+				"stored-excluded": false,
+				"new-excluded":    false,
+			}, nil).Once()
+		}
+	}
+
+	// ledger 4 is not ingested yet
+	evaluation, err := s.EvaluateFilters(history.LedgerRange{StartSequence: 1, EndSequence: 4})
+	assert.NoError(t, err)
+	assert.Equal(t, FilterEvaluation{
+		LedgerRange:          history.LedgerRange{StartSequence: 1, EndSequence: 4},
+		TransactionsStored:   2,
+		TransactionsToRemove: 1,
+		TransactionsToAdd:    1,
+		LedgersNotIngested:   1,
+	}, evaluation)
+	assert.True(t, evaluation.Changed())
+
+	historyQ.AssertExpectations(t)
+	ledgerBackend.AssertExpectations(t)
+	runner.AssertExpectations(t)
+}
+
+func TestEvaluateFiltersInvalidRange(t *testing.T) {
+	s := &system{ctx: context.Background()}
+	_, err := s.EvaluateFilters(history.LedgerRange{StartSequence: 10, EndSequence: 1})
+	assert.EqualError(t, err, "Invalid range: {10 1} from > to")
+}
+
+func TestEvaluateFiltersNotIngested(t *testing.T) {
+	ctx := context.Background()
+	historyQ := &mockDBQ{}
+	s := &system{ctx: ctx, historyQ: historyQ}
+
+	historyQ.On("GetLastLedgerIngestNonBlocking", ctx).Return(uint32(9), nil).Once()
+	evaluation, err := s.EvaluateFilters(history.LedgerRange{StartSequence: 10, EndSequence: 20})
+	assert.NoError(t, err)
+	assert.Equal(t, FilterEvaluation{
+		LedgerRange:        history.LedgerRange{StartSequence: 10, EndSequence: 20},
+		LedgersNotIngested: 11,
+	}, evaluation)
+	assert.False(t, evaluation.Changed())
+	historyQ.AssertExpectations(t)
+}
+
+func TestApplyFiltersSummary(t *testing.T) {
+	summary := ApplyFiltersSummary{}
+	summary.add(FilterEvaluation{LedgerRange: history.LedgerRange{StartSequence: 1, EndSequence: 64}, TransactionsToAdd: 1})
+	summary.add(FilterEvaluation{LedgerRange: history.LedgerRange{StartSequence: 65, EndSequence: 128}, TransactionsToRemove: 1, LedgersNotIngested: 14})
+	summary.add(FilterEvaluation{LedgerRange: history.LedgerRange{StartSequence: 129, EndSequence: 192}, LedgersNotIngested: 64})
+	assert.Equal(t, ApplyFiltersSummary{
+		Ledgers:              114,
+		TransactionsToRemove: 1,
+		TransactionsToAdd:    1,
+		LedgersNotIngested:   78,
+		ChangedRanges:        []history.LedgerRange{{StartSequence: 1, EndSequence: 114}},
+	}, summary)
+}
+
+func applyFiltersTestSystem() (*system, *mockDBQ, *mockLedgerBackend, *mockProcessorsRunner) {
+	ctx := context.Background()
+	historyQ := &mockDBQ{}
+	ledgerBackend := &mockLedgerBackend{}
+	runner := &mockProcessorsRunner{}
+	s := &system{
+		ctx:               ctx,
+		historyQ:          historyQ,
+		ledgerBackend:     ledgerBackend,
+		runner:            runner,
+		maxLedgerPerFlush: 1,
+	}
+
+	ledgerBackend.On("PrepareRange", ctx, ledgerbackend.BoundedRange(100, 102)).Return(nil).Once()
+	historyQ.On("Begin", ctx).Return(nil).Once()
+	historyQ.On("Rollback").Return(nil).Once()
+	// the ingestion is ahead of the range
+	historyQ.On("GetLastLedgerIngest", ctx).Return(uint32(110), nil).Once()
+	historyQ.On(
+		"DeleteRangeAll", ctx, toid.New(100, 0, 0).ToInt64(), toid.New(103, 0, 0).ToInt64(),
+	).Return(int64(10), nil).Once()
+	return s, historyQ, ledgerBackend, runner
+}
+
+func TestApplyFilters(t *testing.T) {
+	s, historyQ, ledgerBackend, runner := applyFiltersTestSystem()
+	for sequence := uint32(100); sequence <= 102; sequence++ {
+		meta := xdr.LedgerCloseMeta{
+			V0: &xdr.LedgerCloseMetaV0{
+				LedgerHeader: xdr.LedgerHeaderHistoryEntry{
+					Header: xdr.LedgerHeader{LedgerSeq: xdr.Uint32(sequence)},
+				},
+			},
+		}
+		ledgerBackend.On("GetLedger", s.ctx, sequence).Return(meta, nil).Once()
+		// the ledgers are ingested in the transaction holding the lock
+		runner.On("RunTransactionProcessorsOnLedgers", []xdr.LedgerCloseMeta{meta}, false).Return(nil).Once()
+	}
+	historyQ.On("Commit").Return(nil).Once()
+
+	assert.NoError(t, s.ApplyFilters(history.LedgerRange{StartSequence: 100, EndSequence: 102}))
+	historyQ.AssertExpectations(t)
+	ledgerBackend.AssertExpectations(t)
+	runner.AssertExpectations(t)
+}
+
+func TestApplyFiltersRollsBackOnError(t *testing.T) {
+	s, historyQ, ledgerBackend, runner := applyFiltersTestSystem()
+	meta := xdr.LedgerCloseMeta{
+		V0: &xdr.LedgerCloseMetaV0{
+			LedgerHeader: xdr.LedgerHeaderHistoryEntry{
+				Header: xdr.LedgerHeader{LedgerSeq: xdr.Uint32(100)},
+			},
+		},
+	}
+	ledgerBackend.On("GetLedger", s.ctx, uint32(100)).Return(meta, nil).Once()
+	runner.On("RunTransactionProcessorsOnLedgers", []xdr.LedgerCloseMeta{meta}, false).Return(nil).Once()
+	ledgerBackend.On("GetLedger", s.ctx, uint32(101)).Return(xdr.LedgerCloseMeta{}, errors.New("my error")).Once()
+
+	err := s.ApplyFilters(history.LedgerRange{StartSequence: 100, EndSequence: 102})
+	assert.EqualError(t, err, "error getting ledger: my error")
+	// the deletion is rolled back with the reingested ledgers
+	historyQ.AssertExpectations(t)
+	historyQ.AssertNotCalled(t, "Commit")
+	ledgerBackend.AssertExpectations(t)
+	runner.AssertExpectations(t)
+}
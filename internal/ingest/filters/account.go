@@ -11,18 +11,21 @@ import (
 
 type accountFilter struct {
 	whitelistedAccountsSet set.Set[string]
+	blacklistedAccountsSet set.Set[string]
 	lastModified           int64
 	enabled                bool
 }
 
 type AccountFilter interface {
 	processors.LedgerTransactionFilterer
+	processors.LedgerTransactionExcluder
 	RefreshAccountFilter(filterConfig *history.AccountFilterConfig) error
 }
 
 func NewAccountFilter() AccountFilter {
 	return &accountFilter{
 		whitelistedAccountsSet: set.Set[string]{},
+		blacklistedAccountsSet: set.Set[string]{},
 	}
 }
 
@@ -38,6 +41,7 @@ func (f *accountFilter) RefreshAccountFilter(filterConfig *history.AccountFilter
 
 		f.enabled = filterConfig.Enabled
 		f.whitelistedAccountsSet = listToSet(filterConfig.Whitelist)
+		f.blacklistedAccountsSet = listToSet(filterConfig.Blacklist)
 		f.lastModified = filterConfig.LastModified
 	}
 
@@ -64,7 +68,28 @@ func (f *accountFilter) FilterTransaction(ctx context.Context, transaction inges
 	return true, false, nil
 }
 
+// ExcludeTransaction returns true if any of the participants of the
+// transaction is blacklisted.
+func (f *accountFilter) ExcludeTransaction(ctx context.Context, transaction ingest.LedgerTransaction) (bool, error) {
+	if !f.enabled || len(f.blacklistedAccountsSet) == 0 {
+		return false, nil
+	}
+
+	participants, err := processors.ParticipantsForTransaction(0, transaction)
+	if err != nil {
+		return false, err
+	}
+
+	for _, p := range participants {
+		if f.blacklistedAccountsSet.Contains(p.Address()) {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
 func (f accountFilter) isEnabled() bool {
-	// filtering is disabled if the whitelist is empty for now, as that is the only filter rule
+	// whitelist filtering is disabled if the whitelist is empty, the blacklist
+	// is applied separately by ExcludeTransaction
 	return len(f.whitelistedAccountsSet) >= 1 && f.enabled
 }
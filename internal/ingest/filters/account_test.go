@@ -104,6 +104,64 @@ func TestAccountFilterDoesNotAllowWhenNoMatch(t *testing.T) {
 	tt.Equal(result, false)
 }
 
+func TestAccountFilterExcludesWhenBlacklisted(t *testing.T) {
+	tt := assert.New(t)
+	ctx := context.Background()
+
+	filterConfig := &history.AccountFilterConfig{
+		Whitelist:    []string{},
+		Blacklist:    []string{"GD6WNNTW664WH7FXC5RUMUTF7P5QSURC2IT36VOQEEGFZ4UWUEQGECAL"},
+		Enabled:      true,
+		LastModified: 1,
+	}
+
+	filter := NewAccountFilter()
+	err := filter.RefreshAccountFilter(filterConfig)
+	tt.NoError(err)
+
+	// an empty whitelist doesn't enable whitelist filtering
+	isEnabled, result, err := filter.FilterTransaction(ctx, getAccountTestTx(t,
+		"GD6WNNTW664WH7FXC5RUMUTF7P5QSURC2IT36VOQEEGFZ4UWUEQGECAL",
+		"GBRPYHIL2CI3FNQ4BXLFMNDLFJUNPU2HY3ZMFSHONUCEOASW7QC7OX2H"))
+	tt.NoError(err)
+	tt.Equal(isEnabled, false)
+	tt.Equal(result, true)
+
+	exclude, err := filter.ExcludeTransaction(ctx, getAccountTestTx(t,
+		"GD6WNNTW664WH7FXC5RUMUTF7P5QSURC2IT36VOQEEGFZ4UWUEQGECAL",
+		"GBRPYHIL2CI3FNQ4BXLFMNDLFJUNPU2HY3ZMFSHONUCEOASW7QC7OX2H"))
+	tt.NoError(err)
+	tt.Equal(exclude, true)
+
+	exclude, err = filter.ExcludeTransaction(ctx, getAccountTestTx(t,
+		"GBRPYHIL2CI3FNQ4BXLFMNDLFJUNPU2HY3ZMFSHONUCEOASW7QC7OX2H",
+		"GBRPYHIL2CI3FNQ4BXLFMNDLFJUNPU2HY3ZMFSHONUCEOASW7QC7OX2H"))
+	tt.NoError(err)
+	tt.Equal(exclude, false)
+}
+
+func TestAccountFilterDoesNotExcludeWhenDisabled(t *testing.T) {
+	tt := assert.New(t)
+	ctx := context.Background()
+
+	filterConfig := &history.AccountFilterConfig{
+		Whitelist:    []string{},
+		Blacklist:    []string{"GD6WNNTW664WH7FXC5RUMUTF7P5QSURC2IT36VOQEEGFZ4UWUEQGECAL"},
+		Enabled:      false,
+		LastModified: 1,
+	}
+
+	filter := NewAccountFilter()
+	err := filter.RefreshAccountFilter(filterConfig)
+	tt.NoError(err)
+
+	exclude, err := filter.ExcludeTransaction(ctx, getAccountTestTx(t,
+		"GD6WNNTW664WH7FXC5RUMUTF7P5QSURC2IT36VOQEEGFZ4UWUEQGECAL",
+		"GBRPYHIL2CI3FNQ4BXLFMNDLFJUNPU2HY3ZMFSHONUCEOASW7QC7OX2H"))
+	tt.NoError(err)
+	tt.Equal(exclude, false)
+}
+
 func getAccountTestTx(t *testing.T, accountId string, issuer string) ingest.LedgerTransaction {
 
 	var xdrAssetCode [12]byte
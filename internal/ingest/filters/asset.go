@@ -19,18 +19,21 @@ var (
 
 type assetFilter struct {
 	canonicalAssetsLookup set.Set[string]
+	blacklistedAssetsSet  set.Set[string]
 	lastModified          int64
 	enabled               bool
 }
 
 type AssetFilter interface {
 	processors.LedgerTransactionFilterer
+	processors.LedgerTransactionExcluder
 	RefreshAssetFilter(filterConfig *history.AssetFilterConfig) error
 }
 
 func NewAssetFilter() AssetFilter {
 	return &assetFilter{
 		canonicalAssetsLookup: set.Set[string]{},
+		blacklistedAssetsSet:  set.Set[string]{},
 	}
 }
 
@@ -45,6 +48,7 @@ func (f *assetFilter) RefreshAssetFilter(filterConfig *history.AssetFilterConfig
 		logger.Infof("New Asset Filter config detected, reloading new config %v ", *filterConfig)
 		f.enabled = filterConfig.Enabled
 		f.canonicalAssetsLookup = listToSet(filterConfig.Whitelist)
+		f.blacklistedAssetsSet = listToSet(filterConfig.Blacklist)
 		f.lastModified = filterConfig.LastModified
 	}
 
@@ -56,6 +60,29 @@ func (f *assetFilter) FilterTransaction(ctx context.Context, transaction ingest.
 		return false, true, nil
 	}
 
+	if assetMatcher(f.canonicalAssetsLookup).operationsMatched(transactionOperations(transaction)) {
+		return true, true, nil
+	}
+
+	logger.Debugf("No match, dropped tx with seq %v ", transaction.Envelope.SeqNum())
+	return true, false, nil
+}
+
+// ExcludeTransaction returns true if any of the operations of the
+// transaction refers to a blacklisted asset.
+func (f *assetFilter) ExcludeTransaction(ctx context.Context, transaction ingest.LedgerTransaction) (bool, error) {
+	if !f.enabled || len(f.blacklistedAssetsSet) == 0 {
+		return false, nil
+	}
+
+	if assetMatcher(f.blacklistedAssetsSet).operationsMatched(transactionOperations(transaction)) {
+		logger.Debugf("Blacklist match, dropped tx with seq %v ", transaction.Envelope.SeqNum())
+		return true, nil
+	}
+	return false, nil
+}
+
+func transactionOperations(transaction ingest.LedgerTransaction) []xdr.Operation {
 	var operations []xdr.Operation
 
 	if txv1, v1Exists := transaction.Envelope.GetV1(); v1Exists {
@@ -66,15 +93,19 @@ func (f *assetFilter) FilterTransaction(ctx context.Context, transaction ingest.
 		operations = txv0.Tx.Operations
 	}
 
-	if f.filterOperationsMatchedOnRules(operations) {
-		return true, true, nil
-	}
+	return operations
+}
 
-	logger.Debugf("No match, dropped tx with seq %v ", transaction.Envelope.SeqNum())
-	return true, false, nil
+// TransactionMatchesAssets returns true if any of the operations of the
+// transaction refers to one of the canonical assets.
+func TransactionMatchesAssets(transaction ingest.LedgerTransaction, assets set.Set[string]) bool {
+	return assetMatcher(assets).operationsMatched(transactionOperations(transaction))
 }
 
-func (f assetFilter) filterOperationsMatchedOnRules(operations []xdr.Operation) bool {
+// assetMatcher matches operations against a set of canonical assets.
+type assetMatcher set.Set[string]
+
+func (f assetMatcher) operationsMatched(operations []xdr.Operation) bool {
 	for _, operation := range operations {
 		switch operation.Body.Type {
 		case xdr.OperationTypeChangeTrust:
@@ -118,7 +149,7 @@ func (f assetFilter) filterOperationsMatchedOnRules(operations []xdr.Operation)
 	return false
 }
 
-func (f assetFilter) filterChangeTrustMatched(operation xdr.Operation) bool {
+func (f assetMatcher) filterChangeTrustMatched(operation xdr.Operation) bool {
 	if pool, ok := operation.Body.ChangeTrustOp.Line.GetLiquidityPool(); ok {
 		if f.assetMatchedFilter(&pool.ConstantProduct.AssetA) || f.assetMatchedFilter(&pool.ConstantProduct.AssetB) {
 			return true
@@ -132,8 +163,8 @@ func (f assetFilter) filterChangeTrustMatched(operation xdr.Operation) bool {
 	return false
 }
 
-func (f *assetFilter) assetMatchedFilter(asset *xdr.Asset) bool {
-	return f.canonicalAssetsLookup.Contains(asset.StringCanonical())
+func (f assetMatcher) assetMatchedFilter(asset *xdr.Asset) bool {
+	return set.Set[string](f).Contains(asset.StringCanonical())
 }
 
 func listToSet(list []string) set.Set[string] {
@@ -145,6 +176,7 @@ func listToSet(list []string) set.Set[string] {
 }
 
 func (f assetFilter) isEnabled() bool {
-	// filtering is disabled if the whitelist is empty for now as that is the only filter rule
+	// whitelist filtering is disabled if the whitelist is empty, the blacklist
+	// is applied separately by ExcludeTransaction
 	return len(f.canonicalAssetsLookup) >= 1 && f.enabled
 }
@@ -105,6 +105,33 @@ func TestAssetFilterDoesNotAllowV1WhenNoMatch(t *testing.T) {
 	tt.Equal(result, false)
 }
 
+func TestAssetFilterExcludesWhenBlacklisted(t *testing.T) {
+	tt := assert.New(t)
+	ctx := context.Background()
+
+	filterConfig := &history.AssetFilterConfig{
+		Whitelist:    []string{"USDC:GD6WNNTW664WH7FXC5RUMUTF7P5QSURC2IT36VOQEEGFZ4UWUEQGECAL"},
+		Blacklist:    []string{"USDC:GBRPYHIL2CI3FNQ4BXLFMNDLFJUNPU2HY3ZMFSHONUCEOASW7QC7OX2H"},
+		Enabled:      true,
+		LastModified: 1,
+	}
+	filter := NewAssetFilter()
+	err := filter.RefreshAssetFilter(filterConfig)
+	tt.NoError(err)
+
+	exclude, err := filter.ExcludeTransaction(ctx, getAssetTestV1Tx(t, "GBRPYHIL2CI3FNQ4BXLFMNDLFJUNPU2HY3ZMFSHONUCEOASW7QC7OX2H"))
+	tt.NoError(err)
+	tt.Equal(exclude, true)
+
+	exclude, err = filter.ExcludeTransaction(ctx, getAssetTestV0Tx(t, "GBRPYHIL2CI3FNQ4BXLFMNDLFJUNPU2HY3ZMFSHONUCEOASW7QC7OX2H"))
+	tt.NoError(err)
+	tt.Equal(exclude, true)
+
+	exclude, err = filter.ExcludeTransaction(ctx, getAssetTestV1Tx(t, "GD6WNNTW664WH7FXC5RUMUTF7P5QSURC2IT36VOQEEGFZ4UWUEQGECAL"))
+	tt.NoError(err)
+	tt.Equal(exclude, false)
+}
+
 func getAssetTestV1Tx(t *testing.T, issuer string) ingest.LedgerTransaction {
 	var xdrAssetCode [12]byte
 	var xdrIssuer xdr.AccountId
@@ -0,0 +1,123 @@
+package filters
+
+import (
+	"context"
+
+	"github.com/stellar/go-stellar-sdk/ingest"
+	"github.com/stellar/go-stellar-sdk/support/collections/set"
+	"github.com/stellar/go-stellar-sdk/xdr"
+	"github.com/stellar/stellar-horizon/internal/db2/history"
+	"github.com/stellar/stellar-horizon/internal/ingest/processors"
+)
+
+type contractFilter struct {
+	whitelistedContractsSet set.Set[string]
+	lastModified            int64
+	enabled                 bool
+}
+
+type ContractFilter interface {
+	processors.LedgerTransactionFilterer
+	RefreshContractFilter(filterConfig *history.ContractFilterConfig) error
+}
+
+func NewContractFilter() ContractFilter {
+	return &contractFilter{
+		whitelistedContractsSet: set.Set[string]{},
+	}
+}
+
+func (f *contractFilter) Name() string {
+	return "filters.contractFilter"
+}
+
+func (f *contractFilter) RefreshContractFilter(filterConfig *history.ContractFilterConfig) error {
+	// only need to re-initialize the filter config state(rules) if its cached version(in  memory)
+	// is older than the incoming config version based on lastModified epoch timestamp
+	if filterConfig.LastModified > f.lastModified {
+		logger.Infof("New Contract Filter config detected, reloading new config %v ", *filterConfig)
+
+		f.enabled = filterConfig.Enabled
+		f.whitelistedContractsSet = listToSet(filterConfig.Whitelist)
+		f.lastModified = filterConfig.LastModified
+	}
+
+	return nil
+}
+
+func (f *contractFilter) FilterTransaction(ctx context.Context, transaction ingest.LedgerTransaction) (bool, bool, error) {
+	if !f.isEnabled() {
+		return false, true, nil
+	}
+
+	if contractMatcher(f.whitelistedContractsSet).transactionMatched(transaction) {
+		return true, true, nil
+	}
+
+	logger.Debugf("No match, dropped tx with seq %v ", transaction.Envelope.SeqNum())
+	return true, false, nil
+}
+
+// TransactionInvokesContracts returns true if the transaction invokes one of
+// the contracts (strkey encoded contract addresses).
+func TransactionInvokesContracts(transaction ingest.LedgerTransaction, contracts set.Set[string]) bool {
+	return contractMatcher(contracts).transactionMatched(transaction)
+}
+
+// contractMatcher matches transactions against a set of contract addresses.
+type contractMatcher set.Set[string]
+
+func (f contractMatcher) transactionMatched(transaction ingest.LedgerTransaction) bool {
+	for _, operation := range transaction.Envelope.Operations() {
+		invokeOp, ok := operation.Body.GetInvokeHostFunctionOp()
+		if !ok {
+			continue
+		}
+
+		if invokeArgs, ok := invokeOp.HostFunction.GetInvokeContract(); ok {
+			if f.contractMatchedFilter(invokeArgs.ContractAddress) {
+				return true
+			}
+		}
+
+		// contracts invoked by the top level invocation are only known
+		// through the authorization entries of the operation
+		for _, auth := range invokeOp.Auth {
+			if f.invocationMatchedFilter(auth.RootInvocation) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func (f contractMatcher) invocationMatchedFilter(invocation xdr.SorobanAuthorizedInvocation) bool {
+	if contractFn, ok := invocation.Function.GetContractFn(); ok {
+		if f.contractMatchedFilter(contractFn.ContractAddress) {
+			return true
+		}
+	}
+	for _, subInvocation := range invocation.SubInvocations {
+		if f.invocationMatchedFilter(subInvocation) {
+			return true
+		}
+	}
+	return false
+}
+
+func (f contractMatcher) contractMatchedFilter(address xdr.ScAddress) bool {
+	if address.Type != xdr.ScAddressTypeScAddressTypeContract {
+		return false
+	}
+	contractID, err := address.String()
+	if err != nil {
+		return false
+	}
+	return set.Set[string](f).Contains(contractID)
+}
+
+func (f contractFilter) isEnabled() bool {
+	// filtering is disabled if no contract is whitelisted, the contract
+	// whitelist being the only rule of the contract filter
+	return len(f.whitelistedContractsSet) >= 1 && f.enabled
+}
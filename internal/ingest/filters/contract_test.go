@@ -0,0 +1,159 @@
+package filters
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/stellar/go-stellar-sdk/ingest"
+	"github.com/stellar/go-stellar-sdk/strkey"
+	"github.com/stellar/go-stellar-sdk/xdr"
+	"github.com/stellar/stellar-horizon/internal/db2/history"
+)
+
+var (
+	invokedContractID    = xdr.ContractId{1}
+	subInvokedContractID = xdr.ContractId{2}
+	otherContractID      = xdr.ContractId{3}
+)
+
+func TestContractFilterAllowsWhenInvokedContractMatches(t *testing.T) {
+	tt := assert.New(t)
+	ctx := context.Background()
+
+	filter := NewContractFilter()
+	tt.NoError(filter.RefreshContractFilter(&history.ContractFilterConfig{
+		Whitelist:    []string{contractAddress(invokedContractID)},
+		Enabled:      true,
+		LastModified: 1,
+	}))
+
+	isEnabled, result, err := filter.FilterTransaction(ctx, getContractTestTx())
+	tt.NoError(err)
+	tt.Equal(isEnabled, true)
+	tt.Equal(result, true)
+}
+
+func TestContractFilterAllowsWhenSubInvokedContractMatches(t *testing.T) {
+	tt := assert.New(t)
+	ctx := context.Background()
+
+	filter := NewContractFilter()
+	tt.NoError(filter.RefreshContractFilter(&history.ContractFilterConfig{
+		Whitelist:    []string{contractAddress(subInvokedContractID)},
+		Enabled:      true,
+		LastModified: 1,
+	}))
+
+	isEnabled, result, err := filter.FilterTransaction(ctx, getContractTestTx())
+	tt.NoError(err)
+	tt.Equal(isEnabled, true)
+	tt.Equal(result, true)
+}
+
+func TestContractFilterAllowsWhenDisabled(t *testing.T) {
+	tt := assert.New(t)
+	ctx := context.Background()
+
+	filter := NewContractFilter()
+	tt.NoError(filter.RefreshContractFilter(&history.ContractFilterConfig{
+		Whitelist:    []string{contractAddress(otherContractID)},
+		Enabled:      false,
+		LastModified: 1,
+	}))
+
+	isEnabled, result, err := filter.FilterTransaction(ctx, getContractTestTx())
+	tt.NoError(err)
+	tt.Equal(isEnabled, false)
+	tt.Equal(result, true)
+}
+
+func TestContractFilterDoesNotAllowWhenNoMatch(t *testing.T) {
+	tt := assert.New(t)
+	ctx := context.Background()
+
+	filter := NewContractFilter()
+	tt.NoError(filter.RefreshContractFilter(&history.ContractFilterConfig{
+		Whitelist:    []string{contractAddress(otherContractID)},
+		Enabled:      true,
+		LastModified: 1,
+	}))
+
+	isEnabled, result, err := filter.FilterTransaction(ctx, getContractTestTx())
+	tt.NoError(err)
+	tt.Equal(isEnabled, true)
+	tt.Equal(result, false)
+
+	// classic transactions never match the contract filter
+	isEnabled, result, err = filter.FilterTransaction(ctx, getAccountTestTx(t,
+		"GD6WNNTW664WH7FXC5RUMUTF7P5QSURC2IT36VOQEEGFZ4UWUEQGECAL",
+		"GBRPYHIL2CI3FNQ4BXLFMNDLFJUNPU2HY3ZMFSHONUCEOASW7QC7OX2H"))
+	tt.NoError(err)
+	tt.Equal(isEnabled, true)
+	tt.Equal(result, false)
+}
+
+func contractAddress(contractID xdr.ContractId) string {
+	return strkey.MustEncode(strkey.VersionByteContract, contractID[:])
+}
+
+func getContractTestTx() ingest.LedgerTransaction {
+	invoked, subInvoked := invokedContractID, subInvokedContractID
+	return ingest.LedgerTransaction{
+		Envelope: xdr.TransactionEnvelope{
+			Type: xdr.EnvelopeTypeEnvelopeTypeTx,
+			V1: &xdr.TransactionV1Envelope{
+				Tx: xdr.Transaction{
+					SourceAccount: xdr.MustMuxedAddress("GD6WNNTW664WH7FXC5RUMUTF7P5QSURC2IT36VOQEEGFZ4UWUEQGECAL"),
+					Operations: []xdr.Operation{
+						{Body: xdr.OperationBody{
+							Type: xdr.OperationTypeInvokeHostFunction,
+							InvokeHostFunctionOp: &xdr.InvokeHostFunctionOp{
+								HostFunction: xdr.HostFunction{
+									Type: xdr.HostFunctionTypeHostFunctionTypeInvokeContract,
+									InvokeContract: &xdr.InvokeContractArgs{
+										ContractAddress: xdr.ScAddress{
+											Type:       xdr.ScAddressTypeScAddressTypeContract,
+											ContractId: &invoked,
+										},
+										FunctionName: "swap",
+									},
+								},
+								Auth: []xdr.SorobanAuthorizationEntry{{
+									Credentials: xdr.SorobanCredentials{
+										Type: xdr.SorobanCredentialsTypeSorobanCredentialsSourceAccount,
+									},
+									RootInvocation: xdr.SorobanAuthorizedInvocation{
+										Function: xdr.SorobanAuthorizedFunction{
+											Type: xdr.SorobanAuthorizedFunctionTypeSorobanAuthorizedFunctionTypeContractFn,
+											ContractFn: &xdr.InvokeContractArgs{
+												ContractAddress: xdr.ScAddress{
+													Type:       xdr.ScAddressTypeScAddressTypeContract,
+													ContractId: &invoked,
+												},
+												FunctionName: "swap",
+											},
+										},
+										SubInvocations: []xdr.SorobanAuthorizedInvocation{{
+											Function: xdr.SorobanAuthorizedFunction{
+												Type: xdr.SorobanAuthorizedFunctionTypeSorobanAuthorizedFunctionTypeContractFn,
+												ContractFn: &xdr.InvokeContractArgs{
+													ContractAddress: xdr.ScAddress{
+														Type:       xdr.ScAddressTypeScAddressTypeContract,
+														ContractId: &subInvoked,
+													},
+													FunctionName: "transfer",
+												},
+											},
+										}},
+									},
+								}},
+							},
+						}},
+					},
+				},
+			},
+		},
+	}
+}
@@ -3,8 +3,12 @@ package filters
 import (
 	"context"
 	"sync"
+	"sync/atomic"
 	"time"
 
+	"github.com/lib/pq"
+
+	"github.com/stellar/go-stellar-sdk/support/errors"
 	"github.com/stellar/go-stellar-sdk/support/log"
 	"github.com/stellar/stellar-horizon/internal/db2/history"
 	"github.com/stellar/stellar-horizon/internal/ingest/processors"
@@ -12,22 +16,23 @@ import (
 
 var (
 
-	// the filter config cache will be checked against latest from db at most once per each of this interval.
+	// the filter config cache will be checked against latest from db at most once per each of this interval,
+	// unless a filter rules update is notified, see ListenForFilterRulesUpdates.
 	//lint:ignore ST1011, don't need the linter warn on literal assignment
-	filterConfigCheckIntervalSeconds     time.Duration = 100
-	filterConfigCheckIntervalSecondsLock sync.RWMutex
+	filterConfigCheckInterval     time.Duration = 100 * time.Second
+	filterConfigCheckIntervalLock sync.RWMutex
 )
 
-func GetFilterConfigCheckIntervalSeconds() time.Duration {
-	filterConfigCheckIntervalSecondsLock.RLock()
-	defer filterConfigCheckIntervalSecondsLock.RUnlock()
-	return filterConfigCheckIntervalSeconds
+func GetFilterConfigCheckInterval() time.Duration {
+	filterConfigCheckIntervalLock.RLock()
+	defer filterConfigCheckIntervalLock.RUnlock()
+	return filterConfigCheckInterval
 }
 
-func SetFilterConfigCheckIntervalSeconds(t time.Duration) {
-	filterConfigCheckIntervalSecondsLock.Lock()
-	defer filterConfigCheckIntervalSecondsLock.Unlock()
-	filterConfigCheckIntervalSeconds = t
+func SetFilterConfigCheckInterval(interval time.Duration) {
+	filterConfigCheckIntervalLock.Lock()
+	defer filterConfigCheckIntervalLock.Unlock()
+	filterConfigCheckInterval = interval
 }
 
 var (
@@ -39,29 +44,54 @@ var (
 type filtersCache struct {
 	assetFilter                    AssetFilter
 	accountFilter                  AccountFilter
+	contractFilter                 ContractFilter
+	operationTypeFilter            OperationTypeFilter
 	lastFilterConfigCheckUnixEpoch int64
+	reload                         atomic.Bool
+	// loadedVersions and recordedVersions are the last_modified of the filter
+	// rules loaded from db and of the ones recorded by RecordAppliedFromLedger.
+	loadedVersions   map[string]int64
+	recordedVersions map[string]int64
 }
 
 type Filters interface {
 	GetFilters(filterQ history.QFilter, ctx context.Context) []processors.LedgerTransactionFilterer
+	// RecordAppliedFromLedger records the ledger as the first one ingested with
+	// the loaded versions of the filter rules, for the versions not recorded yet.
+	RecordAppliedFromLedger(filterQ history.QFilter, ctx context.Context, ledgerSequence uint32) error
+	// Reload makes the next GetFilters call refresh the filter rules from db.
+	// Unlike the other methods it is safe to call from any goroutine.
+	Reload()
 }
 
 func NewFilters() Filters {
 	return &filtersCache{
-		assetFilter:   NewAssetFilter(),
-		accountFilter: NewAccountFilter(),
+		assetFilter:         NewAssetFilter(),
+		accountFilter:       NewAccountFilter(),
+		contractFilter:      NewContractFilter(),
+		operationTypeFilter: NewOperationTypeFilter(),
+		loadedVersions:      map[string]int64{},
+		recordedVersions:    map[string]int64{},
 	}
 }
 
+func (f *filtersCache) Reload() {
+	f.reload.Store(true)
+}
+
 // Provide list of the active filters. Optimize performance by caching the list, only
-// rebuild the list on expiration time interval. Method is NOT thread-safe.
+// rebuild the list on expiration time interval or after Reload. Method is NOT thread-safe.
 func (f *filtersCache) GetFilters(filterQ history.QFilter, ctx context.Context) []processors.LedgerTransactionFilterer {
 	// only attempt to refresh filter config cache state at configured interval limit
-	if time.Now().Unix() < (f.lastFilterConfigCheckUnixEpoch + int64(GetFilterConfigCheckIntervalSeconds().Seconds())) {
+	if !f.reload.Swap(false) &&
+		time.Now().Unix() < (f.lastFilterConfigCheckUnixEpoch+int64(GetFilterConfigCheckInterval().Seconds())) {
 		return f.convertCacheToList()
 	}
 
 	f.lastFilterConfigCheckUnixEpoch = time.Now().Unix()
+	// check again the recorded versions in case the db transaction in which
+	// they were recorded has been rolled back
+	clear(f.recordedVersions)
 
 	LOG.Info("expired filter config cache, refresh from db")
 
@@ -70,6 +100,8 @@ func (f *filtersCache) GetFilters(filterQ history.QFilter, ctx context.Context)
 	} else {
 		if err := f.assetFilter.RefreshAssetFilter(&filterConfig); err != nil {
 			LOG.Errorf("unable to refresh asset filter config %v", err)
+		} else {
+			f.loadedVersions[history.AssetFilterName] = filterConfig.LastModified
 		}
 	}
 
@@ -78,12 +110,103 @@ func (f *filtersCache) GetFilters(filterQ history.QFilter, ctx context.Context)
 	} else {
 		if err := f.accountFilter.RefreshAccountFilter(&filterConfig); err != nil {
 			LOG.Errorf("unable to refresh account filter config %v", err)
+		} else {
+			f.loadedVersions[history.AccountFilterName] = filterConfig.LastModified
+		}
+	}
+
+	if filterConfig, err := filterQ.GetContractFilterConfig(ctx); err != nil {
+		LOG.Errorf("unable to refresh contract filter config %v", err)
+	} else {
+		if err := f.contractFilter.RefreshContractFilter(&filterConfig); err != nil {
+			LOG.Errorf("unable to refresh contract filter config %v", err)
+		} else {
+			f.loadedVersions[history.ContractFilterName] = filterConfig.LastModified
+		}
+	}
+
+	if filterConfig, err := filterQ.GetOperationTypeFilterConfig(ctx); err != nil {
+		LOG.Errorf("unable to refresh operation type filter config %v", err)
+	} else {
+		if err := f.operationTypeFilter.RefreshOperationTypeFilter(&filterConfig); err != nil {
+			LOG.Errorf("unable to refresh operation type filter config %v", err)
+		} else {
+			f.loadedVersions[history.OperationTypeFilterName] = filterConfig.LastModified
 		}
 	}
 
 	return f.convertCacheToList()
 }
 
+// RecordAppliedFromLedger stores in db the ledger from which the loaded
+// versions of the filter rules are applied. A version already recorded, by
+// this or by another ingesting instance, is left untouched. Method is NOT
+// thread-safe.
+func (f *filtersCache) RecordAppliedFromLedger(filterQ history.QFilter, ctx context.Context, ledgerSequence uint32) error {
+	for filterName, version := range f.loadedVersions {
+		if recorded, ok := f.recordedVersions[filterName]; ok && recorded == version {
+			continue
+		}
+
+		appliedFrom, err := filterQ.GetFilterRulesAppliedFrom(ctx, filterName)
+		if err != nil {
+			return errors.Wrapf(err, "error getting %s filter rules applied from ledger", filterName)
+		}
+		if appliedFrom.LastModified != version {
+			appliedFrom = history.FilterRulesAppliedFrom{LastModified: version, Ledger: ledgerSequence}
+			if err := filterQ.UpdateFilterRulesAppliedFrom(ctx, filterName, appliedFrom); err != nil {
+				return errors.Wrapf(err, "error updating %s filter rules applied from ledger", filterName)
+			}
+			LOG.Infof("%s filter rules version %d applied from ledger %d", filterName, version, ledgerSequence)
+		}
+		f.recordedVersions[filterName] = version
+	}
+	return nil
+}
+
+// ListenForFilterRulesUpdates listens for the notifications published on
+// history.FilterRulesUpdatedChannel and reloads the filter rules on the next
+// GetFilters call after each of them, that is at the next ledger ingested,
+// until the context is done.
+func ListenForFilterRulesUpdates(ctx context.Context, databaseURL string, filters Filters) error {
+	listener := pq.NewListener(databaseURL, time.Second, time.Minute, func(event pq.ListenerEventType, err error) {
+		if err != nil {
+			LOG.WithError(err).Warn("filter rules updates listener error")
+		}
+	})
+	if err := listener.Listen(history.FilterRulesUpdatedChannel); err != nil {
+		listener.Close()
+		return errors.Wrap(err, "error listening for filter rules updates")
+	}
+
+	go func() {
+		defer listener.Close()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-time.After(90 * time.Second):
+				// make sure the connection is still alive when no
+				// notification has been received for a while
+				go listener.Ping()
+			case notification := <-listener.Notify:
+				// a nil notification is sent after the connection was
+				// re-established, updates may have been missed meanwhile
+				if notification != nil {
+					LOG.Infof("%s filter rules update notified", notification.Extra)
+				}
+				filters.Reload()
+			}
+		}
+	}()
+	return nil
+}
+
 func (f *filtersCache) convertCacheToList() []processors.LedgerTransactionFilterer {
-	return []processors.LedgerTransactionFilterer{f.assetFilter, f.accountFilter}
+	return []processors.LedgerTransactionFilterer{
+		f.assetFilter,
+		f.accountFilter,
+		f.contractFilter,
+		f.operationTypeFilter,
+	}
 }
@@ -1,8 +1,11 @@
 package filters
 
 import (
+	"context"
 	"testing"
 
+	"github.com/stretchr/testify/assert"
+
 	"github.com/stellar/stellar-horizon/internal/db2/history"
 	"github.com/stellar/stellar-horizon/internal/test"
 )
@@ -18,5 +21,54 @@ func TestItGetsFilters(t *testing.T) {
 	ingestFilters := filtersService.GetFilters(q, tt.Ctx)
 
 	// should be total of filters implemented in the system
-	tt.Assert.Len(ingestFilters, 2)
+	tt.Assert.Len(ingestFilters, 4)
+}
+
+func mockFilterConfigs(q *history.MockQFilter, ctx context.Context, lastModified int64) {
+	q.On("GetAssetFilterConfig", ctx).Return(history.AssetFilterConfig{LastModified: lastModified}, nil).Once()
+	q.On("GetAccountFilterConfig", ctx).Return(history.AccountFilterConfig{LastModified: lastModified}, nil).Once()
+	q.On("GetContractFilterConfig", ctx).Return(history.ContractFilterConfig{LastModified: lastModified}, nil).Once()
+	q.On("GetOperationTypeFilterConfig", ctx).Return(history.OperationTypeFilterConfig{LastModified: lastModified}, nil).Once()
+}
+
+func TestReloadRefreshesFiltersBeforeInterval(t *testing.T) {
+	ctx := context.Background()
+	q := &history.MockQFilter{}
+	filtersService := NewFilters()
+
+	mockFilterConfigs(q, ctx, 1)
+	filtersService.GetFilters(q, ctx)
+	// cached until the check interval expires
+	filtersService.GetFilters(q, ctx)
+
+	filtersService.Reload()
+	mockFilterConfigs(q, ctx, 2)
+	filtersService.GetFilters(q, ctx)
+	filtersService.GetFilters(q, ctx)
+
+	q.AssertExpectations(t)
+}
+
+func TestRecordAppliedFromLedger(t *testing.T) {
+	ctx := context.Background()
+	q := &history.MockQFilter{}
+	filtersService := NewFilters()
+
+	mockFilterConfigs(q, ctx, 10)
+	filtersService.GetFilters(q, ctx)
+
+	// the asset rules version was already recorded by another instance
+	q.On("GetFilterRulesAppliedFrom", ctx, history.AssetFilterName).
+		Return(history.FilterRulesAppliedFrom{LastModified: 10, Ledger: 99}, nil).Once()
+	for _, filterName := range []string{history.AccountFilterName, history.ContractFilterName, history.OperationTypeFilterName} {
+		q.On("GetFilterRulesAppliedFrom", ctx, filterName).
+			Return(history.FilterRulesAppliedFrom{LastModified: 5, Ledger: 50}, nil).Once()
+		q.On("UpdateFilterRulesAppliedFrom", ctx, filterName, history.FilterRulesAppliedFrom{LastModified: 10, Ledger: 100}).
+			Return(nil).Once()
+	}
+	assert.NoError(t, filtersService.RecordAppliedFromLedger(q, ctx, 100))
+	// recorded versions are not checked again
+	assert.NoError(t, filtersService.RecordAppliedFromLedger(q, ctx, 101))
+
+	q.AssertExpectations(t)
 }
@@ -0,0 +1,108 @@
+package filters
+
+import (
+	"context"
+	"encoding/base64"
+	"strconv"
+
+	"github.com/stellar/go-stellar-sdk/ingest"
+	"github.com/stellar/go-stellar-sdk/protocols/horizon/operations"
+	"github.com/stellar/go-stellar-sdk/support/collections/set"
+	"github.com/stellar/go-stellar-sdk/xdr"
+	"github.com/stellar/stellar-horizon/internal/db2/history"
+	"github.com/stellar/stellar-horizon/internal/ingest/processors"
+)
+
+type operationTypeFilter struct {
+	whitelistedTypesSet set.Set[string]
+	whitelistedMemosSet set.Set[string]
+	lastModified        int64
+	enabled             bool
+}
+
+type OperationTypeFilter interface {
+	processors.LedgerTransactionFilterer
+	RefreshOperationTypeFilter(filterConfig *history.OperationTypeFilterConfig) error
+}
+
+func NewOperationTypeFilter() OperationTypeFilter {
+	return &operationTypeFilter{
+		whitelistedTypesSet: set.Set[string]{},
+		whitelistedMemosSet: set.Set[string]{},
+	}
+}
+
+func (f *operationTypeFilter) Name() string {
+	return "filters.operationTypeFilter"
+}
+
+func (f *operationTypeFilter) RefreshOperationTypeFilter(filterConfig *history.OperationTypeFilterConfig) error {
+	// only need to re-initialize the filter config state(rules) if its cached version(in  memory)
+	// is older than the incoming config version based on lastModified epoch timestamp
+	if filterConfig.LastModified > f.lastModified {
+		logger.Infof("New Operation Type Filter config detected, reloading new config %v ", *filterConfig)
+
+		f.enabled = filterConfig.Enabled
+		f.whitelistedTypesSet = listToSet(filterConfig.Whitelist)
+		f.whitelistedMemosSet = listToSet(filterConfig.MemoWhitelist)
+		f.lastModified = filterConfig.LastModified
+	}
+
+	return nil
+}
+
+// FilterTransaction includes a transaction when it matches all the configured
+// rules: at least one of its operations has a whitelisted type (if operation
+// types are whitelisted) and its memo is whitelisted (if memos are whitelisted).
+func (f *operationTypeFilter) FilterTransaction(ctx context.Context, transaction ingest.LedgerTransaction) (bool, bool, error) {
+	if !f.isEnabled() {
+		return false, true, nil
+	}
+
+	if len(f.whitelistedTypesSet) > 0 && !f.operationsMatchedFilter(transaction.Envelope.Operations()) {
+		logger.Debugf("No operation type match, dropped tx with seq %v ", transaction.Envelope.SeqNum())
+		return true, false, nil
+	}
+
+	if len(f.whitelistedMemosSet) > 0 && !f.memoMatchedFilter(transaction.Envelope.Memo()) {
+		logger.Debugf("No memo match, dropped tx with seq %v ", transaction.Envelope.SeqNum())
+		return true, false, nil
+	}
+
+	return true, true, nil
+}
+
+func (f *operationTypeFilter) operationsMatchedFilter(ops []xdr.Operation) bool {
+	for _, op := range ops {
+		if f.whitelistedTypesSet.Contains(operations.TypeNames[op.Body.Type]) {
+			return true
+		}
+	}
+	return false
+}
+
+// memoMatchedFilter compares the memo using the same representation as the
+// `memo` field of transaction resources.
+func (f *operationTypeFilter) memoMatchedFilter(memo xdr.Memo) bool {
+	var value string
+	switch memo.Type {
+	case xdr.MemoTypeMemoText:
+		value = memo.MustText()
+	case xdr.MemoTypeMemoId:
+		value = strconv.FormatUint(uint64(memo.MustId()), 10)
+	case xdr.MemoTypeMemoHash:
+		hash := memo.MustHash()
+		value = base64.StdEncoding.EncodeToString(hash[:])
+	case xdr.MemoTypeMemoReturn:
+		hash := memo.MustRetHash()
+		value = base64.StdEncoding.EncodeToString(hash[:])
+	default:
+		return false
+	}
+	return f.whitelistedMemosSet.Contains(value)
+}
+
+func (f operationTypeFilter) isEnabled() bool {
+	// filtering is disabled if there are no rules configured
+	return (len(f.whitelistedTypesSet) >= 1 || len(f.whitelistedMemosSet) >= 1) && f.enabled
+}
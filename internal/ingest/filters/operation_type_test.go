@@ -0,0 +1,119 @@
+package filters
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/stellar/go-stellar-sdk/ingest"
+	"github.com/stellar/go-stellar-sdk/xdr"
+	"github.com/stellar/stellar-horizon/internal/db2/history"
+)
+
+func TestOperationTypeFilterAllowsOnTypeMatch(t *testing.T) {
+	tt := assert.New(t)
+	ctx := context.Background()
+
+	filter := NewOperationTypeFilter()
+	tt.NoError(filter.RefreshOperationTypeFilter(&history.OperationTypeFilterConfig{
+		Whitelist:    []string{"create_account", "payment"},
+		Enabled:      true,
+		LastModified: 1,
+	}))
+
+	isEnabled, result, err := filter.FilterTransaction(ctx, getMemoTestTx(t, xdr.MemoText("deposit")))
+	tt.NoError(err)
+	tt.Equal(isEnabled, true)
+	tt.Equal(result, true)
+}
+
+func TestOperationTypeFilterAllowsOnMemoMatch(t *testing.T) {
+	tt := assert.New(t)
+	ctx := context.Background()
+
+	filter := NewOperationTypeFilter()
+	tt.NoError(filter.RefreshOperationTypeFilter(&history.OperationTypeFilterConfig{
+		MemoWhitelist: []string{"deposit", "12345"},
+		Enabled:       true,
+		LastModified:  1,
+	}))
+
+	for _, memo := range []xdr.Memo{xdr.MemoText("deposit"), xdr.MemoID(12345)} {
+		isEnabled, result, err := filter.FilterTransaction(ctx, getMemoTestTx(t, memo))
+		tt.NoError(err)
+		tt.Equal(isEnabled, true)
+		tt.Equal(result, true)
+	}
+
+	isEnabled, result, err := filter.FilterTransaction(ctx, getMemoTestTx(t, xdr.MemoText("withdrawal")))
+	tt.NoError(err)
+	tt.Equal(isEnabled, true)
+	tt.Equal(result, false)
+}
+
+func TestOperationTypeFilterRequiresAllRulesToMatch(t *testing.T) {
+	tt := assert.New(t)
+	ctx := context.Background()
+
+	filter := NewOperationTypeFilter()
+	tt.NoError(filter.RefreshOperationTypeFilter(&history.OperationTypeFilterConfig{
+		Whitelist:     []string{"payment"},
+		MemoWhitelist: []string{"deposit"},
+		Enabled:       true,
+		LastModified:  1,
+	}))
+
+	isEnabled, result, err := filter.FilterTransaction(ctx, getMemoTestTx(t, xdr.MemoText("deposit")))
+	tt.NoError(err)
+	tt.Equal(isEnabled, true)
+	tt.Equal(result, true)
+
+	// the operation type matches but the memo doesn't
+	isEnabled, result, err = filter.FilterTransaction(ctx, getMemoTestTx(t, xdr.Memo{Type: xdr.MemoTypeMemoNone}))
+	tt.NoError(err)
+	tt.Equal(isEnabled, true)
+	tt.Equal(result, false)
+}
+
+func TestOperationTypeFilterAllowsWhenDisabled(t *testing.T) {
+	tt := assert.New(t)
+	ctx := context.Background()
+
+	filter := NewOperationTypeFilter()
+	tt.NoError(filter.RefreshOperationTypeFilter(&history.OperationTypeFilterConfig{
+		Whitelist:    []string{"invoke_host_function"},
+		Enabled:      false,
+		LastModified: 1,
+	}))
+
+	isEnabled, result, err := filter.FilterTransaction(ctx, getMemoTestTx(t, xdr.MemoText("deposit")))
+	tt.NoError(err)
+	tt.Equal(isEnabled, false)
+	tt.Equal(result, true)
+}
+
+func TestOperationTypeFilterDoesNotAllowWhenNoMatch(t *testing.T) {
+	tt := assert.New(t)
+	ctx := context.Background()
+
+	filter := NewOperationTypeFilter()
+	tt.NoError(filter.RefreshOperationTypeFilter(&history.OperationTypeFilterConfig{
+		Whitelist:    []string{"invoke_host_function"},
+		Enabled:      true,
+		LastModified: 1,
+	}))
+
+	isEnabled, result, err := filter.FilterTransaction(ctx, getMemoTestTx(t, xdr.MemoText("deposit")))
+	tt.NoError(err)
+	tt.Equal(isEnabled, true)
+	tt.Equal(result, false)
+}
+
+func getMemoTestTx(t *testing.T, memo xdr.Memo) ingest.LedgerTransaction {
+	tx := getAccountTestTx(t,
+		"GD6WNNTW664WH7FXC5RUMUTF7P5QSURC2IT36VOQEEGFZ4UWUEQGECAL",
+		"GBRPYHIL2CI3FNQ4BXLFMNDLFJUNPU2HY3ZMFSHONUCEOASW7QC7OX2H")
+	tx.Envelope.V1.Tx.Memo = memo
+	return tx
+}
@@ -544,6 +544,7 @@ func (r resumeState) run(s *system) (transition, error) {
 	if err = s.completeIngestion(s.ctx, ingestLedger); err != nil {
 		return retryResume(r), err
 	}
+	s.maybePublishLedgers()
 
 	// Update stats metrics
 	changeStatsMap := stats.changeStats.Map()
@@ -554,6 +555,7 @@ func (r resumeState) run(s *system) (transition, error) {
 	r.addLedgerStatsMetricFromMap(s, "ledger", transactionStatsMap)
 	tradeStatsMap := stats.tradeStats.Map()
 	r.addLedgerStatsMetricFromMap(s, "trades", tradeStatsMap)
+	r.addFilteredTransactionsMetricFromMap(s, stats.filterStats)
 	r.addProcessorDurationsMetricFromMap(s, stats.transactionDurations)
 	r.addLoaderDurationsMetricFromMap(s, stats.loaderDurations)
 	r.addLoaderStatsMetric(s, stats.loaderStats)
@@ -600,6 +602,13 @@ func (r resumeState) addLedgerStatsMetricFromMap(s *system, prefix string, m map
 	}
 }
 
+func (r resumeState) addFilteredTransactionsMetricFromMap(s *system, m map[filterRule]int64) {
+	for rule, value := range m {
+		s.Metrics().FilteredTransactionsCounter.
+			With(prometheus.Labels{"filter": rule.filter, "rule": rule.rule}).Add(float64(value))
+	}
+}
+
 func (r resumeState) addProcessorDurationsMetricFromMap(s *system, m map[string]time.Duration) {
 	for processorName, value := range m {
 		s.Metrics().ProcessorsRunDuration.
@@ -161,16 +161,33 @@ func (g *groupTransactionProcessors) ResetStats() {
 	}
 }
 
+// filterRule identifies the rule of a filterer which dropped a transaction,
+// it's used to report the number of filtered transactions per rule.
+type filterRule struct {
+	filter string
+	rule   string
+}
+
+const (
+	filterRuleWhitelist = "whitelist"
+	filterRuleBlacklist = "blacklist"
+)
+
 type groupTransactionFilterers struct {
 	filterers []processors.LedgerTransactionFilterer
 	runDurations
 	droppedTransactions int64
+	// droppedTransactionsByRule counts the dropped transactions per filter
+	// rule. A transaction which isn't included by any whitelist is counted
+	// once for each filterer with whitelist rules.
+	droppedTransactionsByRule map[filterRule]int64
 }
 
 func newGroupTransactionFilterers(filterers []processors.LedgerTransactionFilterer) *groupTransactionFilterers {
 	return &groupTransactionFilterers{
-		filterers:    filterers,
-		runDurations: make(map[string]time.Duration),
+		filterers:                 filterers,
+		runDurations:              make(map[string]time.Duration),
+		droppedTransactionsByRule: make(map[filterRule]int64),
 	}
 }
 
@@ -179,8 +196,27 @@ func (g *groupTransactionFilterers) Name() string {
 }
 
 func (g *groupTransactionFilterers) FilterTransaction(ctx context.Context, tx ingest.LedgerTransaction) (bool, bool, error) {
-	filtersEnabled := false
+	// deny-list rules take precedence over the whitelists of all filterers
+	for _, f := range g.filterers {
+		excluder, ok := f.(processors.LedgerTransactionExcluder)
+		if !ok {
+			continue
+		}
 
+		startTime := time.Now()
+		exclude, err := excluder.ExcludeTransaction(ctx, tx)
+		if err != nil {
+			return true, false, errors.Wrapf(err, "error in %T.ExcludeTransaction", f)
+		}
+		g.AddRunDuration(f.Name(), startTime)
+		if exclude {
+			g.droppedTransactions++
+			g.droppedTransactionsByRule[filterRule{filter: f.Name(), rule: filterRuleBlacklist}]++
+			return true, false, nil
+		}
+	}
+
+	var rejectedBy []string
 	for _, f := range g.filterers {
 		startTime := time.Now()
 		filterEnabled, include, err := f.FilterTransaction(ctx, tx)
@@ -188,7 +224,6 @@ func (g *groupTransactionFilterers) FilterTransaction(ctx context.Context, tx in
 			continue
 		}
 
-		filtersEnabled = true
 		if err != nil {
 			return true, false, errors.Wrapf(err, "error in %T.FilterTransaction", f)
 		}
@@ -196,10 +231,14 @@ func (g *groupTransactionFilterers) FilterTransaction(ctx context.Context, tx in
 		if include {
 			return true, true, nil
 		}
+		rejectedBy = append(rejectedBy, f.Name())
 	}
 
-	if filtersEnabled {
+	if len(rejectedBy) > 0 {
 		g.droppedTransactions++
+		for _, name := range rejectedBy {
+			g.droppedTransactionsByRule[filterRule{filter: name, rule: filterRuleWhitelist}]++
+		}
 		return true, false, nil
 	}
 	return false, true, nil
@@ -207,5 +246,6 @@ func (g *groupTransactionFilterers) FilterTransaction(ctx context.Context, tx in
 
 func (g *groupTransactionFilterers) ResetStats() {
 	g.droppedTransactions = 0
+	g.droppedTransactionsByRule = make(map[filterRule]int64)
 	g.runDurations = make(map[string]time.Duration)
 }
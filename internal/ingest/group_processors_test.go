@@ -7,6 +7,7 @@ import (
 	"errors"
 	"testing"
 
+	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/suite"
 
@@ -213,3 +214,62 @@ func (s *GroupTransactionProcessorsTestSuiteLedger) TestFlushSucceeds() {
 	err := s.processors.Flush(s.ctx, s.session)
 	s.Assert().NoError(err)
 }
+
+type stubTransactionFilterer struct {
+	name    string
+	enabled bool
+	include bool
+	exclude bool
+}
+
+func (f stubTransactionFilterer) Name() string {
+	return f.name
+}
+
+func (f stubTransactionFilterer) FilterTransaction(ctx context.Context, transaction ingest.LedgerTransaction) (bool, bool, error) {
+	return f.enabled, f.include, nil
+}
+
+func (f stubTransactionFilterer) ExcludeTransaction(ctx context.Context, transaction ingest.LedgerTransaction) (bool, error) {
+	return f.exclude, nil
+}
+
+func TestGroupTransactionFilterersBlacklistTakesPrecedence(t *testing.T) {
+	ctx := context.Background()
+	filterers := newGroupTransactionFilterers([]processors.LedgerTransactionFilterer{
+		stubTransactionFilterer{name: "whitelist", enabled: true, include: true},
+		stubTransactionFilterer{name: "blacklist", include: true, exclude: true},
+	})
+
+	enabled, include, err := filterers.FilterTransaction(ctx, ingest.LedgerTransaction{})
+	assert.NoError(t, err)
+	assert.True(t, enabled)
+	assert.False(t, include)
+	assert.Equal(t, int64(1), filterers.droppedTransactions)
+	assert.Equal(t, map[filterRule]int64{
+		{filter: "blacklist", rule: filterRuleBlacklist}: 1,
+	}, filterers.droppedTransactionsByRule)
+}
+
+func TestGroupTransactionFilterersCountsWhitelistRejections(t *testing.T) {
+	ctx := context.Background()
+	filterers := newGroupTransactionFilterers([]processors.LedgerTransactionFilterer{
+		stubTransactionFilterer{name: "a", enabled: true},
+		stubTransactionFilterer{name: "b", enabled: true},
+		stubTransactionFilterer{name: "c", include: true},
+	})
+
+	enabled, include, err := filterers.FilterTransaction(ctx, ingest.LedgerTransaction{})
+	assert.NoError(t, err)
+	assert.True(t, enabled)
+	assert.False(t, include)
+	assert.Equal(t, int64(1), filterers.droppedTransactions)
+	assert.Equal(t, map[filterRule]int64{
+		{filter: "a", rule: filterRuleWhitelist}: 1,
+		{filter: "b", rule: filterRuleWhitelist}: 1,
+	}, filterers.droppedTransactionsByRule)
+
+	filterers.ResetStats()
+	assert.Equal(t, int64(0), filterers.droppedTransactions)
+	assert.Empty(t, filterers.droppedTransactionsByRule)
+}
@@ -30,6 +30,7 @@ import (
 
 	"github.com/stellar/stellar-horizon/internal/db2/history"
 	"github.com/stellar/stellar-horizon/internal/ingest/filters"
+	"github.com/stellar/stellar-horizon/internal/ingest/subscriptions"
 )
 
 const (
@@ -71,7 +72,11 @@ const (
 	// - 19: Archived contract asset balances are no longer stored in the horizon db.
 	// - 20: Mapping of asset to its contract instance is stored in a new
 	//       table (asset_contracts) in the horizon db.
-	CurrentVersion = 20
+	// - 21: Persistent contract data entries are stored in a new table
+	//       (contract_data) in the horizon db.
+	// - 22: Contract asset balances record the holder of the balance and the
+	//       ledger in which the balance last changed.
+	CurrentVersion = 22
 
 	// MaxDBConnections is the size of the postgres connection pool dedicated to Horizon ingestion:
 	//  * Ledger ingestion,
@@ -129,7 +134,10 @@ type Config struct {
 	CaptiveCoreToml        *ledgerbackend.CaptiveCoreToml
 	NetworkPassphrase      string
 
-	HistorySession        db.SessionInterface
+	HistorySession db.SessionInterface
+	// DatabaseURL is used to listen for the ingestion filter rules updates,
+	// the rules are only polled periodically when it is empty.
+	DatabaseURL           string
 	HistoryArchiveURLs    []string
 	HistoryArchiveCaching bool
 
@@ -156,6 +164,14 @@ type Config struct {
 
 	ReapConfig ReapConfig
 
+	// LedgerPublishers are notified of the ingested ledgers, for example to
+	// publish them downstream or to dispatch subscription deliveries.
+	LedgerPublishers []LedgerPublisher
+
+	// EnableSubscriptions records deliveries for the transactions matching
+	// the subscriptions during live ingestion.
+	EnableSubscriptions bool
+
 	LedgerBackendType    LedgerBackendType
 	StorageBackendConfig StorageBackendConfig
 }
@@ -168,6 +184,15 @@ const (
 	updateExpStateInvalidErrMsg  string = "Error updating state invalid value"
 )
 
+// LedgerPublisher publishes the ledgers committed by live ingestion to a
+// downstream system, see the sink and subscriptions packages.
+type LedgerPublisher interface {
+	// Run publishes the ledgers until the context is done.
+	Run(ctx context.Context)
+	// LedgerIngested is called after a ledger is committed, it must not block.
+	LedgerIngested()
+}
+
 type stellarCoreClient interface {
 	SetCursor(ctx context.Context, id string, cursor int32) error
 }
@@ -203,6 +228,10 @@ type Metrics struct {
 	// LedgerStatsCounter exposes ledger stats counters (like number of ops/changes).
 	LedgerStatsCounter *prometheus.CounterVec
 
+	// FilteredTransactionsCounter counts the transactions dropped by the
+	// ingestion filters per filter rule.
+	FilteredTransactionsCounter *prometheus.CounterVec
+
 	// ProcessorsRunDuration exposes processors run durations.
 	// Deprecated in favor of: ProcessorsRunDurationSummary.
 	ProcessorsRunDuration *prometheus.CounterVec
@@ -233,6 +262,8 @@ type System interface {
 	BuildState(sequence uint32, skipChecks bool) error
 	LoadTest(ledgersFilePath, fixturesFilePath string, merge bool, closeDuration time.Duration) error
 	ReingestRange(ledgerRanges []history.LedgerRange, force bool, rebuildTradeAgg bool) error
+	EvaluateFilters(ledgerRange history.LedgerRange) (FilterEvaluation, error)
+	ApplyFilters(ledgerRange history.LedgerRange) error
 	Shutdown()
 	GetCurrentState() State
 	RebuildTradeAggregationBuckets(fromLedger, toLedger uint32) error
@@ -250,6 +281,7 @@ type system struct {
 	loadTestSnapshot *loadTestSnapshot
 	ledgerBackend    ledgerbackend.LedgerBackend
 	historyAdapter   historyArchiveAdapterInterface
+	filters          filters.Filters
 
 	stellarCoreClient stellarCoreClient
 
@@ -272,6 +304,8 @@ type system struct {
 	reaper            *Reaper
 	lookupTableReaper *lookupTableReaper
 
+	ledgerPublishers []LedgerPublisher
+
 	currentStateMutex sync.Mutex
 	currentState      State
 
@@ -355,6 +389,10 @@ func NewSystem(config Config) (System, error) {
 	historyQ := &history.Q{config.HistorySession.Clone()}
 	historyAdapter := newHistoryArchiveAdapter(archive, config.NetworkPassphrase)
 	filters := filters.NewFilters()
+	var subscriptionsIndex *subscriptions.Index
+	if config.EnableSubscriptions {
+		subscriptionsIndex = subscriptions.NewIndex()
+	}
 	loadtestSnapshot := &loadTestSnapshot{HistoryQ: historyQ}
 
 	maxLedgersPerFlush := config.MaxLedgerPerFlush
@@ -370,6 +408,7 @@ func NewSystem(config Config) (System, error) {
 		disableStateVerification:    config.DisableStateVerification,
 		historyAdapter:              historyAdapter,
 		historyQ:                    historyQ,
+		filters:                     filters,
 		loadTestSnapshot:            loadtestSnapshot,
 		ledgerBackend:               ledgerBackend,
 		maxReingestRetries:          config.MaxReingestRetries,
@@ -384,6 +423,7 @@ func NewSystem(config Config) (System, error) {
 			session:        historyQ,
 			historyAdapter: historyAdapter,
 			filters:        filters,
+			subscriptions:  subscriptionsIndex,
 		},
 		runStateVerificationOnLedger: ledgerEligibleForStateVerification(
 			config.CheckpointFrequency,
@@ -395,6 +435,7 @@ func NewSystem(config Config) (System, error) {
 			config.HistorySession,
 		),
 		lookupTableReaper: newLookupTableReaper(config.HistorySession),
+		ledgerPublishers:  config.LedgerPublishers,
 	}
 
 	system.initMetrics()
@@ -476,6 +517,17 @@ func (s *system) initMetrics() {
 		[]string{"type"},
 	)
 
+	s.metrics.FilteredTransactionsCounter = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: "horizon", Subsystem: "ingest", Name: "filtered_transactions_total",
+			Help: "Counters of the transactions dropped by the ingestion filters. " +
+				"'filter' label has the name of the filter and 'rule' label has the rule which dropped the transaction, " +
+				"'blacklist' - the transaction matched the deny-list of the filter, " +
+				"'whitelist' - the transaction didn't match the whitelist of the filter nor of any other enabled filter.",
+		},
+		[]string{"filter", "rule"},
+	)
+
 	s.metrics.ProcessorsRunDuration = prometheus.NewCounterVec(
 		prometheus.CounterOpts{
 			Namespace: "horizon", Subsystem: "ingest", Name: "processor_run_duration_seconds_total",
@@ -566,6 +618,7 @@ func (s *system) registerMetrics() {
 	s.metricsRegistry.MustRegister(s.metrics.StateVerifyDuration)
 	s.metricsRegistry.MustRegister(s.metrics.StateInvalidGauge)
 	s.metricsRegistry.MustRegister(s.metrics.LedgerStatsCounter)
+	s.metricsRegistry.MustRegister(s.metrics.FilteredTransactionsCounter)
 	s.metricsRegistry.MustRegister(s.metrics.ProcessorsRunDuration)
 	s.metricsRegistry.MustRegister(s.metrics.ProcessorsRunDurationSummary)
 	s.metricsRegistry.MustRegister(s.metrics.LoadersRunDurationSummary)
@@ -610,6 +663,18 @@ func (s *system) registerMetrics() {
 //   - If instances is a NOT leader, it runs ledger pipeline without updating a
 //     a database so order book graph is updated but database is not overwritten.
 func (s *system) Run() {
+	if s.config.DatabaseURL != "" {
+		if err := filters.ListenForFilterRulesUpdates(s.ctx, s.config.DatabaseURL, s.filters); err != nil {
+			log.WithError(err).Warn("Filter rules updates will only be applied periodically")
+		}
+	}
+	for _, publisher := range s.ledgerPublishers {
+		s.wg.Add(1)
+		go func(publisher LedgerPublisher) {
+			defer s.wg.Done()
+			publisher.Run(s.ctx)
+		}(publisher)
+	}
 	s.runStateMachine(startState{}, runOptions{})
 }
 
@@ -881,6 +946,14 @@ func (s *system) runStateMachine(cur stateMachineNode, options runOptions) error
 	}
 }
 
+// maybePublishLedgers notifies the ledger publishers, if configured, that a
+// ledger has been committed.
+func (s *system) maybePublishLedgers() {
+	for _, publisher := range s.ledgerPublishers {
+		publisher.LedgerIngested()
+	}
+}
+
 func (s *system) maybeReapHistory(lastIngestedLedger uint32) {
 	if s.reaper.config.Frequency == 0 || lastIngestedLedger%uint32(s.reaper.config.Frequency) != 0 {
 		return
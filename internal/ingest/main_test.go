@@ -410,18 +410,24 @@ type mockDBQ struct {
 	mock.Mock
 
 	history.MockQAccounts
+	history.MockQAccountStateChanges
 	history.MockQFilter
 	history.MockQClaimableBalances
 	history.MockQHistoryClaimableBalances
 	history.MockQLiquidityPools
 	history.MockQHistoryLiquidityPools
+	history.MockQHistoryContracts
 	history.MockQAssetStats
+	history.MockQBalanceChanges
+	history.MockQContractData
+	history.MockQContractEvents
 	history.MockQData
 	history.MockQEffects
 	history.MockQLedgers
 	history.MockQOffers
 	history.MockQOperations
 	history.MockQSigners
+	history.MockQSubscriptions
 	history.MockQTransactions
 	history.MockQTrustLines
 }
@@ -568,6 +574,16 @@ func (m *mockDBQ) DeleteRangeAll(ctx context.Context, start, end int64) (int64,
 	return args.Get(0).(int64), args.Error(1)
 }
 
+func (m *mockDBQ) DeleteAssetStatSnapshotsRange(ctx context.Context, start, end int64) (int64, error) {
+	args := m.Called(ctx, start, end)
+	return args.Get(0).(int64), args.Error(1)
+}
+
+func (m *mockDBQ) DeleteAccountStateChangesRange(ctx context.Context, start, end int64) (int64, error) {
+	args := m.Called(ctx, start, end)
+	return args.Get(0).(int64), args.Error(1)
+}
+
 // Methods from interfaces duplicating methods:
 
 func (m *mockDBQ) NewTransactionParticipantsBatchInsertBuilder() history.TransactionParticipantsBatchInsertBuilder {
@@ -701,6 +717,11 @@ func (m *mockProcessorsRunner) RunFixturesIngestion(
 	return args.Get(0).(processors.StatsChangeProcessorResults), args.Error(1)
 }
 
+func (m *mockProcessorsRunner) RunTransactionFilterersOnLedger(ledger xdr.LedgerCloseMeta) (map[string]bool, error) {
+	args := m.Called(ledger)
+	return args.Get(0).(map[string]bool), args.Error(1)
+}
+
 var _ ProcessorRunnerInterface = (*mockProcessorsRunner)(nil)
 
 type mockStellarCoreClient struct {
@@ -756,6 +777,16 @@ func (m *mockSystem) ReingestRange(ledgerRanges []history.LedgerRange, force boo
 	return args.Error(0)
 }
 
+func (m *mockSystem) EvaluateFilters(ledgerRange history.LedgerRange) (FilterEvaluation, error) {
+	args := m.Called(ledgerRange)
+	return args.Get(0).(FilterEvaluation), args.Error(1)
+}
+
+func (m *mockSystem) ApplyFilters(ledgerRange history.LedgerRange) error {
+	args := m.Called(ledgerRange)
+	return args.Error(0)
+}
+
 func (m *mockSystem) GetCurrentState() State {
 	args := m.Called()
 	return args.Get(0).(State)
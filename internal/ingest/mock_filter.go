@@ -15,3 +15,9 @@ type MockFilters struct {
 func (m *MockFilters) GetFilters(filterQ history.QFilter, ctx context.Context) []processors.LedgerTransactionFilterer {
 	return []processors.LedgerTransactionFilterer{}
 }
+
+func (m *MockFilters) RecordAppliedFromLedger(filterQ history.QFilter, ctx context.Context, ledgerSequence uint32) error {
+	return nil
+}
+
+func (m *MockFilters) Reload() {}
@@ -3,6 +3,7 @@ package ingest
 import (
 	"fmt"
 	"math"
+	"sort"
 	"sync"
 
 	"github.com/stellar/go-stellar-sdk/support/errors"
@@ -59,14 +60,19 @@ func (ps *ParallelSystems) Shutdown() {
 	}
 }
 
-func (ps *ParallelSystems) runReingestWorker(s System, stop <-chan struct{}, reingestJobQueue <-chan history.LedgerRange) rangeError {
+func (ps *ParallelSystems) runReingestWorker(s System, stop <-chan struct{}, reingestJobQueue <-chan history.LedgerRange, applyFilters bool) rangeError {
 
 	for {
 		select {
 		case <-stop:
 			return rangeError{}
 		case reingestRange := <-reingestJobQueue:
-			err := s.ReingestRange([]history.LedgerRange{reingestRange}, false, false)
+			var err error
+			if applyFilters {
+				err = s.ApplyFilters(reingestRange)
+			} else {
+				err = s.ReingestRange([]history.LedgerRange{reingestRange}, false, false)
+			}
 			if err != nil {
 				return rangeError{
 					err:         err,
@@ -116,6 +122,27 @@ func enqueueReingestTasks(ledgerRanges []history.LedgerRange, batchSize uint32,
 	}
 	return lowestLedger
 }
+
+// enqueueFilterEvaluations queues the batches of the ledger ranges to evaluate
+// the filters on, every ledger of the ranges is evaluated, including the last
+// one when it's alone in its batch.
+func enqueueFilterEvaluations(ledgerRanges []history.LedgerRange, batchSize uint32, stop <-chan struct{}, jobQueue chan<- history.LedgerRange) {
+	for _, cur := range ledgerRanges {
+		for subRangeFrom := cur.StartSequence; subRangeFrom <= cur.EndSequence; {
+			subRangeTo := subRangeFrom + (batchSize - 1)
+			if subRangeTo > cur.EndSequence {
+				subRangeTo = cur.EndSequence
+			}
+			select {
+			case <-stop:
+				return
+			case jobQueue <- history.LedgerRange{StartSequence: subRangeFrom, EndSequence: subRangeTo}:
+			}
+			subRangeFrom = subRangeTo + 1
+		}
+	}
+}
+
 func (ps *ParallelSystems) calculateParallelLedgerBatchSize(rangeSize uint32) uint32 {
 	// calculate the initial batch size based on available workers
 	batchSize := rangeSize / uint32(ps.workerCount)
@@ -148,6 +175,14 @@ func totalRangeSize(ledgerRanges []history.LedgerRange) uint32 {
 }
 
 func (ps *ParallelSystems) ReingestRange(ledgerRanges []history.LedgerRange) error {
+	defer ps.Shutdown()
+	return ps.reingestRange(ledgerRanges, false)
+}
+
+// reingestRange reingests the ledger ranges in parallel batches. With
+// applyFilters the batches are reingested with System.ApplyFilters, which
+// holds the ingestion lock, instead of System.ReingestRange.
+func (ps *ParallelSystems) reingestRange(ledgerRanges []history.LedgerRange, applyFilters bool) error {
 	var (
 		batchSize        = ps.calculateParallelLedgerBatchSize(totalRangeSize(ledgerRanges))
 		reingestJobQueue = make(chan history.LedgerRange)
@@ -169,8 +204,6 @@ func (ps *ParallelSystems) ReingestRange(ledgerRanges []history.LedgerRange) err
 		lowestRangeErr *rangeError
 	)
 
-	defer ps.Shutdown()
-
 	if err := validateRanges(ledgerRanges); err != nil {
 		return err
 	}
@@ -183,7 +216,7 @@ func (ps *ParallelSystems) ReingestRange(ledgerRanges []history.LedgerRange) err
 		}
 		go func() {
 			defer wg.Done()
-			rangeErr := ps.runReingestWorker(s, stop, reingestJobQueue)
+			rangeErr := ps.runReingestWorker(s, stop, reingestJobQueue, applyFilters)
 			if rangeErr.err != nil {
 				log.WithError(rangeErr).Error("error in reingest worker")
 				lowestRangeErrMutex.Lock()
@@ -219,3 +252,117 @@ func (ps *ParallelSystems) ReingestRange(ledgerRanges []history.LedgerRange) err
 	}
 	return nil
 }
+
+// ApplyFilters applies the current ingestion filters to the history of the
+// ledger ranges. The ranges are evaluated in parallel and the batches whose
+// stored transactions don't match the filters are reingested, which removes the
+// transactions not passing the filters anymore and ingests the newly included
+// ones. The ledgers which are not ingested yet are skipped and the batches are
+// reingested holding the ingestion lock, so the ranges can include the latest
+// ledgers while the ingestion is running. In dry-run mode the history is left
+// untouched.
+func (ps *ParallelSystems) ApplyFilters(ledgerRanges []history.LedgerRange, dryRun bool) (ApplyFiltersSummary, error) {
+	defer ps.Shutdown()
+
+	summary := ApplyFiltersSummary{}
+	if err := validateRanges(ledgerRanges); err != nil {
+		return summary, err
+	}
+
+	evaluations, err := ps.evaluateFilters(ledgerRanges)
+	if err != nil {
+		return summary, err
+	}
+	for _, evaluation := range evaluations {
+		summary.add(evaluation)
+	}
+
+	if dryRun || len(summary.ChangedRanges) == 0 {
+		return summary, nil
+	}
+
+	log.WithField("ranges", summary.ChangedRanges).Info("Reingesting ranges not matching the filters")
+	return summary, ps.reingestRange(summary.ChangedRanges, true)
+}
+
+// evaluateFilters evaluates the filters on batches of the ledger ranges using
+// the worker systems and returns the evaluations sorted by ledger.
+func (ps *ParallelSystems) evaluateFilters(ledgerRanges []history.LedgerRange) ([]FilterEvaluation, error) {
+	var (
+		totalLedgers = totalRangeSize(ledgerRanges)
+		batchSize    = ps.calculateParallelLedgerBatchSize(totalLedgers)
+		jobQueue     = make(chan history.LedgerRange)
+		wg           sync.WaitGroup
+
+		stopOnce sync.Once
+		stop     = make(chan struct{})
+
+		resultsMutex     sync.Mutex
+		evaluations      []FilterEvaluation
+		evaluatedLedgers uint32
+		lowestRangeErr   *rangeError
+	)
+
+	systems := make([]System, 0, ps.workerCount)
+	for i := uint(0); i < ps.workerCount; i++ {
+		s, err := ps.systemFactory(ps.config)
+		if err != nil {
+			return nil, errors.Wrap(err, "error creating new system")
+		}
+		systems = append(systems, s)
+	}
+
+	for _, s := range systems {
+		wg.Add(1)
+		go func(s System) {
+			defer wg.Done()
+			for {
+				select {
+				case <-stop:
+					return
+				case ledgerRange := <-jobQueue:
+					evaluation, err := s.EvaluateFilters(ledgerRange)
+					resultsMutex.Lock()
+					if err != nil {
+						log.WithError(err).Error("error in filters evaluation worker")
+						if lowestRangeErr == nil || lowestRangeErr.ledgerRange.StartSequence > ledgerRange.StartSequence {
+							lowestRangeErr = &rangeError{err: err, ledgerRange: ledgerRange}
+						}
+						resultsMutex.Unlock()
+						stopOnce.Do(func() {
+							close(stop)
+						})
+						return
+					}
+					evaluations = append(evaluations, evaluation)
+					evaluatedLedgers += totalRangeSize([]history.LedgerRange{ledgerRange})
+					progress := float64(evaluatedLedgers) / float64(totalLedgers) * 100
+					resultsMutex.Unlock()
+
+					log.WithFields(logpkg.F{
+						"from":     ledgerRange.StartSequence,
+						"to":       ledgerRange.EndSequence,
+						"progress": fmt.Sprintf("%.2f%%", progress),
+					}).Info("successfully evaluated filters on range")
+				}
+			}
+		}(s)
+	}
+
+	enqueueFilterEvaluations(ledgerRanges, batchSize, stop, jobQueue)
+
+	stopOnce.Do(func() {
+		close(stop)
+	})
+	wg.Wait()
+	close(jobQueue)
+
+	if lowestRangeErr != nil {
+		return nil, errors.Wrap(lowestRangeErr, "filters evaluation failed")
+	}
+
+	sort.Slice(evaluations, func(i, j int) bool {
+		return evaluations[i].LedgerRange.StartSequence < evaluations[j].LedgerRange.StartSequence
+	})
+	return evaluations, nil
+}
@@ -195,3 +195,97 @@ func TestParallelReingestRangeErrorInEarlierJob(t *testing.T) {
 	assert.Equal(t, "job failed, recommended restart range: [641, 2050]: error when processing [641, 1280] range: failed because of foo", err.Error())
 
 }
+
+func TestParallelApplyFilters(t *testing.T) {
+	config := Config{}
+	result := &mockSystem{}
+	result.On("EvaluateFilters", history.LedgerRange{StartSequence: 1, EndSequence: 640}).
+		Return(FilterEvaluation{LedgerRange: history.LedgerRange{StartSequence: 1, EndSequence: 640}, TransactionsStored: 10}, nil).Once()
+	result.On("EvaluateFilters", history.LedgerRange{StartSequence: 641, EndSequence: 1280}).
+		Return(FilterEvaluation{LedgerRange: history.LedgerRange{StartSequence: 641, EndSequence: 1280}, TransactionsStored: 5, TransactionsToRemove: 2}, nil).Once()
+	result.On("EvaluateFilters", history.LedgerRange{StartSequence: 1281, EndSequence: 1920}).
+		Return(FilterEvaluation{LedgerRange: history.LedgerRange{StartSequence: 1281, EndSequence: 1920}, TransactionsToAdd: 3}, nil).Once()
+	// the last 30 ledgers are not ingested yet
+	result.On("EvaluateFilters", history.LedgerRange{StartSequence: 1921, EndSequence: 2050}).
+		Return(FilterEvaluation{LedgerRange: history.LedgerRange{StartSequence: 1921, EndSequence: 2050}, TransactionsStored: 1, TransactionsToRemove: 1, LedgersNotIngested: 30}, nil).Once()
+	var (
+		rangesReingested []history.LedgerRange
+		m                sync.Mutex
+	)
+	result.On("ApplyFilters", mock.AnythingOfType("history.LedgerRange")).Run(
+		func(args mock.Arguments) {
+			m.Lock()
+			defer m.Unlock()
+			rangesReingested = append(rangesReingested, args.Get(0).(history.LedgerRange))
+		}).Return(nil)
+	result.On("RebuildTradeAggregationBuckets", uint32(641), uint32(2020)).Return(nil).Once()
+	factory := func(c Config) (System, error) {
+		return result, nil
+	}
+
+	system, err := newParallelSystems(config, 3, MinBatchSize, MaxCaptiveCoreBackendBatchSize, factory)
+	assert.NoError(t, err)
+	summary, err := system.ApplyFilters([]history.LedgerRange{{StartSequence: 1, EndSequence: 2050}}, false)
+	assert.NoError(t, err)
+	result.AssertExpectations(t)
+	assert.Equal(t, ApplyFiltersSummary{
+		Ledgers:              2020,
+		TransactionsStored:   16,
+		TransactionsToRemove: 3,
+		TransactionsToAdd:    3,
+		LedgersNotIngested:   30,
+		ChangedRanges:        []history.LedgerRange{{StartSequence: 641, EndSequence: 2020}},
+	}, summary)
+
+	// only the changed ranges are reingested, up to the last ingested ledger
+	sort.Slice(rangesReingested, func(i, j int) bool {
+		return rangesReingested[i].StartSequence < rangesReingested[j].StartSequence
+	})
+	assert.Equal(t, []history.LedgerRange{
+		{StartSequence: 641, EndSequence: 1088}, {StartSequence: 1089, EndSequence: 1536}, {StartSequence: 1537, EndSequence: 1984}, {StartSequence: 1985, EndSequence: 2020},
+	}, rangesReingested)
+	result.AssertNotCalled(t, "ReingestRange", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestParallelApplyFiltersDryRun(t *testing.T) {
+	config := Config{}
+	result := &mockSystem{}
+	result.On("EvaluateFilters", history.LedgerRange{StartSequence: 1, EndSequence: 64}).
+		Return(FilterEvaluation{LedgerRange: history.LedgerRange{StartSequence: 1, EndSequence: 64}, TransactionsToAdd: 1}, nil).Once()
+	result.On("EvaluateFilters", history.LedgerRange{StartSequence: 65, EndSequence: 65}).
+		Return(FilterEvaluation{LedgerRange: history.LedgerRange{StartSequence: 65, EndSequence: 65}, TransactionsStored: 1, TransactionsToRemove: 1}, nil).Once()
+	factory := func(c Config) (System, error) {
+		return result, nil
+	}
+
+	system, err := newParallelSystems(config, 2, 0, 0, factory)
+	assert.NoError(t, err)
+	summary, err := system.ApplyFilters([]history.LedgerRange{{StartSequence: 1, EndSequence: 65}}, true)
+	assert.NoError(t, err)
+	result.AssertExpectations(t)
+	result.AssertNotCalled(t, "ApplyFilters", mock.Anything)
+	assert.Equal(t, ApplyFiltersSummary{
+		Ledgers:              65,
+		TransactionsStored:   1,
+		TransactionsToRemove: 1,
+		TransactionsToAdd:    1,
+		ChangedRanges:        []history.LedgerRange{{StartSequence: 1, EndSequence: 65}},
+	}, summary)
+}
+
+func TestParallelApplyFiltersError(t *testing.T) {
+	config := Config{}
+	result := &mockSystem{}
+	result.On("EvaluateFilters", history.LedgerRange{StartSequence: 641, EndSequence: 1280}).
+		Return(FilterEvaluation{}, errors.New("failed because of foo")).Once()
+	result.On("EvaluateFilters", mock.AnythingOfType("history.LedgerRange")).Return(FilterEvaluation{}, nil)
+	factory := func(c Config) (System, error) {
+		return result, nil
+	}
+
+	system, err := newParallelSystems(config, 3, MinBatchSize, MaxCaptiveCoreBackendBatchSize, factory)
+	assert.NoError(t, err)
+	_, err = system.ApplyFilters([]history.LedgerRange{{StartSequence: 1, EndSequence: 2050}}, false)
+	assert.EqualError(t, err, "filters evaluation failed: error when processing [641, 1280] range: failed because of foo")
+	result.AssertNotCalled(t, "ApplyFilters", mock.Anything)
+}
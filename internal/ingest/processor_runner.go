@@ -17,6 +17,7 @@ import (
 	"github.com/stellar/stellar-horizon/internal/db2/history"
 	"github.com/stellar/stellar-horizon/internal/ingest/filters"
 	"github.com/stellar/stellar-horizon/internal/ingest/processors"
+	"github.com/stellar/stellar-horizon/internal/ingest/subscriptions"
 )
 
 type ingestionSource int
@@ -66,6 +67,7 @@ type ledgerStats struct {
 	loaderDurations      runDurations
 	loaderStats          map[string]history.LoaderStats
 	tradeStats           processors.TradeStats
+	filterStats          map[filterRule]int64
 }
 
 type ProcessorRunnerInterface interface {
@@ -79,6 +81,7 @@ type ProcessorRunnerInterface interface {
 		bucketListHash xdr.Hash,
 	) (processors.StatsChangeProcessorResults, error)
 	RunTransactionProcessorsOnLedgers(ledgers []xdr.LedgerCloseMeta, execInTx bool) error
+	RunTransactionFilterersOnLedger(ledger xdr.LedgerCloseMeta) (map[string]bool, error)
 	RunAllProcessorsOnLedger(ledger xdr.LedgerCloseMeta) (
 		stats ledgerStats,
 		err error,
@@ -101,6 +104,7 @@ type ProcessorRunner struct {
 	historyAdapter        historyArchiveAdapterInterface
 	logMemoryStats        bool
 	filters               filters.Filters
+	subscriptions         *subscriptions.Index
 	lastTransactionsTmpGC time.Time
 }
 
@@ -121,27 +125,39 @@ func buildChangeProcessor(
 	changeStats *processors.StatsChangeProcessor,
 	source ingestionSource,
 	ledgerSequence uint32,
+	closedAt time.Time,
 	networkPassphrase string,
 ) *groupChangeProcessors {
 	statsChangeProcessor := &statsChangeProcessor{
 		StatsChangeProcessor: changeStats,
 	}
 
+	// the account state change log is only recorded by live ingestion, the
+	// state ingested from history archives has no previous state
+	var accountsLog, dataLog, trustLinesLog *processors.AccountStateChangeLog
+	if source == ledgerSource {
+		accountsLog = processors.NewAccountStateChangeLog(historyQ, ledgerSequence)
+		dataLog = processors.NewAccountStateChangeLog(historyQ, ledgerSequence)
+		trustLinesLog = processors.NewAccountStateChangeLog(historyQ, ledgerSequence)
+	}
+
 	return newGroupChangeProcessors([]horizonChangeProcessor{
 		statsChangeProcessor,
-		processors.NewAccountDataProcessor(historyQ),
-		processors.NewAccountsProcessor(historyQ),
+		processors.NewAccountDataProcessor(historyQ, dataLog),
+		processors.NewAccountsProcessor(historyQ, accountsLog),
 		processors.NewOffersProcessor(historyQ, ledgerSequence),
 		processors.NewAssetStatsProcessor(
 			historyQ,
 			networkPassphrase,
 			source == historyArchiveSource,
 			ledgerSequence,
+			closedAt,
 		),
 		processors.NewSignersProcessor(historyQ),
-		processors.NewTrustLinesProcessor(historyQ),
+		processors.NewTrustLinesProcessor(historyQ, trustLinesLog),
 		processors.NewClaimableBalancesChangeProcessor(historyQ),
 		processors.NewLiquidityPoolsChangeProcessor(historyQ, ledgerSequence),
+		processors.NewContractDataProcessor(historyQ),
 	})
 }
 
@@ -150,8 +166,9 @@ func (s *ProcessorRunner) buildTransactionProcessor(ledgersProcessor *processors
 	assetLoader := history.NewAssetLoader(concurrencyMode)
 	lpLoader := history.NewLiquidityPoolLoader(concurrencyMode)
 	cbLoader := history.NewClaimableBalanceLoader(concurrencyMode)
+	contractLoader := history.NewContractLoader(concurrencyMode)
 
-	loaders := newGroupLoaders([]horizonLazyLoader{accountLoader, assetLoader, lpLoader, cbLoader})
+	loaders := newGroupLoaders([]horizonLazyLoader{accountLoader, assetLoader, lpLoader, cbLoader, contractLoader})
 	statsLedgerTransactionProcessor := processors.NewStatsLedgerTransactionProcessor()
 
 	tradeProcessor := processors.NewTradeProcessor(accountLoader,
@@ -163,13 +180,17 @@ func (s *ProcessorRunner) buildTransactionProcessor(ledgersProcessor *processors
 		ledgersProcessor,
 		processors.NewOperationProcessor(s.historyQ.NewOperationBatchInsertBuilder(), s.config.NetworkPassphrase),
 		tradeProcessor,
-		processors.NewParticipantsProcessor(accountLoader,
-			s.historyQ.NewTransactionParticipantsBatchInsertBuilder(), s.historyQ.NewOperationParticipantBatchInsertBuilder(), s.config.NetworkPassphrase),
+		processors.NewParticipantsProcessor(accountLoader, contractLoader,
+			s.historyQ.NewTransactionParticipantsBatchInsertBuilder(), s.historyQ.NewOperationParticipantBatchInsertBuilder(),
+			s.historyQ.NewTransactionContractBatchInsertBuilder(), s.historyQ.NewOperationContractBatchInsertBuilder(), s.config.NetworkPassphrase),
 		processors.NewTransactionProcessor(s.historyQ.NewTransactionBatchInsertBuilder(), s.config.SkipTxmeta),
 		processors.NewClaimableBalancesTransactionProcessor(cbLoader,
 			s.historyQ.NewTransactionClaimableBalanceBatchInsertBuilder(), s.historyQ.NewOperationClaimableBalanceBatchInsertBuilder()),
 		processors.NewLiquidityPoolsTransactionProcessor(lpLoader,
-			s.historyQ.NewTransactionLiquidityPoolBatchInsertBuilder(), s.historyQ.NewOperationLiquidityPoolBatchInsertBuilder())}
+			s.historyQ.NewTransactionLiquidityPoolBatchInsertBuilder(), s.historyQ.NewOperationLiquidityPoolBatchInsertBuilder(),
+			s.historyQ.NewLiquidityPoolSnapshotBatchInsertBuilder()),
+		processors.NewContractEventsProcessor(s.historyQ.NewContractEventBatchInsertBuilder()),
+		processors.NewBalanceChangesProcessor(accountLoader, assetLoader, s.historyQ.NewBalanceChangeBatchInsertBuilder())}
 
 	return loaders, newGroupTransactionProcessors(processors, statsLedgerTransactionProcessor, tradeProcessor)
 }
@@ -180,6 +201,19 @@ func (s *ProcessorRunner) buildTransactionFilterer() *groupTransactionFilterers
 	return newGroupTransactionFilterers(f)
 }
 
+// buildSubscriptionsProcessor returns the processor recording the deliveries of
+// the subscriptions, or nil if subscriptions are disabled.
+func (s *ProcessorRunner) buildSubscriptionsProcessor() (horizonTransactionProcessor, error) {
+	if s.subscriptions == nil {
+		return nil, nil
+	}
+	matcher, err := s.subscriptions.Matcher(s.ctx, s.historyQ)
+	if err != nil {
+		return nil, errors.Wrap(err, "Error loading subscriptions")
+	}
+	return subscriptions.NewProcessor(matcher, s.historyQ.NewSubscriptionDeliveryBatchInsertBuilder()), nil
+}
+
 func (s *ProcessorRunner) buildFilteredOutProcessor() *groupTransactionProcessors {
 	var p []horizonTransactionProcessor
 
@@ -219,6 +253,7 @@ func (s *ProcessorRunner) RunHistoryArchiveIngestion(
 		&changeStats,
 		historyArchiveSource,
 		checkpointLedger,
+		time.Time{},
 		s.config.NetworkPassphrase,
 	)
 
@@ -386,6 +421,7 @@ func (s *ProcessorRunner) runTransactionProcessorsOnLedger(registry nameRegistry
 	tradeStats processors.TradeStats,
 	loaderDurations runDurations,
 	loaderStats map[string]history.LoaderStats,
+	filterStats map[filterRule]int64,
 	err error,
 ) {
 	// ensure capture of the ledger to history regardless of whether it has transactions.
@@ -396,6 +432,13 @@ func (s *ProcessorRunner) runTransactionProcessorsOnLedger(registry nameRegistry
 	// when in online mode, the submission result processor must always run (regardless of whether filter rules exist or not)
 	groupFilteredOutProcessors := s.buildFilteredOutProcessor()
 	loaders, groupTransactionProcessors := s.buildTransactionProcessor(ledgersProcessor, concurrencyMode)
+	// subscriptions are only delivered for the ledgers ingested live
+	var subscriptionsProcessor horizonTransactionProcessor
+	if subscriptionsProcessor, err = s.buildSubscriptionsProcessor(); err != nil {
+		return
+	} else if subscriptionsProcessor != nil {
+		groupTransactionProcessors.processors = append(groupTransactionProcessors.processors, subscriptionsProcessor)
+	}
 
 	if err = registerTransactionProcessors(
 		registry,
@@ -428,6 +471,7 @@ func (s *ProcessorRunner) runTransactionProcessorsOnLedger(registry nameRegistry
 
 	transactionStats = groupTransactionProcessors.transactionStatsProcessor.GetResults()
 	transactionStats.TransactionsFiltered = groupTransactionFilterers.droppedTransactions
+	filterStats = groupTransactionFilterers.droppedTransactionsByRule
 
 	transactionDurations = groupTransactionProcessors.processorsRunDurations
 	for key, duration := range groupFilteredOutProcessors.processorsRunDurations {
@@ -561,6 +605,39 @@ func (s *ProcessorRunner) RunTransactionProcessorsOnLedgers(ledgers []xdr.Ledger
 	return nil
 }
 
+// RunTransactionFilterersOnLedger runs the ingestion filters on the
+// transactions of the ledger without ingesting them. It returns the hashes of
+// all the transactions of the ledger mapped to whether they pass the filters.
+func (s *ProcessorRunner) RunTransactionFilterersOnLedger(ledger xdr.LedgerCloseMeta) (map[string]bool, error) {
+	if err := s.checkIfProtocolVersionSupported(ledger.ProtocolVersion()); err != nil {
+		return nil, errors.Wrap(err, "Error while checking for supported protocol version")
+	}
+
+	transactionReader, err := ingest.NewLedgerTransactionReaderFromLedgerCloseMeta(s.config.NetworkPassphrase, ledger)
+	if err != nil {
+		return nil, errors.Wrap(err, "Error creating ledger reader")
+	}
+	defer transactionReader.Close()
+
+	groupTransactionFilterers := s.buildTransactionFilterer()
+	included := map[string]bool{}
+	for {
+		tx, err := transactionReader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, errors.Wrap(err, "could not read transaction")
+		}
+		_, include, err := groupTransactionFilterers.FilterTransaction(s.ctx, tx)
+		if err != nil {
+			return nil, errors.Wrapf(err, "could not filter transaction %v", tx.Index)
+		}
+		included[tx.Result.TransactionHash.HexString()] = include
+	}
+	return included, nil
+}
+
 func (s *ProcessorRunner) flushProcessors(groupFilteredOutProcessors *groupTransactionProcessors, groupTransactionProcessors *groupTransactionProcessors, execInTx bool) error {
 	if execInTx {
 		if err := s.session.Begin(s.ctx); err != nil {
@@ -615,6 +692,7 @@ func (s *ProcessorRunner) RunAllProcessorsOnLedger(ledger xdr.LedgerCloseMeta) (
 		&changeStatsProcessor,
 		ledgerSource,
 		ledger.LedgerSequence(),
+		ledger.ClosedAt(),
 		s.config.NetworkPassphrase,
 	)
 
@@ -631,7 +709,12 @@ func (s *ProcessorRunner) RunAllProcessorsOnLedger(ledger xdr.LedgerCloseMeta) (
 		return
 	}
 
-	transactionStats, transactionDurations, tradeStats, loaderDurations, loaderStats, err := s.runTransactionProcessorsOnLedger(registry, ledger, history.ConcurrentDeletes)
+	transactionStats, transactionDurations, tradeStats, loaderDurations, loaderStats, filterStats, err := s.runTransactionProcessorsOnLedger(registry, ledger, history.ConcurrentDeletes)
+	if err == nil {
+		if err = s.filters.RecordAppliedFromLedger(s.historyQ, s.ctx, ledger.LedgerSequence()); err != nil {
+			err = errors.Wrap(err, "Error recording filter rules applied from ledger")
+		}
+	}
 
 	stats.changeStats = changeStatsProcessor.GetResults()
 	stats.changeDurations = groupChangeProcessors.processorsRunDurations
@@ -640,6 +723,7 @@ func (s *ProcessorRunner) RunAllProcessorsOnLedger(ledger xdr.LedgerCloseMeta) (
 	stats.tradeStats = tradeStats
 	stats.loaderDurations = loaderDurations
 	stats.loaderStats = loaderStats
+	stats.filterStats = filterStats
 
 	return
 }
@@ -658,6 +742,7 @@ func (s *ProcessorRunner) RunFixturesIngestion(
 		&changeStats,
 		historyArchiveSource,
 		0, // checkpointLedger not used for fixtures
+		time.Time{},
 		s.config.NetworkPassphrase,
 	)
 
@@ -6,6 +6,7 @@ import (
 	"io"
 	"reflect"
 	"testing"
+	"time"
 
 	"github.com/stellar/go-stellar-sdk/amount"
 
@@ -152,7 +153,7 @@ func TestProcessorRunnerBuildChangeProcessor(t *testing.T) {
 	}
 
 	stats := &processors.StatsChangeProcessor{}
-	processor := buildChangeProcessor(runner.historyQ, stats, ledgerSource, 123, "")
+	processor := buildChangeProcessor(runner.historyQ, stats, ledgerSource, 123, time.Time{}, "")
 	assert.IsType(t, &groupChangeProcessors{}, processor)
 
 	assert.IsType(t, &statsChangeProcessor{}, processor.processors[0])
@@ -164,6 +165,9 @@ func TestProcessorRunnerBuildChangeProcessor(t *testing.T) {
 		Elem().FieldByName("ingestFromHistoryArchive").Bool())
 	assert.IsType(t, &processors.SignersProcessor{}, processor.processors[5])
 	assert.IsType(t, &processors.TrustLinesProcessor{}, processor.processors[6])
+	assert.IsType(t, &processors.ClaimableBalancesChangeProcessor{}, processor.processors[7])
+	assert.IsType(t, &processors.LiquidityPoolsChangeProcessor{}, processor.processors[8])
+	assert.IsType(t, &processors.ContractDataProcessor{}, processor.processors[9])
 
 	runner = ProcessorRunner{
 		ctx:      ctx,
@@ -171,7 +175,7 @@ func TestProcessorRunnerBuildChangeProcessor(t *testing.T) {
 		filters:  &MockFilters{},
 	}
 
-	processor = buildChangeProcessor(runner.historyQ, stats, historyArchiveSource, 456, "")
+	processor = buildChangeProcessor(runner.historyQ, stats, historyArchiveSource, 456, time.Time{}, "")
 	assert.IsType(t, &groupChangeProcessors{}, processor)
 
 	assert.IsType(t, &statsChangeProcessor{}, processor.processors[0])
@@ -204,6 +208,10 @@ func TestProcessorRunnerBuildTransactionProcessor(t *testing.T) {
 		Return(&history.MockTransactionParticipantsBatchInsertBuilder{})
 	q.On("NewOperationParticipantBatchInsertBuilder").
 		Return(&history.MockOperationParticipantBatchInsertBuilder{})
+	q.MockQHistoryContracts.On("NewTransactionContractBatchInsertBuilder").
+		Return(&history.MockTransactionContractBatchInsertBuilder{})
+	q.MockQHistoryContracts.On("NewOperationContractBatchInsertBuilder").
+		Return(&history.MockOperationContractBatchInsertBuilder{})
 	q.MockQHistoryClaimableBalances.On("NewTransactionClaimableBalanceBatchInsertBuilder").
 		Return(&history.MockTransactionClaimableBalanceBatchInsertBuilder{})
 	q.MockQHistoryClaimableBalances.On("NewOperationClaimableBalanceBatchInsertBuilder").
@@ -212,6 +220,12 @@ func TestProcessorRunnerBuildTransactionProcessor(t *testing.T) {
 		Return(&history.MockTransactionLiquidityPoolBatchInsertBuilder{})
 	q.MockQHistoryLiquidityPools.On("NewOperationLiquidityPoolBatchInsertBuilder").
 		Return(&history.MockOperationLiquidityPoolBatchInsertBuilder{})
+	q.MockQHistoryLiquidityPools.On("NewLiquidityPoolSnapshotBatchInsertBuilder").
+		Return(&history.MockLiquidityPoolSnapshotBatchInsertBuilder{})
+	q.MockQContractEvents.On("NewContractEventBatchInsertBuilder").
+		Return(&history.MockContractEventBatchInsertBuilder{})
+	q.MockQBalanceChanges.On("NewBalanceChangeBatchInsertBuilder").
+		Return(&history.MockBalanceChangeBatchInsertBuilder{})
 
 	runner := ProcessorRunner{
 		ctx:      ctx,
@@ -231,6 +245,8 @@ func TestProcessorRunnerBuildTransactionProcessor(t *testing.T) {
 	assert.IsType(t, &processors.ParticipantsProcessor{}, processor.processors[5])
 	assert.IsType(t, &processors.ClaimableBalancesTransactionProcessor{}, processor.processors[7])
 	assert.IsType(t, &processors.LiquidityPoolsTransactionProcessor{}, processor.processors[8])
+	assert.IsType(t, &processors.ContractEventsProcessor{}, processor.processors[9])
+	assert.IsType(t, &processors.BalanceChangesProcessor{}, processor.processors[10])
 }
 
 func TestProcessorRunnerRunAllProcessorsOnLedger(t *testing.T) {
@@ -282,7 +298,7 @@ func TestProcessorRunnerRunAllProcessorsOnLedger(t *testing.T) {
 
 	q.MockQAssetStats.On("RemoveContractAssetBalances", ctx, []xdr.Hash(nil)).
 		Return(nil).Once()
-	q.MockQAssetStats.On("UpdateContractAssetBalanceAmounts", ctx, []xdr.Hash{}, []string{}).
+	q.MockQAssetStats.On("UpdateContractAssetBalanceAmounts", ctx, []xdr.Hash{}, []string{}, uint32(23)).
 		Return(nil).Once()
 	q.MockQAssetStats.On("InsertContractAssetBalances", ctx, []history.ContractAssetBalance(nil)).
 		Return(nil).Once()
@@ -375,7 +391,7 @@ func TestProcessorRunnerRunTransactionsProcessorsOnLedgers(t *testing.T) {
 
 	q.MockQAssetStats.On("RemoveContractAssetBalances", ctx, []xdr.Hash(nil)).
 		Return(nil).Once()
-	q.MockQAssetStats.On("UpdateContractAssetBalanceAmounts", ctx, []xdr.Hash{}, []string{}).
+	q.MockQAssetStats.On("UpdateContractAssetBalanceAmounts", ctx, []xdr.Hash{}, []string{}, uint32(23)).
 		Return(nil).Once()
 	q.MockQAssetStats.On("InsertContractAssetBalances", ctx, []history.ContractAssetBalance(nil)).
 		Return(nil).Once()
@@ -503,7 +519,7 @@ func TestProcessorRunnerRunAllProcessorsOnLedgerProtocolVersionNotSupportedButAl
 
 	q.MockQAssetStats.On("RemoveContractAssetBalances", ctx, []xdr.Hash(nil)).
 		Return(nil).Once()
-	q.MockQAssetStats.On("UpdateContractAssetBalanceAmounts", ctx, []xdr.Hash{}, []string{}).
+	q.MockQAssetStats.On("UpdateContractAssetBalanceAmounts", ctx, []xdr.Hash{}, []string{}, uint32(23)).
 		Return(nil).Once()
 	q.MockQAssetStats.On("InsertContractAssetBalances", ctx, []history.ContractAssetBalance(nil)).
 		Return(nil).Once()
@@ -556,6 +572,16 @@ func mockTxProcessorBatchBuilders(q *mockDBQ, mockSession *db.MockSession, ctx c
 	q.On("NewOperationParticipantBatchInsertBuilder").
 		Return(mockOperationParticipantBatchInsertBuilder).Once()
 
+	mockTransactionContractBatchInsertBuilder := &history.MockTransactionContractBatchInsertBuilder{}
+	mockTransactionContractBatchInsertBuilder.On("Exec", ctx, mockSession).Return(nil).Once()
+	q.MockQHistoryContracts.On("NewTransactionContractBatchInsertBuilder").
+		Return(mockTransactionContractBatchInsertBuilder).Once()
+
+	mockOperationContractBatchInsertBuilder := &history.MockOperationContractBatchInsertBuilder{}
+	mockOperationContractBatchInsertBuilder.On("Exec", ctx, mockSession).Return(nil).Once()
+	q.MockQHistoryContracts.On("NewOperationContractBatchInsertBuilder").
+		Return(mockOperationContractBatchInsertBuilder).Once()
+
 	mockTransactionClaimableBalanceBatchInsertBuilder := &history.MockTransactionClaimableBalanceBatchInsertBuilder{}
 	mockTransactionClaimableBalanceBatchInsertBuilder.On("Exec", ctx, mockSession).Return(nil).Once()
 	q.MockQHistoryClaimableBalances.On("NewTransactionClaimableBalanceBatchInsertBuilder").
@@ -576,16 +602,36 @@ func mockTxProcessorBatchBuilders(q *mockDBQ, mockSession *db.MockSession, ctx c
 	q.MockQHistoryLiquidityPools.On("NewOperationLiquidityPoolBatchInsertBuilder").
 		Return(mockOperationLiquidityPoolBatchInsertBuilder).Once()
 
+	mockLiquidityPoolSnapshotBatchInsertBuilder := &history.MockLiquidityPoolSnapshotBatchInsertBuilder{}
+	mockLiquidityPoolSnapshotBatchInsertBuilder.On("Exec", ctx, mockSession).Return(nil).Once()
+	q.MockQHistoryLiquidityPools.On("NewLiquidityPoolSnapshotBatchInsertBuilder").
+		Return(mockLiquidityPoolSnapshotBatchInsertBuilder).Once()
+
+	mockContractEventBatchInsertBuilder := &history.MockContractEventBatchInsertBuilder{}
+	mockContractEventBatchInsertBuilder.On("Exec", ctx, mockSession).Return(nil).Once()
+	q.MockQContractEvents.On("NewContractEventBatchInsertBuilder").
+		Return(mockContractEventBatchInsertBuilder).Once()
+
+	mockBalanceChangeBatchInsertBuilder := &history.MockBalanceChangeBatchInsertBuilder{}
+	mockBalanceChangeBatchInsertBuilder.On("Exec", ctx, mockSession).Return(nil).Once()
+	q.MockQBalanceChanges.On("NewBalanceChangeBatchInsertBuilder").
+		Return(mockBalanceChangeBatchInsertBuilder).Once()
+
 	return []interface{}{mockTradeBatchInsertBuilder,
 		mockTransactionsBatchInsertBuilder,
 		mockOperationsBatchInsertBuilder,
 		mockEffectBatchInsertBuilder,
 		mockTransactionsParticipantsBatchInsertBuilder,
 		mockOperationParticipantBatchInsertBuilder,
+		mockTransactionContractBatchInsertBuilder,
+		mockOperationContractBatchInsertBuilder,
 		mockTransactionClaimableBalanceBatchInsertBuilder,
 		mockOperationClaimableBalanceBatchInsertBuilder,
 		mockTransactionLiquidityPoolBatchInsertBuilder,
-		mockOperationLiquidityPoolBatchInsertBuilder}
+		mockOperationLiquidityPoolBatchInsertBuilder,
+		mockLiquidityPoolSnapshotBatchInsertBuilder,
+		mockContractEventBatchInsertBuilder,
+		mockBalanceChangeBatchInsertBuilder}
 }
 
 func mockChangeProcessorBatchBuilders(q *mockDBQ, ctx context.Context, mockExec bool) []interface{} {
@@ -10,15 +10,16 @@ import (
 )
 
 type AccountDataProcessor struct {
-	dataQ history.QData
+	dataQ     history.QData
+	changeLog *AccountStateChangeLog
 
 	batchInsertBuilder history.AccountDataBatchInsertBuilder
 	dataToUpdate       []history.Data
 	dataToDelete       []history.AccountDataKey
 }
 
-func NewAccountDataProcessor(dataQ history.QData) *AccountDataProcessor {
-	p := &AccountDataProcessor{dataQ: dataQ}
+func NewAccountDataProcessor(dataQ history.QData, changeLog *AccountStateChangeLog) *AccountDataProcessor {
+	p := &AccountDataProcessor{dataQ: dataQ, changeLog: changeLog}
 	p.reset()
 	return p
 }
@@ -39,10 +40,14 @@ func (p *AccountDataProcessor) ProcessChange(ctx context.Context, change ingest.
 		return nil
 	}
 
+	if err := p.changeLog.add(change); err != nil {
+		return errors.Wrap(err, "Error adding to AccountStateChangeLog")
+	}
+
 	switch {
 	case change.Pre == nil && change.Post != nil:
 		// Created
-		err := p.batchInsertBuilder.Add(p.ledgerEntryToRow(change.Post))
+		err := p.batchInsertBuilder.Add(DataEntryToRow(*change.Post))
 		if err != nil {
 			return errors.Wrap(err, "Error adding to AccountDataBatchInsertBuilder")
 		}
@@ -56,10 +61,10 @@ func (p *AccountDataProcessor) ProcessChange(ctx context.Context, change ingest.
 		p.dataToDelete = append(p.dataToDelete, key)
 	default:
 		// Updated
-		p.dataToUpdate = append(p.dataToUpdate, p.ledgerEntryToRow(change.Post))
+		p.dataToUpdate = append(p.dataToUpdate, DataEntryToRow(*change.Post))
 	}
 
-	if p.batchInsertBuilder.Len()+len(p.dataToUpdate)+len(p.dataToDelete) > maxBatchSize {
+	if p.batchInsertBuilder.Len()+len(p.dataToUpdate)+len(p.dataToDelete)+p.changeLog.len() > maxBatchSize {
 
 		if err := p.Commit(ctx); err != nil {
 			return errors.Wrap(err, "error in Commit")
@@ -77,6 +82,10 @@ func (p *AccountDataProcessor) Commit(ctx context.Context) error {
 		return errors.Wrap(err, "Error executing AccountDataBatchInsertBuilder")
 	}
 
+	if err = p.changeLog.exec(ctx); err != nil {
+		return err
+	}
+
 	if len(p.dataToUpdate) > 0 {
 		if err := p.dataQ.UpsertAccountData(ctx, p.dataToUpdate); err != nil {
 			return errors.Wrap(err, "error executing upsert")
@@ -100,13 +109,15 @@ func (p *AccountDataProcessor) Commit(ctx context.Context) error {
 	return nil
 }
 
-func (p *AccountDataProcessor) ledgerEntryToRow(entry *xdr.LedgerEntry) history.Data {
+// DataEntryToRow converts a data ledger entry into a row of the
+// accounts_data table.
+func DataEntryToRow(entry xdr.LedgerEntry) history.Data {
 	data := entry.Data.MustData()
 	return history.Data{
 		AccountID:          data.AccountId.Address(),
 		Name:               string(data.DataName),
 		Value:              history.AccountDataValue(data.DataValue),
 		LastModifiedLedger: uint32(entry.LastModifiedLedgerSeq),
-		Sponsor:            ledgerEntrySponsorToNullString(*entry),
+		Sponsor:            ledgerEntrySponsorToNullString(entry),
 	}
 }
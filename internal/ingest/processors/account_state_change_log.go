@@ -0,0 +1,97 @@
+package processors
+
+import (
+	"context"
+
+	"github.com/guregu/null"
+
+	"github.com/stellar/go-stellar-sdk/ingest"
+	"github.com/stellar/go-stellar-sdk/support/errors"
+	"github.com/stellar/go-stellar-sdk/toid"
+	"github.com/stellar/go-stellar-sdk/xdr"
+	"github.com/stellar/stellar-horizon/internal/db2/history"
+)
+
+// AccountStateChangeLog records the account, trustline and data ledger
+// entries changed in a ledger as they were before the ledger, in the
+// history_account_state_changes table. The log is only recorded by live
+// ingestion and is used to rebuild the state of accounts at past ledgers.
+// A nil log records nothing.
+type AccountStateChangeLog struct {
+	q       history.QAccountStateChanges
+	ledger  uint32
+	builder history.AccountStateChangeBatchInsertBuilder
+}
+
+// NewAccountStateChangeLog constructs a log of the changes of the given ledger.
+func NewAccountStateChangeLog(q history.QAccountStateChanges, ledger uint32) *AccountStateChangeLog {
+	return &AccountStateChangeLog{q: q, ledger: ledger}
+}
+
+func (l *AccountStateChangeLog) add(change ingest.Change) error {
+	if l == nil {
+		return nil
+	}
+
+	entry := change.Post
+	if change.Pre != nil {
+		entry = change.Pre
+	}
+	key, err := entry.LedgerKey()
+	if err != nil {
+		return errors.Wrap(err, "Error creating ledger key")
+	}
+	keyString, err := key.MarshalBinaryBase64()
+	if err != nil {
+		return errors.Wrap(err, "Error marshaling ledger key")
+	}
+
+	var accountID xdr.AccountId
+	switch change.Type {
+	case xdr.LedgerEntryTypeAccount:
+		accountID = entry.Data.MustAccount().AccountId
+	case xdr.LedgerEntryTypeTrustline:
+		accountID = entry.Data.MustTrustLine().AccountId
+	case xdr.LedgerEntryTypeData:
+		accountID = entry.Data.MustData().AccountId
+	default:
+		return errors.Errorf("Unexpected ledger entry type: %s", change.Type)
+	}
+
+	var preEntry null.String
+	if change.Pre != nil {
+		encoded, err := xdr.MarshalBase64(change.Pre)
+		if err != nil {
+			return errors.Wrap(err, "Error marshaling ledger entry")
+		}
+		preEntry = null.StringFrom(encoded)
+	}
+
+	if l.builder == nil {
+		l.builder = l.q.NewAccountStateChangeBatchInsertBuilder()
+	}
+	return l.builder.Add(history.AccountStateChange{
+		AccountID: accountID.Address(),
+		LedgerKey: keyString,
+		LedgerID:  toid.New(int32(l.ledger), 0, 0).ToInt64(),
+		PreEntry:  preEntry,
+	})
+}
+
+func (l *AccountStateChangeLog) len() int {
+	if l == nil || l.builder == nil {
+		return 0
+	}
+	return l.builder.Len()
+}
+
+func (l *AccountStateChangeLog) exec(ctx context.Context) error {
+	if l == nil || l.builder == nil {
+		return nil
+	}
+	defer func() { l.builder = nil }()
+	if err := l.builder.Exec(ctx); err != nil {
+		return errors.Wrap(err, "Error executing AccountStateChangeBatchInsertBuilder")
+	}
+	return nil
+}
@@ -0,0 +1,112 @@
+package processors
+
+import (
+	"context"
+	"testing"
+
+	"github.com/guregu/null"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/stellar/go-stellar-sdk/ingest"
+	"github.com/stellar/go-stellar-sdk/toid"
+	"github.com/stellar/go-stellar-sdk/xdr"
+	"github.com/stellar/stellar-horizon/internal/db2/history"
+)
+
+func TestAccountsProcessorRecordsAccountStateChangeLog(t *testing.T) {
+	ctx := context.Background()
+	accountsQ := &history.MockQAccounts{}
+	accountsBatchInsertBuilder := &history.MockAccountsBatchInsertBuilder{}
+	accountsQ.On("NewAccountsBatchInsertBuilder").Return(accountsBatchInsertBuilder).Twice()
+	accountsBatchInsertBuilder.On("Len").Return(0).Maybe()
+	accountsBatchInsertBuilder.On("Exec", ctx).Return(nil).Once()
+
+	changesQ := &history.MockQAccountStateChanges{}
+	changesBatchInsertBuilder := &history.MockAccountStateChangeBatchInsertBuilder{}
+	changesQ.On("NewAccountStateChangeBatchInsertBuilder").Return(changesBatchInsertBuilder).Once()
+	changesBatchInsertBuilder.On("Len").Return(1).Maybe()
+	changesBatchInsertBuilder.On("Exec", ctx).Return(nil).Once()
+	defer mock.AssertExpectationsForObjects(t, accountsQ, accountsBatchInsertBuilder, changesQ, changesBatchInsertBuilder)
+
+	processor := NewAccountsProcessor(accountsQ, NewAccountStateChangeLog(changesQ, 124))
+
+	address := "GC3C4AKRBQLHOJ45U4XG35ESVWRDECWO5XLDGYADO6DPR3L7KIDVUMML"
+	pre := xdr.LedgerEntry{
+		Data: xdr.LedgerEntryData{
+			Type: xdr.LedgerEntryTypeAccount,
+			Account: &xdr.AccountEntry{
+				AccountId:  xdr.MustAddress(address),
+				Thresholds: [4]byte{1, 1, 1, 1},
+			},
+		},
+		LastModifiedLedgerSeq: 123,
+	}
+	// only the signers change, which is ignored by the accounts table but
+	// still logged
+	post := pre
+	post.Data.Account = &xdr.AccountEntry{
+		AccountId:  xdr.MustAddress(address),
+		Thresholds: [4]byte{1, 1, 1, 1},
+		Signers: []xdr.Signer{
+			{Key: xdr.MustSigner("GCYVFGI3SEQJGBNQQG7YCMFWEYOHK3XPVOVPA6C566PXWN4SN7LILZSM"), Weight: 1},
+		},
+	}
+
+	key, err := pre.LedgerKey()
+	require.NoError(t, err)
+	keyString, err := key.MarshalBinaryBase64()
+	require.NoError(t, err)
+	preString, err := xdr.MarshalBase64(pre)
+	require.NoError(t, err)
+	changesBatchInsertBuilder.On("Add", history.AccountStateChange{
+		AccountID: address,
+		LedgerKey: keyString,
+		LedgerID:  toid.New(124, 0, 0).ToInt64(),
+		PreEntry:  null.StringFrom(preString),
+	}).Return(nil).Once()
+
+	require.NoError(t, processor.ProcessChange(ctx, ingest.Change{
+		Type: xdr.LedgerEntryTypeAccount,
+		Pre:  &pre,
+		Post: &post,
+	}))
+	require.NoError(t, processor.Commit(ctx))
+}
+
+func TestAccountEntrySignersToRows(t *testing.T) {
+	address := "GC3C4AKRBQLHOJ45U4XG35ESVWRDECWO5XLDGYADO6DPR3L7KIDVUMML"
+	signer := "GCYVFGI3SEQJGBNQQG7YCMFWEYOHK3XPVOVPA6C566PXWN4SN7LILZSM"
+	sponsor := xdr.MustAddress("GBYSBDAJZMHL5AMD7QXQ3JEP3Q4GLKADWIJURAAHQALNAWD6Z5XF2RAC")
+	entry := xdr.LedgerEntry{
+		Data: xdr.LedgerEntryData{
+			Type: xdr.LedgerEntryTypeAccount,
+			Account: &xdr.AccountEntry{
+				AccountId:  xdr.MustAddress(address),
+				Thresholds: [4]byte{2, 1, 1, 1},
+				Signers: []xdr.Signer{
+					{Key: xdr.MustSigner(signer), Weight: 3},
+				},
+				Ext: xdr.AccountEntryExt{
+					V: 1,
+					V1: &xdr.AccountEntryExtensionV1{
+						Ext: xdr.AccountEntryExtensionV1Ext{
+							V: 2,
+							V2: &xdr.AccountEntryExtensionV2{
+								NumSponsored:        0,
+								NumSponsoring:       0,
+								SignerSponsoringIDs: []xdr.SponsorshipDescriptor{&sponsor},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	assert.Equal(t, []history.AccountSigner{
+		{Account: address, Signer: address, Weight: 2},
+		{Account: address, Signer: signer, Weight: 3, Sponsor: null.StringFrom(sponsor.Address())},
+	}, AccountEntrySignersToRows(entry))
+}
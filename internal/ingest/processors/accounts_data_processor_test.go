@@ -35,7 +35,7 @@ func (s *AccountsDataProcessorTestSuiteState) SetupTest() {
 	s.mockAccountDataBatchInsertBuilder.On("Exec", s.ctx).Return(nil)
 	s.mockAccountDataBatchInsertBuilder.On("Len").Return(1).Maybe()
 
-	s.processor = NewAccountDataProcessor(s.mockQ)
+	s.processor = NewAccountDataProcessor(s.mockQ, nil)
 }
 
 func (s *AccountsDataProcessorTestSuiteState) TearDownTest() {
@@ -100,7 +100,7 @@ func (s *AccountsDataProcessorTestSuiteLedger) SetupTest() {
 	s.mockAccountDataBatchInsertBuilder.On("Exec", s.ctx).Return(nil)
 	s.mockAccountDataBatchInsertBuilder.On("Len").Return(1).Maybe()
 
-	s.processor = NewAccountDataProcessor(s.mockQ)
+	s.processor = NewAccountDataProcessor(s.mockQ, nil)
 }
 
 func (s *AccountsDataProcessorTestSuiteLedger) TearDownTest() {
@@ -13,14 +13,15 @@ import (
 
 type AccountsProcessor struct {
 	accountsQ history.QAccounts
+	changeLog *AccountStateChangeLog
 
 	batchUpdateAccounts []history.AccountEntry
 	removeBatch         []string
 	batchInsertBuilder  history.AccountsBatchInsertBuilder
 }
 
-func NewAccountsProcessor(accountsQ history.QAccounts) *AccountsProcessor {
-	p := &AccountsProcessor{accountsQ: accountsQ}
+func NewAccountsProcessor(accountsQ history.QAccounts, changeLog *AccountStateChangeLog) *AccountsProcessor {
+	p := &AccountsProcessor{accountsQ: accountsQ, changeLog: changeLog}
 	p.reset()
 	return p
 }
@@ -40,6 +41,11 @@ func (p *AccountsProcessor) ProcessChange(ctx context.Context, change ingest.Cha
 		return nil
 	}
 
+	// signers are part of the account entry so every change is logged
+	if err := p.changeLog.add(change); err != nil {
+		return errors.Wrap(err, "Error adding to AccountStateChangeLog")
+	}
+
 	changed, err := change.AccountChangedExceptSigners()
 	if err != nil {
 		return errors.Wrap(err, "Error running change.AccountChangedExceptSigners")
@@ -52,14 +58,14 @@ func (p *AccountsProcessor) ProcessChange(ctx context.Context, change ingest.Cha
 	switch {
 	case change.Pre == nil && change.Post != nil:
 		// Created
-		row := p.ledgerEntryToRow(*change.Post)
+		row := AccountEntryToRow(*change.Post)
 		err = p.batchInsertBuilder.Add(row)
 		if err != nil {
 			return errors.Wrap(err, "Error adding to AccountsBatchInsertBuilder")
 		}
 	case change.Pre != nil && change.Post != nil:
 		// Updated
-		row := p.ledgerEntryToRow(*change.Post)
+		row := AccountEntryToRow(*change.Post)
 		p.batchUpdateAccounts = append(p.batchUpdateAccounts, row)
 	case change.Pre != nil && change.Post == nil:
 		// Removed
@@ -70,7 +76,7 @@ func (p *AccountsProcessor) ProcessChange(ctx context.Context, change ingest.Cha
 		return errors.New("Invalid io.Change: change.Pre == nil && change.Post == nil")
 	}
 
-	if p.batchInsertBuilder.Len()+len(p.batchUpdateAccounts)+len(p.removeBatch) > maxBatchSize {
+	if p.batchInsertBuilder.Len()+len(p.batchUpdateAccounts)+len(p.removeBatch)+p.changeLog.len() > maxBatchSize {
 		err = p.Commit(ctx)
 		if err != nil {
 			return errors.Wrap(err, "error in Commit")
@@ -88,6 +94,10 @@ func (p *AccountsProcessor) Commit(ctx context.Context) error {
 		return errors.Wrap(err, "Error executing AccountsBatchInsertBuilder")
 	}
 
+	if err = p.changeLog.exec(ctx); err != nil {
+		return err
+	}
+
 	// Upsert accounts
 	if len(p.batchUpdateAccounts) > 0 {
 		err := p.accountsQ.UpsertAccounts(ctx, p.batchUpdateAccounts)
@@ -114,7 +124,9 @@ func (p *AccountsProcessor) Commit(ctx context.Context) error {
 	return nil
 }
 
-func (p *AccountsProcessor) ledgerEntryToRow(entry xdr.LedgerEntry) history.AccountEntry {
+// AccountEntryToRow converts an account ledger entry into a row of the
+// accounts table.
+func AccountEntryToRow(entry xdr.LedgerEntry) history.AccountEntry {
 	account := entry.Data.MustAccount()
 	liabilities := account.Liabilities()
 
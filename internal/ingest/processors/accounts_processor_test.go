@@ -35,7 +35,7 @@ func (s *AccountsProcessorTestSuiteState) SetupTest() {
 	s.mockAccountsBatchInsertBuilder.On("Exec", s.ctx).Return(nil).Once()
 	s.mockAccountsBatchInsertBuilder.On("Len").Return(1).Maybe()
 
-	s.processor = NewAccountsProcessor(s.mockQ)
+	s.processor = NewAccountsProcessor(s.mockQ, nil)
 }
 
 func (s *AccountsProcessorTestSuiteState) TearDownTest() {
@@ -96,7 +96,7 @@ func (s *AccountsProcessorTestSuiteLedger) SetupTest() {
 	s.mockAccountsBatchInsertBuilder.On("Exec", s.ctx).Return(nil).Once()
 	s.mockAccountsBatchInsertBuilder.On("Len").Return(1).Maybe()
 
-	s.processor = NewAccountsProcessor(s.mockQ)
+	s.processor = NewAccountsProcessor(s.mockQ, nil)
 }
 
 func (s *AccountsProcessorTestSuiteLedger) TearDownTest() {
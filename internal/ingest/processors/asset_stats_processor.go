@@ -1,9 +1,12 @@
 package processors
 
 import (
+	"bytes"
 	"context"
 	"database/sql"
 	"math/big"
+	"sort"
+	"time"
 
 	"github.com/stellar/go-stellar-sdk/ingest"
 	"github.com/stellar/go-stellar-sdk/ingest/sac"
@@ -16,6 +19,7 @@ import (
 type AssetStatsProcessor struct {
 	assetStatsQ              history.QAssetStats
 	currentLedger            uint32
+	closedAt                 time.Time
 	assetStatSet             AssetStatSet
 	contractDataChanges      []ingest.Change
 	removedExpirationEntries map[xdr.Hash]uint32
@@ -26,14 +30,19 @@ type AssetStatsProcessor struct {
 }
 
 // NewAssetStatsProcessor constructs a new AssetStatsProcessor instance.
+// closedAt is the close time of the current ledger, when it is known the
+// stats of the assets changed in the ledger are recorded in the asset stats
+// history.
 func NewAssetStatsProcessor(
 	assetStatsQ history.QAssetStats,
 	networkPassphrase string,
 	ingestFromHistoryArchive bool,
 	currentLedger uint32,
+	closedAt time.Time,
 ) *AssetStatsProcessor {
 	p := &AssetStatsProcessor{
 		currentLedger:            currentLedger,
+		closedAt:                 closedAt,
 		assetStatsQ:              assetStatsQ,
 		ingestFromHistoryArchive: ingestFromHistoryArchive,
 		networkPassphrase:        networkPassphrase,
@@ -285,7 +294,38 @@ func (p *AssetStatsProcessor) updateDB(
 		return errors.Wrap(err, "Error inserting contract asset balances")
 	}
 
-	return p.updateContractAssetStats(ctx, contractAssetStatSet.contractAssetStats)
+	if err := p.updateContractAssetStats(ctx, contractAssetStatSet.contractAssetStats); err != nil {
+		return err
+	}
+
+	return p.insertAssetStatSnapshots(ctx, assetStatsDeltas, contractAssetStatSet.contractAssetStats)
+}
+
+// insertAssetStatSnapshots records the stats of the assets changed in the
+// ledger, once the changes were applied to the asset stats tables.
+func (p *AssetStatsProcessor) insertAssetStatSnapshots(
+	ctx context.Context,
+	assetStatsDeltas []history.ExpAssetStat,
+	contractAssetStats map[xdr.ContractId]assetContractStatValue,
+) error {
+	if p.closedAt.IsZero() || (len(assetStatsDeltas) == 0 && len(contractAssetStats) == 0) {
+		return nil
+	}
+
+	contractIDs := make([]xdr.ContractId, 0, len(contractAssetStats))
+	for contractID := range contractAssetStats {
+		contractIDs = append(contractIDs, contractID)
+	}
+	sort.Slice(contractIDs, func(i, j int) bool {
+		return bytes.Compare(contractIDs[i][:], contractIDs[j][:]) < 0
+	})
+
+	if err := p.assetStatsQ.InsertAssetStatSnapshots(
+		ctx, p.currentLedger, p.closedAt, assetStatsDeltas, contractIDs,
+	); err != nil {
+		return errors.Wrap(err, "Error inserting asset stat snapshots")
+	}
+	return nil
 }
 
 func (p *AssetStatsProcessor) updateContractAssetBalanceAmounts(ctx context.Context, updatedBalances map[xdr.Hash]*big.Int) error {
@@ -295,7 +335,7 @@ func (p *AssetStatsProcessor) updateContractAssetBalanceAmounts(ctx context.Cont
 		keys = append(keys, key)
 		amounts = append(amounts, amount.String())
 	}
-	if err := p.assetStatsQ.UpdateContractAssetBalanceAmounts(ctx, keys, amounts); err != nil {
+	if err := p.assetStatsQ.UpdateContractAssetBalanceAmounts(ctx, keys, amounts, p.currentLedger); err != nil {
 		return errors.Wrap(err, "Error updating contract asset balance amounts")
 	}
 	return nil
@@ -4,6 +4,7 @@ import (
 	"context"
 	"database/sql"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
@@ -40,7 +41,7 @@ func runContractDataLedger(
 	q.On("GetContractAssetBalances", ctx, mock.Anything).Return(storedRows, nil).Maybe()
 
 	q.On("InsertAssetContracts", ctx, mock.Anything).Return(nil).Maybe()
-	q.On("UpdateContractAssetBalanceAmounts", ctx, mock.Anything, mock.Anything).Return(nil).Maybe()
+	q.On("UpdateContractAssetBalanceAmounts", ctx, mock.Anything, mock.Anything, mock.Anything).Return(nil).Maybe()
 	q.On("UpdateContractAssetBalanceExpirations", ctx, mock.Anything, mock.Anything).Return(nil).Maybe()
 	q.On("UpdateAssetContractExpirations", ctx, mock.Anything, mock.Anything).Return(nil).Maybe()
 	q.On("DeleteContractAssetBalancesExpiringAt", ctx, mock.Anything).
@@ -52,7 +53,7 @@ func runContractDataLedger(
 		statInserts = append(statInserts, args.Get(1).(history.ContractAssetStatRow))
 	}).Return(int64(1), nil).Maybe()
 
-	p := NewAssetStatsProcessor(q, "passphrase", false, ledger)
+	p := NewAssetStatsProcessor(q, "passphrase", false, ledger, time.Time{})
 	for _, change := range changes {
 		assert.NoError(t, p.ProcessChange(ctx, change))
 	}
@@ -8,6 +8,7 @@ import (
 	"crypto/sha256"
 	"database/sql"
 	"testing"
+	"time"
 
 	"github.com/stellar/go-stellar-sdk/ingest"
 	"github.com/stellar/go-stellar-sdk/ingest/sac"
@@ -33,7 +34,7 @@ type AssetStatsProcessorTestSuiteState struct {
 func (s *AssetStatsProcessorTestSuiteState) SetupTest() {
 	s.ctx = context.Background()
 	s.mockQ = &history.MockQAssetStats{}
-	s.processor = NewAssetStatsProcessor(s.mockQ, "", true, 123)
+	s.processor = NewAssetStatsProcessor(s.mockQ, "", true, 123, time.Time{})
 }
 
 func (s *AssetStatsProcessorTestSuiteState) TearDownTest() {
@@ -195,7 +196,7 @@ func (s *AssetStatsProcessorTestSuiteLedger) SetupTest() {
 	s.ctx = context.Background()
 	s.mockQ = &history.MockQAssetStats{}
 
-	s.processor = NewAssetStatsProcessor(s.mockQ, "", false, 1235)
+	s.processor = NewAssetStatsProcessor(s.mockQ, "", false, 1235, time.Time{})
 }
 
 func (s *AssetStatsProcessorTestSuiteLedger) TearDownTest() {
@@ -347,7 +348,7 @@ func (s *AssetStatsProcessorTestSuiteLedger) TestInsertClaimableBalance() {
 
 	s.mockQ.On("RemoveContractAssetBalances", s.ctx, []xdr.Hash(nil)).
 		Return(nil).Once()
-	s.mockQ.On("UpdateContractAssetBalanceAmounts", s.ctx, []xdr.Hash{}, []string{}).
+	s.mockQ.On("UpdateContractAssetBalanceAmounts", s.ctx, []xdr.Hash{}, []string{}, uint32(1235)).
 		Return(nil).Once()
 	s.mockQ.On("InsertContractAssetBalances", s.ctx, []history.ContractAssetBalance(nil)).
 		Return(nil).Once()
@@ -517,7 +518,7 @@ func (s *AssetStatsProcessorTestSuiteLedger) TestInsertTrustLine() {
 
 	s.mockQ.On("RemoveContractAssetBalances", s.ctx, []xdr.Hash(nil)).
 		Return(nil).Once()
-	s.mockQ.On("UpdateContractAssetBalanceAmounts", s.ctx, []xdr.Hash{}, []string{}).
+	s.mockQ.On("UpdateContractAssetBalanceAmounts", s.ctx, []xdr.Hash{}, []string{}, uint32(1235)).
 		Return(nil).Once()
 	s.mockQ.On("InsertContractAssetBalances", s.ctx, []history.ContractAssetBalance(nil)).
 		Return(nil).Once()
@@ -668,7 +669,7 @@ func (s *AssetStatsProcessorTestSuiteLedger) TestInsertAssetContract() {
 
 	s.mockQ.On("RemoveContractAssetBalances", s.ctx, []xdr.Hash(nil)).
 		Return(nil).Once()
-	s.mockQ.On("UpdateContractAssetBalanceAmounts", s.ctx, []xdr.Hash{}, []string{}).
+	s.mockQ.On("UpdateContractAssetBalanceAmounts", s.ctx, []xdr.Hash{}, []string{}, uint32(1235)).
 		Return(nil).Once()
 	s.mockQ.On("InsertContractAssetBalances", s.ctx, []history.ContractAssetBalance(nil)).
 		Return(nil).Once()
@@ -706,7 +707,7 @@ func (s *AssetStatsProcessorTestSuiteLedger) TestInsertAssetContractMissingTTL()
 
 	s.mockQ.On("RemoveContractAssetBalances", s.ctx, []xdr.Hash(nil)).
 		Return(nil).Once()
-	s.mockQ.On("UpdateContractAssetBalanceAmounts", s.ctx, []xdr.Hash{}, []string{}).
+	s.mockQ.On("UpdateContractAssetBalanceAmounts", s.ctx, []xdr.Hash{}, []string{}, uint32(1235)).
 		Return(nil).Once()
 	s.mockQ.On("InsertContractAssetBalances", s.ctx, []history.ContractAssetBalance(nil)).
 		Return(nil).Once()
@@ -759,7 +760,7 @@ func (s *AssetStatsProcessorTestSuiteLedger) TestInsertExpiredAssetContract() {
 
 	s.mockQ.On("RemoveContractAssetBalances", s.ctx, []xdr.Hash(nil)).
 		Return(nil).Once()
-	s.mockQ.On("UpdateContractAssetBalanceAmounts", s.ctx, []xdr.Hash{}, []string{}).
+	s.mockQ.On("UpdateContractAssetBalanceAmounts", s.ctx, []xdr.Hash{}, []string{}, uint32(1235)).
 		Return(nil).Once()
 	s.mockQ.On("InsertContractAssetBalances", s.ctx, []history.ContractAssetBalance(nil)).
 		Return(nil).Once()
@@ -822,7 +823,7 @@ func (s *AssetStatsProcessorTestSuiteLedger) TestRestoreReapsBeforeInsert() {
 	markInsert := func(mock.Arguments) { inserted = true }
 
 	s.mockQ.On("RemoveContractAssetBalances", s.ctx, []xdr.Hash(nil)).Return(nil).Once()
-	s.mockQ.On("UpdateContractAssetBalanceAmounts", s.ctx, []xdr.Hash{}, []string{}).Return(nil).Once()
+	s.mockQ.On("UpdateContractAssetBalanceAmounts", s.ctx, []xdr.Hash{}, []string{}, uint32(1235)).Return(nil).Once()
 	s.mockQ.On("UpdateContractAssetBalanceExpirations", s.ctx, []xdr.Hash{}, []uint32{}).Return(nil).Once()
 	s.mockQ.On("UpdateAssetContractExpirations", s.ctx, []xdr.Hash{}, []uint32{}).Return(nil).Once()
 	s.mockQ.On("DeleteContractAssetBalancesExpiringAt", s.ctx, reapLedger).Run(reapFirst).
@@ -893,14 +894,17 @@ func (s *AssetStatsProcessorTestSuiteLedger) TestInsertContractBalance() {
 
 	s.mockQ.On("RemoveContractAssetBalances", s.ctx, []xdr.Hash(nil)).
 		Return(nil).Once()
-	s.mockQ.On("UpdateContractAssetBalanceAmounts", s.ctx, []xdr.Hash{}, []string{}).
+	s.mockQ.On("UpdateContractAssetBalanceAmounts", s.ctx, []xdr.Hash{}, []string{}, uint32(1235)).
 		Return(nil).Once()
+	holderID := [32]byte{1}
 	s.mockQ.On("InsertContractAssetBalances", s.ctx, []history.ContractAssetBalance{
 		{
-			KeyHash:          keyHash[:],
-			ContractID:       usdID[:],
-			Amount:           "200",
-			ExpirationLedger: 2234,
+			KeyHash:            keyHash[:],
+			ContractID:         usdID[:],
+			HolderContractID:   holderID[:],
+			Amount:             "200",
+			ExpirationLedger:   2234,
+			LastModifiedLedger: 1234,
 		},
 	}).Return(nil).Once()
 	s.mockQ.On("UpdateContractAssetBalanceExpirations", s.ctx, []xdr.Hash{}, []uint32{}).
@@ -956,7 +960,7 @@ func (s *AssetStatsProcessorTestSuiteLedger) TestUpdateContractBalance() {
 		Return(nil).Once()
 	s.mockQ.On("RemoveContractAssetBalances", s.ctx, []xdr.Hash(nil)).
 		Return(nil).Once()
-	s.mockQ.On("UpdateContractAssetBalanceAmounts", s.ctx, []xdr.Hash{keyHash}, []string{"300"}).
+	s.mockQ.On("UpdateContractAssetBalanceAmounts", s.ctx, []xdr.Hash{keyHash}, []string{"300"}, uint32(1235)).
 		Return(nil).Once()
 	s.mockQ.On("UpdateContractAssetBalanceExpirations", s.ctx, []xdr.Hash{}, []uint32{}).
 		Return(nil).Once()
@@ -1020,7 +1024,7 @@ func (s *AssetStatsProcessorTestSuiteLedger) TestRemoveContractBalance() {
 		}, nil).Once()
 	s.mockQ.On("RemoveContractAssetBalances", s.ctx, []xdr.Hash{keyHash}).
 		Return(nil).Once()
-	s.mockQ.On("UpdateContractAssetBalanceAmounts", s.ctx, []xdr.Hash{}, []string{}).
+	s.mockQ.On("UpdateContractAssetBalanceAmounts", s.ctx, []xdr.Hash{}, []string{}, uint32(1235)).
 		Return(nil).Once()
 	s.mockQ.On("InsertContractAssetBalances", s.ctx, []history.ContractAssetBalance(nil)).
 		Return(nil).Once()
@@ -1210,14 +1214,17 @@ func (s *AssetStatsProcessorTestSuiteLedger) TestInsertAssetContractWithBalance(
 
 	s.mockQ.On("RemoveContractAssetBalances", s.ctx, []xdr.Hash(nil)).
 		Return(nil).Once()
-	s.mockQ.On("UpdateContractAssetBalanceAmounts", s.ctx, []xdr.Hash{}, []string{}).
+	s.mockQ.On("UpdateContractAssetBalanceAmounts", s.ctx, []xdr.Hash{}, []string{}, uint32(1235)).
 		Return(nil).Once()
+	holderID := [32]byte{1}
 	s.mockQ.On("InsertContractAssetBalances", s.ctx, []history.ContractAssetBalance{
 		{
-			KeyHash:          keyHash[:],
-			ContractID:       btcID[:],
-			Amount:           "20",
-			ExpirationLedger: 2234,
+			KeyHash:            keyHash[:],
+			ContractID:         btcID[:],
+			HolderContractID:   holderID[:],
+			Amount:             "20",
+			ExpirationLedger:   2234,
+			LastModifiedLedger: 1234,
 		},
 	}).Return(nil).Once()
 	s.mockQ.On("UpdateContractAssetBalanceExpirations", s.ctx, []xdr.Hash{}, []uint32{}).
@@ -1334,7 +1341,7 @@ func (s *AssetStatsProcessorTestSuiteLedger) TestInsertClaimableBalanceAndTrustl
 
 	s.mockQ.On("RemoveContractAssetBalances", s.ctx, []xdr.Hash(nil)).
 		Return(nil).Once()
-	s.mockQ.On("UpdateContractAssetBalanceAmounts", s.ctx, []xdr.Hash{}, []string{}).
+	s.mockQ.On("UpdateContractAssetBalanceAmounts", s.ctx, []xdr.Hash{}, []string{}, uint32(1235)).
 		Return(nil).Once()
 	s.mockQ.On("InsertContractAssetBalances", s.ctx, []history.ContractAssetBalance(nil)).
 		Return(nil).Once()
@@ -1388,7 +1395,7 @@ func (s *AssetStatsProcessorTestSuiteLedger) TestExpirationLedgerDecreases() {
 		Return(nil).Once()
 	s.mockQ.On("RemoveContractAssetBalances", s.ctx, []xdr.Hash(nil)).
 		Return(nil).Once()
-	s.mockQ.On("UpdateContractAssetBalanceAmounts", s.ctx, []xdr.Hash{}, []string{}).
+	s.mockQ.On("UpdateContractAssetBalanceAmounts", s.ctx, []xdr.Hash{}, []string{}, uint32(1235)).
 		Return(nil).Once()
 	s.mockQ.On("UpdateContractAssetBalanceExpirations", s.ctx, []xdr.Hash{keyHash}, []uint32{2234}).
 		Return(nil).Once()
@@ -1440,7 +1447,7 @@ func (s *AssetStatsProcessorTestSuiteLedger) TestExpirationLedgerIgnoredIfLessTh
 		Return(nil).Once()
 	s.mockQ.On("RemoveContractAssetBalances", s.ctx, []xdr.Hash(nil)).
 		Return(nil).Once()
-	s.mockQ.On("UpdateContractAssetBalanceAmounts", s.ctx, []xdr.Hash{}, []string{}).
+	s.mockQ.On("UpdateContractAssetBalanceAmounts", s.ctx, []xdr.Hash{}, []string{}, uint32(1235)).
 		Return(nil).Once()
 	s.mockQ.On("UpdateContractAssetBalanceExpirations", s.ctx, []xdr.Hash{}, []uint32{}).
 		Return(nil).Once()
@@ -1558,7 +1565,7 @@ func (s *AssetStatsProcessorTestSuiteLedger) TestUpdateTrustLine() {
 
 	s.mockQ.On("RemoveContractAssetBalances", s.ctx, []xdr.Hash(nil)).
 		Return(nil).Once()
-	s.mockQ.On("UpdateContractAssetBalanceAmounts", s.ctx, []xdr.Hash{}, []string{}).
+	s.mockQ.On("UpdateContractAssetBalanceAmounts", s.ctx, []xdr.Hash{}, []string{}, uint32(1235)).
 		Return(nil).Once()
 	s.mockQ.On("InsertContractAssetBalances", s.ctx, []history.ContractAssetBalance(nil)).
 		Return(nil).Once()
@@ -1570,6 +1577,110 @@ func (s *AssetStatsProcessorTestSuiteLedger) TestUpdateTrustLine() {
 	s.Assert().NoError(s.processor.Commit(s.ctx))
 }
 
+func (s *AssetStatsProcessorTestSuiteLedger) TestUpdateTrustLineRecordsSnapshot() {
+	closedAt := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+	s.processor = NewAssetStatsProcessor(s.mockQ, "", false, 1235, closedAt)
+	lastModifiedLedgerSeq := xdr.Uint32(1234)
+
+	trustLine := xdr.TrustLineEntry{
+		AccountId: xdr.MustAddress("GAOQJGUAB7NI7K7I62ORBXMN3J4SSWQUQ7FOEPSDJ322W2HMCNWPHXFB"),
+		Asset:     xdr.MustNewCreditAsset("EUR", trustLineIssuer.Address()).ToTrustLineAsset(),
+		Balance:   0,
+		Flags:     xdr.Uint32(xdr.TrustLineFlagsAuthorizedFlag),
+	}
+	updatedTrustLine := xdr.TrustLineEntry{
+		AccountId: xdr.MustAddress("GAOQJGUAB7NI7K7I62ORBXMN3J4SSWQUQ7FOEPSDJ322W2HMCNWPHXFB"),
+		Asset:     xdr.MustNewCreditAsset("EUR", trustLineIssuer.Address()).ToTrustLineAsset(),
+		Balance:   10,
+		Flags:     xdr.Uint32(xdr.TrustLineFlagsAuthorizedFlag),
+	}
+
+	err := s.processor.ProcessChange(s.ctx, ingest.Change{
+		Type: xdr.LedgerEntryTypeTrustline,
+		Pre: &xdr.LedgerEntry{
+			LastModifiedLedgerSeq: lastModifiedLedgerSeq,
+			Data: xdr.LedgerEntryData{
+				Type:      xdr.LedgerEntryTypeTrustline,
+				TrustLine: &trustLine,
+			},
+		},
+		Post: &xdr.LedgerEntry{
+			LastModifiedLedgerSeq: lastModifiedLedgerSeq,
+			Data: xdr.LedgerEntryData{
+				Type:      xdr.LedgerEntryTypeTrustline,
+				TrustLine: &updatedTrustLine,
+			},
+		},
+	})
+	s.Assert().NoError(err)
+
+	s.mockQ.On("GetAssetStat", s.ctx,
+		xdr.AssetTypeAssetTypeCreditAlphanum4,
+		"EUR",
+		trustLineIssuer.Address(),
+	).Return(history.ExpAssetStat{
+		AssetType:   xdr.AssetTypeAssetTypeCreditAlphanum4,
+		AssetIssuer: trustLineIssuer.Address(),
+		AssetCode:   "EUR",
+		Accounts:    history.ExpAssetStatAccounts{Authorized: 1},
+		Balances: history.ExpAssetStatBalances{
+			Authorized:                      "100",
+			AuthorizedToMaintainLiabilities: "0",
+			Unauthorized:                    "0",
+			ClaimableBalances:               "0",
+			LiquidityPools:                  "0",
+		},
+	}, nil).Once()
+	s.mockQ.On("UpdateAssetStat", s.ctx, history.ExpAssetStat{
+		AssetType:   xdr.AssetTypeAssetTypeCreditAlphanum4,
+		AssetIssuer: trustLineIssuer.Address(),
+		AssetCode:   "EUR",
+		Accounts:    history.ExpAssetStatAccounts{Authorized: 1},
+		Balances: history.ExpAssetStatBalances{
+			Authorized:                      "110",
+			AuthorizedToMaintainLiabilities: "0",
+			Unauthorized:                    "0",
+			ClaimableBalances:               "0",
+			LiquidityPools:                  "0",
+		},
+	}).Return(int64(1), nil).Once()
+
+	s.mockQ.On("InsertAssetContracts", s.ctx, []history.AssetContract(nil)).
+		Return(nil).Once()
+	s.mockQ.On("UpdateAssetContractExpirations", s.ctx, []xdr.Hash{}, []uint32{}).
+		Return(nil).Once()
+	s.mockQ.On("DeleteAssetContractsExpiringAt", s.ctx, uint32(1234)).
+		Return(int64(0), nil).Once()
+
+	s.mockQ.On("RemoveContractAssetBalances", s.ctx, []xdr.Hash(nil)).
+		Return(nil).Once()
+	s.mockQ.On("UpdateContractAssetBalanceAmounts", s.ctx, []xdr.Hash{}, []string{}, uint32(1235)).
+		Return(nil).Once()
+	s.mockQ.On("InsertContractAssetBalances", s.ctx, []history.ContractAssetBalance(nil)).
+		Return(nil).Once()
+	s.mockQ.On("UpdateContractAssetBalanceExpirations", s.ctx, []xdr.Hash{}, []uint32{}).
+		Return(nil).Once()
+	s.mockQ.On("DeleteContractAssetBalancesExpiringAt", s.ctx, uint32(1234)).
+		Return([]history.ContractAssetBalance{}, nil).Once()
+
+	s.mockQ.On("InsertAssetStatSnapshots", s.ctx, uint32(1235), closedAt, []history.ExpAssetStat{
+		{
+			AssetType:   xdr.AssetTypeAssetTypeCreditAlphanum4,
+			AssetIssuer: trustLineIssuer.Address(),
+			AssetCode:   "EUR",
+			Balances: history.ExpAssetStatBalances{
+				Authorized:                      "10",
+				AuthorizedToMaintainLiabilities: "0",
+				Unauthorized:                    "0",
+				ClaimableBalances:               "0",
+				LiquidityPools:                  "0",
+			},
+		},
+	}, []xdr.ContractId{}).Return(nil).Once()
+
+	s.Assert().NoError(s.processor.Commit(s.ctx))
+}
+
 func (s *AssetStatsProcessorTestSuiteLedger) TestUpdateTrustLineAuthorization() {
 	lastModifiedLedgerSeq := xdr.Uint32(1234)
 
@@ -1784,7 +1895,7 @@ func (s *AssetStatsProcessorTestSuiteLedger) TestUpdateTrustLineAuthorization()
 
 	s.mockQ.On("RemoveContractAssetBalances", s.ctx, []xdr.Hash(nil)).
 		Return(nil).Once()
-	s.mockQ.On("UpdateContractAssetBalanceAmounts", s.ctx, []xdr.Hash{}, []string{}).
+	s.mockQ.On("UpdateContractAssetBalanceAmounts", s.ctx, []xdr.Hash{}, []string{}, uint32(1235)).
 		Return(nil).Once()
 	s.mockQ.On("InsertContractAssetBalances", s.ctx, []history.ContractAssetBalance(nil)).
 		Return(nil).Once()
@@ -1906,7 +2017,7 @@ func (s *AssetStatsProcessorTestSuiteLedger) TestRemoveClaimableBalance() {
 
 	s.mockQ.On("RemoveContractAssetBalances", s.ctx, []xdr.Hash(nil)).
 		Return(nil).Once()
-	s.mockQ.On("UpdateContractAssetBalanceAmounts", s.ctx, []xdr.Hash{}, []string{}).
+	s.mockQ.On("UpdateContractAssetBalanceAmounts", s.ctx, []xdr.Hash{}, []string{}, uint32(1235)).
 		Return(nil).Once()
 	s.mockQ.On("InsertContractAssetBalances", s.ctx, []history.ContractAssetBalance(nil)).
 		Return(nil).Once()
@@ -2014,7 +2125,7 @@ func (s *AssetStatsProcessorTestSuiteLedger) TestRemoveTrustLine() {
 
 	s.mockQ.On("RemoveContractAssetBalances", s.ctx, []xdr.Hash(nil)).
 		Return(nil).Once()
-	s.mockQ.On("UpdateContractAssetBalanceAmounts", s.ctx, []xdr.Hash{}, []string{}).
+	s.mockQ.On("UpdateContractAssetBalanceAmounts", s.ctx, []xdr.Hash{}, []string{}, uint32(1235)).
 		Return(nil).Once()
 	s.mockQ.On("InsertContractAssetBalances", s.ctx, []history.ContractAssetBalance(nil)).
 		Return(nil).Once()
@@ -2104,7 +2215,7 @@ func (s *AssetStatsProcessorTestSuiteLedger) TestProcessUpgradeChange() {
 
 	s.mockQ.On("RemoveContractAssetBalances", s.ctx, []xdr.Hash(nil)).
 		Return(nil).Once()
-	s.mockQ.On("UpdateContractAssetBalanceAmounts", s.ctx, []xdr.Hash{}, []string{}).
+	s.mockQ.On("UpdateContractAssetBalanceAmounts", s.ctx, []xdr.Hash{}, []string{}, uint32(1235)).
 		Return(nil).Once()
 	s.mockQ.On("InsertContractAssetBalances", s.ctx, []history.ContractAssetBalance(nil)).
 		Return(nil).Once()
@@ -0,0 +1,180 @@
+package processors
+
+import (
+	"context"
+	"time"
+
+	"github.com/stellar/go-stellar-sdk/ingest"
+	"github.com/stellar/go-stellar-sdk/protocols/horizon/operations"
+	"github.com/stellar/go-stellar-sdk/support/db"
+	"github.com/stellar/go-stellar-sdk/support/errors"
+	"github.com/stellar/go-stellar-sdk/toid"
+	"github.com/stellar/go-stellar-sdk/xdr"
+	"github.com/stellar/stellar-horizon/internal/db2/history"
+)
+
+// BalanceChangesProcessor stores the changes of the native and credit asset
+// balances of accounts in the history_balance_changes table. The changes are
+// derived from the account and trustline ledger entry changes of the
+// transactions, so they include fees and the balance changes of offers
+// crossed by path payments and of Stellar Asset Contract transfers.
+type BalanceChangesProcessor struct {
+	accountLoader *history.AccountLoader
+	assetLoader   *history.AssetLoader
+	batch         history.BalanceChangeBatchInsertBuilder
+}
+
+func NewBalanceChangesProcessor(
+	accountLoader *history.AccountLoader,
+	assetLoader *history.AssetLoader,
+	batch history.BalanceChangeBatchInsertBuilder,
+) *BalanceChangesProcessor {
+	return &BalanceChangesProcessor{
+		accountLoader: accountLoader,
+		assetLoader:   assetLoader,
+		batch:         batch,
+	}
+}
+
+func (p *BalanceChangesProcessor) Name() string {
+	return "processors.BalanceChangesProcessor"
+}
+
+func (p *BalanceChangesProcessor) ProcessTransaction(lcm xdr.LedgerCloseMeta, transaction ingest.LedgerTransaction) error {
+	sequence := int32(lcm.LedgerSequence())
+	closedAt := time.Unix(lcm.LedgerCloseTime(), 0).UTC()
+	transactionID := toid.New(sequence, int32(transaction.Index), 0).ToInt64()
+
+	changes, err := transaction.GetChanges()
+	if err != nil {
+		return errors.Wrap(err, "could not read transaction changes")
+	}
+	changes = append(transaction.GetFeeChanges(), changes...)
+	changes = append(changes, transaction.GetPostApplyFeeChanges()...)
+
+	orders := map[int64]int32{}
+	for _, change := range changes {
+		account, asset, before, after, ok := balanceChange(change)
+		if !ok {
+			continue
+		}
+
+		operationID := transactionID
+		reason := history.BalanceChangeReasonFee
+		if change.Reason == ingest.LedgerEntryChangeReasonOperation {
+			operationID = toid.New(sequence, int32(transaction.Index), int32(change.OperationIndex+1)).ToInt64()
+			op, found := transaction.GetOperation(change.OperationIndex)
+			if !found {
+				return errors.Errorf("could not find operation %d", operationID)
+			}
+			reason = balanceChangeReason(transaction, op, account)
+		}
+		orders[operationID]++
+
+		err = p.batch.Add(history.InsertBalanceChange{
+			OperationID:     operationID,
+			Order:           orders[operationID],
+			TransactionID:   transactionID,
+			Account:         p.accountLoader.GetFuture(account),
+			Asset:           p.assetLoader.GetFuture(history.AssetKeyFromXDR(asset)),
+			LedgerCloseTime: closedAt,
+			Reason:          reason,
+			AmountBefore:    before,
+			AmountAfter:     after,
+		})
+		if err != nil {
+			return errors.Wrapf(err, "could not add balance change of operation %d", operationID)
+		}
+	}
+
+	return nil
+}
+
+// balanceChange returns the account and asset of the balance changed by a
+// ledger entry change, with the balance before and after the change. Created
+// entries had a zero balance before and removed entries have a zero balance
+// after. It returns false when the entry doesn't hold an account balance or
+// when the balance didn't change.
+func balanceChange(change ingest.Change) (string, xdr.Asset, int64, int64, bool) {
+	var (
+		account       string
+		asset         xdr.Asset
+		before, after int64
+	)
+	for _, entry := range []*xdr.LedgerEntry{change.Pre, change.Post} {
+		if entry == nil {
+			continue
+		}
+		var balance int64
+		switch entry.Data.Type {
+		case xdr.LedgerEntryTypeAccount:
+			accountEntry := entry.Data.MustAccount()
+			account = accountEntry.AccountId.Address()
+			asset = xdr.MustNewNativeAsset()
+			balance = int64(accountEntry.Balance)
+		case xdr.LedgerEntryTypeTrustline:
+			trustLine := entry.Data.MustTrustLine()
+			// liquidity pool shares are not account balances
+			if trustLine.Asset.Type == xdr.AssetTypeAssetTypePoolShare {
+				return "", xdr.Asset{}, 0, 0, false
+			}
+			account = trustLine.AccountId.Address()
+			asset = trustLine.Asset.ToAsset()
+			balance = int64(trustLine.Balance)
+		default:
+			return "", xdr.Asset{}, 0, 0, false
+		}
+		if entry == change.Pre {
+			before = balance
+		} else {
+			after = balance
+		}
+	}
+	return account, asset, before, after, before != after
+}
+
+// balanceChangeReason returns the reason of a change of the balance of an
+// account made by an operation.
+func balanceChangeReason(transaction ingest.LedgerTransaction, op xdr.Operation, account string) string {
+	source := transaction.Envelope.SourceAccount()
+	if op.SourceAccount != nil {
+		source = *op.SourceAccount
+	}
+
+	var destination string
+	switch op.Body.Type {
+	case xdr.OperationTypeCreateAccount:
+		destination = op.Body.MustCreateAccountOp().Destination.Address()
+	case xdr.OperationTypePayment:
+		destination = op.Body.MustPaymentOp().Destination.ToAccountId().Address()
+	case xdr.OperationTypePathPaymentStrictReceive:
+		destination = op.Body.MustPathPaymentStrictReceiveOp().Destination.ToAccountId().Address()
+	case xdr.OperationTypePathPaymentStrictSend:
+		destination = op.Body.MustPathPaymentStrictSendOp().Destination.ToAccountId().Address()
+	case xdr.OperationTypeAccountMerge:
+		destination = op.Body.MustDestination().ToAccountId().Address()
+	case xdr.OperationTypeManageSellOffer,
+		xdr.OperationTypeManageBuyOffer,
+		xdr.OperationTypeCreatePassiveSellOffer:
+		return history.BalanceChangeReasonTrade
+	case xdr.OperationTypeLiquidityPoolDeposit:
+		return history.BalanceChangeReasonLiquidityPoolDeposit
+	case xdr.OperationTypeLiquidityPoolWithdraw:
+		return history.BalanceChangeReasonLiquidityPoolWithdraw
+	case xdr.OperationTypeInvokeHostFunction:
+		return history.BalanceChangeReasonContractTransfer
+	default:
+		return operations.TypeNames[op.Body.Type]
+	}
+
+	// the other accounts changed by path payments are the owners of the
+	// crossed offers
+	if account == source.ToAccountId().Address() || account == destination {
+		return history.BalanceChangeReasonPayment
+	}
+	return history.BalanceChangeReasonTrade
+}
+
+func (p *BalanceChangesProcessor) Flush(ctx context.Context, session db.SessionInterface) error {
+	return p.batch.Exec(ctx, session)
+}
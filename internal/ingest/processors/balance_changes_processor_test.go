@@ -0,0 +1,177 @@
+package processors
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/stellar/go-stellar-sdk/ingest"
+	"github.com/stellar/go-stellar-sdk/support/db"
+	"github.com/stellar/go-stellar-sdk/toid"
+	"github.com/stellar/go-stellar-sdk/xdr"
+	"github.com/stellar/stellar-horizon/internal/db2/history"
+)
+
+func balanceChangeTestAccountEntry(address string, balance int64) xdr.LedgerEntry {
+	return xdr.LedgerEntry{
+		Data: xdr.LedgerEntryData{
+			Type: xdr.LedgerEntryTypeAccount,
+			Account: &xdr.AccountEntry{
+				AccountId: xdr.MustAddress(address),
+				Balance:   xdr.Int64(balance),
+			},
+		},
+	}
+}
+
+func balanceChangeTestTrustLineEntry(address string, asset xdr.Asset, balance int64) xdr.LedgerEntry {
+	return xdr.LedgerEntry{
+		Data: xdr.LedgerEntryData{
+			Type: xdr.LedgerEntryTypeTrustline,
+			TrustLine: &xdr.TrustLineEntry{
+				AccountId: xdr.MustAddress(address),
+				Asset:     asset.ToTrustLineAsset(),
+				Balance:   xdr.Int64(balance),
+			},
+		},
+	}
+}
+
+func balanceChangeTestUpdate(pre, post xdr.LedgerEntry) xdr.LedgerEntryChanges {
+	return xdr.LedgerEntryChanges{
+		{Type: xdr.LedgerEntryChangeTypeLedgerEntryState, State: &pre},
+		{Type: xdr.LedgerEntryChangeTypeLedgerEntryUpdated, Updated: &post},
+	}
+}
+
+func TestBalanceChangesProcessor(t *testing.T) {
+	ctx := context.Background()
+	session := &db.MockSession{}
+	batch := &history.MockBalanceChangeBatchInsertBuilder{}
+	defer mock.AssertExpectationsForObjects(t, batch)
+
+	accountLoader := history.NewAccountLoader(history.ConcurrentInserts)
+	assetLoader := history.NewAssetLoader(history.ConcurrentInserts)
+	processor := NewBalanceChangesProcessor(accountLoader, assetLoader, batch)
+
+	source := "GAOQJGUAB7NI7K7I62ORBXMN3J4SSWQUQ7FOEPSDJ322W2HMCNWPHXFB"
+	destination := "GBRPYHIL2CI3FNQ4BXLFMNDLFJUNPU2HY3ZMFSHONUCEOASW7QC7OX2H"
+	seller := "GC3C4AKRBQLHOJ45U4XG35ESVWRDECWO5XLDGYADO6DPR3L7KIDVUMML"
+	usd := xdr.MustNewCreditAsset("USD", seller)
+	closedAt := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+
+	lcm := xdr.LedgerCloseMeta{
+		V0: &xdr.LedgerCloseMetaV0{
+			LedgerHeader: xdr.LedgerHeaderHistoryEntry{
+				Header: xdr.LedgerHeader{
+					LedgerSeq: 20,
+					ScpValue:  xdr.StellarValue{CloseTime: xdr.TimePoint(closedAt.Unix())},
+				},
+			},
+		},
+	}
+
+	// the source pays USD to the destination with a path payment crossing
+	// an offer of the seller, who is also the issuer of USD
+	op := xdr.Operation{
+		Body: xdr.OperationBody{
+			Type: xdr.OperationTypePathPaymentStrictReceive,
+			PathPaymentStrictReceiveOp: &xdr.PathPaymentStrictReceiveOp{
+				SendAsset:   xdr.MustNewNativeAsset(),
+				SendMax:     100,
+				Destination: xdr.MustMuxedAddress(destination),
+				DestAsset:   usd,
+				DestAmount:  50,
+			},
+		},
+	}
+	offer := xdr.LedgerEntry{
+		Data: xdr.LedgerEntryData{
+			Type:  xdr.LedgerEntryTypeOffer,
+			Offer: &xdr.OfferEntry{SellerId: xdr.MustAddress(seller), OfferId: 1, Amount: 100},
+		},
+	}
+	soldOffer := offer
+	soldOffer.Data.Offer = &xdr.OfferEntry{SellerId: xdr.MustAddress(seller), OfferId: 1, Amount: 50}
+
+	var opChanges xdr.LedgerEntryChanges
+	opChanges = append(opChanges, balanceChangeTestUpdate(
+		balanceChangeTestAccountEntry(source, 900), balanceChangeTestAccountEntry(source, 800),
+	)...)
+	opChanges = append(opChanges, balanceChangeTestUpdate(offer, soldOffer)...)
+	opChanges = append(opChanges, balanceChangeTestUpdate(
+		balanceChangeTestAccountEntry(seller, 500), balanceChangeTestAccountEntry(seller, 600),
+	)...)
+	opChanges = append(opChanges, balanceChangeTestUpdate(
+		balanceChangeTestTrustLineEntry(destination, usd, 10), balanceChangeTestTrustLineEntry(destination, usd, 60),
+	)...)
+
+	transaction := ingest.LedgerTransaction{
+		Index: 1,
+		Envelope: xdr.TransactionEnvelope{
+			Type: xdr.EnvelopeTypeEnvelopeTypeTx,
+			V1: &xdr.TransactionV1Envelope{
+				Tx: xdr.Transaction{
+					SourceAccount: xdr.MustMuxedAddress(source),
+					Operations:    []xdr.Operation{op},
+				},
+			},
+		},
+		Result: xdr.TransactionResultPair{
+			Result: xdr.TransactionResult{
+				Result: xdr.TransactionResultResult{Code: xdr.TransactionResultCodeTxSuccess},
+			},
+		},
+		FeeChanges: balanceChangeTestUpdate(
+			balanceChangeTestAccountEntry(source, 1000), balanceChangeTestAccountEntry(source, 900),
+		),
+		UnsafeMeta: xdr.TransactionMeta{
+			V: 2,
+			V2: &xdr.TransactionMetaV2{
+				Operations: []xdr.OperationMeta{{Changes: opChanges}},
+			},
+		},
+		PostTxApplyFeeChanges: balanceChangeTestUpdate(
+			balanceChangeTestAccountEntry(source, 800), balanceChangeTestAccountEntry(source, 850),
+		),
+		LedgerVersion: 22,
+	}
+
+	transactionID := toid.New(20, 1, 0).ToInt64()
+	operationID := toid.New(20, 1, 1).ToInt64()
+	native := assetLoader.GetFuture(history.AssetKeyFromXDR(xdr.MustNewNativeAsset()))
+	for _, change := range []history.InsertBalanceChange{
+		{
+			OperationID: transactionID, Order: 1, Account: accountLoader.GetFuture(source), Asset: native,
+			Reason: history.BalanceChangeReasonFee, AmountBefore: 1000, AmountAfter: 900,
+		},
+		{
+			OperationID: operationID, Order: 1, Account: accountLoader.GetFuture(source), Asset: native,
+			Reason: history.BalanceChangeReasonPayment, AmountBefore: 900, AmountAfter: 800,
+		},
+		{
+			OperationID: operationID, Order: 2, Account: accountLoader.GetFuture(seller), Asset: native,
+			Reason: history.BalanceChangeReasonTrade, AmountBefore: 500, AmountAfter: 600,
+		},
+		{
+			OperationID: operationID, Order: 3, Account: accountLoader.GetFuture(destination),
+			Asset:  assetLoader.GetFuture(history.AssetKeyFromXDR(usd)),
+			Reason: history.BalanceChangeReasonPayment, AmountBefore: 10, AmountAfter: 60,
+		},
+		{
+			OperationID: transactionID, Order: 2, Account: accountLoader.GetFuture(source), Asset: native,
+			Reason: history.BalanceChangeReasonFee, AmountBefore: 800, AmountAfter: 850,
+		},
+	} {
+		change.TransactionID = transactionID
+		change.LedgerCloseTime = closedAt
+		batch.On("Add", change).Return(nil).Once()
+	}
+	batch.On("Exec", ctx, session).Return(nil).Once()
+
+	require.NoError(t, processor.ProcessTransaction(lcm, transaction))
+	require.NoError(t, processor.Flush(ctx, session))
+}
@@ -138,7 +138,7 @@ func (s *ContractAssetStatSet) ingestAssetContractMetadata(change ingest.Change)
 	if !found {
 		return false, nil
 	}
-	keyHash, err := getKeyHash(*change.Post)
+	keyHash, err := LedgerEntryKeyHash(*change.Post)
 	if err != nil {
 		return false, err
 	}
@@ -153,7 +153,9 @@ func (s *ContractAssetStatSet) ingestAssetContractMetadata(change ingest.Change)
 	return false, nil
 }
 
-func getKeyHash(ledgerEntry xdr.LedgerEntry) (xdr.Hash, error) {
+// LedgerEntryKeyHash returns the hash of the ledger key of the given entry
+// which is used to match entries with their TTL.
+func LedgerEntryKeyHash(ledgerEntry xdr.LedgerEntry) (xdr.Hash, error) {
 	lk, err := ledgerEntry.LedgerKey()
 	if err != nil {
 		return xdr.Hash{}, errors.Wrap(err, "could not extract ledger key")
@@ -173,14 +175,14 @@ func (s *ContractAssetStatSet) ingestContractAssetBalance(change ingest.Change)
 			return nil
 		}
 
-		_, postAmt, postOk := sac.ContractBalanceFromContractData(*change.Post, s.networkPassphrase)
+		holder, postAmt, postOk := sac.ContractBalanceFromContractData(*change.Post, s.networkPassphrase)
 		// we only ingest created ledger entries if we determine that they resemble the shape of
 		// a Stellar Asset Contract balance ledger entry
 		if !postOk {
 			return nil
 		}
 
-		keyHash, err := getKeyHash(*change.Post)
+		keyHash, err := LedgerEntryKeyHash(*change.Post)
 		if err != nil {
 			return err
 		}
@@ -189,10 +191,12 @@ func (s *ContractAssetStatSet) ingestContractAssetBalance(change ingest.Change)
 			return nil
 		}
 		s.createdBalances = append(s.createdBalances, history.ContractAssetBalance{
-			KeyHash:          keyHash[:],
-			ContractID:       (*pContractID)[:],
-			Amount:           postAmt.String(),
-			ExpirationLedger: expirationLedger,
+			KeyHash:            keyHash[:],
+			ContractID:         (*pContractID)[:],
+			HolderContractID:   holder[:],
+			Amount:             postAmt.String(),
+			ExpirationLedger:   expirationLedger,
+			LastModifiedLedger: uint32(change.Post.LastModifiedLedgerSeq),
 		})
 
 		stat := s.getContractAssetStat(*pContractID)
@@ -205,7 +209,7 @@ func (s *ContractAssetStatSet) ingestContractAssetBalance(change ingest.Change)
 			return nil
 		}
 
-		keyHash, err := getKeyHash(*change.Pre)
+		keyHash, err := LedgerEntryKeyHash(*change.Pre)
 		if err != nil {
 			return err
 		}
@@ -237,7 +241,7 @@ func (s *ContractAssetStatSet) ingestContractAssetBalance(change ingest.Change)
 			return nil
 		}
 
-		keyHash, err := getKeyHash(*change.Post)
+		keyHash, err := LedgerEntryKeyHash(*change.Post)
 		if err != nil {
 			return err
 		}
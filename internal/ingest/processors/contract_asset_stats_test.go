@@ -47,7 +47,7 @@ func TestAddContractData(t *testing.T) {
 
 	xlmContractData, err := sac.AssetToContractData(true, "", "", xlmID)
 	assert.NoError(t, err)
-	xlmAssetKeyHash, err := getKeyHash(xdr.LedgerEntry{
+	xlmAssetKeyHash, err := LedgerEntryKeyHash(xdr.LedgerEntry{
 		Data: xlmContractData,
 	})
 	assert.NoError(t, err)
@@ -62,7 +62,7 @@ func TestAddContractData(t *testing.T) {
 
 	uniContractData, err := sac.AssetToContractData(false, "UNI", etherIssuer, uniID)
 	assert.NoError(t, err)
-	uniAssetKeyHash, err := getKeyHash(xdr.LedgerEntry{
+	uniAssetKeyHash, err := LedgerEntryKeyHash(xdr.LedgerEntry{
 		Data: uniContractData,
 	})
 	assert.NoError(t, err)
@@ -98,7 +98,7 @@ func TestAddContractData(t *testing.T) {
 
 	usdcContractData, err := sac.AssetToContractData(false, "USDC", usdcIssuer, usdcID)
 	assert.NoError(t, err)
-	usdcAssetKeyHash, err := getKeyHash(xdr.LedgerEntry{
+	usdcAssetKeyHash, err := LedgerEntryKeyHash(xdr.LedgerEntry{
 		Data: usdcContractData,
 	})
 	assert.NoError(t, err)
@@ -113,7 +113,7 @@ func TestAddContractData(t *testing.T) {
 
 	etherContractData, err := sac.AssetToContractData(false, "ETHER", etherIssuer, etherID)
 	assert.NoError(t, err)
-	etherAssetKeyHash, err := getKeyHash(xdr.LedgerEntry{
+	etherAssetKeyHash, err := LedgerEntryKeyHash(xdr.LedgerEntry{
 		Data: etherContractData,
 	})
 	assert.NoError(t, err)
@@ -193,12 +193,14 @@ func TestAddContractData(t *testing.T) {
 		{
 			KeyHash:          uniBalanceKeyHash[:],
 			ContractID:       uniID[:],
+			HolderContractID: make([]byte, 32),
 			Amount:           "0",
 			ExpirationLedger: 150,
 		},
 		{
 			KeyHash:          otherEtherBalanceKeyHash[:],
 			ContractID:       etherID[:],
+			HolderContractID: append([]byte{1}, make([]byte, 31)...),
 			Amount:           "150",
 			ExpirationLedger: 150,
 		},
@@ -238,7 +240,7 @@ func TestGetCreatedAssetContractsRequiresExpiration(t *testing.T) {
 
 	usdcContractData, err := sac.AssetToContractData(false, "USDC", usdcIssuer, usdcID)
 	assert.NoError(t, err)
-	usdcAssetKeyHash, err := getKeyHash(xdr.LedgerEntry{
+	usdcAssetKeyHash, err := LedgerEntryKeyHash(xdr.LedgerEntry{
 		Data: usdcContractData,
 	})
 	assert.NoError(t, err)
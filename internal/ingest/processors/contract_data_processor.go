@@ -0,0 +1,185 @@
+package processors
+
+import (
+	"context"
+
+	"github.com/stellar/go-stellar-sdk/ingest"
+	"github.com/stellar/go-stellar-sdk/strkey"
+	"github.com/stellar/go-stellar-sdk/support/errors"
+	"github.com/stellar/go-stellar-sdk/xdr"
+	"github.com/stellar/stellar-horizon/internal/db2/history"
+)
+
+// ContractDataProcessor maintains the contract_data table which holds the
+// current persistent contract data entries (including contract instances).
+// Temporary entries are not stored.
+type ContractDataProcessor struct {
+	qContractData history.QContractData
+
+	upserted []history.ContractData
+	removed  []xdr.Hash
+	// expirations contains all the TTLs seen by the processor. Contract data
+	// entries and their TTLs can be split across batches so it's not reset
+	// on commit.
+	expirations map[xdr.Hash]uint32
+	// pendingExpirations are the keys of TTLs which were not yet written to the
+	// db.
+	pendingExpirations []xdr.Hash
+}
+
+func NewContractDataProcessor(Q history.QContractData) *ContractDataProcessor {
+	p := &ContractDataProcessor{
+		qContractData: Q,
+		expirations:   map[xdr.Hash]uint32{},
+	}
+	p.reset()
+	return p
+}
+
+func (p *ContractDataProcessor) Name() string {
+	return "processors.ContractDataProcessor"
+}
+
+func (p *ContractDataProcessor) reset() {
+	p.upserted = []history.ContractData{}
+	p.removed = []xdr.Hash{}
+	p.pendingExpirations = []xdr.Hash{}
+}
+
+// IsStoredContractData returns true if the given contract data entry is
+// stored in the contract_data table.
+func IsStoredContractData(entry xdr.LedgerEntry) bool {
+	contractData := entry.Data.MustContractData()
+	return contractData.Durability == xdr.ContractDataDurabilityPersistent &&
+		contractData.Contract.Type == xdr.ScAddressTypeScAddressTypeContract
+}
+
+func (p *ContractDataProcessor) ProcessChange(ctx context.Context, change ingest.Change) error {
+	switch change.Type {
+	case xdr.LedgerEntryTypeContractData:
+		if err := p.addContractDataChange(change); err != nil {
+			return err
+		}
+	case xdr.LedgerEntryTypeTtl:
+		// Removed TTLs are ignored, the contract data entry is removed together
+		// with its TTL.
+		if change.Post == nil {
+			return nil
+		}
+		ttl := change.Post.Data.MustTtl()
+		p.expirations[ttl.KeyHash] = uint32(ttl.LiveUntilLedgerSeq)
+		p.pendingExpirations = append(p.pendingExpirations, ttl.KeyHash)
+	default:
+		return nil
+	}
+
+	if len(p.upserted)+len(p.removed)+len(p.pendingExpirations) > maxBatchSize {
+		if err := p.Commit(ctx); err != nil {
+			return errors.Wrap(err, "error in Commit")
+		}
+	}
+
+	return nil
+}
+
+func (p *ContractDataProcessor) addContractDataChange(change ingest.Change) error {
+	if change.Post == nil {
+		// Removed (or evicted)
+		if !IsStoredContractData(*change.Pre) {
+			return nil
+		}
+		keyHash, err := LedgerEntryKeyHash(*change.Pre)
+		if err != nil {
+			return err
+		}
+		p.removed = append(p.removed, keyHash)
+		return nil
+	}
+
+	// Created, restored or updated
+	if !IsStoredContractData(*change.Post) {
+		return nil
+	}
+	row, err := ContractDataEntryToRow(*change.Post)
+	if err != nil {
+		return err
+	}
+	p.upserted = append(p.upserted, row)
+	return nil
+}
+
+func (p *ContractDataProcessor) Commit(ctx context.Context) error {
+	defer p.reset()
+
+	if len(p.removed) > 0 {
+		if _, err := p.qContractData.RemoveContractData(ctx, p.removed); err != nil {
+			return errors.Wrap(err, "error removing contract data")
+		}
+	}
+
+	if len(p.upserted) > 0 {
+		if err := p.qContractData.UpsertContractData(ctx, p.upserted); err != nil {
+			return errors.Wrap(err, "error upserting contract data")
+		}
+	}
+
+	// Update expirations of the entries with TTL changes as well as the
+	// entries inserted in this batch whose TTL has been seen in a previous
+	// batch.
+	keys := p.pendingExpirations
+	for _, row := range p.upserted {
+		var keyHash xdr.Hash
+		copy(keyHash[:], row.KeyHash)
+		if _, ok := p.expirations[keyHash]; ok {
+			keys = append(keys, keyHash)
+		}
+	}
+	if len(keys) == 0 {
+		return nil
+	}
+
+	expirationLedgers := make([]uint32, 0, len(keys))
+	for _, key := range keys {
+		expirationLedgers = append(expirationLedgers, p.expirations[key])
+	}
+	if err := p.qContractData.UpdateContractDataExpirations(ctx, keys, expirationLedgers); err != nil {
+		return errors.Wrap(err, "error updating contract data expirations")
+	}
+
+	return nil
+}
+
+// ContractDataEntryToRow converts a contract data ledger entry into a
+// contract_data row. The expiration ledger is not set because it's stored
+// in a separate TTL ledger entry.
+func ContractDataEntryToRow(entry xdr.LedgerEntry) (history.ContractData, error) {
+	contractData := entry.Data.MustContractData()
+
+	keyHash, err := LedgerEntryKeyHash(entry)
+	if err != nil {
+		return history.ContractData{}, err
+	}
+
+	contractID, err := strkey.Encode(strkey.VersionByteContract, contractData.Contract.ContractId[:])
+	if err != nil {
+		return history.ContractData{}, errors.Wrap(err, "could not encode contract id")
+	}
+
+	key, err := xdr.MarshalBase64(contractData.Key)
+	if err != nil {
+		return history.ContractData{}, errors.Wrap(err, "could not encode contract data key")
+	}
+
+	val, err := xdr.MarshalBase64(contractData.Val)
+	if err != nil {
+		return history.ContractData{}, errors.Wrap(err, "could not encode contract data value")
+	}
+
+	return history.ContractData{
+		KeyHash:            keyHash[:],
+		ContractID:         contractID,
+		Key:                key,
+		Val:                val,
+		LastModifiedLedger: uint32(entry.LastModifiedLedgerSeq),
+	}, nil
+}
@@ -0,0 +1,144 @@
+//lint:file-ignore U1001 Ignore all unused code, staticcheck doesn't understand testify/suite
+
+package processors
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+
+	"github.com/stellar/go-stellar-sdk/ingest"
+	"github.com/stellar/go-stellar-sdk/xdr"
+	"github.com/stellar/stellar-horizon/internal/db2/history"
+)
+
+func makeContractDataEntry(contractID xdr.ContractId, key string, val uint32, durability xdr.ContractDataDurability) xdr.LedgerEntry {
+	sym := xdr.ScSymbol(key)
+	v := xdr.Uint32(val)
+	return xdr.LedgerEntry{
+		LastModifiedLedgerSeq: 10,
+		Data: xdr.LedgerEntryData{
+			Type: xdr.LedgerEntryTypeContractData,
+			ContractData: &xdr.ContractDataEntry{
+				Contract: xdr.ScAddress{
+					Type:       xdr.ScAddressTypeScAddressTypeContract,
+					ContractId: &contractID,
+				},
+				Key:        xdr.ScVal{Type: xdr.ScValTypeScvSymbol, Sym: &sym},
+				Durability: durability,
+				Val:        xdr.ScVal{Type: xdr.ScValTypeScvU32, U32: &v},
+			},
+		},
+	}
+}
+
+func makeTTLChange(keyHash xdr.Hash, pre, post uint32) ingest.Change {
+	makeEntry := func(liveUntil uint32) *xdr.LedgerEntry {
+		if liveUntil == 0 {
+			return nil
+		}
+		return &xdr.LedgerEntry{
+			Data: xdr.LedgerEntryData{
+				Type: xdr.LedgerEntryTypeTtl,
+				Ttl: &xdr.TtlEntry{
+					KeyHash:            keyHash,
+					LiveUntilLedgerSeq: xdr.Uint32(liveUntil),
+				},
+			},
+		}
+	}
+	return ingest.Change{
+		Type: xdr.LedgerEntryTypeTtl,
+		Pre:  makeEntry(pre),
+		Post: makeEntry(post),
+	}
+}
+
+type ContractDataProcessorTestSuiteState struct {
+	suite.Suite
+	ctx       context.Context
+	processor *ContractDataProcessor
+	mockQ     *history.MockQContractData
+}
+
+func TestContractDataProcessorTestSuiteState(t *testing.T) {
+	suite.Run(t, new(ContractDataProcessorTestSuiteState))
+}
+
+func (s *ContractDataProcessorTestSuiteState) SetupTest() {
+	s.ctx = context.Background()
+	s.mockQ = &history.MockQContractData{}
+	s.processor = NewContractDataProcessor(s.mockQ)
+}
+
+func (s *ContractDataProcessorTestSuiteState) TearDownTest() {
+	s.mockQ.AssertExpectations(s.T())
+}
+
+func (s *ContractDataProcessorTestSuiteState) TestNoEntries() {
+	// Nothing processed, assertions in TearDownTest.
+	s.Assert().NoError(s.processor.Commit(s.ctx))
+}
+
+func (s *ContractDataProcessorTestSuiteState) TestIgnoresTemporaryEntries() {
+	entry := makeContractDataEntry(xdr.ContractId{1}, "counter", 1, xdr.ContractDataDurabilityTemporary)
+	s.Assert().NoError(s.processor.ProcessChange(s.ctx, ingest.Change{
+		Type: xdr.LedgerEntryTypeContractData,
+		Post: &entry,
+	}))
+	s.Assert().NoError(s.processor.Commit(s.ctx))
+}
+
+func (s *ContractDataProcessorTestSuiteState) TestCreatedEntriesWithTTLAcrossBatches() {
+	first := makeContractDataEntry(xdr.ContractId{1}, "first", 1, xdr.ContractDataDurabilityPersistent)
+	second := makeContractDataEntry(xdr.ContractId{1}, "second", 2, xdr.ContractDataDurabilityPersistent)
+	firstRow, err := ContractDataEntryToRow(first)
+	s.Require().NoError(err)
+	secondRow, err := ContractDataEntryToRow(second)
+	s.Require().NoError(err)
+	firstKeyHash, err := LedgerEntryKeyHash(first)
+	s.Require().NoError(err)
+	secondKeyHash, err := LedgerEntryKeyHash(second)
+	s.Require().NoError(err)
+
+	// first batch: the first entry and the TTL of the second entry
+	s.Assert().NoError(s.processor.ProcessChange(s.ctx, ingest.Change{
+		Type: xdr.LedgerEntryTypeContractData,
+		Post: &first,
+	}))
+	s.Assert().NoError(s.processor.ProcessChange(s.ctx, makeTTLChange(secondKeyHash, 0, 200)))
+
+	s.mockQ.On("UpsertContractData", s.ctx, []history.ContractData{firstRow}).Return(nil).Once()
+	s.mockQ.On("UpdateContractDataExpirations", s.ctx, []xdr.Hash{secondKeyHash}, []uint32{200}).
+		Return(nil).Once()
+	s.Assert().NoError(s.processor.Commit(s.ctx))
+
+	// second batch: the second entry and the TTL of the first entry
+	s.Assert().NoError(s.processor.ProcessChange(s.ctx, makeTTLChange(firstKeyHash, 0, 100)))
+	s.Assert().NoError(s.processor.ProcessChange(s.ctx, ingest.Change{
+		Type: xdr.LedgerEntryTypeContractData,
+		Post: &second,
+	}))
+
+	s.mockQ.On("UpsertContractData", s.ctx, []history.ContractData{secondRow}).Return(nil).Once()
+	s.mockQ.On("UpdateContractDataExpirations", s.ctx, []xdr.Hash{firstKeyHash, secondKeyHash}, []uint32{100, 200}).
+		Return(nil).Once()
+	s.Assert().NoError(s.processor.Commit(s.ctx))
+}
+
+func (s *ContractDataProcessorTestSuiteState) TestRemovedEntry() {
+	entry := makeContractDataEntry(xdr.ContractId{1}, "counter", 1, xdr.ContractDataDurabilityPersistent)
+	keyHash, err := LedgerEntryKeyHash(entry)
+	s.Require().NoError(err)
+
+	s.Assert().NoError(s.processor.ProcessChange(s.ctx, ingest.Change{
+		Type: xdr.LedgerEntryTypeContractData,
+		Pre:  &entry,
+	}))
+	// removed TTLs are ignored
+	s.Assert().NoError(s.processor.ProcessChange(s.ctx, makeTTLChange(keyHash, 100, 0)))
+
+	s.mockQ.On("RemoveContractData", s.ctx, []xdr.Hash{keyHash}).Return(int64(1), nil).Once()
+	s.Assert().NoError(s.processor.Commit(s.ctx))
+}
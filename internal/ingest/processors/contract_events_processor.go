@@ -0,0 +1,92 @@
+package processors
+
+import (
+	"context"
+
+	"github.com/guregu/null"
+
+	"github.com/stellar/go-stellar-sdk/ingest"
+	"github.com/stellar/go-stellar-sdk/strkey"
+	"github.com/stellar/go-stellar-sdk/support/db"
+	"github.com/stellar/go-stellar-sdk/support/errors"
+	"github.com/stellar/go-stellar-sdk/toid"
+	"github.com/stellar/go-stellar-sdk/xdr"
+	"github.com/stellar/stellar-horizon/internal/db2/history"
+)
+
+// ContractEventsProcessor stores the contract events emitted by the
+// operations of successful transactions in the history_contract_events table.
+type ContractEventsProcessor struct {
+	batch history.ContractEventBatchInsertBuilder
+}
+
+func NewContractEventsProcessor(batch history.ContractEventBatchInsertBuilder) *ContractEventsProcessor {
+	return &ContractEventsProcessor{
+		batch: batch,
+	}
+}
+
+func (p *ContractEventsProcessor) Name() string {
+	return "processors.ContractEventsProcessor"
+}
+
+func (p *ContractEventsProcessor) ProcessTransaction(lcm xdr.LedgerCloseMeta, transaction ingest.LedgerTransaction) error {
+	// Failed transactions do not emit contract events
+	if !transaction.Result.Successful() {
+		return nil
+	}
+
+	txEvents, err := transaction.GetTransactionEvents()
+	if err != nil {
+		return errors.Wrap(err, "could not read transaction events")
+	}
+
+	sequence := int32(lcm.LedgerSequence())
+	transactionID := toid.New(sequence, int32(transaction.Index), 0).ToInt64()
+	for opIndex, events := range txEvents.OperationEvents {
+		operationID := toid.New(sequence, int32(transaction.Index), int32(opIndex+1)).ToInt64()
+		for order, event := range events {
+			if err := p.addEvent(operationID, uint32(order+1), transactionID, event); err != nil {
+				return errors.Wrapf(err, "could not add contract event %d of operation %d", order, operationID)
+			}
+		}
+	}
+
+	return nil
+}
+
+func (p *ContractEventsProcessor) addEvent(operationID int64, order uint32, transactionID int64, event xdr.ContractEvent) error {
+	var contractID null.String
+	if event.ContractId != nil {
+		encoded, err := strkey.Encode(strkey.VersionByteContract, event.ContractId[:])
+		if err != nil {
+			return err
+		}
+		contractID = null.StringFrom(encoded)
+	}
+
+	body, ok := event.Body.GetV0()
+	if !ok {
+		return errors.Errorf("unsupported contract event body version: %d", event.Body.V)
+	}
+
+	topics := make([]string, 0, len(body.Topics))
+	for _, topic := range body.Topics {
+		encoded, err := xdr.MarshalBase64(topic)
+		if err != nil {
+			return err
+		}
+		topics = append(topics, encoded)
+	}
+
+	data, err := xdr.MarshalBase64(body.Data)
+	if err != nil {
+		return err
+	}
+
+	return p.batch.Add(operationID, order, transactionID, contractID, event.Type, topics, data)
+}
+
+func (p *ContractEventsProcessor) Flush(ctx context.Context, session db.SessionInterface) error {
+	return p.batch.Exec(ctx, session)
+}
@@ -0,0 +1,140 @@
+//lint:file-ignore U1001 Ignore all unused code, staticcheck doesn't understand testify/suite
+
+package processors
+
+import (
+	"context"
+	"testing"
+
+	"github.com/guregu/null"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/stellar/go-stellar-sdk/strkey"
+	"github.com/stellar/go-stellar-sdk/support/db"
+	"github.com/stellar/go-stellar-sdk/toid"
+	"github.com/stellar/go-stellar-sdk/xdr"
+	"github.com/stellar/stellar-horizon/internal/db2/history"
+)
+
+type ContractEventsProcessorTestSuiteLedger struct {
+	suite.Suite
+	ctx              context.Context
+	processor        *ContractEventsProcessor
+	mockSession      *db.MockSession
+	mockBatchBuilder *history.MockContractEventBatchInsertBuilder
+
+	lcm xdr.LedgerCloseMeta
+}
+
+func TestContractEventsProcessorTestSuiteLedger(t *testing.T) {
+	suite.Run(t, new(ContractEventsProcessorTestSuiteLedger))
+}
+
+func (s *ContractEventsProcessorTestSuiteLedger) SetupTest() {
+	s.ctx = context.Background()
+	s.mockBatchBuilder = &history.MockContractEventBatchInsertBuilder{}
+	s.lcm = xdr.LedgerCloseMeta{
+		V0: &xdr.LedgerCloseMetaV0{
+			LedgerHeader: xdr.LedgerHeaderHistoryEntry{
+				Header: xdr.LedgerHeader{
+					LedgerSeq: xdr.Uint32(20),
+				},
+			},
+		},
+	}
+
+	s.processor = NewContractEventsProcessor(s.mockBatchBuilder)
+}
+
+func (s *ContractEventsProcessorTestSuiteLedger) TearDownTest() {
+	s.mockBatchBuilder.AssertExpectations(s.T())
+}
+
+func (s *ContractEventsProcessorTestSuiteLedger) makeEvent(contractID *xdr.ContractId, topics []xdr.ScVal, data xdr.ScVal) xdr.ContractEvent {
+	eventType := xdr.ContractEventTypeSystem
+	if contractID != nil {
+		eventType = xdr.ContractEventTypeContract
+	}
+	return xdr.ContractEvent{
+		ContractId: contractID,
+		Type:       eventType,
+		Body: xdr.ContractEventBody{
+			V: 0,
+			V0: &xdr.ContractEventV0{
+				Topics: topics,
+				Data:   data,
+			},
+		},
+	}
+}
+
+func (s *ContractEventsProcessorTestSuiteLedger) TestEmptyContractEvents() {
+	s.mockBatchBuilder.On("Exec", s.ctx, s.mockSession).Return(nil).Once()
+
+	err := s.processor.Flush(s.ctx, s.mockSession)
+	s.Assert().NoError(err)
+}
+
+func (s *ContractEventsProcessorTestSuiteLedger) TestIgnoresFailedTransactions() {
+	txn := createTransaction(false, 1, 2)
+	s.Assert().NoError(s.processor.ProcessTransaction(s.lcm, txn))
+}
+
+func (s *ContractEventsProcessorTestSuiteLedger) TestInsertsOperationEvents() {
+	sym := xdr.ScSymbol("transfer")
+	topic := xdr.ScVal{Type: xdr.ScValTypeScvSymbol, Sym: &sym}
+	amount := xdr.Uint32(100)
+	data := xdr.ScVal{Type: xdr.ScValTypeScvU32, U32: &amount}
+	contractID := xdr.ContractId{1, 2, 3}
+
+	txn := createTransaction(true, 2, 2)
+	txn.Index = 3
+	txn.UnsafeMeta = xdr.TransactionMeta{
+		V: 4,
+		V4: &xdr.TransactionMetaV4{
+			Operations: []xdr.OperationMetaV2{
+				{},
+				{
+					Events: []xdr.ContractEvent{
+						s.makeEvent(&contractID, []xdr.ScVal{topic, data}, data),
+						s.makeEvent(nil, []xdr.ScVal{topic}, data),
+					},
+				},
+			},
+		},
+	}
+
+	encodedContractID, err := strkey.Encode(strkey.VersionByteContract, contractID[:])
+	s.Assert().NoError(err)
+	encodedTopic, err := xdr.MarshalBase64(topic)
+	s.Assert().NoError(err)
+	encodedData, err := xdr.MarshalBase64(data)
+	s.Assert().NoError(err)
+
+	txID := toid.New(20, 3, 0).ToInt64()
+	opID := toid.New(20, 3, 2).ToInt64()
+	s.mockBatchBuilder.On(
+		"Add",
+		opID,
+		uint32(1),
+		txID,
+		null.StringFrom(encodedContractID),
+		xdr.ContractEventTypeContract,
+		[]string{encodedTopic, encodedData},
+		encodedData,
+	).Return(nil).Once()
+	s.mockBatchBuilder.On(
+		"Add",
+		opID,
+		uint32(2),
+		txID,
+		null.String{},
+		xdr.ContractEventTypeSystem,
+		[]string{encodedTopic},
+		encodedData,
+	).Return(nil).Once()
+	s.mockBatchBuilder.On("Exec", s.ctx, s.mockSession).Return(nil).Once()
+
+	s.Assert().NoError(s.processor.ProcessTransaction(s.lcm, txn))
+	s.Assert().NoError(s.processor.Flush(s.ctx, s.mockSession))
+}
@@ -3,6 +3,7 @@ package processors
 import (
 	"context"
 	"sort"
+	"time"
 
 	"github.com/stellar/go-stellar-sdk/ingest"
 	"github.com/stellar/go-stellar-sdk/support/db"
@@ -13,20 +14,37 @@ import (
 )
 
 type LiquidityPoolsTransactionProcessor struct {
-	lpLoader *history.LiquidityPoolLoader
-	txBatch  history.TransactionLiquidityPoolBatchInsertBuilder
-	opBatch  history.OperationLiquidityPoolBatchInsertBuilder
+	lpLoader      *history.LiquidityPoolLoader
+	txBatch       history.TransactionLiquidityPoolBatchInsertBuilder
+	opBatch       history.OperationLiquidityPoolBatchInsertBuilder
+	snapshotBatch history.LiquidityPoolSnapshotBatchInsertBuilder
+	snapshots     map[liquidityPoolSnapshotKey]*liquidityPoolSnapshot
+}
+
+// liquidityPoolSnapshotKey identifies the snapshot of a pool in a ledger, the
+// processor can be used across several ledgers when reingesting.
+type liquidityPoolSnapshotKey struct {
+	sequence uint32
+	poolID   string
+}
+
+type liquidityPoolSnapshot struct {
+	history.InsertLiquidityPoolSnapshot
+	assetA xdr.Asset
 }
 
 func NewLiquidityPoolsTransactionProcessor(
 	lpLoader *history.LiquidityPoolLoader,
 	txBatch history.TransactionLiquidityPoolBatchInsertBuilder,
 	opBatch history.OperationLiquidityPoolBatchInsertBuilder,
+	snapshotBatch history.LiquidityPoolSnapshotBatchInsertBuilder,
 ) *LiquidityPoolsTransactionProcessor {
 	return &LiquidityPoolsTransactionProcessor{
-		lpLoader: lpLoader,
-		txBatch:  txBatch,
-		opBatch:  opBatch,
+		lpLoader:      lpLoader,
+		txBatch:       txBatch,
+		opBatch:       opBatch,
+		snapshotBatch: snapshotBatch,
+		snapshots:     map[liquidityPoolSnapshotKey]*liquidityPoolSnapshot{},
 	}
 }
 
@@ -45,6 +63,100 @@ func (p *LiquidityPoolsTransactionProcessor) ProcessTransaction(lcm xdr.LedgerCl
 		return err
 	}
 
+	err = p.updateLiquidityPoolSnapshots(lcm, transaction)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// updateLiquidityPoolSnapshots updates the snapshots of the pools changed by
+// the transaction to their state after the transaction, and adds the trades
+// executed against the pools to their volumes.
+func (p *LiquidityPoolsTransactionProcessor) updateLiquidityPoolSnapshots(lcm xdr.LedgerCloseMeta, transaction ingest.LedgerTransaction) error {
+	sequence := lcm.LedgerSequence()
+	changes, err := transaction.GetChanges()
+	if err != nil {
+		return err
+	}
+
+	for _, c := range changes {
+		if c.Type != xdr.LedgerEntryTypeLiquidityPool {
+			continue
+		}
+		if c.Pre == nil && c.Post == nil {
+			return errors.New("Invalid io.Change: change.Pre == nil && change.Post == nil")
+		}
+
+		entry := c.Post
+		if entry == nil {
+			entry = c.Pre
+		}
+		lp := entry.Data.MustLiquidityPool()
+		cp := lp.Body.MustConstantProduct()
+		poolID := PoolIDToString(lp.LiquidityPoolId)
+
+		key := liquidityPoolSnapshotKey{sequence: sequence, poolID: poolID}
+		snapshot, ok := p.snapshots[key]
+		if !ok {
+			snapshot = &liquidityPoolSnapshot{
+				InsertLiquidityPoolSnapshot: history.InsertLiquidityPoolSnapshot{
+					LiquidityPoolID: p.lpLoader.GetFuture(poolID),
+					LedgerID:        toid.New(int32(sequence), 0, 0).ToInt64(),
+					ClosedAt:        time.Unix(lcm.LedgerCloseTime(), 0).UTC(),
+				},
+			}
+			p.snapshots[key] = snapshot
+		}
+
+		snapshot.assetA = cp.Params.AssetA
+		snapshot.Fee = uint32(cp.Params.Fee)
+		if c.Post == nil {
+			// the pool was removed once its last trustline was removed
+			snapshot.ReserveA, snapshot.ReserveB = 0, 0
+			snapshot.TotalShares, snapshot.TrustlineCount = 0, 0
+			continue
+		}
+		snapshot.ReserveA = int64(cp.ReserveA)
+		snapshot.ReserveB = int64(cp.ReserveB)
+		snapshot.TotalShares = int64(cp.TotalPoolShares)
+		snapshot.TrustlineCount = int64(cp.PoolSharesTrustLineCount)
+	}
+
+	if !transaction.Result.Successful() {
+		return nil
+	}
+	opResults, ok := transaction.Result.OperationResults()
+	if !ok {
+		return errors.New("transaction has no operation results")
+	}
+	for opidx, op := range transaction.Envelope.Operations() {
+		trades, _, _ := operationClaimAtoms(op, opResults[opidx])
+		for _, trade := range trades {
+			if trade.Type != xdr.ClaimAtomTypeClaimAtomTypeLiquidityPool {
+				continue
+			}
+			// see TradeProcessor.extractTrades
+			if trade.AmountBought() == 0 && trade.AmountSold() == 0 {
+				continue
+			}
+
+			poolID := PoolIDToString(trade.MustLiquidityPool().LiquidityPoolId)
+			snapshot, ok := p.snapshots[liquidityPoolSnapshotKey{sequence: sequence, poolID: poolID}]
+			if !ok {
+				return errors.Errorf("liquidity pool %s traded without a change", poolID)
+			}
+			snapshot.TradeCount++
+			// the pool charges its fee on the asset it receives
+			if trade.AssetBought().Equals(snapshot.assetA) {
+				snapshot.VolumeA += int64(trade.AmountBought())
+			} else {
+				snapshot.VolumeB += int64(trade.AmountBought())
+			}
+		}
+	}
+
 	return nil
 }
 
@@ -156,5 +268,24 @@ func (p *LiquidityPoolsTransactionProcessor) Flush(ctx context.Context, session
 		return errors.Wrap(err, "Could not flush operation liquidity pools to db")
 	}
 
+	keys := make([]liquidityPoolSnapshotKey, 0, len(p.snapshots))
+	for key := range p.snapshots {
+		keys = append(keys, key)
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		if keys[i].sequence != keys[j].sequence {
+			return keys[i].sequence < keys[j].sequence
+		}
+		return keys[i].poolID < keys[j].poolID
+	})
+	for _, key := range keys {
+		if err := p.snapshotBatch.Add(p.snapshots[key].InsertLiquidityPoolSnapshot); err != nil {
+			return errors.Wrap(err, "Could not add liquidity pool snapshot to batch")
+		}
+	}
+	if err := p.snapshotBatch.Exec(ctx, session); err != nil {
+		return errors.Wrap(err, "Could not flush liquidity pool snapshots to db")
+	}
+
 	return nil
 }
@@ -5,7 +5,9 @@ package processors
 import (
 	"context"
 	"testing"
+	"time"
 
+	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/suite"
 
 	"github.com/stellar/go-stellar-sdk/support/db"
@@ -22,6 +24,7 @@ type LiquidityPoolsTransactionProcessorTestSuiteLedger struct {
 	lpLoader                          *history.LiquidityPoolLoader
 	mockTransactionBatchInsertBuilder *history.MockTransactionLiquidityPoolBatchInsertBuilder
 	mockOperationBatchInsertBuilder   *history.MockOperationLiquidityPoolBatchInsertBuilder
+	mockSnapshotBatchInsertBuilder    *history.MockLiquidityPoolSnapshotBatchInsertBuilder
 
 	lcm xdr.LedgerCloseMeta
 }
@@ -34,12 +37,16 @@ func (s *LiquidityPoolsTransactionProcessorTestSuiteLedger) SetupTest() {
 	s.ctx = context.Background()
 	s.mockTransactionBatchInsertBuilder = &history.MockTransactionLiquidityPoolBatchInsertBuilder{}
 	s.mockOperationBatchInsertBuilder = &history.MockOperationLiquidityPoolBatchInsertBuilder{}
+	s.mockSnapshotBatchInsertBuilder = &history.MockLiquidityPoolSnapshotBatchInsertBuilder{}
 	sequence := uint32(20)
 	s.lcm = xdr.LedgerCloseMeta{
 		V0: &xdr.LedgerCloseMetaV0{
 			LedgerHeader: xdr.LedgerHeaderHistoryEntry{
 				Header: xdr.LedgerHeader{
 					LedgerSeq: xdr.Uint32(sequence),
+					ScpValue: xdr.StellarValue{
+						CloseTime: 1000,
+					},
 				},
 			},
 		},
@@ -50,17 +57,20 @@ func (s *LiquidityPoolsTransactionProcessorTestSuiteLedger) SetupTest() {
 		s.lpLoader,
 		s.mockTransactionBatchInsertBuilder,
 		s.mockOperationBatchInsertBuilder,
+		s.mockSnapshotBatchInsertBuilder,
 	)
 }
 
 func (s *LiquidityPoolsTransactionProcessorTestSuiteLedger) TearDownTest() {
 	s.mockTransactionBatchInsertBuilder.AssertExpectations(s.T())
 	s.mockOperationBatchInsertBuilder.AssertExpectations(s.T())
+	s.mockSnapshotBatchInsertBuilder.AssertExpectations(s.T())
 }
 
 func (s *LiquidityPoolsTransactionProcessorTestSuiteLedger) TestEmptyLiquidityPools() {
 	s.mockTransactionBatchInsertBuilder.On("Exec", s.ctx, s.mockSession).Return(nil).Once()
 	s.mockOperationBatchInsertBuilder.On("Exec", s.ctx, s.mockSession).Return(nil).Once()
+	s.mockSnapshotBatchInsertBuilder.On("Exec", s.ctx, s.mockSession).Return(nil).Once()
 
 	err := s.processor.Flush(context.Background(), s.mockSession)
 	s.Assert().NoError(err)
@@ -80,6 +90,7 @@ func (s *LiquidityPoolsTransactionProcessorTestSuiteLedger) testOperationInserts
 						Type: xdr.LedgerEntryTypeLiquidityPool,
 						LiquidityPool: &xdr.LiquidityPoolEntry{
 							LiquidityPoolId: poolID,
+							Body:            constantProductBody(),
 						},
 					},
 				},
@@ -94,6 +105,7 @@ func (s *LiquidityPoolsTransactionProcessorTestSuiteLedger) testOperationInserts
 						Type: xdr.LedgerEntryTypeLiquidityPool,
 						LiquidityPool: &xdr.LiquidityPoolEntry{
 							LiquidityPoolId: poolID,
+							Body:            constantProductBody(),
 						},
 					},
 				},
@@ -116,6 +128,19 @@ func (s *LiquidityPoolsTransactionProcessorTestSuiteLedger) testOperationInserts
 					},
 				},
 			}
+	} else {
+		txn.Result.Result.Result.Results =
+			&[]xdr.OperationResult{
+				{
+					Code: xdr.OperationResultCodeOpInner,
+					Tr: &xdr.OperationResultTr{
+						Type: body.Type,
+						LiquidityPoolDepositResult: &xdr.LiquidityPoolDepositResult{
+							Code: xdr.LiquidityPoolDepositResultCodeLiquidityPoolDepositSuccess,
+						},
+					},
+				},
+			}
 	}
 	txnID := toid.New(int32(s.lcm.LedgerSequence()), int32(txn.Index), 0).ToInt64()
 	opID := (&transactionOperationWrapper{
@@ -135,6 +160,10 @@ func (s *LiquidityPoolsTransactionProcessorTestSuiteLedger) testOperationInserts
 	s.mockOperationBatchInsertBuilder.On("Add", opID, s.lpLoader.GetFuture(hexID)).Return(nil).Once()
 	s.mockOperationBatchInsertBuilder.On("Exec", s.ctx, s.mockSession).Return(nil).Once()
 
+	// Prepare to process the pool snapshot successfully
+	s.mockSnapshotBatchInsertBuilder.On("Add", mock.AnythingOfType("history.InsertLiquidityPoolSnapshot")).Return(nil).Once()
+	s.mockSnapshotBatchInsertBuilder.On("Exec", s.ctx, s.mockSession).Return(nil).Once()
+
 	// Process the transaction
 	err := s.processor.ProcessTransaction(s.lcm, txn)
 	s.Assert().NoError(err)
@@ -201,7 +230,7 @@ func (s *LiquidityPoolsTransactionProcessorTestSuiteLedger) TestIngestLiquidityP
 					Type: xdr.LedgerEntryTypeLiquidityPool,
 					LiquidityPool: &xdr.LiquidityPoolEntry{
 						LiquidityPoolId: poolID,
-						Body:            xdr.LiquidityPoolEntryBody{},
+						Body:            constantProductBody(),
 					},
 				},
 			},
@@ -236,10 +265,105 @@ func (s *LiquidityPoolsTransactionProcessorTestSuiteLedger) TestIngestLiquidityP
 					Type: xdr.LedgerEntryTypeLiquidityPool,
 					LiquidityPool: &xdr.LiquidityPoolEntry{
 						LiquidityPoolId: poolID,
-						Body:            xdr.LiquidityPoolEntryBody{},
+						Body:            constantProductBody(),
 					},
 				},
 			},
 		},
 	)
 }
+
+func (s *LiquidityPoolsTransactionProcessorTestSuiteLedger) TestIngestLiquidityPoolSnapshots() {
+	assetDeposited := xdr.MustNewNativeAsset()
+	assetDisbursed := xdr.MustNewCreditAsset("EUR", "GAOQJGUAB7NI7K7I62ORBXMN3J4SSWQUQ7FOEPSDJ322W2HMCNWPHXFB")
+	pool := makePool(assetDeposited, assetDisbursed, 200, 400)
+	trade := xdr.ClaimAtom{
+		Type: xdr.ClaimAtomTypeClaimAtomTypeLiquidityPool,
+		LiquidityPool: &xdr.ClaimLiquidityAtom{
+			LiquidityPoolId: pool.LiquidityPoolId,
+			AssetBought:     assetDeposited,
+			AmountBought:    11,
+			AssetSold:       assetDisbursed,
+			AmountSold:      20,
+		},
+	}
+	txn, err := createTransactionForTrade(trade, 200, 400)
+	s.Assert().NoError(err)
+	txn.Result.Result.Result.Results = &[]xdr.OperationResult{
+		{
+			Code: xdr.OperationResultCodeOpInner,
+			Tr: &xdr.OperationResultTr{
+				Type: xdr.OperationTypePathPaymentStrictReceive,
+				PathPaymentStrictReceiveResult: &xdr.PathPaymentStrictReceiveResult{
+					Code: xdr.PathPaymentStrictReceiveResultCodePathPaymentStrictReceiveSuccess,
+					Success: &xdr.PathPaymentStrictReceiveResultSuccess{
+						Offers: []xdr.ClaimAtom{trade},
+					},
+				},
+			},
+		},
+	}
+
+	nextLedger := s.lcm
+	nextLedger.V0 = &xdr.LedgerCloseMetaV0{
+		LedgerHeader: xdr.LedgerHeaderHistoryEntry{
+			Header: xdr.LedgerHeader{
+				LedgerSeq: 21,
+				ScpValue: xdr.StellarValue{
+					CloseTime: 1005,
+				},
+			},
+		},
+	}
+
+	lp := s.lpLoader.GetFuture(PoolIDToString(pool.LiquidityPoolId))
+	s.mockTransactionBatchInsertBuilder.On("Add", mock.Anything, lp).Return(nil).Times(3)
+	s.mockTransactionBatchInsertBuilder.On("Exec", s.ctx, s.mockSession).Return(nil).Once()
+	s.mockOperationBatchInsertBuilder.On("Add", mock.Anything, lp).Return(nil).Times(3)
+	s.mockOperationBatchInsertBuilder.On("Exec", s.ctx, s.mockSession).Return(nil).Once()
+
+	// the trades of both transactions in ledger 20 are summed, the snapshots
+	// are flushed in ledger order
+	cp := pool.Body.MustConstantProduct()
+	snapshot := history.InsertLiquidityPoolSnapshot{
+		LiquidityPoolID: lp,
+		LedgerID:        toid.New(20, 0, 0).ToInt64(),
+		ClosedAt:        time.Unix(1000, 0).UTC(),
+		Fee:             uint32(cp.Params.Fee),
+		ReserveA:        int64(cp.ReserveA),
+		ReserveB:        int64(cp.ReserveB),
+		TotalShares:     int64(cp.TotalPoolShares),
+		TrustlineCount:  int64(cp.PoolSharesTrustLineCount),
+		TradeCount:      2,
+		VolumeA:         22,
+	}
+	first := s.mockSnapshotBatchInsertBuilder.On("Add", snapshot).Return(nil).Once()
+	snapshot.LedgerID = toid.New(21, 0, 0).ToInt64()
+	snapshot.ClosedAt = time.Unix(1005, 0).UTC()
+	snapshot.TradeCount = 1
+	snapshot.VolumeA = 11
+	s.mockSnapshotBatchInsertBuilder.On("Add", snapshot).Return(nil).Once().NotBefore(first)
+	s.mockSnapshotBatchInsertBuilder.On("Exec", s.ctx, s.mockSession).Return(nil).Once()
+
+	s.Assert().NoError(s.processor.ProcessTransaction(s.lcm, txn))
+	s.Assert().NoError(s.processor.ProcessTransaction(s.lcm, txn))
+	s.Assert().NoError(s.processor.ProcessTransaction(nextLedger, txn))
+	s.Assert().NoError(s.processor.Flush(s.ctx, s.mockSession))
+}
+
+func constantProductBody() xdr.LiquidityPoolEntryBody {
+	return xdr.LiquidityPoolEntryBody{
+		Type: xdr.LiquidityPoolTypeLiquidityPoolConstantProduct,
+		ConstantProduct: &xdr.LiquidityPoolEntryConstantProduct{
+			Params: xdr.LiquidityPoolConstantProductParameters{
+				AssetA: xdr.MustNewCreditAsset("EUR", "GAOQJGUAB7NI7K7I62ORBXMN3J4SSWQUQ7FOEPSDJ322W2HMCNWPHXFB"),
+				AssetB: xdr.MustNewNativeAsset(),
+				Fee:    30,
+			},
+			ReserveA:                 100,
+			ReserveB:                 200,
+			TotalPoolShares:          50,
+			PoolSharesTrustLineCount: 2,
+		},
+	}
+}
@@ -31,6 +31,13 @@ type LedgerTransactionFilterer interface {
 	FilterTransaction(ctx context.Context, transaction ingest.LedgerTransaction) (bool, bool, error)
 }
 
+// LedgerTransactionExcluder is implemented by filterers with deny-list rules.
+// Transactions excluded by a filterer are dropped even if they are included
+// by other filterers.
+type LedgerTransactionExcluder interface {
+	ExcludeTransaction(ctx context.Context, transaction ingest.LedgerTransaction) (bool, error)
+}
+
 func StreamLedgerTransactions(
 	ctx context.Context,
 	txFilterer LedgerTransactionFilterer,
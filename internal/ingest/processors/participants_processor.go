@@ -16,24 +16,33 @@ import (
 // ParticipantsProcessor is a processor which ingests various participants
 // from different sources (transactions, operations, etc)
 type ParticipantsProcessor struct {
-	accountLoader *history.AccountLoader
-	txBatch       history.TransactionParticipantsBatchInsertBuilder
-	opBatch       history.OperationParticipantBatchInsertBuilder
-	network       string
+	accountLoader   *history.AccountLoader
+	contractLoader  *history.ContractLoader
+	txBatch         history.TransactionParticipantsBatchInsertBuilder
+	opBatch         history.OperationParticipantBatchInsertBuilder
+	txContractBatch history.TransactionContractBatchInsertBuilder
+	opContractBatch history.OperationContractBatchInsertBuilder
+	network         string
 }
 
 func NewParticipantsProcessor(
 	accountLoader *history.AccountLoader,
+	contractLoader *history.ContractLoader,
 	txBatch history.TransactionParticipantsBatchInsertBuilder,
 	opBatch history.OperationParticipantBatchInsertBuilder,
+	txContractBatch history.TransactionContractBatchInsertBuilder,
+	opContractBatch history.OperationContractBatchInsertBuilder,
 	network string,
 
 ) *ParticipantsProcessor {
 	return &ParticipantsProcessor{
-		accountLoader: accountLoader,
-		txBatch:       txBatch,
-		opBatch:       opBatch,
-		network:       network,
+		accountLoader:   accountLoader,
+		contractLoader:  contractLoader,
+		txBatch:         txBatch,
+		opBatch:         opBatch,
+		txContractBatch: txContractBatch,
+		opContractBatch: opContractBatch,
+		network:         network,
 	}
 }
 
@@ -150,6 +159,107 @@ func (p *ParticipantsProcessor) addOperationsParticipants(
 	return nil
 }
 
+func (p *ParticipantsProcessor) addContractParticipants(
+	sequence uint32,
+	transaction ingest.LedgerTransaction,
+) error {
+	transactionID := toid.New(int32(sequence), int32(transaction.Index), 0).ToInt64()
+	var transactionContracts []string
+	seen := map[string]bool{}
+
+	for opi, op := range transaction.Envelope.Operations() {
+		operationID := toid.New(int32(sequence), int32(transaction.Index), int32(opi+1)).ToInt64()
+		contracts, err := contractParticipantsForOperation(transaction, op)
+		if err != nil {
+			return errors.Wrapf(err, "could not determine operation %v contract participants", operationID)
+		}
+		for _, contract := range contracts {
+			if err := p.opContractBatch.Add(operationID, p.contractLoader.GetFuture(contract)); err != nil {
+				return err
+			}
+			if !seen[contract] {
+				seen[contract] = true
+				transactionContracts = append(transactionContracts, contract)
+			}
+		}
+	}
+
+	for _, contract := range transactionContracts {
+		if err := p.txContractBatch.Add(transactionID, p.contractLoader.GetFuture(contract)); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// contractParticipantsForOperation returns the strkey encoded addresses of
+// the contracts participating in a Soroban operation: the contracts whose
+// data is in the transaction footprint, the invoked contract and the
+// contracts present in the authorization entries.
+func contractParticipantsForOperation(
+	transaction ingest.LedgerTransaction,
+	op xdr.Operation,
+) ([]string, error) {
+	var addresses []xdr.ScAddress
+
+	switch op.Body.Type {
+	case xdr.OperationTypeInvokeHostFunction:
+		invokeOp := op.Body.MustInvokeHostFunctionOp()
+		if args, ok := invokeOp.HostFunction.GetInvokeContract(); ok {
+			addresses = append(addresses, args.ContractAddress)
+		}
+		for _, auth := range invokeOp.Auth {
+			if credentials, ok := auth.Credentials.GetAddress(); ok {
+				addresses = append(addresses, credentials.Address)
+			}
+			addresses = append(addresses, contractsForAuthorizedInvocation(auth.RootInvocation)...)
+		}
+	case xdr.OperationTypeExtendFootprintTtl, xdr.OperationTypeRestoreFootprint:
+	default:
+		return nil, nil
+	}
+
+	if sorobanData, ok := transaction.GetSorobanData(); ok {
+		footprint := sorobanData.Resources.Footprint
+		for _, keys := range [][]xdr.LedgerKey{footprint.ReadOnly, footprint.ReadWrite} {
+			for _, key := range keys {
+				if contractData, ok := key.GetContractData(); ok {
+					addresses = append(addresses, contractData.Contract)
+				}
+			}
+		}
+	}
+
+	var contracts []string
+	seen := map[string]bool{}
+	for _, address := range addresses {
+		if address.Type != xdr.ScAddressTypeScAddressTypeContract {
+			continue
+		}
+		contract, err := address.String()
+		if err != nil {
+			return nil, err
+		}
+		if !seen[contract] {
+			seen[contract] = true
+			contracts = append(contracts, contract)
+		}
+	}
+	return contracts, nil
+}
+
+func contractsForAuthorizedInvocation(invocation xdr.SorobanAuthorizedInvocation) []xdr.ScAddress {
+	var addresses []xdr.ScAddress
+	if args, ok := invocation.Function.GetContractFn(); ok {
+		addresses = append(addresses, args.ContractAddress)
+	}
+	for _, subInvocation := range invocation.SubInvocations {
+		addresses = append(addresses, contractsForAuthorizedInvocation(subInvocation)...)
+	}
+	return addresses
+}
+
 func (p *ParticipantsProcessor) ProcessTransaction(lcm xdr.LedgerCloseMeta, transaction ingest.LedgerTransaction) error {
 
 	if err := p.addTransactionParticipants(lcm.LedgerSequence(), transaction); err != nil {
@@ -160,6 +270,10 @@ func (p *ParticipantsProcessor) ProcessTransaction(lcm xdr.LedgerCloseMeta, tran
 		return err
 	}
 
+	if err := p.addContractParticipants(lcm.LedgerSequence(), transaction); err != nil {
+		return err
+	}
+
 	return nil
 }
 
@@ -170,6 +284,12 @@ func (p *ParticipantsProcessor) Flush(ctx context.Context, session db.SessionInt
 	if err := p.opBatch.Exec(ctx, session); err != nil {
 		return errors.Wrap(err, "Could not flush operation participants to db")
 	}
+	if err := p.txContractBatch.Exec(ctx, session); err != nil {
+		return errors.Wrap(err, "Could not flush transaction contracts to db")
+	}
+	if err := p.opContractBatch.Exec(ctx, session); err != nil {
+		return errors.Wrap(err, "Could not flush operation contracts to db")
+	}
 	return nil
 }
 
@@ -18,12 +18,15 @@ import (
 
 type ParticipantsProcessorTestSuiteLedger struct {
 	suite.Suite
-	ctx                              context.Context
-	processor                        *ParticipantsProcessor
-	mockSession                      *db.MockSession
-	mockBatchInsertBuilder           *history.MockTransactionParticipantsBatchInsertBuilder
-	mockOperationsBatchInsertBuilder *history.MockOperationParticipantBatchInsertBuilder
-	accountLoader                    *history.AccountLoader
+	ctx                               context.Context
+	processor                         *ParticipantsProcessor
+	mockSession                       *db.MockSession
+	mockBatchInsertBuilder            *history.MockTransactionParticipantsBatchInsertBuilder
+	mockOperationsBatchInsertBuilder  *history.MockOperationParticipantBatchInsertBuilder
+	mockTxContractsBatchInsertBuilder *history.MockTransactionContractBatchInsertBuilder
+	mockOpContractsBatchInsertBuilder *history.MockOperationContractBatchInsertBuilder
+	accountLoader                     *history.AccountLoader
+	contractLoader                    *history.ContractLoader
 
 	lcm             xdr.LedgerCloseMeta
 	firstTx         ingest.LedgerTransaction
@@ -45,6 +48,8 @@ func (s *ParticipantsProcessorTestSuiteLedger) SetupTest() {
 	s.ctx = context.Background()
 	s.mockBatchInsertBuilder = &history.MockTransactionParticipantsBatchInsertBuilder{}
 	s.mockOperationsBatchInsertBuilder = &history.MockOperationParticipantBatchInsertBuilder{}
+	s.mockTxContractsBatchInsertBuilder = &history.MockTransactionContractBatchInsertBuilder{}
+	s.mockOpContractsBatchInsertBuilder = &history.MockOperationContractBatchInsertBuilder{}
 	sequence := uint32(20)
 	s.lcm = xdr.LedgerCloseMeta{
 		V0: &xdr.LedgerCloseMetaV0{
@@ -92,10 +97,15 @@ func (s *ParticipantsProcessorTestSuiteLedger) SetupTest() {
 		s.addressToFuture[address] = s.accountLoader.GetFuture(address)
 	}
 
+	s.contractLoader = history.NewContractLoader(history.ConcurrentInserts)
+
 	s.processor = NewParticipantsProcessor(
 		s.accountLoader,
+		s.contractLoader,
 		s.mockBatchInsertBuilder,
 		s.mockOperationsBatchInsertBuilder,
+		s.mockTxContractsBatchInsertBuilder,
+		s.mockOpContractsBatchInsertBuilder,
 		networkPassphrase,
 	)
 
@@ -109,6 +119,13 @@ func (s *ParticipantsProcessorTestSuiteLedger) SetupTest() {
 func (s *ParticipantsProcessorTestSuiteLedger) TearDownTest() {
 	s.mockBatchInsertBuilder.AssertExpectations(s.T())
 	s.mockOperationsBatchInsertBuilder.AssertExpectations(s.T())
+	s.mockTxContractsBatchInsertBuilder.AssertExpectations(s.T())
+	s.mockOpContractsBatchInsertBuilder.AssertExpectations(s.T())
+}
+
+func (s *ParticipantsProcessorTestSuiteLedger) mockContractsExec() {
+	s.mockTxContractsBatchInsertBuilder.On("Exec", s.ctx, s.mockSession).Return(nil).Once()
+	s.mockOpContractsBatchInsertBuilder.On("Exec", s.ctx, s.mockSession).Return(nil).Once()
 }
 
 func (s *ParticipantsProcessorTestSuiteLedger) mockSuccessfulTransactionBatchAdds() {
@@ -145,6 +162,7 @@ func (s *ParticipantsProcessorTestSuiteLedger) mockSuccessfulOperationBatchAdds(
 func (s *ParticipantsProcessorTestSuiteLedger) TestEmptyParticipants() {
 	s.mockBatchInsertBuilder.On("Exec", s.ctx, s.mockSession).Return(nil).Once()
 	s.mockOperationsBatchInsertBuilder.On("Exec", s.ctx, s.mockSession).Return(nil).Once()
+	s.mockContractsExec()
 
 	err := s.processor.Flush(s.ctx, s.mockSession)
 	s.Assert().NoError(err)
@@ -189,6 +207,7 @@ func (s *ParticipantsProcessorTestSuiteLedger) TestFeeBumptransaction() {
 
 	s.mockBatchInsertBuilder.On("Exec", s.ctx, s.mockSession).Return(nil).Once()
 	s.mockOperationsBatchInsertBuilder.On("Exec", s.ctx, s.mockSession).Return(nil).Once()
+	s.mockContractsExec()
 
 	s.Assert().NoError(s.processor.ProcessTransaction(s.lcm, feeBumpTx))
 	s.Assert().NoError(s.processor.Flush(s.ctx, s.mockSession))
@@ -200,6 +219,7 @@ func (s *ParticipantsProcessorTestSuiteLedger) TestIngestParticipantsSucceeds()
 
 	s.mockBatchInsertBuilder.On("Exec", s.ctx, s.mockSession).Return(nil).Once()
 	s.mockOperationsBatchInsertBuilder.On("Exec", s.ctx, s.mockSession).Return(nil).Once()
+	s.mockContractsExec()
 
 	for _, tx := range s.txs {
 		err := s.processor.ProcessTransaction(s.lcm, tx)
@@ -259,3 +279,124 @@ func (s *ParticipantsProcessorTestSuiteLedger) TestOperationBatchAddExecFails()
 	err := s.processor.Flush(s.ctx, s.mockSession)
 	s.Assert().EqualError(err, "Could not flush operation participants to db: transient error")
 }
+
+func (s *ParticipantsProcessorTestSuiteLedger) TestContractParticipants() {
+	invokedContract := xdr.ContractId{1}
+	footprintContract := xdr.ContractId{2}
+	authContract := xdr.ContractId{3}
+	contractAddress := func(id *xdr.ContractId) xdr.ScAddress {
+		return xdr.ScAddress{Type: xdr.ScAddressTypeScAddressTypeContract, ContractId: id}
+	}
+	invokedContractAddress := contractAddress(&invokedContract)
+	account := xdr.MustAddress(s.addresses[1])
+	accountAddress := xdr.ScAddress{
+		Type:      xdr.ScAddressTypeScAddressTypeAccount,
+		AccountId: &account,
+	}
+	fnName := xdr.ScSymbol("transfer")
+
+	tx := s.firstTx
+	tx.Envelope.V1.Tx.Operations[0].Body = xdr.OperationBody{
+		Type: xdr.OperationTypeInvokeHostFunction,
+		InvokeHostFunctionOp: &xdr.InvokeHostFunctionOp{
+			HostFunction: xdr.HostFunction{
+				Type: xdr.HostFunctionTypeHostFunctionTypeInvokeContract,
+				InvokeContract: &xdr.InvokeContractArgs{
+					ContractAddress: invokedContractAddress,
+					FunctionName:    fnName,
+				},
+			},
+			Auth: []xdr.SorobanAuthorizationEntry{
+				{
+					Credentials: xdr.SorobanCredentials{
+						Type: xdr.SorobanCredentialsTypeSorobanCredentialsAddress,
+						Address: &xdr.SorobanAddressCredentials{
+							Address: accountAddress,
+						},
+					},
+					RootInvocation: xdr.SorobanAuthorizedInvocation{
+						Function: xdr.SorobanAuthorizedFunction{
+							Type: xdr.SorobanAuthorizedFunctionTypeSorobanAuthorizedFunctionTypeContractFn,
+							ContractFn: &xdr.InvokeContractArgs{
+								ContractAddress: invokedContractAddress,
+								FunctionName:    fnName,
+							},
+						},
+						SubInvocations: []xdr.SorobanAuthorizedInvocation{
+							{
+								Function: xdr.SorobanAuthorizedFunction{
+									Type: xdr.SorobanAuthorizedFunctionTypeSorobanAuthorizedFunctionTypeContractFn,
+									ContractFn: &xdr.InvokeContractArgs{
+										ContractAddress: contractAddress(&authContract),
+										FunctionName:    fnName,
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+	tx.Envelope.V1.Tx.Ext = xdr.TransactionExt{
+		V: 1,
+		SorobanData: &xdr.SorobanTransactionData{
+			Resources: xdr.SorobanResources{
+				Footprint: xdr.LedgerFootprint{
+					ReadOnly: []xdr.LedgerKey{
+						{
+							Type: xdr.LedgerEntryTypeContractData,
+							ContractData: &xdr.LedgerKeyContractData{
+								Contract:   invokedContractAddress,
+								Key:        xdr.ScVal{Type: xdr.ScValTypeScvLedgerKeyContractInstance},
+								Durability: xdr.ContractDataDurabilityPersistent,
+							},
+						},
+					},
+					ReadWrite: []xdr.LedgerKey{
+						{
+							Type: xdr.LedgerEntryTypeContractData,
+							ContractData: &xdr.LedgerKeyContractData{
+								Contract:   contractAddress(&footprintContract),
+								Key:        xdr.ScVal{Type: xdr.ScValTypeScvLedgerKeyContractInstance},
+								Durability: xdr.ContractDataDurabilityPersistent,
+							},
+						},
+						{
+							Type: xdr.LedgerEntryTypeContractData,
+							ContractData: &xdr.LedgerKeyContractData{
+								Contract:   accountAddress,
+								Key:        xdr.ScVal{Type: xdr.ScValTypeScvLedgerKeyContractInstance},
+								Durability: xdr.ContractDataDurabilityTemporary,
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	var contracts []history.FutureContractID
+	for _, id := range []xdr.ContractId{invokedContract, authContract, footprintContract} {
+		address, err := contractAddress(&id).String()
+		s.Require().NoError(err)
+		contracts = append(contracts, s.contractLoader.GetFuture(address))
+	}
+
+	s.mockBatchInsertBuilder.On(
+		"Add", s.firstTxID, s.addressToFuture[s.addresses[0]],
+	).Return(nil).Once()
+	s.mockOperationsBatchInsertBuilder.On(
+		"Add", s.firstTxID+1, s.addressToFuture[s.addresses[0]],
+	).Return(nil).Once()
+	for _, contract := range contracts {
+		s.mockOpContractsBatchInsertBuilder.On("Add", s.firstTxID+1, contract).Return(nil).Once()
+		s.mockTxContractsBatchInsertBuilder.On("Add", s.firstTxID, contract).Return(nil).Once()
+	}
+	s.mockBatchInsertBuilder.On("Exec", s.ctx, s.mockSession).Return(nil).Once()
+	s.mockOperationsBatchInsertBuilder.On("Exec", s.ctx, s.mockSession).Return(nil).Once()
+	s.mockContractsExec()
+
+	s.Assert().NoError(s.processor.ProcessTransaction(s.lcm, tx))
+	s.Assert().NoError(s.processor.Flush(s.ctx, s.mockSession))
+}
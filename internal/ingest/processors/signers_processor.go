@@ -2,6 +2,7 @@ package processors
 
 import (
 	"context"
+	"sort"
 
 	"github.com/guregu/null"
 
@@ -157,3 +158,33 @@ func (p *SignersProcessor) addAccountSigners(
 	}
 	return nil
 }
+
+// AccountEntrySignersToRows converts the signers of an account ledger entry,
+// including its master key, into rows of the accounts_signers table ordered
+// by signer.
+func AccountEntrySignersToRows(entry xdr.LedgerEntry) []history.AccountSigner {
+	account := entry.Data.MustAccount()
+	accountAddress := account.AccountId.Address()
+	sponsorsPerSigner := account.SponsorPerSigner()
+
+	var rows []history.AccountSigner
+	for signer, weight := range account.SignerSummary() {
+		// Ignore master key
+		var sponsor null.String
+		if signer != accountAddress {
+			if sponsorDesc, isSponsored := sponsorsPerSigner[signer]; isSponsored {
+				sponsor = null.StringFrom(sponsorDesc.Address())
+			}
+		}
+		rows = append(rows, history.AccountSigner{
+			Account: accountAddress,
+			Signer:  signer,
+			Weight:  weight,
+			Sponsor: sponsor,
+		})
+	}
+	sort.Slice(rows, func(i, j int) bool {
+		return rows[i].Signer < rows[j].Signer
+	})
+	return rows
+}
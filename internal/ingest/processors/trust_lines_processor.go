@@ -11,14 +11,15 @@ import (
 
 type TrustLinesProcessor struct {
 	trustLinesQ history.QTrustLines
+	changeLog   *AccountStateChangeLog
 
 	batchUpdateTrustlines    []history.TrustLine
 	batchRemoveTrustLineKeys []string
 	batchInsertBuilder       history.TrustLinesBatchInsertBuilder
 }
 
-func NewTrustLinesProcessor(trustLinesQ history.QTrustLines) *TrustLinesProcessor {
-	p := &TrustLinesProcessor{trustLinesQ: trustLinesQ}
+func NewTrustLinesProcessor(trustLinesQ history.QTrustLines, changeLog *AccountStateChangeLog) *TrustLinesProcessor {
+	p := &TrustLinesProcessor{trustLinesQ: trustLinesQ, changeLog: changeLog}
 	p.reset()
 	return p
 }
@@ -38,10 +39,14 @@ func (p *TrustLinesProcessor) ProcessChange(ctx context.Context, change ingest.C
 		return nil
 	}
 
+	if err := p.changeLog.add(change); err != nil {
+		return errors.Wrap(err, "Error adding to AccountStateChangeLog")
+	}
+
 	switch {
 	case change.Pre == nil && change.Post != nil:
 		// Created
-		line, err := xdrToTrustline(*change.Post)
+		line, err := TrustLineEntryToRow(*change.Post)
 		if err != nil {
 			return errors.Wrap(err, "Error extracting trustline")
 		}
@@ -52,7 +57,7 @@ func (p *TrustLinesProcessor) ProcessChange(ctx context.Context, change ingest.C
 		}
 	case change.Pre != nil && change.Post != nil:
 		// Updated
-		tl, err := xdrToTrustline(*change.Post)
+		tl, err := TrustLineEntryToRow(*change.Post)
 		if err != nil {
 			return errors.Wrap(err, "Error extracting trustline")
 		}
@@ -70,7 +75,7 @@ func (p *TrustLinesProcessor) ProcessChange(ctx context.Context, change ingest.C
 		return errors.New("Invalid io.Change: change.Pre == nil && change.Post == nil")
 	}
 
-	if p.batchInsertBuilder.Len()+len(p.batchUpdateTrustlines)+len(p.batchRemoveTrustLineKeys) > maxBatchSize {
+	if p.batchInsertBuilder.Len()+len(p.batchUpdateTrustlines)+len(p.batchRemoveTrustLineKeys)+p.changeLog.len() > maxBatchSize {
 
 		if err := p.Commit(ctx); err != nil {
 			return errors.Wrap(err, "error in Commit")
@@ -95,7 +100,9 @@ func trustLineLedgerKey(trustLineEntry xdr.TrustLineEntry) (string, error) {
 	return ledgerKeyString, nil
 }
 
-func xdrToTrustline(ledgerEntry xdr.LedgerEntry) (history.TrustLine, error) {
+// TrustLineEntryToRow converts a trustline ledger entry into a row of the
+// trust_lines table.
+func TrustLineEntryToRow(ledgerEntry xdr.LedgerEntry) (history.TrustLine, error) {
 	trustLineEntry := ledgerEntry.Data.MustTrustLine()
 	ledgerKeyString, err := trustLineLedgerKey(trustLineEntry)
 	if err != nil {
@@ -138,6 +145,10 @@ func (p *TrustLinesProcessor) Commit(ctx context.Context) error {
 		return errors.Wrap(err, "Error executing TrustLinesBatchInsertBuilder")
 	}
 
+	if err = p.changeLog.exec(ctx); err != nil {
+		return err
+	}
+
 	if len(p.batchUpdateTrustlines) > 0 {
 		err := p.trustLinesQ.UpsertTrustLines(ctx, p.batchUpdateTrustlines)
 		if err != nil {
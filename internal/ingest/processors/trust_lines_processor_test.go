@@ -39,7 +39,7 @@ func (s *TrustLinesProcessorTestSuiteState) SetupTest() {
 	s.mockTrustLinesBatchInsertBuilder.On("Exec", s.ctx).Return(nil).Once()
 	s.mockTrustLinesBatchInsertBuilder.On("Len").Return(1).Maybe()
 
-	s.processor = NewTrustLinesProcessor(s.mockQ)
+	s.processor = NewTrustLinesProcessor(s.mockQ, nil)
 }
 
 func (s *TrustLinesProcessorTestSuiteState) TearDownTest() {
@@ -178,7 +178,7 @@ func (s *TrustLinesProcessorTestSuiteLedger) SetupTest() {
 	s.mockTrustLinesBatchInsertBuilder.On("Exec", s.ctx).Return(nil).Once()
 	s.mockTrustLinesBatchInsertBuilder.On("Len").Return(1).Maybe()
 
-	s.processor = NewTrustLinesProcessor(s.mockQ)
+	s.processor = NewTrustLinesProcessor(s.mockQ, nil)
 }
 
 func (s *TrustLinesProcessorTestSuiteLedger) TearDownTest() {
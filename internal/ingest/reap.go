@@ -228,6 +228,16 @@ func (r *Reaper) deleteBatch(ctx context.Context, batchStartSeq, batchEndSeq uin
 	if err != nil {
 		return 0, errors.Wrap(err, "Error in DeleteRangeAll")
 	}
+	snapshotCount, err := r.historyQ.DeleteAssetStatSnapshotsRange(ctx, batchStart, batchEnd)
+	if err != nil {
+		return 0, errors.Wrap(err, "Error in DeleteAssetStatSnapshotsRange")
+	}
+	count += snapshotCount
+	changeCount, err := r.historyQ.DeleteAccountStateChangesRange(ctx, batchStart, batchEnd)
+	if err != nil {
+		return 0, errors.Wrap(err, "Error in DeleteAccountStateChangesRange")
+	}
+	count += changeCount
 
 	err = r.historyQ.Commit()
 	if err != nil {
@@ -312,6 +322,7 @@ func (r *lookupTableReaper) deleteOrphanedRows(ctx context.Context) error {
 	for _, table := range []string{
 		"history_accounts", "history_claimable_balances",
 		"history_assets", "history_liquidity_pools",
+		"history_contracts",
 	} {
 		startTime := time.Now()
 		ids, offset, err := r.historyQ.FindLookupTableRowsToReap(ctx, table, reapLookupTablesBatchSize)
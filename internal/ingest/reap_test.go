@@ -197,6 +197,12 @@ func (t *ReaperTestSuite) TestSucceeds() {
 		t.historyQ.On("DeleteRangeAll", t.ctx,
 			toid.New(55, 0, 0).ToInt64(), toid.New(61, 0, 0).ToInt64(),
 		).Return(int64(400), nil).Once(),
+		t.historyQ.On("DeleteAssetStatSnapshotsRange", t.ctx,
+			toid.New(55, 0, 0).ToInt64(), toid.New(61, 0, 0).ToInt64(),
+		).Return(int64(0), nil).Once(),
+		t.historyQ.On("DeleteAccountStateChangesRange", t.ctx,
+			toid.New(55, 0, 0).ToInt64(), toid.New(61, 0, 0).ToInt64(),
+		).Return(int64(0), nil).Once(),
 		t.historyQ.On("Commit").Return(nil).Once(),
 		t.historyQ.On("Rollback").Return(nil).Once(),
 		t.reapLockQ.On("Rollback").Return(nil).Once(),
@@ -241,6 +247,12 @@ func (t *ReaperTestSuite) TestPartiallySucceeds() {
 		t.historyQ.On("DeleteRangeAll", t.ctx,
 			toid.New(30, 0, 0).ToInt64(), toid.New(41, 0, 0).ToInt64(),
 		).Return(int64(200), nil).Once(),
+		t.historyQ.On("DeleteAssetStatSnapshotsRange", t.ctx,
+			toid.New(30, 0, 0).ToInt64(), toid.New(41, 0, 0).ToInt64(),
+		).Return(int64(0), nil).Once(),
+		t.historyQ.On("DeleteAccountStateChangesRange", t.ctx,
+			toid.New(30, 0, 0).ToInt64(), toid.New(41, 0, 0).ToInt64(),
+		).Return(int64(0), nil).Once(),
 		t.historyQ.On("Commit").Return(nil).Once(),
 		t.historyQ.On("Rollback").Return(nil).Once(),
 
@@ -271,6 +283,12 @@ func (t *ReaperTestSuite) TestSucceedsOnMultipleBatches() {
 		t.historyQ.On("DeleteRangeAll", t.ctx,
 			toid.New(35, 0, 0).ToInt64(), toid.New(46, 0, 0).ToInt64(),
 		).Return(int64(200), nil).Once(),
+		t.historyQ.On("DeleteAssetStatSnapshotsRange", t.ctx,
+			toid.New(35, 0, 0).ToInt64(), toid.New(46, 0, 0).ToInt64(),
+		).Return(int64(0), nil).Once(),
+		t.historyQ.On("DeleteAccountStateChangesRange", t.ctx,
+			toid.New(35, 0, 0).ToInt64(), toid.New(46, 0, 0).ToInt64(),
+		).Return(int64(0), nil).Once(),
 		t.historyQ.On("Commit").Return(nil).Once(),
 		t.historyQ.On("Rollback").Return(nil).Once(),
 
@@ -278,6 +296,12 @@ func (t *ReaperTestSuite) TestSucceedsOnMultipleBatches() {
 		t.historyQ.On("DeleteRangeAll", t.ctx,
 			toid.New(46, 0, 0).ToInt64(), toid.New(57, 0, 0).ToInt64(),
 		).Return(int64(150), nil).Once(),
+		t.historyQ.On("DeleteAssetStatSnapshotsRange", t.ctx,
+			toid.New(46, 0, 0).ToInt64(), toid.New(57, 0, 0).ToInt64(),
+		).Return(int64(0), nil).Once(),
+		t.historyQ.On("DeleteAccountStateChangesRange", t.ctx,
+			toid.New(46, 0, 0).ToInt64(), toid.New(57, 0, 0).ToInt64(),
+		).Return(int64(0), nil).Once(),
 		t.historyQ.On("Commit").Return(nil).Once(),
 		t.historyQ.On("Rollback").Return(nil).Once(),
 
@@ -285,6 +309,12 @@ func (t *ReaperTestSuite) TestSucceedsOnMultipleBatches() {
 		t.historyQ.On("DeleteRangeAll", t.ctx,
 			toid.New(57, 0, 0).ToInt64(), toid.New(61, 0, 0).ToInt64(),
 		).Return(int64(80), nil).Once(),
+		t.historyQ.On("DeleteAssetStatSnapshotsRange", t.ctx,
+			toid.New(57, 0, 0).ToInt64(), toid.New(61, 0, 0).ToInt64(),
+		).Return(int64(0), nil).Once(),
+		t.historyQ.On("DeleteAccountStateChangesRange", t.ctx,
+			toid.New(57, 0, 0).ToInt64(), toid.New(61, 0, 0).ToInt64(),
+		).Return(int64(0), nil).Once(),
 		t.historyQ.On("Commit").Return(nil).Once(),
 		t.historyQ.On("Rollback").Return(nil).Once(),
 
@@ -309,6 +339,12 @@ func (t *ReaperTestSuite) TestSkipGap() {
 		t.historyQ.On("DeleteRangeAll", t.ctx,
 			toid.New(2, 0, 0).ToInt64(), toid.New(13, 0, 0).ToInt64(),
 		).Return(int64(200), nil).Once(),
+		t.historyQ.On("DeleteAssetStatSnapshotsRange", t.ctx,
+			toid.New(2, 0, 0).ToInt64(), toid.New(13, 0, 0).ToInt64(),
+		).Return(int64(0), nil).Once(),
+		t.historyQ.On("DeleteAccountStateChangesRange", t.ctx,
+			toid.New(2, 0, 0).ToInt64(), toid.New(13, 0, 0).ToInt64(),
+		).Return(int64(0), nil).Once(),
 		t.historyQ.On("Commit").Return(nil).Once(),
 		t.historyQ.On("Rollback").Return(nil).Once(),
 
@@ -316,6 +352,12 @@ func (t *ReaperTestSuite) TestSkipGap() {
 		t.historyQ.On("DeleteRangeAll", t.ctx,
 			toid.New(13, 0, 0).ToInt64(), toid.New(24, 0, 0).ToInt64(),
 		).Return(int64(0), nil).Once(),
+		t.historyQ.On("DeleteAssetStatSnapshotsRange", t.ctx,
+			toid.New(13, 0, 0).ToInt64(), toid.New(24, 0, 0).ToInt64(),
+		).Return(int64(0), nil).Once(),
+		t.historyQ.On("DeleteAccountStateChangesRange", t.ctx,
+			toid.New(13, 0, 0).ToInt64(), toid.New(24, 0, 0).ToInt64(),
+		).Return(int64(0), nil).Once(),
 		t.historyQ.On("Commit").Return(nil).Once(),
 		t.historyQ.On("Rollback").Return(nil).Once(),
 		t.historyQ.On("GetNextLedgerSequence", t.ctx, uint32(13)).Return(uint32(55), true, nil).Once(),
@@ -324,6 +366,12 @@ func (t *ReaperTestSuite) TestSkipGap() {
 		t.historyQ.On("DeleteRangeAll", t.ctx,
 			toid.New(55, 0, 0).ToInt64(), toid.New(61, 0, 0).ToInt64(),
 		).Return(int64(20), nil).Once(),
+		t.historyQ.On("DeleteAssetStatSnapshotsRange", t.ctx,
+			toid.New(55, 0, 0).ToInt64(), toid.New(61, 0, 0).ToInt64(),
+		).Return(int64(0), nil).Once(),
+		t.historyQ.On("DeleteAccountStateChangesRange", t.ctx,
+			toid.New(55, 0, 0).ToInt64(), toid.New(61, 0, 0).ToInt64(),
+		).Return(int64(0), nil).Once(),
 		t.historyQ.On("Commit").Return(nil).Once(),
 		t.historyQ.On("Rollback").Return(nil).Once(),
 
@@ -348,6 +396,12 @@ func (t *ReaperTestSuite) TestSkipGapTerminatesEarly() {
 		t.historyQ.On("DeleteRangeAll", t.ctx,
 			toid.New(2, 0, 0).ToInt64(), toid.New(13, 0, 0).ToInt64(),
 		).Return(int64(200), nil).Once(),
+		t.historyQ.On("DeleteAssetStatSnapshotsRange", t.ctx,
+			toid.New(2, 0, 0).ToInt64(), toid.New(13, 0, 0).ToInt64(),
+		).Return(int64(0), nil).Once(),
+		t.historyQ.On("DeleteAccountStateChangesRange", t.ctx,
+			toid.New(2, 0, 0).ToInt64(), toid.New(13, 0, 0).ToInt64(),
+		).Return(int64(0), nil).Once(),
 		t.historyQ.On("Commit").Return(nil).Once(),
 		t.historyQ.On("Rollback").Return(nil).Once(),
 
@@ -355,6 +409,12 @@ func (t *ReaperTestSuite) TestSkipGapTerminatesEarly() {
 		t.historyQ.On("DeleteRangeAll", t.ctx,
 			toid.New(13, 0, 0).ToInt64(), toid.New(24, 0, 0).ToInt64(),
 		).Return(int64(0), nil).Once(),
+		t.historyQ.On("DeleteAssetStatSnapshotsRange", t.ctx,
+			toid.New(13, 0, 0).ToInt64(), toid.New(24, 0, 0).ToInt64(),
+		).Return(int64(0), nil).Once(),
+		t.historyQ.On("DeleteAccountStateChangesRange", t.ctx,
+			toid.New(13, 0, 0).ToInt64(), toid.New(24, 0, 0).ToInt64(),
+		).Return(int64(0), nil).Once(),
 		t.historyQ.On("Commit").Return(nil).Once(),
 		t.historyQ.On("Rollback").Return(nil).Once(),
 		t.historyQ.On("GetNextLedgerSequence", t.ctx, uint32(13)).Return(uint32(65), true, nil).Once(),
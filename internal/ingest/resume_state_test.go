@@ -334,6 +334,36 @@ func (s *ResumeTestTestSuite) TestIngestAllMasterNode() {
 	)
 }
 
+type mockLedgerPublisher struct {
+	mock.Mock
+}
+
+func (m *mockLedgerPublisher) Run(ctx context.Context) {
+	m.Called(ctx)
+}
+
+func (m *mockLedgerPublisher) LedgerIngested() {
+	m.Called()
+}
+
+func (s *ResumeTestTestSuite) TestIngestNotifiesLedgerPublisher() {
+	s.mockSuccessfulIngestion()
+	publisher := &mockLedgerPublisher{}
+	publisher.On("LedgerIngested").Once()
+	s.system.ledgerPublishers = []LedgerPublisher{publisher}
+
+	next, err := resumeState{latestSuccessfullyProcessedLedger: 100}.run(s.system)
+	s.Assert().NoError(err)
+	s.Assert().Equal(
+		transition{
+			node:          resumeState{latestSuccessfullyProcessedLedger: 101},
+			sleepDuration: 0,
+		},
+		next,
+	)
+	publisher.AssertExpectations(s.T())
+}
+
 func (s *ResumeTestTestSuite) TestRebuildTradeAggregationBucketsError() {
 	s.historyQ.On("Begin", s.ctx).Return(nil).Once()
 	s.historyQ.On("GetLastLedgerIngest", s.ctx).Return(uint32(100), nil).Once()
@@ -0,0 +1,40 @@
+package sink
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+
+	"github.com/stellar/go-stellar-sdk/support/errors"
+)
+
+// FileSink appends the ledger batches to a file, one JSON document per line
+// (NDJSON).
+type FileSink struct {
+	file *os.File
+}
+
+// NewFileSink opens or creates the file at path in append mode.
+func NewFileSink(path string) (*FileSink, error) {
+	file, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
+	if err != nil {
+		return nil, errors.Wrap(err, "error opening ledger sink file")
+	}
+	return &FileSink{file: file}, nil
+}
+
+// Publish writes the batch to the file and flushes it to disk.
+func (s *FileSink) Publish(ctx context.Context, batch LedgerBatch) error {
+	line, err := json.Marshal(batch)
+	if err != nil {
+		return errors.Wrap(err, "error encoding ledger batch")
+	}
+	if _, err = s.file.Write(append(line, '\n')); err != nil {
+		return errors.Wrap(err, "error writing ledger batch")
+	}
+	return s.file.Sync()
+}
+
+func (s *FileSink) Close() error {
+	return s.file.Close()
+}
@@ -0,0 +1,52 @@
+package sink
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	protocol "github.com/stellar/go-stellar-sdk/protocols/horizon"
+)
+
+func TestFileSinkAppendsNDJSON(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "ledgers.ndjson")
+
+	s, err := NewFileSink(path)
+	require.NoError(t, err)
+	for _, sequence := range []int32{10, 11} {
+		batch := LedgerBatch{
+			Ledger:       protocol.Ledger{Sequence: sequence},
+			Transactions: []protocol.Transaction{{Hash: "hash"}},
+		}
+		require.NoError(t, s.Publish(context.Background(), batch))
+	}
+	require.NoError(t, s.Close())
+
+	// reopening appends to the existing file
+	s, err = NewFileSink(path)
+	require.NoError(t, err)
+	require.NoError(t, s.Publish(context.Background(), LedgerBatch{Ledger: protocol.Ledger{Sequence: 12}}))
+	require.NoError(t, s.Close())
+
+	file, err := os.Open(path)
+	require.NoError(t, err)
+	defer file.Close()
+
+	var sequences []int32
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		var batch struct {
+			Ledger protocol.Ledger `json:"ledger"`
+		}
+		require.NoError(t, json.Unmarshal(scanner.Bytes(), &batch))
+		sequences = append(sequences, batch.Ledger.Sequence)
+	}
+	require.NoError(t, scanner.Err())
+	assert.Equal(t, []int32{10, 11, 12}, sequences)
+}
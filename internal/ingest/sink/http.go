@@ -0,0 +1,63 @@
+package sink
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/stellar/go-stellar-sdk/support/errors"
+)
+
+// LedgerSequenceHeader is the header of the HTTPSink requests containing the
+// sequence of the published ledger.
+const LedgerSequenceHeader = "X-Horizon-Ledger"
+
+// HTTPSink posts each ledger batch as JSON to a webhook URL. A batch is
+// considered published when the webhook responds with a 2xx status code.
+type HTTPSink struct {
+	url    string
+	client *http.Client
+}
+
+func NewHTTPSink(url string) *HTTPSink {
+	return &HTTPSink{
+		url:    url,
+		client: &http.Client{Timeout: 30 * time.Second},
+	}
+}
+
+func (s *HTTPSink) Publish(ctx context.Context, batch LedgerBatch) error {
+	body, err := json.Marshal(batch)
+	if err != nil {
+		return errors.Wrap(err, "error encoding ledger batch")
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, s.url, bytes.NewReader(body))
+	if err != nil {
+		return errors.Wrap(err, "error creating request")
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(LedgerSequenceHeader, strconv.FormatInt(int64(batch.Ledger.Sequence), 10))
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return errors.Wrap(err, "error posting ledger batch")
+	}
+	defer resp.Body.Close()
+	// drain the body so the connection can be reused
+	_, _ = io.Copy(io.Discard, resp.Body)
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return errors.Errorf("ledger batch rejected with status %d", resp.StatusCode)
+	}
+	return nil
+}
+
+func (s *HTTPSink) Close() error {
+	s.client.CloseIdleConnections()
+	return nil
+}
@@ -0,0 +1,54 @@
+package sink
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	protocol "github.com/stellar/go-stellar-sdk/protocols/horizon"
+)
+
+func TestHTTPSinkPostsBatch(t *testing.T) {
+	var received []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodPost, r.Method)
+		assert.Equal(t, "application/json", r.Header.Get("Content-Type"))
+		received = append(received, r.Header.Get(LedgerSequenceHeader))
+
+		var body struct {
+			Ledger       protocol.Ledger        `json:"ledger"`
+			Transactions []protocol.Transaction `json:"transactions"`
+		}
+		assert.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+		assert.Equal(t, int32(20), body.Ledger.Sequence)
+		assert.Len(t, body.Transactions, 1)
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+
+	s := NewHTTPSink(server.URL)
+	defer s.Close()
+	err := s.Publish(context.Background(), LedgerBatch{
+		Ledger:       protocol.Ledger{Sequence: 20},
+		Transactions: []protocol.Transaction{{Hash: "hash"}},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"20"}, received)
+}
+
+func TestHTTPSinkRejectedBatch(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+
+	s := NewHTTPSink(server.URL)
+	defer s.Close()
+	err := s.Publish(context.Background(), LedgerBatch{Ledger: protocol.Ledger{Sequence: 20}})
+	assert.EqualError(t, err, "ledger batch rejected with status 503")
+}
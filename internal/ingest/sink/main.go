@@ -0,0 +1,57 @@
+// Package sink publishes the ledgers ingested by Horizon to downstream
+// systems, see Publisher.
+package sink
+
+import (
+	"context"
+	"net/url"
+
+	protocol "github.com/stellar/go-stellar-sdk/protocols/horizon"
+	"github.com/stellar/go-stellar-sdk/support/errors"
+	"github.com/stellar/go-stellar-sdk/support/log"
+	"github.com/stellar/go-stellar-sdk/support/render/hal"
+)
+
+var logger = log.WithFields(log.F{
+	"service": "ledger-sink",
+})
+
+// LedgerBatch is the data ingested in a ledger. The resources have the same
+// JSON representation as in the Horizon API.
+type LedgerBatch struct {
+	Ledger       protocol.Ledger        `json:"ledger"`
+	Transactions []protocol.Transaction `json:"transactions"`
+	Operations   []hal.Pageable         `json:"operations"`
+	Effects      []hal.Pageable         `json:"effects"`
+	Trades       []protocol.Trade       `json:"trades"`
+}
+
+// Sink is a destination of the ingested ledgers. Ledgers are published in
+// order but can be published more than once, for example when Horizon is
+// restarted after publishing a ledger but before storing its cursor, so
+// consumers should deduplicate batches using the ledger sequence.
+type Sink interface {
+	Publish(ctx context.Context, batch LedgerBatch) error
+	Close() error
+}
+
+// New creates the Sink for the destination URL: a file:// URL for a
+// FileSink or an http(s):// URL for an HTTPSink.
+func New(destination string) (Sink, error) {
+	u, err := url.Parse(destination)
+	if err != nil {
+		return nil, errors.Wrap(err, "invalid ledger sink url")
+	}
+
+	switch u.Scheme {
+	case "file":
+		if u.Path == "" {
+			return nil, errors.Errorf("missing file path in ledger sink url %s", destination)
+		}
+		return NewFileSink(u.Path)
+	case "http", "https":
+		return NewHTTPSink(destination), nil
+	default:
+		return nil, errors.Errorf("unsupported ledger sink url %s, expected file://, http:// or https://", destination)
+	}
+}
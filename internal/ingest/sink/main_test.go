@@ -0,0 +1,27 @@
+package sink
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNew(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "ledgers.ndjson")
+	s, err := New("file://" + path)
+	require.NoError(t, err)
+	assert.IsType(t, &FileSink{}, s)
+	assert.NoError(t, s.Close())
+
+	s, err = New("https://example.com/hooks/ledgers")
+	require.NoError(t, err)
+	assert.IsType(t, &HTTPSink{}, s)
+
+	_, err = New("kafka://localhost:9092")
+	assert.EqualError(t, err, "unsupported ledger sink url kafka://localhost:9092, expected file://, http:// or https://")
+
+	_, err = New("file://")
+	assert.EqualError(t, err, "missing file path in ledger sink url file://")
+}
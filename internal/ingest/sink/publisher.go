@@ -0,0 +1,202 @@
+package sink
+
+import (
+	"context"
+
+	protocol "github.com/stellar/go-stellar-sdk/protocols/horizon"
+	"github.com/stellar/go-stellar-sdk/support/db"
+	"github.com/stellar/go-stellar-sdk/support/errors"
+	"github.com/stellar/go-stellar-sdk/support/render/hal"
+	"github.com/stellar/stellar-horizon/internal/db2"
+	"github.com/stellar/stellar-horizon/internal/db2/history"
+	"github.com/stellar/stellar-horizon/internal/resourceadapter"
+)
+
+// Publisher publishes the ledgers committed to the history database to a Sink.
+// Ledgers are published in order and at least once: the last published ledger
+// is stored in key_value_store after each successful publication and the
+// publication is retried from there after errors or restarts.
+type Publisher struct {
+	sink       Sink
+	historyQ   *history.Q
+	skipTxMeta bool
+	ingested   chan struct{}
+}
+
+func NewPublisher(sink Sink, session db.SessionInterface, skipTxMeta bool) *Publisher {
+	return &Publisher{
+		sink:       sink,
+		historyQ:   &history.Q{SessionInterface: session},
+		skipTxMeta: skipTxMeta,
+		ingested:   make(chan struct{}, 1),
+	}
+}
+
+// LedgerIngested wakes up the publisher after a ledger has been committed to
+// the history database. It never blocks.
+func (p *Publisher) LedgerIngested() {
+	select {
+	case p.ingested <- struct{}{}:
+	default:
+	}
+}
+
+// Run publishes the pending ledgers every time a ledger is ingested, until
+// the context is done. The sink is closed when Run returns.
+func (p *Publisher) Run(ctx context.Context) {
+	defer func() {
+		if err := p.sink.Close(); err != nil {
+			logger.WithError(err).Warn("Error closing ledger sink")
+		}
+	}()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-p.ingested:
+		}
+
+		// errors are retried after the next ledger is ingested
+		if err := p.PublishPending(ctx); err != nil && ctx.Err() == nil {
+			logger.WithError(err).Error("Error publishing ledgers to sink")
+		}
+	}
+}
+
+// PublishPending publishes the ledgers ingested after the last published one.
+// When no ledger has been published yet it starts from the last ingested
+// ledger.
+func (p *Publisher) PublishPending(ctx context.Context) error {
+	lastIngested, err := p.historyQ.GetLastLedgerIngestNonBlocking(ctx)
+	if err != nil {
+		return errors.Wrap(err, "error getting last ingested ledger")
+	}
+	if lastIngested == 0 {
+		return nil
+	}
+
+	cursor, err := p.historyQ.GetLedgerSinkCursor(ctx)
+	if err != nil {
+		return errors.Wrap(err, "error getting ledger sink cursor")
+	}
+	if cursor == 0 {
+		cursor = lastIngested - 1
+	}
+
+	var elderLedger int32
+	if err = p.historyQ.ElderLedger(ctx, &elderLedger); err != nil {
+		return errors.Wrap(err, "error getting elder ledger")
+	}
+	if next := cursor + 1; next < uint32(elderLedger) {
+		logger.WithField("from", next).WithField("to", elderLedger-1).
+			Warn("Ledgers were removed from history before being published to sink, skipping them")
+		cursor = uint32(elderLedger) - 1
+	}
+
+	for sequence := cursor + 1; sequence <= lastIngested; sequence++ {
+		if ctx.Err() != nil {
+			return nil
+		}
+
+		batch, err := p.LoadBatch(ctx, sequence)
+		if err != nil {
+			return errors.Wrapf(err, "error loading ledger %d", sequence)
+		}
+		if err = p.sink.Publish(ctx, batch); err != nil {
+			return errors.Wrapf(err, "error publishing ledger %d", sequence)
+		}
+		if err = p.historyQ.UpdateLedgerSinkCursor(ctx, sequence); err != nil {
+			return errors.Wrap(err, "error updating ledger sink cursor")
+		}
+		logger.WithField("sequence", sequence).Debug("Published ledger to sink")
+	}
+	return nil
+}
+
+// LoadBatch loads the data ingested in the ledger from the history database.
+func (p *Publisher) LoadBatch(ctx context.Context, sequence uint32) (LedgerBatch, error) {
+	batch := LedgerBatch{
+		Transactions: []protocol.Transaction{},
+		Operations:   []hal.Pageable{},
+		Effects:      []hal.Pageable{},
+		Trades:       []protocol.Trade{},
+	}
+	seq := int32(sequence)
+
+	var ledger history.Ledger
+	if err := p.historyQ.LedgerBySequence(ctx, &ledger, seq); err != nil {
+		return batch, errors.Wrap(err, "error loading ledger")
+	}
+	resourceadapter.PopulateLedger(ctx, &batch.Ledger, ledger)
+
+	page := db2.PageQuery{Order: db2.OrderAscending, Limit: db2.MaxPageSize}
+	for {
+		var transactions []history.Transaction
+		err := p.historyQ.Transactions().ForLedger(ctx, seq).IncludeFailed().Page(page, 0).Select(ctx, &transactions)
+		if err != nil {
+			return batch, errors.Wrap(err, "error loading transactions")
+		}
+		for _, row := range transactions {
+			var transaction protocol.Transaction
+			if err = resourceadapter.PopulateTransaction(ctx, row.TransactionHash, &transaction, row, p.skipTxMeta); err != nil {
+				return batch, errors.Wrap(err, "error populating transaction")
+			}
+			batch.Transactions = append(batch.Transactions, transaction)
+		}
+		if uint64(len(transactions)) < page.Limit {
+			break
+		}
+		page.Cursor = transactions[len(transactions)-1].PagingToken()
+	}
+
+	page.Cursor = ""
+	for {
+		operations, _, err := p.historyQ.Operations().ForLedger(ctx, seq).IncludeFailed().Page(page, 0).Fetch(ctx)
+		if err != nil {
+			return batch, errors.Wrap(err, "error loading operations")
+		}
+		for _, row := range operations {
+			operation, err := resourceadapter.NewOperation(ctx, row, row.TransactionHash, nil, ledger, p.skipTxMeta)
+			if err != nil {
+				return batch, errors.Wrap(err, "error populating operation")
+			}
+			batch.Operations = append(batch.Operations, operation)
+		}
+		if uint64(len(operations)) < page.Limit {
+			break
+		}
+		page.Cursor = operations[len(operations)-1].PagingToken()
+	}
+
+	page.Cursor = ""
+	for {
+		effects, err := p.historyQ.EffectsForLedger(ctx, seq, page)
+		if err != nil {
+			return batch, errors.Wrap(err, "error loading effects")
+		}
+		for _, row := range effects {
+			effect, err := resourceadapter.NewEffect(ctx, row, ledger)
+			if err != nil {
+				return batch, errors.Wrap(err, "error populating effect")
+			}
+			batch.Effects = append(batch.Effects, effect)
+		}
+		if uint64(len(effects)) < page.Limit {
+			break
+		}
+		page.Cursor = effects[len(effects)-1].PagingToken()
+	}
+
+	trades, err := p.historyQ.GetTradesForLedger(ctx, seq)
+	if err != nil {
+		return batch, errors.Wrap(err, "error loading trades")
+	}
+	for _, row := range trades {
+		var trade protocol.Trade
+		resourceadapter.PopulateTrade(ctx, &trade, row)
+		batch.Trades = append(batch.Trades, trade)
+	}
+
+	return batch, nil
+}
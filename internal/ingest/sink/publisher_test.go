@@ -0,0 +1,84 @@
+package sink
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stellar/stellar-horizon/internal/db2/history"
+	"github.com/stellar/stellar-horizon/internal/test"
+)
+
+type memorySink struct {
+	batches []LedgerBatch
+	err     error
+}
+
+func (s *memorySink) Publish(ctx context.Context, batch LedgerBatch) error {
+	if s.err != nil {
+		return s.err
+	}
+	s.batches = append(s.batches, batch)
+	return nil
+}
+
+func (s *memorySink) Close() error {
+	return nil
+}
+
+func TestPublisherPublishesPendingLedgers(t *testing.T) {
+	tt := test.Start(t)
+	defer tt.Finish()
+	tt.Scenario("base")
+	q := &history.Q{SessionInterface: tt.HorizonSession()}
+	tt.Assert.NoError(q.UpdateLastLedgerIngest(tt.Ctx, 3))
+
+	s := &memorySink{}
+	publisher := NewPublisher(s, tt.HorizonSession(), false)
+
+	// without cursor only the last ingested ledger is published
+	tt.Assert.NoError(publisher.PublishPending(tt.Ctx))
+	tt.Assert.Len(s.batches, 1)
+	tt.Assert.Equal(int32(3), s.batches[0].Ledger.Sequence)
+	tt.Assert.Len(s.batches[0].Transactions, 1)
+	tt.Assert.Len(s.batches[0].Operations, 1)
+	cursor, err := q.GetLedgerSinkCursor(tt.Ctx)
+	tt.Assert.NoError(err)
+	tt.Assert.Equal(uint32(3), cursor)
+
+	// nothing new to publish
+	tt.Assert.NoError(publisher.PublishPending(tt.Ctx))
+	tt.Assert.Len(s.batches, 1)
+
+	// ledgers are published from the cursor
+	tt.Assert.NoError(q.UpdateLedgerSinkCursor(tt.Ctx, 1))
+	s.batches = nil
+	tt.Assert.NoError(publisher.PublishPending(tt.Ctx))
+	tt.Assert.Len(s.batches, 2)
+	tt.Assert.Equal(int32(2), s.batches[0].Ledger.Sequence)
+	tt.Assert.Len(s.batches[0].Transactions, 3)
+	tt.Assert.Equal(int32(3), s.batches[1].Ledger.Sequence)
+}
+
+func TestPublisherKeepsCursorOnError(t *testing.T) {
+	tt := test.Start(t)
+	defer tt.Finish()
+	tt.Scenario("base")
+	q := &history.Q{SessionInterface: tt.HorizonSession()}
+	tt.Assert.NoError(q.UpdateLastLedgerIngest(tt.Ctx, 3))
+	tt.Assert.NoError(q.UpdateLedgerSinkCursor(tt.Ctx, 2))
+
+	s := &memorySink{err: errors.New("unavailable")}
+	publisher := NewPublisher(s, tt.HorizonSession(), false)
+
+	tt.Assert.EqualError(publisher.PublishPending(tt.Ctx), "error publishing ledger 3: unavailable")
+	cursor, err := q.GetLedgerSinkCursor(tt.Ctx)
+	tt.Assert.NoError(err)
+	tt.Assert.Equal(uint32(2), cursor)
+
+	// the ledger is published again once the sink is available
+	s.err = nil
+	tt.Assert.NoError(publisher.PublishPending(tt.Ctx))
+	tt.Assert.Len(s.batches, 1)
+	tt.Assert.Equal(int32(3), s.batches[0].Ledger.Sequence)
+}
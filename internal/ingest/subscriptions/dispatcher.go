@@ -0,0 +1,235 @@
+package subscriptions
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"database/sql"
+	"encoding/hex"
+	"io"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/guregu/null"
+
+	"github.com/stellar/go-stellar-sdk/support/db"
+	"github.com/stellar/go-stellar-sdk/support/errors"
+	"github.com/stellar/stellar-horizon/internal/db2/history"
+)
+
+const (
+	// SignatureHeader is the header of the delivery requests containing the
+	// hex encoded HMAC-SHA256 of the request body keyed with the secret of the
+	// subscription, prefixed with "sha256=".
+	SignatureHeader = "X-Horizon-Signature"
+	// SubscriptionHeader is the header of the delivery requests containing the
+	// id of the subscription.
+	SubscriptionHeader = "X-Horizon-Subscription"
+	// DeliveryHeader is the header of the delivery requests containing the id
+	// of the delivery, receivers can use it to discard duplicates.
+	DeliveryHeader = "X-Horizon-Delivery"
+
+	// MaxAttempts is the number of failed attempts after which a delivery is
+	// marked as failed.
+	MaxAttempts = 10
+
+	dispatchBatchSize = 50
+	dispatchInterval  = 5 * time.Second
+	// deleteInterval is the interval at which the deliveries over the
+	// retention are deleted
+	deleteInterval = time.Hour
+	// claimLease must exceed the time needed to attempt a batch of deliveries
+	claimLease     = 10 * time.Minute
+	requestTimeout = 10 * time.Second
+	minBackoff     = 10 * time.Second
+	maxBackoff     = time.Hour
+)
+
+// Dispatcher posts the pending deliveries to the callback urls of their
+// subscriptions. Failed attempts are retried with an exponential backoff until
+// MaxAttempts is reached. Deliveries are claimed in the database, so several
+// Horizon instances can dispatch concurrently. The delivered and failed
+// deliveries are deleted once they are older than the retention.
+type Dispatcher struct {
+	historyQ  *history.Q
+	client    *http.Client
+	ingested  chan struct{}
+	retention time.Duration
+}
+
+// NewDispatcher returns a Dispatcher keeping the delivered and failed
+// deliveries for the retention duration, or forever when it's 0.
+func NewDispatcher(session db.SessionInterface, retention time.Duration) *Dispatcher {
+	return &Dispatcher{
+		historyQ:  &history.Q{SessionInterface: session},
+		client:    &http.Client{Timeout: requestTimeout},
+		ingested:  make(chan struct{}, 1),
+		retention: retention,
+	}
+}
+
+// LedgerIngested wakes up the dispatcher after a ledger has been committed. It
+// never blocks.
+func (d *Dispatcher) LedgerIngested() {
+	select {
+	case d.ingested <- struct{}{}:
+	default:
+	}
+}
+
+// Run dispatches the pending deliveries every time a ledger is ingested, and
+// periodically to retry failed attempts, until the context is done. The
+// deliveries over the retention are deleted every deleteInterval.
+func (d *Dispatcher) Run(ctx context.Context) {
+	ticker := time.NewTicker(dispatchInterval)
+	defer ticker.Stop()
+	deleteTicker := time.NewTicker(deleteInterval)
+	defer deleteTicker.Stop()
+	defer d.client.CloseIdleConnections()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-deleteTicker.C:
+			if err := d.DeleteExpired(ctx); err != nil && ctx.Err() == nil {
+				logger.WithError(err).Error("Error deleting expired subscription deliveries")
+			}
+			continue
+		case <-d.ingested:
+		case <-ticker.C:
+		}
+
+		if err := d.DispatchPending(ctx); err != nil && ctx.Err() == nil {
+			logger.WithError(err).Error("Error dispatching subscription deliveries")
+		}
+	}
+}
+
+// DispatchPending attempts the deliveries which are due, until none is left.
+func (d *Dispatcher) DispatchPending(ctx context.Context) error {
+	for ctx.Err() == nil {
+		deliveries, err := d.historyQ.ClaimSubscriptionDeliveries(ctx, dispatchBatchSize, claimLease)
+		if err != nil {
+			return err
+		}
+		if len(deliveries) == 0 {
+			return nil
+		}
+
+		subscriptions := map[int64]history.Subscription{}
+		for _, delivery := range deliveries {
+			subscription, ok := subscriptions[delivery.SubscriptionID]
+			if !ok {
+				subscription, err = d.historyQ.GetSubscription(ctx, delivery.SubscriptionID)
+				if err == sql.ErrNoRows {
+					// the subscription has been deleted with its deliveries
+					continue
+				} else if err != nil {
+					return errors.Wrap(err, "error getting subscription")
+				}
+				subscriptions[delivery.SubscriptionID] = subscription
+			}
+
+			delivery = d.attempt(ctx, subscription, delivery)
+			if ctx.Err() != nil {
+				// the claim expires and the delivery is attempted again
+				return nil
+			}
+			if err = d.historyQ.UpdateSubscriptionDelivery(ctx, delivery); err != nil {
+				return errors.Wrap(err, "error updating subscription delivery")
+			}
+		}
+	}
+	return nil
+}
+
+// DeleteExpired deletes the delivered and failed deliveries created before the
+// retention, it does nothing when the retention is 0.
+func (d *Dispatcher) DeleteExpired(ctx context.Context) error {
+	if d.retention <= 0 {
+		return nil
+	}
+	deleted, err := d.historyQ.DeleteSubscriptionDeliveriesBefore(ctx, time.Now().Add(-d.retention))
+	if err != nil {
+		return err
+	}
+	if deleted > 0 {
+		logger.WithField("deleted", deleted).Info("Deleted expired subscription deliveries")
+	}
+	return nil
+}
+
+// attempt posts the delivery and returns it updated with the outcome.
+func (d *Dispatcher) attempt(ctx context.Context, subscription history.Subscription, delivery history.SubscriptionDelivery) history.SubscriptionDelivery {
+	delivery.Attempts++
+	status, err := d.post(ctx, subscription, delivery)
+	if status != 0 {
+		delivery.ResponseStatus = null.IntFrom(int64(status))
+	}
+
+	now := time.Now().UTC()
+	if err == nil {
+		delivery.Status = history.SubscriptionDeliveryDelivered
+		delivery.LastError = null.String{}
+		delivery.DeliveredAt = null.TimeFrom(now)
+		delivery.NextAttemptAt = now
+		return delivery
+	}
+
+	logger.WithError(err).WithField("subscription", subscription.ID).
+		WithField("delivery", delivery.ID).WithField("attempts", delivery.Attempts).
+		Warn("Subscription delivery attempt failed")
+	delivery.LastError = null.StringFrom(err.Error())
+	delivery.NextAttemptAt = now.Add(Backoff(delivery.Attempts))
+	if delivery.Attempts >= MaxAttempts {
+		delivery.Status = history.SubscriptionDeliveryFailed
+	}
+	return delivery
+}
+
+func (d *Dispatcher) post(ctx context.Context, subscription history.Subscription, delivery history.SubscriptionDelivery) (int, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, subscription.CallbackURL, bytes.NewReader(delivery.Payload))
+	if err != nil {
+		return 0, errors.Wrap(err, "error creating request")
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(SignatureHeader, Sign(subscription.Secret, delivery.Payload))
+	req.Header.Set(SubscriptionHeader, strconv.FormatInt(subscription.ID, 10))
+	req.Header.Set(DeliveryHeader, strconv.FormatInt(delivery.ID, 10))
+
+	resp, err := d.client.Do(req)
+	if err != nil {
+		return 0, errors.Wrap(err, "error posting delivery")
+	}
+	defer resp.Body.Close()
+	// drain the body so the connection can be reused
+	_, _ = io.Copy(io.Discard, resp.Body)
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return resp.StatusCode, errors.Errorf("delivery rejected with status %d", resp.StatusCode)
+	}
+	return resp.StatusCode, nil
+}
+
+// Sign returns the value of the SignatureHeader of a delivery request.
+func Sign(secret string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(body)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+// Backoff returns the delay before the next attempt of a delivery after the
+// given number of failed attempts.
+func Backoff(attempts int32) time.Duration {
+	backoff := minBackoff
+	for i := int32(1); i < attempts && backoff < maxBackoff; i++ {
+		backoff *= 2
+	}
+	if backoff > maxBackoff {
+		return maxBackoff
+	}
+	return backoff
+}
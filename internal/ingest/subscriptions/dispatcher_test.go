@@ -0,0 +1,167 @@
+package subscriptions
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/lib/pq"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/stellar/stellar-horizon/internal/db2"
+	"github.com/stellar/stellar-horizon/internal/db2/history"
+	"github.com/stellar/stellar-horizon/internal/test"
+)
+
+func TestSign(t *testing.T) {
+	// echo -n '{"ledger":1}' | openssl dgst -sha256 -hmac secret
+	assert.Equal(t,
+		"sha256=3e757b29e77ce5de38c5704c8584af49d302631c2fdbaaef59ccc3daea8679fd",
+		Sign("secret", []byte(`{"ledger":1}`)),
+	)
+}
+
+func TestBackoff(t *testing.T) {
+	assert.Equal(t, 10*time.Second, Backoff(1))
+	assert.Equal(t, 20*time.Second, Backoff(2))
+	assert.Equal(t, 80*time.Second, Backoff(4))
+	assert.Equal(t, time.Hour, Backoff(MaxAttempts))
+}
+
+func TestDispatcherAttempt(t *testing.T) {
+	status := http.StatusOK
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, err := io.ReadAll(r.Body)
+		assert.NoError(t, err)
+		assert.Equal(t, http.MethodPost, r.Method)
+		assert.Equal(t, "application/json", r.Header.Get("Content-Type"))
+		assert.Equal(t, Sign("secret", body), r.Header.Get(SignatureHeader))
+		assert.Equal(t, "3", r.Header.Get(SubscriptionHeader))
+		assert.Equal(t, "7", r.Header.Get(DeliveryHeader))
+		w.WriteHeader(status)
+	}))
+	defer server.Close()
+
+	dispatcher := NewDispatcher(nil, 0)
+	subscription := history.Subscription{ID: 3, CallbackURL: server.URL, Secret: "secret"}
+	pending := history.SubscriptionDelivery{
+		ID:             7,
+		SubscriptionID: 3,
+		Payload:        []byte(`{"ledger":1}`),
+		Status:         history.SubscriptionDeliveryPending,
+	}
+
+	delivery := dispatcher.attempt(context.Background(), subscription, pending)
+	assert.Equal(t, history.SubscriptionDeliveryDelivered, delivery.Status)
+	assert.Equal(t, int32(1), delivery.Attempts)
+	assert.Equal(t, int64(http.StatusOK), delivery.ResponseStatus.Int64)
+	assert.True(t, delivery.DeliveredAt.Valid)
+	assert.False(t, delivery.LastError.Valid)
+
+	status = http.StatusServiceUnavailable
+	before := time.Now().UTC()
+	delivery = dispatcher.attempt(context.Background(), subscription, pending)
+	assert.Equal(t, history.SubscriptionDeliveryPending, delivery.Status)
+	assert.Equal(t, int32(1), delivery.Attempts)
+	assert.Equal(t, int64(http.StatusServiceUnavailable), delivery.ResponseStatus.Int64)
+	assert.Equal(t, "delivery rejected with status 503", delivery.LastError.String)
+	assert.False(t, delivery.NextAttemptAt.Before(before.Add(Backoff(1))))
+
+	// the delivery fails after the last attempt
+	pending.Attempts = MaxAttempts - 1
+	delivery = dispatcher.attempt(context.Background(), subscription, pending)
+	assert.Equal(t, history.SubscriptionDeliveryFailed, delivery.Status)
+	assert.Equal(t, int32(MaxAttempts), delivery.Attempts)
+}
+
+func TestDispatchPending(t *testing.T) {
+	tt := test.Start(t)
+	defer tt.Finish()
+	test.ResetHorizonDB(t, tt.HorizonDB)
+	q := &history.Q{SessionInterface: tt.HorizonSession()}
+
+	var received []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		received = append(received, r.Header.Get(DeliveryHeader))
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+
+	subscription, err := q.CreateSubscription(tt.Ctx, history.Subscription{
+		CallbackURL: server.URL,
+		Secret:      "secret",
+		AccountIDs:  pq.StringArray{sourceAccount},
+	})
+	tt.Assert.NoError(err)
+
+	tt.Assert.NoError(q.Begin(tt.Ctx))
+	batch := q.NewSubscriptionDeliveryBatchInsertBuilder()
+	tt.Assert.NoError(batch.Add(subscription.ID, 10, []byte(`{"ledger":10}`)))
+	tt.Assert.NoError(batch.Add(subscription.ID, 11, []byte(`{"ledger":11}`)))
+	tt.Assert.NoError(batch.Exec(tt.Ctx, q))
+	tt.Assert.NoError(q.Commit())
+
+	dispatcher := NewDispatcher(tt.HorizonSession(), time.Hour)
+	tt.Assert.NoError(dispatcher.DispatchPending(tt.Ctx))
+	tt.Assert.Len(received, 2)
+
+	deliveries, err := q.GetSubscriptionDeliveries(tt.Ctx, subscription.ID, db2.PageQuery{Order: db2.OrderAscending, Limit: 10})
+	tt.Assert.NoError(err)
+	tt.Assert.Len(deliveries, 2)
+	for _, delivery := range deliveries {
+		tt.Assert.Equal(history.SubscriptionDeliveryDelivered, delivery.Status)
+		tt.Assert.Equal(int32(1), delivery.Attempts)
+		tt.Assert.True(delivery.DeliveredAt.Valid)
+	}
+
+	// delivered deliveries are not attempted again
+	tt.Assert.NoError(dispatcher.DispatchPending(tt.Ctx))
+	tt.Assert.Len(received, 2)
+}
+
+func TestDispatcherDeleteExpired(t *testing.T) {
+	tt := test.Start(t)
+	defer tt.Finish()
+	test.ResetHorizonDB(t, tt.HorizonDB)
+	q := &history.Q{SessionInterface: tt.HorizonSession()}
+
+	subscription, err := q.CreateSubscription(tt.Ctx, history.Subscription{
+		CallbackURL: "http://localhost",
+		Secret:      "secret",
+		AccountIDs:  pq.StringArray{sourceAccount},
+	})
+	tt.Assert.NoError(err)
+
+	tt.Assert.NoError(q.Begin(tt.Ctx))
+	batch := q.NewSubscriptionDeliveryBatchInsertBuilder()
+	tt.Assert.NoError(batch.Add(subscription.ID, 10, []byte(`{"ledger":10}`)))
+	tt.Assert.NoError(batch.Add(subscription.ID, 11, []byte(`{"ledger":11}`)))
+	tt.Assert.NoError(batch.Exec(tt.Ctx, q))
+	tt.Assert.NoError(q.Commit())
+
+	page := db2.PageQuery{Order: db2.OrderAscending, Limit: 10}
+	deliveries, err := q.GetSubscriptionDeliveries(tt.Ctx, subscription.ID, page)
+	tt.Assert.NoError(err)
+	tt.Assert.Len(deliveries, 2)
+	deliveries[0].Status = history.SubscriptionDeliveryDelivered
+	tt.Assert.NoError(q.UpdateSubscriptionDelivery(tt.Ctx, deliveries[0]))
+
+	// deliveries are kept without retention, or within it
+	tt.Assert.NoError(NewDispatcher(tt.HorizonSession(), 0).DeleteExpired(tt.Ctx))
+	tt.Assert.NoError(NewDispatcher(tt.HorizonSession(), time.Hour).DeleteExpired(tt.Ctx))
+	deliveries, err = q.GetSubscriptionDeliveries(tt.Ctx, subscription.ID, page)
+	tt.Assert.NoError(err)
+	tt.Assert.Len(deliveries, 2)
+
+	// the pending delivery is kept over the retention
+	time.Sleep(10 * time.Millisecond)
+	tt.Assert.NoError(NewDispatcher(tt.HorizonSession(), time.Millisecond).DeleteExpired(tt.Ctx))
+	deliveries, err = q.GetSubscriptionDeliveries(tt.Ctx, subscription.ID, page)
+	tt.Assert.NoError(err)
+	tt.Assert.Len(deliveries, 1)
+	tt.Assert.Equal(uint32(11), deliveries[0].LedgerSequence)
+	tt.Assert.Equal(history.SubscriptionDeliveryPending, deliveries[0].Status)
+}
@@ -0,0 +1,137 @@
+// Package subscriptions delivers the ingested transactions matching the
+// subscriptions registered through the admin API to their callback urls, see
+// Processor and Dispatcher.
+package subscriptions
+
+import (
+	"context"
+	"sort"
+
+	"github.com/stellar/go-stellar-sdk/ingest"
+	"github.com/stellar/go-stellar-sdk/support/collections/set"
+	"github.com/stellar/go-stellar-sdk/support/errors"
+	"github.com/stellar/go-stellar-sdk/support/log"
+	"github.com/stellar/stellar-horizon/internal/db2/history"
+	"github.com/stellar/stellar-horizon/internal/ingest/filters"
+	"github.com/stellar/stellar-horizon/internal/ingest/processors"
+)
+
+var logger = log.WithFields(log.F{
+	"service": "subscriptions",
+})
+
+type subscriptionSet struct {
+	subscriptionID int64
+	set            set.Set[string]
+}
+
+// Matcher matches transactions against a set of subscriptions. A transaction
+// matches a subscription if one of the accounts of the subscription is a
+// participant of the transaction, or if one of its operations refers to one of
+// the assets of the subscription, or if it invokes one of the contracts of the
+// subscription.
+type Matcher struct {
+	// accounts are indexed as subscriptions can watch a large number of them
+	subscriptionsByAccount map[string][]int64
+	assets                 []subscriptionSet
+	contracts              []subscriptionSet
+}
+
+func NewMatcher(subscriptions []history.Subscription) *Matcher {
+	m := &Matcher{
+		subscriptionsByAccount: map[string][]int64{},
+	}
+	for _, subscription := range subscriptions {
+		for _, account := range subscription.AccountIDs {
+			m.subscriptionsByAccount[account] = append(m.subscriptionsByAccount[account], subscription.ID)
+		}
+		if len(subscription.Assets) > 0 {
+			m.assets = append(m.assets, subscriptionSet{subscription.ID, listToSet(subscription.Assets)})
+		}
+		if len(subscription.ContractIDs) > 0 {
+			m.contracts = append(m.contracts, subscriptionSet{subscription.ID, listToSet(subscription.ContractIDs)})
+		}
+	}
+	return m
+}
+
+// Empty returns true if no transaction can match.
+func (m *Matcher) Empty() bool {
+	return len(m.subscriptionsByAccount) == 0 && len(m.assets) == 0 && len(m.contracts) == 0
+}
+
+// Match returns the ids of the subscriptions matched by the transaction in
+// ascending order.
+func (m *Matcher) Match(transaction ingest.LedgerTransaction) ([]int64, error) {
+	matched := set.Set[int64]{}
+
+	if len(m.subscriptionsByAccount) > 0 {
+		participants, err := processors.ParticipantsForTransaction(0, transaction)
+		if err != nil {
+			return nil, errors.Wrap(err, "could not determine participants")
+		}
+		for _, participant := range participants {
+			for _, id := range m.subscriptionsByAccount[participant.Address()] {
+				matched.Add(id)
+			}
+		}
+	}
+
+	for _, assets := range m.assets {
+		if !matched.Contains(assets.subscriptionID) && filters.TransactionMatchesAssets(transaction, assets.set) {
+			matched.Add(assets.subscriptionID)
+		}
+	}
+
+	for _, contracts := range m.contracts {
+		if !matched.Contains(contracts.subscriptionID) && filters.TransactionInvokesContracts(transaction, contracts.set) {
+			matched.Add(contracts.subscriptionID)
+		}
+	}
+
+	ids := make([]int64, 0, len(matched))
+	for id := range matched {
+		ids = append(ids, id)
+	}
+	sort.Slice(ids, func(i, j int) bool { return ids[i] < ids[j] })
+	return ids, nil
+}
+
+// Index keeps the matcher of the current subscriptions in memory. The
+// subscriptions are reloaded from the database when they change.
+type Index struct {
+	version history.SubscriptionsVersion
+	matcher *Matcher
+}
+
+func NewIndex() *Index {
+	return &Index{}
+}
+
+// Matcher returns the matcher of the current subscriptions.
+func (i *Index) Matcher(ctx context.Context, q history.QSubscriptions) (*Matcher, error) {
+	version, err := q.GetSubscriptionsVersion(ctx)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not get subscriptions version")
+	}
+	if i.matcher != nil && version == i.version {
+		return i.matcher, nil
+	}
+
+	subscriptions, err := q.GetAllSubscriptions(ctx)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not load subscriptions")
+	}
+	logger.WithField("subscriptions", len(subscriptions)).Info("Loaded subscriptions")
+	i.matcher = NewMatcher(subscriptions)
+	i.version = version
+	return i.matcher, nil
+}
+
+func listToSet(list []string) set.Set[string] {
+	s := set.NewSet[string](len(list))
+	for _, item := range list {
+		s.Add(item)
+	}
+	return s
+}
@@ -0,0 +1,184 @@
+package subscriptions
+
+import (
+	"context"
+	"testing"
+
+	"github.com/lib/pq"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/stellar/go-stellar-sdk/ingest"
+	"github.com/stellar/go-stellar-sdk/strkey"
+	"github.com/stellar/go-stellar-sdk/xdr"
+	"github.com/stellar/stellar-horizon/internal/db2/history"
+)
+
+const (
+	sourceAccount      = "GD6WNNTW664WH7FXC5RUMUTF7P5QSURC2IT36VOQEEGFZ4UWUEQGECAL"
+	destinationAccount = "GBRPYHIL2CI3FNQ4BXLFMNDLFJUNPU2HY3ZMFSHONUCEOASW7QC7OX2H"
+	otherAccount       = "GCEZWKCA5VLDNRLN3RPRJMRZOX3Z6G5CHCGSNFHEYVXM3XOJMDS674JZ"
+	usdcIssuer         = "GA5ZSEJYB37JRC5AVCIA5MOP4RHTM335X2KGX3IHOJAPP5RE34K4KZVN"
+)
+
+var invokedContractID = xdr.ContractId{1}
+
+func TestMatcherMatchesAccountsAssetsAndContracts(t *testing.T) {
+	matcher := NewMatcher([]history.Subscription{
+		{ID: 1, AccountIDs: pq.StringArray{destinationAccount}},
+		{ID: 2, AccountIDs: pq.StringArray{otherAccount}},
+		{ID: 3, Assets: pq.StringArray{"USDC:" + usdcIssuer}},
+		{ID: 4, Assets: pq.StringArray{"native"}},
+		{ID: 5, ContractIDs: pq.StringArray{contractAddress(invokedContractID)}},
+		// matched by both its account and its asset
+		{ID: 6, AccountIDs: pq.StringArray{sourceAccount}, Assets: pq.StringArray{"USDC:" + usdcIssuer}},
+	})
+	assert.False(t, matcher.Empty())
+
+	ids, err := matcher.Match(getPaymentTestTx(t))
+	require.NoError(t, err)
+	assert.Equal(t, []int64{1, 3, 6}, ids)
+
+	ids, err = matcher.Match(getContractTestTx())
+	require.NoError(t, err)
+	assert.Equal(t, []int64{5, 6}, ids)
+}
+
+func TestMatcherWithoutSubscriptions(t *testing.T) {
+	matcher := NewMatcher(nil)
+	assert.True(t, matcher.Empty())
+
+	ids, err := matcher.Match(getPaymentTestTx(t))
+	require.NoError(t, err)
+	assert.Empty(t, ids)
+}
+
+func TestIndexReloadsChangedSubscriptions(t *testing.T) {
+	ctx := context.Background()
+	q := &history.MockQSubscriptions{}
+	index := NewIndex()
+
+	q.On("GetSubscriptionsVersion", ctx).Return(history.SubscriptionsVersion{MaxID: 1, Count: 1}, nil).Twice()
+	q.On("GetAllSubscriptions", ctx).Return([]history.Subscription{
+		{ID: 1, AccountIDs: pq.StringArray{destinationAccount}},
+	}, nil).Once()
+
+	matcher, err := index.Matcher(ctx, q)
+	require.NoError(t, err)
+	ids, err := matcher.Match(getPaymentTestTx(t))
+	require.NoError(t, err)
+	assert.Equal(t, []int64{1}, ids)
+
+	// unchanged subscriptions are not reloaded
+	cached, err := index.Matcher(ctx, q)
+	require.NoError(t, err)
+	assert.Same(t, matcher, cached)
+
+	// the subscription has been deleted
+	q.On("GetSubscriptionsVersion", ctx).Return(history.SubscriptionsVersion{MaxID: 0, Count: 0}, nil).Once()
+	q.On("GetAllSubscriptions", ctx).Return([]history.Subscription{}, nil).Once()
+	matcher, err = index.Matcher(ctx, q)
+	require.NoError(t, err)
+	assert.True(t, matcher.Empty())
+
+	q.AssertExpectations(t)
+}
+
+func contractAddress(contractID xdr.ContractId) string {
+	return strkey.MustEncode(strkey.VersionByteContract, contractID[:])
+}
+
+func successfulResult() xdr.TransactionResultPair {
+	return xdr.TransactionResultPair{
+		Result: xdr.TransactionResult{
+			Result: xdr.TransactionResultResult{
+				Code:    xdr.TransactionResultCodeTxSuccess,
+				Results: &[]xdr.OperationResult{},
+			},
+		},
+	}
+}
+
+func getPaymentTestTx(t *testing.T) ingest.LedgerTransaction {
+	var xdrAssetCode [4]byte
+	var xdrIssuer xdr.AccountId
+	copy(xdrAssetCode[:], "USDC")
+	require.NoError(t, xdrIssuer.SetAddress(usdcIssuer))
+
+	return ingest.LedgerTransaction{
+		UnsafeMeta: xdr.TransactionMeta{
+			V: 1,
+			V1: &xdr.TransactionMetaV1{
+				Operations: []xdr.OperationMeta{},
+			},
+		},
+		Result: successfulResult(),
+		Envelope: xdr.TransactionEnvelope{
+			Type: xdr.EnvelopeTypeEnvelopeTypeTx,
+			V1: &xdr.TransactionV1Envelope{
+				Tx: xdr.Transaction{
+					SourceAccount: xdr.MustMuxedAddress(sourceAccount),
+					Operations: []xdr.Operation{
+						{Body: xdr.OperationBody{
+							Type: xdr.OperationTypePayment,
+							PaymentOp: &xdr.PaymentOp{
+								Destination: xdr.MustMuxedAddress(destinationAccount),
+								Asset: xdr.Asset{
+									Type: xdr.AssetTypeAssetTypeCreditAlphanum4,
+									AlphaNum4: &xdr.AlphaNum4{
+										AssetCode: xdrAssetCode,
+										Issuer:    xdrIssuer,
+									},
+								},
+								Amount: 100,
+							},
+						}},
+					},
+				},
+			},
+		},
+	}
+}
+
+func getContractTestTx() ingest.LedgerTransaction {
+	invoked := invokedContractID
+	return ingest.LedgerTransaction{
+		UnsafeMeta: xdr.TransactionMeta{
+			V: 3,
+			V3: &xdr.TransactionMetaV3{
+				Operations:  []xdr.OperationMeta{{}},
+				SorobanMeta: &xdr.SorobanTransactionMeta{},
+			},
+		},
+		Result: successfulResult(),
+		Envelope: xdr.TransactionEnvelope{
+			Type: xdr.EnvelopeTypeEnvelopeTypeTx,
+			V1: &xdr.TransactionV1Envelope{
+				Tx: xdr.Transaction{
+					SourceAccount: xdr.MustMuxedAddress(sourceAccount),
+					Ext: xdr.TransactionExt{
+						V:           1,
+						SorobanData: &xdr.SorobanTransactionData{},
+					},
+					Operations: []xdr.Operation{
+						{Body: xdr.OperationBody{
+							Type: xdr.OperationTypeInvokeHostFunction,
+							InvokeHostFunctionOp: &xdr.InvokeHostFunctionOp{
+								HostFunction: xdr.HostFunction{
+									Type: xdr.HostFunctionTypeHostFunctionTypeInvokeContract,
+									InvokeContract: &xdr.InvokeContractArgs{
+										ContractAddress: xdr.ScAddress{
+											Type:       xdr.ScAddressTypeScAddressTypeContract,
+											ContractId: &invoked,
+										},
+										FunctionName: "swap",
+									},
+								},
+							},
+						}},
+					},
+				},
+			},
+		},
+	}
+}
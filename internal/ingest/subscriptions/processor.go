@@ -0,0 +1,141 @@
+package subscriptions
+
+import (
+	"context"
+	"encoding/json"
+	"sort"
+	"time"
+
+	"github.com/stellar/go-stellar-sdk/ingest"
+	"github.com/stellar/go-stellar-sdk/support/db"
+	"github.com/stellar/go-stellar-sdk/support/errors"
+	"github.com/stellar/go-stellar-sdk/xdr"
+	"github.com/stellar/stellar-horizon/internal/db2/history"
+)
+
+// Payload is the JSON document posted to the callback url of a subscription,
+// it contains the transactions of a ledger matching the subscription.
+type Payload struct {
+	SubscriptionID  int64         `json:"subscription_id"`
+	Ledger          uint32        `json:"ledger"`
+	LedgerCloseTime time.Time     `json:"ledger_close_time"`
+	Transactions    []Transaction `json:"transactions"`
+}
+
+// Transaction is a transaction matching a subscription.
+type Transaction struct {
+	Hash           string `json:"hash"`
+	Successful     bool   `json:"successful"`
+	SourceAccount  string `json:"source_account"`
+	OperationCount int    `json:"operation_count"`
+	EnvelopeXdr    string `json:"envelope_xdr"`
+	ResultXdr      string `json:"result_xdr"`
+}
+
+type payloadKey struct {
+	ledger         uint32
+	subscriptionID int64
+}
+
+// Processor collects the ingested transactions matching the subscriptions and
+// inserts a pending delivery per subscription and ledger when flushed. The
+// deliveries are inserted in the ingestion transaction so they are recorded
+// exactly once per ingested ledger.
+type Processor struct {
+	matcher  *Matcher
+	batch    history.SubscriptionDeliveryBatchInsertBuilder
+	payloads map[payloadKey]*Payload
+}
+
+func NewProcessor(matcher *Matcher, batch history.SubscriptionDeliveryBatchInsertBuilder) *Processor {
+	return &Processor{
+		matcher:  matcher,
+		batch:    batch,
+		payloads: map[payloadKey]*Payload{},
+	}
+}
+
+func (p *Processor) Name() string {
+	return "subscriptions.Processor"
+}
+
+func (p *Processor) ProcessTransaction(lcm xdr.LedgerCloseMeta, transaction ingest.LedgerTransaction) error {
+	if p.matcher.Empty() {
+		return nil
+	}
+
+	subscriptionIDs, err := p.matcher.Match(transaction)
+	if err != nil || len(subscriptionIDs) == 0 {
+		return err
+	}
+
+	tx, err := newTransaction(transaction)
+	if err != nil {
+		return err
+	}
+
+	for _, subscriptionID := range subscriptionIDs {
+		key := payloadKey{ledger: lcm.LedgerSequence(), subscriptionID: subscriptionID}
+		payload, ok := p.payloads[key]
+		if !ok {
+			payload = &Payload{
+				SubscriptionID:  subscriptionID,
+				Ledger:          lcm.LedgerSequence(),
+				LedgerCloseTime: time.Unix(lcm.LedgerCloseTime(), 0).UTC(),
+			}
+			p.payloads[key] = payload
+		}
+		payload.Transactions = append(payload.Transactions, tx)
+	}
+	return nil
+}
+
+func (p *Processor) Flush(ctx context.Context, session db.SessionInterface) error {
+	if len(p.payloads) == 0 {
+		return nil
+	}
+
+	keys := make([]payloadKey, 0, len(p.payloads))
+	for key := range p.payloads {
+		keys = append(keys, key)
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		if keys[i].ledger != keys[j].ledger {
+			return keys[i].ledger < keys[j].ledger
+		}
+		return keys[i].subscriptionID < keys[j].subscriptionID
+	})
+
+	for _, key := range keys {
+		body, err := json.Marshal(p.payloads[key])
+		if err != nil {
+			return errors.Wrap(err, "error encoding subscription payload")
+		}
+		if err = p.batch.Add(key.subscriptionID, key.ledger, body); err != nil {
+			return errors.Wrap(err, "error adding subscription delivery")
+		}
+	}
+	p.payloads = map[payloadKey]*Payload{}
+	return p.batch.Exec(ctx, session)
+}
+
+func newTransaction(transaction ingest.LedgerTransaction) (Transaction, error) {
+	envelope, err := xdr.MarshalBase64(transaction.Envelope)
+	if err != nil {
+		return Transaction{}, errors.Wrap(err, "error encoding transaction envelope")
+	}
+	result, err := xdr.MarshalBase64(transaction.Result.Result)
+	if err != nil {
+		return Transaction{}, errors.Wrap(err, "error encoding transaction result")
+	}
+	sourceAccount := transaction.Envelope.SourceAccount().ToAccountId()
+
+	return Transaction{
+		Hash:           transaction.Result.TransactionHash.HexString(),
+		Successful:     transaction.Result.Successful(),
+		SourceAccount:  sourceAccount.Address(),
+		OperationCount: len(transaction.Envelope.Operations()),
+		EnvelopeXdr:    envelope,
+		ResultXdr:      result,
+	}, nil
+}
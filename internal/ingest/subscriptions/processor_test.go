@@ -0,0 +1,86 @@
+package subscriptions
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/lib/pq"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/stellar/go-stellar-sdk/support/db"
+	"github.com/stellar/go-stellar-sdk/xdr"
+	"github.com/stellar/stellar-horizon/internal/db2/history"
+)
+
+func testLedger(sequence uint32) xdr.LedgerCloseMeta {
+	return xdr.LedgerCloseMeta{
+		V0: &xdr.LedgerCloseMetaV0{
+			LedgerHeader: xdr.LedgerHeaderHistoryEntry{
+				Header: xdr.LedgerHeader{
+					LedgerSeq: xdr.Uint32(sequence),
+					ScpValue:  xdr.StellarValue{CloseTime: 1700000000},
+				},
+			},
+		},
+	}
+}
+
+func TestProcessorInsertsDeliveryPerSubscription(t *testing.T) {
+	ctx := context.Background()
+	session := &db.MockSession{}
+	batch := &history.MockSubscriptionDeliveryBatchInsertBuilder{}
+	processor := NewProcessor(NewMatcher([]history.Subscription{
+		{ID: 1, AccountIDs: pq.StringArray{sourceAccount}},
+		{ID: 2, ContractIDs: pq.StringArray{contractAddress(invokedContractID)}},
+	}), batch)
+
+	ledger := testLedger(10)
+	require.NoError(t, processor.ProcessTransaction(ledger, getPaymentTestTx(t)))
+	require.NoError(t, processor.ProcessTransaction(ledger, getContractTestTx()))
+
+	var payloads []Payload
+	batch.On("Add", mock.Anything, uint32(10), mock.Anything).Run(func(args mock.Arguments) {
+		var payload Payload
+		require.NoError(t, json.Unmarshal(args.Get(2).([]byte), &payload))
+		assert.Equal(t, args.Get(0).(int64), payload.SubscriptionID)
+		payloads = append(payloads, payload)
+	}).Return(nil).Twice()
+	batch.On("Exec", ctx, session).Return(nil).Once()
+
+	require.NoError(t, processor.Flush(ctx, session))
+	batch.AssertExpectations(t)
+
+	require.Len(t, payloads, 2)
+	assert.Equal(t, int64(1), payloads[0].SubscriptionID)
+	assert.Equal(t, uint32(10), payloads[0].Ledger)
+	assert.Equal(t, time.Unix(1700000000, 0).UTC(), payloads[0].LedgerCloseTime)
+	require.Len(t, payloads[0].Transactions, 2)
+	assert.Equal(t, sourceAccount, payloads[0].Transactions[0].SourceAccount)
+	assert.True(t, payloads[0].Transactions[0].Successful)
+	assert.Equal(t, 1, payloads[0].Transactions[0].OperationCount)
+	assert.NotEmpty(t, payloads[0].Transactions[0].EnvelopeXdr)
+	assert.NotEmpty(t, payloads[0].Transactions[0].ResultXdr)
+
+	assert.Equal(t, int64(2), payloads[1].SubscriptionID)
+	require.Len(t, payloads[1].Transactions, 1)
+
+	// the payloads are reset after the flush
+	require.NoError(t, processor.Flush(ctx, session))
+	batch.AssertExpectations(t)
+}
+
+func TestProcessorWithoutMatch(t *testing.T) {
+	ctx := context.Background()
+	batch := &history.MockSubscriptionDeliveryBatchInsertBuilder{}
+	processor := NewProcessor(NewMatcher([]history.Subscription{
+		{ID: 1, AccountIDs: pq.StringArray{otherAccount}},
+	}), batch)
+
+	require.NoError(t, processor.ProcessTransaction(testLedger(10), getPaymentTestTx(t)))
+	require.NoError(t, processor.Flush(ctx, &db.MockSession{}))
+	batch.AssertExpectations(t)
+}
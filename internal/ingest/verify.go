@@ -15,6 +15,7 @@ import (
 
 	ingestsdk "github.com/stellar/go-stellar-sdk/ingest"
 
+	"github.com/stellar/go-stellar-sdk/strkey"
 	"github.com/stellar/go-stellar-sdk/support/errors"
 	logpkg "github.com/stellar/go-stellar-sdk/support/log"
 	"github.com/stellar/go-stellar-sdk/xdr"
@@ -55,7 +56,7 @@ type StateVerifier struct {
 // check them.
 // There is a test that checks it, to fix it: update the actual `verifyState`
 // method instead of just updating this value!
-const StateVerifierExpectedIngestionVersion = 20
+const StateVerifierExpectedIngestionVersion = 22
 
 func NewStateVerifier(stateReader ingestsdk.ChangeReader) *StateVerifier {
 	return &StateVerifier{
@@ -355,7 +356,9 @@ func (s *system) verifyState(verifyAgainstLatestCheckpoint bool, checkpointSeque
 
 	assetStats := processors.NewAssetStatSet()
 	createdExpirationEntries := map[xdr.Hash]uint32{}
+	contractDataExpirations := map[xdr.Hash]uint32{}
 	var contractDataEntries []xdr.LedgerEntry
+	untrackedContractData := 0
 	total := int64(0)
 	for {
 		var entries []xdr.LedgerEntry
@@ -374,6 +377,7 @@ func (s *system) verifyState(verifyAgainstLatestCheckpoint bool, checkpointSeque
 		trustLines := make([]xdr.LedgerKeyTrustLine, 0, verifyBatchSize)
 		cBalances := make([]xdr.ClaimableBalanceId, 0, verifyBatchSize)
 		lPools := make([]xdr.PoolId, 0, verifyBatchSize)
+		contractData := make([]xdr.Hash, 0, verifyBatchSize)
 		for _, entry := range entries {
 			switch entry.Data.Type {
 			case xdr.LedgerEntryTypeAccount:
@@ -403,11 +407,19 @@ func (s *system) verifyState(verifyAgainstLatestCheckpoint bool, checkpointSeque
 				lPools = append(lPools, entry.Data.MustLiquidityPool().LiquidityPoolId)
 				totalByType["liquidity_pools"]++
 			case xdr.LedgerEntryTypeContractData:
-				// contract data is a special case.
-				// we don't store contract data entries in the db,
-				// however, we ingest contract data entries for asset stats.
-				if err = verifier.Write(entry); err != nil {
-					return err
+				// we only store persistent contract data entries in the db,
+				// however, we ingest all contract data entries for asset stats.
+				if processors.IsStoredContractData(entry) {
+					keyHash, keyErr := processors.LedgerEntryKeyHash(entry)
+					if keyErr != nil {
+						return keyErr
+					}
+					contractData = append(contractData, keyHash)
+				} else {
+					if err = verifier.Write(entry); err != nil {
+						return err
+					}
+					untrackedContractData++
 				}
 				contractDataEntries = append(contractDataEntries, entry)
 				totalByType["contract_data"]++
@@ -456,6 +468,11 @@ func (s *system) verifyState(verifyAgainstLatestCheckpoint bool, checkpointSeque
 			return errors.Wrap(err, "addLiquidityPoolsToStateVerifier failed")
 		}
 
+		err = addContractDataToStateVerifier(ctx, verifier, historyQ, contractData, contractDataExpirations)
+		if err != nil {
+			return errors.Wrap(err, "addContractDataToStateVerifier failed")
+		}
+
 		total += int64(len(entries))
 		localLog.WithField("total", total).Info("Batch added to StateVerifier")
 	}
@@ -510,14 +527,24 @@ func (s *system) verifyState(verifyAgainstLatestCheckpoint bool, checkpointSeque
 		return errors.Wrap(err, "Error running historyQ.CountLiquidityPools")
 	}
 
+	countContractData, err := historyQ.CountContractData(ctx)
+	if err != nil {
+		return errors.Wrap(err, "Error running historyQ.CountContractData")
+	}
+
 	err = verifier.Verify(
 		countAccounts + countData + countOffers + countTrustLines + countClaimableBalances +
-			countLiquidityPools + int(totalByType["contract_data"]) + int(totalByType["ttl"]),
+			countLiquidityPools + countContractData + untrackedContractData + int(totalByType["ttl"]),
 	)
 	if err != nil {
 		return errors.Wrap(err, "verifier.Verify failed")
 	}
 
+	err = checkContractDataExpirations(contractDataExpirations, createdExpirationEntries)
+	if err != nil {
+		return errors.Wrap(err, "checkContractDataExpirations failed")
+	}
+
 	err = checkAssetStats(ctx, assetStats, contractAssetStatSet, historyQ)
 	if err != nil {
 		return errors.Wrap(err, "checkAssetStats failed")
@@ -713,6 +740,17 @@ func checkContractBalances(
 				)
 			}
 
+			if !bytes.Equal(row.HolderContractID, expected.HolderContractID) {
+				return ingestsdk.NewStateError(
+					fmt.Errorf(
+						"contract balance %v has holder %v in HAS but is %v in db",
+						key,
+						expected.HolderContractID,
+						row.HolderContractID,
+					),
+				)
+			}
+
 			if row.ExpirationLedger != expected.ExpirationLedger {
 				return ingestsdk.NewStateError(
 					fmt.Errorf(
@@ -1221,6 +1259,95 @@ func addLiquidityPoolsToStateVerifier(
 	return nil
 }
 
+func addContractDataToStateVerifier(
+	ctx context.Context,
+	verifier *StateVerifier,
+	q history.IngestionQ,
+	keys []xdr.Hash,
+	expirations map[xdr.Hash]uint32,
+) error {
+	if len(keys) == 0 {
+		return nil
+	}
+
+	rows, err := q.GetContractDataByKeyHashes(ctx, keys)
+	if err != nil {
+		return errors.Wrap(err, "Error running history.Q.GetContractDataByKeyHashes")
+	}
+
+	for _, row := range rows {
+		entry, err := contractDataToXDR(row)
+		if err != nil {
+			return errors.Wrap(err, "Invalid contract data row")
+		}
+		if err := verifier.Write(entry); err != nil {
+			return err
+		}
+
+		var keyHash xdr.Hash
+		copy(keyHash[:], row.KeyHash)
+		expirations[keyHash] = row.ExpirationLedger
+	}
+
+	return nil
+}
+
+func contractDataToXDR(row history.ContractData) (xdr.LedgerEntry, error) {
+	rawContractID, err := strkey.Decode(strkey.VersionByteContract, row.ContractID)
+	if err != nil {
+		return xdr.LedgerEntry{}, errors.Wrap(err, "Error decoding contract id")
+	}
+	var contractID xdr.ContractId
+	copy(contractID[:], rawContractID)
+
+	var key, val xdr.ScVal
+	if err = xdr.SafeUnmarshalBase64(row.Key, &key); err != nil {
+		return xdr.LedgerEntry{}, errors.Wrap(err, "Error decoding contract data key")
+	}
+	if err = xdr.SafeUnmarshalBase64(row.Val, &val); err != nil {
+		return xdr.LedgerEntry{}, errors.Wrap(err, "Error decoding contract data value")
+	}
+
+	return xdr.LedgerEntry{
+		LastModifiedLedgerSeq: xdr.Uint32(row.LastModifiedLedger),
+		Data: xdr.LedgerEntryData{
+			Type: xdr.LedgerEntryTypeContractData,
+			ContractData: &xdr.ContractDataEntry{
+				Contract: xdr.ScAddress{
+					Type:       xdr.ScAddressTypeScAddressTypeContract,
+					ContractId: &contractID,
+				},
+				Key:        key,
+				Durability: xdr.ContractDataDurabilityPersistent,
+				Val:        val,
+			},
+		},
+	}, nil
+}
+
+// checkContractDataExpirations compares the expiration ledgers stored in the
+// contract_data table with the TTL entries from the history archives.
+func checkContractDataExpirations(actual, expected map[xdr.Hash]uint32) error {
+	for keyHash, expirationLedger := range actual {
+		expectedExpirationLedger, ok := expected[keyHash]
+		if !ok {
+			return ingestsdk.NewStateError(errors.Errorf(
+				"contract data %s has no TTL entry in the history archives",
+				hex.EncodeToString(keyHash[:]),
+			))
+		}
+		if expirationLedger != expectedExpirationLedger {
+			return ingestsdk.NewStateError(errors.Errorf(
+				"contract data %s expiration ledger (%d) does not match expiration ledger in the history archives (%d)",
+				hex.EncodeToString(keyHash[:]),
+				expirationLedger,
+				expectedExpirationLedger,
+			))
+		}
+	}
+	return nil
+}
+
 func liquidityPoolToXDR(row history.LiquidityPool) (xdr.LiquidityPoolEntry, error) {
 	if len(row.AssetReserves) != 2 {
 		return xdr.LiquidityPoolEntry{}, fmt.Errorf("unexpected number of asset reserves (%d), expected %d", len(row.AssetReserves), 2)
@@ -603,6 +603,8 @@ func (s *VerifyRangeStateTestSuite) TestSuccessWithVerify() {
 		On("GetLiquidityPoolsByID", s.ctx, []string{liquidityPool.PoolID}).
 		Return([]history.LiquidityPool{liquidityPool}, nil).Once()
 
+	clonedQ.MockQContractData.On("CountContractData", s.ctx).Return(0, nil).Once()
+
 	next, err := verifyRangeState{
 		fromLedger: 100, toLedger: 110, verifyState: true,
 	}.run(s.system)
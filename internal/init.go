@@ -14,6 +14,8 @@ import (
 
 	"github.com/stellar/stellar-horizon/internal/db2/history"
 	"github.com/stellar/stellar-horizon/internal/ingest"
+	"github.com/stellar/stellar-horizon/internal/ingest/sink"
+	"github.com/stellar/stellar-horizon/internal/ingest/subscriptions"
 	"github.com/stellar/stellar-horizon/internal/paths"
 	"github.com/stellar/stellar-horizon/internal/simplepath"
 	"github.com/stellar/stellar-horizon/internal/txsub"
@@ -84,10 +86,25 @@ func mustInitHorizonDB(app *App) {
 
 func initIngester(app *App) {
 	var err error
+	historySession := mustNewDBSession(
+		db.IngestSubservice, app.config.DatabaseURL, ingest.MaxDBConnections, ingest.MaxDBConnections, app.prometheusRegistry,
+	)
+
+	var ledgerPublishers []ingest.LedgerPublisher
+	if app.config.LedgerSinkURL != "" {
+		ledgerSink, err := sink.New(app.config.LedgerSinkURL)
+		if err != nil {
+			log.Fatal(err)
+		}
+		ledgerPublishers = append(ledgerPublishers, sink.NewPublisher(ledgerSink, historySession.Clone(), app.config.SkipTxmeta))
+	}
+	if app.config.EnableSubscriptions {
+		ledgerPublishers = append(ledgerPublishers, subscriptions.NewDispatcher(historySession.Clone(), app.config.SubscriptionDeliveryRetention))
+	}
+
 	app.ingester, err = ingest.NewSystem(ingest.Config{
-		HistorySession: mustNewDBSession(
-			db.IngestSubservice, app.config.DatabaseURL, ingest.MaxDBConnections, ingest.MaxDBConnections, app.prometheusRegistry,
-		),
+		HistorySession:                       historySession,
+		DatabaseURL:                          app.config.DatabaseURL,
 		NetworkPassphrase:                    app.config.NetworkPassphrase,
 		HistoryArchiveURLs:                   app.config.HistoryArchiveURLs,
 		HistoryArchiveCaching:                app.config.HistoryArchiveCaching,
@@ -104,6 +121,8 @@ func initIngester(app *App) {
 		SkipProtocolVersionCheck:             app.config.IngestSkipProtocolVersionCheck,
 		RoundingSlippageFilter:               app.config.RoundingSlippageFilter,
 		SkipTxmeta:                           app.config.SkipTxmeta,
+		LedgerPublishers:                     ledgerPublishers,
+		EnableSubscriptions:                  app.config.EnableSubscriptions,
 		ReapConfig: ingest.ReapConfig{
 			Frequency:      app.config.ReapFrequency,
 			RetentionCount: uint32(app.config.HistoryRetentionCount),
@@ -126,7 +145,10 @@ func initPathFinder(app *App) {
 		orderBookGraph,
 	)
 
-	var finder paths.Finder = simplepath.NewInMemoryFinder(orderBookGraph, !app.config.DisablePoolPathFinding)
+	inMemoryFinder := simplepath.NewInMemoryFinder(orderBookGraph, !app.config.DisablePoolPathFinding)
+	app.quotes = inMemoryFinder
+
+	var finder paths.Finder = inMemoryFinder
 	if app.config.MaxPathFindingRequests != 0 {
 		finder = paths.NewRateLimitedFinder(finder, app.config.MaxPathFindingRequests)
 	}
@@ -235,4 +257,17 @@ func initSubmissionSystem(app *App) {
 		},
 		LedgerState: app.ledgerState,
 	}
+
+	if app.config.SharedTxSub {
+		// the pending submissions are written to the primary database
+		q := app.historyQ
+		if app.primaryHistoryQ != nil {
+			q = app.primaryHistoryQ
+		}
+		pending := txsub.NewSharedSubmissionList(app.ctx, &history.Q{SessionInterface: q.SessionInterface.Clone()})
+		if err := pending.Listen(app.ctx, app.config.DatabaseURL, app.submitter.LoadResult); err != nil {
+			log.Fatal(err)
+		}
+		app.submitter.Pending = pending
+	}
 }
@@ -732,7 +732,7 @@ func TestReingestDBWithFilterRules(t *testing.T) {
 	itest.MustEstablishTrustline(nonWhitelistedAccountKey, nonWhitelistedAccount, defaultAllowedAsset)
 
 	// Setup a whitelisted account rule, force refresh of filter configs to be quick
-	filters.SetFilterConfigCheckIntervalSeconds(1)
+	filters.SetFilterConfigCheckInterval(time.Second)
 
 	expectedAccountFilter := hProtocol.AccountFilterConfig{
 		Whitelist: []string{whitelistedAccount.GetAccountID()},
@@ -748,7 +748,7 @@ func TestReingestDBWithFilterRules(t *testing.T) {
 	tt.Equal(expectedAccountFilter.Enabled, accountFilter.Enabled)
 
 	// Ensure the latest filter configs are reloaded by the ingestion state machine processor
-	time.Sleep(time.Duration(filters.GetFilterConfigCheckIntervalSeconds()) * time.Second)
+	time.Sleep(filters.GetFilterConfigCheckInterval())
 
 	// Make sure that when using a non-whitelisted account, the transaction is not stored
 	nonWhiteListTxResp := itest.MustSubmitOperations(itest.MasterAccount(), itest.Master(),
@@ -65,7 +65,7 @@ func TestFilteringAccountWhiteList(t *testing.T) {
 	itest.MustEstablishTrustline(nonWhitelistedAccountKey, nonWhitelistedAccount, defaultAllowedAsset)
 
 	// Setup a whitelisted account rule, force refresh of filter configs to be quick
-	filters.SetFilterConfigCheckIntervalSeconds(1)
+	filters.SetFilterConfigCheckInterval(time.Second)
 
 	expectedAccountFilter := hProtocol.AccountFilterConfig{
 		Whitelist: []string{whitelistedAccount.GetAccountID()},
@@ -81,7 +81,7 @@ func TestFilteringAccountWhiteList(t *testing.T) {
 	tt.Equal(expectedAccountFilter.Enabled, accountFilter.Enabled)
 
 	// Ensure the latest filter configs are reloaded by the ingestion state machine processor
-	time.Sleep(time.Duration(filters.GetFilterConfigCheckIntervalSeconds()) * time.Second)
+	time.Sleep(filters.GetFilterConfigCheckInterval())
 
 	// Make sure that when using a non-whitelisted account, the transaction is not stored
 	txResp := itest.MustSubmitOperations(itest.MasterAccount(), itest.Master(),
@@ -127,7 +127,7 @@ func TestFilteringAssetWhiteList(t *testing.T) {
 	enabled := true
 
 	// Setup a whitelisted asset rule, force refresh of filters to be quick
-	filters.SetFilterConfigCheckIntervalSeconds(1)
+	filters.SetFilterConfigCheckInterval(time.Second)
 
 	asset, err := whitelistedAsset.ToXDR()
 	tt.NoError(err)
@@ -145,7 +145,7 @@ func TestFilteringAssetWhiteList(t *testing.T) {
 	tt.Equal(expectedAssetFilter.Enabled, assetFilter.Enabled)
 
 	// Ensure the latest filter configs are reloaded by the ingestion state machine processor
-	time.Sleep(time.Duration(filters.GetFilterConfigCheckIntervalSeconds()) * time.Second)
+	time.Sleep(filters.GetFilterConfigCheckInterval())
 
 	// Make sure that when using a non-whitelisted asset, the transaction is not stored
 	txResp := itest.MustSubmitOperations(itest.MasterAccount(), itest.Master(),
@@ -191,7 +191,7 @@ func TestFilteringAssetAndAccountFilters(t *testing.T) {
 	itest.MustEstablishTrustline(whitelistedAccountKey, whitelistedAccount, nonWhitelistedAsset)
 
 	// Setup whitelisted account and asset rule, force refresh of filter configs to be quick
-	filters.SetFilterConfigCheckIntervalSeconds(1)
+	filters.SetFilterConfigCheckInterval(time.Second)
 
 	expectedAccountFilter := hProtocol.AccountFilterConfig{
 		Whitelist: []string{whitelistedAccount.GetAccountID()},
@@ -219,7 +219,7 @@ func TestFilteringAssetAndAccountFilters(t *testing.T) {
 	tt.Equal(expectedAssetFilter.Enabled, assetFilter.Enabled)
 
 	// Ensure the latest filter configs are reloaded by the ingestion state machine processor
-	time.Sleep(filters.GetFilterConfigCheckIntervalSeconds() * time.Second)
+	time.Sleep(filters.GetFilterConfigCheckInterval())
 
 	// Use a non-whitelisted account to submit a non-whitelisted asset to a whitelisted account.
 	// The transaction should be stored.
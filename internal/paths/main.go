@@ -2,6 +2,7 @@ package paths
 
 import (
 	"context"
+	"math/big"
 
 	"github.com/stellar/go-stellar-sdk/xdr"
 )
@@ -45,3 +46,25 @@ type Finder interface {
 		maxLength uint,
 	) ([]Path, uint32, error)
 }
+
+// Market is a pair of assets, the prices of a market are amounts of the
+// counter asset per unit of the base asset.
+type Market struct {
+	Base    xdr.Asset
+	Counter xdr.Asset
+}
+
+// Quote is the top of the book of a market: Bid is the highest price at which
+// the base asset can be sold and Ask the lowest price at which it can be
+// bought. Bid and Ask are nil when there is no liquidity on their side of the
+// market.
+type Quote struct {
+	Bid *big.Rat
+	Ask *big.Rat
+}
+
+// QuoteFinder finds the top of the book of markets.
+type QuoteFinder interface {
+	// Quotes returns the quotes of the given markets, in the same order.
+	Quotes(ctx context.Context, markets []Market) ([]Quote, error)
+}
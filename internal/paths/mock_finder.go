@@ -31,3 +31,11 @@ func (m *MockFinder) FindFixedPaths(
 
 	return args.Get(0).([]Path), args.Get(1).(uint32), args.Error(2)
 }
+
+var _ QuoteFinder = (*MockFinder)(nil)
+
+func (m *MockFinder) Quotes(ctx context.Context, markets []Market) ([]Quote, error) {
+	args := m.Called(ctx, markets)
+
+	return args.Get(0).([]Quote), args.Error(1)
+}
@@ -0,0 +1,40 @@
+package csv
+
+import (
+	"bytes"
+	"context"
+	"encoding/csv"
+	"io"
+	"net/http"
+
+	"github.com/stellar/go-stellar-sdk/support/log"
+)
+
+// Write writes the header followed by the records to w as CSV.
+func Write(w io.Writer, header []string, records [][]string) error {
+	writer := csv.NewWriter(w)
+	if err := writer.Write(header); err != nil {
+		return err
+	}
+	if err := writer.WriteAll(records); err != nil {
+		return err
+	}
+	return writer.Error()
+}
+
+// Render writes the header followed by the records to w as a text/csv
+// response. The errors writing the response are logged with the logger of ctx.
+func Render(ctx context.Context, w http.ResponseWriter, header []string, records [][]string) {
+	var buf bytes.Buffer
+	if err := Write(&buf, header, records); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Disposition", "inline")
+	w.Header().Set("Content-Type", "text/csv; charset=utf-8")
+	w.WriteHeader(http.StatusOK)
+	if _, err := w.Write(buf.Bytes()); err != nil {
+		log.Ctx(ctx).WithStack(err).Error(err)
+	}
+}
@@ -0,0 +1,39 @@
+package csv
+
+import (
+	"context"
+	"errors"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stellar/go-stellar-sdk/support/test"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRender(t *testing.T) {
+	w := httptest.NewRecorder()
+	Render(context.Background(), w, []string{"asset", "amount"}, [][]string{
+		{"native", "1.0000000"},
+		{"USD:GABC,DEF", ""},
+	})
+
+	assert.Equal(t, 200, w.Code)
+	assert.Equal(t, "text/csv; charset=utf-8", w.Header().Get("Content-Type"))
+	assert.Equal(t, "asset,amount\nnative,1.0000000\n\"USD:GABC,DEF\",\n", w.Body.String())
+}
+
+// failingWriter is a ResponseRecorder whose body can't be written.
+type failingWriter struct {
+	*httptest.ResponseRecorder
+}
+
+func (w failingWriter) Write([]byte) (int, error) {
+	return 0, errors.New("broken pipe")
+}
+
+func TestRenderLogsWriteErrors(t *testing.T) {
+	ctx, log := test.ContextWithLogBuffer()
+	Render(ctx, failingWriter{httptest.NewRecorder()}, []string{"asset"}, [][]string{{"native"}})
+	assert.Contains(t, log.String(), "level=error")
+	assert.Contains(t, log.String(), "broken pipe")
+}
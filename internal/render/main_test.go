@@ -20,6 +20,9 @@ func TestNegotiate(t *testing.T) {
 		// Obeys the Accept header's prioritization
 		{"application/hal+json", MimeHal},
 		{"text/event-stream,application/hal+json", MimeEventStream},
+		{"text/csv", MimeCSV},
+		{"text/csv;q=0.5,application/json", MimeJSON},
+		{"application/x-ndjson", MimeNDJSON},
 		// Defaults to HAL
 		{"text/event-stream;q=0.5,application/hal+json", MimeHal},
 		{"", MimeHal},
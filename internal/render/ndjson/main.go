@@ -0,0 +1,31 @@
+package ndjson
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+
+	"github.com/stellar/go-stellar-sdk/support/log"
+)
+
+// Render writes each of the records to w as a line of JSON, in an
+// application/x-ndjson response. The errors writing the response are logged
+// with the logger of ctx.
+func Render(ctx context.Context, w http.ResponseWriter, records []interface{}) {
+	var buf bytes.Buffer
+	encoder := json.NewEncoder(&buf)
+	for _, record := range records {
+		if err := encoder.Encode(record); err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+	}
+
+	w.Header().Set("Content-Disposition", "inline")
+	w.Header().Set("Content-Type", "application/x-ndjson; charset=utf-8")
+	w.WriteHeader(http.StatusOK)
+	if _, err := w.Write(buf.Bytes()); err != nil {
+		log.Ctx(ctx).WithStack(err).Error(err)
+	}
+}
@@ -0,0 +1,39 @@
+package ndjson
+
+import (
+	"context"
+	"errors"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stellar/go-stellar-sdk/support/test"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRender(t *testing.T) {
+	w := httptest.NewRecorder()
+	Render(context.Background(), w, []interface{}{
+		map[string]interface{}{"id": "1", "amount": "1.0000000"},
+		map[string]interface{}{"id": "2"},
+	})
+
+	assert.Equal(t, 200, w.Code)
+	assert.Equal(t, "application/x-ndjson; charset=utf-8", w.Header().Get("Content-Type"))
+	assert.Equal(t, "{\"amount\":\"1.0000000\",\"id\":\"1\"}\n{\"id\":\"2\"}\n", w.Body.String())
+}
+
+// failingWriter is a ResponseRecorder whose body can't be written.
+type failingWriter struct {
+	*httptest.ResponseRecorder
+}
+
+func (w failingWriter) Write([]byte) (int, error) {
+	return 0, errors.New("broken pipe")
+}
+
+func TestRenderLogsWriteErrors(t *testing.T) {
+	ctx, log := test.ContextWithLogBuffer()
+	Render(ctx, failingWriter{httptest.NewRecorder()}, []interface{}{map[string]interface{}{"id": "1"}})
+	assert.Contains(t, log.String(), "level=error")
+	assert.Contains(t, log.String(), "broken pipe")
+}
@@ -0,0 +1,77 @@
+package resource
+
+import (
+	"strconv"
+	"time"
+
+	"github.com/stellar/go-stellar-sdk/support/render/hal"
+)
+
+// AccountStatement is the statement of the debits and credits of the balances
+// of an account from a ledger to another, both included.
+type AccountStatement struct {
+	Links struct {
+		Self    hal.Link `json:"self"`
+		Account hal.Link `json:"account"`
+	} `json:"_links"`
+
+	Account    string                  `json:"account"`
+	FromLedger uint32                  `json:"from_ledger"`
+	ToLedger   uint32                  `json:"to_ledger"`
+	Entries    []AccountStatementEntry `json:"entries"`
+}
+
+// AccountStatementEntry is a debit or a credit of the balance of an asset held
+// by an account. Type is what changed the balance: fee, payment, trade,
+// claim_claimable_balance or any other reason of balance changes. Balance is
+// the balance after the entry. OperationID is omitted for fees.
+type AccountStatementEntry struct {
+	ID              string    `json:"id"`
+	CreatedAt       time.Time `json:"created_at"`
+	Ledger          int32     `json:"ledger"`
+	TransactionHash string    `json:"transaction_hash"`
+	OperationID     string    `json:"operation_id,omitempty"`
+	Type            string    `json:"type"`
+	AssetType       string    `json:"asset_type"`
+	AssetCode       string    `json:"asset_code,omitempty"`
+	AssetIssuer     string    `json:"asset_issuer,omitempty"`
+	Debit           string    `json:"debit,omitempty"`
+	Credit          string    `json:"credit,omitempty"`
+	Balance         string    `json:"balance"`
+}
+
+// AccountStatementCSVHeader is the header of account statements rendered as
+// CSV, with a column per field of AccountStatementEntry.
+var AccountStatementCSVHeader = []string{
+	"id",
+	"created_at",
+	"ledger",
+	"transaction_hash",
+	"operation_id",
+	"type",
+	"asset_type",
+	"asset_code",
+	"asset_issuer",
+	"debit",
+	"credit",
+	"balance",
+}
+
+// CSVRecord returns the entry as a CSV record with the columns of
+// AccountStatementCSVHeader.
+func (e AccountStatementEntry) CSVRecord() []string {
+	return []string{
+		e.ID,
+		e.CreatedAt.UTC().Format(time.RFC3339),
+		strconv.FormatInt(int64(e.Ledger), 10),
+		e.TransactionHash,
+		e.OperationID,
+		e.Type,
+		e.AssetType,
+		e.AssetCode,
+		e.AssetIssuer,
+		e.Debit,
+		e.Credit,
+		e.Balance,
+	}
+}
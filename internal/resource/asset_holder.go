@@ -0,0 +1,23 @@
+package resource
+
+import (
+	"github.com/stellar/go-stellar-sdk/support/render/hal"
+)
+
+// AssetHolder represents an account or a contract holding an asset. Balance
+// is the balance in units of the asset.
+type AssetHolder struct {
+	Links struct {
+		Holder hal.Link `json:"holder"`
+	} `json:"_links"`
+
+	PT                 string `json:"paging_token"`
+	Holder             string `json:"holder"`
+	Balance            string `json:"balance"`
+	LastModifiedLedger uint32 `json:"last_modified_ledger"`
+}
+
+// PagingToken implementation for hal.Pageable
+func (h AssetHolder) PagingToken() string {
+	return h.PT
+}
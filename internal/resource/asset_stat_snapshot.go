@@ -0,0 +1,38 @@
+package resource
+
+import (
+	"time"
+
+	protocol "github.com/stellar/go-stellar-sdk/protocols/horizon"
+	"github.com/stellar/go-stellar-sdk/support/render/hal"
+)
+
+// AssetStatSnapshot is the state of the stats of an asset at the end of a
+// ledger, or at the end of a bucket of ledgers when Timestamp is set. The
+// fields have the same meaning as in the /assets end-point, Supply is the sum
+// of all the amounts held in trustlines, claimable balances, liquidity pools
+// and contracts.
+type AssetStatSnapshot struct {
+	Links struct {
+		Ledger hal.Link `json:"ledger"`
+	} `json:"_links"`
+
+	PT                      string                     `json:"paging_token"`
+	Timestamp               int64                      `json:"timestamp,string,omitempty"`
+	Ledger                  int32                      `json:"ledger"`
+	ClosedAt                time.Time                  `json:"closed_at"`
+	Accounts                protocol.AssetStatAccounts `json:"accounts"`
+	NumClaimableBalances    int32                      `json:"num_claimable_balances"`
+	NumLiquidityPools       int32                      `json:"num_liquidity_pools"`
+	NumContracts            int32                      `json:"num_contracts"`
+	Balances                protocol.AssetStatBalances `json:"balances"`
+	ClaimableBalancesAmount string                     `json:"claimable_balances_amount"`
+	LiquidityPoolsAmount    string                     `json:"liquidity_pools_amount"`
+	ContractsAmount         string                     `json:"contracts_amount"`
+	Supply                  string                     `json:"supply"`
+}
+
+// PagingToken implementation for hal.Pageable
+func (s AssetStatSnapshot) PagingToken() string {
+	return s.PT
+}
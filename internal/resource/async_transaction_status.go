@@ -0,0 +1,35 @@
+package resource
+
+// The statuses of transactions submitted to /transactions_async.
+const (
+	// AsyncTransactionStatusPending is the status of transactions accepted by
+	// Stellar Core which aren't included in an ingested ledger yet.
+	AsyncTransactionStatusPending = "PENDING"
+	// AsyncTransactionStatusIngested is the status of transactions included in
+	// an ingested ledger, whether they were successful or not.
+	AsyncTransactionStatusIngested = "INGESTED"
+	// AsyncTransactionStatusFailed is the status of transactions rejected by
+	// Stellar Core.
+	AsyncTransactionStatusFailed = "FAILED"
+	// AsyncTransactionStatusExpired is the status of transactions which can't
+	// be included in a ledger anymore because of their time or ledger bounds.
+	AsyncTransactionStatusExpired = "EXPIRED"
+)
+
+// AsyncTransactionStatus is the status of a transaction submitted to
+// /transactions_async. Ledger, Successful and ResultXDR are set for ingested
+// transactions and ErrorResultXDR for transactions rejected by Stellar Core.
+type AsyncTransactionStatus struct {
+	Hash           string `json:"hash"`
+	TxStatus       string `json:"tx_status"`
+	Ledger         int32  `json:"ledger,omitempty"`
+	Successful     *bool  `json:"successful,omitempty"`
+	ResultXDR      string `json:"result_xdr,omitempty"`
+	ErrorResultXDR string `json:"error_result_xdr,omitempty"`
+}
+
+// IsFinal returns true when the status of the transaction can't change
+// anymore.
+func (s AsyncTransactionStatus) IsFinal() bool {
+	return s.TxStatus != AsyncTransactionStatusPending
+}
@@ -0,0 +1,38 @@
+package resource
+
+import (
+	"time"
+
+	"github.com/stellar/go-stellar-sdk/support/render/hal"
+)
+
+// BalanceChange represents a change of the balance of an asset held by an
+// account. Reason is what changed the balance: fee, payment, trade,
+// liquidity_pool_deposit, liquidity_pool_withdraw, contract_transfer or the
+// type of the operation which changed it. OperationID is omitted for fees.
+type BalanceChange struct {
+	Links struct {
+		Account     hal.Link  `json:"account"`
+		Transaction hal.Link  `json:"transaction"`
+		Operation   *hal.Link `json:"operation,omitempty"`
+	} `json:"_links"`
+
+	ID              string    `json:"id"`
+	PT              string    `json:"paging_token"`
+	Account         string    `json:"account"`
+	AssetType       string    `json:"asset_type"`
+	AssetCode       string    `json:"asset_code,omitempty"`
+	AssetIssuer     string    `json:"asset_issuer,omitempty"`
+	Reason          string    `json:"reason"`
+	AmountBefore    string    `json:"amount_before"`
+	AmountAfter     string    `json:"amount_after"`
+	Ledger          int32     `json:"ledger"`
+	LedgerCloseTime time.Time `json:"created_at"`
+	TransactionHash string    `json:"transaction_hash"`
+	OperationID     string    `json:"operation_id,omitempty"`
+}
+
+// PagingToken implementation for hal.Pageable
+func (c BalanceChange) PagingToken() string {
+	return c.PT
+}
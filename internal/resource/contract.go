@@ -0,0 +1,87 @@
+package resource
+
+import (
+	"time"
+
+	"github.com/stellar/go-stellar-sdk/support/render/hal"
+)
+
+// Contract represents the instance of a smart contract deployed on the
+// network.
+type Contract struct {
+	Links struct {
+		Self   hal.Link `json:"self"`
+		Data   hal.Link `json:"data"`
+		Events hal.Link `json:"events"`
+	} `json:"_links"`
+
+	ID                 string                 `json:"id"`
+	Executable         ContractExecutable     `json:"executable"`
+	Storage            []ContractStorageEntry `json:"storage"`
+	LiveUntilLedger    uint32                 `json:"live_until_ledger"`
+	LastModifiedLedger uint32                 `json:"last_modified_ledger"`
+	LastModifiedTime   *time.Time             `json:"last_modified_time"`
+}
+
+// ContractExecutable describes the code run by a contract. WasmHash is only
+// set for contracts of type `wasm`.
+type ContractExecutable struct {
+	Type     string `json:"type"`
+	WasmHash string `json:"wasm_hash,omitempty"`
+}
+
+// ContractStorageEntry is a key-value pair of the instance storage of a
+// contract. Key and Value are base64 encoded XDR ScVals, KeyJSON and ValueJSON
+// are their decoded representation.
+type ContractStorageEntry struct {
+	Key       string      `json:"key"`
+	KeyJSON   interface{} `json:"key_json"`
+	Value     string      `json:"value"`
+	ValueJSON interface{} `json:"value_json"`
+}
+
+// ContractData represents a persistent contract data entry.
+type ContractData struct {
+	Links struct {
+		Contract hal.Link `json:"contract"`
+	} `json:"_links"`
+
+	ID                 string      `json:"id"`
+	PT                 string      `json:"paging_token"`
+	ContractID         string      `json:"contract_id"`
+	Durability         string      `json:"durability"`
+	Key                string      `json:"key"`
+	KeyJSON            interface{} `json:"key_json"`
+	Value              string      `json:"value"`
+	ValueJSON          interface{} `json:"value_json"`
+	LiveUntilLedger    uint32      `json:"live_until_ledger"`
+	LastModifiedLedger uint32      `json:"last_modified_ledger"`
+	LastModifiedTime   *time.Time  `json:"last_modified_time"`
+}
+
+// PagingToken implementation for hal.Pageable
+func (d ContractData) PagingToken() string {
+	return d.PT
+}
+
+// ContractBalance represents a balance of a stellar asset contract held by
+// an account or a contract. Amount is the balance in units of the asset.
+type ContractBalance struct {
+	Links struct {
+		AssetContract hal.Link `json:"asset_contract"`
+	} `json:"_links"`
+
+	PT                 string     `json:"paging_token"`
+	AssetContractID    string     `json:"asset_contract_id"`
+	AssetType          string     `json:"asset_type"`
+	AssetCode          string     `json:"asset_code,omitempty"`
+	AssetIssuer        string     `json:"asset_issuer,omitempty"`
+	Amount             string     `json:"amount"`
+	LastModifiedLedger uint32     `json:"last_modified_ledger"`
+	LastModifiedTime   *time.Time `json:"last_modified_time"`
+}
+
+// PagingToken implementation for hal.Pageable
+func (b ContractBalance) PagingToken() string {
+	return b.PT
+}
@@ -0,0 +1,32 @@
+package resource
+
+import (
+	"time"
+
+	"github.com/stellar/go-stellar-sdk/support/render/hal"
+)
+
+// ContractEvent represents an event emitted by a smart contract (or by the
+// Stellar Asset Contract on behalf of a classic operation).
+type ContractEvent struct {
+	Links struct {
+		Transaction hal.Link `json:"transaction"`
+		Operation   hal.Link `json:"operation"`
+	} `json:"_links"`
+
+	ID              string    `json:"id"`
+	PT              string    `json:"paging_token"`
+	Type            string    `json:"type"`
+	ContractID      string    `json:"contract_id,omitempty"`
+	Topics          []string  `json:"topics"`
+	Value           string    `json:"value"`
+	Ledger          int32     `json:"ledger"`
+	LedgerCloseTime time.Time `json:"created_at"`
+	TransactionHash string    `json:"transaction_hash"`
+	OperationID     string    `json:"operation_id"`
+}
+
+// PagingToken implementation for hal.Pageable
+func (e ContractEvent) PagingToken() string {
+	return e.PT
+}
@@ -0,0 +1,15 @@
+package resource
+
+import "time"
+
+// FeeBumpAttempt is a rebroadcast of a pending transaction with one of the
+// pre-signed fee bump transactions of its submission. ResultXDR is set when
+// Stellar Core rejected the fee bump transaction and Error when its submission
+// failed.
+type FeeBumpAttempt struct {
+	Hash            string    `json:"hash"`
+	FeePerOperation int64     `json:"fee_per_operation,string"`
+	SubmittedAt     time.Time `json:"submitted_at"`
+	ResultXDR       string    `json:"result_xdr,omitempty"`
+	Error           string    `json:"error,omitempty"`
+}
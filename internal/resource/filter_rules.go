@@ -0,0 +1,145 @@
+package resource
+
+import (
+	"encoding/json"
+	"errors"
+)
+
+// AssetFilterConfig is the configuration of the ingestion filter matching
+// transactions by asset. Blacklist is optional.
+type AssetFilterConfig struct {
+	Whitelist    []string `json:"whitelist"`
+	Blacklist    []string `json:"blacklist"`
+	Enabled      *bool    `json:"enabled"`
+	LastModified int64    `json:"last_modified,omitempty"`
+	// AppliedFromLedger is the first ledger ingested with this version of the
+	// rules, it is omitted until ingestion applies the version.
+	AppliedFromLedger uint32 `json:"applied_from_ledger,omitempty"`
+}
+
+func (f *AssetFilterConfig) UnmarshalJSON(data []byte) error {
+	type assetFilterConfig AssetFilterConfig
+	var config = assetFilterConfig{}
+
+	if err := json.Unmarshal(data, &config); err != nil {
+		return err
+	}
+
+	if config.Whitelist == nil {
+		return errors.New("missing required whitelist")
+	}
+
+	if config.Enabled == nil {
+		return errors.New("missing required enabled")
+	}
+
+	if config.Blacklist == nil {
+		config.Blacklist = []string{}
+	}
+
+	*f = AssetFilterConfig(config)
+	return nil
+}
+
+// AccountFilterConfig is the configuration of the ingestion filter matching
+// transactions by participant account. Blacklist is optional.
+type AccountFilterConfig struct {
+	Whitelist    []string `json:"whitelist"`
+	Blacklist    []string `json:"blacklist"`
+	Enabled      *bool    `json:"enabled"`
+	LastModified int64    `json:"last_modified,omitempty"`
+	// AppliedFromLedger is the first ledger ingested with this version of the
+	// rules, it is omitted until ingestion applies the version.
+	AppliedFromLedger uint32 `json:"applied_from_ledger,omitempty"`
+}
+
+func (f *AccountFilterConfig) UnmarshalJSON(data []byte) error {
+	type accountFilterConfig AccountFilterConfig
+	var config = accountFilterConfig{}
+
+	if err := json.Unmarshal(data, &config); err != nil {
+		return err
+	}
+
+	if config.Whitelist == nil {
+		return errors.New("missing required whitelist")
+	}
+
+	if config.Enabled == nil {
+		return errors.New("missing required enabled")
+	}
+
+	if config.Blacklist == nil {
+		config.Blacklist = []string{}
+	}
+
+	*f = AccountFilterConfig(config)
+	return nil
+}
+
+// ContractFilterConfig is the configuration of the ingestion filter matching
+// transactions by invoked contract.
+type ContractFilterConfig struct {
+	Whitelist    []string `json:"whitelist"`
+	Enabled      *bool    `json:"enabled"`
+	LastModified int64    `json:"last_modified,omitempty"`
+	// AppliedFromLedger is the first ledger ingested with this version of the
+	// rules, it is omitted until ingestion applies the version.
+	AppliedFromLedger uint32 `json:"applied_from_ledger,omitempty"`
+}
+
+func (f *ContractFilterConfig) UnmarshalJSON(data []byte) error {
+	type contractFilterConfig ContractFilterConfig
+	var config = contractFilterConfig{}
+
+	if err := json.Unmarshal(data, &config); err != nil {
+		return err
+	}
+
+	if config.Whitelist == nil {
+		return errors.New("missing required whitelist")
+	}
+
+	if config.Enabled == nil {
+		return errors.New("missing required enabled")
+	}
+
+	*f = ContractFilterConfig(config)
+	return nil
+}
+
+// OperationTypeFilterConfig is the configuration of the ingestion filter
+// matching transactions by operation type and memo. MemoWhitelist is optional.
+type OperationTypeFilterConfig struct {
+	Whitelist     []string `json:"whitelist"`
+	MemoWhitelist []string `json:"memo_whitelist"`
+	Enabled       *bool    `json:"enabled"`
+	LastModified  int64    `json:"last_modified,omitempty"`
+	// AppliedFromLedger is the first ledger ingested with this version of the
+	// rules, it is omitted until ingestion applies the version.
+	AppliedFromLedger uint32 `json:"applied_from_ledger,omitempty"`
+}
+
+func (f *OperationTypeFilterConfig) UnmarshalJSON(data []byte) error {
+	type operationTypeFilterConfig OperationTypeFilterConfig
+	var config = operationTypeFilterConfig{}
+
+	if err := json.Unmarshal(data, &config); err != nil {
+		return err
+	}
+
+	if config.Whitelist == nil {
+		return errors.New("missing required whitelist")
+	}
+
+	if config.Enabled == nil {
+		return errors.New("missing required enabled")
+	}
+
+	if config.MemoWhitelist == nil {
+		config.MemoWhitelist = []string{}
+	}
+
+	*f = OperationTypeFilterConfig(config)
+	return nil
+}
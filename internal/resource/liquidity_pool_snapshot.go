@@ -0,0 +1,43 @@
+package resource
+
+import (
+	"time"
+
+	"github.com/stellar/go-stellar-sdk/support/render/hal"
+)
+
+// LiquidityPoolSnapshot is the state of a liquidity pool at the end of a
+// ledger, or at the end of a bucket of ledgers when Timestamp is set, with the
+// trades executed against the pool in the ledger or bucket.
+type LiquidityPoolSnapshot struct {
+	Links struct {
+		Ledger        hal.Link `json:"ledger"`
+		LiquidityPool hal.Link `json:"liquidity_pool"`
+	} `json:"_links"`
+
+	PT              string                         `json:"paging_token"`
+	Timestamp       int64                          `json:"timestamp,string,omitempty"`
+	Ledger          int32                          `json:"ledger"`
+	ClosedAt        time.Time                      `json:"closed_at"`
+	FeeBP           uint32                         `json:"fee_bp"`
+	Reserves        []LiquidityPoolSnapshotReserve `json:"reserves"`
+	TotalShares     string                         `json:"total_shares"`
+	TotalTrustlines int64                          `json:"total_trustlines,string"`
+	TradeCount      int64                          `json:"trade_count,string"`
+	FeeAPR          string                         `json:"fee_apr,omitempty"`
+}
+
+// LiquidityPoolSnapshotReserve is a reserve of a liquidity pool snapshot. The
+// volume is the amount of the asset received by the pool in trades and the
+// fees are the part of the volume kept by the pool.
+type LiquidityPoolSnapshotReserve struct {
+	Asset  string `json:"asset"`
+	Amount string `json:"amount"`
+	Volume string `json:"volume"`
+	Fees   string `json:"fees"`
+}
+
+// PagingToken implementation for hal.Pageable
+func (s LiquidityPoolSnapshot) PagingToken() string {
+	return s.PT
+}
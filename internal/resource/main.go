@@ -0,0 +1,3 @@
+// Package resource contains the types for the Horizon API resources which are
+// not (yet) part of the protocols/horizon package of the Go SDK.
+package resource
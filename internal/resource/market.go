@@ -0,0 +1,41 @@
+package resource
+
+import (
+	"time"
+
+	"github.com/stellar/go-stellar-sdk/support/render/hal"
+)
+
+// Market is the ticker of a market: the summary of its trades since a time
+// (the last 24 hours) and the top of its order book. Prices are amounts of the
+// counter asset per unit of the base asset, they are omitted when there were no
+// trades in the period, and the bid and ask are omitted when there is no
+// liquidity on their side of the order book.
+type Market struct {
+	Links struct {
+		Self hal.Link `json:"self"`
+	} `json:"_links"`
+
+	PT                 string    `json:"paging_token,omitempty"`
+	BaseAssetType      string    `json:"base_asset_type"`
+	BaseAssetCode      string    `json:"base_asset_code,omitempty"`
+	BaseAssetIssuer    string    `json:"base_asset_issuer,omitempty"`
+	CounterAssetType   string    `json:"counter_asset_type"`
+	CounterAssetCode   string    `json:"counter_asset_code,omitempty"`
+	CounterAssetIssuer string    `json:"counter_asset_issuer,omitempty"`
+	Since              time.Time `json:"since"`
+	TradeCount         int64     `json:"trade_count,string"`
+	BaseVolume         string    `json:"base_volume"`
+	CounterVolume      string    `json:"counter_volume"`
+	LastPrice          string    `json:"last_price,omitempty"`
+	Open               string    `json:"open,omitempty"`
+	High               string    `json:"high,omitempty"`
+	Low                string    `json:"low,omitempty"`
+	Bid                string    `json:"bid,omitempty"`
+	Ask                string    `json:"ask,omitempty"`
+}
+
+// PagingToken implementation for hal.Pageable
+func (m Market) PagingToken() string {
+	return m.PT
+}
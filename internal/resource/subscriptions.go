@@ -0,0 +1,75 @@
+package resource
+
+import (
+	"encoding/json"
+	"errors"
+	"time"
+)
+
+// Subscription is a subscription to the ingested transactions. The payloads
+// posted to the callback url are signed with the secret, which is only
+// returned when the subscription is created.
+type Subscription struct {
+	ID          int64     `json:"id"`
+	CallbackURL string    `json:"callback_url"`
+	Secret      string    `json:"secret,omitempty"`
+	AccountIDs  []string  `json:"account_ids"`
+	Assets      []string  `json:"assets"`
+	ContractIDs []string  `json:"contract_ids"`
+	CreatedAt   time.Time `json:"created_at"`
+}
+
+// SubscriptionRequest registers a subscription. At least one account, asset
+// or contract is required.
+type SubscriptionRequest struct {
+	CallbackURL string   `json:"callback_url"`
+	AccountIDs  []string `json:"account_ids"`
+	Assets      []string `json:"assets"`
+	ContractIDs []string `json:"contract_ids"`
+}
+
+func (s *SubscriptionRequest) UnmarshalJSON(data []byte) error {
+	type subscriptionRequest SubscriptionRequest
+	var request = subscriptionRequest{}
+
+	if err := json.Unmarshal(data, &request); err != nil {
+		return err
+	}
+
+	if request.CallbackURL == "" {
+		return errors.New("missing required callback_url")
+	}
+
+	if len(request.AccountIDs)+len(request.Assets)+len(request.ContractIDs) == 0 {
+		return errors.New("at least one of account_ids, assets or contract_ids is required")
+	}
+
+	if request.AccountIDs == nil {
+		request.AccountIDs = []string{}
+	}
+	if request.Assets == nil {
+		request.Assets = []string{}
+	}
+	if request.ContractIDs == nil {
+		request.ContractIDs = []string{}
+	}
+
+	*s = SubscriptionRequest(request)
+	return nil
+}
+
+// SubscriptionDelivery is the delivery to a subscription of the transactions
+// of a ledger. Status is one of pending, delivered or failed.
+type SubscriptionDelivery struct {
+	ID             int64           `json:"id"`
+	SubscriptionID int64           `json:"subscription_id"`
+	Ledger         uint32          `json:"ledger"`
+	Status         string          `json:"status"`
+	Attempts       int32           `json:"attempts"`
+	NextAttemptAt  *time.Time      `json:"next_attempt_at,omitempty"`
+	LastError      string          `json:"last_error,omitempty"`
+	ResponseStatus int64           `json:"response_status,omitempty"`
+	CreatedAt      time.Time       `json:"created_at"`
+	DeliveredAt    *time.Time      `json:"delivered_at,omitempty"`
+	Payload        json.RawMessage `json:"payload"`
+}
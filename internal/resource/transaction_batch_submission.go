@@ -0,0 +1,38 @@
+package resource
+
+import (
+	"github.com/stellar/go-stellar-sdk/protocols/horizon"
+	"github.com/stellar/go-stellar-sdk/support/render/problem"
+)
+
+// TransactionBatchSubmission is the response of POST /transactions/batch, it
+// holds the result of each submitted transaction in the order of the request.
+type TransactionBatchSubmission struct {
+	Results []TransactionBatchResult `json:"results"`
+}
+
+// TransactionBatchResult is the result of a transaction of a batch: the
+// transaction included in a ledger, or the problem POST /transactions would
+// have responded with.
+type TransactionBatchResult struct {
+	Hash        string               `json:"hash,omitempty"`
+	Transaction *horizon.Transaction `json:"transaction,omitempty"`
+	Error       *problem.P           `json:"error,omitempty"`
+}
+
+// AsyncTransactionBatchSubmission is the response of
+// POST /transactions_async/batch, it holds the status of each submitted
+// transaction in the order of the request.
+type AsyncTransactionBatchSubmission struct {
+	Results []AsyncTransactionBatchResult `json:"results"`
+}
+
+// AsyncTransactionBatchResult is the status of a transaction of a batch
+// returned by Stellar Core, see horizon.AsyncTransactionSubmissionResponse, or
+// the problem POST /transactions_async would have responded with.
+type AsyncTransactionBatchResult struct {
+	Hash           string     `json:"hash,omitempty"`
+	TxStatus       string     `json:"tx_status,omitempty"`
+	ErrorResultXDR string     `json:"error_result_xdr,omitempty"`
+	Error          *problem.P `json:"error,omitempty"`
+}
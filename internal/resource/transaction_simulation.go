@@ -0,0 +1,18 @@
+package resource
+
+import (
+	"github.com/stellar/go-stellar-sdk/protocols/horizon"
+)
+
+// TransactionSimulation is the predicted result of a classic transaction in
+// the ledger following Ledger, computed from the state ingested by Horizon.
+// UnsimulatedOperations are the indexes of the operations of which only the
+// source account and the signatures were checked.
+type TransactionSimulation struct {
+	Hash                  string                         `json:"hash"`
+	Ledger                int32                          `json:"ledger"`
+	Successful            bool                           `json:"successful"`
+	FeeCharged            string                         `json:"fee_charged"`
+	ResultCodes           horizon.TransactionResultCodes `json:"result_codes"`
+	UnsimulatedOperations []int                          `json:"unsimulated_operations"`
+}
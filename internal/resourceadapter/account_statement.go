@@ -0,0 +1,34 @@
+package resourceadapter
+
+import (
+	"strconv"
+
+	"github.com/stellar/go-stellar-sdk/amount"
+	"github.com/stellar/stellar-horizon/internal/db2/history"
+	"github.com/stellar/stellar-horizon/internal/resource"
+)
+
+// PopulateAccountStatementEntry fills out the resource's fields from a balance
+// change, which is a debit when the balance decreased and a credit otherwise.
+func PopulateAccountStatementEntry(
+	dest *resource.AccountStatementEntry,
+	row history.BalanceChange,
+) {
+	dest.ID = row.ID()
+	dest.CreatedAt = row.LedgerCloseTime
+	dest.Ledger = row.LedgerSequence()
+	dest.TransactionHash = row.TransactionHash
+	if !row.IsFee() {
+		dest.OperationID = strconv.FormatInt(row.HistoryOperationID, 10)
+	}
+	dest.Type = row.Reason
+	dest.AssetType = row.AssetType
+	dest.AssetCode = row.AssetCode
+	dest.AssetIssuer = row.AssetIssuer
+	if row.AmountAfter < row.AmountBefore {
+		dest.Debit = amount.StringFromInt64(row.AmountBefore - row.AmountAfter)
+	} else {
+		dest.Credit = amount.StringFromInt64(row.AmountAfter - row.AmountBefore)
+	}
+	dest.Balance = amount.StringFromInt64(row.AmountAfter)
+}
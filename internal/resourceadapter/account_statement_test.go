@@ -0,0 +1,62 @@
+package resourceadapter
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/stellar/go-stellar-sdk/toid"
+	"github.com/stellar/stellar-horizon/internal/db2/history"
+	"github.com/stellar/stellar-horizon/internal/resource"
+)
+
+func TestPopulateAccountStatementEntry(t *testing.T) {
+	transactionID := toid.New(20, 1, 0).ToInt64()
+	operationID := toid.New(20, 1, 1).ToInt64()
+	closedAt := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+	issuer := "GBRPYHIL2CI3FNQ4BXLFMNDLFJUNPU2HY3ZMFSHONUCEOASW7QC7OX2H"
+
+	var entry resource.AccountStatementEntry
+	PopulateAccountStatementEntry(&entry, history.BalanceChange{
+		HistoryOperationID:   operationID,
+		Order:                2,
+		HistoryTransactionID: transactionID,
+		TransactionHash:      "abcd",
+		AssetType:            "credit_alphanum4",
+		AssetCode:            "USD",
+		AssetIssuer:          issuer,
+		LedgerCloseTime:      closedAt,
+		Reason:               "claim_claimable_balance",
+		AmountBefore:         5000000,
+		AmountAfter:          15000000,
+	})
+	assert.Equal(t, "0000000085899350017-0000000002", entry.ID)
+	assert.Equal(t, int32(20), entry.Ledger)
+	assert.Equal(t, "85899350017", entry.OperationID)
+	assert.Equal(t, "claim_claimable_balance", entry.Type)
+	assert.Empty(t, entry.Debit)
+	assert.Equal(t, "1.0000000", entry.Credit)
+	assert.Equal(t, "1.5000000", entry.Balance)
+	assert.Equal(t, []string{
+		"0000000085899350017-0000000002", "2024-01-02T03:04:05Z", "20", "abcd", "85899350017",
+		"claim_claimable_balance", "credit_alphanum4", "USD", issuer, "", "1.0000000", "1.5000000",
+	}, entry.CSVRecord())
+
+	// fees are debits which are not made by an operation
+	entry = resource.AccountStatementEntry{}
+	PopulateAccountStatementEntry(&entry, history.BalanceChange{
+		HistoryOperationID:   transactionID,
+		Order:                1,
+		HistoryTransactionID: transactionID,
+		AssetType:            "native",
+		Reason:               history.BalanceChangeReasonFee,
+		AmountBefore:         1000,
+		AmountAfter:          900,
+	})
+	assert.Empty(t, entry.OperationID)
+	assert.Equal(t, "fee", entry.Type)
+	assert.Equal(t, "0.0000100", entry.Debit)
+	assert.Empty(t, entry.Credit)
+	assert.Equal(t, "0.0000900", entry.Balance)
+}
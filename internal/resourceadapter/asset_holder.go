@@ -0,0 +1,35 @@
+package resourceadapter
+
+import (
+	"context"
+
+	"github.com/stellar/go-stellar-sdk/amount"
+	"github.com/stellar/go-stellar-sdk/support/errors"
+	"github.com/stellar/go-stellar-sdk/support/render/hal"
+	horizonContext "github.com/stellar/stellar-horizon/internal/context"
+	"github.com/stellar/stellar-horizon/internal/db2/history"
+	"github.com/stellar/stellar-horizon/internal/resource"
+)
+
+// PopulateAssetHolder fills out the resource's fields
+func PopulateAssetHolder(
+	ctx context.Context,
+	dest *resource.AssetHolder,
+	row history.AssetHolder,
+) error {
+	var err error
+	dest.PT = row.PagingToken()
+	dest.Holder = row.Address()
+	if dest.Balance, err = amount.IntStringToAmount(row.Balance); err != nil {
+		return errors.Wrapf(err, "invalid asset holder balance: %q", row.Balance)
+	}
+	dest.LastModifiedLedger = row.LastModifiedLedger
+
+	lb := hal.LinkBuilder{Base: horizonContext.BaseURL(ctx)}
+	if row.AccountID != "" {
+		dest.Links.Holder = lb.Linkf("/accounts/%s", dest.Holder)
+	} else {
+		dest.Links.Holder = lb.Linkf("/contracts/%s", dest.Holder)
+	}
+	return nil
+}
@@ -0,0 +1,42 @@
+package resourceadapter
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/stellar/go-stellar-sdk/strkey"
+	"github.com/stellar/go-stellar-sdk/xdr"
+	"github.com/stellar/stellar-horizon/internal/db2/history"
+	"github.com/stellar/stellar-horizon/internal/resource"
+)
+
+func TestPopulateAssetHolder(t *testing.T) {
+	accountID := "GCYVFGI3SEQJGBNQQG7YCMFWEYOHK3XPVOVPA6C566PXWN4SN7LILZSM"
+	var holder resource.AssetHolder
+	require.NoError(t, PopulateAssetHolder(context.Background(), &holder, history.AssetHolder{
+		AccountID:          accountID,
+		Balance:            "12345678",
+		LastModifiedLedger: 100,
+	}))
+	assert.Equal(t, "12345678-"+accountID, holder.PT)
+	assert.Equal(t, accountID, holder.Holder)
+	assert.Equal(t, "1.2345678", holder.Balance)
+	assert.Equal(t, uint32(100), holder.LastModifiedLedger)
+	assert.Equal(t, "/accounts/"+accountID, holder.Links.Holder.Href)
+
+	contractID := xdr.Hash{1}
+	contract := strkey.MustEncode(strkey.VersionByteContract, contractID[:])
+	holder = resource.AssetHolder{}
+	require.NoError(t, PopulateAssetHolder(context.Background(), &holder, history.AssetHolder{
+		HolderContractID:   contractID[:],
+		Balance:            "170141183460469231731687303715884105727",
+		LastModifiedLedger: 200,
+	}))
+	assert.Equal(t, "170141183460469231731687303715884105727-"+contract, holder.PT)
+	assert.Equal(t, contract, holder.Holder)
+	assert.Equal(t, "17014118346046923173168730371588.4105727", holder.Balance)
+	assert.Equal(t, "/contracts/"+contract, holder.Links.Holder.Href)
+}
@@ -0,0 +1,73 @@
+package resourceadapter
+
+import (
+	"context"
+	"math/big"
+	"strconv"
+
+	"github.com/stellar/go-stellar-sdk/amount"
+	protocol "github.com/stellar/go-stellar-sdk/protocols/horizon"
+	"github.com/stellar/go-stellar-sdk/support/errors"
+	"github.com/stellar/go-stellar-sdk/support/render/hal"
+	"github.com/stellar/go-stellar-sdk/toid"
+	horizonContext "github.com/stellar/stellar-horizon/internal/context"
+	"github.com/stellar/stellar-horizon/internal/db2/history"
+	"github.com/stellar/stellar-horizon/internal/resource"
+)
+
+// PopulateAssetStatSnapshot fills out the resource's fields. resolution is the
+// bucket resolution in milliseconds or 0 for a per ledger snapshot.
+func PopulateAssetStatSnapshot(
+	ctx context.Context,
+	dest *resource.AssetStatSnapshot,
+	row history.AssetStatSnapshot,
+	resolution int64,
+) error {
+	if resolution > 0 {
+		dest.PT = strconv.FormatInt(row.Timestamp, 10)
+		dest.Timestamp = row.Timestamp
+	} else {
+		dest.PT = strconv.FormatInt(row.LedgerID, 10)
+	}
+	dest.Ledger = toid.Parse(row.LedgerID).LedgerSequence
+	dest.ClosedAt = row.ClosedAt
+	dest.Accounts = protocol.AssetStatAccounts{
+		Authorized:                      row.Accounts.Authorized,
+		AuthorizedToMaintainLiabilities: row.Accounts.AuthorizedToMaintainLiabilities,
+		Unauthorized:                    row.Accounts.Unauthorized,
+	}
+	dest.NumClaimableBalances = row.Accounts.ClaimableBalances
+	dest.NumLiquidityPools = row.Accounts.LiquidityPools
+	dest.NumContracts = row.Contracts.ActiveHolders
+
+	supply := new(big.Int)
+	for _, field := range []struct {
+		dest   *string
+		amount string
+	}{
+		{&dest.Balances.Authorized, row.Balances.Authorized},
+		{&dest.Balances.AuthorizedToMaintainLiabilities, row.Balances.AuthorizedToMaintainLiabilities},
+		{&dest.Balances.Unauthorized, row.Balances.Unauthorized},
+		{&dest.ClaimableBalancesAmount, row.Balances.ClaimableBalances},
+		{&dest.LiquidityPoolsAmount, row.Balances.LiquidityPools},
+		{&dest.ContractsAmount, row.Contracts.ActiveBalance},
+	} {
+		v, ok := new(big.Int).SetString(field.amount, 10)
+		if !ok {
+			return errors.Errorf("invalid amount in asset stat snapshot: %q", field.amount)
+		}
+		supply.Add(supply, v)
+		var err error
+		if *field.dest, err = amount.IntStringToAmount(field.amount); err != nil {
+			return errors.Wrapf(err, "invalid amount in asset stat snapshot: %q", field.amount)
+		}
+	}
+	var err error
+	if dest.Supply, err = amount.IntStringToAmount(supply.String()); err != nil {
+		return errors.Wrap(err, "invalid supply")
+	}
+
+	lb := hal.LinkBuilder{Base: horizonContext.BaseURL(ctx)}
+	dest.Links.Ledger = lb.Linkf("/ledgers/%d", dest.Ledger)
+	return nil
+}
@@ -0,0 +1,63 @@
+package resourceadapter
+
+import (
+	"context"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/stellar/go-stellar-sdk/toid"
+	"github.com/stellar/stellar-horizon/internal/db2/history"
+	"github.com/stellar/stellar-horizon/internal/resource"
+)
+
+func TestPopulateAssetStatSnapshot(t *testing.T) {
+	row := history.AssetStatSnapshot{
+		Timestamp: 86400000,
+		LedgerID:  toid.New(1000, 0, 0).ToInt64(),
+		ClosedAt:  time.Unix(86400, 0).UTC(),
+		Accounts: history.ExpAssetStatAccounts{
+			Authorized:        3,
+			Unauthorized:      1,
+			ClaimableBalances: 2,
+			LiquidityPools:    1,
+		},
+		Balances: history.ExpAssetStatBalances{
+			Authorized:                      "10000000000",
+			AuthorizedToMaintainLiabilities: "0",
+			Unauthorized:                    "5000000",
+			ClaimableBalances:               "20000000",
+			LiquidityPools:                  "30000000",
+		},
+		Contracts: history.ContractStat{ActiveBalance: "45000000", ActiveHolders: 4},
+	}
+
+	var snapshot resource.AssetStatSnapshot
+	require.NoError(t, PopulateAssetStatSnapshot(context.Background(), &snapshot, row, 0))
+	assert.Equal(t, strconv.FormatInt(row.LedgerID, 10), snapshot.PT)
+	assert.Zero(t, snapshot.Timestamp)
+	assert.Equal(t, int32(1000), snapshot.Ledger)
+	assert.Equal(t, int32(3), snapshot.Accounts.Authorized)
+	assert.Equal(t, int32(1), snapshot.Accounts.Unauthorized)
+	assert.Equal(t, int32(2), snapshot.NumClaimableBalances)
+	assert.Equal(t, int32(1), snapshot.NumLiquidityPools)
+	assert.Equal(t, int32(4), snapshot.NumContracts)
+	assert.Equal(t, "1000.0000000", snapshot.Balances.Authorized)
+	assert.Equal(t, "0.5000000", snapshot.Balances.Unauthorized)
+	assert.Equal(t, "2.0000000", snapshot.ClaimableBalancesAmount)
+	assert.Equal(t, "3.0000000", snapshot.LiquidityPoolsAmount)
+	assert.Equal(t, "4.5000000", snapshot.ContractsAmount)
+	assert.Equal(t, "1010.0000000", snapshot.Supply)
+	assert.Equal(t, "/ledgers/1000", snapshot.Links.Ledger.Href)
+
+	snapshot = resource.AssetStatSnapshot{}
+	require.NoError(t, PopulateAssetStatSnapshot(context.Background(), &snapshot, row, 86400000))
+	assert.Equal(t, "86400000", snapshot.PT)
+	assert.Equal(t, int64(86400000), snapshot.Timestamp)
+
+	row.Balances.Authorized = "invalid"
+	assert.Error(t, PopulateAssetStatSnapshot(context.Background(), &resource.AssetStatSnapshot{}, row, 0))
+}
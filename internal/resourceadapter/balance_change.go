@@ -0,0 +1,41 @@
+package resourceadapter
+
+import (
+	"context"
+	"strconv"
+
+	"github.com/stellar/go-stellar-sdk/amount"
+	"github.com/stellar/go-stellar-sdk/support/render/hal"
+	horizonContext "github.com/stellar/stellar-horizon/internal/context"
+	"github.com/stellar/stellar-horizon/internal/db2/history"
+	"github.com/stellar/stellar-horizon/internal/resource"
+)
+
+// PopulateBalanceChange fills out the resource's fields
+func PopulateBalanceChange(
+	ctx context.Context,
+	dest *resource.BalanceChange,
+	row history.BalanceChange,
+) {
+	dest.ID = row.ID()
+	dest.PT = row.PagingToken()
+	dest.Account = row.Account
+	dest.AssetType = row.AssetType
+	dest.AssetCode = row.AssetCode
+	dest.AssetIssuer = row.AssetIssuer
+	dest.Reason = row.Reason
+	dest.AmountBefore = amount.StringFromInt64(row.AmountBefore)
+	dest.AmountAfter = amount.StringFromInt64(row.AmountAfter)
+	dest.Ledger = row.LedgerSequence()
+	dest.LedgerCloseTime = row.LedgerCloseTime
+	dest.TransactionHash = row.TransactionHash
+
+	lb := hal.LinkBuilder{Base: horizonContext.BaseURL(ctx)}
+	dest.Links.Account = lb.Linkf("/accounts/%s", row.Account)
+	dest.Links.Transaction = lb.Linkf("/transactions/%s", row.TransactionHash)
+	if !row.IsFee() {
+		dest.OperationID = strconv.FormatInt(row.HistoryOperationID, 10)
+		operation := lb.Linkf("/operations/%d", row.HistoryOperationID)
+		dest.Links.Operation = &operation
+	}
+}
@@ -0,0 +1,67 @@
+package resourceadapter
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/stellar/go-stellar-sdk/toid"
+	"github.com/stellar/stellar-horizon/internal/db2/history"
+	"github.com/stellar/stellar-horizon/internal/resource"
+)
+
+func TestPopulateBalanceChange(t *testing.T) {
+	account := "GCYVFGI3SEQJGBNQQG7YCMFWEYOHK3XPVOVPA6C566PXWN4SN7LILZSM"
+	transactionID := toid.New(20, 1, 0).ToInt64()
+	operationID := toid.New(20, 1, 1).ToInt64()
+	closedAt := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+
+	var change resource.BalanceChange
+	PopulateBalanceChange(context.Background(), &change, history.BalanceChange{
+		HistoryOperationID:   operationID,
+		Order:                2,
+		HistoryTransactionID: transactionID,
+		TransactionHash:      "abcd",
+		Account:              account,
+		AssetType:            "credit_alphanum4",
+		AssetCode:            "USD",
+		AssetIssuer:          "GBRPYHIL2CI3FNQ4BXLFMNDLFJUNPU2HY3ZMFSHONUCEOASW7QC7OX2H",
+		LedgerCloseTime:      closedAt,
+		Reason:               history.BalanceChangeReasonPayment,
+		AmountBefore:         10000000,
+		AmountAfter:          5000000,
+	})
+	assert.Equal(t, "0000000085899350017-0000000002", change.ID)
+	assert.Equal(t, "85899350017-2", change.PT)
+	assert.Equal(t, account, change.Account)
+	assert.Equal(t, "USD", change.AssetCode)
+	assert.Equal(t, "payment", change.Reason)
+	assert.Equal(t, "1.0000000", change.AmountBefore)
+	assert.Equal(t, "0.5000000", change.AmountAfter)
+	assert.Equal(t, int32(20), change.Ledger)
+	assert.Equal(t, closedAt, change.LedgerCloseTime)
+	assert.Equal(t, "85899350017", change.OperationID)
+	assert.Equal(t, "/accounts/"+account, change.Links.Account.Href)
+	assert.Equal(t, "/transactions/abcd", change.Links.Transaction.Href)
+	assert.Equal(t, "/operations/85899350017", change.Links.Operation.Href)
+
+	// fees are not changed by an operation
+	change = resource.BalanceChange{}
+	PopulateBalanceChange(context.Background(), &change, history.BalanceChange{
+		HistoryOperationID:   transactionID,
+		Order:                1,
+		HistoryTransactionID: transactionID,
+		Account:              account,
+		AssetType:            "native",
+		Reason:               history.BalanceChangeReasonFee,
+		AmountBefore:         100,
+		AmountAfter:          0,
+	})
+	assert.Equal(t, "native", change.AssetType)
+	assert.Empty(t, change.AssetCode)
+	assert.Equal(t, "0.0000100", change.AmountBefore)
+	assert.Empty(t, change.OperationID)
+	assert.Nil(t, change.Links.Operation)
+}
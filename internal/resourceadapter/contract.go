@@ -0,0 +1,157 @@
+package resourceadapter
+
+import (
+	"context"
+	"encoding/hex"
+	"fmt"
+
+	"github.com/stellar/go-stellar-sdk/amount"
+	"github.com/stellar/go-stellar-sdk/support/errors"
+	"github.com/stellar/go-stellar-sdk/support/render/hal"
+	"github.com/stellar/go-stellar-sdk/xdr"
+	horizonContext "github.com/stellar/stellar-horizon/internal/context"
+	"github.com/stellar/stellar-horizon/internal/db2/history"
+	"github.com/stellar/stellar-horizon/internal/resource"
+)
+
+var contractExecutableTypeNames = map[xdr.ContractExecutableType]string{
+	xdr.ContractExecutableTypeContractExecutableWasm:         "wasm",
+	xdr.ContractExecutableTypeContractExecutableStellarAsset: "stellar_asset",
+	xdr.ContractExecutableTypeContractExecutableExternalRef:  "external_ref",
+}
+
+// PopulateContract fills out the resource's fields from the contract
+// instance entry of the contract.
+func PopulateContract(
+	ctx context.Context,
+	dest *resource.Contract,
+	instance history.ContractData,
+	ledger *history.Ledger,
+) error {
+	var val xdr.ScVal
+	if err := xdr.SafeUnmarshalBase64(instance.Val, &val); err != nil {
+		return errors.Wrap(err, "could not decode contract instance")
+	}
+	contractInstance, ok := val.GetInstance()
+	if !ok {
+		return errors.Errorf("unexpected contract instance value type: %s", val.Type)
+	}
+
+	dest.ID = instance.ContractID
+	executable := contractInstance.Executable
+	typ, ok := contractExecutableTypeNames[executable.Type]
+	if !ok {
+		return errors.Errorf("unknown contract executable type: %d", executable.Type)
+	}
+	dest.Executable.Type = typ
+	if hash, ok := executable.GetWasmHash(); ok {
+		dest.Executable.WasmHash = hex.EncodeToString(hash[:])
+	}
+
+	dest.Storage = []resource.ContractStorageEntry{}
+	if contractInstance.Storage != nil {
+		for _, entry := range *contractInstance.Storage {
+			var storageEntry resource.ContractStorageEntry
+			var err error
+			if storageEntry.Key, storageEntry.KeyJSON, err = encodeScVal(entry.Key); err != nil {
+				return err
+			}
+			if storageEntry.Value, storageEntry.ValueJSON, err = encodeScVal(entry.Val); err != nil {
+				return err
+			}
+			dest.Storage = append(dest.Storage, storageEntry)
+		}
+	}
+
+	dest.LiveUntilLedger = instance.ExpirationLedger
+	dest.LastModifiedLedger = instance.LastModifiedLedger
+	if ledger != nil {
+		dest.LastModifiedTime = &ledger.ClosedAt
+	}
+
+	lb := hal.LinkBuilder{Base: horizonContext.BaseURL(ctx)}
+	self := fmt.Sprintf("/contracts/%s", dest.ID)
+	dest.Links.Self = lb.Link(self)
+	dest.Links.Data = lb.PagedLink(self, "data")
+	dest.Links.Events = lb.PagedLink(self, "events")
+	return nil
+}
+
+// PopulateContractData fills out the resource's fields
+func PopulateContractData(
+	ctx context.Context,
+	dest *resource.ContractData,
+	row history.ContractData,
+	ledger *history.Ledger,
+) error {
+	dest.ID = hex.EncodeToString(row.KeyHash)
+	dest.PT = row.PagingToken()
+	dest.ContractID = row.ContractID
+	dest.Durability = "persistent"
+
+	var key, val xdr.ScVal
+	if err := xdr.SafeUnmarshalBase64(row.Key, &key); err != nil {
+		return errors.Wrap(err, "could not decode contract data key")
+	}
+	if err := xdr.SafeUnmarshalBase64(row.Val, &val); err != nil {
+		return errors.Wrap(err, "could not decode contract data value")
+	}
+	var err error
+	dest.Key = row.Key
+	if dest.KeyJSON, err = ScValToJSON(key); err != nil {
+		return err
+	}
+	dest.Value = row.Val
+	if dest.ValueJSON, err = ScValToJSON(val); err != nil {
+		return err
+	}
+
+	dest.LiveUntilLedger = row.ExpirationLedger
+	dest.LastModifiedLedger = row.LastModifiedLedger
+	if ledger != nil {
+		dest.LastModifiedTime = &ledger.ClosedAt
+	}
+
+	lb := hal.LinkBuilder{Base: horizonContext.BaseURL(ctx)}
+	dest.Links.Contract = lb.Linkf("/contracts/%s", row.ContractID)
+	return nil
+}
+
+// PopulateContractBalance fills out the resource's fields
+func PopulateContractBalance(
+	ctx context.Context,
+	dest *resource.ContractBalance,
+	row history.ContractBalance,
+	ledger *history.Ledger,
+) error {
+	var err error
+	dest.PT = row.PagingToken()
+	dest.AssetContractID = row.PagingToken()
+	dest.AssetType = xdr.AssetTypeToString[row.AssetType]
+	dest.AssetCode = row.AssetCode
+	dest.AssetIssuer = row.AssetIssuer
+	if dest.Amount, err = amount.IntStringToAmount(row.Amount); err != nil {
+		return errors.Wrapf(err, "invalid contract balance amount: %q", row.Amount)
+	}
+
+	dest.LastModifiedLedger = row.LastModifiedLedger
+	if ledger != nil {
+		dest.LastModifiedTime = &ledger.ClosedAt
+	}
+
+	lb := hal.LinkBuilder{Base: horizonContext.BaseURL(ctx)}
+	dest.Links.AssetContract = lb.Linkf("/contracts/%s", dest.AssetContractID)
+	return nil
+}
+
+func encodeScVal(val xdr.ScVal) (string, interface{}, error) {
+	encoded, err := xdr.MarshalBase64(val)
+	if err != nil {
+		return "", nil, errors.Wrap(err, "could not encode ScVal")
+	}
+	decoded, err := ScValToJSON(val)
+	if err != nil {
+		return "", nil, err
+	}
+	return encoded, decoded, nil
+}
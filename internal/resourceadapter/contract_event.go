@@ -0,0 +1,48 @@
+package resourceadapter
+
+import (
+	"context"
+	"strconv"
+
+	"github.com/stellar/go-stellar-sdk/support/render/hal"
+	"github.com/stellar/go-stellar-sdk/xdr"
+	horizonContext "github.com/stellar/stellar-horizon/internal/context"
+	"github.com/stellar/stellar-horizon/internal/db2/history"
+	"github.com/stellar/stellar-horizon/internal/resource"
+)
+
+var contractEventTypeNames = map[xdr.ContractEventType]string{
+	xdr.ContractEventTypeSystem:     "system",
+	xdr.ContractEventTypeContract:   "contract",
+	xdr.ContractEventTypeDiagnostic: "diagnostic",
+}
+
+// PopulateContractEvent fills out the resource's fields
+func PopulateContractEvent(
+	ctx context.Context,
+	dest *resource.ContractEvent,
+	row history.ContractEvent,
+	ledger history.Ledger,
+) {
+	dest.ID = row.ID()
+	dest.PT = row.PagingToken()
+	if name, ok := contractEventTypeNames[row.Type]; ok {
+		dest.Type = name
+	} else {
+		dest.Type = "unknown"
+	}
+	dest.ContractID = row.ContractID.String
+	dest.Topics = row.Topics
+	if dest.Topics == nil {
+		dest.Topics = []string{}
+	}
+	dest.Value = row.Data
+	dest.Ledger = row.LedgerSequence()
+	dest.LedgerCloseTime = ledger.ClosedAt
+	dest.TransactionHash = row.TransactionHash
+	dest.OperationID = strconv.FormatInt(row.HistoryOperationID, 10)
+
+	lb := hal.LinkBuilder{horizonContext.BaseURL(ctx)}
+	dest.Links.Transaction = lb.Linkf("/transactions/%s", row.TransactionHash)
+	dest.Links.Operation = lb.Linkf("/operations/%d", row.HistoryOperationID)
+}
@@ -0,0 +1,148 @@
+package resourceadapter
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stellar/go-stellar-sdk/support/test"
+	"github.com/stellar/go-stellar-sdk/xdr"
+	"github.com/stellar/stellar-horizon/internal/db2/history"
+	"github.com/stellar/stellar-horizon/internal/resource"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestScValToJSON(t *testing.T) {
+	contractID := xdr.ContractId{1}
+	sym := xdr.ScSymbol("balance")
+	u32 := xdr.Uint32(7)
+	b := true
+	vec := &xdr.ScVec{
+		{Type: xdr.ScValTypeScvSymbol, Sym: &sym},
+		{Type: xdr.ScValTypeScvBool, B: &b},
+	}
+	m := &xdr.ScMap{
+		{
+			Key: xdr.ScVal{Type: xdr.ScValTypeScvAddress, Address: &xdr.ScAddress{
+				Type:       xdr.ScAddressTypeScAddressTypeContract,
+				ContractId: &contractID,
+			}},
+			Val: xdr.ScVal{
+				Type: xdr.ScValTypeScvI128,
+				I128: &xdr.Int128Parts{Hi: 1, Lo: 0},
+			},
+		},
+		{
+			Key: xdr.ScVal{Type: xdr.ScValTypeScvU32, U32: &u32},
+			Val: xdr.ScVal{Type: xdr.ScValTypeScvVec, Vec: &vec},
+		},
+	}
+	val := xdr.ScVal{Type: xdr.ScValTypeScvMap, Map: &m}
+
+	decoded, err := ScValToJSON(val)
+	require.NoError(t, err)
+	serialized, err := json.Marshal(decoded)
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"map": [
+		{
+			"key": {"address": "CAAQAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAABDQF"},
+			"val": {"i128": "18446744073709551616"}
+		},
+		{
+			"key": {"u32": 7},
+			"val": {"vec": [{"symbol": "balance"}, {"bool": true}]}
+		}
+	]}`, string(serialized))
+
+	decoded, err = ScValToJSON(xdr.ScVal{Type: xdr.ScValTypeScvLedgerKeyContractInstance})
+	require.NoError(t, err)
+	assert.Equal(t, "ledger_key_contract_instance", decoded)
+}
+
+func TestPopulateContract(t *testing.T) {
+	ctx, _ := test.ContextWithLogBuffer()
+
+	wasmHash := xdr.Hash{1, 2, 3}
+	sym := xdr.ScSymbol("admin")
+	u32 := xdr.Uint32(1)
+	storage := xdr.ScMap{
+		{
+			Key: xdr.ScVal{Type: xdr.ScValTypeScvSymbol, Sym: &sym},
+			Val: xdr.ScVal{Type: xdr.ScValTypeScvU32, U32: &u32},
+		},
+	}
+	instance := xdr.ScVal{
+		Type: xdr.ScValTypeScvContractInstance,
+		Instance: &xdr.ScContractInstance{
+			Executable: xdr.ContractExecutable{
+				Type:     xdr.ContractExecutableTypeContractExecutableWasm,
+				WasmHash: &wasmHash,
+			},
+			Storage: &storage,
+		},
+	}
+	key, err := xdr.MarshalBase64(xdr.ScVal{Type: xdr.ScValTypeScvLedgerKeyContractInstance})
+	require.NoError(t, err)
+	val, err := xdr.MarshalBase64(instance)
+	require.NoError(t, err)
+
+	row := history.ContractData{
+		KeyHash:            []byte{1},
+		ContractID:         "CAAQAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAABDQF",
+		Key:                key,
+		Val:                val,
+		ExpirationLedger:   100,
+		LastModifiedLedger: 10,
+	}
+
+	var contract resource.Contract
+	require.NoError(t, PopulateContract(ctx, &contract, row, nil))
+	assert.Equal(t, row.ContractID, contract.ID)
+	assert.Equal(t, "wasm", contract.Executable.Type)
+	assert.Equal(t, "0102030000000000000000000000000000000000000000000000000000000000", contract.Executable.WasmHash)
+	require.Len(t, contract.Storage, 1)
+	assert.Equal(t, map[string]interface{}{"symbol": "admin"}, contract.Storage[0].KeyJSON)
+	assert.Equal(t, map[string]interface{}{"u32": uint32(1)}, contract.Storage[0].ValueJSON)
+	assert.Equal(t, uint32(100), contract.LiveUntilLedger)
+	assert.Equal(t, uint32(10), contract.LastModifiedLedger)
+	assert.Nil(t, contract.LastModifiedTime)
+	assert.Equal(t, "/contracts/"+row.ContractID+"/data{?cursor,limit,order}", contract.Links.Data.Href)
+
+	var data resource.ContractData
+	require.NoError(t, PopulateContractData(ctx, &data, row, nil))
+	assert.Equal(t, "01", data.ID)
+	assert.Equal(t, "01", data.PagingToken())
+	assert.Equal(t, "ledger_key_contract_instance", data.KeyJSON)
+	assert.Equal(t, val, data.Value)
+	assert.Equal(t, uint32(100), data.LiveUntilLedger)
+	assert.Equal(t, "/contracts/"+row.ContractID, data.Links.Contract.Href)
+}
+
+func TestPopulateContractBalance(t *testing.T) {
+	ctx, _ := test.ContextWithLogBuffer()
+
+	row := history.ContractBalance{
+		AssetContractID:    make([]byte, 32),
+		AssetType:          xdr.AssetTypeAssetTypeCreditAlphanum4,
+		AssetCode:          "USD",
+		AssetIssuer:        "GAOQJGUAB7NI7K7I62ORBXMN3J4SSWQUQ7FOEPSDJ322W2HMCNWPHXFB",
+		Amount:             "170141183460469231731687303715884105727",
+		LastModifiedLedger: 10,
+	}
+	row.AssetContractID[0] = 1
+
+	var balance resource.ContractBalance
+	require.NoError(t, PopulateContractBalance(ctx, &balance, row, &history.Ledger{}))
+	assert.Equal(t, "CAAQAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAABDQF", balance.AssetContractID)
+	assert.Equal(t, balance.AssetContractID, balance.PagingToken())
+	assert.Equal(t, "credit_alphanum4", balance.AssetType)
+	assert.Equal(t, "USD", balance.AssetCode)
+	assert.Equal(t, row.AssetIssuer, balance.AssetIssuer)
+	assert.Equal(t, "17014118346046923173168730371588.4105727", balance.Amount)
+	assert.Equal(t, uint32(10), balance.LastModifiedLedger)
+	assert.NotNil(t, balance.LastModifiedTime)
+	assert.Equal(t, "/contracts/"+balance.AssetContractID, balance.Links.AssetContract.Href)
+
+	row.Amount = "invalid"
+	assert.Error(t, PopulateContractBalance(ctx, &balance, row, nil))
+}
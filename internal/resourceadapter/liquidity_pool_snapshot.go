@@ -0,0 +1,88 @@
+package resourceadapter
+
+import (
+	"context"
+	"math/big"
+	"strconv"
+	"time"
+
+	"github.com/stellar/go-stellar-sdk/amount"
+	"github.com/stellar/go-stellar-sdk/support/errors"
+	"github.com/stellar/go-stellar-sdk/support/render/hal"
+	"github.com/stellar/go-stellar-sdk/toid"
+	horizonContext "github.com/stellar/stellar-horizon/internal/context"
+	"github.com/stellar/stellar-horizon/internal/db2/history"
+	"github.com/stellar/stellar-horizon/internal/resource"
+)
+
+var millisPerYear = big.NewRat(int64(365*24*time.Hour/time.Millisecond), 1)
+
+// PopulateLiquidityPoolSnapshot fills out the resource's fields. assets are
+// the reserve assets of the pool in canonical form, and resolution is the
+// bucket resolution in milliseconds or 0 for a per ledger snapshot. The fee
+// APR is only computed for buckets, by annualizing the fees earned in the
+// bucket relative to the value of the reserves at the end of the bucket.
+func PopulateLiquidityPoolSnapshot(
+	ctx context.Context,
+	dest *resource.LiquidityPoolSnapshot,
+	poolID string,
+	assets [2]string,
+	row history.LiquidityPoolSnapshot,
+	resolution int64,
+) error {
+	if resolution > 0 {
+		dest.PT = strconv.FormatInt(row.Timestamp, 10)
+		dest.Timestamp = row.Timestamp
+	} else {
+		dest.PT = strconv.FormatInt(row.LedgerID, 10)
+	}
+	dest.Ledger = toid.Parse(row.LedgerID).LedgerSequence
+	dest.ClosedAt = row.ClosedAt
+	dest.FeeBP = row.Fee
+	dest.TotalShares = amount.StringFromInt64(row.TotalShares)
+	dest.TotalTrustlines = row.TrustlineCount
+	dest.TradeCount = row.TradeCount
+
+	reserves := [2]int64{row.ReserveA, row.ReserveB}
+	var fees [2]*big.Int
+	dest.Reserves = make([]resource.LiquidityPoolSnapshotReserve, 0, 2)
+	for i, volume := range []string{row.VolumeA, row.VolumeB} {
+		v, ok := new(big.Int).SetString(volume, 10)
+		if !ok {
+			return errors.Errorf("invalid volume: %s", volume)
+		}
+		fees[i] = new(big.Int).Mul(v, big.NewInt(int64(row.Fee)))
+		fees[i].Quo(fees[i], big.NewInt(10000))
+
+		reserve := resource.LiquidityPoolSnapshotReserve{
+			Asset:  assets[i],
+			Amount: amount.StringFromInt64(reserves[i]),
+		}
+		var err error
+		if reserve.Volume, err = amount.IntStringToAmount(v.String()); err != nil {
+			return errors.Wrap(err, "invalid volume")
+		}
+		if reserve.Fees, err = amount.IntStringToAmount(fees[i].String()); err != nil {
+			return errors.Wrap(err, "invalid fees")
+		}
+		dest.Reserves = append(dest.Reserves, reserve)
+	}
+
+	if resolution > 0 && row.ReserveA > 0 && row.ReserveB > 0 {
+		// value the fees and the reserves in asset A at the pool price
+		feesInA := new(big.Rat).SetFrac(fees[1], big.NewInt(row.ReserveB))
+		feesInA.Mul(feesInA, big.NewRat(row.ReserveA, 1))
+		feesInA.Add(feesInA, new(big.Rat).SetInt(fees[0]))
+
+		apr := feesInA.Quo(feesInA, big.NewRat(row.ReserveA, 1))
+		apr.Quo(apr, big.NewRat(2, 1))
+		apr.Mul(apr, millisPerYear)
+		apr.Quo(apr, big.NewRat(resolution, 1))
+		dest.FeeAPR = apr.FloatString(7)
+	}
+
+	lb := hal.LinkBuilder{Base: horizonContext.BaseURL(ctx)}
+	dest.Links.Ledger = lb.Linkf("/ledgers/%d", dest.Ledger)
+	dest.Links.LiquidityPool = lb.Linkf("/liquidity_pools/%s", poolID)
+	return nil
+}
@@ -0,0 +1,61 @@
+package resourceadapter
+
+import (
+	"context"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/stellar/go-stellar-sdk/toid"
+	"github.com/stellar/stellar-horizon/internal/db2/history"
+	"github.com/stellar/stellar-horizon/internal/resource"
+)
+
+func TestPopulateLiquidityPoolSnapshot(t *testing.T) {
+	assets := [2]string{"native", "USD:GAOQJGUAB7NI7K7I62ORBXMN3J4SSWQUQ7FOEPSDJ322W2HMCNWPHXFB"}
+	row := history.LiquidityPoolSnapshot{
+		Timestamp:      86400000,
+		LedgerID:       toid.New(1000, 0, 0).ToInt64(),
+		ClosedAt:       time.Unix(86400, 0).UTC(),
+		Fee:            30,
+		ReserveA:       10000000000,
+		ReserveB:       20000000000,
+		TotalShares:    14142135623,
+		TrustlineCount: 12,
+		TradeCount:     7,
+		VolumeA:        "1000000000",
+		VolumeB:        "2000000000",
+	}
+
+	var snapshot resource.LiquidityPoolSnapshot
+	require.NoError(t, PopulateLiquidityPoolSnapshot(context.Background(), &snapshot, "cafebabe", assets, row, 0))
+	assert.Equal(t, strconv.FormatInt(row.LedgerID, 10), snapshot.PT)
+	assert.Zero(t, snapshot.Timestamp)
+	assert.Equal(t, int32(1000), snapshot.Ledger)
+	assert.Equal(t, "1414.2135623", snapshot.TotalShares)
+	assert.Equal(t, []resource.LiquidityPoolSnapshotReserve{
+		{Asset: assets[0], Amount: "1000.0000000", Volume: "100.0000000", Fees: "0.3000000"},
+		{Asset: assets[1], Amount: "2000.0000000", Volume: "200.0000000", Fees: "0.6000000"},
+	}, snapshot.Reserves)
+	// the fee APR is only computed for buckets
+	assert.Empty(t, snapshot.FeeAPR)
+	assert.Equal(t, "/ledgers/1000", snapshot.Links.Ledger.Href)
+	assert.Equal(t, "/liquidity_pools/cafebabe", snapshot.Links.LiquidityPool.Href)
+
+	day := int64(24 * time.Hour / time.Millisecond)
+	snapshot = resource.LiquidityPoolSnapshot{}
+	require.NoError(t, PopulateLiquidityPoolSnapshot(context.Background(), &snapshot, "cafebabe", assets, row, day))
+	assert.Equal(t, "86400000", snapshot.PT)
+	assert.Equal(t, row.Timestamp, snapshot.Timestamp)
+	// 0.3 + 0.6/2 earned on reserves worth 2000 native in a day
+	assert.Equal(t, "0.1095000", snapshot.FeeAPR)
+
+	// no APR for an empty pool
+	row.ReserveA, row.ReserveB = 0, 0
+	snapshot = resource.LiquidityPoolSnapshot{}
+	require.NoError(t, PopulateLiquidityPoolSnapshot(context.Background(), &snapshot, "cafebabe", assets, row, day))
+	assert.Empty(t, snapshot.FeeAPR)
+}
@@ -0,0 +1,67 @@
+package resourceadapter
+
+import (
+	"context"
+	"time"
+
+	"github.com/stellar/go-stellar-sdk/amount"
+	protocol "github.com/stellar/go-stellar-sdk/protocols/horizon"
+	"github.com/stellar/go-stellar-sdk/support/errors"
+	"github.com/stellar/go-stellar-sdk/support/render/hal"
+	"github.com/stellar/go-stellar-sdk/xdr"
+	horizonContext "github.com/stellar/stellar-horizon/internal/context"
+	"github.com/stellar/stellar-horizon/internal/db2/history"
+	"github.com/stellar/stellar-horizon/internal/paths"
+	"github.com/stellar/stellar-horizon/internal/resource"
+)
+
+// PopulateMarket fills out the resource's fields. summary is nil when the
+// market had no trades since the given time, and quote is nil when the top of
+// the order book is not available.
+func PopulateMarket(
+	ctx context.Context,
+	dest *resource.Market,
+	base, counter xdr.Asset,
+	summary *history.MarketSummary,
+	quote *paths.Quote,
+	since time.Time,
+) error {
+	if err := base.Extract(&dest.BaseAssetType, &dest.BaseAssetCode, &dest.BaseAssetIssuer); err != nil {
+		return errors.Wrap(err, "invalid base asset")
+	}
+	if err := counter.Extract(&dest.CounterAssetType, &dest.CounterAssetCode, &dest.CounterAssetIssuer); err != nil {
+		return errors.Wrap(err, "invalid counter asset")
+	}
+	dest.Since = since
+
+	dest.BaseVolume = amount.StringFromInt64(0)
+	dest.CounterVolume = amount.StringFromInt64(0)
+	if summary != nil {
+		var err error
+		dest.PT = summary.PagingToken()
+		dest.TradeCount = summary.TradeCount
+		if dest.BaseVolume, err = amount.IntStringToAmount(summary.BaseVolume); err != nil {
+			return errors.Wrap(err, "invalid base volume")
+		}
+		if dest.CounterVolume, err = amount.IntStringToAmount(summary.CounterVolume); err != nil {
+			return errors.Wrap(err, "invalid counter volume")
+		}
+		dest.LastPrice = protocol.TradePrice{N: summary.CloseN, D: summary.CloseD}.String()
+		dest.Open = protocol.TradePrice{N: summary.OpenN, D: summary.OpenD}.String()
+		dest.High = protocol.TradePrice{N: summary.HighN, D: summary.HighD}.String()
+		dest.Low = protocol.TradePrice{N: summary.LowN, D: summary.LowD}.String()
+	}
+
+	if quote != nil {
+		if quote.Bid != nil {
+			dest.Bid = quote.Bid.FloatString(7)
+		}
+		if quote.Ask != nil {
+			dest.Ask = quote.Ask.FloatString(7)
+		}
+	}
+
+	lb := hal.LinkBuilder{Base: horizonContext.BaseURL(ctx)}
+	dest.Links.Self = lb.Linkf("/markets/%s/%s", base.StringCanonical(), counter.StringCanonical())
+	return nil
+}
@@ -0,0 +1,68 @@
+package resourceadapter
+
+import (
+	"context"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/stellar/go-stellar-sdk/xdr"
+	"github.com/stellar/stellar-horizon/internal/db2/history"
+	"github.com/stellar/stellar-horizon/internal/paths"
+	"github.com/stellar/stellar-horizon/internal/resource"
+)
+
+func TestPopulateMarket(t *testing.T) {
+	base := xdr.MustNewNativeAsset()
+	counter := xdr.MustNewCreditAsset("USD", "GAOQJGUAB7NI7K7I62ORBXMN3J4SSWQUQ7FOEPSDJ322W2HMCNWPHXFB")
+	since := time.Unix(86400, 0).UTC()
+
+	// a market without trades nor offers
+	var market resource.Market
+	require.NoError(t, PopulateMarket(context.Background(), &market, base, counter, nil, nil, since))
+	assert.Equal(t, "native", market.BaseAssetType)
+	assert.Equal(t, "credit_alphanum4", market.CounterAssetType)
+	assert.Equal(t, "USD", market.CounterAssetCode)
+	assert.Equal(t, since, market.Since)
+	assert.Zero(t, market.TradeCount)
+	assert.Equal(t, "0.0000000", market.BaseVolume)
+	assert.Equal(t, "0.0000000", market.CounterVolume)
+	assert.Empty(t, market.LastPrice)
+	assert.Empty(t, market.Bid)
+	assert.Empty(t, market.Ask)
+	assert.Equal(t, "/markets/native/"+counter.StringCanonical(), market.Links.Self.Href)
+
+	summary := history.MarketSummary{
+		BaseAssetID:    1,
+		CounterAssetID: 2,
+		TradeAggregation: history.TradeAggregation{
+			TradeCount:    3,
+			BaseVolume:    "300000000",
+			CounterVolume: "650000000",
+			HighN:         5,
+			HighD:         2,
+			LowN:          2,
+			LowD:          1,
+			OpenN:         2,
+			OpenD:         1,
+			CloseN:        9,
+			CloseD:        4,
+		},
+	}
+	quote := paths.Quote{Bid: big.NewRat(11, 5), Ask: big.NewRat(23, 10)}
+	market = resource.Market{}
+	require.NoError(t, PopulateMarket(context.Background(), &market, base, counter, &summary, &quote, since))
+	assert.Equal(t, "1-2", market.PagingToken())
+	assert.Equal(t, int64(3), market.TradeCount)
+	assert.Equal(t, "30.0000000", market.BaseVolume)
+	assert.Equal(t, "65.0000000", market.CounterVolume)
+	assert.Equal(t, "2.2500000", market.LastPrice)
+	assert.Equal(t, "2.0000000", market.Open)
+	assert.Equal(t, "2.5000000", market.High)
+	assert.Equal(t, "2.0000000", market.Low)
+	assert.Equal(t, "2.2000000", market.Bid)
+	assert.Equal(t, "2.3000000", market.Ask)
+}
@@ -0,0 +1,146 @@
+package resourceadapter
+
+import (
+	"encoding/hex"
+	"strconv"
+
+	"github.com/stellar/go-stellar-sdk/support/errors"
+	"github.com/stellar/go-stellar-sdk/xdr"
+)
+
+// ScValToJSON converts an ScVal into a value which can be marshaled to JSON.
+// Every value is tagged with its type (e.g. `{"u32": 1}`), integers which
+// don't fit into a JSON number safely are represented as decimal strings
+// and byte arrays are hex encoded.
+func ScValToJSON(val xdr.ScVal) (interface{}, error) {
+	switch val.Type {
+	case xdr.ScValTypeScvBool:
+		return map[string]interface{}{"bool": val.MustB()}, nil
+	case xdr.ScValTypeScvVoid:
+		return "void", nil
+	case xdr.ScValTypeScvError:
+		return map[string]interface{}{"error": val.String()}, nil
+	case xdr.ScValTypeScvU32:
+		return map[string]interface{}{"u32": uint32(val.MustU32())}, nil
+	case xdr.ScValTypeScvI32:
+		return map[string]interface{}{"i32": int32(val.MustI32())}, nil
+	case xdr.ScValTypeScvU64:
+		return map[string]interface{}{"u64": val.String()}, nil
+	case xdr.ScValTypeScvI64:
+		return map[string]interface{}{"i64": val.String()}, nil
+	case xdr.ScValTypeScvTimepoint:
+		return map[string]interface{}{"timepoint": strconv.FormatUint(uint64(val.MustTimepoint()), 10)}, nil
+	case xdr.ScValTypeScvDuration:
+		return map[string]interface{}{"duration": val.String()}, nil
+	case xdr.ScValTypeScvU128:
+		return map[string]interface{}{"u128": val.String()}, nil
+	case xdr.ScValTypeScvI128:
+		return map[string]interface{}{"i128": val.String()}, nil
+	case xdr.ScValTypeScvU256:
+		return map[string]interface{}{"u256": val.String()}, nil
+	case xdr.ScValTypeScvI256:
+		return map[string]interface{}{"i256": val.String()}, nil
+	case xdr.ScValTypeScvBytes:
+		return map[string]interface{}{"bytes": hex.EncodeToString(val.MustBytes())}, nil
+	case xdr.ScValTypeScvString:
+		return map[string]interface{}{"string": string(val.MustStr())}, nil
+	case xdr.ScValTypeScvSymbol:
+		return map[string]interface{}{"symbol": string(val.MustSym())}, nil
+	case xdr.ScValTypeScvVec:
+		vec := val.MustVec()
+		if vec == nil {
+			return map[string]interface{}{"vec": nil}, nil
+		}
+		items := make([]interface{}, 0, len(*vec))
+		for _, item := range *vec {
+			decoded, err := ScValToJSON(item)
+			if err != nil {
+				return nil, err
+			}
+			items = append(items, decoded)
+		}
+		return map[string]interface{}{"vec": items}, nil
+	case xdr.ScValTypeScvMap:
+		m := val.MustMap()
+		if m == nil {
+			return map[string]interface{}{"map": nil}, nil
+		}
+		entries, err := scMapToJSON(*m)
+		if err != nil {
+			return nil, err
+		}
+		return map[string]interface{}{"map": entries}, nil
+	case xdr.ScValTypeScvAddress:
+		address, err := val.MustAddress().String()
+		if err != nil {
+			return nil, err
+		}
+		return map[string]interface{}{"address": address}, nil
+	case xdr.ScValTypeScvContractInstance:
+		instance := val.MustInstance()
+		executable, err := contractExecutableToJSON(instance.Executable)
+		if err != nil {
+			return nil, err
+		}
+		var storage []map[string]interface{}
+		if instance.Storage != nil {
+			if storage, err = scMapToJSON(*instance.Storage); err != nil {
+				return nil, err
+			}
+		}
+		return map[string]interface{}{
+			"contract_instance": map[string]interface{}{
+				"executable": executable,
+				"storage":    storage,
+			},
+		}, nil
+	case xdr.ScValTypeScvLedgerKeyContractInstance:
+		return "ledger_key_contract_instance", nil
+	case xdr.ScValTypeScvLedgerKeyNonce:
+		nonce := val.MustNonceKey()
+		return map[string]interface{}{
+			"ledger_key_nonce": map[string]interface{}{"nonce": strconv.FormatInt(int64(nonce.Nonce), 10)},
+		}, nil
+	case xdr.ScValTypeScvExecutableTag:
+		return map[string]interface{}{"executable_tag": string(val.MustExecutableTag())}, nil
+	default:
+		return nil, errors.Errorf("unknown ScVal type: %d", val.Type)
+	}
+}
+
+func scMapToJSON(m xdr.ScMap) ([]map[string]interface{}, error) {
+	entries := make([]map[string]interface{}, 0, len(m))
+	for _, entry := range m {
+		key, err := ScValToJSON(entry.Key)
+		if err != nil {
+			return nil, err
+		}
+		val, err := ScValToJSON(entry.Val)
+		if err != nil {
+			return nil, err
+		}
+		entries = append(entries, map[string]interface{}{"key": key, "val": val})
+	}
+	return entries, nil
+}
+
+func contractExecutableToJSON(executable xdr.ContractExecutable) (interface{}, error) {
+	switch executable.Type {
+	case xdr.ContractExecutableTypeContractExecutableWasm:
+		hash := executable.MustWasmHash()
+		return map[string]interface{}{"wasm": hex.EncodeToString(hash[:])}, nil
+	case xdr.ContractExecutableTypeContractExecutableStellarAsset:
+		return "stellar_asset", nil
+	case xdr.ContractExecutableTypeContractExecutableExternalRef:
+		ref := executable.MustExternalRef()
+		owner, err := ref.ExecutableOwner.String()
+		if err != nil {
+			return nil, err
+		}
+		return map[string]interface{}{
+			"external_ref": map[string]interface{}{"owner": owner, "tag": string(ref.Tag)},
+		}, nil
+	default:
+		return nil, errors.Errorf("unknown contract executable type: %d", executable.Type)
+	}
+}
@@ -0,0 +1,178 @@
+package resourceadapter
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+)
+
+// The columns of the resources of the collection end-points rendered as CSV.
+// Columns are the JSON fields of the resources, fields nested in objects are
+// separated by a dot. Columns which don't apply to a record, like the asset
+// of an operation which is not a payment, are left empty.
+var (
+	// OperationCSVColumns are the columns of operations
+	OperationCSVColumns = []string{
+		"id",
+		"paging_token",
+		"transaction_successful",
+		"source_account",
+		"type",
+		"type_i",
+		"created_at",
+		"transaction_hash",
+		"funder",
+		"account",
+		"starting_balance",
+		"from",
+		"to",
+		"into",
+		"asset_type",
+		"asset_code",
+		"asset_issuer",
+		"amount",
+		"source_asset_type",
+		"source_asset_code",
+		"source_asset_issuer",
+		"source_amount",
+	}
+	// EffectCSVColumns are the columns of effects
+	EffectCSVColumns = []string{
+		"id",
+		"paging_token",
+		"account",
+		"type",
+		"type_i",
+		"created_at",
+		"starting_balance",
+		"asset_type",
+		"asset_code",
+		"asset_issuer",
+		"amount",
+	}
+	// TradeCSVColumns are the columns of trades
+	TradeCSVColumns = []string{
+		"id",
+		"paging_token",
+		"ledger_close_time",
+		"trade_type",
+		"base_offer_id",
+		"base_liquidity_pool_id",
+		"base_account",
+		"base_amount",
+		"base_asset_type",
+		"base_asset_code",
+		"base_asset_issuer",
+		"counter_offer_id",
+		"counter_liquidity_pool_id",
+		"counter_account",
+		"counter_amount",
+		"counter_asset_type",
+		"counter_asset_code",
+		"counter_asset_issuer",
+		"base_is_seller",
+		"price.n",
+		"price.d",
+	}
+	// TransactionCSVColumns are the columns of transactions
+	TransactionCSVColumns = []string{
+		"id",
+		"paging_token",
+		"successful",
+		"hash",
+		"ledger",
+		"created_at",
+		"source_account",
+		"source_account_sequence",
+		"fee_account",
+		"fee_charged",
+		"max_fee",
+		"operation_count",
+		"memo_type",
+		"memo",
+	}
+	// LedgerCSVColumns are the columns of ledgers
+	LedgerCSVColumns = []string{
+		"id",
+		"paging_token",
+		"hash",
+		"prev_hash",
+		"sequence",
+		"successful_transaction_count",
+		"failed_transaction_count",
+		"operation_count",
+		"tx_set_operation_count",
+		"closed_at",
+		"total_coins",
+		"fee_pool",
+		"base_fee_in_stroops",
+		"base_reserve_in_stroops",
+		"max_tx_set_size",
+		"protocol_version",
+	}
+)
+
+// PopulateRecordFields returns the JSON fields of a resource, without its HAL
+// links and the links of the resources embedded in it.
+func PopulateRecordFields(record interface{}) (map[string]interface{}, error) {
+	encoded, err := json.Marshal(record)
+	if err != nil {
+		return nil, err
+	}
+	decoder := json.NewDecoder(bytes.NewReader(encoded))
+	// keep the numbers as they are rendered by the API
+	decoder.UseNumber()
+	var fields map[string]interface{}
+	if err = decoder.Decode(&fields); err != nil {
+		return nil, err
+	}
+	removeLinks(fields)
+	return fields, nil
+}
+
+func removeLinks(value interface{}) {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		delete(v, "_links")
+		for _, field := range v {
+			removeLinks(field)
+		}
+	case []interface{}:
+		for _, item := range v {
+			removeLinks(item)
+		}
+	}
+}
+
+// CSVRecord returns the values of the columns in the fields of a resource
+// returned by PopulateRecordFields. Missing fields are empty and objects and
+// arrays are JSON encoded.
+func CSVRecord(fields map[string]interface{}, columns []string) ([]string, error) {
+	record := make([]string, len(columns))
+	for i, column := range columns {
+		var value interface{} = fields
+		for _, name := range strings.Split(column, ".") {
+			object, ok := value.(map[string]interface{})
+			if !ok {
+				value = nil
+				break
+			}
+			value = object[name]
+		}
+
+		switch v := value.(type) {
+		case nil:
+		case string:
+			record[i] = v
+		case json.Number:
+			record[i] = v.String()
+		default:
+			encoded, err := json.Marshal(v)
+			if err != nil {
+				return nil, err
+			}
+			record[i] = string(encoded)
+		}
+	}
+	return record, nil
+}
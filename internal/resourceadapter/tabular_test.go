@@ -0,0 +1,58 @@
+package resourceadapter
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	protocol "github.com/stellar/go-stellar-sdk/protocols/horizon"
+	"github.com/stellar/go-stellar-sdk/protocols/horizon/base"
+	"github.com/stellar/go-stellar-sdk/protocols/horizon/operations"
+	"github.com/stellar/go-stellar-sdk/support/render/hal"
+)
+
+func TestCSVRecord(t *testing.T) {
+	payment := operations.Payment{
+		Base: operations.Base{
+			ID:                    "12884905985",
+			PT:                    "12884905985",
+			TransactionSuccessful: true,
+			SourceAccount:         "GAOQJGUAB7NI7K7I62ORBXMN3J4SSWQUQ7FOEPSDJ322W2HMCNWPHXFB",
+			Type:                  "payment",
+			TypeI:                 1,
+			LedgerCloseTime:       time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC),
+			TransactionHash:       "abcd",
+			Transaction:           &protocol.Transaction{ID: "abcd"},
+		},
+		Asset:  base.Asset{Type: "native"},
+		From:   "GAOQJGUAB7NI7K7I62ORBXMN3J4SSWQUQ7FOEPSDJ322W2HMCNWPHXFB",
+		To:     "GBRPYHIL2CI3FNQ4BXLFMNDLFJUNPU2HY3ZMFSHONUCEOASW7QC7OX2H",
+		Amount: "10.0000000",
+	}
+	payment.Links.Self = hal.NewLink("/operations/12884905985")
+	payment.Transaction.Links.Self = hal.NewLink("/transactions/abcd")
+
+	fields, err := PopulateRecordFields(payment)
+	require.NoError(t, err)
+	assert.NotContains(t, fields, "_links")
+	assert.NotContains(t, fields["transaction"], "_links")
+
+	record, err := CSVRecord(fields, OperationCSVColumns)
+	require.NoError(t, err)
+	assert.Equal(t, []string{
+		"12884905985", "12884905985", "true", payment.SourceAccount, "payment", "1",
+		"2024-01-02T03:04:05Z", "abcd", "", "", "", payment.From, payment.To, "",
+		"native", "", "", "10.0000000", "", "", "", "",
+	}, record)
+
+	fields, err = PopulateRecordFields(protocol.Trade{
+		ID:    "1-1",
+		Price: protocol.TradePrice{N: 1, D: 2},
+	})
+	require.NoError(t, err)
+	record, err = CSVRecord(fields, []string{"id", "price.n", "price.d", "price.x", "id.x"})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"1-1", "1", "2", "", ""}, record)
+}
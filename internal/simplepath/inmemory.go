@@ -2,6 +2,8 @@ package simplepath
 
 import (
 	"context"
+	"math/big"
+	"sync"
 
 	"github.com/go-errors/errors"
 	"github.com/stellar/go-stellar-sdk/exp/orderbook"
@@ -13,6 +15,8 @@ const (
 	maxAssetsPerPath = 5
 	// MaxInMemoryPathLength is the maximum path length which can be queried by the InMemoryFinder
 	MaxInMemoryPathLength = 5
+	// maxBasisPoints is the denominator of liquidity pool fees
+	maxBasisPoints = 10_000
 )
 
 var (
@@ -25,6 +29,7 @@ var (
 type InMemoryFinder struct {
 	graph        *orderbook.OrderBookGraph
 	includePools bool
+	quotes       *quoteCache
 }
 
 // NewInMemoryFinder constructs a new InMemoryFinder instance
@@ -32,6 +37,7 @@ func NewInMemoryFinder(graph *orderbook.OrderBookGraph, includePools bool) InMem
 	return InMemoryFinder{
 		graph:        graph,
 		includePools: includePools,
+		quotes:       &quoteCache{},
 	}
 }
 
@@ -117,3 +123,89 @@ func (finder InMemoryFinder) FindFixedPaths(
 	}
 	return results, lastLedger, err
 }
+
+// quoteCache holds the best prices of the venues of the in memory orderbook at
+// a ledger, so that the whole orderbook is scanned once per ledger rather than
+// on every Quotes call. It's shared by the copies of an InMemoryFinder.
+type quoteCache struct {
+	lock   sync.Mutex
+	ledger uint32
+	// prices maps a selling and a buying asset to the lowest price, in units
+	// of the buying asset per unit of the selling asset, of the venues
+	// selling the first asset for the second one
+	prices map[[2]string]*big.Rat
+}
+
+// Quotes implements the paths.QuoteFinder interface using the offers of the in
+// memory orderbook and, when pools are included, the marginal prices of the
+// liquidity pools net of their fees.
+func (finder InMemoryFinder) Quotes(ctx context.Context, markets []paths.Market) ([]paths.Quote, error) {
+	if finder.graph.IsEmpty() {
+		return nil, ErrEmptyInMemoryOrderBook
+	}
+
+	prices, err := finder.prices(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	// venues selling the base asset are asks and venues selling the counter
+	// asset are bids
+	quotes := make([]paths.Quote, len(markets))
+	for i, market := range markets {
+		base, counter := market.Base.String(), market.Counter.String()
+		if ask, ok := prices[[2]string{base, counter}]; ok {
+			quotes[i].Ask = new(big.Rat).Set(ask)
+		}
+		if price, ok := prices[[2]string{counter, base}]; ok {
+			quotes[i].Bid = new(big.Rat).Inv(price)
+		}
+	}
+	return quotes, nil
+}
+
+// prices returns the best prices of the orderbook, which are computed again
+// only when the orderbook has been updated to a new ledger. The returned map
+// must not be modified.
+func (finder InMemoryFinder) prices(ctx context.Context) (map[[2]string]*big.Rat, error) {
+	// the ledger of the orderbook is only exposed by the path finding
+	// queries, a query without source assets returns it without searching
+	_, ledger, err := finder.graph.FindPaths(ctx, 0, xdr.MustNewNativeAsset(), 0, nil, nil, nil, false, 0, false)
+	if err != nil {
+		return nil, err
+	}
+
+	finder.quotes.lock.Lock()
+	defer finder.quotes.lock.Unlock()
+	if finder.quotes.prices != nil && finder.quotes.ledger == ledger {
+		return finder.quotes.prices, nil
+	}
+
+	prices := map[[2]string]*big.Rat{}
+	update := func(selling, buying xdr.Asset, price *big.Rat) {
+		key := [2]string{selling.String(), buying.String()}
+		if best, ok := prices[key]; !ok || price.Cmp(best) < 0 {
+			prices[key] = price
+		}
+	}
+	for _, offer := range finder.graph.Offers() {
+		update(offer.Selling, offer.Buying, big.NewRat(int64(offer.Price.N), int64(offer.Price.D)))
+	}
+	if finder.includePools {
+		for _, pool := range finder.graph.LiquidityPools() {
+			cp, ok := pool.Body.GetConstantProduct()
+			if !ok || cp.ReserveA <= 0 || cp.ReserveB <= 0 {
+				continue
+			}
+			kept := big.NewRat(maxBasisPoints-int64(cp.Params.Fee), maxBasisPoints)
+			update(cp.Params.AssetA, cp.Params.AssetB,
+				new(big.Rat).Quo(big.NewRat(int64(cp.ReserveB), int64(cp.ReserveA)), kept))
+			update(cp.Params.AssetB, cp.Params.AssetA,
+				new(big.Rat).Quo(big.NewRat(int64(cp.ReserveA), int64(cp.ReserveB)), kept))
+		}
+	}
+
+	finder.quotes.ledger = ledger
+	finder.quotes.prices = prices
+	return prices, nil
+}
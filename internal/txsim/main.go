@@ -0,0 +1,565 @@
+// Package txsim predicts the result of classic transactions from the ledger
+// state ingested by Horizon, without submitting them to Stellar Core.
+//
+// The simulation follows the validation and application steps of Stellar
+// Core: the transaction is checked (operations, time and ledger bounds, fee,
+// source account, sequence number, signatures against the thresholds of the
+// accounts in accounts_signers and fee balance) and its operations are applied
+// in order to an in-memory copy of the accounts and trustlines they use. The
+// create_account, payment, change_trust (for credit assets), account_merge and
+// bump_sequence operations are simulated; only the source account and the
+// signatures of the other operations are checked, which is reported in
+// Result.UncheckedOperations. Surge pricing, sponsorships and signed payload
+// signers are not simulated.
+package txsim
+
+import (
+	"context"
+	"crypto/sha256"
+	"math"
+	"time"
+
+	"github.com/stellar/go-stellar-sdk/keypair"
+	"github.com/stellar/go-stellar-sdk/network"
+	"github.com/stellar/go-stellar-sdk/strkey"
+	"github.com/stellar/go-stellar-sdk/support/errors"
+	"github.com/stellar/go-stellar-sdk/xdr"
+	"github.com/stellar/stellar-horizon/internal/codes"
+	"github.com/stellar/stellar-horizon/internal/db2/history"
+)
+
+// ErrSorobanNotSupported is returned when the simulated transaction has a
+// Soroban operation, which can only be simulated by Stellar RPC.
+var ErrSorobanNotSupported = errors.New("transactions with Soroban operations can't be simulated")
+
+// State is the ingested ledger state transactions are simulated against. It
+// is implemented by history.Q.
+type State interface {
+	GetAccountsByIDs(ctx context.Context, ids []string) ([]history.AccountEntry, error)
+	GetAccountSignersByAccountID(ctx context.Context, id string) ([]history.AccountSigner, error)
+	GetTrustLinesByKeys(ctx context.Context, ledgerKeys []string) ([]history.TrustLine, error)
+}
+
+// Ledger is the last ledger ingested in the state. Transactions are simulated
+// as if they were included in the next ledger.
+type Ledger struct {
+	Sequence    uint32
+	CloseTime   time.Time
+	BaseFee     int64
+	BaseReserve int64
+}
+
+// Result is the predicted result of a transaction, with the result codes of
+// the internal/codes vocabulary.
+type Result struct {
+	// TransactionCode is the result code of the transaction, or of the fee
+	// bump transaction.
+	TransactionCode string
+	// InnerTransactionCode is the result code of the inner transaction of fee
+	// bump transactions.
+	InnerTransactionCode string
+	// OperationCodes are the result codes of the operations. They are empty
+	// when the transaction is invalid.
+	OperationCodes []string
+	// UncheckedOperations are the indexes of the operations of which only the
+	// source account and the signatures were checked.
+	UncheckedOperations []int
+	// FeeCharged is the fee charged without surge pricing.
+	FeeCharged int64
+}
+
+// Successful returns true when the transaction is predicted to succeed.
+func (r Result) Successful() bool {
+	return r.TransactionCode == resultCode(xdr.TransactionResultCodeTxSuccess) ||
+		r.TransactionCode == resultCode(xdr.TransactionResultCodeTxFeeBumpInnerSuccess)
+}
+
+// Simulate predicts the result of a transaction in the ledger following the
+// given one.
+func Simulate(
+	ctx context.Context,
+	state State,
+	ledger Ledger,
+	networkPassphrase string,
+	envelope xdr.TransactionEnvelope,
+) (Result, error) {
+	for _, op := range envelope.Operations() {
+		if isSoroban(op.Body.Type) {
+			return Result{}, ErrSorobanNotSupported
+		}
+	}
+
+	s := &simulation{
+		ctx:        ctx,
+		state:      state,
+		ledger:     ledger,
+		accounts:   map[string]*history.AccountEntry{},
+		signers:    map[string][]history.AccountSigner{},
+		trustLines: map[string]*history.TrustLine{},
+	}
+	if envelope.IsFeeBump() {
+		return s.feeBumpTransaction(networkPassphrase, envelope)
+	}
+	hash, err := network.HashTransactionInEnvelope(envelope, networkPassphrase)
+	if err != nil {
+		return Result{}, errors.Wrap(err, "could not hash transaction")
+	}
+	numOps := int64(len(envelope.Operations()))
+	return s.transaction(envelope, hash, int64(envelope.Fee()), numOps*ledger.BaseFee)
+}
+
+func isSoroban(operationType xdr.OperationType) bool {
+	switch operationType {
+	case xdr.OperationTypeInvokeHostFunction,
+		xdr.OperationTypeExtendFootprintTtl,
+		xdr.OperationTypeRestoreFootprint:
+		return true
+	default:
+		return false
+	}
+}
+
+// resultCode returns the string of a result code. The simulation only uses
+// result codes known to the codes package.
+func resultCode(code interface{}) string {
+	result, err := codes.String(code)
+	if err != nil {
+		panic(err)
+	}
+	return result
+}
+
+type simulation struct {
+	ctx    context.Context
+	state  State
+	ledger Ledger
+	// the accounts, signers and trustlines loaded from the state and changed
+	// by the operations, nil when they don't exist
+	accounts   map[string]*history.AccountEntry
+	signers    map[string][]history.AccountSigner
+	trustLines map[string]*history.TrustLine
+}
+
+func (s *simulation) account(address string) (*history.AccountEntry, error) {
+	if account, ok := s.accounts[address]; ok {
+		return account, nil
+	}
+	rows, err := s.state.GetAccountsByIDs(s.ctx, []string{address})
+	if err != nil {
+		return nil, errors.Wrap(err, "could not load account")
+	}
+	var account *history.AccountEntry
+	if len(rows) > 0 {
+		account = &rows[0]
+	}
+	s.accounts[address] = account
+	return account, nil
+}
+
+func (s *simulation) accountSigners(address string) ([]history.AccountSigner, error) {
+	if signers, ok := s.signers[address]; ok {
+		return signers, nil
+	}
+	signers, err := s.state.GetAccountSignersByAccountID(s.ctx, address)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not load account signers")
+	}
+	s.signers[address] = signers
+	return signers, nil
+}
+
+func trustLineKey(address string, asset xdr.Asset) (string, error) {
+	var key xdr.LedgerKey
+	if err := key.SetTrustline(xdr.MustAddress(address), asset.ToTrustLineAsset()); err != nil {
+		return "", errors.Wrap(err, "could not create trustline ledger key")
+	}
+	return key.MarshalBinaryBase64()
+}
+
+func (s *simulation) trustLine(address string, asset xdr.Asset) (*history.TrustLine, error) {
+	key, err := trustLineKey(address, asset)
+	if err != nil {
+		return nil, err
+	}
+	if trustLine, ok := s.trustLines[key]; ok {
+		return trustLine, nil
+	}
+	rows, err := s.state.GetTrustLinesByKeys(s.ctx, []string{key})
+	if err != nil {
+		return nil, errors.Wrap(err, "could not load trustline")
+	}
+	var trustLine *history.TrustLine
+	if len(rows) > 0 {
+		trustLine = &rows[0]
+	}
+	s.trustLines[key] = trustLine
+	return trustLine, nil
+}
+
+// availableBalance returns the native balance of an account which can be
+// spent without going below its minimum balance.
+func (s *simulation) availableBalance(account *history.AccountEntry) int64 {
+	subEntries := 2 + int64(account.NumSubEntries) + int64(account.NumSponsoring) - int64(account.NumSponsored)
+	return account.Balance - subEntries*s.ledger.BaseReserve - account.SellingLiabilities
+}
+
+func (s *simulation) feeBumpTransaction(networkPassphrase string, envelope xdr.TransactionEnvelope) (Result, error) {
+	hash, err := network.HashTransactionInEnvelope(envelope, networkPassphrase)
+	if err != nil {
+		return Result{}, errors.Wrap(err, "could not hash fee bump transaction")
+	}
+	inner := xdr.TransactionEnvelope{
+		Type: xdr.EnvelopeTypeEnvelopeTypeTx,
+		V1:   envelope.FeeBump.Tx.InnerTx.V1,
+	}
+	innerHash, err := network.HashTransactionInEnvelope(inner, networkPassphrase)
+	if err != nil {
+		return Result{}, errors.Wrap(err, "could not hash inner transaction")
+	}
+
+	fee := envelope.FeeBumpFee()
+	feeCharged := (int64(len(inner.Operations())) + 1) * s.ledger.BaseFee
+	result := Result{FeeCharged: feeCharged}
+	if fee < feeCharged {
+		result.TransactionCode = resultCode(xdr.TransactionResultCodeTxInsufficientFee)
+		return result, nil
+	}
+
+	feeSource := envelope.FeeBumpAccount().ToAccountId().Address()
+	account, err := s.account(feeSource)
+	if err != nil {
+		return Result{}, err
+	}
+	if account == nil {
+		result.TransactionCode = resultCode(xdr.TransactionResultCodeTxNoAccount)
+		return result, nil
+	}
+	checker := newSignatureChecker(hash, envelope.FeeBumpSignatures())
+	if ok, err := s.checkSignature(checker, feeSource, thresholdLow); err != nil {
+		return Result{}, err
+	} else if !ok {
+		result.TransactionCode = resultCode(xdr.TransactionResultCodeTxBadAuth)
+		return result, nil
+	}
+	if !checker.allUsed() {
+		result.TransactionCode = resultCode(xdr.TransactionResultCodeTxBadAuthExtra)
+		return result, nil
+	}
+	if s.availableBalance(account) < feeCharged {
+		result.TransactionCode = resultCode(xdr.TransactionResultCodeTxInsufficientBalance)
+		return result, nil
+	}
+	account.Balance -= feeCharged
+
+	// the fee of the inner transaction is paid by the fee bump transaction
+	innerResult, err := s.transaction(inner, innerHash, -1, 0)
+	if err != nil {
+		return Result{}, err
+	}
+	result.InnerTransactionCode = innerResult.TransactionCode
+	result.OperationCodes = innerResult.OperationCodes
+	result.UncheckedOperations = innerResult.UncheckedOperations
+	if innerResult.Successful() {
+		result.TransactionCode = resultCode(xdr.TransactionResultCodeTxFeeBumpInnerSuccess)
+	} else {
+		result.TransactionCode = resultCode(xdr.TransactionResultCodeTxFeeBumpInnerFailed)
+	}
+	return result, nil
+}
+
+// transaction simulates a transaction which pays the given fee, or whose fee
+// is paid by a fee bump transaction when fee is negative.
+func (s *simulation) transaction(envelope xdr.TransactionEnvelope, hash [32]byte, fee, feeCharged int64) (Result, error) {
+	result := Result{FeeCharged: feeCharged}
+	fail := func(code xdr.TransactionResultCode) (Result, error) {
+		result.TransactionCode = resultCode(code)
+		return result, nil
+	}
+
+	operations := envelope.Operations()
+	if len(operations) == 0 {
+		return fail(xdr.TransactionResultCodeTxMissingOperation)
+	}
+
+	nextLedger := s.ledger.Sequence + 1
+	closeTime := s.ledger.CloseTime.Unix()
+	if timeBounds := envelope.TimeBounds(); timeBounds != nil {
+		if int64(timeBounds.MinTime) > closeTime {
+			return fail(xdr.TransactionResultCodeTxTooEarly)
+		}
+		if timeBounds.MaxTime != 0 && int64(timeBounds.MaxTime) < closeTime {
+			return fail(xdr.TransactionResultCodeTxTooLate)
+		}
+	}
+	if ledgerBounds := envelope.LedgerBounds(); ledgerBounds != nil {
+		if uint32(ledgerBounds.MinLedger) > nextLedger {
+			return fail(xdr.TransactionResultCodeTxTooEarly)
+		}
+		if ledgerBounds.MaxLedger != 0 && uint32(ledgerBounds.MaxLedger) <= nextLedger {
+			return fail(xdr.TransactionResultCodeTxTooLate)
+		}
+	}
+
+	if fee >= 0 && fee < int64(len(operations))*s.ledger.BaseFee {
+		return fail(xdr.TransactionResultCodeTxInsufficientFee)
+	}
+
+	source := envelope.SourceAccount().ToAccountId().Address()
+	account, err := s.account(source)
+	if err != nil {
+		return Result{}, err
+	}
+	if account == nil {
+		return fail(xdr.TransactionResultCodeTxNoAccount)
+	}
+
+	seqNum := envelope.SeqNum()
+	if minSeqNum := envelope.MinSeqNum(); minSeqNum != nil {
+		if account.SequenceNumber < *minSeqNum || seqNum <= account.SequenceNumber {
+			return fail(xdr.TransactionResultCodeTxBadSeq)
+		}
+	} else if seqNum != account.SequenceNumber+1 {
+		return fail(xdr.TransactionResultCodeTxBadSeq)
+	}
+
+	checker := newSignatureChecker(hash, envelope.Signatures())
+	if ok, err := s.checkSignature(checker, source, thresholdLow); err != nil {
+		return Result{}, err
+	} else if !ok {
+		return fail(xdr.TransactionResultCodeTxBadAuth)
+	}
+
+	if fee >= 0 && s.availableBalance(account) < feeCharged {
+		return fail(xdr.TransactionResultCodeTxInsufficientBalance)
+	}
+
+	// the signatures of the operations are checked before any of them is
+	// applied
+	failed := false
+	result.OperationCodes = make([]string, len(operations))
+	for i, op := range operations {
+		result.OperationCodes[i] = codes.OpSuccess
+		code, err := s.checkOperationSignature(checker, source, op)
+		if err != nil {
+			return Result{}, err
+		}
+		if code != "" {
+			result.OperationCodes[i] = code
+			failed = true
+		}
+	}
+	if failed {
+		return fail(xdr.TransactionResultCodeTxFailed)
+	}
+	if !checker.allUsed() {
+		result.OperationCodes = nil
+		return fail(xdr.TransactionResultCodeTxBadAuthExtra)
+	}
+
+	if fee >= 0 {
+		account.Balance -= feeCharged
+	}
+	if seqNum > account.SequenceNumber {
+		account.SequenceNumber = seqNum
+	}
+
+	for i, op := range operations {
+		opSource := source
+		if op.SourceAccount != nil {
+			opSource = op.SourceAccount.ToAccountId().Address()
+		}
+		code, checked, err := s.applyOperation(opSource, op)
+		if err != nil {
+			return Result{}, err
+		}
+		if !checked {
+			result.UncheckedOperations = append(result.UncheckedOperations, i)
+		}
+		if code != codes.OpSuccess {
+			failed = true
+		}
+		result.OperationCodes[i] = code
+	}
+	if failed {
+		return fail(xdr.TransactionResultCodeTxFailed)
+	}
+	result.TransactionCode = resultCode(xdr.TransactionResultCodeTxSuccess)
+	return result, nil
+}
+
+// checkOperationSignature checks the signatures of the source account of an
+// operation and returns the result code of the operation when it fails.
+func (s *simulation) checkOperationSignature(checker *signatureChecker, source string, op xdr.Operation) (string, error) {
+	if op.SourceAccount != nil {
+		source = op.SourceAccount.ToAccountId().Address()
+	}
+	account, err := s.account(source)
+	if err != nil {
+		return "", err
+	}
+	if account == nil {
+		// the source account can be created by a previous operation, in
+		// which case the operation must be signed by its master key
+		if checker.checkMasterKey(source) {
+			return "", nil
+		}
+		return resultCode(xdr.OperationResultCodeOpNoAccount), nil
+	}
+	if ok, err := s.checkSignature(checker, source, operationThreshold(op)); err != nil {
+		return "", err
+	} else if !ok {
+		return resultCode(xdr.OperationResultCodeOpBadAuth), nil
+	}
+	return "", nil
+}
+
+type threshold int
+
+const (
+	thresholdLow threshold = iota
+	thresholdMedium
+	thresholdHigh
+)
+
+// operationThreshold returns the threshold of the signatures required by an
+// operation.
+func operationThreshold(op xdr.Operation) threshold {
+	switch op.Body.Type {
+	case xdr.OperationTypeAccountMerge:
+		return thresholdHigh
+	case xdr.OperationTypeSetOptions:
+		setOptions := op.Body.MustSetOptionsOp()
+		if setOptions.MasterWeight != nil || setOptions.LowThreshold != nil ||
+			setOptions.MedThreshold != nil || setOptions.HighThreshold != nil ||
+			setOptions.Signer != nil {
+			return thresholdHigh
+		}
+		return thresholdMedium
+	case xdr.OperationTypeAllowTrust,
+		xdr.OperationTypeBumpSequence,
+		xdr.OperationTypeSetTrustLineFlags,
+		xdr.OperationTypeClaimClaimableBalance,
+		xdr.OperationTypeInflation:
+		return thresholdLow
+	default:
+		return thresholdMedium
+	}
+}
+
+// checkSignature returns true when the signers of the account which signed
+// the transaction reach the threshold of the account.
+func (s *simulation) checkSignature(checker *signatureChecker, address string, level threshold) (bool, error) {
+	account, err := s.account(address)
+	if err != nil {
+		return false, err
+	}
+	signers, err := s.accountSigners(address)
+	if err != nil {
+		return false, err
+	}
+
+	needed := int32(account.ThresholdLow)
+	switch level {
+	case thresholdMedium:
+		needed = int32(account.ThresholdMedium)
+	case thresholdHigh:
+		needed = int32(account.ThresholdHigh)
+	}
+	return checker.check(signers, needed), nil
+}
+
+// signatureChecker matches the signatures of a transaction with the signers
+// of accounts and keeps track of the signatures which were used.
+type signatureChecker struct {
+	hash       [32]byte
+	signatures []xdr.DecoratedSignature
+	used       []bool
+}
+
+func newSignatureChecker(hash [32]byte, signatures []xdr.DecoratedSignature) *signatureChecker {
+	return &signatureChecker{
+		hash:       hash,
+		signatures: signatures,
+		used:       make([]bool, len(signatures)),
+	}
+}
+
+// check returns true when the weight of the signers which signed the
+// transaction reaches the needed weight. At least one signer must have signed
+// the transaction, even when the needed weight is 0.
+func (c *signatureChecker) check(signers []history.AccountSigner, needed int32) bool {
+	var total int32
+	for _, signer := range signers {
+		if !c.signed(signer.Signer) {
+			continue
+		}
+		weight := signer.Weight
+		if weight > math.MaxUint8 {
+			weight = math.MaxUint8
+		}
+		total += weight
+		if total >= needed {
+			return true
+		}
+	}
+	return false
+}
+
+// checkMasterKey returns true when the transaction is signed by the master
+// key of an account.
+func (c *signatureChecker) checkMasterKey(address string) bool {
+	return c.signed(address)
+}
+
+// signed returns true when the transaction is signed by a signer, marking the
+// matching signature as used.
+func (c *signatureChecker) signed(signer string) bool {
+	versionByte, err := strkey.Version(signer)
+	if err != nil {
+		return false
+	}
+	switch versionByte {
+	case strkey.VersionByteHashTx:
+		// pre-authorized transactions don't need a signature
+		hash, err := strkey.Decode(strkey.VersionByteHashTx, signer)
+		return err == nil && string(hash) == string(c.hash[:])
+	case strkey.VersionByteHashX:
+		hash, err := strkey.Decode(strkey.VersionByteHashX, signer)
+		if err != nil {
+			return false
+		}
+		for i, signature := range c.signatures {
+			preimage := sha256.Sum256(signature.Signature)
+			if string(preimage[:]) == string(hash) {
+				c.used[i] = true
+				return true
+			}
+		}
+	case strkey.VersionByteAccountID:
+		kp, err := keypair.ParseAddress(signer)
+		if err != nil {
+			return false
+		}
+		hint := kp.Hint()
+		for i, signature := range c.signatures {
+			if signature.Hint != xdr.SignatureHint(hint) {
+				continue
+			}
+			if kp.Verify(c.hash[:], signature.Signature) == nil {
+				c.used[i] = true
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// allUsed returns true when every signature of the transaction was used.
+func (c *signatureChecker) allUsed() bool {
+	for _, used := range c.used {
+		if !used {
+			return false
+		}
+	}
+	return true
+}
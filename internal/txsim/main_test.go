@@ -0,0 +1,289 @@
+package txsim
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/stellar/go-stellar-sdk/keypair"
+	"github.com/stellar/go-stellar-sdk/network"
+	"github.com/stellar/go-stellar-sdk/txnbuild"
+	"github.com/stellar/go-stellar-sdk/xdr"
+	"github.com/stellar/stellar-horizon/internal/db2/history"
+)
+
+type testState struct {
+	accounts   map[string]history.AccountEntry
+	trustLines map[string]history.TrustLine
+}
+
+func (s *testState) GetAccountsByIDs(ctx context.Context, ids []string) ([]history.AccountEntry, error) {
+	var rows []history.AccountEntry
+	for _, id := range ids {
+		if account, ok := s.accounts[id]; ok {
+			rows = append(rows, account)
+		}
+	}
+	return rows, nil
+}
+
+func (s *testState) GetAccountSignersByAccountID(ctx context.Context, id string) ([]history.AccountSigner, error) {
+	account, ok := s.accounts[id]
+	if !ok || account.MasterWeight == 0 {
+		return nil, nil
+	}
+	return []history.AccountSigner{{Account: id, Signer: id, Weight: int32(account.MasterWeight)}}, nil
+}
+
+func (s *testState) GetTrustLinesByKeys(ctx context.Context, ledgerKeys []string) ([]history.TrustLine, error) {
+	var rows []history.TrustLine
+	for _, key := range ledgerKeys {
+		if trustLine, ok := s.trustLines[key]; ok {
+			rows = append(rows, trustLine)
+		}
+	}
+	return rows, nil
+}
+
+func (s *testState) addAccount(kp *keypair.Full, balance int64) {
+	s.accounts[kp.Address()] = history.AccountEntry{
+		AccountID:      kp.Address(),
+		Balance:        balance,
+		SequenceNumber: 100,
+		MasterWeight:   1,
+	}
+}
+
+func (s *testState) addTrustLine(t *testing.T, kp *keypair.Full, asset xdr.Asset, balance int64, flags uint32) {
+	key, err := trustLineKey(kp.Address(), asset)
+	require.NoError(t, err)
+	s.trustLines[key] = history.TrustLine{
+		AccountID: kp.Address(),
+		Balance:   balance,
+		Limit:     1000_0000000,
+		Flags:     flags,
+		LedgerKey: key,
+	}
+}
+
+var (
+	testSource      = keypair.Master("source").(*keypair.Full)
+	testDestination = keypair.Master("destination").(*keypair.Full)
+	testIssuer      = keypair.Master("issuer").(*keypair.Full)
+	testLedger      = Ledger{
+		Sequence:    1000,
+		CloseTime:   time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC),
+		BaseFee:     100,
+		BaseReserve: 5000000,
+	}
+)
+
+func newTestState() *testState {
+	state := &testState{
+		accounts:   map[string]history.AccountEntry{},
+		trustLines: map[string]history.TrustLine{},
+	}
+	state.addAccount(testSource, 100_0000000)
+	state.addAccount(testDestination, 10_0000000)
+	state.addAccount(testIssuer, 10_0000000)
+	return state
+}
+
+func buildTransaction(t *testing.T, sequence int64, operations []txnbuild.Operation, signers ...*keypair.Full) *txnbuild.Transaction {
+	tx, err := txnbuild.NewTransaction(txnbuild.TransactionParams{
+		SourceAccount: &txnbuild.SimpleAccount{AccountID: testSource.Address(), Sequence: sequence},
+		Operations:    operations,
+		BaseFee:       txnbuild.MinBaseFee,
+		Preconditions: txnbuild.Preconditions{TimeBounds: txnbuild.NewInfiniteTimeout()},
+	})
+	require.NoError(t, err)
+	tx, err = tx.Sign(network.TestNetworkPassphrase, signers...)
+	require.NoError(t, err)
+	return tx
+}
+
+func simulate(t *testing.T, state State, envelope xdr.TransactionEnvelope) Result {
+	result, err := Simulate(context.Background(), state, testLedger, network.TestNetworkPassphrase, envelope)
+	require.NoError(t, err)
+	return result
+}
+
+func TestSimulateTransaction(t *testing.T) {
+	usd := txnbuild.CreditAsset{Code: "USD", Issuer: testIssuer.Address()}
+	newAccount := keypair.Master("new account").(*keypair.Full)
+
+	for _, tc := range []struct {
+		name             string
+		sequence         int64
+		operations       []txnbuild.Operation
+		signers          []*keypair.Full
+		transactionCode  string
+		operationCodes   []string
+		uncheckedIndexes []int
+	}{
+		{
+			name:     "native payment",
+			sequence: 101,
+			operations: []txnbuild.Operation{
+				&txnbuild.Payment{Destination: testDestination.Address(), Amount: "10", Asset: txnbuild.NativeAsset{}},
+			},
+			signers:         []*keypair.Full{testSource},
+			transactionCode: "tx_success",
+			operationCodes:  []string{"op_success"},
+		},
+		{
+			name:     "bad sequence",
+			sequence: 102,
+			operations: []txnbuild.Operation{
+				&txnbuild.Payment{Destination: testDestination.Address(), Amount: "10", Asset: txnbuild.NativeAsset{}},
+			},
+			signers:         []*keypair.Full{testSource},
+			transactionCode: "tx_bad_seq",
+		},
+		{
+			name:     "bad auth",
+			sequence: 101,
+			operations: []txnbuild.Operation{
+				&txnbuild.Payment{Destination: testDestination.Address(), Amount: "10", Asset: txnbuild.NativeAsset{}},
+			},
+			signers:         []*keypair.Full{testDestination},
+			transactionCode: "tx_bad_auth",
+		},
+		{
+			name:     "extra signature",
+			sequence: 101,
+			operations: []txnbuild.Operation{
+				&txnbuild.Payment{Destination: testDestination.Address(), Amount: "10", Asset: txnbuild.NativeAsset{}},
+			},
+			signers:         []*keypair.Full{testSource, testDestination},
+			transactionCode: "tx_bad_auth_extra",
+		},
+		{
+			name:     "operation of another account without its signature",
+			sequence: 101,
+			operations: []txnbuild.Operation{
+				&txnbuild.Payment{
+					Destination:   testSource.Address(),
+					Amount:        "1",
+					Asset:         txnbuild.NativeAsset{},
+					SourceAccount: testDestination.Address(),
+				},
+			},
+			signers:         []*keypair.Full{testSource},
+			transactionCode: "tx_failed",
+			operationCodes:  []string{"op_bad_auth"},
+		},
+		{
+			name:     "underfunded and missing trustlines",
+			sequence: 101,
+			operations: []txnbuild.Operation{
+				&txnbuild.Payment{Destination: testDestination.Address(), Amount: "99", Asset: txnbuild.NativeAsset{}},
+				&txnbuild.Payment{Destination: testDestination.Address(), Amount: "1", Asset: usd},
+				&txnbuild.Payment{Destination: testIssuer.Address(), Amount: "1", Asset: usd},
+				&txnbuild.Payment{Destination: newAccount.Address(), Amount: "1", Asset: txnbuild.NativeAsset{}},
+			},
+			signers:         []*keypair.Full{testSource},
+			transactionCode: "tx_failed",
+			operationCodes:  []string{"op_underfunded", "op_no_trust", "op_src_no_trust", "op_no_destination"},
+		},
+		{
+			name:     "create an account and pay from it",
+			sequence: 101,
+			operations: []txnbuild.Operation{
+				&txnbuild.CreateAccount{Destination: newAccount.Address(), Amount: "5"},
+				&txnbuild.Payment{
+					Destination:   testDestination.Address(),
+					Amount:        "1",
+					Asset:         txnbuild.NativeAsset{},
+					SourceAccount: newAccount.Address(),
+				},
+				&txnbuild.ManageData{Name: "key", Value: []byte("value")},
+			},
+			signers:          []*keypair.Full{testSource, newAccount},
+			transactionCode:  "tx_success",
+			operationCodes:   []string{"op_success", "op_success", "op_success"},
+			uncheckedIndexes: []int{2},
+		},
+		{
+			name:     "trustline and payment of an issued asset",
+			sequence: 101,
+			operations: []txnbuild.Operation{
+				&txnbuild.ChangeTrust{Line: usd.MustToChangeTrustAsset(), Limit: "100"},
+				&txnbuild.Payment{
+					Destination:   testSource.Address(),
+					Amount:        "50",
+					Asset:         usd,
+					SourceAccount: testIssuer.Address(),
+				},
+				&txnbuild.Payment{
+					Destination:   testSource.Address(),
+					Amount:        "51",
+					Asset:         usd,
+					SourceAccount: testIssuer.Address(),
+				},
+			},
+			signers:         []*keypair.Full{testSource, testIssuer},
+			transactionCode: "tx_failed",
+			operationCodes:  []string{"op_success", "op_success", "op_line_full"},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			tx := buildTransaction(t, tc.sequence, tc.operations, tc.signers...)
+			result := simulate(t, newTestState(), tx.ToXDR())
+			assert.Equal(t, tc.transactionCode, result.TransactionCode)
+			assert.Equal(t, tc.operationCodes, result.OperationCodes)
+			assert.Equal(t, tc.uncheckedIndexes, result.UncheckedOperations)
+			assert.Equal(t, int64(len(tc.operations))*testLedger.BaseFee, result.FeeCharged)
+		})
+	}
+}
+
+func TestSimulateUnauthorizedTrustLine(t *testing.T) {
+	usd := txnbuild.CreditAsset{Code: "USD", Issuer: testIssuer.Address()}
+	usdXDR := xdr.MustNewCreditAsset("USD", testIssuer.Address())
+	state := newTestState()
+	state.addTrustLine(t, testSource, usdXDR, 10_0000000, uint32(xdr.TrustLineFlagsAuthorizedFlag))
+	state.addTrustLine(t, testDestination, usdXDR, 0, 0)
+
+	tx := buildTransaction(t, 101, []txnbuild.Operation{
+		&txnbuild.Payment{Destination: testDestination.Address(), Amount: "1", Asset: usd},
+		&txnbuild.Payment{Destination: testIssuer.Address(), Amount: "11", Asset: usd},
+		&txnbuild.Payment{Destination: testIssuer.Address(), Amount: "10", Asset: usd},
+	}, testSource)
+	result := simulate(t, state, tx.ToXDR())
+	assert.Equal(t, "tx_failed", result.TransactionCode)
+	assert.Equal(t, []string{"op_not_authorized", "op_underfunded", "op_success"}, result.OperationCodes)
+}
+
+func TestSimulateFeeBumpTransaction(t *testing.T) {
+	state := newTestState()
+	inner := buildTransaction(t, 101, []txnbuild.Operation{
+		&txnbuild.Payment{Destination: testDestination.Address(), Amount: "1000", Asset: txnbuild.NativeAsset{}},
+	}, testSource)
+	feeBump, err := txnbuild.NewFeeBumpTransaction(txnbuild.FeeBumpTransactionParams{
+		Inner:      inner,
+		FeeAccount: testDestination.Address(),
+		BaseFee:    txnbuild.MinBaseFee,
+	})
+	require.NoError(t, err)
+	feeBump, err = feeBump.Sign(network.TestNetworkPassphrase, testDestination)
+	require.NoError(t, err)
+
+	result := simulate(t, state, feeBump.ToXDR())
+	assert.Equal(t, "tx_fee_bump_inner_failed", result.TransactionCode)
+	assert.Equal(t, "tx_failed", result.InnerTransactionCode)
+	assert.Equal(t, []string{"op_underfunded"}, result.OperationCodes)
+	assert.Equal(t, int64(200), result.FeeCharged)
+	assert.False(t, result.Successful())
+}
+
+func TestSimulateSorobanTransaction(t *testing.T) {
+	tx := buildTransaction(t, 101, []txnbuild.Operation{
+		&txnbuild.ExtendFootprintTtl{ExtendTo: 100},
+	}, testSource)
+	_, err := Simulate(context.Background(), newTestState(), testLedger, network.TestNetworkPassphrase, tx.ToXDR())
+	assert.Equal(t, ErrSorobanNotSupported, err)
+}
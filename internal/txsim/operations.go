@@ -0,0 +1,268 @@
+package txsim
+
+import (
+	"math"
+
+	"github.com/guregu/null/zero"
+
+	"github.com/stellar/go-stellar-sdk/xdr"
+	"github.com/stellar/stellar-horizon/internal/codes"
+	"github.com/stellar/stellar-horizon/internal/db2/history"
+)
+
+// applyOperation applies an operation to the simulated state when it
+// succeeds. It returns the result code of the operation and false when the
+// operation type isn't simulated, in which case it is successful when its
+// source account exists.
+func (s *simulation) applyOperation(source string, op xdr.Operation) (string, bool, error) {
+	account, err := s.account(source)
+	if err != nil {
+		return "", false, err
+	}
+	if account == nil {
+		return resultCode(xdr.OperationResultCodeOpNoAccount), true, nil
+	}
+
+	switch op.Body.Type {
+	case xdr.OperationTypeCreateAccount:
+		code, err := s.createAccount(account, op.Body.MustCreateAccountOp())
+		return code, true, err
+	case xdr.OperationTypePayment:
+		code, err := s.payment(account, op.Body.MustPaymentOp())
+		return code, true, err
+	case xdr.OperationTypeChangeTrust:
+		changeTrust := op.Body.MustChangeTrustOp()
+		if changeTrust.Line.Type == xdr.AssetTypeAssetTypePoolShare {
+			return codes.OpSuccess, false, nil
+		}
+		code, err := s.changeTrust(account, changeTrust)
+		return code, true, err
+	case xdr.OperationTypeAccountMerge:
+		code, err := s.accountMerge(account, op.Body.MustDestination())
+		return code, true, err
+	case xdr.OperationTypeBumpSequence:
+		bumpTo := int64(op.Body.MustBumpSequenceOp().BumpTo)
+		if bumpTo < 0 {
+			return resultCode(xdr.BumpSequenceResultCodeBumpSequenceBadSeq), true, nil
+		}
+		if bumpTo > account.SequenceNumber {
+			account.SequenceNumber = bumpTo
+		}
+		return codes.OpSuccess, true, nil
+	default:
+		return codes.OpSuccess, false, nil
+	}
+}
+
+func (s *simulation) createAccount(source *history.AccountEntry, op xdr.CreateAccountOp) (string, error) {
+	destination := op.Destination.Address()
+	startingBalance := int64(op.StartingBalance)
+	if startingBalance < 0 || destination == source.AccountID {
+		return resultCode(xdr.CreateAccountResultCodeCreateAccountMalformed), nil
+	}
+
+	account, err := s.account(destination)
+	if err != nil {
+		return "", err
+	}
+	if account != nil {
+		return resultCode(xdr.CreateAccountResultCodeCreateAccountAlreadyExist), nil
+	}
+	if s.availableBalance(source) < startingBalance {
+		return resultCode(xdr.CreateAccountResultCodeCreateAccountUnderfunded), nil
+	}
+	if startingBalance < 2*s.ledger.BaseReserve {
+		return resultCode(xdr.CreateAccountResultCodeCreateAccountLowReserve), nil
+	}
+
+	source.Balance -= startingBalance
+	s.accounts[destination] = &history.AccountEntry{
+		AccountID:      destination,
+		Balance:        startingBalance,
+		SequenceNumber: int64(s.ledger.Sequence+1) << 32,
+		SequenceLedger: zero.IntFrom(int64(s.ledger.Sequence + 1)),
+		MasterWeight:   1,
+	}
+	s.signers[destination] = []history.AccountSigner{
+		{Account: destination, Signer: destination, Weight: 1},
+	}
+	return codes.OpSuccess, nil
+}
+
+func (s *simulation) payment(source *history.AccountEntry, op xdr.PaymentOp) (string, error) {
+	destination := op.Destination.ToAccountId().Address()
+	amount := int64(op.Amount)
+	if amount <= 0 {
+		return resultCode(xdr.PaymentResultCodePaymentMalformed), nil
+	}
+
+	destinationAccount, err := s.account(destination)
+	if err != nil {
+		return "", err
+	}
+	if destinationAccount == nil {
+		return resultCode(xdr.PaymentResultCodePaymentNoDestination), nil
+	}
+
+	if op.Asset.Type == xdr.AssetTypeAssetTypeNative {
+		if destinationAccount.Balance > math.MaxInt64-destinationAccount.BuyingLiabilities-amount {
+			return resultCode(xdr.PaymentResultCodePaymentLineFull), nil
+		}
+		if s.availableBalance(source) < amount {
+			return resultCode(xdr.PaymentResultCodePaymentUnderfunded), nil
+		}
+		source.Balance -= amount
+		destinationAccount.Balance += amount
+		return codes.OpSuccess, nil
+	}
+
+	// the destination is credited before the source is debited, like
+	// Stellar Core does. Issuers have no trustline to their own assets.
+	issuer := op.Asset.GetIssuer()
+	var destinationLine, sourceLine *history.TrustLine
+	if destination != issuer {
+		if destinationLine, err = s.trustLine(destination, op.Asset); err != nil {
+			return "", err
+		}
+		switch {
+		case destinationLine == nil:
+			return resultCode(xdr.PaymentResultCodePaymentNoTrust), nil
+		case !destinationLine.IsAuthorized():
+			return resultCode(xdr.PaymentResultCodePaymentNotAuthorized), nil
+		case destinationLine.Balance > destinationLine.Limit-destinationLine.BuyingLiabilities-amount:
+			return resultCode(xdr.PaymentResultCodePaymentLineFull), nil
+		}
+	}
+	if source.AccountID != issuer {
+		if sourceLine, err = s.trustLine(source.AccountID, op.Asset); err != nil {
+			return "", err
+		}
+		switch {
+		case sourceLine == nil:
+			return resultCode(xdr.PaymentResultCodePaymentSrcNoTrust), nil
+		case !sourceLine.IsAuthorized():
+			return resultCode(xdr.PaymentResultCodePaymentSrcNotAuthorized), nil
+		case sourceLine.Balance-sourceLine.SellingLiabilities < amount:
+			return resultCode(xdr.PaymentResultCodePaymentUnderfunded), nil
+		}
+	}
+
+	if destinationLine != nil {
+		destinationLine.Balance += amount
+	}
+	if sourceLine != nil {
+		sourceLine.Balance -= amount
+	}
+	return codes.OpSuccess, nil
+}
+
+func (s *simulation) changeTrust(source *history.AccountEntry, op xdr.ChangeTrustOp) (string, error) {
+	limit := int64(op.Limit)
+	if op.Line.Type == xdr.AssetTypeAssetTypeNative || limit < 0 {
+		return resultCode(xdr.ChangeTrustResultCodeChangeTrustMalformed), nil
+	}
+	asset := op.Line.ToAsset()
+	issuer := asset.GetIssuer()
+	if issuer == source.AccountID {
+		return resultCode(xdr.ChangeTrustResultCodeChangeTrustSelfNotAllowed), nil
+	}
+
+	key, err := trustLineKey(source.AccountID, asset)
+	if err != nil {
+		return "", err
+	}
+	trustLine, err := s.trustLine(source.AccountID, asset)
+	if err != nil {
+		return "", err
+	}
+
+	if trustLine != nil {
+		if limit < trustLine.Balance+trustLine.BuyingLiabilities {
+			return resultCode(xdr.ChangeTrustResultCodeChangeTrustInvalidLimit), nil
+		}
+		if limit == 0 {
+			s.trustLines[key] = nil
+			source.NumSubEntries--
+			return codes.OpSuccess, nil
+		}
+		trustLine.Limit = limit
+		return codes.OpSuccess, nil
+	}
+
+	if limit == 0 {
+		return resultCode(xdr.ChangeTrustResultCodeChangeTrustInvalidLimit), nil
+	}
+	issuerAccount, err := s.account(issuer)
+	if err != nil {
+		return "", err
+	}
+	if issuerAccount == nil {
+		return resultCode(xdr.ChangeTrustResultCodeChangeTrustNoIssuer), nil
+	}
+	if s.availableBalance(source) < s.ledger.BaseReserve {
+		return resultCode(xdr.ChangeTrustResultCodeChangeTrustLowReserve), nil
+	}
+
+	var flags uint32
+	if xdr.AccountFlags(issuerAccount.Flags)&xdr.AccountFlagsAuthRequiredFlag == 0 {
+		flags = uint32(xdr.TrustLineFlagsAuthorizedFlag)
+	}
+	s.trustLines[key] = &history.TrustLine{
+		AccountID:   source.AccountID,
+		AssetType:   asset.Type,
+		AssetIssuer: issuer,
+		AssetCode:   asset.GetCode(),
+		LedgerKey:   key,
+		Limit:       limit,
+		Flags:       flags,
+	}
+	source.NumSubEntries++
+	return codes.OpSuccess, nil
+}
+
+func (s *simulation) accountMerge(source *history.AccountEntry, muxedDestination xdr.MuxedAccount) (string, error) {
+	destination := muxedDestination.ToAccountId().Address()
+	if destination == source.AccountID {
+		return resultCode(xdr.AccountMergeResultCodeAccountMergeMalformed), nil
+	}
+
+	destinationAccount, err := s.account(destination)
+	if err != nil {
+		return "", err
+	}
+	if destinationAccount == nil {
+		return resultCode(xdr.AccountMergeResultCodeAccountMergeNoAccount), nil
+	}
+	if xdr.AccountFlags(source.Flags)&xdr.AccountFlagsAuthImmutableFlag != 0 {
+		return resultCode(xdr.AccountMergeResultCodeAccountMergeImmutableSet), nil
+	}
+
+	// signers are the only sub entries merged accounts can have
+	signers, err := s.accountSigners(source.AccountID)
+	if err != nil {
+		return "", err
+	}
+	numSigners := uint32(0)
+	for _, signer := range signers {
+		if signer.Signer != source.AccountID {
+			numSigners++
+		}
+	}
+	if source.NumSubEntries > numSigners {
+		return resultCode(xdr.AccountMergeResultCodeAccountMergeHasSubEntries), nil
+	}
+	if source.SequenceNumber >= int64(s.ledger.Sequence+1)<<32 {
+		return resultCode(xdr.AccountMergeResultCodeAccountMergeSeqnumTooFar), nil
+	}
+	if source.NumSponsoring > 0 {
+		return resultCode(xdr.AccountMergeResultCodeAccountMergeIsSponsor), nil
+	}
+	if destinationAccount.Balance > math.MaxInt64-destinationAccount.BuyingLiabilities-source.Balance {
+		return resultCode(xdr.AccountMergeResultCodeAccountMergeDestFull), nil
+	}
+
+	destinationAccount.Balance += source.Balance
+	s.accounts[source.AccountID] = nil
+	delete(s.signers, source.AccountID)
+	return codes.OpSuccess, nil
+}
@@ -0,0 +1,95 @@
+package txsub
+
+import (
+	"container/list"
+	"sync"
+	"time"
+
+	"github.com/stellar/stellar-horizon/internal/db2/history"
+)
+
+// AsyncSubmission is a transaction submitted to Stellar Core through the
+// async submission endpoint.
+type AsyncSubmission struct {
+	Hash string
+	// CoreStatus is the submission status returned by Stellar Core.
+	CoreStatus string
+	// ErrorResultXDR is the transaction result returned by Stellar Core when
+	// it rejected the transaction.
+	ErrorResultXDR string
+	SubmittedAt    time.Time
+	TimeBounds     history.TimeBounds
+	LedgerBounds   history.LedgerBounds
+}
+
+// AsyncSubmissions keeps track of the latest transactions submitted through
+// the async submission endpoint. It keeps at most a fixed number of
+// submissions, forgetting the oldest ones first.
+type AsyncSubmissions struct {
+	sync.Mutex
+	maxSize     int
+	submissions map[string]*list.Element // hash => element of order
+	order       *list.List               // submissions from the oldest
+}
+
+// DefaultMaxAsyncSubmissions is the default number of submissions kept by
+// AsyncSubmissions.
+const DefaultMaxAsyncSubmissions = 100000
+
+// NewAsyncSubmissions returns an AsyncSubmissions keeping track of at most
+// maxSize submissions, or DefaultMaxAsyncSubmissions when maxSize isn't
+// positive.
+func NewAsyncSubmissions(maxSize int) *AsyncSubmissions {
+	if maxSize <= 0 {
+		maxSize = DefaultMaxAsyncSubmissions
+	}
+	return &AsyncSubmissions{
+		maxSize:     maxSize,
+		submissions: map[string]*list.Element{},
+		order:       list.New(),
+	}
+}
+
+// Add records a submission, replacing a previous submission of the same
+// transaction.
+func (s *AsyncSubmissions) Add(submission AsyncSubmission) {
+	s.Lock()
+	defer s.Unlock()
+
+	if element, ok := s.submissions[submission.Hash]; ok {
+		s.order.Remove(element)
+	}
+	s.submissions[submission.Hash] = s.order.PushBack(submission)
+
+	for s.order.Len() > s.maxSize {
+		oldest := s.order.Front()
+		s.order.Remove(oldest)
+		delete(s.submissions, oldest.Value.(AsyncSubmission).Hash)
+	}
+}
+
+// Get returns the submission of a transaction, and false when it isn't
+// tracked.
+func (s *AsyncSubmissions) Get(hash string) (AsyncSubmission, bool) {
+	s.Lock()
+	defer s.Unlock()
+
+	element, ok := s.submissions[hash]
+	if !ok {
+		return AsyncSubmission{}, false
+	}
+	return element.Value.(AsyncSubmission), true
+}
+
+// Expired returns true when the submitted transaction can't be included in
+// the ledgers following the given one because of its time or ledger bounds.
+func (s AsyncSubmission) Expired(ledgerSequence int32, closedAt time.Time) bool {
+	if !s.TimeBounds.Null && s.TimeBounds.Upper.Valid && closedAt.Unix() > s.TimeBounds.Upper.Int64 {
+		return true
+	}
+	// transactions are valid in ledgers strictly before max_ledger
+	if !s.LedgerBounds.Null && s.LedgerBounds.MaxLedger.Valid && int64(ledgerSequence)+1 >= s.LedgerBounds.MaxLedger.Int64 {
+		return true
+	}
+	return false
+}
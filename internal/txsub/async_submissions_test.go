@@ -0,0 +1,54 @@
+package txsub
+
+import (
+	"testing"
+	"time"
+
+	"github.com/guregu/null"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/stellar/stellar-horizon/internal/db2/history"
+)
+
+func TestAsyncSubmissionsEvictsOldest(t *testing.T) {
+	submissions := NewAsyncSubmissions(2)
+	submissions.Add(AsyncSubmission{Hash: "a", CoreStatus: "PENDING"})
+	submissions.Add(AsyncSubmission{Hash: "b", CoreStatus: "PENDING"})
+	submissions.Add(AsyncSubmission{Hash: "a", CoreStatus: "DUPLICATE"})
+	submissions.Add(AsyncSubmission{Hash: "c", CoreStatus: "ERROR"})
+
+	_, ok := submissions.Get("b")
+	assert.False(t, ok)
+
+	submission, ok := submissions.Get("a")
+	assert.True(t, ok)
+	assert.Equal(t, "DUPLICATE", submission.CoreStatus)
+
+	submission, ok = submissions.Get("c")
+	assert.True(t, ok)
+	assert.Equal(t, "ERROR", submission.CoreStatus)
+}
+
+func TestAsyncSubmissionExpired(t *testing.T) {
+	closedAt := time.Unix(1000, 0)
+
+	submission := AsyncSubmission{
+		TimeBounds:   history.TimeBounds{Null: true},
+		LedgerBounds: history.LedgerBounds{Null: true},
+	}
+	assert.False(t, submission.Expired(100, closedAt))
+
+	submission.TimeBounds = history.TimeBounds{Lower: null.IntFrom(0), Upper: null.IntFrom(1000)}
+	assert.False(t, submission.Expired(100, closedAt))
+	assert.True(t, submission.Expired(100, closedAt.Add(time.Second)))
+
+	submission.TimeBounds = history.TimeBounds{Lower: null.IntFrom(0)}
+	submission.LedgerBounds = history.LedgerBounds{MinLedger: null.IntFrom(0), MaxLedger: null.IntFrom(102)}
+	assert.False(t, submission.Expired(100, closedAt))
+	assert.True(t, submission.Expired(101, closedAt))
+}
+
+func TestAsyncSubmissionsDefaultSize(t *testing.T) {
+	assert.Equal(t, DefaultMaxAsyncSubmissions, NewAsyncSubmissions(0).maxSize)
+	assert.Equal(t, 10, NewAsyncSubmissions(10).maxSize)
+}
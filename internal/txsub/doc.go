@@ -9,4 +9,7 @@ package txsub
 // - errors.go: error definitions exposed by txsub
 // - system.go: txsub.System, the struct that ties all the interfaces together
 // - open_submission_list.go: A default implementation of the OpenSubmissionList interface
+// - shared_submission_list.go: An OpenSubmissionList shared by the instances using the same database
 // - submitter.go: A default implementation of the Submitter interface
+// - fee_bumps.go: rebroadcast of pending submissions with fee bump transactions
+// - async_submissions.go: tracking of the transactions submitted asynchronously
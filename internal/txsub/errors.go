@@ -14,6 +14,10 @@ var (
 	ErrCanceled  = errors.New("canceled")
 	ErrTimeout   = errors.New("timeout")
 
+	// ErrFeeBumpPolicyRegistered is the error of a SubmitWithFeeBumps call for
+	// a transaction whose fee bump policy is registered by a pending call.
+	ErrFeeBumpPolicyRegistered = errors.New("fee bump policy already registered")
+
 	// ErrBadSequence is a canned error response for transactions whose sequence
 	// number is wrong.
 	ErrBadSequence = &FailedTransactionError{"AAAAAAAAAAD////7AAAAAA==", ""}
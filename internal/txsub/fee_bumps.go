@@ -0,0 +1,259 @@
+package txsub
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/stellar/go-stellar-sdk/support/errors"
+	"github.com/stellar/go-stellar-sdk/support/log"
+	"github.com/stellar/go-stellar-sdk/xdr"
+	"github.com/stellar/stellar-horizon/internal/operationfeestats"
+)
+
+// FeeBumpPercentiles are the percentiles of the fee stats a FeeBumpPolicy can
+// use.
+var FeeBumpPercentiles = []int{10, 20, 30, 40, 50, 60, 70, 80, 90, 95, 99}
+
+// FeeBumpPolicy is the opt-in policy of a submission which is rebroadcast with
+// pre-signed fee bump transactions while it's pending with a fee below the fees
+// recently charged on the network.
+type FeeBumpPolicy struct {
+	// Percentile is the percentile of the fees charged per operation in the
+	// recent ledgers (see operationfeestats) the fee of the pending
+	// transaction is compared to.
+	Percentile int
+	// Envelopes are the fee bump transactions wrapping the submitted
+	// transaction, which are broadcast by increasing fee.
+	Envelopes []FeeBumpEnvelope
+}
+
+// FeeBumpEnvelope is a pre-signed fee bump transaction of a FeeBumpPolicy.
+type FeeBumpEnvelope struct {
+	Raw      string
+	Envelope xdr.TransactionEnvelope
+	Hash     string
+}
+
+// FeeBumpAttempt is a rebroadcast of a submission with a fee bump
+// transaction.
+type FeeBumpAttempt struct {
+	Hash            string
+	FeePerOperation int64
+	SubmittedAt     time.Time
+	// Err is the error of the submission to Stellar Core, nil when the fee bump
+	// transaction was accepted.
+	Err error
+}
+
+// feePerOperation returns the fee per operation offered by a transaction, the
+// fee bump transaction counting as an operation.
+func feePerOperation(envelope xdr.TransactionEnvelope) int64 {
+	if envelope.IsFeeBump() {
+		return envelope.FeeBumpFee() / int64(len(envelope.Operations())+1)
+	}
+	if len(envelope.Operations()) == 0 {
+		return 0
+	}
+	return int64(envelope.Fee()) / int64(len(envelope.Operations()))
+}
+
+// feeChargedPercentile returns a percentile of the fees charged per operation
+// in the recent ledgers.
+func feeChargedPercentile(state operationfeestats.State, percentile int) (int64, error) {
+	switch percentile {
+	case 10:
+		return state.FeeChargedP10, nil
+	case 20:
+		return state.FeeChargedP20, nil
+	case 30:
+		return state.FeeChargedP30, nil
+	case 40:
+		return state.FeeChargedP40, nil
+	case 50:
+		return state.FeeChargedP50, nil
+	case 60:
+		return state.FeeChargedP60, nil
+	case 70:
+		return state.FeeChargedP70, nil
+	case 80:
+		return state.FeeChargedP80, nil
+	case 90:
+		return state.FeeChargedP90, nil
+	case 95:
+		return state.FeeChargedP95, nil
+	case 99:
+		return state.FeeChargedP99, nil
+	default:
+		return 0, errors.Errorf("unsupported fee percentile %d", percentile)
+	}
+}
+
+// feeBumpResubmission tracks the fee bump transactions broadcast for a
+// pending submission. It's guarded by System.feeBumpsMutex.
+type feeBumpResubmission struct {
+	policy FeeBumpPolicy
+	// fee per operation of the last transaction broadcast
+	fee int64
+	// index of the next envelope of the policy which can be broadcast
+	next     int
+	attempts []FeeBumpAttempt
+}
+
+// nextEnvelope returns the index of the envelope to broadcast when the fee of
+// the last transaction broadcast is below the target fee, or -1. It's the
+// first envelope reaching the target fee, or the envelope with the highest fee.
+func (r *feeBumpResubmission) nextEnvelope(target int64) int {
+	if r.fee >= target || r.next >= len(r.policy.Envelopes) {
+		return -1
+	}
+	for i := r.next; i < len(r.policy.Envelopes); i++ {
+		if feePerOperation(r.policy.Envelopes[i].Envelope) >= target {
+			return i
+		}
+	}
+	return len(r.policy.Envelopes) - 1
+}
+
+// SubmitWithFeeBumps submits a transaction like Submit and, while it's
+// pending, rebroadcasts it with the fee bump transactions of the policy when
+// its fee is below the fee percentile of the policy. The hash is the hash of
+// the inner transaction: the fee bump transactions have their own hashes, but
+// the ingested transactions are also matched by their inner transaction hash.
+// The result reports every fee bump transaction broadcast. The result error is
+// ErrFeeBumpPolicyRegistered when a policy is already registered for the hash
+// by a pending call.
+func (sys *System) SubmitWithFeeBumps(
+	ctx context.Context,
+	rawTx string,
+	envelope xdr.TransactionEnvelope,
+	hash string,
+	policy FeeBumpPolicy,
+) <-chan Result {
+	sys.Init()
+
+	envelopes := append([]FeeBumpEnvelope{}, policy.Envelopes...)
+	sort.SliceStable(envelopes, func(i, j int) bool {
+		return feePerOperation(envelopes[i].Envelope) < feePerOperation(envelopes[j].Envelope)
+	})
+	policy.Envelopes = envelopes
+	resubmission := &feeBumpResubmission{
+		policy: policy,
+		fee:    feePerOperation(envelope),
+	}
+
+	resultCh := make(chan Result, 1)
+	sys.feeBumpsMutex.Lock()
+	if _, ok := sys.feeBumps[hash]; ok {
+		sys.feeBumpsMutex.Unlock()
+		resultCh <- Result{Err: ErrFeeBumpPolicyRegistered}
+		close(resultCh)
+		return resultCh
+	}
+	sys.feeBumps[hash] = resubmission
+	sys.feeBumpsMutex.Unlock()
+
+	submission := sys.Submit(ctx, rawTx, envelope, hash)
+	go func() {
+		r := <-submission
+
+		sys.feeBumpsMutex.Lock()
+		if sys.feeBumps[hash] == resubmission {
+			delete(sys.feeBumps, hash)
+		}
+		r.FeeBumpAttempts = append([]FeeBumpAttempt{}, resubmission.attempts...)
+		sys.feeBumpsMutex.Unlock()
+
+		resultCh <- r
+		close(resultCh)
+	}()
+	return resultCh
+}
+
+// maxConcurrentFeeBumps is the maximum number of fee bump transactions
+// submitted concurrently by rebroadcastFeeBumps.
+const maxConcurrentFeeBumps = 10
+
+// feeBumpBroadcast is a fee bump transaction selected by rebroadcastFeeBumps.
+type feeBumpBroadcast struct {
+	hash         string
+	resubmission *feeBumpResubmission
+	envelope     FeeBumpEnvelope
+	target       int64
+}
+
+// rebroadcastFeeBumps broadcasts the next fee bump transaction of the pending
+// submissions whose fee is below the fee percentile of their policy. The
+// transactions are submitted concurrently, at most maxConcurrentFeeBumps at a
+// time, and it returns once they have all been submitted.
+func (sys *System) rebroadcastFeeBumps(ctx context.Context) {
+	feeStats, ok := operationfeestats.CurrentState()
+	if !ok {
+		return
+	}
+
+	// every envelope is broadcast at most once, even when Stellar Core
+	// rejects it
+	var broadcasts []feeBumpBroadcast
+	sys.feeBumpsMutex.Lock()
+	for hash, resubmission := range sys.feeBumps {
+		target, err := feeChargedPercentile(feeStats, resubmission.policy.Percentile)
+		if err != nil {
+			sys.Log.Ctx(ctx).WithField("hash", hash).WithError(err).Error("invalid fee bump policy")
+			continue
+		}
+		next := resubmission.nextEnvelope(target)
+		if next < 0 {
+			continue
+		}
+		resubmission.next = next + 1
+		broadcasts = append(broadcasts, feeBumpBroadcast{
+			hash:         hash,
+			resubmission: resubmission,
+			envelope:     resubmission.policy.Envelopes[next],
+			target:       target,
+		})
+	}
+	sys.feeBumpsMutex.Unlock()
+
+	var wg sync.WaitGroup
+	semaphore := make(chan struct{}, maxConcurrentFeeBumps)
+	for _, broadcast := range broadcasts {
+		wg.Add(1)
+		semaphore <- struct{}{}
+		go func(broadcast feeBumpBroadcast) {
+			defer func() {
+				<-semaphore
+				wg.Done()
+			}()
+			sys.broadcastFeeBump(ctx, broadcast)
+		}(broadcast)
+	}
+	wg.Wait()
+}
+
+// broadcastFeeBump submits a fee bump transaction selected by
+// rebroadcastFeeBumps and records the attempt.
+func (sys *System) broadcastFeeBump(ctx context.Context, broadcast feeBumpBroadcast) {
+	attempt := FeeBumpAttempt{
+		Hash:            broadcast.envelope.Hash,
+		FeePerOperation: feePerOperation(broadcast.envelope.Envelope),
+		SubmittedAt:     time.Now(),
+	}
+	attempt.Err = sys.submitOnce(ctx, broadcast.envelope.Raw).Err
+	sys.Log.Ctx(ctx).WithFields(log.F{
+		"hash":              broadcast.hash,
+		"fee_bump_hash":     attempt.Hash,
+		"fee_per_operation": attempt.FeePerOperation,
+		"target_fee":        broadcast.target,
+		"err":               attempt.Err,
+	}).Info("Rebroadcast pending transaction with a fee bump transaction")
+
+	sys.feeBumpsMutex.Lock()
+	broadcast.resubmission.attempts = append(broadcast.resubmission.attempts, attempt)
+	if attempt.Err == nil && attempt.FeePerOperation > broadcast.resubmission.fee {
+		broadcast.resubmission.fee = attempt.FeePerOperation
+	}
+	sys.feeBumpsMutex.Unlock()
+}
@@ -28,6 +28,17 @@ func (sub *MockSubmitter) Submit(ctx context.Context, env string) SubmissionResu
 	return sub.R
 }
 
+// blockingSubmitter is a Submitter whose submissions are made by a function,
+// which can block them.
+type blockingSubmitter struct {
+	submit func() SubmissionResult
+}
+
+// Submit implements `txsub.Submitter`
+func (sub *blockingSubmitter) Submit(ctx context.Context, env string) SubmissionResult {
+	return sub.submit()
+}
+
 type mockDBQ struct {
 	mock.Mock
 }
@@ -38,6 +38,18 @@ type OpenSubmissionList interface {
 	Pending() []string
 }
 
+// SharedOpenSubmissionList is an OpenSubmissionList shared by several Horizon
+// instances, a transaction pending in any of them isn't submitted again.
+type SharedOpenSubmissionList interface {
+	OpenSubmissionList
+
+	// Claim registers the listener like Add and returns true when the caller
+	// owns the submission, in which case it must submit the transaction and
+	// Finish the submission if it fails. Otherwise the transaction has been
+	// submitted in the provided duration and its result is awaited.
+	Claim(ctx context.Context, hash string, l Listener, maxAge time.Duration) (bool, error)
+}
+
 // Submitter represents the low-level "submit a transaction to stellar-core"
 // provider.
 type Submitter interface {
@@ -54,6 +66,9 @@ type Result struct {
 	// The full details of the transaction which was submitted
 	// to Stellar Core
 	Transaction history.Transaction
+
+	// The fee bump transactions broadcast by SubmitWithFeeBumps
+	FeeBumpAttempts []FeeBumpAttempt
 }
 
 // SubmissionResult gets returned in response to a call to Submitter.Submit.
@@ -112,3 +112,11 @@ func (s *submissionList) Pending() []string {
 
 	return results
 }
+
+// has returns true when at least one listener is registered for the hash.
+func (s *submissionList) has(hash string) bool {
+	s.Lock()
+	defer s.Unlock()
+	_, ok := s.submissions[hash]
+	return ok
+}
@@ -0,0 +1,170 @@
+package txsub
+
+import (
+	"context"
+	"time"
+
+	"github.com/lib/pq"
+
+	"github.com/stellar/go-stellar-sdk/support/errors"
+	"github.com/stellar/go-stellar-sdk/support/log"
+	"github.com/stellar/stellar-horizon/internal/db2/history"
+)
+
+// SharedSubmissionsQ defines the queries of the pending submissions shared by
+// the Horizon instances using the same database.
+type SharedSubmissionsQ interface {
+	AddPendingSubmission(ctx context.Context, hash string, submittedAt, expiredBefore time.Time) (bool, error)
+	FinishPendingSubmission(ctx context.Context, hash string) error
+	DeletePendingSubmissionsBefore(ctx context.Context, before time.Time) (int64, error)
+}
+
+// SharedSubmissionList is an OpenSubmissionList recording the pending
+// submissions in the database, so that a transaction submitted to several
+// Horizon instances is only submitted to Stellar Core once. The listeners are
+// kept in memory by each instance, the instance finding the result of a
+// submission notifies the other instances through postgres notifications
+// (see Listen).
+type SharedSubmissionList struct {
+	ctx   context.Context
+	q     SharedSubmissionsQ
+	local *submissionList
+	log   *log.Entry
+
+	// loadAttempts and loadRetryDelay bound the loads of the result of a
+	// submission finished by another instance, which may not be visible yet
+	// by this instance when the notification is received.
+	loadAttempts   int
+	loadRetryDelay time.Duration
+}
+
+// NewSharedSubmissionList returns a list sharing its pending submissions
+// through the database. The context is used for the queries of the list.
+func NewSharedSubmissionList(ctx context.Context, q SharedSubmissionsQ) *SharedSubmissionList {
+	return &SharedSubmissionList{
+		ctx: ctx,
+		q:   q,
+		local: &submissionList{
+			submissions: map[string]*openSubmission{},
+			log:         log.DefaultLogger.WithField("service", "txsub.submissionList"),
+		},
+		log:            log.DefaultLogger.WithField("service", "txsub.SharedSubmissionList"),
+		loadAttempts:   5,
+		loadRetryDelay: time.Second,
+	}
+}
+
+// Claim registers the listener and records the submission in the database.
+// It returns true when the caller owns the submission, and must submit the
+// transaction to Stellar Core, which is the case unless the submission is
+// already pending, in any instance, for less than maxAge. The listener
+// receives the result of the submission either way.
+func (s *SharedSubmissionList) Claim(ctx context.Context, hash string, l Listener, maxAge time.Duration) (bool, error) {
+	s.local.Add(hash, l)
+	now := time.Now()
+	owner, err := s.q.AddPendingSubmission(ctx, hash, now, now.Add(-maxAge))
+	if err != nil {
+		return false, errors.Wrap(err, "could not record pending submission")
+	}
+	return owner, nil
+}
+
+// Add registers the listener and records the submission in the database
+// unless it's already pending. System claims its submissions with Claim,
+// which tells whether the submission is owned.
+func (s *SharedSubmissionList) Add(hash string, l Listener) {
+	s.local.Add(hash, l)
+	if _, err := s.q.AddPendingSubmission(s.ctx, hash, time.Now(), time.Time{}); err != nil {
+		s.log.WithField("hash", hash).WithError(err).Error("could not record pending submission")
+	}
+}
+
+// Finish forwards the result to the listeners and notifies the other
+// instances that the result of the submission has been found.
+func (s *SharedSubmissionList) Finish(hash string, r Result) {
+	s.local.Finish(hash, r)
+	if err := s.q.FinishPendingSubmission(s.ctx, hash); err != nil {
+		s.log.WithField("hash", hash).WithError(err).Error("could not finish pending submission")
+	}
+}
+
+// Clean removes the open submissions over the provided age, including the
+// ones recorded by instances which never finished them.
+func (s *SharedSubmissionList) Clean(maxAge time.Duration) int {
+	if _, err := s.q.DeletePendingSubmissionsBefore(s.ctx, time.Now().Add(-maxAge)); err != nil {
+		s.log.WithError(err).Error("could not delete expired pending submissions")
+	}
+	return s.local.Clean(maxAge)
+}
+
+// Pending returns the hashes of the submissions with a listener registered in
+// this instance.
+func (s *SharedSubmissionList) Pending() []string {
+	return s.local.Pending()
+}
+
+// Listen listens for the submissions finished by the other instances, whose
+// results are loaded with loadResult and forwarded to the listeners
+// registered in this instance.
+func (s *SharedSubmissionList) Listen(ctx context.Context, databaseURL string, loadResult func(context.Context, string) Result) error {
+	listener := pq.NewListener(databaseURL, time.Second, time.Minute, func(event pq.ListenerEventType, err error) {
+		if err != nil {
+			s.log.WithError(err).Warn("finished submissions listener error")
+		}
+	})
+	if err := listener.Listen(history.TxSubFinishedChannel); err != nil {
+		listener.Close()
+		return errors.Wrap(err, "error listening for finished submissions")
+	}
+
+	go func() {
+		defer listener.Close()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-time.After(90 * time.Second):
+				// make sure the connection is still alive when no
+				// notification has been received for a while
+				go listener.Ping()
+			case notification := <-listener.Notify:
+				// a nil notification is sent after the connection was
+				// re-established, the missed results are found by Tick
+				if notification != nil {
+					go s.finished(ctx, notification.Extra, loadResult)
+				}
+			}
+		}
+	}()
+	return nil
+}
+
+// finished forwards the result of a submission finished by another instance
+// to the listeners registered in this instance. The result is loaded again
+// while it's not visible by this instance, e.g. when reading from a replica
+// lagging behind, after which it's left to the next System.Tick.
+func (s *SharedSubmissionList) finished(ctx context.Context, hash string, loadResult func(context.Context, string) Result) {
+	logger := s.log.WithField("hash", hash)
+	for attempt := 1; s.local.has(hash); attempt++ {
+		r := loadResult(ctx, hash)
+		if _, failed := r.Err.(*FailedTransactionError); r.Err == nil || failed {
+			logger.Info("Submission finished by another instance")
+			s.local.Finish(hash, r)
+			return
+		}
+		if r.Err != ErrNoResults {
+			logger.WithError(r.Err).Error("could not load result of finished submission")
+			return
+		}
+		if attempt >= s.loadAttempts {
+			logger.Warn("result of finished submission not found, leaving it to the next tick")
+			return
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(s.loadRetryDelay):
+		}
+	}
+}
@@ -0,0 +1,190 @@
+package txsub
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/stellar/go-stellar-sdk/support/errors"
+	"github.com/stellar/stellar-horizon/internal/db2/history"
+	"github.com/stellar/stellar-horizon/internal/test"
+)
+
+// sharedSubmissionsQ is an in memory SharedSubmissionsQ, which can be shared
+// by several lists like the database by several instances.
+type sharedSubmissionsQ struct {
+	sync.Mutex
+	pending  map[string]time.Time
+	finished []string
+}
+
+func newSharedSubmissionsQ() *sharedSubmissionsQ {
+	return &sharedSubmissionsQ{pending: map[string]time.Time{}}
+}
+
+func (q *sharedSubmissionsQ) AddPendingSubmission(ctx context.Context, hash string, submittedAt, expiredBefore time.Time) (bool, error) {
+	q.Lock()
+	defer q.Unlock()
+	if pendingSince, ok := q.pending[hash]; ok && !pendingSince.Before(expiredBefore) {
+		return false, nil
+	}
+	q.pending[hash] = submittedAt
+	return true, nil
+}
+
+func (q *sharedSubmissionsQ) FinishPendingSubmission(ctx context.Context, hash string) error {
+	q.Lock()
+	defer q.Unlock()
+	if _, ok := q.pending[hash]; ok {
+		delete(q.pending, hash)
+		q.finished = append(q.finished, hash)
+	}
+	return nil
+}
+
+func (q *sharedSubmissionsQ) DeletePendingSubmissionsBefore(ctx context.Context, before time.Time) (int64, error) {
+	q.Lock()
+	defer q.Unlock()
+	var deleted int64
+	for hash, submittedAt := range q.pending {
+		if submittedAt.Before(before) {
+			delete(q.pending, hash)
+			deleted++
+		}
+	}
+	return deleted, nil
+}
+
+func newTestSharedSubmissionList(ctx context.Context, q SharedSubmissionsQ) *SharedSubmissionList {
+	list := NewSharedSubmissionList(ctx, q)
+	list.loadRetryDelay = time.Millisecond
+	return list
+}
+
+func TestSharedSubmissionList(t *testing.T) {
+	ctx := test.Context()
+	q := newSharedSubmissionsQ()
+	first := newTestSharedSubmissionList(ctx, q)
+	second := newTestSharedSubmissionList(ctx, q)
+	hash := "2374e99349b9ef7dba9a5db3339b78fda8f34777b1af33ba468ad5c0df946d4d"
+
+	// the first instance owns the submission, the second one joins it
+	firstListener := make(chan Result, 1)
+	owner, err := first.Claim(ctx, hash, firstListener, time.Minute)
+	require.NoError(t, err)
+	assert.True(t, owner)
+
+	secondListener := make(chan Result, 1)
+	owner, err = second.Claim(ctx, hash, secondListener, time.Minute)
+	require.NoError(t, err)
+	assert.False(t, owner)
+	assert.Equal(t, []string{hash}, second.Pending())
+	assert.Len(t, q.pending, 1)
+
+	// the first instance finds the result and notifies the second one
+	result := Result{Transaction: history.Transaction{
+		TransactionWithoutLedger: history.TransactionWithoutLedger{TransactionHash: hash},
+	}}
+	first.Finish(hash, result)
+	assert.Equal(t, result, <-firstListener)
+	assert.Equal(t, []string{hash}, q.finished)
+	assert.Empty(t, q.pending)
+
+	loadResult := func(ctx context.Context, h string) Result {
+		assert.Equal(t, hash, h)
+		return result
+	}
+	second.finished(ctx, hash, loadResult)
+	assert.Equal(t, result, <-secondListener)
+	assert.Empty(t, second.Pending())
+
+	// notifications of submissions without listeners are ignored
+	second.finished(ctx, hash, func(ctx context.Context, h string) Result {
+		t.Fatal("unexpected result load")
+		return Result{}
+	})
+}
+
+func TestSharedSubmissionListClaimExpired(t *testing.T) {
+	ctx := test.Context()
+	q := newSharedSubmissionsQ()
+	list := newTestSharedSubmissionList(ctx, q)
+	hash := "2374e99349b9ef7dba9a5db3339b78fda8f34777b1af33ba468ad5c0df946d4d"
+	q.pending[hash] = time.Now().Add(-time.Hour)
+
+	// the submission of an instance which never finished it is claimed again
+	owner, err := list.Claim(ctx, hash, make(chan Result, 1), time.Minute)
+	require.NoError(t, err)
+	assert.True(t, owner)
+}
+
+func TestSharedSubmissionListFinishedNotVisibleYet(t *testing.T) {
+	ctx := test.Context()
+	list := newTestSharedSubmissionList(ctx, newSharedSubmissionsQ())
+	hash := "2374e99349b9ef7dba9a5db3339b78fda8f34777b1af33ba468ad5c0df946d4d"
+	listeners := []chan Result{make(chan Result, 1), make(chan Result, 1)}
+	for _, listener := range listeners {
+		list.Add(hash, listener)
+	}
+
+	// the result isn't visible when the notification is received
+	failed := &FailedTransactionError{ResultXDR: "AAAAAAAAAGT/////AAAAAQAAAAAAAAAB////+wAAAAA="}
+	loads := 0
+	list.finished(ctx, hash, func(ctx context.Context, h string) Result {
+		loads++
+		if loads < 3 {
+			return Result{Err: ErrNoResults}
+		}
+		return Result{Err: failed}
+	})
+	assert.Equal(t, 3, loads)
+	for _, listener := range listeners {
+		assert.Equal(t, Result{Err: failed}, <-listener)
+	}
+	assert.Empty(t, list.Pending())
+}
+
+func TestSharedSubmissionListFinishedNotLoaded(t *testing.T) {
+	ctx := test.Context()
+	list := newTestSharedSubmissionList(ctx, newSharedSubmissionsQ())
+	hash := "2374e99349b9ef7dba9a5db3339b78fda8f34777b1af33ba468ad5c0df946d4d"
+	list.Add(hash, make(chan Result, 1))
+
+	// the result is left to Tick when it's never visible or can't be loaded
+	for _, testCase := range []struct {
+		err   error
+		loads int
+	}{
+		{err: ErrNoResults, loads: list.loadAttempts},
+		{err: errors.New("connection reset"), loads: 1},
+	} {
+		loads := 0
+		list.finished(ctx, hash, func(ctx context.Context, h string) Result {
+			loads++
+			return Result{Err: testCase.err}
+		})
+		assert.Equal(t, testCase.loads, loads)
+		assert.Equal(t, []string{hash}, list.Pending())
+	}
+}
+
+func TestSharedSubmissionListClean(t *testing.T) {
+	ctx := test.Context()
+	q := newSharedSubmissionsQ()
+	list := newTestSharedSubmissionList(ctx, q)
+	q.pending["expired"] = time.Now().Add(-time.Hour)
+
+	listener := make(chan Result, 1)
+	list.Add("pending", listener)
+	assert.Equal(t, 1, list.Clean(time.Minute))
+	assert.Len(t, q.pending, 1)
+	assert.Contains(t, q.pending, "pending")
+
+	assert.Equal(t, 0, list.Clean(0))
+	assert.Equal(t, ErrTimeout, (<-listener).Err)
+	assert.Empty(t, q.pending)
+}
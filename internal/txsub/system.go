@@ -36,6 +36,9 @@ type System struct {
 	tickMutex      sync.Mutex
 	tickInProgress bool
 
+	feeBumpsMutex sync.Mutex
+	feeBumps      map[string]*feeBumpResubmission // hash => resubmission of a SubmitWithFeeBumps call
+
 	accountSeqPollInterval time.Duration
 
 	DB                func(context.Context) HorizonDB
@@ -111,17 +114,47 @@ func (sys *System) Submit(
 		return
 	}
 
+	finish := func(r Result) {
+		sys.finish(ctx, hash, resultCh, r)
+	}
+	claimed := false
+	if shared, ok := sys.Pending.(SharedOpenSubmissionList); ok {
+		// the submission is claimed before the transaction is submitted to
+		// core, so that concurrent requests for the same transaction, possibly
+		// to other instances, wait for the result of the owner's submission
+		owner, err := shared.Claim(ctx, hash, resultCh, sys.SubmissionTimeout)
+		if err != nil {
+			sys.Log.Ctx(ctx).WithField("hash", hash).WithError(err).Error("Error claiming submission, submitting it anyway")
+		} else if !owner {
+			sys.Log.Ctx(ctx).WithField("hash", hash).Info("Joining pending submission")
+			return
+		}
+
+		// resultCh is registered in the list, the failures are forwarded to
+		// it and to the requests to this instance which joined the
+		// submission meanwhile. The requests to other instances are notified
+		// but find no result, they time out.
+		claimed = true
+		finish = func(r Result) {
+			sys.Log.Ctx(ctx).
+				WithField("result", fmt.Sprintf("%+v", r)).
+				WithField("hash", hash).
+				Info("Submission system result")
+			sys.Pending.Finish(hash, r)
+		}
+	}
+
 	sr := sys.submitOnce(ctx, rawTx)
 
 	if sr.Err != nil {
 		// any error other than "txBAD_SEQ" is a failure
 		isBad, err := sr.IsBadSeq()
 		if err != nil {
-			sys.finish(ctx, hash, resultCh, Result{Err: err})
+			finish(Result{Err: err})
 			return
 		}
 		if !isBad {
-			sys.finish(ctx, hash, resultCh, Result{Err: sr.Err})
+			finish(Result{Err: sr.Err})
 			return
 		}
 
@@ -129,7 +162,7 @@ func (sys *System) Submit(
 		// be lagging behind leading to txBAD_SEQ. This function will block a txsub request
 		// until the request times out or account sequence is bumped to txn sequence.
 		if err = sys.waitUntilAccountSequence(ctx, db, sourceAddress, uint64(envelope.SeqNum())); err != nil {
-			sys.finish(ctx, hash, resultCh, Result{Err: err})
+			finish(Result{Err: err})
 			return
 		}
 
@@ -137,18 +170,20 @@ func (sys *System) Submit(
 		tx, err = txResultByHash(ctx, db, hash)
 		if err != nil {
 			// finally, return the bad_seq error if no result was found on 2nd attempt
-			sys.finish(ctx, hash, resultCh, Result{Err: sr.Err})
+			finish(Result{Err: sr.Err})
 			return
 		}
 		// If we found the result, use it as the result
-		sys.finish(ctx, hash, resultCh, Result{Transaction: tx})
+		finish(Result{Transaction: tx})
 		return
 	}
 
 	// Add transaction to open list of pending txns: the transaction has been successfully submitted to core
 	// but that does not mean it is included in the ledger. The txn status remains pending
 	// until we see an ingestion in the db.
-	sys.Pending.Add(hash, resultCh)
+	if !claimed {
+		sys.Pending.Add(hash, resultCh)
+	}
 	return
 }
 
@@ -253,6 +288,23 @@ func (sys *System) Tick(ctx context.Context) {
 
 	logger.Debug("ticking txsub system")
 
+	if !sys.finishIngested(ctx) {
+		return
+	}
+
+	stillOpen := sys.Pending.Clean(sys.SubmissionTimeout)
+	sys.Metrics.OpenSubmissionsGauge.Set(float64(stillOpen))
+
+	// the transaction of finishIngested is closed, it isn't held open while
+	// Stellar Core receives the fee bump transactions
+	sys.rebroadcastFeeBumps(ctx)
+}
+
+// finishIngested finishes the pending submissions whose transactions have been
+// ingested, in a repeatable read transaction. It returns false when the
+// transactions could not be loaded.
+func (sys *System) finishIngested(ctx context.Context) bool {
+	logger := log.Ctx(ctx)
 	db := sys.DB(ctx)
 	options := &sql.TxOptions{
 		Isolation: sql.LevelRepeatableRead,
@@ -260,7 +312,7 @@ func (sys *System) Tick(ctx context.Context) {
 	}
 	if err := db.BeginTx(ctx, options); err != nil {
 		logger.WithError(err).Error("could not start repeatable read transaction for txsub tick")
-		return
+		return false
 	}
 	defer db.Rollback()
 
@@ -270,7 +322,7 @@ func (sys *System) Tick(ctx context.Context) {
 		latestLedger, err := db.GetLatestHistoryLedger(ctx)
 		if err != nil {
 			logger.WithError(err).Error("error getting latest history ledger")
-			return
+			return false
 		}
 
 		// In Tick we only check txs in a queue so those which did not have results before Tick
@@ -283,7 +335,7 @@ func (sys *System) Tick(ctx context.Context) {
 		txs, err := db.AllTransactionsByHashesSinceLedger(ctx, pending, uint32(sinceLedgerSeq))
 		if err != nil && !db.NoRows(err) {
 			logger.WithError(err).Error("error getting transactions by hashes")
-			return
+			return false
 		}
 
 		txMap := make(map[string]history.Transaction, len(txs))
@@ -318,9 +370,7 @@ func (sys *System) Tick(ctx context.Context) {
 			}
 		}
 	}
-
-	stillOpen := sys.Pending.Clean(sys.SubmissionTimeout)
-	sys.Metrics.OpenSubmissionsGauge.Set(float64(stillOpen))
+	return true
 }
 
 // Init initializes `sys`
@@ -339,6 +389,7 @@ func (sys *System) Init() {
 		})
 
 		sys.accountSeqPollInterval = time.Second
+		sys.feeBumps = map[string]*feeBumpResubmission{}
 
 		if sys.SubmissionTimeout == 0 {
 			// HTTP clients in SDKs usually timeout in 60 seconds. We want SubmissionTimeout
@@ -352,6 +403,13 @@ func (sys *System) Init() {
 	})
 }
 
+// LoadResult returns the result of a transaction ingested in the database, its
+// error is ErrNoResults when the transaction hasn't been ingested.
+func (sys *System) LoadResult(ctx context.Context, hash string) Result {
+	tx, err := txResultByHash(ctx, sys.DB(ctx), hash)
+	return Result{Transaction: tx, Err: err}
+}
+
 func (sys *System) finish(ctx context.Context, hash string, response chan<- Result, r Result) {
 	sys.Log.Ctx(ctx).
 		WithField("result", fmt.Sprintf("%+v", r)).
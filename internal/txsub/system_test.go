@@ -6,6 +6,8 @@ import (
 	"context"
 	"database/sql"
 	"errors"
+	"fmt"
+	"sync/atomic"
 	"testing"
 	"time"
 
@@ -21,6 +23,7 @@ import (
 
 	"github.com/stellar/go-stellar-sdk/xdr"
 	"github.com/stellar/stellar-horizon/internal/db2/history"
+	"github.com/stellar/stellar-horizon/internal/operationfeestats"
 	"github.com/stellar/stellar-horizon/internal/test"
 )
 
@@ -253,6 +256,77 @@ func (suite *SystemTestSuite) TestSubmit_NotFoundError() {
 	assert.Equal(suite.T(), float64(1), getMetricValue(suite.system.Metrics.FailedSubmissionsCounter).GetCounter().GetValue())
 }
 
+// Waits for the result of a transaction pending in another instance without
+// submitting it again.
+func (suite *SystemTestSuite) TestSubmit_JoinsPendingSubmission() {
+	q := newSharedSubmissionsQ()
+	hash := suite.successTx.Transaction.TransactionHash
+	q.pending[hash] = time.Now()
+	suite.system.Pending = NewSharedSubmissionList(suite.ctx, q)
+
+	suite.db.On("PreFilteredTransactionByHash", suite.ctx, mock.Anything, hash).
+		Return(sql.ErrNoRows).Once()
+	suite.db.On("TransactionByHash", suite.ctx, mock.Anything, hash).
+		Return(sql.ErrNoRows).Once()
+	suite.db.On("NoRows", sql.ErrNoRows).Return(true).Twice()
+
+	l := suite.system.Submit(
+		suite.ctx,
+		suite.successTx.Transaction.TxEnvelope,
+		suite.successXDR,
+		hash,
+	)
+
+	assert.False(suite.T(), suite.submitter.WasSubmittedTo)
+	assert.Equal(suite.T(), []string{hash}, suite.system.Pending.Pending())
+	assert.Equal(suite.T(), 0, len(l))
+}
+
+// Concurrent submissions of the same transaction are submitted once, the
+// failure of the submission is sent to every request.
+func (suite *SystemTestSuite) TestSubmit_ClaimsSharedSubmission() {
+	q := newSharedSubmissionsQ()
+	hash := suite.successTx.Transaction.TransactionHash
+	suite.system.Pending = NewSharedSubmissionList(suite.ctx, q)
+
+	suite.db.On("PreFilteredTransactionByHash", suite.ctx, mock.Anything, hash).
+		Return(sql.ErrNoRows)
+	suite.db.On("TransactionByHash", suite.ctx, mock.Anything, hash).
+		Return(sql.ErrNoRows)
+	suite.db.On("NoRows", sql.ErrNoRows).Return(true)
+
+	var submissions int32
+	submitted := make(chan struct{})
+	release := make(chan struct{})
+	submitter := &blockingSubmitter{
+		submit: func() SubmissionResult {
+			if atomic.AddInt32(&submissions, 1) == 1 {
+				close(submitted)
+			}
+			<-release
+			return SubmissionResult{Err: errors.New("core is unreachable")}
+		},
+	}
+	suite.system.Submitter = submitter
+
+	firstSubmission := make(chan (<-chan Result), 1)
+	go func() {
+		firstSubmission <- suite.system.Submit(suite.ctx, suite.successTx.Transaction.TxEnvelope, suite.successXDR, hash)
+	}()
+	<-submitted
+	// the transaction is being submitted by the first request
+	second := suite.system.Submit(suite.ctx, suite.successTx.Transaction.TxEnvelope, suite.successXDR, hash)
+	close(release)
+
+	for _, l := range []<-chan Result{<-firstSubmission, second} {
+		r := <-l
+		assert.EqualError(suite.T(), r.Err, "core is unreachable")
+	}
+	assert.Equal(suite.T(), int32(1), atomic.LoadInt32(&submissions))
+	assert.Empty(suite.T(), q.pending)
+	assert.Equal(suite.T(), []string{hash}, q.finished)
+}
+
 // If the error is bad_seq and the result at the transaction's sequence number is for the same hash, return result.
 func (suite *SystemTestSuite) TestSubmit_BadSeq() {
 	suite.submitter.R = suite.badSeq
@@ -501,6 +575,121 @@ func (suite *SystemTestSuite) TestTickFinishFeeBumpTransaction() {
 	assert.Equal(suite.T(), feeBumpTx, r)
 }
 
+// Test that Tick rebroadcasts pending transactions with the fee bump
+// transactions of their policy when their fee is too low.
+func (suite *SystemTestSuite) TestTick_RebroadcastsFeeBumps() {
+	operationfeestats.ResetState()
+	defer operationfeestats.ResetState()
+	operationfeestats.SetState(operationfeestats.State{FeeChargedP90: 1000, LastLedger: 1})
+
+	feeBump := func(fee int64) FeeBumpEnvelope {
+		envelope := xdr.TransactionEnvelope{
+			Type: xdr.EnvelopeTypeEnvelopeTypeTxFeeBump,
+			FeeBump: &xdr.FeeBumpTransactionEnvelope{
+				Tx: xdr.FeeBumpTransaction{
+					FeeSource: suite.successXDR.V1.Tx.SourceAccount,
+					Fee:       xdr.Int64(fee),
+					InnerTx: xdr.FeeBumpTransactionInnerTx{
+						Type: xdr.EnvelopeTypeEnvelopeTypeTx,
+						V1:   suite.successXDR.V1,
+					},
+				},
+			},
+		}
+		raw, err := xdr.MarshalBase64(envelope)
+		suite.Assert().NoError(err)
+		return FeeBumpEnvelope{Raw: raw, Envelope: envelope, Hash: fmt.Sprintf("fee-bump-%d", fee)}
+	}
+
+	hash := suite.successTx.Transaction.TransactionHash
+	suite.db.On("PreFilteredTransactionByHash", suite.ctx, mock.Anything, hash).
+		Return(sql.ErrNoRows).Once()
+	suite.db.On("TransactionByHash", suite.ctx, mock.Anything, hash).
+		Return(sql.ErrNoRows).Once()
+	suite.db.On("NoRows", sql.ErrNoRows).Return(true)
+
+	l := suite.system.SubmitWithFeeBumps(
+		suite.ctx,
+		suite.successTx.Transaction.TxEnvelope,
+		suite.successXDR,
+		hash,
+		FeeBumpPolicy{
+			Percentile: 90,
+			// 2000, 500 and 1500 per operation
+			Envelopes: []FeeBumpEnvelope{feeBump(4000), feeBump(1000), feeBump(3000)},
+		},
+	)
+
+	// the fee bump transactions are submitted once the transaction of the
+	// tick is closed
+	rollbacks, submissions := 0, 0
+	suite.system.Submitter = &blockingSubmitter{submit: func() SubmissionResult {
+		submissions++
+		assert.Equal(suite.T(), submissions, rollbacks)
+		return SubmissionResult{}
+	}}
+
+	suite.db.On("BeginTx", mock.AnythingOfType("*context.valueCtx"), &sql.TxOptions{
+		Isolation: sql.LevelRepeatableRead,
+		ReadOnly:  true,
+	}).Return(nil).Twice()
+	suite.db.On("Rollback").Return(nil).Run(func(mock.Arguments) { rollbacks++ }).Twice()
+	suite.db.On("AllTransactionsByHashesSinceLedger", suite.ctx, []string{hash}, uint32(940)).
+		Return(nil, sql.ErrNoRows).Once()
+
+	suite.system.Tick(suite.ctx)
+	assert.Equal(suite.T(), 1, submissions)
+
+	// the fee of the fee bump transaction is enough
+	suite.db.On("AllTransactionsByHashesSinceLedger", suite.ctx, []string{hash}, uint32(940)).
+		Return([]history.Transaction{suite.successTx.Transaction}, nil).Once()
+
+	suite.system.Tick(suite.ctx)
+	assert.Equal(suite.T(), 1, submissions)
+
+	r := <-l
+	assert.NoError(suite.T(), r.Err)
+	assert.Equal(suite.T(), suite.successTx.Transaction, r.Transaction)
+	assert.Len(suite.T(), r.FeeBumpAttempts, 1)
+	assert.Equal(suite.T(), "fee-bump-3000", r.FeeBumpAttempts[0].Hash)
+	assert.Equal(suite.T(), int64(1500), r.FeeBumpAttempts[0].FeePerOperation)
+	assert.NoError(suite.T(), r.FeeBumpAttempts[0].Err)
+}
+
+// Test that a fee bump policy can't be registered twice for a pending
+// transaction.
+func (suite *SystemTestSuite) TestSubmitWithFeeBumps_PolicyRegistered() {
+	hash := suite.successTx.Transaction.TransactionHash
+	suite.db.On("PreFilteredTransactionByHash", suite.ctx, mock.Anything, hash).
+		Return(sql.ErrNoRows).Once()
+	suite.db.On("TransactionByHash", suite.ctx, mock.Anything, hash).
+		Return(sql.ErrNoRows).Once()
+	suite.db.On("NoRows", sql.ErrNoRows).Return(true)
+
+	policy := FeeBumpPolicy{Percentile: 90}
+	first := suite.system.SubmitWithFeeBumps(
+		suite.ctx,
+		suite.successTx.Transaction.TxEnvelope,
+		suite.successXDR,
+		hash,
+		policy,
+	)
+	suite.submitter.WasSubmittedTo = false
+
+	second := suite.system.SubmitWithFeeBumps(
+		suite.ctx,
+		suite.successTx.Transaction.TxEnvelope,
+		suite.successXDR,
+		hash,
+		policy,
+	)
+	r := <-second
+	assert.Equal(suite.T(), ErrFeeBumpPolicyRegistered, r.Err)
+	assert.False(suite.T(), suite.submitter.WasSubmittedTo)
+	assert.Empty(suite.T(), first)
+	assert.Equal(suite.T(), []string{hash}, suite.system.Pending.Pending())
+}
+
 // Test that Tick removes old submissions that have timed out.
 func (suite *SystemTestSuite) TestTick_RemovesStaleSubmissions() {
 	l := make(chan Result, 1)